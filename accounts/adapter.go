@@ -6,6 +6,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/zksync-sdk/zksync2-go/contracts/zksync"
 	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"github.com/zksync-sdk/zksync2-go/utils"
 	"math/big"
 )
 
@@ -25,6 +26,11 @@ type AdapterL1 interface {
 	L2TokenAddress(ctx context.Context, token common.Address) (common.Address, error)
 	// ApproveERC20 approves the specified amount of tokens for the specified L1 bridge.
 	ApproveERC20(auth *TransactOpts, token common.Address, amount *big.Int, bridgeAddress common.Address) (*types.Transaction, error)
+	// EnsureAllowanceL1 approves the amount of the L1 token to the spender, only if the current allowance
+	// is lower than the amount. If the allowance is sufficient, no transaction is sent and nil is returned.
+	EnsureAllowanceL1(auth *TransactOpts, token, spender common.Address, amount *big.Int) (*types.Transaction, error)
+	// RevokeApprovalL1 sets the allowance of the L1 token for the spender to zero.
+	RevokeApprovalL1(auth *TransactOpts, token, spender common.Address) (*types.Transaction, error)
 	// BaseCost returns base cost for L2 transaction.
 	BaseCost(opts *CallOpts, gasLimit, gasPerPubdataByte, gasPrice *big.Int) (*big.Int, error)
 	// Deposit transfers the specified token from the associated account on the L1 network
@@ -34,14 +40,42 @@ type AdapterL1 interface {
 	// DepositTransaction.ApproveERC20 can be enabled to perform token approval.
 	// If there are already enough approved tokens for the L1 bridge, token approval will be skipped.
 	// To check the amount of approved tokens for a specific bridge, use the AdapterL1.AllowanceL1 method.
+	// On chains with custom base token, tokens are deposited through the Bridgehub and the shared bridge,
+	// and the fee is paid in the base token, which must be approved to the shared bridge as well.
 	Deposit(auth *TransactOpts, tx DepositTransaction) (*types.Transaction, error)
+	// DepositBaseToken transfers the base token of the chain from the associated account on the L1 network
+	// to the target account on the L2 network. The DepositTransaction.Token is ignored. On ETH-based chains,
+	// it behaves the same as depositing ETH using the Deposit method, while on chains with custom base token
+	// the amount along with the fee is transferred through the Bridgehub.
+	DepositBaseToken(auth *TransactOpts, tx DepositTransaction) (*types.Transaction, error)
+	// PermitAndDeposit transfers the ERC20 token supporting EIP-2612 from the associated account on
+	// the L1 network to the target account on the L2 network, approving the L1 bridge using the permit
+	// valid until the deadline. The permit is applied in the transaction sent before the deposit, both paid
+	// by the associated account, so the deposit is neither gasless nor atomic.
+	PermitAndDeposit(auth *TransactOpts, tx DepositTransaction, deadline *big.Int) (*types.Transaction, error)
+	// PermitL1 signs the EIP-2612 permit which approves the spender to spend the value of the L1 token
+	// until the deadline.
+	PermitL1(opts *CallOpts, token, spender common.Address, value, deadline *big.Int) (*SignedPermit, error)
 	// EstimateGasDeposit estimates the amount of gas required for a deposit transaction on L1 network.
 	// Gas of approving ERC20 token is not included in estimation.
 	EstimateGasDeposit(ctx context.Context, msg DepositCallMsg) (uint64, error)
 	// FullRequiredDepositFee retrieves the full needed ETH fee for the deposit on both L1 and L2 networks.
+	//
+	// Deprecated: Deprecated in favor of EstimateDepositFee.
 	FullRequiredDepositFee(ctx context.Context, msg DepositCallMsg) (*FullDepositFee, error)
+	// EstimateDepositFee retrieves the breakdown of the fee required for the deposit on both L1 and L2
+	// networks, including the gas limits, the base cost and the operator tip, and their ETH total.
+	EstimateDepositFee(ctx context.Context, msg DepositCallMsg) (*DepositFee, error)
 	// FinalizeWithdraw proves the inclusion of the L2 -> L1 withdrawal message.
 	FinalizeWithdraw(auth *TransactOpts, withdrawalHash common.Hash, index int) (*types.Transaction, error)
+	// FinalizeWithdrawals finalizes the withdrawals of the L2 transactions in a single L1 transaction, which
+	// aggregates the finalizations using the Multicall3 contract, utils.L1Multicall3Address by default, saving
+	// the L1 gas of the separate transactions, e.g. when many withdrawals are processed by an exchange. All
+	// messages sent to L1 by the base token and the known L2 bridges in each L2 transaction are finalized,
+	// as by FinalizeWithdraw with each index. The messages which are already finalized or sent by other
+	// contracts are skipped, and ErrWithdrawalsFinalized is returned if none is left. A failing finalization
+	// does not revert the others. The outcome of each message is returned along with the transaction.
+	FinalizeWithdrawals(auth *TransactOpts, withdrawalHashes []common.Hash) (*types.Transaction, []WithdrawalFinalization, error)
 	// IsWithdrawFinalized checks if the withdrawal finalized on L1 network.
 	IsWithdrawFinalized(opts *CallOpts, withdrawalHash common.Hash, index int) (bool, error)
 	// ClaimFailedDeposit withdraws funds from the initiated deposit, which failed when finalizing on L2.
@@ -50,6 +84,13 @@ type AdapterL1 interface {
 	ClaimFailedDeposit(auth *TransactOpts, depositHash common.Hash) (*types.Transaction, error)
 	// RequestExecute request execution of L2 transaction from L1.
 	RequestExecute(auth *TransactOpts, tx RequestExecuteTransaction) (*types.Transaction, error)
+	// RequestExecuteTransactionWithEstimate prepares the L1 -> L2 transaction without sending it, and returns
+	// the breakdown of its fee, so that the costs can be shown before the transaction is sent using
+	// RequestExecuteEstimate.Send.
+	RequestExecuteTransactionWithEstimate(auth *TransactOpts, tx RequestExecuteTransaction) (*RequestExecuteEstimate, error)
+	// WaitRequestExecute waits until the L1 -> L2 transaction is executed on L2, and returns its outcome
+	// along with the refund of the excess fee to the refund recipient.
+	WaitRequestExecute(ctx context.Context, tx *types.Transaction) (*RequestExecuteResult, error)
 	// EstimateGasRequestExecute estimates the amount of gas required for a request execute transaction.
 	EstimateGasRequestExecute(ctx context.Context, msg RequestExecuteCallMsg) (uint64, error)
 }
@@ -61,7 +102,13 @@ type AdapterL2 interface {
 	Address() common.Address
 	// Signer returns the signer of the associated account.
 	Signer() Signer
+	// BaseToken returns the L1 address of the base token of the chain, in which fees are paid.
+	// For ETH-based chains, utils.EthAddressInContracts is returned.
+	BaseToken(ctx context.Context) (common.Address, error)
+	// IsEthBasedChain reports whether the base token of the chain is ETH.
+	IsEthBasedChain(ctx context.Context) (bool, error)
 	// Balance returns the balance of the specified token that can be either ETH or any ERC20 token.
+	// The balance of the base token can be fetched using utils.L2BaseTokenAddress.
 	// The block number can be nil, in which case the balance is taken from the latest known block.
 	Balance(ctx context.Context, token common.Address, at *big.Int) (*big.Int, error)
 	// AllBalances returns all balances for confirmed tokens given by an associated
@@ -71,17 +118,68 @@ type AdapterL2 interface {
 	L2BridgeContracts(ctx context.Context) (*zkTypes.L2BridgeContracts, error)
 	// Withdraw initiates the withdrawal process which withdraws ETH or any ERC20
 	// token from the associated account on L2 network to the target account on L1
-	// network.
+	// network. On chains with custom base token, utils.EthAddress refers to the bridged
+	// ETH token, while the base token is referred to by utils.L2BaseTokenAddress or its L1 address.
 	Withdraw(auth *TransactOpts, tx WithdrawalTransaction) (*types.Transaction, error)
+	// WithdrawBaseToken initiates the withdrawal process of the base token from the associated account
+	// on L2 network to the target account on L1 network. The WithdrawalTransaction.Token and
+	// WithdrawalTransaction.BridgeAddress are ignored. On ETH-based chains, it behaves the same as
+	// withdrawing ETH using the Withdraw method.
+	WithdrawBaseToken(auth *TransactOpts, tx WithdrawalTransaction) (*types.Transaction, error)
 	// EstimateGasWithdraw estimates the amount of gas required for a withdrawal
 	// transaction.
 	EstimateGasWithdraw(ctx context.Context, msg WithdrawalCallMsg) (uint64, error)
 	// Transfer moves the ETH or any ERC20 token from the associated account to the
-	// target account.
+	// target account. Tokens are resolved the same way as in Withdraw.
 	Transfer(auth *TransactOpts, tx TransferTransaction) (*types.Transaction, error)
+	// BatchTransfer sends the base token and ERC20 tokens from the associated account to many recipients.
+	// The transfers of the base token are aggregated into a single transaction, while ERC20 transfers are
+	// sent as sequential transactions. The hashes of the sent transactions are returned.
+	BatchTransfer(ctx context.Context, transfers []TransferCallMsg) ([]common.Hash, error)
 	// EstimateGasTransfer estimates the amount of gas required for a transfer
 	// transaction.
 	EstimateGasTransfer(ctx context.Context, msg TransferCallMsg) (uint64, error)
+	// Allowance returns the amount of the L2 token which the spender is allowed to spend
+	// on behalf of the associated account.
+	Allowance(ctx context.Context, token, spender common.Address) (*big.Int, error)
+	// ApproveToken approves the spender to spend the amount of the L2 token on behalf
+	// of the associated account.
+	ApproveToken(auth *TransactOpts, token, spender common.Address, amount *big.Int) (*types.Transaction, error)
+	// RevokeApproval sets the allowance of the L2 token for the spender to zero.
+	RevokeApproval(auth *TransactOpts, token, spender common.Address) (*types.Transaction, error)
+	// EnsureAllowance approves the amount of the L2 token to the spender, only if the current allowance
+	// is lower than the amount. If the allowance is sufficient, no transaction is sent and nil is returned.
+	EnsureAllowance(auth *TransactOpts, token, spender common.Address, amount *big.Int) (*types.Transaction, error)
+	// TransferNFT moves the ERC721 token from the associated account to the target account
+	// using safeTransferFrom.
+	TransferNFT(auth *TransactOpts, tx TransferNFTTransaction) (*types.Transaction, error)
+	// SafeTransfer1155 moves the amount of the ERC1155 token from the associated account
+	// to the target account.
+	SafeTransfer1155(auth *TransactOpts, tx Transfer1155Transaction) (*types.Transaction, error)
+	// SafeBatchTransfer1155 moves the amounts of multiple ERC1155 tokens of the same contract
+	// from the associated account to the target account in a single transaction.
+	SafeBatchTransfer1155(auth *TransactOpts, tx BatchTransfer1155Transaction) (*types.Transaction, error)
+	// OwnerOfNFT returns the owner of the ERC721 token.
+	OwnerOfNFT(ctx context.Context, token common.Address, tokenID *big.Int) (common.Address, error)
+	// BalanceNFT returns the number of ERC721 tokens of the contract owned by the associated account.
+	BalanceNFT(ctx context.Context, token common.Address) (*big.Int, error)
+	// Balance1155 returns the balance of the ERC1155 token owned by the associated account.
+	Balance1155(ctx context.Context, token common.Address, id *big.Int) (*big.Int, error)
+	// ApproveNFT approves the spender to transfer the ERC721 token of the associated account.
+	ApproveNFT(auth *TransactOpts, token, spender common.Address, tokenID *big.Int) (*types.Transaction, error)
+	// IsApprovedForAll checks if the operator is approved to transfer all ERC721 or ERC1155 tokens
+	// of the contract owned by the associated account.
+	IsApprovedForAll(ctx context.Context, token, operator common.Address) (bool, error)
+	// SetApprovalForAll approves or revokes the operator to transfer all ERC721 or ERC1155 tokens
+	// of the contract owned by the associated account.
+	SetApprovalForAll(auth *TransactOpts, token, operator common.Address, approved bool) (*types.Transaction, error)
+	// Permit signs the EIP-2612 permit which approves the spender to spend the value of the L2 token
+	// until the deadline.
+	Permit(ctx context.Context, token, spender common.Address, value, deadline *big.Int) (*SignedPermit, error)
+	// TransferWithPermit transfers the amount of the L2 token from the owner of the permit, which approves
+	// the associated account, to the recipient. The associated account pays for the gas of applying the permit
+	// and of the transfer, so the owner of the tokens needs no gas.
+	TransferWithPermit(auth *TransactOpts, permit *SignedPermit, to common.Address, amount *big.Int) (*types.Transaction, error)
 	// CallContract executes a message call for EIP-712 transaction, which is
 	// directly executed in the VM of the node, but never mined into the blockchain.
 	//
@@ -103,6 +201,24 @@ type AdapterL2 interface {
 	// SendTransaction injects a transaction into the pending pool for execution. Any
 	// unset transaction fields are prepared using the PopulateTransaction method.
 	SendTransaction(ctx context.Context, tx *Transaction) (common.Hash, error)
+	// ApplyApprovalBasedPaymaster configures the transaction to use the approval-based paymaster, with
+	// the minimal allowance computed from the estimated fee and the exchange rate of the paymaster.
+	ApplyApprovalBasedPaymaster(ctx context.Context, tx *Transaction, paymaster common.Address,
+		input zkTypes.ApprovalBasedPaymasterInput, rate utils.PaymasterExchangeRate) error
+	// TransferWithTestnetPaymaster transfers the token using the testnet paymaster, paying the fee in feeToken.
+	TransferWithTestnetPaymaster(ctx context.Context, tx TransferTransaction, feeToken common.Address) (common.Hash, error)
+	// ValidatePaymaster checks whether the paymaster of the transaction would accept it, before
+	// the transaction is broadcast.
+	ValidatePaymaster(ctx context.Context, tx Transaction) error
+	// SendChain sends the sequence of dependent transactions, each one after the previous one has been
+	// included, aborting on the first failure.
+	SendChain(ctx context.Context, steps []ChainedTransaction, opts *SendChainOptions) ([]*zkTypes.Receipt, error)
+	// SpeedUpTransaction replaces the pending transaction of the associated account with
+	// the same one, having the fees increased by the bumpPercent.
+	SpeedUpTransaction(ctx context.Context, txHash common.Hash, bumpPercent uint64) (common.Hash, error)
+	// CancelTransaction replaces the pending transaction of the associated account with
+	// the transfer of zero value to the associated account, having the fees increased.
+	CancelTransaction(ctx context.Context, txHash common.Hash) (common.Hash, error)
 }
 
 // Deployer is associated with an account and provides deployment of smart contracts
@@ -0,0 +1,86 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/zksync-sdk/zksync2-go/contracts/erc20"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"math/big"
+)
+
+// errBaseTokenApproval is returned when the approval of the L2 base token is requested.
+var errBaseTokenApproval = errors.New("base token can't be approved. It is not an ERC20 token on L2")
+
+func (a *WalletL2) Allowance(ctx context.Context, token, spender common.Address) (*big.Int, error) {
+	if token == utils.EthAddress || token == utils.L2BaseTokenAddress {
+		return nil, errBaseTokenApproval
+	}
+	erc20Token, err := erc20.NewIERC20Caller(token, *a.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load IERC20: %w", err)
+	}
+	return erc20Token.Allowance(&bind.CallOpts{From: a.Address(), Context: ensureContext(ctx)}, a.Address(), spender)
+}
+
+func (a *WalletL2) ApproveToken(auth *TransactOpts, token, spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	if token == utils.EthAddress || token == utils.L2BaseTokenAddress {
+		return nil, errBaseTokenApproval
+	}
+	erc20Token, err := erc20.NewIERC20(token, *a.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load IERC20: %w", err)
+	}
+	return a.transactContract(auth, common.Address{}, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return erc20Token.Approve(opts, spender, amount)
+	})
+}
+
+func (a *WalletL2) RevokeApproval(auth *TransactOpts, token, spender common.Address) (*types.Transaction, error) {
+	return a.ApproveToken(auth, token, spender, big.NewInt(0))
+}
+
+func (a *WalletL2) EnsureAllowance(auth *TransactOpts, token, spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	allowance, err := a.Allowance(ensureTransactOpts(auth).Context, token, spender)
+	if err != nil {
+		return nil, err
+	}
+	if allowance.Cmp(amount) >= 0 {
+		return nil, nil
+	}
+	return a.ApproveToken(auth, token, spender, amount)
+}
+
+func (a *WalletL1) EnsureAllowanceL1(auth *TransactOpts, token, spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	auth = ensureTransactOpts(auth)
+	allowance, err := a.AllowanceL1(&CallOpts{Context: auth.Context}, token, spender)
+	if err != nil {
+		return nil, err
+	}
+	if allowance.Cmp(amount) >= 0 {
+		return nil, nil
+	}
+	return a.ApproveERC20(auth, token, amount, spender)
+}
+
+func (a *WalletL1) RevokeApprovalL1(auth *TransactOpts, token, spender common.Address) (*types.Transaction, error) {
+	if spender == (common.Address{}) {
+		// ApproveERC20 would otherwise revoke the approval of the default bridge.
+		return nil, errors.New("spender must be provided")
+	}
+	return a.ApproveERC20(auth, token, big.NewInt(0), spender)
+}
+
+// ensureAllowance approves the amount of the token to the spender, if the current allowance is not enough,
+// and waits for the approval transaction to be mined.
+func (a *WalletL1) ensureAllowance(auth *TransactOpts, token, spender common.Address, amount *big.Int) error {
+	approveTx, err := a.EnsureAllowanceL1(auth, token, spender, amount)
+	if err != nil || approveTx == nil {
+		return err
+	}
+	_, err = bind.WaitMined(ensureContext(ensureTransactOpts(auth).Context), a.clientL1, approveTx)
+	return err
+}
@@ -0,0 +1,109 @@
+package accounts
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"math/big"
+	"sync"
+)
+
+// ErrAmountBelowMinimum is matched by AmountBelowMinimumError using errors.Is.
+var ErrAmountBelowMinimum = errors.New("amount is below minimum")
+
+// AmountBelowMinimumError is returned when a transfer or withdrawal amount is below the minimum
+// configured in MinimumAmounts.
+type AmountBelowMinimumError struct {
+	Operation string         // The operation being validated, either "transfer" or "withdrawal".
+	Token     common.Address // The address of the token.
+	Amount    *big.Int       // The requested amount.
+	Minimum   *big.Int       // The minimum amount of the token.
+}
+
+func (e *AmountBelowMinimumError) Error() string {
+	return fmt.Sprintf("%s amount %s of token %s is below minimum %s", e.Operation, e.Amount, e.Token, e.Minimum)
+}
+
+func (e *AmountBelowMinimumError) Unwrap() error {
+	return ErrAmountBelowMinimum
+}
+
+// MinimumAmounts contains per-token minimum amounts (dust thresholds) of transfers and withdrawals,
+// which are validated before the transactions are sent. It prevents uneconomical withdrawals, whose
+// L1 finalization cost exceeds the withdrawn value. Tokens without configured minimum are not restricted.
+// The base token can be configured using either utils.EthAddress or utils.L2BaseTokenAddress.
+// It is safe for concurrent use.
+type MinimumAmounts struct {
+	mu          sync.RWMutex
+	transfers   map[common.Address]*big.Int
+	withdrawals map[common.Address]*big.Int
+}
+
+// NewMinimumAmounts creates an instance of MinimumAmounts without any minimum configured.
+func NewMinimumAmounts() *MinimumAmounts {
+	return &MinimumAmounts{
+		transfers:   make(map[common.Address]*big.Int),
+		withdrawals: make(map[common.Address]*big.Int),
+	}
+}
+
+// SetTransferMinimum sets the minimum transfer amount of the token. If the minimum is nil, it is removed.
+func (m *MinimumAmounts) SetTransferMinimum(token common.Address, minimum *big.Int) {
+	m.set(m.transfers, token, minimum)
+}
+
+// SetWithdrawalMinimum sets the minimum withdrawal amount of the token. If the minimum is nil, it is removed.
+func (m *MinimumAmounts) SetWithdrawalMinimum(token common.Address, minimum *big.Int) {
+	m.set(m.withdrawals, token, minimum)
+}
+
+// CheckTransfer returns AmountBelowMinimumError if the transfer amount of the token is below the minimum.
+func (m *MinimumAmounts) CheckTransfer(token common.Address, amount *big.Int) error {
+	return m.check("transfer", m.transfers, token, amount)
+}
+
+// CheckWithdrawal returns AmountBelowMinimumError if the withdrawal amount of the token is below the minimum.
+func (m *MinimumAmounts) CheckWithdrawal(token common.Address, amount *big.Int) error {
+	return m.check("withdrawal", m.withdrawals, token, amount)
+}
+
+func (m *MinimumAmounts) set(minimums map[common.Address]*big.Int, token common.Address, minimum *big.Int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if minimum == nil {
+		delete(minimums, normalizeToken(token))
+		return
+	}
+	minimums[normalizeToken(token)] = new(big.Int).Set(minimum)
+}
+
+func (m *MinimumAmounts) check(operation string, minimums map[common.Address]*big.Int, token common.Address, amount *big.Int) error {
+	m.mu.RLock()
+	minimum, ok := minimums[normalizeToken(token)]
+	m.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+	if amount == nil {
+		amount = big.NewInt(0)
+	}
+	if amount.Cmp(minimum) < 0 {
+		return &AmountBelowMinimumError{
+			Operation: operation,
+			Token:     token,
+			Amount:    amount,
+			Minimum:   new(big.Int).Set(minimum),
+		}
+	}
+	return nil
+}
+
+// normalizeToken returns utils.EthAddress for the base token, since it can be referred to by either
+// utils.EthAddress or utils.L2BaseTokenAddress.
+func normalizeToken(token common.Address) common.Address {
+	if token == utils.L2BaseTokenAddress {
+		return utils.EthAddress
+	}
+	return token
+}
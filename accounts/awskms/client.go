@@ -0,0 +1,64 @@
+// Package awskms provides the implementation of accounts.KMSClient backed by AWS KMS, which allows
+// signing zkSync Era transactions with non-extractable keys using accounts.KMSSigner.
+package awskms
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/zksync-sdk/zksync2-go/accounts"
+)
+
+// API contains the methods of kms.Client used by Client.
+type API interface {
+	GetPublicKey(ctx context.Context, params *kms.GetPublicKeyInput, optFns ...func(*kms.Options)) (*kms.GetPublicKeyOutput, error)
+	Sign(ctx context.Context, params *kms.SignInput, optFns ...func(*kms.Options)) (*kms.SignOutput, error)
+}
+
+// Client implements accounts.KMSClient using an AWS KMS asymmetric key with ECC_SECG_P256K1 key spec.
+// It can also be used with AWS CloudHSM keys through AWS KMS custom key store.
+type Client struct {
+	api   API
+	keyID string
+}
+
+var _ accounts.KMSClient = (*Client)(nil)
+
+// NewClient creates an instance of Client for the key with the given ID, ARN or alias.
+func NewClient(api API, keyID string) *Client {
+	return &Client{
+		api:   api,
+		keyID: keyID,
+	}
+}
+
+// NewSigner creates an instance of accounts.KMSSigner for the key with the given ID, ARN or alias.
+func NewSigner(ctx context.Context, api API, keyID string, chainId int64) (*accounts.KMSSigner, error) {
+	return accounts.NewKMSSigner(ctx, NewClient(api, keyID), chainId)
+}
+
+func (c *Client) PublicKey(ctx context.Context) ([]byte, error) {
+	out, err := c.api.GetPublicKey(ctx, &kms.GetPublicKeyInput{KeyId: aws.String(c.keyID)})
+	if err != nil {
+		return nil, err
+	}
+	if out.KeySpec != types.KeySpecEccSecgP256k1 {
+		return nil, fmt.Errorf("unsupported key spec %s, %s is required", out.KeySpec, types.KeySpecEccSecgP256k1)
+	}
+	return out.PublicKey, nil
+}
+
+func (c *Client) Sign(ctx context.Context, digest []byte) ([]byte, error) {
+	out, err := c.api.Sign(ctx, &kms.SignInput{
+		KeyId:            aws.String(c.keyID),
+		Message:          digest,
+		MessageType:      types.MessageTypeDigest,
+		SigningAlgorithm: types.SigningAlgorithmSpecEcdsaSha256,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Signature, nil
+}
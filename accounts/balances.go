@@ -0,0 +1,185 @@
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/zksync-sdk/zksync2-go/contracts/erc20"
+	"github.com/zksync-sdk/zksync2-go/tokens"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"math/big"
+	"sort"
+)
+
+// TokenBalance is the balance of the token held by the account, along with the metadata of the token.
+type TokenBalance struct {
+	Token     common.Address // L2 address of the token, utils.L2BaseTokenAddress for the base token.
+	Name      string         // Name of the token.
+	Symbol    string         // Symbol of the token.
+	Decimals  uint8          // Number of decimals of the token.
+	Amount    *big.Int       // Balance in the smallest units of the token.
+	Formatted string         // Balance in the units of the token, e.g. "1.5".
+}
+
+// BalanceOptions configures WalletL2.TokenBalances and WalletL2.BalancesAt.
+type BalanceOptions struct {
+	// Registry resolves the metadata of the tokens. Optional, the registry of the wallet, which caches
+	// the metadata for the lifetime of the wallet, is used by default.
+	Registry *tokens.Registry
+	// MinAmount is the amount, in the units of the token, below which the balances are omitted as dust.
+	// Optional, only zero balances are omitted by default.
+	MinAmount *big.Float
+	// Tokens are the L2 addresses of the tokens whose balances are returned by WalletL2.BalancesAt. Optional,
+	// the tokens currently held by the account are used by default, which omits the tokens held only in the past.
+	Tokens []common.Address
+}
+
+// TokenBalances returns the balances of the tokens held by the associated account, along with the metadata
+// of the tokens and the formatted amounts. The base token is returned first.
+func (a *WalletL2) TokenBalances(ctx context.Context, opts *BalanceOptions) ([]TokenBalance, error) {
+	ctx = ensureContext(ctx)
+	balances, err := (*a.client).AllAccountBalances(ctx, a.Address())
+	if err != nil {
+		return nil, err
+	}
+	return a.enrichBalances(ctx, balances, opts)
+}
+
+// BalancesAt returns the balances of the tokens held by the associated account at the given block,
+// which are fetched in a single batch request. The block number can be nil, in which case the balances
+// are taken from the latest known block.
+func (a *WalletL2) BalancesAt(ctx context.Context, blockNumber *big.Int, opts *BalanceOptions) ([]TokenBalance, error) {
+	ctx = ensureContext(ctx)
+	var tokenList []common.Address
+	if opts != nil && len(opts.Tokens) > 0 {
+		tokenList = opts.Tokens
+	} else {
+		current, err := (*a.client).AllAccountBalances(ctx, a.Address())
+		if err != nil {
+			return nil, err
+		}
+		for token := range current {
+			tokenList = append(tokenList, token)
+		}
+	}
+
+	// The balances of the tokens are fetched in a single batch request, at the same block.
+	erc20ABI, err := erc20.IERC20MetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load erc20ABI: %w", err)
+	}
+	calldata, err := erc20ABI.Pack("balanceOf", a.Address())
+	if err != nil {
+		return nil, err
+	}
+	balances := make(map[common.Address]*big.Int, len(tokenList))
+	var msgs []zkTypes.CallMsg
+	var tokenCalls []common.Address
+	for _, token := range tokenList {
+		if isBaseTokenKey(token) {
+			// The base token can be specified by both addresses, but its balance is fetched once.
+			token = utils.L2BaseTokenAddress
+		}
+		if _, ok := balances[token]; ok {
+			continue
+		}
+		balances[token] = nil
+		if token == utils.L2BaseTokenAddress {
+			balance, err := (*a.client).BalanceAt(ctx, a.Address(), blockNumber)
+			if err != nil {
+				return nil, err
+			}
+			balances[token] = balance
+			continue
+		}
+		to := token
+		msgs = append(msgs, zkTypes.CallMsg{CallMsg: ethereum.CallMsg{From: a.Address(), To: &to, Data: calldata}})
+		tokenCalls = append(tokenCalls, token)
+	}
+	if len(msgs) > 0 {
+		results, _, err := (*a.client).BatchCallContractL2(ctx, msgs, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		for i, result := range results {
+			if result.Err != nil {
+				return nil, fmt.Errorf("failed to query balance of token %s: %w", tokenCalls[i], result.Err)
+			}
+			balances[tokenCalls[i]] = new(big.Int).SetBytes(result.Data)
+		}
+	}
+	return a.enrichBalances(ctx, balances, opts)
+}
+
+// enrichBalances resolves the metadata of the tokens, omits the dust, and orders the balances with the base
+// token first, followed by the other tokens ordered by symbol.
+func (a *WalletL2) enrichBalances(ctx context.Context, balances map[common.Address]*big.Int, opts *BalanceOptions) ([]TokenBalance, error) {
+	registry := a.tokenRegistry
+	var minAmount *big.Float
+	if opts != nil {
+		if opts.Registry != nil {
+			registry = opts.Registry
+		}
+		minAmount = opts.MinAmount
+	}
+	if registry == nil {
+		registry = tokens.NewRegistry(*a.client, nil)
+	}
+
+	// The base token is reported by the node either by the ETH address or by its L2 address, or by both.
+	held := make(map[common.Address]*big.Int, len(balances))
+	for token, amount := range balances {
+		if amount == nil || amount.Sign() == 0 {
+			continue
+		}
+		if isBaseTokenKey(token) {
+			token = utils.L2BaseTokenAddress
+		}
+		held[token] = amount
+	}
+	tokenList := make([]common.Address, 0, len(held))
+	for token := range held {
+		tokenList = append(tokenList, token)
+	}
+	metadataOf, err := registry.MetadataOf(ctx, tokenList)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]TokenBalance, 0, len(held))
+	for token, amount := range held {
+		metadata := metadataOf[token]
+		if minAmount != nil {
+			scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(metadata.Decimals)), nil))
+			if new(big.Float).Quo(new(big.Float).SetInt(amount), scale).Cmp(minAmount) < 0 {
+				continue
+			}
+		}
+		result = append(result, TokenBalance{
+			Token:     token,
+			Name:      metadata.Name,
+			Symbol:    metadata.Symbol,
+			Decimals:  metadata.Decimals,
+			Amount:    amount,
+			Formatted: utils.FormatUnits(amount, int(metadata.Decimals)),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if base := result[i].Token == utils.L2BaseTokenAddress; base != (result[j].Token == utils.L2BaseTokenAddress) {
+			return base
+		}
+		if result[i].Symbol != result[j].Symbol {
+			return result[i].Symbol < result[j].Symbol
+		}
+		return bytes.Compare(result[i].Token.Bytes(), result[j].Token.Bytes()) < 0
+	})
+	return result, nil
+}
+
+// isBaseTokenKey reports whether the token address refers to the base token in the balances.
+func isBaseTokenKey(token common.Address) bool {
+	return token == utils.EthAddress || token == utils.L2BaseTokenAddress
+}
@@ -0,0 +1,127 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/zksync-sdk/zksync2-go/contracts/erc20"
+	"github.com/zksync-sdk/zksync2-go/contracts/multicall3"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"math/big"
+)
+
+// ErrBatchTransferTokenNotSupported was returned when a batch contained a transfer of an ERC20 token.
+//
+// Deprecated: BatchTransfer sends ERC20 transfers as sequential transactions, so it is no longer returned.
+var ErrBatchTransferTokenNotSupported = errors.New("batch transfer of ERC20 tokens is not supported")
+
+// BatchTransfer sends the base token and ERC20 tokens to many recipients. The transfers of the base token are
+// aggregated into a single transaction using the Multicall3 contract, see WalletL2.SetMulticall3Address, which
+// reverts if any of them fails. Batching ERC20 transfers through Multicall3 would require an allowance for
+// the Multicall3 contract, which can be spent by anyone calling it, so they are sent as sequential transactions
+// following the aggregated one, with consecutive nonces. The hashes of the sent transactions are returned in
+// that order. If a transaction cannot be sent, the hashes of the ones sent before it are returned along with
+// the error, and the following ones are not sent. Only the To, Amount and Token fields of the transfers are used.
+func (a *WalletL2) BatchTransfer(ctx context.Context, transfers []TransferCallMsg) ([]common.Hash, error) {
+	ctx = ensureContext(ctx)
+	if len(transfers) == 0 {
+		return nil, errors.New("no transfers provided")
+	}
+	erc20Abi, err := erc20.IERC20MetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load erc20 ABI: %w", err)
+	}
+	var (
+		calls      []multicall3.Multicall3Call3Value
+		recipients []common.Address // Recipients of the base token transfers.
+		tokenTxs   []*Transaction
+		tokenTos   [][]common.Address // Recipient of each ERC20 transfer.
+	)
+	total := big.NewInt(0)
+	for i, transfer := range transfers {
+		if transfer.Amount == nil || transfer.Amount.Sign() <= 0 {
+			return nil, fmt.Errorf("transfer %d: amount must be positive", i)
+		}
+		if a.minimumAmounts != nil {
+			if err := a.minimumAmounts.CheckTransfer(transfer.Token, transfer.Amount); err != nil {
+				return nil, fmt.Errorf("transfer %d: %w", i, err)
+			}
+		}
+		if err := a.checkRecipient(ctx, transfer.To); err != nil {
+			return nil, fmt.Errorf("transfer %d: %w", i, err)
+		}
+		token, err := a.resolveL2Token(ctx, transfer.Token)
+		if err != nil {
+			return nil, fmt.Errorf("transfer %d: %w", i, err)
+		}
+		if token == utils.EthAddress || token == utils.L2BaseTokenAddress {
+			calls = append(calls, multicall3.Multicall3Call3Value{
+				Target:   transfer.To,
+				Value:    transfer.Amount,
+				CallData: []byte{},
+			})
+			recipients = append(recipients, transfer.To)
+			total.Add(total, transfer.Amount)
+			continue
+		}
+		data, err := erc20Abi.Pack("transfer", transfer.To, transfer.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("transfer %d: failed to pack transfer: %w", i, err)
+		}
+		tokenTxs = append(tokenTxs, &Transaction{To: &token, Data: data})
+		tokenTos = append(tokenTos, []common.Address{transfer.To})
+	}
+
+	var (
+		txs   []*Transaction
+		txTos [][]common.Address // Recipients of the transfers of each transaction.
+	)
+	if len(calls) > 0 {
+		multicallAbi, err := multicall3.IMulticall3MetaData.GetAbi()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load multicall3 ABI: %w", err)
+		}
+		data, err := multicallAbi.Pack("aggregate3Value", calls)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack aggregate3Value: %w", err)
+		}
+		multicall := a.multicall3()
+		txs = append(txs, &Transaction{To: &multicall, Data: data, Value: total})
+		txTos = append(txTos, recipients)
+	}
+	txs, txTos = append(txs, tokenTxs...), append(txTos, tokenTos...)
+
+	var nonce *big.Int
+	if a.nonceManager == nil && len(txs) > 1 {
+		// The transactions are sent without waiting for each other, so the nonces are assigned consecutively.
+		pending, err := (*a.client).PendingNonceAt(ctx, a.Address())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get nonce: %w", err)
+		}
+		nonce = new(big.Int).SetUint64(pending)
+	}
+	hashes := make([]common.Hash, 0, len(txs))
+	for i, tx := range txs {
+		if nonce != nil {
+			tx.Nonce = new(big.Int).Add(nonce, big.NewInt(int64(i)))
+		}
+		hash, err := a.SendTransaction(ctx, tx)
+		for _, to := range txTos[i] {
+			a.recordRecipient(to, err)
+		}
+		if err != nil {
+			return hashes, err
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, nil
+}
+
+// multicall3 returns the address of the Multicall3 contract used by the wallet.
+func (a *WalletL2) multicall3() common.Address {
+	if a.multicall3Address != (common.Address{}) {
+		return a.multicall3Address
+	}
+	return utils.Multicall3Address
+}
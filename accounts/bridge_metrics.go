@@ -0,0 +1,143 @@
+package accounts
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"sort"
+	"sync"
+	"time"
+)
+
+// BridgeFlow is the direction of the bridging flow.
+type BridgeFlow int
+
+const (
+	BridgeDeposit    BridgeFlow = iota // Transfer from L1 to L2 network.
+	BridgeWithdrawal                   // Transfer from L2 to L1 network.
+)
+
+func (f BridgeFlow) String() string {
+	switch f {
+	case BridgeDeposit:
+		return "deposit"
+	case BridgeWithdrawal:
+		return "withdrawal"
+	default:
+		return fmt.Sprintf("BridgeFlow(%d)", int(f))
+	}
+}
+
+// BridgeLatency is the latency of the completed bridging flow. For deposits, it is the time from the inclusion
+// of the L1 transaction to the execution of the L2 transaction, measured using the block timestamps. For
+// withdrawals, it is the time from sending the L2 transaction to the inclusion of the L1 finalization.
+type BridgeLatency struct {
+	Flow     BridgeFlow
+	L1TxHash common.Hash // The deposit or finalization transaction, empty if the withdrawal was finalized by another account.
+	L2TxHash common.Hash // The L2 transaction of the deposit or the withdrawal.
+	Latency  time.Duration
+	Failed   bool // Whether the flow has completed with failure, e.g. the L2 transaction of the deposit has failed.
+}
+
+// BridgeMetrics receives the latencies of completed bridging flows, giving operators the data to monitor
+// bridging against their SLOs. Implementations can export the latencies to a metrics system, e.g. Prometheus,
+// or use BridgeLatencyRecorder. Implementations must be safe for concurrent use.
+type BridgeMetrics interface {
+	ObserveBridgeLatency(latency BridgeLatency)
+}
+
+// BridgeLatencyStats contains the statistics of the latencies of successful bridging flows.
+// Percentiles are computed over the recent flows kept by BridgeLatencyRecorder.
+type BridgeLatencyStats struct {
+	Count  uint64 // Number of successful flows.
+	Failed uint64 // Number of failed flows.
+	Min    time.Duration
+	Max    time.Duration
+	Mean   time.Duration
+	P50    time.Duration
+	P95    time.Duration
+	P99    time.Duration
+}
+
+// BridgeLatencyRecorder implements the BridgeMetrics interface by keeping the statistics in memory,
+// which can be exported on request using Stats. It is safe for concurrent use.
+type BridgeLatencyRecorder struct {
+	window int
+
+	mu    sync.Mutex
+	flows map[BridgeFlow]*latencySamples
+}
+
+type latencySamples struct {
+	count, failed uint64
+	min, max, sum time.Duration
+	recent        []time.Duration // Ring buffer of the latencies of the recent flows.
+	next          int             // Position in recent where the next latency is stored.
+}
+
+// NewBridgeLatencyRecorder creates an instance of BridgeLatencyRecorder, which computes percentiles over
+// the given number of recent flows of each direction. If the window is not positive, it defaults to 1000.
+func NewBridgeLatencyRecorder(window int) *BridgeLatencyRecorder {
+	if window <= 0 {
+		window = 1000
+	}
+	return &BridgeLatencyRecorder{
+		window: window,
+		flows:  make(map[BridgeFlow]*latencySamples),
+	}
+}
+
+func (r *BridgeLatencyRecorder) ObserveBridgeLatency(latency BridgeLatency) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	samples, ok := r.flows[latency.Flow]
+	if !ok {
+		samples = &latencySamples{}
+		r.flows[latency.Flow] = samples
+	}
+	if latency.Failed {
+		samples.failed++
+		return
+	}
+	if samples.count == 0 || latency.Latency < samples.min {
+		samples.min = latency.Latency
+	}
+	if latency.Latency > samples.max {
+		samples.max = latency.Latency
+	}
+	samples.count++
+	samples.sum += latency.Latency
+	if len(samples.recent) < r.window {
+		samples.recent = append(samples.recent, latency.Latency)
+	} else {
+		samples.recent[samples.next] = latency.Latency
+	}
+	samples.next = (samples.next + 1) % r.window
+}
+
+// Stats returns the statistics of the flows of the given direction.
+func (r *BridgeLatencyRecorder) Stats(flow BridgeFlow) BridgeLatencyStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	samples, ok := r.flows[flow]
+	if !ok {
+		return BridgeLatencyStats{}
+	}
+	stats := BridgeLatencyStats{Count: samples.count, Failed: samples.failed}
+	if samples.count == 0 {
+		return stats
+	}
+	sorted := make([]time.Duration, len(samples.recent))
+	copy(sorted, samples.recent)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	// Percentiles are computed using the nearest-rank method.
+	percentile := func(p int) time.Duration {
+		return sorted[(len(sorted)*p+99)/100-1]
+	}
+	stats.Min = samples.min
+	stats.Max = samples.max
+	stats.Mean = samples.sum / time.Duration(samples.count)
+	stats.P50 = percentile(50)
+	stats.P95 = percentile(95)
+	stats.P99 = percentile(99)
+	return stats
+}
@@ -0,0 +1,231 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/zksync-sdk/zksync2-go/contracts/bridgehub"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"math/big"
+)
+
+// bridgehubContracts contains the contracts used to bridge tokens to the chain through the Bridgehub.
+type bridgehubContracts struct {
+	bridgehub      *bridgehub.IBridgehub
+	chainID        *big.Int
+	baseToken      common.Address // L1 address of the base token of the chain.
+	l1SharedBridge common.Address
+	l2SharedBridge common.Address
+}
+
+// bridgehubContracts loads the contracts used to bridge tokens to the chain through the Bridgehub.
+// ErrCustomBaseTokenNotSupported is returned if the chain is not registered in the Bridgehub.
+func (a *WalletL1) bridgehubContracts(ctx context.Context) (*bridgehubContracts, error) {
+	bridgehubAddress, err := (*a.clientL2).BridgehubContractAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if bridgehubAddress == (common.Address{}) {
+		return nil, ErrCustomBaseTokenNotSupported
+	}
+	hub, err := bridgehub.NewIBridgehub(bridgehubAddress, a.clientL1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load IBridgehub: %w", err)
+	}
+	chainID, err := (*a.clientL2).ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	baseToken, err := (*a.clientL2).BaseTokenContractAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bridgeContracts, err := (*a.clientL2).BridgeContracts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	l1SharedBridge := bridgeContracts.L1SharedDefaultBridge
+	if l1SharedBridge == (common.Address{}) {
+		if l1SharedBridge, err = hub.SharedBridge(&bind.CallOpts{Context: ctx}); err != nil {
+			return nil, fmt.Errorf("failed to get shared bridge: %w", err)
+		}
+	}
+	l2SharedBridge := bridgeContracts.L2SharedDefaultBridge
+	if l2SharedBridge == (common.Address{}) {
+		l2SharedBridge = bridgeContracts.L2Erc20DefaultBridge
+	}
+	return &bridgehubContracts{
+		bridgehub:      hub,
+		chainID:        chainID,
+		baseToken:      baseToken,
+		l1SharedBridge: l1SharedBridge,
+		l2SharedBridge: l2SharedBridge,
+	}, nil
+}
+
+// depositThroughBridgehub deposits the token to the chain whose base token is not ETH. The fee is paid
+// in the base token, so the mint value, consisting of the base cost, the operator tip and, when depositing
+// the base token, the deposited amount, is transferred from the account to the shared bridge.
+// Depositing the base token is requested directly, while ETH and other ERC20 tokens are deposited
+// using the shared bridge as the second bridge.
+func (a *WalletL1) depositThroughBridgehub(auth *TransactOpts, tx DepositTransaction) (*types.Transaction, error) {
+	opts := ensureTransactOpts(auth)
+	ctx := ensureContext(opts.Context)
+	tx.PopulateEmptyFields(a.auth.From)
+	if tx.BridgeAddress != nil {
+		return nil, errors.New("custom bridges are not supported on chains with custom base token")
+	}
+	contracts, err := a.bridgehubContracts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	isBaseToken := tx.Token == contracts.baseToken
+
+	if tx.L2GasLimit == nil {
+		var gas uint64
+		if isBaseToken {
+			// The base token is deposited the same way as ETH is deposited to ETH-based chains.
+			gas, err = a.EstimateDefaultBridgeDepositL2Gas(ctx, utils.EthAddress, tx.Amount, tx.To,
+				a.auth.From, tx.GasPerPubdataByte)
+		} else {
+			token := tx.Token
+			if token == utils.EthAddress {
+				token = utils.EthAddressInContracts
+			}
+			if tx.CustomBridgeData == nil {
+				if tx.CustomBridgeData, err = utils.Erc20DefaultBridgeData(tx.Token, a.clientL1); err != nil {
+					return nil, err
+				}
+			}
+			gas, err = a.EstimateCustomBridgeDepositL2Gas(ctx, contracts.l1SharedBridge, contracts.l2SharedBridge,
+				token, tx.Amount, tx.To, tx.CustomBridgeData, a.auth.From, tx.GasPerPubdataByte)
+		}
+		if err != nil {
+			return nil, err
+		}
+		tx.L2GasLimit = a.gasScaler.ScaleBig(GasOperationDeposit, new(big.Int).SetUint64(gas))
+	}
+
+	if err = a.insertGasPriceInTransactOpts(&opts); err != nil {
+		return nil, err
+	}
+	gasPriceForEstimation := opts.GasFeeCap
+	if gasPriceForEstimation == nil {
+		gasPriceForEstimation = opts.GasPrice
+	}
+	baseCost, err := contracts.bridgehub.L2TransactionBaseCost(&bind.CallOpts{Context: ctx},
+		contracts.chainID, gasPriceForEstimation, tx.L2GasLimit, tx.GasPerPubdataByte)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get base cost: %w", err)
+	}
+
+	mintValue := new(big.Int).Add(baseCost, tx.OperatorTip)
+	if isBaseToken {
+		mintValue.Add(mintValue, tx.Amount)
+	}
+	if err = utils.CheckBaseCost(baseCost, mintValue); err != nil {
+		return nil, err
+	}
+
+	if tx.ApproveERC20 {
+		err = a.ensureAllowance(tx.ApproveAuth, contracts.baseToken, contracts.l1SharedBridge, mintValue)
+		if err == nil && !isBaseToken && tx.Token != utils.EthAddress {
+			err = a.ensureAllowance(tx.ApproveAuth, tx.Token, contracts.l1SharedBridge, tx.Amount)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if isBaseToken {
+		if tx.RefundRecipient == (common.Address{}) {
+			tx.RefundRecipient = tx.To
+		}
+		opts.Value = big.NewInt(0)
+		transactOpts, release := a.transactOpts(opts)
+		return a.hooks.sentL1(release.sent(contracts.bridgehub.RequestL2TransactionDirect(
+			transactOpts,
+			bridgehub.L2TransactionRequestDirect{
+				ChainId:                  contracts.chainID,
+				MintValue:                mintValue,
+				L2Contract:               tx.To,
+				L2Value:                  tx.Amount,
+				L2Calldata:               []byte{},
+				L2GasLimit:               tx.L2GasLimit,
+				L2GasPerPubdataByteLimit: tx.GasPerPubdataByte,
+				FactoryDeps:              [][]byte{},
+				RefundRecipient:          tx.RefundRecipient,
+			})))
+	}
+
+	token, secondBridgeValue := tx.Token, big.NewInt(0)
+	depositAmount := tx.Amount
+	if tx.Token == utils.EthAddress {
+		// ETH is transferred to the shared bridge as the value of the second bridge, in which case
+		// the deposited amount in the calldata must be zero.
+		token, secondBridgeValue, depositAmount = utils.EthAddressInContracts, tx.Amount, big.NewInt(0)
+	}
+	calldata, err := encodeSecondBridgeCalldata(token, depositAmount, tx.To)
+	if err != nil {
+		return nil, err
+	}
+	opts.Value = secondBridgeValue
+	transactOpts, release := a.transactOpts(opts)
+	return a.hooks.sentL1(release.sent(contracts.bridgehub.RequestL2TransactionTwoBridges(
+		transactOpts,
+		bridgehub.L2TransactionRequestTwoBridgesOuter{
+			ChainId:                  contracts.chainID,
+			MintValue:                mintValue,
+			L2Value:                  big.NewInt(0),
+			L2GasLimit:               tx.L2GasLimit,
+			L2GasPerPubdataByteLimit: tx.GasPerPubdataByte,
+			RefundRecipient:          tx.RefundRecipient,
+			SecondBridgeAddress:      contracts.l1SharedBridge,
+			SecondBridgeValue:        secondBridgeValue,
+			SecondBridgeCalldata:     calldata,
+		})))
+}
+
+// encodeSecondBridgeCalldata encodes the deposit request handled by the shared bridge as the second bridge.
+func encodeSecondBridgeCalldata(token common.Address, amount *big.Int, to common.Address) ([]byte, error) {
+	addressType, err := abi.NewType("address", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	uint256Type, err := abi.NewType("uint256", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	return abi.Arguments{{Type: addressType}, {Type: uint256Type}, {Type: addressType}}.Pack(token, amount, to)
+}
+
+// resolveL2Token returns the L2 address of the token to be withdrawn or transferred. On chains whose base
+// token is not ETH, utils.EthAddress refers to the bridged ETH token, and the L1 address of the base token
+// refers to utils.L2BaseTokenAddress. On ETH-based chains the token is returned unchanged.
+func (a *WalletL2) resolveL2Token(ctx context.Context, token common.Address) (common.Address, error) {
+	if token == utils.L2BaseTokenAddress {
+		return token, nil
+	}
+	baseToken, err := (*a.client).BaseTokenContractAddress(ctx)
+	if err != nil {
+		return common.Address{}, err
+	}
+	switch {
+	case baseToken == utils.EthAddressInContracts:
+		return token, nil
+	case token == baseToken:
+		return utils.L2BaseTokenAddress, nil
+	case token == utils.EthAddress || token == utils.EthAddressInContracts:
+		l2Token, err := (*a.client).L2TokenAddress(ctx, utils.EthAddressInContracts)
+		if err != nil {
+			return common.Address{}, fmt.Errorf("failed to get L2 address of ETH: %w", err)
+		}
+		return l2Token, nil
+	default:
+		return token, nil
+	}
+}
@@ -0,0 +1,45 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ClaimFailedDepositAndWait recovers the funds of the deposit whose L2 transaction has failed. It waits until
+// the L2 transaction is included in a block, which is finalized on L1 so that the proof of the failure can be
+// verified, claims the deposit using AdapterL1.ClaimFailedDeposit, and returns the receipt of the L1 transaction.
+// ErrDepositNotFailed is returned if the L2 transaction has succeeded. Since the finalization of L2 blocks
+// can take hours, the context should not have a short deadline.
+func (w *Wallet) ClaimFailedDepositAndWait(ctx context.Context, l2TxHash common.Hash) (*types.Receipt, error) {
+	if w.AdapterL1 == nil || w.clientL1 == nil {
+		return nil, errors.New("wallet is not connected to L1 network")
+	}
+	ctx = ensureContext(ctx)
+	receipt, err := (*w.clientL2).WaitMined(ctx, l2TxHash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		return nil, ErrDepositNotFailed
+	}
+	if _, err = (*w.clientL2).WaitFinalized(ctx, l2TxHash); err != nil {
+		return nil, err
+	}
+
+	tx, err := w.ClaimFailedDeposit(&TransactOpts{Context: ctx}, l2TxHash)
+	if err != nil {
+		return nil, err
+	}
+	l1Receipt, err := bind.WaitMined(ctx, w.clientL1, tx)
+	if err != nil {
+		return nil, err
+	}
+	if l1Receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("claim transaction %s failed", tx.Hash())
+	}
+	return l1Receipt, nil
+}
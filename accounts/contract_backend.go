@@ -0,0 +1,170 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/zksync-sdk/zksync2-go/clients"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"math/big"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrContractCreation is returned by ContractBackend when the binding deploys a contract. Contracts on zkSync
+// are deployed through ContractDeployer, which requires the bytecode to be passed separately from the constructor
+// arguments, so Deployer should be used instead.
+var ErrContractCreation = errors.New("contract creation is not supported by ContractBackend, use Deployer instead")
+
+type contractBackendKey struct{}
+
+// contractBackendOptions are the options of the transaction sent by ContractBackend, passed through the context.
+type contractBackendOptions struct {
+	meta        *zkTypes.Eip712Meta
+	walletNonce bool
+}
+
+// WithEip712Meta returns a context which makes ContractBackend send transactions with the EIP-712 metadata,
+// e.g. paymaster parameters or factory dependencies. The context is set as bind.TransactOpts.Context of
+// the binding method.
+func WithEip712Meta(ctx context.Context, meta *zkTypes.Eip712Meta) context.Context {
+	opts := contractBackendOptionsFrom(ctx)
+	opts.meta = meta
+	return context.WithValue(ensureContext(ctx), contractBackendKey{}, opts)
+}
+
+func contractBackendOptionsFrom(ctx context.Context) contractBackendOptions {
+	if ctx == nil {
+		return contractBackendOptions{}
+	}
+	opts, _ := ctx.Value(contractBackendKey{}).(contractBackendOptions)
+	return opts
+}
+
+// ContractBackend implements bind.ContractBackend for the wallet, so that contract bindings generated by
+// the standard abigen tool send EIP-712 transactions signed by the wallet, instead of legacy or EIP-1559
+// transactions. The transactions built by the binding are converted and sent using AdapterL2.SendTransaction,
+// so that the configuration of the wallet, such as the nonce manager or the paymaster provider, is applied.
+//
+// The transaction returned by the binding only carries the parameters of the call, and its hash differs from
+// the hash of the sent EIP-712 transaction, which is returned by ContractBackend.TransactionHash.
+// Calls and log filtering are performed using the client.
+type ContractBackend struct {
+	clients.Client
+	wallet AdapterL2
+
+	// placeholders is the counter of placeholder nonces of the transactions whose nonce is assigned by the wallet,
+	// which keeps the hashes of the transactions returned by the binding unique.
+	placeholders atomic.Uint64
+
+	mu     sync.Mutex
+	hashes map[common.Hash]common.Hash
+}
+
+var _ bind.ContractBackend = (*ContractBackend)(nil)
+
+// NewContractBackend creates an instance of ContractBackend sending transactions using the wallet.
+func NewContractBackend(client *clients.Client, wallet AdapterL2) *ContractBackend {
+	return &ContractBackend{
+		Client: *client,
+		wallet: wallet,
+		hashes: make(map[common.Hash]common.Hash),
+	}
+}
+
+// TransactOpts returns the options for binding methods, which send the transaction from the account of
+// the wallet with the EIP-712 metadata. The meta is optional. If the nonce is not set, the nonce is assigned
+// by the wallet when the transaction is sent.
+func (b *ContractBackend) TransactOpts(opts *TransactOpts, meta *zkTypes.Eip712Meta) *bind.TransactOpts {
+	opts = ensureTransactOpts(opts)
+	backendOpts := contractBackendOptions{meta: meta}
+	nonce := opts.Nonce
+	if nonce == nil {
+		// Setting the nonce prevents the binding from fetching the pending one.
+		backendOpts.walletNonce = true
+		nonce = new(big.Int).SetUint64(b.placeholders.Add(1))
+	}
+	from := b.wallet.Address()
+	return &bind.TransactOpts{
+		From:  from,
+		Nonce: nonce,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != from {
+				return nil, bind.ErrNotAuthorized
+			}
+			// The transaction is signed by the wallet once it is converted to EIP-712 transaction.
+			return tx, nil
+		},
+		Value:     opts.Value,
+		GasPrice:  opts.GasPrice,
+		GasFeeCap: opts.GasFeeCap,
+		GasTipCap: opts.GasTipCap,
+		GasLimit:  opts.GasLimit,
+		Context:   context.WithValue(ensureContext(opts.Context), contractBackendKey{}, backendOpts),
+	}
+}
+
+// EstimateGas estimates the gas of the EIP-712 transaction with the metadata from the context.
+func (b *ContractBackend) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return b.Client.EstimateGasL2(ensureContext(ctx), zkTypes.CallMsg{
+		CallMsg: msg,
+		Meta:    contractBackendOptionsFrom(ctx).meta,
+	})
+}
+
+// SendTransaction sends the transaction built by the binding as EIP-712 transaction signed by the wallet.
+func (b *ContractBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if tx.To() == nil {
+		return ErrContractCreation
+	}
+	opts := contractBackendOptionsFrom(ctx)
+	transaction := &Transaction{
+		To:        tx.To(),
+		Data:      tx.Data(),
+		Value:     tx.Value(),
+		GasTipCap: tx.GasTipCap(),
+		GasFeeCap: tx.GasFeeCap(),
+		Gas:       tx.Gas(),
+		Meta:      opts.meta,
+	}
+	if !opts.walletNonce {
+		transaction.Nonce = new(big.Int).SetUint64(tx.Nonce())
+	}
+	hash, err := b.wallet.SendTransaction(ctx, transaction)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.hashes[tx.Hash()] = hash
+	return nil
+}
+
+// TransactionHash returns the hash of the EIP-712 transaction sent for the transaction returned by the binding.
+func (b *ContractBackend) TransactionHash(tx *types.Transaction) (common.Hash, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	hash, ok := b.hashes[tx.Hash()]
+	return hash, ok
+}
+
+// WaitMined waits for the EIP-712 transaction sent for the transaction returned by the binding to be mined,
+// and forgets the mapping of the hashes.
+func (b *ContractBackend) WaitMined(ctx context.Context, tx *types.Transaction) (*zkTypes.Receipt, error) {
+	hash, ok := b.TransactionHash(tx)
+	if !ok {
+		return nil, fmt.Errorf("transaction %s has not been sent by ContractBackend", tx.Hash())
+	}
+	receipt, err := b.Client.WaitMined(ensureContext(ctx), hash)
+	if err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.hashes, tx.Hash())
+	return receipt, nil
+}
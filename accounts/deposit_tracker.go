@@ -0,0 +1,217 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/zksync-sdk/zksync2-go/clients"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"time"
+)
+
+// DepositState is the state of the deposit tracked by DepositTracker.
+type DepositState int
+
+const (
+	DepositL1Pending   DepositState = iota // The L1 transaction has not been included in a block yet.
+	DepositL1Mined                         // The L1 transaction has been included, the L2 transaction is not known yet.
+	DepositL2Executing                     // The L2 transaction has been created, but not included in a block yet.
+	DepositL2Executed                      // The L2 transaction has been included in a block.
+	DepositL2Finalized                     // The L2 block containing the transaction has been finalized on L1.
+	DepositFailed                          // Either L1 or L2 transaction has failed.
+)
+
+func (s DepositState) String() string {
+	switch s {
+	case DepositL1Pending:
+		return "L1 pending"
+	case DepositL1Mined:
+		return "L1 mined"
+	case DepositL2Executing:
+		return "L2 executing"
+	case DepositL2Executed:
+		return "L2 executed"
+	case DepositL2Finalized:
+		return "L2 finalized"
+	case DepositFailed:
+		return "failed"
+	default:
+		return fmt.Sprintf("DepositState(%d)", int(s))
+	}
+}
+
+// Final reports whether the state cannot change anymore.
+func (s DepositState) Final() bool {
+	return s == DepositL2Finalized || s == DepositFailed
+}
+
+// DepositStatus is the status of the deposit.
+type DepositStatus struct {
+	State     DepositState
+	L1TxHash  common.Hash
+	L2TxHash  common.Hash      // The hash of the L2 transaction, set from DepositL1Mined.
+	L1Receipt *types.Receipt   // The receipt of the L1 transaction, set from DepositL1Mined.
+	L2Receipt *zkTypes.Receipt // The receipt of the L2 transaction, set from DepositL2Executed.
+	// The reason of the failure, set for DepositFailed. If the L2 transaction has failed,
+	// the deposited funds can be claimed using AdapterL1.ClaimFailedDeposit.
+	Err error
+}
+
+// DepositTracker resolves the L2 transactions of deposits (or any other L1 -> L2 transactions) and reports
+// their states, either on request using Status or continuously using Track.
+type DepositTracker struct {
+	clientL1 *ethclient.Client
+	clientL2 clients.Client
+	interval time.Duration
+	metrics  BridgeMetrics
+}
+
+// NewDepositTracker creates an instance of DepositTracker.
+func NewDepositTracker(clientL1 *ethclient.Client, clientL2 clients.Client) *DepositTracker {
+	return &DepositTracker{
+		clientL1: clientL1,
+		clientL2: clientL2,
+		interval: 5 * time.Second,
+	}
+}
+
+// SetPollInterval sets the interval in which Track polls the status of the deposit. Defaults to 5 seconds.
+func (t *DepositTracker) SetPollInterval(interval time.Duration) {
+	t.interval = interval
+}
+
+// SetMetrics sets the metrics receiving the latencies of deposits completed while being tracked by Track.
+// The latency of the deposit is observed once its L2 transaction is executed or has failed.
+func (t *DepositTracker) SetMetrics(metrics BridgeMetrics) {
+	t.metrics = metrics
+}
+
+// Status returns the current status of the deposit with the given L1 transaction hash.
+func (t *DepositTracker) Status(ctx context.Context, l1TxHash common.Hash) (*DepositStatus, error) {
+	ctx = ensureContext(ctx)
+	status := &DepositStatus{State: DepositL1Pending, L1TxHash: l1TxHash}
+
+	l1Receipt, err := t.clientL1.TransactionReceipt(ctx, l1TxHash)
+	if errors.Is(err, ethereum.NotFound) {
+		if _, _, err = t.clientL1.TransactionByHash(ctx, l1TxHash); err != nil {
+			return nil, fmt.Errorf("failed to get L1 transaction: %w", err)
+		}
+		return status, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get L1 transaction receipt: %w", err)
+	}
+	status.L1Receipt = l1Receipt
+	if l1Receipt.Status != types.ReceiptStatusSuccessful {
+		status.State = DepositFailed
+		status.Err = errors.New("L1 transaction has failed")
+		return status, nil
+	}
+
+	status.State = DepositL1Mined
+	l2TxHash, err := t.l2TxHash(ctx, l1Receipt)
+	if err != nil {
+		return nil, err
+	}
+	status.L2TxHash = l2TxHash
+
+	l2Receipt, err := t.clientL2.TransactionReceipt(ctx, l2TxHash)
+	if err != nil || l2Receipt == nil || l2Receipt.BlockNumber == nil {
+		// The L2 transaction is known once the priority request is processed by the node.
+		status.State = DepositL2Executing
+		return status, nil
+	}
+	status.L2Receipt = l2Receipt
+	if l2Receipt.Status != types.ReceiptStatusSuccessful {
+		status.State = DepositFailed
+		status.Err = errors.New("L2 transaction has failed")
+		return status, nil
+	}
+
+	status.State = DepositL2Executed
+	details, err := t.clientL2.TransactionDetails(ctx, l2TxHash)
+	if err != nil {
+		return nil, err
+	}
+	if details.Status == "verified" {
+		status.State = DepositL2Finalized
+	}
+	return status, nil
+}
+
+// Track polls the status of the deposit with the given L1 transaction hash, and sends it to the returned
+// channel whenever the state changes. The channel is closed once the final state is reached, or the context
+// is canceled. Errors of polling are not fatal, the status is polled again after the interval.
+func (t *DepositTracker) Track(ctx context.Context, l1TxHash common.Hash) <-chan DepositStatus {
+	ctx = ensureContext(ctx)
+	updates := make(chan DepositStatus, 1)
+	go func() {
+		defer close(updates)
+		ticker := time.NewTicker(t.interval)
+		defer ticker.Stop()
+		last := DepositState(-1)
+		observed := false
+		for {
+			status, err := t.Status(ctx, l1TxHash)
+			if err == nil && status.State != last {
+				last = status.State
+				if !observed && status.State >= DepositL2Executed {
+					observed = t.observe(ctx, status)
+				}
+				select {
+				case updates <- *status:
+				case <-ctx.Done():
+					return
+				}
+				if status.State.Final() {
+					return
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return updates
+}
+
+// observe reports the latency of the completed deposit to the metrics, if they are set.
+// It reports whether the latency has been observed.
+func (t *DepositTracker) observe(ctx context.Context, status *DepositStatus) bool {
+	if t.metrics == nil {
+		return true
+	}
+	latency := BridgeLatency{
+		Flow:     BridgeDeposit,
+		L1TxHash: status.L1TxHash,
+		L2TxHash: status.L2TxHash,
+		Failed:   status.State == DepositFailed,
+	}
+	if status.L1Receipt != nil && status.L2Receipt != nil {
+		l1Header, err := t.clientL1.HeaderByNumber(ctx, status.L1Receipt.BlockNumber)
+		if err != nil {
+			return false
+		}
+		l2Header, err := t.clientL2.HeaderByNumber(ctx, status.L2Receipt.BlockNumber)
+		if err != nil {
+			return false
+		}
+		latency.Latency = time.Duration(int64(l2Header.Time)-int64(l1Header.Time)) * time.Second
+	}
+	t.metrics.ObserveBridgeLatency(latency)
+	return true
+}
+
+// l2TxHash returns the hash of the L2 transaction created by the priority request in the L1 transaction.
+func (t *DepositTracker) l2TxHash(ctx context.Context, l1Receipt *types.Receipt) (common.Hash, error) {
+	ops, err := PriorityOps(ctx, t.clientL2, l1Receipt)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return ops[0].L2TxHash, nil
+}
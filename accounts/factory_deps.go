@@ -0,0 +1,159 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"math/big"
+	"strings"
+)
+
+// ErrMissingFactoryDeps is returned when the deployed contract creates contracts whose bytecode is neither
+// known on the network nor included in the factory dependencies of the transaction.
+var ErrMissingFactoryDeps = errors.New("missing factory dependencies")
+
+// MissingFactoryDepsError is returned when populating the deployment transaction fails, because the deployed
+// contract references the bytecode hashes which are unknown on the network.
+type MissingFactoryDepsError struct {
+	Hashes []common.Hash // Bytecode hashes referenced by the deployed bytecode, which are unknown on the network.
+	Err    error         // The error of the simulation of the transaction.
+}
+
+func (e *MissingFactoryDepsError) Error() string {
+	hashes := make([]string, len(e.Hashes))
+	for i, hash := range e.Hashes {
+		hashes[i] = hash.Hex()
+	}
+	return fmt.Sprintf("%s: %s: %v", ErrMissingFactoryDeps, strings.Join(hashes, ", "), e.Err)
+}
+
+func (e *MissingFactoryDepsError) Unwrap() error {
+	return ErrMissingFactoryDeps
+}
+
+// FactoryDepsResolver provides the bytecode of the contracts which can be deployed by other contracts,
+// e.g. artifacts.Set loaded from the compiler output.
+type FactoryDepsResolver interface {
+	// FactoryDep returns the bytecode with the given bytecode hash, if it is known to the resolver.
+	FactoryDep(hash common.Hash) ([]byte, bool)
+}
+
+// FactoryDepsResolverFunc is an adapter to allow the use of ordinary functions as FactoryDepsResolver.
+type FactoryDepsResolverFunc func(hash common.Hash) ([]byte, bool)
+
+func (f FactoryDepsResolverFunc) FactoryDep(hash common.Hash) ([]byte, bool) {
+	return f(hash)
+}
+
+// getMarkerSelector is the selector of KnownCodesStorage.getMarker(bytes32).
+var getMarkerSelector = crypto.Keccak256([]byte("getMarker(bytes32)"))[:4]
+
+// bytecodeHashCandidates returns the words of the EraVM bytecode which have the format of bytecode hashes,
+// i.e. the version 1 followed by zero byte and odd length in words. zksolc embeds the bytecode hashes
+// of the contracts created by the contract as constants, so the factory dependencies are among the candidates.
+func bytecodeHashCandidates(bytecode []byte) []common.Hash {
+	var candidates []common.Hash
+	for i := 0; i+common.HashLength <= len(bytecode); i += common.HashLength {
+		word := bytecode[i : i+common.HashLength]
+		length := int(word[2])<<8 | int(word[3])
+		if word[0] == 1 && word[1] == 0 && length%2 == 1 {
+			candidates = append(candidates, common.BytesToHash(word))
+		}
+	}
+	return candidates
+}
+
+// resolveFactoryDeps adds to the deployment transaction the factory dependencies referenced by the included
+// bytecode, transitively, which are provided by the factory deps resolver.
+func (a *WalletL2) resolveFactoryDeps(tx *Transaction) error {
+	if a.factoryDepsResolver == nil || tx.Meta == nil || len(tx.Meta.FactoryDeps) == 0 {
+		return nil
+	}
+	included := make(map[common.Hash]bool)
+	queue := make([][]byte, 0, len(tx.Meta.FactoryDeps))
+	for _, dep := range tx.Meta.FactoryDeps {
+		hash, err := utils.HashBytecode(dep)
+		if err != nil {
+			return fmt.Errorf("invalid factory dependency: %w", err)
+		}
+		included[common.BytesToHash(hash)] = true
+		queue = append(queue, dep)
+	}
+	var resolved []hexutil.Bytes
+	for len(queue) > 0 {
+		bytecode := queue[0]
+		queue = queue[1:]
+		for _, candidate := range bytecodeHashCandidates(bytecode) {
+			if included[candidate] {
+				continue
+			}
+			dep, ok := a.factoryDepsResolver.FactoryDep(candidate)
+			if !ok {
+				continue
+			}
+			if hash, err := utils.HashBytecode(dep); err != nil || common.BytesToHash(hash) != candidate {
+				return fmt.Errorf("factory deps resolver returned bytecode not matching hash %s", candidate)
+			}
+			included[candidate] = true
+			resolved = append(resolved, dep)
+			queue = append(queue, dep)
+		}
+	}
+	if len(resolved) == 0 {
+		return nil
+	}
+	meta := *tx.Meta
+	// The deployed bytecode is kept last, as required by ContractDeployer.
+	deps := make([]hexutil.Bytes, 0, len(meta.FactoryDeps)+len(resolved))
+	deps = append(deps, resolved...)
+	deps = append(deps, meta.FactoryDeps...)
+	meta.FactoryDeps = deps
+	tx.Meta = &meta
+	return nil
+}
+
+// isUnknownCodeHashError reports whether the error is caused by deploying the bytecode which is unknown.
+func isUnknownCodeHashError(err error) bool {
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "code hash is not known") || strings.Contains(message, "unknowncodehash")
+}
+
+// missingFactoryDeps returns MissingFactoryDepsError describing the bytecode hashes referenced by the factory
+// dependencies of the transaction, which are neither included nor known on the network, if the error of
+// the simulation of the transaction is caused by the unknown bytecode. Otherwise, the error is returned as is.
+func (a *WalletL2) missingFactoryDeps(ctx context.Context, tx *Transaction, err error) error {
+	if tx.Meta == nil || len(tx.Meta.FactoryDeps) == 0 || !isUnknownCodeHashError(err) {
+		return err
+	}
+	included := make(map[common.Hash]bool)
+	for _, dep := range tx.Meta.FactoryDeps {
+		if hash, hashErr := utils.HashBytecode(dep); hashErr == nil {
+			included[common.BytesToHash(hash)] = true
+		}
+	}
+	missing := &MissingFactoryDepsError{Err: err}
+	for _, dep := range tx.Meta.FactoryDeps {
+		for _, candidate := range bytecodeHashCandidates(dep) {
+			if included[candidate] {
+				continue
+			}
+			included[candidate] = true
+			marker, callErr := (*a.client).CallContract(ctx, ethereum.CallMsg{
+				To:   &utils.KnownCodesStorageAddress,
+				Data: append(append([]byte{}, getMarkerSelector...), candidate.Bytes()...),
+			}, nil)
+			if callErr == nil && new(big.Int).SetBytes(marker).Sign() == 0 {
+				missing.Hashes = append(missing.Hashes, candidate)
+			}
+		}
+	}
+	if len(missing.Hashes) == 0 {
+		return err
+	}
+	return missing
+}
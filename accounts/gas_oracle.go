@@ -0,0 +1,208 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"math/big"
+	"reflect"
+	"sync"
+)
+
+// GasPriceClient is the client of the network whose gas price is provided by GasOracle. It is implemented
+// by clients.Client and ethclient.Client.
+type GasPriceClient interface {
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+}
+
+// GasOracle provides the gas price of transactions sent by the wallet, used as the gas price of legacy
+// transactions and as the max fee per gas of EIP-1559 and EIP-712 transactions. When set on the wallet, it is
+// consulted whenever the transaction does not specify the fee, so that fee behavior can be controlled globally.
+type GasOracle interface {
+	// GasPrice returns the gas price of the transaction sent on the network of the client.
+	GasPrice(ctx context.Context, client GasPriceClient) (*big.Int, error)
+}
+
+// GasOracleFunc is an adapter allowing the use of ordinary functions as GasOracle.
+type GasOracleFunc func(ctx context.Context, client GasPriceClient) (*big.Int, error)
+
+func (f GasOracleFunc) GasPrice(ctx context.Context, client GasPriceClient) (*big.Int, error) {
+	return f(ctx, client)
+}
+
+// SuggestedGasOracle is the GasOracle using the latest gas price suggested by the node, which is the default
+// behavior of the wallet.
+type SuggestedGasOracle struct{}
+
+func (SuggestedGasOracle) GasPrice(ctx context.Context, client GasPriceClient) (*big.Int, error) {
+	return client.SuggestGasPrice(ctx)
+}
+
+// FeeHistoryGasOracle is the GasOracle computing the gas price from the fee history of the recent blocks:
+// the base fee of the next block increased by the average of the priority fees paid at the percentile
+// in each block.
+type FeeHistoryGasOracle struct {
+	Blocks     uint64  // Number of the recent blocks. Values equal to 0 mean 10.
+	Percentile float64 // Percentile of the priority fees paid in each block, in range [0, 100].
+}
+
+// NewFeeHistoryGasOracle creates an instance of FeeHistoryGasOracle.
+func NewFeeHistoryGasOracle(blocks uint64, percentile float64) *FeeHistoryGasOracle {
+	return &FeeHistoryGasOracle{Blocks: blocks, Percentile: percentile}
+}
+
+func (o *FeeHistoryGasOracle) GasPrice(ctx context.Context, client GasPriceClient) (*big.Int, error) {
+	if o.Percentile < 0 || o.Percentile > 100 {
+		return nil, fmt.Errorf("invalid percentile %v, must be in range [0, 100]", o.Percentile)
+	}
+	blocks := o.Blocks
+	if blocks == 0 {
+		blocks = 10
+	}
+	history, err := feeHistory(ctx, client, blocks, []float64{o.Percentile})
+	if err != nil {
+		return nil, err
+	}
+	if len(history.BaseFee) == 0 {
+		return nil, errors.New("fee history does not contain base fees")
+	}
+	// The last base fee is the base fee of the next block.
+	price := new(big.Int).Set(history.BaseFee[len(history.BaseFee)-1])
+	if len(history.Reward) > 0 {
+		tips := new(big.Int)
+		for _, reward := range history.Reward {
+			if len(reward) > 0 {
+				tips.Add(tips, reward[0])
+			}
+		}
+		price.Add(price, tips.Div(tips, big.NewInt(int64(len(history.Reward)))))
+	}
+	return price, nil
+}
+
+// EMAGasOracle is the GasOracle smoothing the gas prices provided by the underlying oracle using
+// the exponential moving average, so that short spikes of the gas price have a limited effect on the fees.
+// The average is kept per client, so the same instance can be used for L1 and L2 networks. It is safe
+// for concurrent use.
+type EMAGasOracle struct {
+	Oracle GasOracle // Underlying oracle. Optional, SuggestedGasOracle is used by default.
+	Alpha  float64   // Weight of the latest gas price, in range (0, 1].
+
+	mu       sync.Mutex
+	averages map[interface{}]*big.Float
+}
+
+// NewEMAGasOracle creates an instance of EMAGasOracle.
+func NewEMAGasOracle(oracle GasOracle, alpha float64) *EMAGasOracle {
+	return &EMAGasOracle{Oracle: oracle, Alpha: alpha}
+}
+
+func (o *EMAGasOracle) GasPrice(ctx context.Context, client GasPriceClient) (*big.Int, error) {
+	if o.Alpha <= 0 || o.Alpha > 1 {
+		return nil, fmt.Errorf("invalid smoothing factor %v, must be in range (0, 1]", o.Alpha)
+	}
+	price, err := underlyingOracle(o.Oracle).GasPrice(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	// Clients which cannot be used as the map key share the average.
+	var key interface{}
+	if client != nil && reflect.TypeOf(client).Comparable() {
+		key = client
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.averages == nil {
+		o.averages = make(map[interface{}]*big.Float)
+	}
+	average, ok := o.averages[key]
+	if !ok {
+		average = new(big.Float).SetInt(price)
+	} else {
+		// average = alpha * price + (1 - alpha) * average
+		latest := new(big.Float).Mul(big.NewFloat(o.Alpha), new(big.Float).SetInt(price))
+		average = latest.Add(latest, new(big.Float).Mul(big.NewFloat(1-o.Alpha), average))
+	}
+	o.averages[key] = average
+	result, _ := average.Int(nil)
+	return result, nil
+}
+
+// CappedGasOracle is the GasOracle limiting the gas prices provided by the underlying oracle to the cap.
+type CappedGasOracle struct {
+	Oracle GasOracle // Underlying oracle. Optional, SuggestedGasOracle is used by default.
+	Cap    *big.Int  // Maximal gas price.
+}
+
+// NewCappedGasOracle creates an instance of CappedGasOracle.
+func NewCappedGasOracle(oracle GasOracle, maxPrice *big.Int) *CappedGasOracle {
+	return &CappedGasOracle{Oracle: oracle, Cap: maxPrice}
+}
+
+func (o *CappedGasOracle) GasPrice(ctx context.Context, client GasPriceClient) (*big.Int, error) {
+	price, err := underlyingOracle(o.Oracle).GasPrice(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	if o.Cap != nil && price.Cmp(o.Cap) > 0 {
+		return new(big.Int).Set(o.Cap), nil
+	}
+	return price, nil
+}
+
+// underlyingOracle returns the oracle, or SuggestedGasOracle if the oracle is nil.
+func underlyingOracle(oracle GasOracle) GasOracle {
+	if oracle == nil {
+		return SuggestedGasOracle{}
+	}
+	return oracle
+}
+
+// gasPrice returns the gas price provided by the oracle, or suggested by the client if the oracle is nil.
+func gasPrice(ctx context.Context, oracle GasOracle, client GasPriceClient) (*big.Int, error) {
+	return underlyingOracle(oracle).GasPrice(ctx, client)
+}
+
+// feeHistory returns the fee history of the recent blocks, using the FeeHistory method of the client if
+// available, such as ethclient.Client, or eth_feeHistory RPC method otherwise.
+func feeHistory(ctx context.Context, client GasPriceClient, blocks uint64, percentiles []float64) (*ethereum.FeeHistory, error) {
+	type feeHistoryClient interface {
+		FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+	}
+	if c, ok := client.(feeHistoryClient); ok {
+		return c.FeeHistory(ctx, blocks, nil, percentiles)
+	}
+	c, ok := client.(interface{ Client() *rpc.Client })
+	if !ok || c.Client() == nil {
+		return nil, errors.New("client does not provide fee history")
+	}
+	var res struct {
+		OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+		Reward       [][]*hexutil.Big `json:"reward"`
+		BaseFee      []*hexutil.Big   `json:"baseFeePerGas"`
+		GasUsedRatio []float64        `json:"gasUsedRatio"`
+	}
+	if err := c.Client().CallContext(ctx, &res, "eth_feeHistory", hexutil.Uint64(blocks), "latest", percentiles); err != nil {
+		return nil, fmt.Errorf("failed to query eth_feeHistory: %w", err)
+	}
+	history := &ethereum.FeeHistory{
+		OldestBlock:  res.OldestBlock.ToInt(),
+		Reward:       make([][]*big.Int, len(res.Reward)),
+		BaseFee:      make([]*big.Int, len(res.BaseFee)),
+		GasUsedRatio: res.GasUsedRatio,
+	}
+	for i, reward := range res.Reward {
+		history.Reward[i] = make([]*big.Int, len(reward))
+		for j, r := range reward {
+			history.Reward[i][j] = r.ToInt()
+		}
+	}
+	for i, baseFee := range res.BaseFee {
+		history.BaseFee[i] = baseFee.ToInt()
+	}
+	return history, nil
+}
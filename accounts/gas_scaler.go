@@ -0,0 +1,79 @@
+package accounts
+
+import (
+	"math"
+	"math/big"
+)
+
+// GasOperation is the kind of operation whose estimated gas limit is scaled by GasScaler.
+type GasOperation string
+
+const (
+	GasOperationTransaction    GasOperation = "transaction"     // L2 transaction sent using AdapterL2.SendTransaction.
+	GasOperationTransfer       GasOperation = "transfer"        // L2 transfer.
+	GasOperationWithdraw       GasOperation = "withdraw"        // L2 withdrawal.
+	GasOperationDeploy         GasOperation = "deploy"          // Deployment of contract or smart account.
+	GasOperationDeposit        GasOperation = "deposit"         // L2 gas limit of deposit.
+	GasOperationRequestExecute GasOperation = "request_execute" // L2 gas limit of L1 -> L2 transaction.
+)
+
+// GasScaling is the safety margin added to the estimated gas limit: the estimation is multiplied
+// by the multiplier, and the buffer is added to the result.
+type GasScaling struct {
+	Multiplier float64 // Multiplier of the estimated gas limit. Values less than or equal to 0 mean 1.
+	Buffer     uint64  // Amount of gas added to the scaled gas limit.
+}
+
+// GasScaler scales the gas limits estimated by the wallet, so that the safety margins of all operations can be
+// tuned centrally. The scaling is applied only to the estimated gas limits, gas limits provided by the caller
+// are used as is. Per-operation overrides take precedence over the default scaling.
+type GasScaler struct {
+	Default   GasScaling                  // Scaling applied to operations without override.
+	Overrides map[GasOperation]GasScaling // Scaling of specific operations. Optional.
+}
+
+// NewGasScaler creates an instance of GasScaler which multiplies all estimated gas limits by the multiplier.
+func NewGasScaler(multiplier float64) *GasScaler {
+	return &GasScaler{Default: GasScaling{Multiplier: multiplier}}
+}
+
+// WithOverride sets the scaling of the operation and returns the scaler.
+func (s *GasScaler) WithOverride(operation GasOperation, scaling GasScaling) *GasScaler {
+	if s.Overrides == nil {
+		s.Overrides = make(map[GasOperation]GasScaling)
+	}
+	s.Overrides[operation] = scaling
+	return s
+}
+
+// Scale returns the estimated gas limit of the operation with the safety margin added.
+// The nil scaler returns the gas limit unchanged.
+func (s *GasScaler) Scale(operation GasOperation, gas uint64) uint64 {
+	if s == nil {
+		return gas
+	}
+	scaling, ok := s.Overrides[operation]
+	if !ok {
+		scaling = s.Default
+	}
+	scaled := gas
+	if scaling.Multiplier > 0 && scaling.Multiplier != 1 {
+		product := math.Ceil(float64(gas) * scaling.Multiplier)
+		if product >= math.MaxUint64 {
+			return math.MaxUint64
+		}
+		scaled = uint64(product)
+	}
+	if scaled > math.MaxUint64-scaling.Buffer {
+		return math.MaxUint64
+	}
+	return scaled + scaling.Buffer
+}
+
+// ScaleBig is Scale for gas limits represented as big.Int, as used by L1 -> L2 transactions.
+func (s *GasScaler) ScaleBig(operation GasOperation, gas *big.Int) *big.Int {
+	if s == nil || gas == nil || !gas.IsUint64() {
+		return gas
+	}
+	return new(big.Int).SetUint64(s.Scale(operation, gas.Uint64()))
+}
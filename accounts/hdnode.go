@@ -0,0 +1,148 @@
+package accounts
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/miguelmota/go-ethereum-hdwallet"
+	"github.com/pkg/errors"
+	"math/big"
+	"strings"
+)
+
+// DefaultDerivationPath is the BIP-44 derivation path of the first Ethereum account.
+const DefaultDerivationPath = "m/44'/60'/0'/0/0"
+
+// HardenedKeyStart is the index of the first hardened child key.
+const HardenedKeyStart uint32 = 0x80000000
+
+// HDNode represents a node of BIP-32 hierarchical deterministic wallet, which holds a private key
+// and is able to derive child nodes. It allows managing many accounts derived from a single
+// BIP-39 mnemonic phrase.
+type HDNode struct {
+	privateKey *ecdsa.PrivateKey
+	chainCode  []byte
+	path       accounts.DerivationPath
+}
+
+// NewHDNodeFromMnemonic creates the master HDNode from the provided BIP-39 mnemonic phrase.
+func NewHDNodeFromMnemonic(mnemonic string) (*HDNode, error) {
+	seed, err := hdwallet.NewSeedFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid mnemonic")
+	}
+	return NewHDNodeFromSeed(seed)
+}
+
+// NewHDNodeFromSeed creates the master HDNode from the provided BIP-39 seed.
+func NewHDNodeFromSeed(seed []byte) (*HDNode, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, errors.New("seed length must be between 16 and 64 bytes")
+	}
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	pk, err := crypto.ToECDSA(sum[:32])
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid master key")
+	}
+	return &HDNode{
+		privateKey: pk,
+		chainCode:  sum[32:],
+		path:       accounts.DerivationPath{},
+	}, nil
+}
+
+// Derive derives the child node with the given index. Indexes starting from HardenedKeyStart
+// derive hardened child nodes.
+func (n *HDNode) Derive(index uint32) (*HDNode, error) {
+	var data []byte
+	if index >= HardenedKeyStart {
+		data = append([]byte{0x00}, math.PaddedBigBytes(n.privateKey.D, 32)...)
+	} else {
+		data = crypto.CompressPubkey(&n.privateKey.PublicKey)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, n.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	curveN := crypto.S256().Params().N
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(curveN) >= 0 {
+		return nil, fmt.Errorf("invalid child key at index %d, proceed with the next index", index)
+	}
+	key := il.Add(il, n.privateKey.D)
+	key.Mod(key, curveN)
+	if key.Sign() == 0 {
+		return nil, fmt.Errorf("invalid child key at index %d, proceed with the next index", index)
+	}
+	pk, err := crypto.ToECDSA(math.PaddedBigBytes(key, 32))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid child key")
+	}
+
+	path := make(accounts.DerivationPath, len(n.path), len(n.path)+1)
+	copy(path, n.path)
+	return &HDNode{
+		privateKey: pk,
+		chainCode:  sum[32:],
+		path:       append(path, index),
+	}, nil
+}
+
+// DerivePath derives the node at the given derivation path. Absolute paths (e.g. "m/44'/60'/0'/0/1")
+// can be derived only from the master node, while relative paths (e.g. "0/1") are derived from the
+// current node.
+func (n *HDNode) DerivePath(path string) (*HDNode, error) {
+	path = strings.TrimSpace(path)
+	if !strings.HasPrefix(path, "m/") {
+		path = "m/" + path
+	} else if len(n.path) != 0 {
+		return nil, errors.New("absolute derivation path can only be derived from the master node")
+	}
+	derivationPath, err := accounts.ParseDerivationPath(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse derivation path")
+	}
+	node := n
+	for _, index := range derivationPath {
+		if node, err = node.Derive(index); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+// Path returns the derivation path of the node from the master node.
+func (n *HDNode) Path() string {
+	return n.path.String()
+}
+
+// Depth returns the depth of the node in the tree, where the master node has depth 0.
+func (n *HDNode) Depth() int {
+	return len(n.path)
+}
+
+// PrivateKey returns the private key of the node.
+func (n *HDNode) PrivateKey() *ecdsa.PrivateKey {
+	return n.privateKey
+}
+
+// Address returns the address of the account associated with the node.
+func (n *HDNode) Address() common.Address {
+	return crypto.PubkeyToAddress(n.privateKey.PublicKey)
+}
+
+// Signer creates an instance of BaseSigner for the account associated with the node.
+func (n *HDNode) Signer(chainId int64) (*BaseSigner, error) {
+	return NewBaseSignerFromRawPrivateKey(crypto.FromECDSA(n.privateKey), chainId)
+}
@@ -0,0 +1,241 @@
+package accounts
+
+import (
+	"errors"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"log/slog"
+	"math/big"
+	"sync"
+)
+
+// ErrTransactionFailed is passed to OnFailed callbacks when the transaction has been included, but failed.
+var ErrTransactionFailed = errors.New("transaction failed")
+
+// TransactionEvent describes the transaction passed to the callbacks registered in Hooks.
+type TransactionEvent struct {
+	L1      bool            // Whether the transaction is sent on L1 network.
+	Hash    common.Hash     // Hash of the transaction, zero before the transaction is signed.
+	Nonce   *big.Int        // Nonce of the transaction.
+	To      *common.Address // The address of the recipient, nil for contract creations.
+	Value   *big.Int        // Funds transferred along the transaction.
+	Data    []byte          // Input data of the transaction.
+	Receipt *types.Receipt  // Receipt of the transaction, set in OnMined and OnFailed for failed transactions.
+	Err     error           // Error which has caused the failure, set in OnFailed.
+}
+
+// Hooks holds the callbacks invoked on the lifecycle of transactions sent by the wallet, so that applications
+// can log, persist, or alert on them without wrapping each method of the wallet. It is safe for concurrent use.
+//
+// OnBeforeSign callbacks are invoked for every transaction signed by the wallet, and OnSent callbacks for
+// every transaction sent by the wallet. Since most of the wallet methods return once the transaction is sent,
+// OnMined callbacks are invoked only when the wallet waits for the inclusion of the transaction, e.g. in
+// WalletL2.SendChain and Wallet.WithdrawAndWait. Hooks.Mined can be used to report the outcome of
+// transactions which are awaited by the application.
+type Hooks struct {
+	mu         sync.RWMutex
+	beforeSign []func(event TransactionEvent) error
+	sent       []func(event TransactionEvent)
+	mined      []func(event TransactionEvent)
+	failed     []func(event TransactionEvent)
+}
+
+// NewHooks creates an instance of Hooks without callbacks.
+func NewHooks() *Hooks {
+	return &Hooks{}
+}
+
+// OnBeforeSign registers the callback invoked before the transaction is signed. If the callback returns
+// an error, the transaction is not signed, and the error is returned by the wallet method.
+func (h *Hooks) OnBeforeSign(callback func(event TransactionEvent) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.beforeSign = append(h.beforeSign, callback)
+}
+
+// OnSent registers the callback invoked after the transaction has been sent to the network.
+func (h *Hooks) OnSent(callback func(event TransactionEvent)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.sent = append(h.sent, callback)
+}
+
+// OnMined registers the callback invoked after the transaction has been successfully included in a block.
+func (h *Hooks) OnMined(callback func(event TransactionEvent)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.mined = append(h.mined, callback)
+}
+
+// OnFailed registers the callback invoked when the transaction could not be signed or sent, or when it has
+// been included in a block, but failed.
+func (h *Hooks) OnFailed(callback func(event TransactionEvent)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.failed = append(h.failed, callback)
+}
+
+// Mined reports the outcome of the transaction awaited by the application, invoking OnMined callbacks
+// if the transaction is successful, or OnFailed callbacks otherwise.
+func (h *Hooks) Mined(event TransactionEvent, receipt *types.Receipt) {
+	if h == nil {
+		return
+	}
+	event.Hash, event.Receipt = receipt.TxHash, receipt
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		event.Err = ErrTransactionFailed
+		h.notify(&h.failed, event)
+		return
+	}
+	h.notify(&h.mined, event)
+}
+
+// LogTo registers the callbacks logging the lifecycle of transactions to the logger: signing at debug level,
+// sending and inclusion at info level, and failures at warn level.
+func (h *Hooks) LogTo(logger *slog.Logger) {
+	h.OnBeforeSign(func(event TransactionEvent) error {
+		logger.Debug("Signing transaction", event.logAttrs()...)
+		return nil
+	})
+	h.OnSent(func(event TransactionEvent) {
+		logger.Info("Transaction sent", event.logAttrs()...)
+	})
+	h.OnMined(func(event TransactionEvent) {
+		logger.Info("Transaction mined", append(event.logAttrs(), "block", event.Receipt.BlockNumber,
+			"gasUsed", event.Receipt.GasUsed)...)
+	})
+	h.OnFailed(func(event TransactionEvent) {
+		logger.Warn("Transaction failed", append(event.logAttrs(), "err", event.Err)...)
+	})
+}
+
+// logAttrs returns the attributes of the event logged by the callbacks registered by Hooks.LogTo.
+func (e TransactionEvent) logAttrs() []interface{} {
+	attrs := []interface{}{"l1", e.L1, "nonce", e.Nonce, "to", e.To, "value", e.Value}
+	if e.Hash != (common.Hash{}) {
+		attrs = append(attrs, "hash", e.Hash)
+	}
+	return attrs
+}
+
+func (h *Hooks) notify(registered *[]func(event TransactionEvent), event TransactionEvent) {
+	h.mu.RLock()
+	callbacks := append([]func(TransactionEvent){}, *registered...)
+	h.mu.RUnlock()
+	for _, callback := range callbacks {
+		callback(event)
+	}
+}
+
+// checkBeforeSign invokes OnBeforeSign callbacks and returns the first error, which is also reported
+// to OnFailed callbacks.
+func (h *Hooks) checkBeforeSign(event TransactionEvent) error {
+	if h == nil {
+		return nil
+	}
+	h.mu.RLock()
+	callbacks := append([]func(TransactionEvent) error{}, h.beforeSign...)
+	h.mu.RUnlock()
+	for _, callback := range callbacks {
+		if err := callback(event); err != nil {
+			event.Err = err
+			h.notify(&h.failed, event)
+			return err
+		}
+	}
+	return nil
+}
+
+// signer wraps the signer function of contract bindings, so that OnBeforeSign callbacks are invoked.
+func (h *Hooks) signer(signer bind.SignerFn, l1 bool) bind.SignerFn {
+	if h == nil {
+		return signer
+	}
+	return func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if err := h.checkBeforeSign(transactionEvent(tx, l1)); err != nil {
+			return nil, err
+		}
+		return signer(address, tx)
+	}
+}
+
+// sentL1 reports the L1 transaction sent using contract bindings, passing the results through.
+func (h *Hooks) sentL1(tx *types.Transaction, err error) (*types.Transaction, error) {
+	return h.sentTx(tx, err, true)
+}
+
+// sentL2 reports the L2 transaction sent using contract bindings, passing the results through.
+func (h *Hooks) sentL2(tx *types.Transaction, err error) (*types.Transaction, error) {
+	return h.sentTx(tx, err, false)
+}
+
+func (h *Hooks) sentTx(tx *types.Transaction, err error, l1 bool) (*types.Transaction, error) {
+	if h == nil {
+		return tx, err
+	}
+	if err != nil {
+		// The transaction is not available, unless it has been signed and the node rejected it.
+		event := TransactionEvent{L1: l1, Err: err}
+		if tx != nil {
+			event = transactionEvent(tx, l1)
+			event.Err = err
+		}
+		h.notify(&h.failed, event)
+		return tx, err
+	}
+	h.notify(&h.sent, transactionEvent(tx, l1))
+	return tx, nil
+}
+
+// sent712 reports the result of sending the EIP-712 transaction.
+func (h *Hooks) sent712(tx *zkTypes.Transaction712, hash common.Hash, err error) {
+	if h == nil {
+		return
+	}
+	event := transaction712Event(tx)
+	event.Hash = hash
+	if err != nil {
+		event.Err = err
+		h.notify(&h.failed, event)
+		return
+	}
+	h.notify(&h.sent, event)
+}
+
+// minedL2 reports the result of waiting for the inclusion of the L2 transaction.
+func (h *Hooks) minedL2(hash common.Hash, receipt *zkTypes.Receipt, err error) {
+	if h == nil {
+		return
+	}
+	if err != nil {
+		h.notify(&h.failed, TransactionEvent{Hash: hash, Err: err})
+		return
+	}
+	h.Mined(TransactionEvent{Hash: hash}, &receipt.Receipt)
+}
+
+func transactionEvent(tx *types.Transaction, l1 bool) TransactionEvent {
+	event := TransactionEvent{
+		L1:    l1,
+		Nonce: new(big.Int).SetUint64(tx.Nonce()),
+		To:    tx.To(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+	// The hash of unsigned transaction differs from the one of the signed transaction.
+	if _, r, _ := tx.RawSignatureValues(); r != nil && r.Sign() != 0 {
+		event.Hash = tx.Hash()
+	}
+	return event
+}
+
+func transaction712Event(tx *zkTypes.Transaction712) TransactionEvent {
+	return TransactionEvent{
+		Nonce: tx.Nonce,
+		To:    tx.To,
+		Value: tx.Value,
+		Data:  tx.Data,
+	}
+}
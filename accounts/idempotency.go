@@ -0,0 +1,367 @@
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/zksync-sdk/zksync2-go/clients"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrIdempotencyKeyExists is returned by IdempotencyStore.Create when the key already has a record.
+var ErrIdempotencyKeyExists = errors.New("idempotency key already exists")
+
+// ErrIdempotencyUnresolved is returned by TxManager.SendIdempotent when the transaction of the key has been
+// sent, but its hash has not been recorded, e.g. because the process has crashed right after sending it, and
+// the transaction cannot be sent again to obtain the hash, since it is already pending or included. The
+// transaction is not sent again, and the outcome has to be reconciled using the nonce of the record.
+var ErrIdempotencyUnresolved = errors.New("idempotent transaction has been sent, but its hash is unknown")
+
+// IdempotencyRecord is the record of the transaction sent by TxManager.SendIdempotent under the key.
+type IdempotencyRecord struct {
+	Nonce  uint64        `json:"nonce"`  // Reserved nonce of the transaction, shared by all of its submissions.
+	RawTx  hexutil.Bytes `json:"rawTx"`  // The latest signed submission of the transaction.
+	Hashes []common.Hash `json:"hashes"` // Hashes of the submissions sent so far.
+}
+
+// IdempotencyStore persists the transactions sent by TxManager.SendIdempotent, mapping the keys provided by
+// the application to the records of the transactions. Implementations must be safe for concurrent use.
+type IdempotencyStore interface {
+	// Get returns the record of the key, or nil if the key has no record.
+	Get(ctx context.Context, key string) (*IdempotencyRecord, error)
+	// Create atomically stores the record of the key, unless the key already has a record, in which case
+	// ErrIdempotencyKeyExists is returned.
+	Create(ctx context.Context, key string, record *IdempotencyRecord) error
+	// Update replaces the record of the key.
+	Update(ctx context.Context, key string, record *IdempotencyRecord) error
+	// Delete removes the record of the key.
+	Delete(ctx context.Context, key string) error
+}
+
+// SendIdempotent is Send which guarantees that at most one transaction is sent under the key provided by
+// the application, e.g. the ID of the payment, even across process restarts, as long as the records are
+// persisted by TxManagerConfig.IdempotencyStore.
+//
+// The signed transaction is recorded before it is sent, and its resubmissions share its nonce, so at most one
+// of them can be included. If the key already has a record, the transaction is not prepared again: the recorded
+// transaction is sent again, in case it has not reached the node, and its inclusion is awaited. If the node
+// rejects the first submission of the transaction, the record is deleted, so that the call can be retried,
+// unless the rejection is due to the used nonce and the recorded transaction has been included.
+//
+// The recorded nonce is claimed again when the key is resumed, so after the restart, the keys with pending
+// records should be resumed before other transactions are sent, which could otherwise reserve their nonces.
+func (m *TxManager) SendIdempotent(ctx context.Context, key string, tx Transaction) (*zkTypes.Receipt, error) {
+	store := m.config.IdempotencyStore
+	if store == nil {
+		return nil, errors.New("idempotency store is not configured")
+	}
+	ctx = ensureContext(ctx)
+	if m.config.Deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.config.Deadline)
+		defer cancel()
+	}
+
+	record, err := store.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get record of idempotency key %s: %w", key, err)
+	}
+	if record != nil {
+		return m.resume(ctx, key, record)
+	}
+
+	prepared, maxFeeCap, release, err := m.prepare(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	recorder := &idempotencyRecorder{store: store, key: key, nonce: prepared.Nonce.Uint64()}
+	hash, err := m.submitReplacing(ctx, prepared, maxFeeCap, recorder)
+	if err != nil && recorder.record == nil {
+		// The transaction has not been sent, so its nonce is not used.
+		release()
+	}
+	if errors.Is(err, ErrIdempotencyKeyExists) {
+		// The transaction has been sent under the key by another caller in the meantime.
+		if record, err = store.Get(ctx, key); err != nil {
+			return nil, fmt.Errorf("failed to get record of idempotency key %s: %w", key, err)
+		}
+		return m.resume(ctx, key, record)
+	}
+	if err != nil {
+		var rpcErr rpc.Error
+		class := m.config.ErrorClassifier.Classify(err)
+		if recorder.record != nil && errors.As(err, &rpcErr) && len(recorder.record.Hashes) == 0 &&
+			class != clients.BroadcastAlreadyKnown {
+			if class == clients.BroadcastNonceTooLow {
+				// The nonce may have been used by the recorded transaction itself, e.g. if it has reached
+				// the node, but the response has been lost.
+				receipt, included, receiptErr := m.recordedReceipt(ctx, recorder)
+				if receiptErr != nil {
+					return nil, fmt.Errorf("%w (failed to check inclusion of recorded transaction: %v)", err, receiptErr)
+				}
+				if included {
+					return receipt, nil
+				}
+			} else {
+				// The nonce has not been used, unlike the nonce which is too low.
+				release()
+			}
+			// The node has rejected the transaction, so it can be prepared again.
+			if deleteErr := store.Delete(ctx, key); deleteErr != nil {
+				return nil, fmt.Errorf("%w (failed to delete record of idempotency key %s: %v)", err, key, deleteErr)
+			}
+		}
+		return nil, err
+	}
+	return m.await(ctx, prepared.Nonce.Uint64(), prepared, maxFeeCap, []common.Hash{hash}, recorder)
+}
+
+// nonceClaimer is implemented by the nonce managers, such as LocalNonceManager, which can mark the nonce
+// reserved outside of them as reserved.
+type nonceClaimer interface {
+	Claim(ctx context.Context, account common.Address, nonce uint64) error
+}
+
+// resume sends the recorded transaction again, and awaits the inclusion of any of its submissions.
+// The recorded nonce is claimed in the nonce manager, if it supports it, so that the nonce is not reserved
+// for another transaction, e.g. after the restart, which would replace the recorded one.
+func (m *TxManager) resume(ctx context.Context, key string, record *IdempotencyRecord) (*zkTypes.Receipt, error) {
+	if claimer, ok := m.nonceManager().(nonceClaimer); ok {
+		if err := claimer.Claim(ctx, m.adapter.Address(), record.Nonce); err != nil {
+			return nil, fmt.Errorf("failed to claim nonce %d of idempotency key %s: %w", record.Nonce, key, err)
+		}
+	}
+	hashes := append([]common.Hash{}, record.Hashes...)
+	hash, err := m.client.SendRawTransaction(ctx, record.RawTx)
+	if err == nil {
+		known := false
+		for _, h := range hashes {
+			known = known || h == hash
+		}
+		if !known {
+			recorder := &idempotencyRecorder{store: m.config.IdempotencyStore, key: key, nonce: record.Nonce, record: record}
+			recorder.sent(ctx, hash)
+			hashes = append(hashes, hash)
+		}
+	} else if len(hashes) == 0 {
+		switch m.config.ErrorClassifier.Classify(err) {
+		case clients.BroadcastNonceTooLow, clients.BroadcastAlreadyKnown:
+			return nil, fmt.Errorf("%w: key %s, nonce %d", ErrIdempotencyUnresolved, key, record.Nonce)
+		default:
+			return nil, err
+		}
+	}
+	return m.await(ctx, record.Nonce, nil, nil, hashes, nil)
+}
+
+// recordedReceipt returns the receipt of the latest recorded submission, and reports whether it has been
+// included, in which case its hash is recorded.
+func (m *TxManager) recordedReceipt(ctx context.Context, recorder *idempotencyRecorder) (*zkTypes.Receipt, bool, error) {
+	tx, err := zkTypes.ParseEip712Transaction(recorder.record.RawTx)
+	if err != nil {
+		return nil, false, err
+	}
+	hash, err := tx.Hash()
+	if err != nil {
+		return nil, false, err
+	}
+	receipt, err := m.client.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) || (err == nil && (receipt == nil || receipt.BlockNumber == nil)) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	recorder.sent(ctx, hash)
+	m.emit(TxEvent{Kind: TxIncluded, Hash: hash, Nonce: recorder.nonce, Receipt: receipt})
+	return receipt, true, nil
+}
+
+// idempotencyRecorder records the submissions of the transaction sent by TxManager.SendIdempotent.
+// The nil recorder records nothing.
+type idempotencyRecorder struct {
+	store  IdempotencyStore
+	key    string
+	nonce  uint64
+	record *IdempotencyRecord // The record of the key, nil until the first submission is signed.
+}
+
+// signed records the signed submission before it is sent.
+func (r *idempotencyRecorder) signed(ctx context.Context, rawTx []byte) error {
+	if r == nil {
+		return nil
+	}
+	if r.record == nil {
+		record := &IdempotencyRecord{Nonce: r.nonce, RawTx: rawTx}
+		if err := r.store.Create(ctx, r.key, record); err != nil {
+			return err
+		}
+		r.record = record
+		return nil
+	}
+	updated := *r.record
+	updated.RawTx = rawTx
+	if err := r.store.Update(ctx, r.key, &updated); err != nil {
+		return fmt.Errorf("failed to update record of idempotency key %s: %w", r.key, err)
+	}
+	r.record = &updated
+	return nil
+}
+
+// sent records the hash of the sent submission. The failure is not fatal, since the hash is obtained again
+// by sending the recorded submission, unless it has been included in the meantime.
+func (r *idempotencyRecorder) sent(ctx context.Context, hash common.Hash) {
+	if r == nil || r.record == nil {
+		return
+	}
+	updated := *r.record
+	updated.Hashes = append(append([]common.Hash{}, r.record.Hashes...), hash)
+	if err := r.store.Update(ctx, r.key, &updated); err == nil {
+		r.record = &updated
+	}
+}
+
+// MemoryIdempotencyStore is the IdempotencyStore keeping the records in memory, which does not guarantee
+// idempotency across process restarts. It is safe for concurrent use.
+type MemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]IdempotencyRecord
+}
+
+// NewMemoryIdempotencyStore creates an instance of MemoryIdempotencyStore.
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{records: make(map[string]IdempotencyRecord)}
+}
+
+func (s *MemoryIdempotencyStore) Get(_ context.Context, key string) (*IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.records[key]
+	if !ok {
+		return nil, nil
+	}
+	return &record, nil
+}
+
+func (s *MemoryIdempotencyStore) Create(_ context.Context, key string, record *IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.records[key]; ok {
+		return ErrIdempotencyKeyExists
+	}
+	s.records[key] = *record
+	return nil
+}
+
+func (s *MemoryIdempotencyStore) Update(_ context.Context, key string, record *IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[key] = *record
+	return nil
+}
+
+func (s *MemoryIdempotencyStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+// FileIdempotencyStore is the IdempotencyStore keeping each record in a JSON file in the directory, named
+// after the hash of the key. The records are written atomically, so that they survive process crashes.
+type FileIdempotencyStore struct {
+	dir string
+}
+
+// NewFileIdempotencyStore creates an instance of FileIdempotencyStore, creating the directory if it does
+// not exist.
+func NewFileIdempotencyStore(dir string) (*FileIdempotencyStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create idempotency store directory: %w", err)
+	}
+	return &FileIdempotencyStore{dir: dir}, nil
+}
+
+func (s *FileIdempotencyStore) Get(_ context.Context, key string) (*IdempotencyRecord, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var record IdempotencyRecord
+	if err = json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to decode record of idempotency key %s: %w", key, err)
+	}
+	return &record, nil
+}
+
+func (s *FileIdempotencyStore) Create(_ context.Context, key string, record *IdempotencyRecord) error {
+	tmp, err := s.writeTemp(record)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	// Unlike renaming, linking fails if the record already exists.
+	if err = os.Link(tmp, s.path(key)); errors.Is(err, os.ErrExist) {
+		return ErrIdempotencyKeyExists
+	}
+	return err
+}
+
+func (s *FileIdempotencyStore) Update(_ context.Context, key string, record *IdempotencyRecord) error {
+	tmp, err := s.writeTemp(record)
+	if err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, s.path(key)); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+func (s *FileIdempotencyStore) Delete(_ context.Context, key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *FileIdempotencyStore) path(key string) string {
+	return filepath.Join(s.dir, crypto.Keccak256Hash([]byte(key)).Hex()[2:]+".json")
+}
+
+// writeTemp writes the record to the synced temporary file in the directory, and returns its path.
+func (s *FileIdempotencyStore) writeTemp(record *IdempotencyRecord) (string, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return "", err
+	}
+	file, err := os.CreateTemp(s.dir, ".record-*")
+	if err != nil {
+		return "", err
+	}
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return "", err
+	}
+	return file.Name(), nil
+}
@@ -0,0 +1,82 @@
+package accounts
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/zksync-sdk/zksync2-go/clients"
+	"github.com/zksync-sdk/zksync2-go/eip712"
+	"math/big"
+)
+
+// ErrImpersonatedTransaction is returned when ImpersonatedSigner is requested to sign a transaction other than
+// EIP-712 transaction, whose sender is recovered from the signature and therefore can't be impersonated.
+var ErrImpersonatedTransaction = errors.New("only EIP-712 transactions can be sent from impersonated account")
+
+// placeholderSignature is the well-formed signature, which is not verified for impersonated accounts.
+var placeholderSignature = append(append(common.LeftPadBytes([]byte{1}, 32), common.LeftPadBytes([]byte{1}, 32)...), 27)
+
+// ImpersonatedSigner implements the Signer interface for the account impersonated on the development node,
+// see clients.DevClient.ImpersonateAccount. It returns the placeholder signatures, which the node does not
+// verify for impersonated accounts, so that EIP-712 transactions can be sent from any account, including
+// contracts. Transactions signed by it are rejected by any other node.
+type ImpersonatedSigner struct {
+	address common.Address
+	domain  *eip712.Domain
+}
+
+// NewImpersonatedSigner creates an instance of ImpersonatedSigner for the account with the given address.
+func NewImpersonatedSigner(address common.Address, chainId int64) *ImpersonatedSigner {
+	return &ImpersonatedSigner{
+		address: address,
+		domain:  eip712.ZkSyncEraEIP712Domain(chainId),
+	}
+}
+
+func (s *ImpersonatedSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *ImpersonatedSigner) Domain() *eip712.Domain {
+	return s.domain
+}
+
+func (s *ImpersonatedSigner) PrivateKey() *ecdsa.PrivateKey {
+	return nil
+}
+
+// SignHash returns the placeholder signature.
+func (s *ImpersonatedSigner) SignHash(_ []byte) ([]byte, error) {
+	return common.CopyBytes(placeholderSignature), nil
+}
+
+// SignTypedData returns the placeholder signature.
+func (s *ImpersonatedSigner) SignTypedData(_ *eip712.Domain, _ eip712.TypedData) ([]byte, error) {
+	return common.CopyBytes(placeholderSignature), nil
+}
+
+// SignTx returns ErrImpersonatedTransaction.
+func (s *ImpersonatedSigner) SignTx(_ *types.Transaction, _ *big.Int) (*types.Transaction, error) {
+	return nil, ErrImpersonatedTransaction
+}
+
+// NewImpersonatedWallet impersonates the account on the development node and creates the wallet sending
+// L2 transactions from it. Impersonation can be stopped using clients.DevClient.StopImpersonatingAccount.
+func NewImpersonatedWallet(ctx context.Context, client *clients.DevClient, address common.Address) (*Wallet, error) {
+	if client == nil {
+		return nil, errors.New("client must be provided")
+	}
+	ctx = ensureContext(ctx)
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = client.ImpersonateAccount(ctx, address); err != nil {
+		return nil, err
+	}
+	signer := Signer(NewImpersonatedSigner(address, chainID.Int64()))
+	clientL2 := client.Client
+	return NewWalletFromSigner(&signer, &clientL2, nil)
+}
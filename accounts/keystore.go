@@ -0,0 +1,78 @@
+package accounts
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/zksync-sdk/zksync2-go/clients"
+	"github.com/zksync-sdk/zksync2-go/eip712"
+)
+
+// NewBaseSignerFromKeystore creates a new instance of BaseSigner based on the provided encrypted
+// keystore (Web3 Secret Storage V3) JSON and its password.
+func NewBaseSignerFromKeystore(keyJson []byte, password string, chainId int64) (*BaseSigner, error) {
+	key, err := keystore.DecryptKey(keyJson, password)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt keystore")
+	}
+	return &BaseSigner{
+		pk:      key.PrivateKey,
+		address: key.Address,
+		domain:  eip712.ZkSyncEraEIP712Domain(chainId),
+	}, nil
+}
+
+// NewWalletFromKeystore creates an instance of Wallet associated with the account stored in the provided
+// encrypted keystore (Web3 Secret Storage V3) JSON, which is decrypted using the password.
+// The clientL2 is required, since it is used to fetch the chain ID, while the clientL1 is optional and
+// can be configured with Wallet.ConnectL1.
+//
+// Deprecated: Deprecated in favor of v2/accounts.NewWallet with WalletOptions.Keystore.
+func NewWalletFromKeystore(keyJson []byte, password string, clientL2 *clients.Client, clientL1 *ethclient.Client) (*Wallet, error) {
+	if clientL2 == nil {
+		return nil, errors.New("clientL2 must be provided")
+	}
+	return NewWalletWithOptions(WalletOptions{
+		SignerOptions: SignerOptions{Keystore: keyJson, Password: password},
+		ClientL2:      *clientL2,
+		ClientL1:      clientL1,
+	})
+}
+
+// ExportKeystore encrypts the private key of the signer with the password and returns it as
+// keystore (Web3 Secret Storage V3) JSON, using the standard scrypt parameters.
+// The signer must provide its private key.
+func ExportKeystore(signer Signer, password string) ([]byte, error) {
+	return ExportKeystoreWithParams(signer, password, keystore.StandardScryptN, keystore.StandardScryptP)
+}
+
+// ExportKeystoreWithParams is like ExportKeystore, but uses the provided scrypt parameters.
+// Use keystore.LightScryptN and keystore.LightScryptP for faster, but less secure encryption.
+func ExportKeystoreWithParams(signer Signer, password string, scryptN, scryptP int) ([]byte, error) {
+	pk := signer.PrivateKey()
+	if pk == nil {
+		return nil, errors.New("signer does not provide private key")
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate keystore id")
+	}
+	key := &keystore.Key{
+		Id:         id,
+		Address:    crypto.PubkeyToAddress(pk.PublicKey),
+		PrivateKey: pk,
+	}
+	keyJson, err := keystore.EncryptKey(key, password, scryptN, scryptP)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt keystore")
+	}
+	return keyJson, nil
+}
+
+// ExportKeystore encrypts the private key of the wallet's signer with the password and returns it as
+// keystore (Web3 Secret Storage V3) JSON.
+func (w *Wallet) ExportKeystore(password string) ([]byte, error) {
+	return ExportKeystore(w.Signer(), password)
+}
@@ -0,0 +1,157 @@
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/zksync-sdk/zksync2-go/eip712"
+	"math/big"
+	"time"
+)
+
+// KMSClient is a generic interface of a key management service (e.g. AWS KMS, GCP KMS, Azure Key Vault or
+// Cloud HSM) holding a non-extractable secp256k1 key. Implementations are bound to a single key.
+type KMSClient interface {
+	// PublicKey returns the public key of the key as DER-encoded X.509 SubjectPublicKeyInfo structure.
+	PublicKey(ctx context.Context) ([]byte, error)
+	// Sign signs the 32-byte digest using ECDSA and returns the DER-encoded signature.
+	Sign(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// KMSSigner implements the Signer interface by delegating signing to a key management service.
+// The signatures returned by the service are normalized to the canonical (low-S) form and
+// extended with the recovery ID, which is resolved by recovering the public key.
+//
+// Since the private key is non-extractable, KMSSigner.PrivateKey returns nil.
+type KMSSigner struct {
+	client    KMSClient
+	publicKey *ecdsa.PublicKey
+	address   common.Address
+	domain    *eip712.Domain
+	timeout   time.Duration
+}
+
+// NewKMSSigner creates an instance of KMSSigner using the key of the given KMS client.
+// The public key is fetched once, in order to derive the address of the account.
+func NewKMSSigner(ctx context.Context, client KMSClient, chainId int64) (*KMSSigner, error) {
+	der, err := client.PublicKey(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get public key from KMS")
+	}
+	publicKey, err := parseKMSPublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+	return &KMSSigner{
+		client:    client,
+		publicKey: publicKey,
+		address:   crypto.PubkeyToAddress(*publicKey),
+		domain:    eip712.ZkSyncEraEIP712Domain(chainId),
+		timeout:   30 * time.Second,
+	}, nil
+}
+
+// SetTimeout sets the timeout of the signing requests sent to KMS. Default timeout is 30 seconds.
+func (s *KMSSigner) SetTimeout(timeout time.Duration) {
+	s.timeout = timeout
+}
+
+func (s *KMSSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *KMSSigner) Domain() *eip712.Domain {
+	return s.domain
+}
+
+func (s *KMSSigner) PrivateKey() *ecdsa.PrivateKey {
+	return nil
+}
+
+// SignHash signs the hash using KMS and returns the signature in the [R || S || V] format,
+// where V is 0 or 1, which is the same format as returned by BaseSigner.SignHash.
+func (s *KMSSigner) SignHash(msg []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	return s.SignHashContext(ctx, msg)
+}
+
+// SignHashContext is like SignHash, but with context.
+func (s *KMSSigner) SignHashContext(ctx context.Context, msg []byte) ([]byte, error) {
+	if len(msg) != 32 {
+		return nil, fmt.Errorf("hash is required to be exactly 32 bytes (%d)", len(msg))
+	}
+	der, err := s.client.Sign(ctx, msg)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign hash with KMS")
+	}
+	r, sv, err := parseKMSSignature(der)
+	if err != nil {
+		return nil, err
+	}
+	// Resolve the recovery ID by recovering the public key for both possible values.
+	sig := make([]byte, 65)
+	copy(sig[:32], math.PaddedBigBytes(r, 32))
+	copy(sig[32:64], math.PaddedBigBytes(sv, 32))
+	expected := crypto.FromECDSAPub(s.publicKey)
+	for v := byte(0); v < 2; v++ {
+		sig[64] = v
+		recovered, err := crypto.Ecrecover(msg, sig)
+		if err == nil && bytes.Equal(recovered, expected) {
+			return sig, nil
+		}
+	}
+	return nil, errors.New("failed to resolve recovery id of KMS signature")
+}
+
+func (s *KMSSigner) SignTypedData(domain *eip712.Domain, data eip712.TypedData) ([]byte, error) {
+	hash, err := eip712.HashTypedData(domain, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hash of typed data: %w", err)
+	}
+	sig, err := s.SignHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+// parseKMSPublicKey parses the DER-encoded X.509 SubjectPublicKeyInfo structure containing secp256k1 key.
+func parseKMSPublicKey(der []byte) (*ecdsa.PublicKey, error) {
+	var info struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, errors.Wrap(err, "failed to decode KMS public key")
+	}
+	publicKey, err := crypto.UnmarshalPubkey(info.PublicKey.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "KMS public key is not a valid secp256k1 key")
+	}
+	return publicKey, nil
+}
+
+// parseKMSSignature parses the DER-encoded ECDSA signature and returns its R and S values,
+// where S is normalized to the lower half of the curve order as required by EIP-2.
+func parseKMSSignature(der []byte) (*big.Int, *big.Int, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+	if _, err := asn1.Unmarshal(der, &sig); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to decode KMS signature")
+	}
+	n := crypto.S256().Params().N
+	if sig.S.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		sig.S = new(big.Int).Sub(n, sig.S)
+	}
+	return sig.R, sig.S, nil
+}
@@ -0,0 +1,149 @@
+package accounts
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/usbwallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/zksync-sdk/zksync2-go/eip712"
+	"math/big"
+)
+
+// ErrSignHashNotSupported is returned by signers which are not able to sign arbitrary hashes.
+var ErrSignHashNotSupported = errors.New("signing of arbitrary hashes is not supported")
+
+// LedgerSigner implements the Signer interface using a Ledger hardware wallet, so that the private key
+// never leaves the device. EIP-712 transactions are signed using the EIP-712 signing capability of the
+// Ethereum application running on the device, which needs to be unlocked and opened.
+//
+// Since the device does not expose the private key and is not able to sign arbitrary hashes,
+// LedgerSigner.PrivateKey returns nil and LedgerSigner.SignHash returns ErrSignHashNotSupported.
+// Other transactions, e.g. those sent to L1, are signed using LedgerSigner.SignTx and must use
+// legacy gas pricing, i.e. have TransactOpts.GasPrice set.
+type LedgerSigner struct {
+	hub     *usbwallet.Hub
+	wallet  accounts.Wallet
+	account accounts.Account
+	domain  *eip712.Domain
+}
+
+// NewLedgerSigner creates an instance of LedgerSigner using the first connected Ledger device and
+// the account at the given derivation path. If the derivation path is empty, DefaultDerivationPath is used.
+// The LedgerSigner.Close should be called once the signer is no longer needed.
+func NewLedgerSigner(derivationPath string, chainId int64) (*LedgerSigner, error) {
+	hub, err := usbwallet.NewLedgerHub()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize Ledger hub")
+	}
+	wallets := hub.Wallets()
+	if len(wallets) == 0 {
+		return nil, errors.New("no Ledger device found")
+	}
+	if err = wallets[0].Open(""); err != nil {
+		return nil, errors.Wrap(err, "failed to open Ledger device")
+	}
+	signer, err := NewLedgerSignerFromWallet(wallets[0], derivationPath, chainId)
+	if err != nil {
+		wallets[0].Close()
+		return nil, err
+	}
+	signer.hub = hub
+	return signer, nil
+}
+
+// NewLedgerSignerFromWallet creates an instance of LedgerSigner using the already opened
+// Ledger wallet and the account at the given derivation path.
+// If the derivation path is empty, DefaultDerivationPath is used.
+func NewLedgerSignerFromWallet(wallet accounts.Wallet, derivationPath string, chainId int64) (*LedgerSigner, error) {
+	if derivationPath == "" {
+		derivationPath = DefaultDerivationPath
+	}
+	path, err := accounts.ParseDerivationPath(derivationPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse derivation path")
+	}
+	account, err := wallet.Derive(path, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive account from Ledger device")
+	}
+	return &LedgerSigner{
+		wallet:  wallet,
+		account: account,
+		domain:  eip712.ZkSyncEraEIP712Domain(chainId),
+	}, nil
+}
+
+// Close closes the connection to the Ledger device and releases the USB hub, if it has been created
+// by NewLedgerSigner. The signer cannot be used after it is closed.
+func (s *LedgerSigner) Close() error {
+	wallets := []accounts.Wallet{s.wallet}
+	if s.hub != nil {
+		// The hub has no Close method, it holds the devices only through the wallets it tracks.
+		// Closing the wallet which has not been opened, or has been closed, is a no-op.
+		wallets = append(wallets, s.hub.Wallets()...)
+		s.hub = nil
+	}
+	var err error
+	for _, wallet := range wallets {
+		if closeErr := wallet.Close(); closeErr != nil && err == nil {
+			err = errors.Wrap(closeErr, "failed to close Ledger device")
+		}
+	}
+	return err
+}
+
+func (s *LedgerSigner) Address() common.Address {
+	return s.account.Address
+}
+
+func (s *LedgerSigner) Domain() *eip712.Domain {
+	return s.domain
+}
+
+func (s *LedgerSigner) PrivateKey() *ecdsa.PrivateKey {
+	return nil
+}
+
+func (s *LedgerSigner) SignHash(_ []byte) ([]byte, error) {
+	return nil, ErrSignHashNotSupported
+}
+
+func (s *LedgerSigner) SignTypedData(domain *eip712.Domain, data eip712.TypedData) ([]byte, error) {
+	typedData, err := eip712.NewTypedData(domain, data)
+	if err != nil {
+		return nil, err
+	}
+	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hash of typed data domain: %w", err)
+	}
+	dataHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hash of typed message: %w", err)
+	}
+	// The device signs typed data if the payload is in the form of 0x1901 || domainSeparator || hashStruct(message).
+	payload := append([]byte{0x19, 0x01}, append(domainSeparator, dataHash...)...)
+	sig, err := s.wallet.SignData(s.account, accounts.MimetypeTypedData, payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign typed data with Ledger device")
+	}
+	if sig[64] < 27 {
+		sig[64] += 27
+	}
+	return sig, nil
+}
+
+// SignTx signs the transaction using the Ledger device. Only legacy transactions are supported.
+func (s *LedgerSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if tx.Type() != types.LegacyTxType {
+		return nil, fmt.Errorf("transaction type %d is not supported by Ledger signer, use legacy transaction", tx.Type())
+	}
+	signedTx, err := s.wallet.SignTx(s.account, tx, chainID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign transaction with Ledger device")
+	}
+	return signedTx, nil
+}
@@ -0,0 +1,57 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/zksync-sdk/zksync2-go/utils"
+)
+
+// MessageSigner is implemented by signers which sign EIP-191 personal messages themselves, such as signers
+// which are not able to sign arbitrary hashes. If the Signer implements this interface, it is used by SignMessage.
+type MessageSigner interface {
+	// SignMessage signs the message prefixed with "\x19Ethereum Signed Message:\n" and its length.
+	SignMessage(msg []byte) ([]byte, error)
+}
+
+// SignMessage signs the message as EIP-191 personal message, as done by personal_sign, using the signer.
+// The v value of the returned signature is 27 or 28. The signature can be verified using utils.VerifyMessage,
+// or utils.IsMessageSignatureValid for smart accounts.
+func SignMessage(signer Signer, msg []byte) ([]byte, error) {
+	if messageSigner, ok := signer.(MessageSigner); ok {
+		return messageSigner.SignMessage(msg)
+	}
+	sig, err := signer.SignHash(accounts.TextHash(msg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign message: %w", err)
+	}
+	if len(sig) == crypto.SignatureLength && sig[64] < 27 {
+		sig[64] += 27
+	}
+	return sig, nil
+}
+
+// SignMessage signs the message as EIP-191 personal message.
+func (s *BaseSigner) SignMessage(msg []byte) ([]byte, error) {
+	sig, err := crypto.Sign(accounts.TextHash(msg), s.pk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign message: %w", err)
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+// SignMessage signs the message as EIP-191 personal message using the signer of the wallet.
+func (w *Wallet) SignMessage(msg []byte) ([]byte, error) {
+	return SignMessage(w.Signer(), msg)
+}
+
+// VerifyMessage reports whether the message has been signed by the account of the wallet, which is either
+// an EOA or a smart account implementing EIP-1271.
+func (w *Wallet) VerifyMessage(msg, sig []byte) (bool, error) {
+	if w.clientL2 == nil {
+		return utils.VerifyMessage(w.Address(), msg, sig), nil
+	}
+	return utils.IsMessageSignatureValid(context.Background(), *w.clientL2, w.Address(), msg, sig)
+}
@@ -0,0 +1,292 @@
+package accounts
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/zksync-sdk/zksync2-go/eip712"
+	"sort"
+	"sync"
+)
+
+// ErrMultisigThreshold is returned when fewer partial signatures than the threshold of the multisig account
+// have been collected.
+var ErrMultisigThreshold = errors.New("multisig threshold not reached")
+
+// PartialSignature is the signature of the hash made by a single owner of the multisig account. It is the format
+// in which the owners exchange their signatures, e.g. encoded as JSON.
+type PartialSignature struct {
+	Signer    common.Address `json:"signer"`    // The owner who made the signature.
+	Hash      common.Hash    `json:"hash"`      // The signed hash.
+	Signature hexutil.Bytes  `json:"signature"` // 65-byte ECDSA signature, with v being 27 or 28.
+}
+
+// SignPartial signs the hash using the signer of the owner of the multisig account.
+func SignPartial(signer Signer, hash common.Hash) (*PartialSignature, error) {
+	sig, err := signer.SignHash(hash.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if len(sig) != crypto.SignatureLength {
+		return nil, fmt.Errorf("invalid length of signature made by %s: %d", signer.Address(), len(sig))
+	}
+	sig = common.CopyBytes(sig)
+	if sig[64] < 27 {
+		sig[64] += 27
+	}
+	return &PartialSignature{Signer: signer.Address(), Hash: hash, Signature: sig}, nil
+}
+
+// MultisigBuilder aggregates the partial signatures of the hash made by the owners of the n-of-m multisig
+// account, which can be collected asynchronously. It is safe for concurrent use.
+type MultisigBuilder struct {
+	hash      common.Hash
+	owners    map[common.Address]int // Index of each owner in the owners passed to NewMultisigBuilder.
+	threshold int
+
+	mu         sync.Mutex
+	signatures map[common.Address][]byte
+}
+
+// NewMultisigBuilder creates an instance of MultisigBuilder for the hash, which must be signed by at least
+// threshold of the owners. The signatures are concatenated in the order of the owners, which must be the order
+// expected by the multisig account contract, e.g. owner1 followed by owner2 for the TwoUserMultisig account
+// of the zkSync Era documentation.
+func NewMultisigBuilder(hash common.Hash, owners []common.Address, threshold int) (*MultisigBuilder, error) {
+	if threshold < 1 || threshold > len(owners) {
+		return nil, fmt.Errorf("invalid multisig threshold %d of %d owners", threshold, len(owners))
+	}
+	b := &MultisigBuilder{
+		hash:       hash,
+		owners:     make(map[common.Address]int, len(owners)),
+		threshold:  threshold,
+		signatures: make(map[common.Address][]byte, threshold),
+	}
+	for i, owner := range owners {
+		if _, ok := b.owners[owner]; ok {
+			return nil, fmt.Errorf("duplicate multisig owner %s", owner)
+		}
+		b.owners[owner] = i
+	}
+	return b, nil
+}
+
+// Hash returns the hash signed by the owners.
+func (b *MultisigBuilder) Hash() common.Hash {
+	return b.hash
+}
+
+// Add verifies the partial signature and adds it to the collected ones. Adding the signature of the owner
+// who has already signed replaces the previous signature.
+func (b *MultisigBuilder) Add(partial PartialSignature) error {
+	if partial.Hash != b.hash {
+		return fmt.Errorf("partial signature of %s signs hash %s instead of %s", partial.Signer, partial.Hash, b.hash)
+	}
+	if _, ok := b.owners[partial.Signer]; !ok {
+		return fmt.Errorf("%s is not owner of multisig account", partial.Signer)
+	}
+	if len(partial.Signature) != crypto.SignatureLength {
+		return fmt.Errorf("invalid length of partial signature of %s: %d", partial.Signer, len(partial.Signature))
+	}
+	recoverable := common.CopyBytes(partial.Signature)
+	if recoverable[64] >= 27 {
+		recoverable[64] -= 27
+	}
+	publicKey, err := crypto.SigToPub(b.hash.Bytes(), recoverable)
+	if err != nil || crypto.PubkeyToAddress(*publicKey) != partial.Signer {
+		return fmt.Errorf("partial signature is not made by %s", partial.Signer)
+	}
+
+	recoverable[64] += 27
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.signatures[partial.Signer] = recoverable
+	return nil
+}
+
+// Count returns the number of owners whose signatures have been collected.
+func (b *MultisigBuilder) Count() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.signatures)
+}
+
+// Ready reports whether the threshold has been reached.
+func (b *MultisigBuilder) Ready() bool {
+	return b.Count() >= b.threshold
+}
+
+// Missing returns the owners whose signatures have not been collected, in the order of the owners.
+func (b *MultisigBuilder) Missing() []common.Address {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var missing []common.Address
+	for owner := range b.owners {
+		if _, ok := b.signatures[owner]; !ok {
+			missing = append(missing, owner)
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		return b.owners[missing[i]] < b.owners[missing[j]]
+	})
+	return missing
+}
+
+// Partials returns the collected partial signatures, in the order of the owners, so that they can be passed
+// to other owners.
+func (b *MultisigBuilder) Partials() []PartialSignature {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	partials := make([]PartialSignature, 0, len(b.signatures))
+	for owner, sig := range b.signatures {
+		partials = append(partials, PartialSignature{Signer: owner, Hash: b.hash, Signature: common.CopyBytes(sig)})
+	}
+	sort.Slice(partials, func(i, j int) bool {
+		return b.owners[partials[i].Signer] < b.owners[partials[j].Signer]
+	})
+	return partials
+}
+
+// Signature returns the signature expected by the multisig account contract: the concatenation of exactly
+// threshold signatures, in the order of the owners passed to NewMultisigBuilder. If more signatures have been
+// collected, those of the first owners are used.
+func (b *MultisigBuilder) Signature() ([]byte, error) {
+	partials := b.Partials()
+	if len(partials) < b.threshold {
+		return nil, fmt.Errorf("%w: %d of %d signatures", ErrMultisigThreshold, len(partials), b.threshold)
+	}
+	signature := make([]byte, 0, b.threshold*crypto.SignatureLength)
+	for _, partial := range partials[:b.threshold] {
+		signature = append(signature, partial.Signature...)
+	}
+	return signature, nil
+}
+
+// MultisigCollector collects partial signatures of the hash from the owners whose signers are not available
+// locally, e.g. from co-signers reachable over the network. It is passed the signatures collected so far and
+// may block until the remaining owners have signed.
+type MultisigCollector func(hash common.Hash, collected []PartialSignature) ([]PartialSignature, error)
+
+// MultisigSigner implements the Signer interface for the n-of-m multisig smart account. The signatures are
+// made by the owners whose signers are available locally, and collected from the remaining owners using
+// MultisigCollector, until the threshold is reached. The resulting signature is the one produced by
+// MultisigBuilder.Signature, which is sent as the custom signature of the EIP-712 transaction.
+//
+// Since the account is a contract, MultisigSigner.PrivateKey returns nil, and the signer can be used only
+// for signing EIP-712 transactions and messages.
+type MultisigSigner struct {
+	account   common.Address
+	owners    []common.Address
+	threshold int
+	signers   []Signer
+	collector MultisigCollector
+	domain    *eip712.Domain
+}
+
+// NewMultisigSigner creates an instance of MultisigSigner for the multisig account with the given owners,
+// at least threshold of which must sign. The owners are given in the order in which the account contract
+// expects their signatures, see NewMultisigBuilder. The signers of the owners available locally are optional.
+func NewMultisigSigner(account common.Address, owners []common.Address, threshold int, chainId int64, signers ...Signer) (*MultisigSigner, error) {
+	// The builder validates the owners and the threshold.
+	if _, err := NewMultisigBuilder(common.Hash{}, owners, threshold); err != nil {
+		return nil, err
+	}
+	for _, signer := range signers {
+		if !containsAddress(owners, signer.Address()) {
+			return nil, fmt.Errorf("%s is not owner of multisig account", signer.Address())
+		}
+	}
+	return &MultisigSigner{
+		account:   account,
+		owners:    append([]common.Address{}, owners...),
+		threshold: threshold,
+		signers:   signers,
+		domain:    eip712.ZkSyncEraEIP712Domain(chainId),
+	}, nil
+}
+
+// SetCollector sets the collector of signatures of the owners whose signers are not available locally.
+// If the collector is nil, only the local signers are used.
+func (s *MultisigSigner) SetCollector(collector MultisigCollector) {
+	s.collector = collector
+}
+
+// Owners returns the owners of the multisig account.
+func (s *MultisigSigner) Owners() []common.Address {
+	return append([]common.Address{}, s.owners...)
+}
+
+// Threshold returns the number of owners which must sign.
+func (s *MultisigSigner) Threshold() int {
+	return s.threshold
+}
+
+// NewBuilder creates the builder aggregating the partial signatures of the hash.
+func (s *MultisigSigner) NewBuilder(hash common.Hash) *MultisigBuilder {
+	b, _ := NewMultisigBuilder(hash, s.owners, s.threshold)
+	return b
+}
+
+func (s *MultisigSigner) Address() common.Address {
+	return s.account
+}
+
+func (s *MultisigSigner) Domain() *eip712.Domain {
+	return s.domain
+}
+
+func (s *MultisigSigner) PrivateKey() *ecdsa.PrivateKey {
+	return nil
+}
+
+// SignHash returns the aggregated signature of the hash made by the local signers and the collector.
+func (s *MultisigSigner) SignHash(msg []byte) ([]byte, error) {
+	if len(msg) != common.HashLength {
+		return nil, fmt.Errorf("invalid length of hash: %d", len(msg))
+	}
+	b := s.NewBuilder(common.BytesToHash(msg))
+	for _, signer := range s.signers {
+		if b.Ready() {
+			break
+		}
+		partial, err := SignPartial(signer, b.Hash())
+		if err != nil {
+			return nil, err
+		}
+		if err = b.Add(*partial); err != nil {
+			return nil, err
+		}
+	}
+	if !b.Ready() && s.collector != nil {
+		collected, err := s.collector(b.Hash(), b.Partials())
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect multisig signatures: %w", err)
+		}
+		for _, partial := range collected {
+			if err = b.Add(partial); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return b.Signature()
+}
+
+func (s *MultisigSigner) SignTypedData(domain *eip712.Domain, data eip712.TypedData) ([]byte, error) {
+	hash, err := eip712.HashTypedData(domain, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hash of typed data: %w", err)
+	}
+	return s.SignHash(hash)
+}
+
+func containsAddress(addresses []common.Address, address common.Address) bool {
+	for _, a := range addresses {
+		if a == address {
+			return true
+		}
+	}
+	return false
+}
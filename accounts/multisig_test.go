@@ -0,0 +1,63 @@
+package accounts
+
+import (
+	"bytes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"reflect"
+	"testing"
+)
+
+func TestMultisigBuilderOwnerOrder(t *testing.T) {
+	hash := crypto.Keccak256Hash([]byte("multisig"))
+	var (
+		signers []*BaseSigner
+		owners  []common.Address
+	)
+	for i := 0; i < 3; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		signer, err := NewBaseSignerFromRawPrivateKey(crypto.FromECDSA(key), 270)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signers = append(signers, signer)
+		owners = append(owners, signer.Address())
+	}
+	// The owners are in the order of the contract rather than of their addresses.
+	if bytes.Compare(owners[0].Bytes(), owners[2].Bytes()) < 0 {
+		owners[0], owners[2] = owners[2], owners[0]
+		signers[0], signers[2] = signers[2], signers[0]
+	}
+
+	b, err := NewMultisigBuilder(hash, owners, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	partials := make([]*PartialSignature, len(signers))
+	for _, i := range []int{2, 0} {
+		if partials[i], err = SignPartial(signers[i], hash); err != nil {
+			t.Fatal(err)
+		}
+		if err = b.Add(*partials[i]); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	if got, want := b.Missing(), []common.Address{owners[1]}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Missing() = %v, want %v", got, want)
+	}
+	if got := b.Partials(); len(got) != 2 || got[0].Signer != owners[0] || got[1].Signer != owners[2] {
+		t.Errorf("Partials() = %v, want signatures of %s and %s", got, owners[0], owners[2])
+	}
+	signature, err := b.Signature()
+	if err != nil {
+		t.Fatalf("Signature() error = %v", err)
+	}
+	want := append(append([]byte{}, partials[0].Signature...), partials[2].Signature...)
+	if !bytes.Equal(signature, want) {
+		t.Errorf("Signature() = %x, want %x", signature, want)
+	}
+}
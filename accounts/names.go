@@ -0,0 +1,32 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/zksync-sdk/zksync2-go/ens"
+)
+
+// ErrNameResolverNotConfigured is returned when the transaction specifies the recipient by name,
+// but the wallet has no name resolver, e.g. because it is not connected to L1.
+var ErrNameResolverNotConfigured = errors.New("name resolver is not configured")
+
+// resolveRecipient resolves the name of the recipient into the address, if the name is provided.
+func resolveRecipient(ctx context.Context, resolver ens.NameResolver, name string, to *common.Address) error {
+	if name == "" {
+		return nil
+	}
+	if resolver == nil {
+		return fmt.Errorf("%w: cannot resolve %s", ErrNameResolverNotConfigured, name)
+	}
+	address, err := resolver.ResolveName(ensureContext(ctx), name)
+	if err != nil {
+		return err
+	}
+	if *to != (common.Address{}) && *to != address {
+		return fmt.Errorf("name %s resolves to %s, which differs from the specified recipient %s", name, address, *to)
+	}
+	*to = address
+	return nil
+}
@@ -0,0 +1,168 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/zksync-sdk/zksync2-go/contracts/erc1155"
+	"github.com/zksync-sdk/zksync2-go/contracts/erc721"
+	"math/big"
+)
+
+// TransferNFTTransaction represents a transfer of ERC721 token on L2 initiated by the account associated
+// with AdapterL2.
+type TransferNFTTransaction struct {
+	To      common.Address // The address of the recipient.
+	Token   common.Address // The address of the ERC721 contract.
+	TokenID *big.Int       // The ID of the token to transfer.
+	Data    []byte         // Data passed to the recipient contract in onERC721Received. Optional.
+}
+
+// Transfer1155Transaction represents a transfer of ERC1155 token on L2 initiated by the account associated
+// with AdapterL2.
+type Transfer1155Transaction struct {
+	To     common.Address // The address of the recipient.
+	Token  common.Address // The address of the ERC1155 contract.
+	ID     *big.Int       // The ID of the token to transfer.
+	Amount *big.Int       // The amount of the token to transfer.
+	Data   []byte         // Data passed to the recipient contract in onERC1155Received. Optional.
+}
+
+// BatchTransfer1155Transaction represents a transfer of multiple ERC1155 tokens of the same contract on L2
+// initiated by the account associated with AdapterL2.
+type BatchTransfer1155Transaction struct {
+	To      common.Address // The address of the recipient.
+	Token   common.Address // The address of the ERC1155 contract.
+	IDs     []*big.Int     // The IDs of the tokens to transfer.
+	Amounts []*big.Int     // The amounts of the tokens to transfer, in the order of IDs.
+	Data    []byte         // Data passed to the recipient contract in onERC1155BatchReceived. Optional.
+}
+
+func (a *WalletL2) TransferNFT(auth *TransactOpts, tx TransferNFTTransaction) (*types.Transaction, error) {
+	token, err := erc721.NewIERC721(tx.Token, *a.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load IERC721: %w", err)
+	}
+	return a.transactContract(auth, tx.To, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		if len(tx.Data) > 0 {
+			return token.SafeTransferFrom0(opts, a.Address(), tx.To, tx.TokenID, tx.Data)
+		}
+		return token.SafeTransferFrom(opts, a.Address(), tx.To, tx.TokenID)
+	})
+}
+
+func (a *WalletL2) SafeTransfer1155(auth *TransactOpts, tx Transfer1155Transaction) (*types.Transaction, error) {
+	token, err := erc1155.NewIERC1155(tx.Token, *a.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load IERC1155: %w", err)
+	}
+	return a.transactContract(auth, tx.To, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return token.SafeTransferFrom(opts, a.Address(), tx.To, tx.ID, tx.Amount, nonNilBytes(tx.Data))
+	})
+}
+
+func (a *WalletL2) SafeBatchTransfer1155(auth *TransactOpts, tx BatchTransfer1155Transaction) (*types.Transaction, error) {
+	if len(tx.IDs) == 0 {
+		return nil, errors.New("no tokens provided")
+	}
+	if len(tx.IDs) != len(tx.Amounts) {
+		return nil, errors.New("IDs and amounts must have the same length")
+	}
+	token, err := erc1155.NewIERC1155(tx.Token, *a.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load IERC1155: %w", err)
+	}
+	return a.transactContract(auth, tx.To, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return token.SafeBatchTransferFrom(opts, a.Address(), tx.To, tx.IDs, tx.Amounts, nonNilBytes(tx.Data))
+	})
+}
+
+func (a *WalletL2) OwnerOfNFT(ctx context.Context, token common.Address, tokenID *big.Int) (common.Address, error) {
+	contract, err := erc721.NewIERC721Caller(token, *a.client)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to load IERC721: %w", err)
+	}
+	return contract.OwnerOf(&bind.CallOpts{Context: ensureContext(ctx)}, tokenID)
+}
+
+func (a *WalletL2) BalanceNFT(ctx context.Context, token common.Address) (*big.Int, error) {
+	contract, err := erc721.NewIERC721Caller(token, *a.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load IERC721: %w", err)
+	}
+	return contract.BalanceOf(&bind.CallOpts{Context: ensureContext(ctx)}, a.Address())
+}
+
+func (a *WalletL2) Balance1155(ctx context.Context, token common.Address, id *big.Int) (*big.Int, error) {
+	contract, err := erc1155.NewIERC1155Caller(token, *a.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load IERC1155: %w", err)
+	}
+	return contract.BalanceOf(&bind.CallOpts{Context: ensureContext(ctx)}, a.Address(), id)
+}
+
+func (a *WalletL2) ApproveNFT(auth *TransactOpts, token, spender common.Address, tokenID *big.Int) (*types.Transaction, error) {
+	contract, err := erc721.NewIERC721(token, *a.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load IERC721: %w", err)
+	}
+	return a.transactContract(auth, common.Address{}, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return contract.Approve(opts, spender, tokenID)
+	})
+}
+
+func (a *WalletL2) IsApprovedForAll(ctx context.Context, token, operator common.Address) (bool, error) {
+	// The function has the same signature in both ERC721 and ERC1155 standards.
+	contract, err := erc721.NewIERC721Caller(token, *a.client)
+	if err != nil {
+		return false, fmt.Errorf("failed to load IERC721: %w", err)
+	}
+	return contract.IsApprovedForAll(&bind.CallOpts{Context: ensureContext(ctx)}, a.Address(), operator)
+}
+
+func (a *WalletL2) SetApprovalForAll(auth *TransactOpts, token, operator common.Address, approved bool) (*types.Transaction, error) {
+	// The function has the same signature in both ERC721 and ERC1155 standards.
+	contract, err := erc721.NewIERC721(token, *a.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load IERC721: %w", err)
+	}
+	return a.transactContract(auth, common.Address{}, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return contract.SetApprovalForAll(opts, operator, approved)
+	})
+}
+
+// transactContract sends the transaction of the contract, checking the recipient using the recipient guard,
+// if the recipient is provided, and reserving the nonce using the nonce manager of the wallet.
+func (a *WalletL2) transactContract(auth *TransactOpts, recipient common.Address,
+	send func(opts *bind.TransactOpts) (*types.Transaction, error)) (_ *types.Transaction, err error) {
+	// Options are copied, so that the reserved nonce is not stored in the provided ones.
+	opts := *ensureTransactOpts(auth)
+	if recipient != (common.Address{}) {
+		if err := a.checkRecipient(opts.Context, recipient); err != nil {
+			return nil, err
+		}
+		defer func() { a.recordRecipient(recipient, err) }()
+	}
+	reserved, err := a.reserveNonce(opts.Context, &opts.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { a.settleNonce(opts.Context, reserved, opts.Nonce, err) }()
+	opts.Value = big.NewInt(0)
+	transactOpts, release, err := a.transactOpts(&opts)
+	if err != nil {
+		return nil, err
+	}
+	return a.hooks.sentL2(release.sent(send(transactOpts)))
+}
+
+// nonNilBytes returns the data, or empty data if it is nil.
+func nonNilBytes(data []byte) []byte {
+	if data == nil {
+		return []byte{}
+	}
+	return data
+}
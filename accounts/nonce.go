@@ -0,0 +1,180 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"sort"
+	"sync"
+)
+
+// NonceManager assigns nonces to transactions sent on L2 network, allowing many transactions of the same
+// account to be sent concurrently without waiting for the previous ones to be included in a block.
+// The LocalNonceManager tracks nonces in memory, which is suitable for a single process. Services running
+// in multiple instances which share an account should provide a distributed implementation, e.g. one backed
+// by Redis, which reserves nonces atomically across instances.
+type NonceManager interface {
+	// Next reserves and returns the next nonce of the account.
+	Next(ctx context.Context, account common.Address) (uint64, error)
+	// Release returns the reserved nonce which has not been used, because the transaction has not been sent,
+	// so that it can be reserved again and no gap is left in the sequence of account nonces.
+	Release(ctx context.Context, account common.Address, nonce uint64) error
+	// Reset discards the tracked state of the account, so that it is synchronized with the network on the next
+	// reservation. It should be used when transactions have been dropped by the network.
+	Reset(ctx context.Context, account common.Address) error
+}
+
+// NonceSource provides the nonces of accounts from the network.
+type NonceSource interface {
+	// PendingNonceAt returns the account nonce in the pending state.
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}
+
+// LocalNonceManager implements the NonceManager interface by tracking the nonces in memory.
+// On each reservation, the tracked state is synchronized with the network, so that transactions sent
+// outside the manager are taken into account. Released nonces are reserved again before new ones,
+// healing gaps left by transactions which failed to be sent. It is safe for concurrent use.
+type LocalNonceManager struct {
+	source NonceSource
+
+	mu       sync.Mutex
+	accounts map[common.Address]*nonceState
+}
+
+type nonceState struct {
+	next     uint64   // The next nonce which has never been reserved.
+	released []uint64 // Released nonces lower than next, in ascending order.
+}
+
+// NewLocalNonceManager creates an instance of LocalNonceManager which fetches the nonces from the given source,
+// e.g. clients.Client.
+func NewLocalNonceManager(source NonceSource) *LocalNonceManager {
+	return &LocalNonceManager{
+		source:   source,
+		accounts: make(map[common.Address]*nonceState),
+	}
+}
+
+func (m *LocalNonceManager) Next(ctx context.Context, account common.Address) (uint64, error) {
+	networkNonce, err := m.source.PendingNonceAt(ctx, account)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get nonce: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state, ok := m.accounts[account]
+	if !ok {
+		state = &nonceState{next: networkNonce}
+		m.accounts[account] = state
+	}
+	// Nonces lower than the network one have been used, either by transactions sent outside
+	// the manager, or by transactions which have been sent despite reported failure.
+	if networkNonce > state.next {
+		state.next = networkNonce
+	}
+	i := sort.Search(len(state.released), func(i int) bool { return state.released[i] >= networkNonce })
+	state.released = state.released[i:]
+
+	if len(state.released) > 0 {
+		nonce := state.released[0]
+		state.released = state.released[1:]
+		return nonce, nil
+	}
+	nonce := state.next
+	state.next++
+	return nonce, nil
+}
+
+func (m *LocalNonceManager) Release(_ context.Context, account common.Address, nonce uint64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state, ok := m.accounts[account]
+	if !ok || nonce >= state.next {
+		return nil
+	}
+	if nonce == state.next-1 {
+		state.next--
+		// Trailing released nonces can be issued in order again.
+		for len(state.released) > 0 && state.released[len(state.released)-1] == state.next-1 {
+			state.released = state.released[:len(state.released)-1]
+			state.next--
+		}
+		return nil
+	}
+	i := sort.Search(len(state.released), func(i int) bool { return state.released[i] >= nonce })
+	if i < len(state.released) && state.released[i] == nonce {
+		return nil
+	}
+	state.released = append(state.released, 0)
+	copy(state.released[i+1:], state.released[i:])
+	state.released[i] = nonce
+	return nil
+}
+
+// Claim marks the nonce reserved outside the manager as reserved, so that it is not issued by Next, e.g. the nonce
+// of the transaction recorded by TxManager.SendIdempotent before the restart. The nonces between the network nonce
+// and the claimed one are issued by Next before new ones, so that no gap is left.
+func (m *LocalNonceManager) Claim(ctx context.Context, account common.Address, nonce uint64) error {
+	networkNonce, err := m.source.PendingNonceAt(ctx, account)
+	if err != nil {
+		return fmt.Errorf("failed to get nonce: %w", err)
+	}
+	if nonce < networkNonce {
+		// The nonce has been used, so it is not issued anyway.
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state, ok := m.accounts[account]
+	if !ok {
+		state = &nonceState{next: networkNonce}
+		m.accounts[account] = state
+	}
+	if networkNonce > state.next {
+		state.next = networkNonce
+	}
+	if nonce >= state.next {
+		for n := state.next; n < nonce; n++ {
+			state.released = append(state.released, n)
+		}
+		state.next = nonce + 1
+		return nil
+	}
+	i := sort.Search(len(state.released), func(i int) bool { return state.released[i] >= nonce })
+	if i < len(state.released) && state.released[i] == nonce {
+		state.released = append(state.released[:i], state.released[i+1:]...)
+	}
+	return nil
+}
+
+func (m *LocalNonceManager) Reset(_ context.Context, account common.Address) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.accounts, account)
+	return nil
+}
+
+// reserveNonce reserves the nonce using the nonce manager of the wallet, if the nonce is not already set
+// and the nonce manager is configured. It reports whether the nonce has been reserved.
+func (a *WalletL2) reserveNonce(ctx context.Context, nonce **big.Int) (bool, error) {
+	if a.nonceManager == nil || *nonce != nil {
+		return false, nil
+	}
+	n, err := a.nonceManager.Next(ctx, a.Address())
+	if err != nil {
+		return false, err
+	}
+	*nonce = new(big.Int).SetUint64(n)
+	return true, nil
+}
+
+// settleNonce releases the reserved nonce if the transaction has not been sent due to the error.
+func (a *WalletL2) settleNonce(ctx context.Context, reserved bool, nonce *big.Int, err error) {
+	if reserved && err != nil {
+		// The release error is ignored in favor of the error which caused the release.
+		_ = a.nonceManager.Release(ctx, a.Address(), nonce.Uint64())
+	}
+}
@@ -0,0 +1,154 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"testing"
+)
+
+// pendingNonce is the NonceSource returning the same pending nonce of all accounts.
+type pendingNonce uint64
+
+func (n pendingNonce) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	return uint64(n), nil
+}
+
+func TestWalletL2SettleNonce(t *testing.T) {
+	sendErr := errors.New("failed to send transaction")
+	tests := []struct {
+		name         string
+		nonceManager bool
+		nonce        *big.Int // The nonce set by the caller.
+		err          error    // The error the transaction is settled with.
+		wantReserved bool
+		wantNonce    uint64 // The nonce of the transaction.
+		wantNext     uint64 // The nonce reserved by the following transaction.
+	}{
+		{name: "sent", nonceManager: true, wantReserved: true, wantNonce: 5, wantNext: 6},
+		{name: "failed", nonceManager: true, err: sendErr, wantReserved: true, wantNonce: 5, wantNext: 5},
+		{name: "nonce set by caller", nonceManager: true, nonce: big.NewInt(7), err: sendErr, wantNonce: 7, wantNext: 5},
+		{name: "no nonce manager", nonce: big.NewInt(7), err: sendErr, wantNonce: 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			a := &WalletL2{auth: &bind.TransactOpts{From: common.HexToAddress("0x36615Cf349d7F6344891B1e7CA7C72883F5dc049")}}
+			if tt.nonceManager {
+				a.nonceManager = NewLocalNonceManager(pendingNonce(5))
+			}
+			nonce := tt.nonce
+			reserved, err := a.reserveNonce(ctx, &nonce)
+			if err != nil {
+				t.Fatalf("reserveNonce() error = %v", err)
+			}
+			if reserved != tt.wantReserved {
+				t.Errorf("reserveNonce() reserved = %t, want %t", reserved, tt.wantReserved)
+			}
+			if nonce == nil || nonce.Uint64() != tt.wantNonce {
+				t.Errorf("reserveNonce() nonce = %v, want %d", nonce, tt.wantNonce)
+			}
+			a.settleNonce(ctx, reserved, nonce, tt.err)
+			if a.nonceManager == nil {
+				return
+			}
+			next, err := a.nonceManager.Next(ctx, a.Address())
+			if err != nil {
+				t.Fatalf("Next() error = %v", err)
+			}
+			if next != tt.wantNext {
+				t.Errorf("Next() = %d, want %d", next, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestLocalNonceManagerRelease(t *testing.T) {
+	account := common.HexToAddress("0x36615Cf349d7F6344891B1e7CA7C72883F5dc049")
+	tests := []struct {
+		name     string
+		reserved int      // Number of nonces reserved before the release, starting from 0.
+		released []uint64 // Nonces released, in the order.
+		want     []uint64 // Nonces reserved after the release.
+	}{
+		{name: "last nonce", reserved: 3, released: []uint64{2}, want: []uint64{2, 3}},
+		{name: "gap", reserved: 3, released: []uint64{1}, want: []uint64{1, 3}},
+		{name: "gaps healed in order", reserved: 4, released: []uint64{2, 0}, want: []uint64{0, 2, 4}},
+		{name: "trailing nonces", reserved: 4, released: []uint64{2, 3}, want: []uint64{2, 3, 4}},
+		{name: "released twice", reserved: 3, released: []uint64{1, 1}, want: []uint64{1, 3}},
+		{name: "not reserved", reserved: 2, released: []uint64{5}, want: []uint64{2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			m := NewLocalNonceManager(pendingNonce(0))
+			for i := 0; i < tt.reserved; i++ {
+				if _, err := m.Next(ctx, account); err != nil {
+					t.Fatalf("Next() error = %v", err)
+				}
+			}
+			for _, nonce := range tt.released {
+				if err := m.Release(ctx, account, nonce); err != nil {
+					t.Fatalf("Release() error = %v", err)
+				}
+			}
+			for i, want := range tt.want {
+				got, err := m.Next(ctx, account)
+				if err != nil {
+					t.Fatalf("Next() error = %v", err)
+				}
+				if got != want {
+					t.Errorf("Next() #%d = %d, want %d", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLocalNonceManagerClaim(t *testing.T) {
+	account := common.HexToAddress("0x36615Cf349d7F6344891B1e7CA7C72883F5dc049")
+	tests := []struct {
+		name     string
+		network  uint64   // Pending nonce of the network.
+		reserved int      // Number of nonces reserved before the claim, starting from the network nonce.
+		released []uint64 // Nonces released before the claim.
+		claimed  uint64
+		want     []uint64 // Nonces reserved after the claim.
+	}{
+		{name: "next nonce", network: 3, claimed: 3, want: []uint64{4, 5}},
+		{name: "gap filled first", network: 3, claimed: 5, want: []uint64{3, 4, 6}},
+		{name: "used nonce", network: 3, claimed: 1, want: []uint64{3, 4}},
+		{name: "released nonce", network: 0, reserved: 3, released: []uint64{1}, claimed: 1, want: []uint64{3}},
+		{name: "reserved nonce", network: 0, reserved: 2, claimed: 0, want: []uint64{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			m := NewLocalNonceManager(pendingNonce(tt.network))
+			for i := 0; i < tt.reserved; i++ {
+				if _, err := m.Next(ctx, account); err != nil {
+					t.Fatalf("Next() error = %v", err)
+				}
+			}
+			for _, nonce := range tt.released {
+				if err := m.Release(ctx, account, nonce); err != nil {
+					t.Fatalf("Release() error = %v", err)
+				}
+			}
+			if err := m.Claim(ctx, account, tt.claimed); err != nil {
+				t.Fatalf("Claim() error = %v", err)
+			}
+			for i, want := range tt.want {
+				got, err := m.Next(ctx, account)
+				if err != nil {
+					t.Fatalf("Next() error = %v", err)
+				}
+				if got != want {
+					t.Errorf("Next() #%d = %d, want %d", i, got, want)
+				}
+			}
+		})
+	}
+}
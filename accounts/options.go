@@ -0,0 +1,187 @@
+package accounts
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pkg/errors"
+	"github.com/zksync-sdk/zksync2-go/clients"
+	"github.com/zksync-sdk/zksync2-go/ens"
+	"log/slog"
+)
+
+// SignerOptions contains options used to create a BaseSigner. Exactly one source of the account
+// must be provided: PrivateKey, Mnemonic, Keystore or Random.
+type SignerOptions struct {
+	PrivateKey []byte // Raw private key of the account.
+
+	Mnemonic       string // BIP-39 mnemonic phrase from which the account is derived.
+	DerivationPath string // BIP-44 derivation path used with Mnemonic. Defaults to DefaultDerivationPath.
+
+	Keystore []byte // Encrypted keystore (Web3 Secret Storage V3) JSON of the account.
+	Password string // Password used to decrypt the Keystore.
+
+	Random bool // Whether to generate an account with random private key.
+
+	// ChainID of the L2 network, used in the EIP-712 domain. When creating a wallet it is optional,
+	// if not provided, it is fetched using WalletOptions.ClientL2.
+	ChainID int64
+}
+
+// WalletOptions contains options used to create a Wallet. The account is either provided by the Signer,
+// or created from the embedded SignerOptions.
+type WalletOptions struct {
+	Signer Signer // Signer of the account. If provided, SignerOptions are ignored.
+	SignerOptions
+
+	// Client of the L2 network. It is optional if the Signer or SignerOptions.ChainID are provided,
+	// in which case the wallet can be connected later using Wallet.Connect.
+	ClientL2 clients.Client
+	// Client of the L1 network. Optional, the wallet can be connected later using Wallet.ConnectL1.
+	ClientL1 *ethclient.Client
+
+	PaymasterPolicy PaymasterPolicy // Policy enforced on paymasters used by transactions. Optional.
+	NonceManager    NonceManager    // Manager assigning nonces to L2 transactions. Optional.
+	MinimumAmounts  *MinimumAmounts // Minimum amounts of L2 transfers and withdrawals. Optional.
+	RecipientGuard  RecipientGuard  // Guard checking recipients of L2 transfers and withdrawals. Optional.
+	BridgeMetrics   BridgeMetrics   // Metrics receiving latencies of withdrawals. Optional.
+	PaymasterGuard  PaymasterGuard  // Guard limiting fees sponsored by paymasters. Optional.
+	SpendingGuard   SpendingGuard   // Guard limiting transactions signed by the wallet. Optional.
+	Hooks           *Hooks          // Callbacks invoked on the lifecycle of transactions. Optional.
+	// Provider of paymaster parameters of L2 transactions, e.g. a gas sponsorship service. Optional.
+	PaymasterParamsProvider PaymasterParamsProvider
+	// Resolver providing the bytecode of contracts created by deployed contracts, e.g. artifacts.Set. Optional.
+	FactoryDepsResolver FactoryDepsResolver
+	// Scaler of the gas limits estimated by the wallet. Optional, the estimations are used as is by default.
+	GasScaler *GasScaler
+	// Oracle providing the gas price of transactions. Optional, the gas price suggested by the nodes is used by default.
+	GasOracle GasOracle
+	// Resolver of the recipient names. Optional, ENS on L1 is used by default if ClientL1 is provided.
+	NameResolver ens.NameResolver
+	// Whether to simulate L2 transactions before they are sent, see WalletL2.SetPreflight. Optional.
+	Preflight bool
+	// Logger receiving the lifecycle of transactions sent by the wallet, see Hooks.LogTo. Optional.
+	Logger *slog.Logger
+}
+
+// derefClient returns the client the pointer points to, or nil if the pointer is nil.
+func derefClient(client *clients.Client) clients.Client {
+	if client == nil {
+		return nil
+	}
+	return *client
+}
+
+// NewSignerWithOptions creates an instance of BaseSigner using the provided options.
+func NewSignerWithOptions(opts SignerOptions) (*BaseSigner, error) {
+	sources := 0
+	for _, provided := range []bool{len(opts.PrivateKey) > 0, opts.Mnemonic != "", len(opts.Keystore) > 0, opts.Random} {
+		if provided {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return nil, errors.New("exactly one of private key, mnemonic, keystore or random must be provided")
+	}
+
+	switch {
+	case len(opts.PrivateKey) > 0:
+		return NewBaseSignerFromRawPrivateKey(opts.PrivateKey, opts.ChainID)
+	case opts.Mnemonic != "":
+		derivationPath := opts.DerivationPath
+		if derivationPath == "" {
+			derivationPath = DefaultDerivationPath
+		}
+		master, err := NewHDNodeFromMnemonic(opts.Mnemonic)
+		if err != nil {
+			return nil, err
+		}
+		node, err := master.DerivePath(derivationPath)
+		if err != nil {
+			return nil, err
+		}
+		return node.Signer(opts.ChainID)
+	case len(opts.Keystore) > 0:
+		return NewBaseSignerFromKeystore(opts.Keystore, opts.Password, opts.ChainID)
+	default:
+		return NewRandomBaseSigner(opts.ChainID)
+	}
+}
+
+// NewWalletWithOptions creates an instance of Wallet using the provided options.
+func NewWalletWithOptions(opts WalletOptions) (*Wallet, error) {
+	var clientL2 *clients.Client
+	if opts.ClientL2 != nil {
+		clientL2 = &opts.ClientL2
+	}
+
+	signer := opts.Signer
+	if signer == nil {
+		signerOpts := opts.SignerOptions
+		if signerOpts.ChainID == 0 {
+			if clientL2 == nil {
+				return nil, errors.New("either chain ID or L2 client must be provided")
+			}
+			chainID, err := opts.ClientL2.ChainID(context.Background())
+			if err != nil {
+				return nil, err
+			}
+			signerOpts.ChainID = chainID.Int64()
+		}
+		baseSigner, err := NewSignerWithOptions(signerOpts)
+		if err != nil {
+			return nil, err
+		}
+		signer = baseSigner
+	}
+
+	wallet, err := NewWalletFromSigner(&signer, clientL2, opts.ClientL1)
+	if err != nil {
+		return nil, err
+	}
+	if opts.PaymasterPolicy != nil {
+		wallet.SetPaymasterPolicy(opts.PaymasterPolicy)
+	}
+	if opts.NonceManager != nil {
+		wallet.SetNonceManager(opts.NonceManager)
+	}
+	if opts.MinimumAmounts != nil {
+		wallet.SetMinimumAmounts(opts.MinimumAmounts)
+	}
+	if opts.RecipientGuard != nil {
+		wallet.SetRecipientGuard(opts.RecipientGuard)
+	}
+	if opts.BridgeMetrics != nil {
+		wallet.SetBridgeMetrics(opts.BridgeMetrics)
+	}
+	if opts.PaymasterGuard != nil {
+		wallet.SetPaymasterGuard(opts.PaymasterGuard)
+	}
+	if opts.SpendingGuard != nil {
+		wallet.SetSpendingGuard(opts.SpendingGuard)
+	}
+	if opts.Hooks != nil {
+		wallet.SetHooks(opts.Hooks)
+	}
+	if opts.PaymasterParamsProvider != nil {
+		wallet.SetPaymasterParamsProvider(opts.PaymasterParamsProvider)
+	}
+	if opts.FactoryDepsResolver != nil {
+		wallet.SetFactoryDepsResolver(opts.FactoryDepsResolver)
+	}
+	if opts.GasScaler != nil {
+		wallet.SetGasScaler(opts.GasScaler)
+	}
+	if opts.GasOracle != nil {
+		wallet.SetGasOracle(opts.GasOracle)
+	}
+	if opts.NameResolver != nil {
+		wallet.SetNameResolver(opts.NameResolver)
+	}
+	if opts.Preflight {
+		wallet.SetPreflight(true)
+	}
+	if opts.Logger != nil {
+		wallet.Hooks().LogTo(opts.Logger)
+	}
+	return wallet, nil
+}
@@ -0,0 +1,194 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"math/big"
+	"sync"
+)
+
+// ErrUntrustedPaymaster is returned when a transaction is configured to use a paymaster
+// which is rejected by the PaymasterPolicy of the wallet.
+var ErrUntrustedPaymaster = errors.New("untrusted paymaster")
+
+// PaymasterPolicy decides whether the paymaster can be used by transactions sent from the wallet.
+type PaymasterPolicy interface {
+	// CheckPaymaster returns an error if the transaction must not use the given paymaster parameters.
+	CheckPaymaster(ctx context.Context, params *zkTypes.PaymasterParams) error
+}
+
+// PaymasterReputationFunc consults an external reputation service and reports whether the paymaster
+// can be trusted. It is used by PaymasterRegistry for paymasters that are not in the allow-list.
+type PaymasterReputationFunc func(ctx context.Context, paymaster common.Address) (bool, error)
+
+// PaymasterRegistry implements PaymasterPolicy using an allow-list of trusted paymasters and an optional
+// reputation check. A paymaster is accepted if it is in the allow-list or, when the paymaster is not in the
+// allow-list, the reputation check reports it as trusted. It is safe for concurrent use.
+type PaymasterRegistry struct {
+	mu         sync.RWMutex
+	trusted    map[common.Address]struct{}
+	reputation PaymasterReputationFunc
+}
+
+// NewPaymasterRegistry creates an instance of PaymasterRegistry with the given trusted paymasters.
+func NewPaymasterRegistry(trusted ...common.Address) *PaymasterRegistry {
+	r := &PaymasterRegistry{trusted: make(map[common.Address]struct{}, len(trusted))}
+	for _, paymaster := range trusted {
+		r.trusted[paymaster] = struct{}{}
+	}
+	return r
+}
+
+// Trust adds the paymasters to the allow-list.
+func (r *PaymasterRegistry) Trust(paymasters ...common.Address) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, paymaster := range paymasters {
+		r.trusted[paymaster] = struct{}{}
+	}
+}
+
+// Revoke removes the paymasters from the allow-list.
+func (r *PaymasterRegistry) Revoke(paymasters ...common.Address) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, paymaster := range paymasters {
+		delete(r.trusted, paymaster)
+	}
+}
+
+// IsTrusted reports whether the paymaster is in the allow-list.
+func (r *PaymasterRegistry) IsTrusted(paymaster common.Address) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.trusted[paymaster]
+	return ok
+}
+
+// SetReputationCheck sets the function used to check paymasters which are not in the allow-list.
+// If it is not set, only paymasters in the allow-list are accepted.
+func (r *PaymasterRegistry) SetReputationCheck(check PaymasterReputationFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reputation = check
+}
+
+func (r *PaymasterRegistry) CheckPaymaster(ctx context.Context, params *zkTypes.PaymasterParams) error {
+	if params == nil {
+		return nil
+	}
+	if r.IsTrusted(params.Paymaster) {
+		return nil
+	}
+	r.mu.RLock()
+	reputation := r.reputation
+	r.mu.RUnlock()
+	if reputation == nil {
+		return fmt.Errorf("%w: %s is not in the allow-list", ErrUntrustedPaymaster, params.Paymaster)
+	}
+	trusted, err := reputation(ctx, params.Paymaster)
+	if err != nil {
+		return fmt.Errorf("failed to check reputation of paymaster %s: %w", params.Paymaster, err)
+	}
+	if !trusted {
+		return fmt.Errorf("%w: %s is rejected by reputation check", ErrUntrustedPaymaster, params.Paymaster)
+	}
+	return nil
+}
+
+// ApplyApprovalBasedPaymaster configures the transaction to use the approval-based paymaster, with the minimal
+// allowance computed from the estimated fee of the transaction and the exchange rate of the paymaster, instead
+// of being hard-coded in input.MinimalAllowance. The gas limit and the gas fee cap used to estimate the fee are
+// set to the transaction, so that the allowance covers the fee of the transaction once it is sent.
+func (a *WalletL2) ApplyApprovalBasedPaymaster(ctx context.Context, tx *Transaction, paymaster common.Address,
+	input zkTypes.ApprovalBasedPaymasterInput, rate utils.PaymasterExchangeRate) error {
+	ctx = ensureContext(ctx)
+	meta := zkTypes.Eip712Meta{}
+	if tx.Meta != nil {
+		meta = *tx.Meta
+	}
+	if meta.GasPerPubdata == nil {
+		meta.GasPerPubdata = utils.NewBig(utils.DefaultGasPerPubdataLimit.Int64())
+	}
+	if tx.GasFeeCap == nil {
+		gasFeeCap, err := gasPrice(ctx, a.gasOracle, *a.client)
+		if err != nil {
+			return fmt.Errorf("failed to SuggestGasPrice: %w", err)
+		}
+		tx.GasFeeCap = gasFeeCap
+	}
+	if tx.Gas == 0 {
+		// The allowance is not known until the gas is estimated, so the minimal allowance is used instead,
+		// which does not change the gas consumed by the paymaster.
+		estimation := input
+		estimation.MinimalAllowance = big.NewInt(1)
+		params, err := utils.GetPaymasterParams(paymaster, &estimation)
+		if err != nil {
+			return err
+		}
+		meta.PaymasterParams = params
+		tx.Meta = &meta
+		gas, err := (*a.client).EstimateGasL2(ctx, tx.ToCallMsg(a.Address()))
+		if err != nil {
+			return fmt.Errorf("failed to EstimateGasL2: %w", err)
+		}
+		tx.Gas = gas
+	}
+
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(tx.Gas), tx.GasFeeCap)
+	paymasterInput, err := utils.GetApprovalBasedPaymasterInputForFee(ctx, input, fee, rate)
+	if err != nil {
+		return err
+	}
+	meta.PaymasterParams = &zkTypes.PaymasterParams{Paymaster: paymaster, PaymasterInput: paymasterInput}
+	tx.Meta = &meta
+	return nil
+}
+
+// TransferWithTestnetPaymaster transfers the token using the testnet paymaster, so that the fee is paid in
+// feeToken instead of the base token. The paymaster is discovered using the zks_getTestnetPaymaster method,
+// and the allowance required by the paymaster is computed from the estimated fee at its 1:1 exchange rate.
+// It is available only on networks that provide the testnet paymaster.
+func (a *WalletL2) TransferWithTestnetPaymaster(ctx context.Context, tx TransferTransaction, feeToken common.Address) (_ common.Hash, err error) {
+	ctx = ensureContext(ctx)
+	if a.minimumAmounts != nil {
+		if err := a.minimumAmounts.CheckTransfer(tx.Token, tx.Amount); err != nil {
+			return common.Hash{}, err
+		}
+	}
+	if err := resolveRecipient(ctx, a.nameResolver, tx.ToName, &tx.To); err != nil {
+		return common.Hash{}, err
+	}
+	if err := a.checkRecipient(ctx, tx.To); err != nil {
+		return common.Hash{}, err
+	}
+	defer func() { a.recordRecipient(tx.To, err) }()
+	if tx.Token, err = a.resolveL2Token(ctx, tx.Token); err != nil {
+		return common.Hash{}, err
+	}
+
+	paymaster, err := (*a.client).TestnetPaymaster(ctx)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to get testnet paymaster: %w", err)
+	}
+	if paymaster == (common.Address{}) {
+		return common.Hash{}, errors.New("testnet paymaster is not available")
+	}
+	transferMsg := tx.ToTransferCallMsg(a.Address(), &TransactOpts{})
+	msg, err := transferMsg.ToCallMsg()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	transaction := Transaction{To: msg.To, Value: msg.Value, Data: msg.Data}
+	err = a.ApplyApprovalBasedPaymaster(ctx, &transaction, paymaster,
+		zkTypes.ApprovalBasedPaymasterInput{Token: feeToken, InnerInput: []byte{}},
+		utils.FixedExchangeRate(utils.ExchangeRateScale))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return a.SendTransaction(ctx, &transaction)
+}
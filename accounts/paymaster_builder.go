@@ -0,0 +1,133 @@
+package accounts
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"strings"
+)
+
+// PaymasterBuilder builds the paymaster parameters of the general paymaster flow with an arbitrary inner
+// input, which is encoded and validated using the ABI of the paymaster. Errors are collected while building
+// and returned by Build.
+//
+//	params, err := NewPaymasterBuilder(paymaster).
+//		WithABI(paymasterAbi).
+//		WithMethod("sponsor", campaignID, signature).
+//		Build()
+type PaymasterBuilder struct {
+	paymaster  common.Address
+	abi        *abi.ABI
+	innerInput []byte
+	raw        bool // Whether the inner input is set by WithInnerInput, so that it must be validated.
+	err        error
+}
+
+// NewPaymasterBuilder creates an instance of PaymasterBuilder for the paymaster with an empty inner input.
+func NewPaymasterBuilder(paymaster common.Address) *PaymasterBuilder {
+	return &PaymasterBuilder{paymaster: paymaster, innerInput: []byte{}}
+}
+
+// WithABI registers the JSON ABI of the paymaster, used to encode the inner input by WithMethod and
+// to validate the inner input set by WithInnerInput.
+func (b *PaymasterBuilder) WithABI(paymasterAbi string) *PaymasterBuilder {
+	parsed, err := abi.JSON(strings.NewReader(paymasterAbi))
+	if err != nil {
+		return b.fail(fmt.Errorf("failed to parse paymaster ABI: %w", err))
+	}
+	return b.WithParsedABI(&parsed)
+}
+
+// WithParsedABI registers the ABI of the paymaster, e.g. the one of generated contract bindings.
+func (b *PaymasterBuilder) WithParsedABI(paymasterAbi *abi.ABI) *PaymasterBuilder {
+	b.abi = paymasterAbi
+	return b
+}
+
+// WithMethod sets the inner input to the call of the method of the registered ABI with the given arguments,
+// i.e. the method selector followed by the encoded arguments.
+func (b *PaymasterBuilder) WithMethod(name string, args ...interface{}) *PaymasterBuilder {
+	if b.abi == nil {
+		return b.fail(fmt.Errorf("paymaster ABI is required to encode method %s", name))
+	}
+	input, err := b.abi.Pack(name, args...)
+	if err != nil {
+		return b.fail(fmt.Errorf("failed to encode paymaster method %s: %w", name, err))
+	}
+	b.innerInput, b.raw = input, false
+	return b
+}
+
+// WithArguments sets the inner input to the arguments encoded without the method selector,
+// for paymasters decoding the inner input using abi.decode.
+func (b *PaymasterBuilder) WithArguments(arguments abi.Arguments, values ...interface{}) *PaymasterBuilder {
+	input, err := arguments.Pack(values...)
+	if err != nil {
+		return b.fail(fmt.Errorf("failed to encode paymaster arguments: %w", err))
+	}
+	b.innerInput, b.raw = input, false
+	return b
+}
+
+// WithInnerInput sets the already encoded inner input. If the ABI is registered, the input must be
+// a valid call of one of its methods.
+func (b *PaymasterBuilder) WithInnerInput(input []byte) *PaymasterBuilder {
+	b.innerInput, b.raw = input, true
+	return b
+}
+
+// Build validates the inner input and returns the paymaster parameters of the general paymaster flow.
+func (b *PaymasterBuilder) Build() (*zkTypes.PaymasterParams, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if b.paymaster == (common.Address{}) {
+		return nil, errors.New("paymaster address must be provided")
+	}
+	if b.abi != nil && b.raw {
+		if err := validateInnerInput(b.abi, b.innerInput); err != nil {
+			return nil, err
+		}
+	}
+	input := zkTypes.GeneralPaymasterInput(b.innerInput)
+	return utils.GetPaymasterParams(b.paymaster, &input)
+}
+
+// Apply builds the paymaster parameters and sets them to the transaction, so that it is sent using
+// the paymaster by AdapterL2.SendTransaction.
+func (b *PaymasterBuilder) Apply(tx *Transaction) error {
+	params, err := b.Build()
+	if err != nil {
+		return err
+	}
+	if tx.Meta == nil {
+		tx.Meta = &zkTypes.Eip712Meta{}
+	}
+	tx.Meta.PaymasterParams = params
+	return nil
+}
+
+func (b *PaymasterBuilder) fail(err error) *PaymasterBuilder {
+	if b.err == nil {
+		b.err = err
+	}
+	return b
+}
+
+// validateInnerInput checks that the input is a valid call of a method of the ABI.
+func validateInnerInput(paymasterAbi *abi.ABI, input []byte) error {
+	if len(input) < 4 {
+		return fmt.Errorf("paymaster inner input is too short: %d bytes", len(input))
+	}
+	method, err := paymasterAbi.MethodById(input[:4])
+	if err != nil {
+		return fmt.Errorf("paymaster inner input does not match paymaster ABI: %w", err)
+	}
+	if _, err = method.Inputs.Unpack(input[4:]); err != nil {
+		return fmt.Errorf("paymaster inner input is not valid call of %s: %w", method.Name, err)
+	}
+	return nil
+}
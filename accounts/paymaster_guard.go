@@ -0,0 +1,145 @@
+package accounts
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// ErrSponsoredFeeExceeded is returned when signing of the transaction using a paymaster is rejected
+// by the PaymasterGuard of the wallet.
+var ErrSponsoredFeeExceeded = errors.New("sponsored fee limit exceeded")
+
+// PaymasterGuard limits the fees sponsored by paymasters. It is consulted before the transaction using
+// a paymaster is signed, so that sponsorship budgets are not exhausted by faulty code sending transactions
+// in a loop.
+type PaymasterGuard interface {
+	// ReserveSponsoredFee returns an error if the transaction with the given maximum fee, i.e. the gas limit
+	// multiplied by the maximum fee per gas, must not be sponsored by the paymaster. Otherwise, the fee is
+	// accounted as sponsored, until the returned function releases it.
+	ReserveSponsoredFee(paymaster common.Address, fee *big.Int) (release func(), err error)
+}
+
+// PaymasterGuards composes multiple guards, all of which must accept the sponsored fee.
+type PaymasterGuards []PaymasterGuard
+
+func (g PaymasterGuards) ReserveSponsoredFee(paymaster common.Address, fee *big.Int) (func(), error) {
+	releases := make([]func(), 0, len(g))
+	release := func() {
+		for _, r := range releases {
+			r()
+		}
+	}
+	for _, guard := range g {
+		r, err := guard.ReserveSponsoredFee(paymaster, fee)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		releases = append(releases, r)
+	}
+	return release, nil
+}
+
+// SponsoredFeeLimit is the PaymasterGuard limiting the sponsored fee of a single transaction.
+type SponsoredFeeLimit struct {
+	max *big.Int
+}
+
+// NewSponsoredFeeLimit creates an instance of SponsoredFeeLimit rejecting transactions whose maximum fee
+// exceeds the limit.
+func NewSponsoredFeeLimit(max *big.Int) *SponsoredFeeLimit {
+	return &SponsoredFeeLimit{max: new(big.Int).Set(max)}
+}
+
+func (l *SponsoredFeeLimit) ReserveSponsoredFee(paymaster common.Address, fee *big.Int) (func(), error) {
+	if fee.Cmp(l.max) > 0 {
+		return nil, fmt.Errorf("%w: fee %s sponsored by %s exceeds per-transaction limit %s",
+			ErrSponsoredFeeExceeded, fee, paymaster, l.max)
+	}
+	return func() {}, nil
+}
+
+// SponsoredFeeBudget is the PaymasterGuard limiting the total fee sponsored within a sliding time window.
+// It is safe for concurrent use.
+type SponsoredFeeBudget struct {
+	max    *big.Int
+	window time.Duration
+
+	mu    sync.Mutex
+	spent []*sponsoredFee // Ordered by the time of the reservation.
+}
+
+type sponsoredFee struct {
+	at  time.Time
+	fee *big.Int
+}
+
+// NewSponsoredFeeBudget creates an instance of SponsoredFeeBudget rejecting transactions whose maximum fee,
+// added to the fees sponsored within the window, exceeds the budget.
+func NewSponsoredFeeBudget(max *big.Int, window time.Duration) *SponsoredFeeBudget {
+	return &SponsoredFeeBudget{max: new(big.Int).Set(max), window: window}
+}
+
+func (b *SponsoredFeeBudget) ReserveSponsoredFee(paymaster common.Address, fee *big.Int) (func(), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	total := new(big.Int).Add(b.prune(time.Now()), fee)
+	if total.Cmp(b.max) > 0 {
+		return nil, fmt.Errorf("%w: fee %s sponsored by %s exceeds budget %s per %s",
+			ErrSponsoredFeeExceeded, fee, paymaster, b.max, b.window)
+	}
+	reserved := &sponsoredFee{at: time.Now(), fee: new(big.Int).Set(fee)}
+	b.spent = append(b.spent, reserved)
+	return func() { b.release(reserved) }, nil
+}
+
+// Spent returns the total fee sponsored within the current window.
+func (b *SponsoredFeeBudget) Spent() *big.Int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.prune(time.Now())
+}
+
+// prune removes the fees sponsored before the window and returns the total of the remaining ones.
+func (b *SponsoredFeeBudget) prune(now time.Time) *big.Int {
+	start := now.Add(-b.window)
+	i := 0
+	for i < len(b.spent) && !b.spent[i].at.After(start) {
+		i++
+	}
+	b.spent = b.spent[i:]
+	total := new(big.Int)
+	for _, s := range b.spent {
+		total.Add(total, s.fee)
+	}
+	return total
+}
+
+func (b *SponsoredFeeBudget) release(reserved *sponsoredFee) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, s := range b.spent {
+		if s == reserved {
+			b.spent = append(b.spent[:i], b.spent[i+1:]...)
+			return
+		}
+	}
+}
+
+// reserveSponsoredFee reserves the maximum fee of the transaction using a paymaster with the paymaster guard.
+// The returned function releases the reservation; it is not nil even if nothing has been reserved.
+func (a *WalletL2) reserveSponsoredFee(tx *zkTypes.Transaction712) (func(), error) {
+	if a.paymasterGuard == nil || tx.Meta == nil || tx.Meta.PaymasterParams == nil {
+		return func() {}, nil
+	}
+	if tx.Gas == nil || tx.GasFeeCap == nil {
+		return nil, errors.New("gas limit and fee cap must be set to sign transaction using paymaster")
+	}
+	fee := new(big.Int).Mul(tx.Gas, tx.GasFeeCap)
+	return a.paymasterGuard.ReserveSponsoredFee(tx.Meta.PaymasterParams.Paymaster, fee)
+}
@@ -0,0 +1,143 @@
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"io"
+	"net/http"
+)
+
+// PaymasterParamsProvider provides the paymaster parameters of transactions, e.g. using an external gas
+// sponsorship service. When set on the wallet, it is consulted by AdapterL2.PopulateTransaction for every
+// transaction which does not specify the paymaster, before the fees and the gas limit are prepared.
+//
+// The provider applies to EIP-712 transactions only, i.e. the ones sent using AdapterL2.SendTransaction.
+// AdapterL2.Transfer and AdapterL2.Withdraw send EIP-1559 transactions, which cannot use the paymaster,
+// so they are never sponsored; to sponsor them, send the calldata of the transfer or withdrawal using
+// AdapterL2.SendTransaction instead.
+type PaymasterParamsProvider interface {
+	// Params returns the paymaster parameters of the transaction draft, or nil if the transaction
+	// is not sponsored, in which case the fee is paid by the account.
+	Params(ctx context.Context, txDraft *TransactionDraft) (*zkTypes.PaymasterParams, error)
+}
+
+// PaymasterParamsProviderFunc is an adapter allowing the use of ordinary functions as PaymasterParamsProvider.
+type PaymasterParamsProviderFunc func(ctx context.Context, txDraft *TransactionDraft) (*zkTypes.PaymasterParams, error)
+
+func (f PaymasterParamsProviderFunc) Params(ctx context.Context, txDraft *TransactionDraft) (*zkTypes.PaymasterParams, error) {
+	return f(ctx, txDraft)
+}
+
+// TransactionDraft is the transaction whose paymaster parameters are requested from PaymasterParamsProvider.
+// Fields which are not provided by the transaction are nil.
+type TransactionDraft struct {
+	From    common.Address  `json:"from"`
+	To      *common.Address `json:"to"`
+	Value   *hexutil.Big    `json:"value"`
+	Data    hexutil.Bytes   `json:"data"`
+	ChainID *hexutil.Big    `json:"chainId"`
+	Nonce   *hexutil.Big    `json:"nonce"`
+}
+
+func newTransactionDraft(from common.Address, tx *Transaction) *TransactionDraft {
+	return &TransactionDraft{
+		From:    from,
+		To:      tx.To,
+		Value:   (*hexutil.Big)(tx.Value),
+		Data:    tx.Data,
+		ChainID: (*hexutil.Big)(tx.ChainID),
+		Nonce:   (*hexutil.Big)(tx.Nonce),
+	}
+}
+
+// HTTPPaymasterParamsProvider is the reference implementation of PaymasterParamsProvider, which requests
+// the paymaster parameters from the sponsorship service over HTTP. The transaction draft is sent as JSON
+// in the body of POST request, and the service responds either with the paymaster parameters:
+//
+//	{"paymaster": "0x...", "paymasterInput": "0x..."}
+//
+// or with 204 No Content, when the transaction is not sponsored.
+type HTTPPaymasterParamsProvider struct {
+	URL    string       // URL of the sponsorship service.
+	Header http.Header  // Headers added to each request, e.g. the API key. Optional.
+	Client *http.Client // HTTP client used to send requests. Optional, http.DefaultClient is used by default.
+}
+
+// NewHTTPPaymasterParamsProvider creates an instance of HTTPPaymasterParamsProvider for the sponsorship
+// service at the given URL.
+func NewHTTPPaymasterParamsProvider(url string) *HTTPPaymasterParamsProvider {
+	return &HTTPPaymasterParamsProvider{URL: url, Header: make(http.Header)}
+}
+
+func (p *HTTPPaymasterParamsProvider) Params(ctx context.Context, txDraft *TransactionDraft) (*zkTypes.PaymasterParams, error) {
+	body, err := json.Marshal(txDraft)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ensureContext(ctx), http.MethodPost, p.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range p.Header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request paymaster params: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("sponsorship service responded with %s: %s", resp.Status, bytes.TrimSpace(message))
+	}
+	var params struct {
+		Paymaster      common.Address `json:"paymaster"`
+		PaymasterInput hexutil.Bytes  `json:"paymasterInput"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&params); err != nil {
+		return nil, fmt.Errorf("failed to decode paymaster params: %w", err)
+	}
+	if params.Paymaster == (common.Address{}) {
+		return nil, errors.New("sponsorship service responded without paymaster")
+	}
+	return &zkTypes.PaymasterParams{Paymaster: params.Paymaster, PaymasterInput: params.PaymasterInput}, nil
+}
+
+// providePaymasterParams sets the paymaster parameters provided by the paymaster provider to the transaction,
+// if the transaction does not specify the paymaster.
+func (a *WalletL2) providePaymasterParams(ctx context.Context, tx *Transaction) error {
+	if a.paymasterProvider == nil || tx.Meta != nil && tx.Meta.PaymasterParams != nil {
+		return nil
+	}
+	params, err := a.paymasterProvider.Params(ctx, newTransactionDraft(a.Address(), tx))
+	if err != nil {
+		return fmt.Errorf("failed to get paymaster params: %w", err)
+	}
+	if params == nil {
+		return nil
+	}
+	meta := zkTypes.Eip712Meta{}
+	if tx.Meta != nil {
+		meta = *tx.Meta
+	}
+	meta.PaymasterParams = params
+	tx.Meta = &meta
+	return nil
+}
@@ -0,0 +1,256 @@
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/zksync-sdk/zksync2-go/contracts/paymaster"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"math/big"
+)
+
+// PaymasterFailureReason is the reason why the paymaster would reject the transaction.
+type PaymasterFailureReason string
+
+const (
+	PaymasterNotSet                   PaymasterFailureReason = "paymaster not set"                      // The transaction does not use a paymaster.
+	PaymasterNotContract              PaymasterFailureReason = "paymaster is not a contract"            // There is no contract at the paymaster address.
+	PaymasterInsufficientBalance      PaymasterFailureReason = "insufficient paymaster balance"         // The paymaster cannot pay the fee.
+	PaymasterInsufficientTokenBalance PaymasterFailureReason = "insufficient fee token balance"         // The account cannot pay the paymaster.
+	PaymasterValidationReverted       PaymasterFailureReason = "paymaster validation reverted"          // The paymaster rejected the transaction.
+	PaymasterInvalidMagic             PaymasterFailureReason = "paymaster returned invalid magic value" // The paymaster did not accept the transaction.
+)
+
+// PaymasterValidationError is returned by ValidatePaymaster when the paymaster would reject the transaction.
+type PaymasterValidationError struct {
+	Reason     PaymasterFailureReason
+	Paymaster  common.Address
+	Required   *big.Int      // Required balance, set for the insufficient balance reasons.
+	Available  *big.Int      // Available balance, set for the insufficient balance reasons.
+	RevertData hexutil.Bytes // Data returned by the reverted validation, if provided by the node.
+	Err        error         // Error returned by the simulation of the validation.
+}
+
+func (e *PaymasterValidationError) Error() string {
+	msg := fmt.Sprintf("paymaster %s: %s", e.Paymaster, e.Reason)
+	if e.Required != nil && e.Available != nil {
+		msg = fmt.Sprintf("%s: required %s, available %s", msg, e.Required, e.Available)
+	}
+	if e.Err != nil {
+		msg = fmt.Sprintf("%s: %v", msg, e.Err)
+	}
+	return msg
+}
+
+func (e *PaymasterValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidatePaymaster checks whether the paymaster of the transaction would accept it, so that the transaction
+// is not broadcast only to fail with an opaque validation error. It checks that the paymaster is deployed
+// and its balance covers the fee, that the account holds the fee token required by the approval-based flow,
+// and simulates the validateAndPayForPaymasterTransaction call made by the bootloader. The failure is
+// returned as *PaymasterValidationError.
+//
+// Since the account approves the fee token to the paymaster only during the validation of the transaction,
+// the approval-based flow is simulated by estimating the gas of the whole transaction, unless the paymaster
+// already has sufficient allowance.
+func (a *WalletL2) ValidatePaymaster(ctx context.Context, tx Transaction) error {
+	ctx = ensureContext(ctx)
+	if err := a.providePaymasterParams(ctx, &tx); err != nil {
+		return err
+	}
+	if tx.Meta == nil || tx.Meta.PaymasterParams == nil {
+		return &PaymasterValidationError{Reason: PaymasterNotSet}
+	}
+	params := tx.Meta.PaymasterParams
+	fail := func(reason PaymasterFailureReason) *PaymasterValidationError {
+		return &PaymasterValidationError{Reason: reason, Paymaster: params.Paymaster}
+	}
+
+	code, err := (*a.client).CodeAt(ctx, params.Paymaster, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get paymaster code: %w", err)
+	}
+	if len(code) == 0 {
+		return fail(PaymasterNotContract)
+	}
+
+	approval, err := utils.DecodePaymasterParams(params)
+	if err != nil {
+		return err
+	}
+	allowanceSufficient := true
+	if approval.Flow == utils.PaymasterFlowApprovalBased {
+		balance, err := a.Balance(ctx, approval.Token, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get fee token balance: %w", err)
+		}
+		if balance.Cmp(approval.MinimalAllowance) < 0 {
+			failure := fail(PaymasterInsufficientTokenBalance)
+			failure.Required, failure.Available = approval.MinimalAllowance, balance
+			return failure
+		}
+		allowance, err := a.Allowance(ctx, approval.Token, params.Paymaster)
+		if err != nil {
+			return err
+		}
+		allowanceSufficient = allowance.Cmp(approval.MinimalAllowance) >= 0
+	}
+
+	if err = a.prepareValidation(ctx, &tx); err != nil {
+		return err
+	}
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(tx.Gas), tx.GasFeeCap)
+	balance, err := (*a.client).BalanceAt(ctx, params.Paymaster, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get paymaster balance: %w", err)
+	}
+	if balance.Cmp(fee) < 0 {
+		failure := fail(PaymasterInsufficientBalance)
+		failure.Required, failure.Available = fee, balance
+		return failure
+	}
+
+	if !allowanceSufficient {
+		if _, err = (*a.client).EstimateGasL2(ctx, tx.ToCallMsg(a.Address())); err != nil {
+			failure := fail(PaymasterValidationReverted)
+			failure.RevertData, failure.Err = revertData(err), err
+			return failure
+		}
+		return nil
+	}
+	return a.simulatePaymasterValidation(ctx, &tx, fail)
+}
+
+// prepareValidation sets the fields of the transaction required to simulate the validation.
+func (a *WalletL2) prepareValidation(ctx context.Context, tx *Transaction) error {
+	if tx.Nonce == nil {
+		nonce, err := (*a.client).NonceAt(ctx, a.Address(), nil)
+		if err != nil {
+			return fmt.Errorf("failed to get nonce: %w", err)
+		}
+		tx.Nonce = new(big.Int).SetUint64(nonce)
+	}
+	if tx.GasFeeCap == nil {
+		gasFeeCap, err := gasPrice(ctx, a.gasOracle, *a.client)
+		if err != nil {
+			return fmt.Errorf("failed to SuggestGasPrice: %w", err)
+		}
+		tx.GasFeeCap = gasFeeCap
+	}
+	if tx.GasTipCap == nil {
+		tx.GasTipCap = big.NewInt(0)
+	}
+	if tx.Meta.GasPerPubdata == nil {
+		meta := *tx.Meta
+		meta.GasPerPubdata = utils.NewBig(utils.DefaultGasPerPubdataLimit.Int64())
+		tx.Meta = &meta
+	}
+	if tx.Gas == 0 {
+		// The gas is estimated without the paymaster, since the estimation fails if the paymaster
+		// rejects the transaction, which is reported by the simulation of the validation.
+		meta := *tx.Meta
+		meta.PaymasterParams = nil
+		estimation := *tx
+		estimation.Meta = &meta
+		gas, err := (*a.client).EstimateGasL2(ctx, estimation.ToCallMsg(a.Address()))
+		if err != nil {
+			return fmt.Errorf("failed to EstimateGasL2: %w", err)
+		}
+		tx.Gas = gas
+	}
+	return nil
+}
+
+// simulatePaymasterValidation calls validateAndPayForPaymasterTransaction on behalf of the bootloader.
+func (a *WalletL2) simulatePaymasterValidation(ctx context.Context, tx *Transaction,
+	fail func(reason PaymasterFailureReason) *PaymasterValidationError) error {
+	paymasterAbi, err := paymaster.IPaymasterMetaData.GetAbi()
+	if err != nil {
+		return fmt.Errorf("failed to load IPaymaster ABI: %w", err)
+	}
+	bootloaderTx, err := a.bootloaderTransaction(tx)
+	if err != nil {
+		return err
+	}
+	data, err := paymasterAbi.Pack("validateAndPayForPaymasterTransaction", [32]byte{}, [32]byte{}, bootloaderTx)
+	if err != nil {
+		return fmt.Errorf("failed to encode paymaster validation: %w", err)
+	}
+	result, err := (*a.client).CallContractL2(ctx, zkTypes.CallMsg{
+		CallMsg: ethereum.CallMsg{
+			From: utils.BootloaderFormalAddress,
+			To:   &tx.Meta.PaymasterParams.Paymaster,
+			Data: data,
+		},
+	}, nil)
+	if err != nil {
+		failure := fail(PaymasterValidationReverted)
+		failure.RevertData, failure.Err = revertData(err), err
+		return failure
+	}
+	method := paymasterAbi.Methods["validateAndPayForPaymasterTransaction"]
+	if len(result) < 32 || !bytes.Equal(result[:4], method.ID) {
+		return fail(PaymasterInvalidMagic)
+	}
+	return nil
+}
+
+// bootloaderTransaction returns the transaction in the format passed by the bootloader to the paymaster.
+func (a *WalletL2) bootloaderTransaction(tx *Transaction) (paymaster.Transaction, error) {
+	factoryDeps := make([][32]byte, len(tx.Meta.FactoryDeps))
+	for i, dep := range tx.Meta.FactoryDeps {
+		hash, err := utils.HashBytecode(dep)
+		if err != nil {
+			return paymaster.Transaction{}, fmt.Errorf("failed to hash factory dependency: %w", err)
+		}
+		copy(factoryDeps[i][:], hash)
+	}
+	to := new(big.Int)
+	if tx.To != nil {
+		to.SetBytes(tx.To.Bytes())
+	}
+	value := tx.Value
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	return paymaster.Transaction{
+		TxType:                 hexutil.MustDecodeBig(zkTypes.EIP712TxType),
+		From:                   new(big.Int).SetBytes(a.Address().Bytes()),
+		To:                     to,
+		GasLimit:               new(big.Int).SetUint64(tx.Gas),
+		GasPerPubdataByteLimit: tx.Meta.GasPerPubdata.ToInt(),
+		MaxFeePerGas:           tx.GasFeeCap,
+		MaxPriorityFeePerGas:   tx.GasTipCap,
+		Paymaster:              new(big.Int).SetBytes(tx.Meta.PaymasterParams.Paymaster.Bytes()),
+		Nonce:                  tx.Nonce,
+		Value:                  value,
+		Reserved:               [4]*big.Int{big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0)},
+		Data:                   nonNilBytes(tx.Data),
+		Signature:              []byte{},
+		FactoryDeps:            factoryDeps,
+		PaymasterInput:         nonNilBytes(tx.Meta.PaymasterParams.PaymasterInput),
+		ReservedDynamic:        []byte{},
+	}, nil
+}
+
+// revertData returns the data of the reverted call carried by the RPC error, if any.
+func revertData(err error) hexutil.Bytes {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil
+	}
+	if data, ok := dataErr.ErrorData().(string); ok {
+		if decoded, err := hexutil.Decode(data); err == nil {
+			return decoded
+		}
+	}
+	return nil
+}
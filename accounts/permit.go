@@ -0,0 +1,255 @@
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/zksync-sdk/zksync2-go/contracts/erc20"
+	"github.com/zksync-sdk/zksync2-go/contracts/erc20permit"
+	"github.com/zksync-sdk/zksync2-go/eip712"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"math/big"
+)
+
+// Permit is the EIP-2612 permit message, which approves the spender to spend the value of tokens
+// of the owner, without sending an approval transaction from the owner.
+type Permit struct {
+	Owner    common.Address // The owner of the tokens.
+	Spender  common.Address // The account approved to spend the tokens.
+	Value    *big.Int       // The approved amount of tokens.
+	Nonce    *big.Int       // The permit nonce of the owner.
+	Deadline *big.Int       // The timestamp after which the permit cannot be used.
+}
+
+func (p *Permit) EIP712Type() string {
+	return "Permit"
+}
+
+func (p *Permit) EIP712Types() []apitypes.Type {
+	return []apitypes.Type{
+		{Name: "owner", Type: "address"},
+		{Name: "spender", Type: "address"},
+		{Name: "value", Type: "uint256"},
+		{Name: "nonce", Type: "uint256"},
+		{Name: "deadline", Type: "uint256"},
+	}
+}
+
+func (p *Permit) EIP712Message() (apitypes.TypedDataMessage, error) {
+	if p.Value == nil || p.Nonce == nil || p.Deadline == nil {
+		return nil, errors.New("value, nonce and deadline must be provided")
+	}
+	return apitypes.TypedDataMessage{
+		"owner":    p.Owner.String(),
+		"spender":  p.Spender.String(),
+		"value":    p.Value.String(),
+		"nonce":    p.Nonce.String(),
+		"deadline": p.Deadline.String(),
+	}, nil
+}
+
+// SignedPermit is the permit signed by the owner of the tokens. It can be submitted to the token
+// by any account, e.g. a relayer paying for the gas.
+type SignedPermit struct {
+	Permit
+	Token     common.Address // The address of the token.
+	Signature []byte         // The 65-byte signature of the permit, with V being 27 or 28.
+}
+
+// Submit sends the transaction which applies the permit to the token.
+func (p *SignedPermit) Submit(auth *bind.TransactOpts, backend bind.ContractBackend) (*types.Transaction, error) {
+	if len(p.Signature) != 65 {
+		return nil, fmt.Errorf("invalid signature length %d", len(p.Signature))
+	}
+	token, err := erc20permit.NewIERC20Permit(p.Token, backend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load IERC20Permit: %w", err)
+	}
+	var r, s [32]byte
+	copy(r[:], p.Signature[:32])
+	copy(s[:], p.Signature[32:64])
+	return token.Permit(auth, p.Owner, p.Spender, p.Value, p.Deadline, p.Signature[64], r, s)
+}
+
+// signPermit creates and signs the permit of the token, whose EIP-712 domain is fetched from the token contract.
+func signPermit(ctx context.Context, signer Signer, caller bind.ContractCaller, chainID *big.Int,
+	token, spender common.Address, value, deadline *big.Int) (*SignedPermit, error) {
+	contract, err := erc20permit.NewIERC20PermitCaller(token, caller)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load IERC20Permit: %w", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	owner := signer.Address()
+	nonce, err := contract.Nonces(opts, owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get permit nonce, the token might not support EIP-2612: %w", err)
+	}
+	name, err := contract.Name(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token name: %w", err)
+	}
+	// The version is not a part of EIP-2612, tokens which do not expose it commonly use "1".
+	version, err := contract.Version(opts)
+	if err != nil {
+		version = "1"
+	}
+	domain := &eip712.Domain{
+		Name:              name,
+		Version:           version,
+		ChainId:           chainID,
+		VerifyingContract: &token,
+	}
+	domainSeparator, err := contract.DOMAINSEPARATOR(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domain separator: %w", err)
+	}
+	permit := Permit{
+		Owner:    owner,
+		Spender:  spender,
+		Value:    value,
+		Nonce:    nonce,
+		Deadline: deadline,
+	}
+	// Signing the permit for the domain which differs from the one of the token would produce an invalid permit.
+	typedData, err := eip712.NewTypedData(domain, &permit)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := typedData.HashStruct(domain.EIP712Type(), typedData.Domain.Map())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hash of typed data domain: %w", err)
+	}
+	if !bytes.Equal(expected, domainSeparator[:]) {
+		return nil, fmt.Errorf("unsupported EIP-712 domain of token %s", token)
+	}
+
+	signature, err := signer.SignTypedData(domain, &permit)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedPermit{
+		Permit:    permit,
+		Token:     token,
+		Signature: signature,
+	}, nil
+}
+
+// PermitL1 signs the EIP-2612 permit which approves the spender to spend the value of the L1 token of the
+// associated account until the deadline. The permit can be submitted using SignedPermit.Submit.
+func (a *WalletL1) PermitL1(opts *CallOpts, token, spender common.Address, value, deadline *big.Int) (*SignedPermit, error) {
+	ctx := ensureContext(ensureCallOpts(opts).Context)
+	chainID, err := a.clientL1.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return signPermit(ctx, *a.signer, a.clientL1, chainID, token, spender, value, deadline)
+}
+
+// PermitAndDeposit deposits the ERC20 token supporting EIP-2612, approving the bridge using the permit valid
+// until the deadline, if the allowance of the bridge is not sufficient. It is neither gasless nor atomic: the permit
+// is applied in the transaction sent by the account and mined before the deposit is sent, so the deposit can fail
+// after the permit has been applied. To let another account pay for applying the permit, use PermitL1 and
+// SignedPermit.Submit instead. The bridge is resolved the same way as by Deposit: the shared bridge on chains
+// with custom base token, DepositTransaction.BridgeAddress or the default bridge otherwise. Other approvals,
+// e.g. of the base token paying the fee on chains with custom base token, are sent only if
+// DepositTransaction.ApproveERC20 is set.
+func (a *WalletL1) PermitAndDeposit(auth *TransactOpts, tx DepositTransaction, deadline *big.Int) (*types.Transaction, error) {
+	opts := ensureTransactOpts(auth)
+	if tx.Token == utils.EthAddress {
+		return nil, errors.New("ETH does not support permits")
+	}
+	bridge, err := a.depositBridge(ensureContext(opts.Context), tx.BridgeAddress)
+	if err != nil {
+		return nil, err
+	}
+	allowance, err := a.AllowanceL1(&CallOpts{Context: opts.Context}, tx.Token, bridge)
+	if err != nil {
+		return nil, err
+	}
+	if allowance.Cmp(tx.Amount) < 0 {
+		permit, err := a.PermitL1(&CallOpts{Context: opts.Context}, tx.Token, bridge, tx.Amount, deadline)
+		if err != nil {
+			return nil, err
+		}
+		signer, release := a.signerFn()
+		permitOpts := &bind.TransactOpts{From: a.auth.From, Signer: signer, Context: opts.Context}
+		permitTx, err := a.hooks.sentL1(release.sent(permit.Submit(permitOpts, a.clientL1)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to submit permit: %w", err)
+		}
+		if _, err = bind.WaitMined(ensureContext(opts.Context), a.clientL1, permitTx); err != nil {
+			return nil, err
+		}
+	}
+	return a.Deposit(opts, tx)
+}
+
+// depositBridge returns the L1 bridge which is approved to spend the deposited ERC20 token by Deposit.
+func (a *WalletL1) depositBridge(ctx context.Context, bridgeAddress *common.Address) (common.Address, error) {
+	isEthBased, err := (*a.clientL2).IsEthBasedChain(ctx)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if !isEthBased {
+		if bridgeAddress != nil {
+			return common.Address{}, errors.New("custom bridges are not supported on chains with custom base token")
+		}
+		contracts, err := a.bridgehubContracts(ctx)
+		if err != nil {
+			return common.Address{}, err
+		}
+		return contracts.l1SharedBridge, nil
+	}
+	if bridgeAddress != nil {
+		return *bridgeAddress, nil
+	}
+	return a.defaultL1BridgeAddress, nil
+}
+
+// Permit signs the EIP-2612 permit which approves the spender to spend the value of the L2 token of the
+// associated account until the deadline. The bridged tokens support permits. The permit can be submitted
+// using SignedPermit.Submit.
+func (a *WalletL2) Permit(ctx context.Context, token, spender common.Address, value, deadline *big.Int) (*SignedPermit, error) {
+	return signPermit(ensureContext(ctx), *a.signer, *a.client, (*a.signer).Domain().ChainId, token, spender, value, deadline)
+}
+
+// TransferWithPermit transfers the amount of the L2 token from the owner of the permit, signed using Permit
+// with the associated account as the spender, to the recipient. The associated account pays for the gas, so
+// the owner of the tokens needs no gas to transfer them. Unless the allowance of the associated account is
+// already sufficient, the permit is applied in the transaction sent and mined before the transfer.
+func (a *WalletL2) TransferWithPermit(auth *TransactOpts, permit *SignedPermit, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	if permit == nil {
+		return nil, errors.New("permit must be provided")
+	}
+	if permit.Spender != a.Address() {
+		return nil, fmt.Errorf("permit approves %s, not the associated account %s", permit.Spender, a.Address())
+	}
+	opts := ensureTransactOpts(auth)
+	token, err := erc20.NewIERC20(permit.Token, *a.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load erc20 contract: %w", err)
+	}
+	allowance, err := token.Allowance(&bind.CallOpts{Context: opts.Context}, permit.Owner, permit.Spender)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allowance: %w", err)
+	}
+	if allowance.Cmp(amount) < 0 {
+		permitTx, err := a.transactContract(opts, common.Address{}, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return permit.Submit(opts, *a.client)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to submit permit: %w", err)
+		}
+		if _, err = (*a.client).WaitMined(ensureContext(opts.Context), permitTx.Hash()); err != nil {
+			return nil, err
+		}
+	}
+	return a.transactContract(opts, to, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return token.TransferFrom(opts, permit.Owner, to, amount)
+	})
+}
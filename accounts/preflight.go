@@ -0,0 +1,110 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"github.com/zksync-sdk/zksync2-go/utils"
+)
+
+// ErrPreflightFailed is matched by PreflightError using errors.Is.
+var ErrPreflightFailed = errors.New("pre-flight simulation failed")
+
+// PreflightError is returned when the transaction is not sent, because its pre-flight simulation has failed.
+// It wraps the error of the simulation, which is utils.RevertError if the node has returned the revert data.
+type PreflightError struct {
+	Err error // Error returned by the simulation.
+}
+
+func (e *PreflightError) Error() string {
+	return fmt.Sprintf("%s: %v", ErrPreflightFailed, e.Err)
+}
+
+func (e *PreflightError) Unwrap() error {
+	return e.Err
+}
+
+func (e *PreflightError) Is(target error) bool {
+	return target == ErrPreflightFailed
+}
+
+// Revert returns the decoded revert reason of the simulation, or nil if the node has not returned the revert data.
+func (e *PreflightError) Revert() *utils.DecodedRevert {
+	var revertErr *utils.RevertError
+	if errors.As(e.Err, &revertErr) {
+		return revertErr.Decoded
+	}
+	return nil
+}
+
+// preflight simulates the transaction with its final payload, including the gas limit, the fees and
+// the paymaster parameters, if the pre-flight simulation is enabled.
+func (a *WalletL2) preflight(ctx context.Context, msg zkTypes.CallMsg) error {
+	if !a.preflightEnabled {
+		return nil
+	}
+	var err error
+	if msg.Meta != nil && msg.Meta.PaymasterParams != nil {
+		// The fee estimation also validates the transaction with the paymaster.
+		_, err = (*a.client).EstimateFee(ctx, msg)
+	} else {
+		_, err = (*a.client).EstimateGasL2(ctx, msg)
+	}
+	if err != nil {
+		return &PreflightError{Err: utils.DecodeRevertError(nil, err)}
+	}
+	return nil
+}
+
+// preflight712 simulates the EIP-712 transaction, if the pre-flight simulation is enabled.
+func (a *WalletL2) preflight712(ctx context.Context, tx *zkTypes.Transaction712) error {
+	if !a.preflightEnabled {
+		return nil
+	}
+	msg := zkTypes.CallMsg{
+		CallMsg: ethereum.CallMsg{
+			From:      a.Address(),
+			To:        tx.To,
+			GasFeeCap: tx.GasFeeCap,
+			GasTipCap: tx.GasTipCap,
+			Value:     tx.Value,
+			Data:      tx.Data,
+		},
+		Meta: tx.Meta,
+	}
+	if tx.Gas != nil {
+		msg.Gas = tx.Gas.Uint64()
+	}
+	return a.preflight(ctx, msg)
+}
+
+// preflightSigner wraps the signer function of contract bindings, so that the transaction is simulated
+// before it is signed, if the pre-flight simulation is enabled.
+func (a *WalletL2) preflightSigner(ctx context.Context, signer bind.SignerFn) bind.SignerFn {
+	if !a.preflightEnabled {
+		return signer
+	}
+	return func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		msg := zkTypes.CallMsg{CallMsg: ethereum.CallMsg{
+			From:  address,
+			To:    tx.To(),
+			Gas:   tx.Gas(),
+			Value: tx.Value(),
+			Data:  tx.Data(),
+		}}
+		if tx.Type() == types.LegacyTxType {
+			msg.GasPrice = tx.GasPrice()
+		} else {
+			msg.GasFeeCap, msg.GasTipCap = tx.GasFeeCap(), tx.GasTipCap()
+		}
+		if err := a.preflight(ensureContext(ctx), msg); err != nil {
+			return nil, err
+		}
+		return signer(address, tx)
+	}
+}
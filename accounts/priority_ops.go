@@ -0,0 +1,127 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/zksync-sdk/zksync2-go/clients"
+	"github.com/zksync-sdk/zksync2-go/contracts/zksync"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"math/big"
+)
+
+// ErrNoPriorityOps is returned when the L1 transaction has not created any priority operation.
+var ErrNoPriorityOps = errors.New("L1 transaction did not create a priority request")
+
+// ErrPriorityOpFailed is set as PriorityOpResult.Err when the L2 transaction of the priority operation has failed.
+var ErrPriorityOpFailed = errors.New("L2 transaction of priority operation has failed")
+
+// PriorityOp is the L1 -> L2 transaction requested by the L1 transaction, e.g. the deposit, and queued in
+// the priority queue of the main contract.
+type PriorityOp struct {
+	ID                  *big.Int    // Serial ID of the operation in the priority queue.
+	L2TxHash            common.Hash // Canonical hash of the L2 transaction executing the operation.
+	ExpirationTimestamp uint64      // Time by which the operation must be processed.
+	Request             *zksync.IZkSyncNewPriorityRequest
+}
+
+// PriorityOpResult is the outcome of the priority operation executed on L2.
+type PriorityOpResult struct {
+	PriorityOp
+	Receipt *zkTypes.Receipt // Receipt of the L2 transaction.
+	// ErrPriorityOpFailed if the L2 transaction has failed, nil otherwise. The funds of the failed deposit can be
+	// claimed using AdapterL1.ClaimFailedDeposit.
+	Err error
+}
+
+// PriorityOps returns the priority operations created by the L1 transaction, in the order of their creation.
+// Unlike Client.L2TransactionFromPriorityOp, it returns all operations of the L1 transaction, which can create
+// multiple operations, e.g. when it is sent by the contract batching deposits.
+func PriorityOps(ctx context.Context, client clients.Client, l1Receipt *types.Receipt) ([]PriorityOp, error) {
+	mainContractAddress, err := client.MainContractAddress(ensureContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	// parsing events does not require backend to be set
+	mainContract, err := zksync.NewIZkSync(mainContractAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load IZkSync: %w", err)
+	}
+	var ops []PriorityOp
+	for _, l := range l1Receipt.Logs {
+		if l.Address != mainContractAddress {
+			continue
+		}
+		if request, err := mainContract.ParseNewPriorityRequest(*l); err == nil {
+			ops = append(ops, PriorityOp{
+				ID:                  request.TxId,
+				L2TxHash:            request.TxHash,
+				ExpirationTimestamp: request.ExpirationTimestamp,
+				Request:             request,
+			})
+		}
+	}
+	if len(ops) == 0 {
+		return nil, ErrNoPriorityOps
+	}
+	return ops, nil
+}
+
+// PriorityOpResults returns the current outcome of the priority operations created by the L1 transaction.
+// The receipt of the operation is nil if its L2 transaction has not been executed yet. The error is returned
+// if the receipt of any operation cannot be queried.
+func PriorityOpResults(ctx context.Context, client clients.Client, l1Receipt *types.Receipt) ([]PriorityOpResult, error) {
+	ctx = ensureContext(ctx)
+	ops, err := PriorityOps(ctx, client, l1Receipt)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]PriorityOpResult, len(ops))
+	for i, op := range ops {
+		results[i].PriorityOp = op
+		receipt, err := client.TransactionReceipt(ctx, op.L2TxHash)
+		if errors.Is(err, ethereum.NotFound) {
+			// The L2 transaction is known once the priority request is processed by the node.
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get receipt of priority operation %s: %w", op.ID, err)
+		}
+		if receipt == nil || receipt.BlockNumber == nil {
+			continue
+		}
+		results[i].setReceipt(receipt)
+	}
+	return results, nil
+}
+
+// WaitPriorityOps waits until the priority operations created by the L1 transaction are executed on L2,
+// and returns their outcome. The failure of the L2 transaction is reported by PriorityOpResult.Err, rather
+// than by the returned error.
+func WaitPriorityOps(ctx context.Context, client clients.Client, l1Receipt *types.Receipt) ([]PriorityOpResult, error) {
+	ctx = ensureContext(ctx)
+	ops, err := PriorityOps(ctx, client, l1Receipt)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]PriorityOpResult, len(ops))
+	for i, op := range ops {
+		results[i].PriorityOp = op
+		receipt, err := client.WaitMined(ctx, op.L2TxHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to wait for priority operation %s: %w", op.ID, err)
+		}
+		results[i].setReceipt(receipt)
+	}
+	return results, nil
+}
+
+func (r *PriorityOpResult) setReceipt(receipt *zkTypes.Receipt) {
+	r.Receipt = receipt
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		r.Err = ErrPriorityOpFailed
+	}
+}
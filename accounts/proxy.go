@@ -0,0 +1,270 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/zksync-sdk/zksync2-go/bytecode"
+	"strings"
+)
+
+// proxyABI is the ABI of the upgrade functions of the OpenZeppelin (v4) proxy contracts: upgradeTo and
+// upgradeToAndCall of the transparent proxy, called by its admin, of the UUPS implementation and of the beacon,
+// and upgrade and upgradeAndCall of ProxyAdmin.
+const proxyABI = `[
+	{"type":"function","name":"upgradeTo","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"newImplementation","type":"address"}]},
+	{"type":"function","name":"upgradeToAndCall","stateMutability":"payable","outputs":[],"inputs":[
+		{"name":"newImplementation","type":"address"},{"name":"data","type":"bytes"}]},
+	{"type":"function","name":"upgrade","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"proxy","type":"address"},{"name":"implementation","type":"address"}]},
+	{"type":"function","name":"upgradeAndCall","stateMutability":"payable","outputs":[],"inputs":[
+		{"name":"proxy","type":"address"},{"name":"implementation","type":"address"},{"name":"data","type":"bytes"}]}
+]`
+
+// ProxyKind is the kind of the upgradeable proxy.
+type ProxyKind string
+
+const (
+	ProxyKindTransparent ProxyKind = "transparent" // TransparentUpgradeableProxy administered by ProxyAdmin.
+	ProxyKindUUPS        ProxyKind = "uups"        // ERC1967Proxy whose implementation performs upgrades.
+	ProxyKindBeacon      ProxyKind = "beacon"      // BeaconProxy whose implementation is provided by UpgradeableBeacon.
+)
+
+// ProxyBytecodes contains the zkEVM bytecode of the OpenZeppelin (v4) proxy contracts compiled by zksolc,
+// e.g. loaded using artifacts.Set.ProxyBytecodes from the output of hardhat-zksync. The EVM bytecode of
+// the contracts cannot be deployed on zkSync. Only the bytecode of the deployed kind of proxy is required.
+type ProxyBytecodes struct {
+	ERC1967Proxy                []byte // Proxy of the UUPS implementation.
+	TransparentUpgradeableProxy []byte
+	ProxyAdmin                  []byte // Admin of the transparent proxy.
+	BeaconProxy                 []byte
+	UpgradeableBeacon           []byte
+}
+
+// ProxyDeployment is the result of the deployment of the upgradeable proxy.
+type ProxyDeployment struct {
+	Kind           ProxyKind
+	Proxy          common.Address
+	Implementation common.Address
+	Admin          common.Address // ProxyAdmin of the transparent proxy, zero for other kinds.
+	Beacon         common.Address // UpgradeableBeacon of the beacon proxy, zero for other kinds.
+	TxHashes       []common.Hash  // Hashes of the deployment transactions, in the order they were sent.
+}
+
+// ProxyUpgrade is the result of the upgrade of the proxy.
+type ProxyUpgrade struct {
+	Kind           ProxyKind
+	Proxy          common.Address
+	Implementation common.Address // The new implementation.
+	TxHashes       []common.Hash  // Hashes of the sent transactions, including the deployment of the implementation.
+}
+
+// DeployTransparentProxy deploys the implementation, ProxyAdmin owned by the wallet and TransparentUpgradeableProxy
+// administered by ProxyAdmin, which calls the implementation with the initialization data, if provided.
+func (w *Wallet) DeployTransparentProxy(ctx context.Context, bytecodes *ProxyBytecodes, implementation CreateTransaction, initData []byte) (*ProxyDeployment, error) {
+	if len(bytecodes.TransparentUpgradeableProxy) == 0 || len(bytecodes.ProxyAdmin) == 0 {
+		return nil, errors.New("bytecode of TransparentUpgradeableProxy and ProxyAdmin must be provided")
+	}
+	deployment := &ProxyDeployment{Kind: ProxyKindTransparent}
+	var err error
+	if deployment.Implementation, err = w.deployProxyContract(ctx, deployment, implementation); err != nil {
+		return deployment, fmt.Errorf("failed to deploy implementation: %w", err)
+	}
+	if deployment.Admin, err = w.deployProxyContract(ctx, deployment, CreateTransaction{Bytecode: bytecodes.ProxyAdmin}); err != nil {
+		return deployment, fmt.Errorf("failed to deploy ProxyAdmin: %w", err)
+	}
+	calldata, err := packConstructor([]string{"address", "address", "bytes"}, deployment.Implementation, deployment.Admin, nonNilBytes(initData))
+	if err != nil {
+		return deployment, err
+	}
+	proxy := CreateTransaction{Bytecode: bytecodes.TransparentUpgradeableProxy, Calldata: calldata}
+	if deployment.Proxy, err = w.deployProxyContract(ctx, deployment, proxy); err != nil {
+		return deployment, fmt.Errorf("failed to deploy TransparentUpgradeableProxy: %w", err)
+	}
+	return deployment, nil
+}
+
+// DeployUUPS deploys the UUPS implementation and ERC1967Proxy, which calls the implementation with
+// the initialization data, if provided. The implementation must inherit UUPSUpgradeable.
+func (w *Wallet) DeployUUPS(ctx context.Context, bytecodes *ProxyBytecodes, implementation CreateTransaction, initData []byte) (*ProxyDeployment, error) {
+	if len(bytecodes.ERC1967Proxy) == 0 {
+		return nil, errors.New("bytecode of ERC1967Proxy must be provided")
+	}
+	deployment := &ProxyDeployment{Kind: ProxyKindUUPS}
+	var err error
+	if deployment.Implementation, err = w.deployProxyContract(ctx, deployment, implementation); err != nil {
+		return deployment, fmt.Errorf("failed to deploy implementation: %w", err)
+	}
+	calldata, err := packConstructor([]string{"address", "bytes"}, deployment.Implementation, nonNilBytes(initData))
+	if err != nil {
+		return deployment, err
+	}
+	proxy := CreateTransaction{Bytecode: bytecodes.ERC1967Proxy, Calldata: calldata}
+	if deployment.Proxy, err = w.deployProxyContract(ctx, deployment, proxy); err != nil {
+		return deployment, fmt.Errorf("failed to deploy ERC1967Proxy: %w", err)
+	}
+	return deployment, nil
+}
+
+// DeployBeaconProxy deploys the implementation, UpgradeableBeacon owned by the wallet and BeaconProxy,
+// which calls the implementation with the initialization data, if provided.
+func (w *Wallet) DeployBeaconProxy(ctx context.Context, bytecodes *ProxyBytecodes, implementation CreateTransaction, initData []byte) (*ProxyDeployment, error) {
+	if len(bytecodes.BeaconProxy) == 0 || len(bytecodes.UpgradeableBeacon) == 0 {
+		return nil, errors.New("bytecode of BeaconProxy and UpgradeableBeacon must be provided")
+	}
+	deployment := &ProxyDeployment{Kind: ProxyKindBeacon}
+	var err error
+	if deployment.Implementation, err = w.deployProxyContract(ctx, deployment, implementation); err != nil {
+		return deployment, fmt.Errorf("failed to deploy implementation: %w", err)
+	}
+	calldata, err := packConstructor([]string{"address"}, deployment.Implementation)
+	if err != nil {
+		return deployment, err
+	}
+	beacon := CreateTransaction{Bytecode: bytecodes.UpgradeableBeacon, Calldata: calldata}
+	if deployment.Beacon, err = w.deployProxyContract(ctx, deployment, beacon); err != nil {
+		return deployment, fmt.Errorf("failed to deploy UpgradeableBeacon: %w", err)
+	}
+	if calldata, err = packConstructor([]string{"address", "bytes"}, deployment.Beacon, nonNilBytes(initData)); err != nil {
+		return deployment, err
+	}
+	proxy := CreateTransaction{Bytecode: bytecodes.BeaconProxy, Calldata: calldata}
+	if deployment.Proxy, err = w.deployProxyContract(ctx, deployment, proxy); err != nil {
+		return deployment, fmt.Errorf("failed to deploy BeaconProxy: %w", err)
+	}
+	return deployment, nil
+}
+
+// UpgradeProxy deploys the new implementation and upgrades the proxy to it using UpgradeProxyTo.
+func (w *Wallet) UpgradeProxy(ctx context.Context, proxy common.Address, implementation CreateTransaction, data []byte) (*ProxyUpgrade, error) {
+	deployment := &ProxyDeployment{}
+	address, err := w.deployProxyContract(ctx, deployment, implementation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy implementation: %w", err)
+	}
+	upgrade, err := w.UpgradeProxyTo(ctx, proxy, address, data)
+	if upgrade != nil {
+		upgrade.TxHashes = append(deployment.TxHashes, upgrade.TxHashes...)
+	}
+	return upgrade, err
+}
+
+// UpgradeProxyTo upgrades the proxy to the deployed implementation, and calls the implementation with the data,
+// if provided. The kind of the proxy is detected from its EIP-1967 storage slots: the beacon of the beacon proxy
+// is upgraded, which does not support the call, the transparent proxy is upgraded through its admin, which is
+// either ProxyAdmin or the wallet, and the UUPS proxy is upgraded by calling the current implementation.
+func (w *Wallet) UpgradeProxyTo(ctx context.Context, proxy, implementation common.Address, data []byte) (*ProxyUpgrade, error) {
+	ctx = ensureContext(ctx)
+	if w.clientL2 == nil {
+		return nil, errors.New("wallet is not connected to L2 network")
+	}
+	beacon, err := bytecode.Beacon(ctx, *w.clientL2, proxy, nil)
+	if err != nil {
+		return nil, err
+	}
+	admin, err := bytecode.Admin(ctx, *w.clientL2, proxy, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	upgrade := &ProxyUpgrade{Proxy: proxy, Implementation: implementation}
+	var (
+		target   common.Address
+		calldata []byte
+	)
+	switch {
+	case beacon != (common.Address{}):
+		if len(data) > 0 {
+			return nil, errors.New("upgrade of beacon proxy does not support the call of implementation")
+		}
+		upgrade.Kind, target = ProxyKindBeacon, beacon
+		calldata, err = packProxyCall("upgradeTo", implementation)
+	case admin != (common.Address{}) && admin != w.Address():
+		upgrade.Kind, target = ProxyKindTransparent, admin
+		if len(data) > 0 {
+			calldata, err = packProxyCall("upgradeAndCall", proxy, implementation, data)
+		} else {
+			calldata, err = packProxyCall("upgrade", proxy, implementation)
+		}
+	default:
+		upgrade.Kind, target = ProxyKindUUPS, proxy
+		if admin != (common.Address{}) {
+			upgrade.Kind = ProxyKindTransparent
+		}
+		if len(data) > 0 {
+			calldata, err = packProxyCall("upgradeToAndCall", implementation, data)
+		} else {
+			calldata, err = packProxyCall("upgradeTo", implementation)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	hash, err := w.AdapterL2.SendTransaction(ctx, &Transaction{To: &target, Data: calldata})
+	if err != nil {
+		return nil, fmt.Errorf("failed to upgrade proxy: %w", err)
+	}
+	upgrade.TxHashes = append(upgrade.TxHashes, hash)
+	receipt, err := (*w.clientL2).WaitMined(ctx, hash)
+	if err != nil {
+		return upgrade, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return upgrade, fmt.Errorf("upgrade of proxy %s failed in transaction %s", proxy, hash)
+	}
+	return upgrade, nil
+}
+
+// deployProxyContract deploys the contract using CREATE, waits for the deployment, and returns the address
+// of the contract. The hash of the deployment transaction is recorded in the deployment.
+func (w *Wallet) deployProxyContract(ctx context.Context, deployment *ProxyDeployment, tx CreateTransaction) (common.Address, error) {
+	ctx = ensureContext(ctx)
+	if w.clientL2 == nil {
+		return common.Address{}, errors.New("wallet is not connected to L2 network")
+	}
+	hash, err := w.DeployWithCreate(&TransactOpts{Context: ctx}, tx)
+	if err != nil {
+		return common.Address{}, err
+	}
+	deployment.TxHashes = append(deployment.TxHashes, hash)
+	receipt, err := (*w.clientL2).WaitMined(ctx, hash)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return common.Address{}, fmt.Errorf("deployment %s failed", hash)
+	}
+	return deployedAddress(receipt, tx.Bytecode)
+}
+
+func packConstructor(types []string, values ...interface{}) ([]byte, error) {
+	arguments := make(abi.Arguments, len(types))
+	for i, t := range types {
+		argumentType, err := abi.NewType(t, "", nil)
+		if err != nil {
+			return nil, err
+		}
+		arguments[i] = abi.Argument{Type: argumentType}
+	}
+	calldata, err := arguments.Pack(values...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode constructor arguments: %w", err)
+	}
+	return calldata, nil
+}
+
+func packProxyCall(name string, args ...interface{}) ([]byte, error) {
+	parsed, err := abi.JSON(strings.NewReader(proxyABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load proxy ABI: %w", err)
+	}
+	data, err := parsed.Pack(name, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,257 @@
+package accounts
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/zksync-sdk/zksync2-go/clients"
+	"github.com/zksync-sdk/zksync2-go/contracts/l1bridge"
+	"github.com/zksync-sdk/zksync2-go/eip712"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"math/big"
+)
+
+// ErrReadOnlyWallet is returned when signing is requested from the account of ReadOnlyWallet.
+var ErrReadOnlyWallet = errors.New("read-only wallet can't sign")
+
+// watchSigner implements the Signer interface for the account whose key is not held. It does not sign,
+// except for TransactionSigner.SignTx, which returns the transaction unsigned, so that unsigned
+// transactions can be built using the contract bindings.
+type watchSigner struct {
+	address common.Address
+	domain  *eip712.Domain
+}
+
+func (s *watchSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *watchSigner) Domain() *eip712.Domain {
+	return s.domain
+}
+
+func (s *watchSigner) PrivateKey() *ecdsa.PrivateKey {
+	return nil
+}
+
+func (s *watchSigner) SignHash(_ []byte) ([]byte, error) {
+	return nil, ErrReadOnlyWallet
+}
+
+func (s *watchSigner) SignTypedData(_ *eip712.Domain, _ eip712.TypedData) ([]byte, error) {
+	return nil, ErrReadOnlyWallet
+}
+
+func (s *watchSigner) SignTx(tx *types.Transaction, _ *big.Int) (*types.Transaction, error) {
+	return tx, nil
+}
+
+// ReadOnlyWallet provides the read operations of the account on both L1 and L2 network, along with building
+// of unsigned transactions, without holding the key of the account. It is intended for monitoring services
+// and for transactions which are signed elsewhere, e.g. offline.
+type ReadOnlyWallet struct {
+	clientL1 *ethclient.Client
+	clientL2 *clients.Client
+
+	l1 *WalletL1 // It is nil if the wallet is not connected to L1 network.
+	l2 *WalletL2
+}
+
+// NewReadOnlyWallet creates an instance of ReadOnlyWallet for the account with the given address.
+// The clientL1 parameter is optional; if not provided, only L2 operations can be used.
+func NewReadOnlyWallet(address common.Address, clientL2 *clients.Client, clientL1 *ethclient.Client) (*ReadOnlyWallet, error) {
+	if clientL2 == nil {
+		return nil, errors.New("clientL2 is not provided")
+	}
+	chainID, err := (*clientL2).ChainID(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	signer := Signer(&watchSigner{
+		address: address,
+		domain:  eip712.ZkSyncEraEIP712Domain(chainID.Int64()),
+	})
+	l2, err := NewWalletL2FromSigner(&signer, clientL2)
+	if err != nil {
+		return nil, err
+	}
+	wallet := &ReadOnlyWallet{
+		clientL1: clientL1,
+		clientL2: clientL2,
+		l2:       l2,
+	}
+	if clientL1 != nil {
+		if wallet.l1, err = NewWalletL1FromSigner(&signer, clientL1, clientL2); err != nil {
+			return nil, err
+		}
+	}
+	return wallet, nil
+}
+
+// Address returns the address of the account.
+func (w *ReadOnlyWallet) Address() common.Address {
+	return w.l2.Address()
+}
+
+// Balance returns the balance of the specified token on L2 network at the given block number.
+// If the token is not provided, ETH is used. If the block number is nil, the latest block is used.
+func (w *ReadOnlyWallet) Balance(ctx context.Context, token common.Address, at *big.Int) (*big.Int, error) {
+	return w.l2.Balance(ctx, token, at)
+}
+
+// AllBalances returns all balances of confirmed tokens on L2 network.
+func (w *ReadOnlyWallet) AllBalances(ctx context.Context) (map[common.Address]*big.Int, error) {
+	return w.l2.AllBalances(ctx)
+}
+
+// TokenBalances returns the balances of the tokens held on L2 network, along with the metadata of the tokens.
+func (w *ReadOnlyWallet) TokenBalances(ctx context.Context, opts *BalanceOptions) ([]TokenBalance, error) {
+	return w.l2.TokenBalances(ctx, opts)
+}
+
+// BalancesAt returns the balances of the tokens held on L2 network at the given block, along with
+// the metadata of the tokens. If the block number is nil, the latest block is used.
+func (w *ReadOnlyWallet) BalancesAt(ctx context.Context, blockNumber *big.Int, opts *BalanceOptions) ([]TokenBalance, error) {
+	return w.l2.BalancesAt(ctx, blockNumber, opts)
+}
+
+// Nonce returns the nonce of the account on L2 network at the given block number. If the block number is nil,
+// the latest block is used.
+func (w *ReadOnlyWallet) Nonce(ctx context.Context, blockNumber *big.Int) (uint64, error) {
+	return (*w.clientL2).NonceAt(ensureContext(ctx), w.Address(), blockNumber)
+}
+
+// PendingNonce returns the nonce of the account on L2 network in the pending state, which is the nonce
+// to be used by the next transaction.
+func (w *ReadOnlyWallet) PendingNonce(ctx context.Context) (uint64, error) {
+	return (*w.clientL2).PendingNonceAt(ensureContext(ctx), w.Address())
+}
+
+// EstimateGasWithdraw estimates the amount of gas required for a withdrawal transaction.
+func (w *ReadOnlyWallet) EstimateGasWithdraw(ctx context.Context, msg WithdrawalCallMsg) (uint64, error) {
+	return w.l2.EstimateGasWithdraw(ctx, msg)
+}
+
+// EstimateGasTransfer estimates the amount of gas required for a transfer transaction.
+func (w *ReadOnlyWallet) EstimateGasTransfer(ctx context.Context, msg TransferCallMsg) (uint64, error) {
+	return w.l2.EstimateGasTransfer(ctx, msg)
+}
+
+// PopulateTransaction builds the unsigned L2 transaction of the account, preparing any unset fields.
+// The transaction can be signed elsewhere and sent using clients.Client.SendRawTransaction.
+func (w *ReadOnlyWallet) PopulateTransaction(ctx context.Context, tx Transaction) (*zkTypes.Transaction712, error) {
+	return w.l2.PopulateTransaction(ctx, tx)
+}
+
+// BalanceL1 returns the balance of the specified token on L1 network.
+func (w *ReadOnlyWallet) BalanceL1(opts *CallOpts, token common.Address) (*big.Int, error) {
+	if w.l1 == nil {
+		return nil, errors.New("wallet is not connected to L1 network")
+	}
+	return w.l1.BalanceL1(opts, token)
+}
+
+// AllowanceL1 returns the amount of approved tokens for a specific L1 bridge.
+func (w *ReadOnlyWallet) AllowanceL1(opts *CallOpts, token common.Address, bridgeAddress common.Address) (*big.Int, error) {
+	if w.l1 == nil {
+		return nil, errors.New("wallet is not connected to L1 network")
+	}
+	return w.l1.AllowanceL1(opts, token, bridgeAddress)
+}
+
+// L2TokenAddress returns the corresponding address on the L2 network for the token on the L1 network.
+func (w *ReadOnlyWallet) L2TokenAddress(ctx context.Context, token common.Address) (common.Address, error) {
+	return (*w.clientL2).L2TokenAddress(ensureContext(ctx), token)
+}
+
+// BaseCost returns base cost for L2 transaction.
+func (w *ReadOnlyWallet) BaseCost(opts *CallOpts, gasLimit, gasPerPubdataByte, gasPrice *big.Int) (*big.Int, error) {
+	if w.l1 == nil {
+		return nil, errors.New("wallet is not connected to L1 network")
+	}
+	return w.l1.BaseCost(opts, gasLimit, gasPerPubdataByte, gasPrice)
+}
+
+// EstimateGasDeposit estimates the amount of gas required for a deposit transaction on L1 network.
+func (w *ReadOnlyWallet) EstimateGasDeposit(ctx context.Context, msg DepositCallMsg) (uint64, error) {
+	if w.l1 == nil {
+		return 0, errors.New("wallet is not connected to L1 network")
+	}
+	return w.l1.EstimateGasDeposit(ctx, msg)
+}
+
+// FullRequiredDepositFee retrieves the full needed ETH fee for the deposit on both L1 and L2 networks.
+//
+// Deprecated: Deprecated in favor of EstimateDepositFee.
+func (w *ReadOnlyWallet) FullRequiredDepositFee(ctx context.Context, msg DepositCallMsg) (*FullDepositFee, error) {
+	if w.l1 == nil {
+		return nil, errors.New("wallet is not connected to L1 network")
+	}
+	return w.l1.FullRequiredDepositFee(ctx, msg)
+}
+
+// EstimateDepositFee retrieves the breakdown of the fee required for the deposit on both L1 and L2 networks.
+func (w *ReadOnlyWallet) EstimateDepositFee(ctx context.Context, msg DepositCallMsg) (*DepositFee, error) {
+	if w.l1 == nil {
+		return nil, errors.New("wallet is not connected to L1 network")
+	}
+	return w.l1.EstimateDepositFee(ctx, msg)
+}
+
+// BuildDeposit builds the unsigned L1 deposit transaction, the same way as AdapterL1.Deposit sends it.
+// Token approvals are not performed, so DepositTransaction.ApproveERC20 is ignored, and the tokens must
+// be approved to the bridge before the L1 gas limit can be estimated. Depositing to chains with custom
+// base token is not supported.
+func (w *ReadOnlyWallet) BuildDeposit(auth *TransactOpts, tx DepositTransaction) (*types.Transaction, error) {
+	if w.l1 == nil {
+		return nil, errors.New("wallet is not connected to L1 network")
+	}
+	opts := ensureTransactOpts(auth)
+	isEthBased, err := (*w.clientL2).IsEthBasedChain(ensureContext(opts.Context))
+	if err != nil {
+		return nil, err
+	}
+	if !isEthBased {
+		return nil, ErrCustomBaseTokenNotSupported
+	}
+	tx.ApproveERC20 = false
+	opts, depositTx, err := w.l1.prepareDepositTx(*opts, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	bindOpts := opts.ToTransactOpts(w.l1.auth.From, w.l1.auth.Signer)
+	bindOpts.NoSend = true
+	if depositTx.Token == utils.EthAddress {
+		return w.l1.mainContract.RequestL2Transaction(
+			bindOpts,
+			depositTx.To,
+			depositTx.Amount,
+			[]byte{},
+			depositTx.L2GasLimit,
+			depositTx.GasPerPubdataByte,
+			[][]byte{},
+			depositTx.RefundRecipient,
+		)
+	}
+	bridge := w.l1.defaultL1Bridge
+	if depositTx.BridgeAddress != nil {
+		if bridge, err = l1bridge.NewIL1Bridge(*depositTx.BridgeAddress, w.clientL1); err != nil {
+			return nil, fmt.Errorf("failed to load IL1Bridge: %w", err)
+		}
+	}
+	return bridge.Deposit(
+		bindOpts,
+		depositTx.To,
+		depositTx.Token,
+		depositTx.Amount,
+		depositTx.L2GasLimit,
+		depositTx.GasPerPubdataByte,
+		depositTx.RefundRecipient,
+	)
+}
@@ -0,0 +1,138 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"strings"
+	"sync"
+)
+
+// ErrLookalikeRecipient is matched by LookalikeRecipientError using errors.Is.
+var ErrLookalikeRecipient = errors.New("recipient looks like a known counterparty")
+
+// LookalikeRecipientError is returned when the recipient is visually similar to, but different from,
+// a known counterparty, which is a sign of address poisoning.
+type LookalikeRecipientError struct {
+	Recipient common.Address // The recipient of the transaction.
+	Similar   common.Address // The known counterparty the recipient resembles.
+}
+
+func (e *LookalikeRecipientError) Error() string {
+	return fmt.Sprintf("recipient %s looks like known counterparty %s", e.Recipient, e.Similar)
+}
+
+func (e *LookalikeRecipientError) Unwrap() error {
+	return ErrLookalikeRecipient
+}
+
+// RecipientGuard checks recipients of transfers and withdrawals before the transactions are sent.
+type RecipientGuard interface {
+	// CheckRecipient returns an error if the funds must not be sent to the recipient.
+	CheckRecipient(ctx context.Context, recipient common.Address) error
+	// RecordRecipient records the recipient of the sent transaction as a known counterparty.
+	RecordRecipient(recipient common.Address)
+}
+
+type confirmedRecipientKey struct{}
+
+// ConfirmRecipient returns a context which overrides the RecipientGuard check of the recipient, after the user
+// has explicitly confirmed that the recipient is intended.
+func ConfirmRecipient(ctx context.Context, recipient common.Address) context.Context {
+	return context.WithValue(ctx, confirmedRecipientKey{}, recipient)
+}
+
+// isRecipientConfirmed reports whether the recipient is confirmed using ConfirmRecipient.
+func isRecipientConfirmed(ctx context.Context, recipient common.Address) bool {
+	confirmed, ok := ctx.Value(confirmedRecipientKey{}).(common.Address)
+	return ok && confirmed == recipient
+}
+
+// LookalikeGuard implements RecipientGuard by flagging recipients whose hex representation shares the prefix
+// and suffix with a known counterparty, while the addresses differ. Address poisoning attacks rely on users
+// copying such addresses from their transaction history. Counterparties are learned from the transactions
+// sent by the wallet, and can be added using Remember. It is safe for concurrent use.
+type LookalikeGuard struct {
+	prefix, suffix int
+
+	mu    sync.RWMutex
+	known map[common.Address]struct{}
+}
+
+// NewLookalikeGuard creates an instance of LookalikeGuard which compares the given number of leading
+// and trailing hex characters of addresses. Values out of range (1 to 20) default to 4.
+func NewLookalikeGuard(prefix, suffix int) *LookalikeGuard {
+	if prefix <= 0 || prefix > 20 {
+		prefix = 4
+	}
+	if suffix <= 0 || suffix > 20 {
+		suffix = 4
+	}
+	return &LookalikeGuard{
+		prefix: prefix,
+		suffix: suffix,
+		known:  make(map[common.Address]struct{}),
+	}
+}
+
+// Remember adds the addresses to the known counterparties.
+func (g *LookalikeGuard) Remember(addresses ...common.Address) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, address := range addresses {
+		g.known[address] = struct{}{}
+	}
+}
+
+// Forget removes the addresses from the known counterparties.
+func (g *LookalikeGuard) Forget(addresses ...common.Address) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, address := range addresses {
+		delete(g.known, address)
+	}
+}
+
+func (g *LookalikeGuard) CheckRecipient(ctx context.Context, recipient common.Address) error {
+	if isRecipientConfirmed(ctx, recipient) {
+		return nil
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if _, ok := g.known[recipient]; ok {
+		return nil
+	}
+	for known := range g.known {
+		if g.similar(recipient, known) {
+			return &LookalikeRecipientError{Recipient: recipient, Similar: known}
+		}
+	}
+	return nil
+}
+
+func (g *LookalikeGuard) RecordRecipient(recipient common.Address) {
+	g.Remember(recipient)
+}
+
+// similar reports whether the addresses share the prefix and suffix of their hex representation.
+func (g *LookalikeGuard) similar(a, b common.Address) bool {
+	hexA := strings.ToLower(a.Hex()[2:])
+	hexB := strings.ToLower(b.Hex()[2:])
+	return hexA[:g.prefix] == hexB[:g.prefix] && hexA[len(hexA)-g.suffix:] == hexB[len(hexB)-g.suffix:]
+}
+
+// checkRecipient checks the recipient using the recipient guard of the wallet, if it is configured.
+func (a *WalletL2) checkRecipient(ctx context.Context, recipient common.Address) error {
+	if a.recipientGuard == nil {
+		return nil
+	}
+	return a.recipientGuard.CheckRecipient(ensureContext(ctx), recipient)
+}
+
+// recordRecipient records the recipient of the sent transaction, if the recipient guard is configured.
+func (a *WalletL2) recordRecipient(recipient common.Address, err error) {
+	if a.recipientGuard != nil && err == nil {
+		a.recipientGuard.RecordRecipient(recipient)
+	}
+}
@@ -0,0 +1,83 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/zksync-sdk/zksync2-go/contracts/zksync"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"math/big"
+)
+
+// RequestExecuteResult contains the outcome of the L1 -> L2 transaction, including the refund of the excess fee.
+type RequestExecuteResult struct {
+	L1Receipt *types.Receipt   // The receipt of the L1 transaction.
+	L2TxHash  common.Hash      // The hash of the L2 transaction.
+	L2Receipt *zkTypes.Receipt // The receipt of the L2 transaction.
+
+	MintValue       *big.Int       // The amount of the base token minted on L2, covering the L2 value and fee.
+	L2Value         *big.Int       // The value of the L2 transaction, received by the contract only on success.
+	Fee             *big.Int       // The fee charged for the L2 transaction.
+	RefundRecipient common.Address // The address on L2 which receives the refund.
+	// The amount of the base token refunded to the RefundRecipient: MintValue - Fee, reduced by the L2Value
+	// if the L2 transaction has succeeded.
+	Refund *big.Int
+}
+
+// WaitRequestExecute waits until the L1 -> L2 transaction, sent by RequestExecute or Deposit, is executed on L2,
+// and returns its outcome along with the refund of the excess fee. Since the base token minted on L2 covers
+// the maximum fee, the amount received on L2 differs from the one sent on L1, which the refund accounts for.
+func (a *WalletL1) WaitRequestExecute(ctx context.Context, tx *types.Transaction) (*RequestExecuteResult, error) {
+	ctx = ensureContext(ctx)
+	l1Receipt, err := bind.WaitMined(ctx, a.clientL1, tx)
+	if err != nil {
+		return nil, err
+	}
+	if l1Receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("L1 transaction %s failed", tx.Hash())
+	}
+	request, err := a.priorityRequest(l1Receipt)
+	if err != nil {
+		return nil, err
+	}
+	l2TxHash := common.Hash(request.TxHash)
+	l2Receipt, err := (*a.clientL2).WaitMined(ctx, l2TxHash)
+	if err != nil {
+		return nil, err
+	}
+	details, err := (*a.clientL2).TransactionDetails(ctx, l2TxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RequestExecuteResult{
+		L1Receipt:       l1Receipt,
+		L2TxHash:        l2TxHash,
+		L2Receipt:       l2Receipt,
+		MintValue:       request.Transaction.Reserved[0],
+		L2Value:         request.Transaction.Value,
+		Fee:             details.Fee.ToInt(),
+		RefundRecipient: common.BigToAddress(request.Transaction.Reserved[1]),
+	}
+	result.Refund = new(big.Int).Sub(result.MintValue, result.Fee)
+	if l2Receipt.Status == types.ReceiptStatusSuccessful {
+		result.Refund.Sub(result.Refund, result.L2Value)
+	}
+	return result, nil
+}
+
+// priorityRequest returns the priority request emitted by the main contract in the L1 transaction.
+func (a *WalletL1) priorityRequest(receipt *types.Receipt) (*zksync.IZkSyncNewPriorityRequest, error) {
+	for _, l := range receipt.Logs {
+		if l.Address != a.mainContractAddress {
+			continue
+		}
+		if request, err := a.mainContract.ParseNewPriorityRequest(*l); err == nil {
+			return request, nil
+		}
+	}
+	return nil, errors.New("L1 transaction did not create a priority request")
+}
@@ -0,0 +1,133 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/zksync-sdk/zksync2-go/clients"
+	"github.com/zksync-sdk/zksync2-go/eip712"
+	"math/big"
+	"sort"
+	"sync"
+)
+
+// ErrChainNotRegistered is returned when the wallet of the chain which has not been added to WalletRegistry
+// is requested.
+var ErrChainNotRegistered = errors.New("chain is not registered")
+
+// chainSigner is the Signer using the EIP-712 domain of the specific chain, so that one account
+// can sign transactions of multiple chains.
+type chainSigner struct {
+	Signer
+	domain *eip712.Domain
+}
+
+func (s *chainSigner) Domain() *eip712.Domain {
+	return s.domain
+}
+
+// SignTx signs the transaction using the underlying signer, which is required to preserve the signing
+// of signers implementing the TransactionSigner interface.
+func (s *chainSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if txSigner, ok := s.Signer.(TransactionSigner); ok {
+		return txSigner.SignTx(tx, chainID)
+	}
+	latestSigner := types.LatestSignerForChainID(chainID)
+	signature, err := s.Signer.SignHash(latestSigner.Hash(tx).Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(latestSigner, signature)
+}
+
+// WalletRegistry binds one account to multiple L2 chains, e.g. ZK Stack hyperchains, and provides the wallet
+// of each chain by its chain ID. All wallets share the L1 client, since the chains settle on the same L1 network.
+// It is safe for concurrent use.
+type WalletRegistry struct {
+	signer   Signer
+	clientL1 *ethclient.Client
+
+	mu      sync.RWMutex
+	wallets map[int64]*Wallet
+}
+
+// NewWalletRegistry creates an instance of WalletRegistry for the account provided by the signer.
+// The clientL1 parameter is optional; if not provided, the wallets can not be used for operations
+// requiring communication with the L1 network.
+func NewWalletRegistry(signer Signer, clientL1 *ethclient.Client) *WalletRegistry {
+	return &WalletRegistry{
+		signer:   signer,
+		clientL1: clientL1,
+		wallets:  make(map[int64]*Wallet),
+	}
+}
+
+// Add creates the wallet connected to the chain of the client and registers it by the chain ID, which is
+// fetched from the client. The wallet previously registered for the chain is replaced.
+func (r *WalletRegistry) Add(ctx context.Context, client clients.Client) (*Wallet, error) {
+	chainID, err := client.ChainID(ensureContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	signer := Signer(&chainSigner{
+		Signer: r.signer,
+		domain: eip712.ZkSyncEraEIP712Domain(chainID.Int64()),
+	})
+	wallet, err := NewWalletFromSigner(&signer, &client, r.clientL1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create wallet of chain %d: %w", chainID, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.wallets[chainID.Int64()] = wallet
+	return wallet, nil
+}
+
+// Remove unregisters the wallet of the chain.
+func (r *WalletRegistry) Remove(chainID int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.wallets, chainID)
+}
+
+// Wallet returns the wallet of the chain. ErrChainNotRegistered is returned if the chain has not been added.
+func (r *WalletRegistry) Wallet(chainID int64) (*Wallet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	wallet, ok := r.wallets[chainID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrChainNotRegistered, chainID)
+	}
+	return wallet, nil
+}
+
+// ChainIDs returns the IDs of the registered chains in ascending order.
+func (r *WalletRegistry) ChainIDs() []int64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := make([]int64, 0, len(r.wallets))
+	for id := range r.wallets {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// Each calls the function with the wallet of each registered chain, in ascending order of chain IDs,
+// and stops on the first error, which is returned.
+func (r *WalletRegistry) Each(fn func(chainID int64, wallet *Wallet) error) error {
+	for _, id := range r.ChainIDs() {
+		wallet, err := r.Wallet(id)
+		if errors.Is(err, ErrChainNotRegistered) {
+			// The wallet has been removed in the meantime.
+			continue
+		}
+		if err = fn(id, wallet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
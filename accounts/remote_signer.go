@@ -0,0 +1,258 @@
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/pkg/errors"
+	"github.com/zksync-sdk/zksync2-go/eip712"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"math/big"
+	"time"
+)
+
+// RemoteSigner implements the Signer interface by forwarding signing requests to an external signing
+// service over JSON-RPC, such as Web3Signer or Clef, so that the key is isolated from the application process.
+// EIP-712 transactions are signed using eth_signTypedData_v4, while other transactions, e.g. those sent
+// to L1, are signed using eth_signTransaction.
+//
+// Since the key is held by the signing service, RemoteSigner.PrivateKey returns nil and
+// RemoteSigner.SignHash returns ErrSignHashNotSupported.
+type RemoteSigner struct {
+	client  *rpc.Client
+	address common.Address
+	domain  *eip712.Domain
+	timeout time.Duration
+}
+
+// DialRemoteSigner connects to the signing service at the given URL and creates an instance of RemoteSigner
+// for the given account. If the address is zero, the first account managed by the service is used.
+func DialRemoteSigner(ctx context.Context, rawUrl string, address common.Address, chainId int64) (*RemoteSigner, error) {
+	client, err := rpc.DialContext(ctx, rawUrl)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to remote signer")
+	}
+	signer, err := NewRemoteSigner(ctx, client, address, chainId)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	return signer, nil
+}
+
+// NewRemoteSigner creates an instance of RemoteSigner for the given account using the RPC client connected
+// to the signing service. If the address is zero, the first account managed by the service is used.
+func NewRemoteSigner(ctx context.Context, client *rpc.Client, address common.Address, chainId int64) (*RemoteSigner, error) {
+	if address == (common.Address{}) {
+		var addresses []common.Address
+		if err := client.CallContext(ctx, &addresses, "eth_accounts"); err != nil {
+			return nil, errors.Wrap(err, "failed to get accounts from remote signer")
+		}
+		if len(addresses) == 0 {
+			return nil, errors.New("remote signer does not manage any account")
+		}
+		address = addresses[0]
+	}
+	return &RemoteSigner{
+		client:  client,
+		address: address,
+		domain:  eip712.ZkSyncEraEIP712Domain(chainId),
+		timeout: 30 * time.Second,
+	}, nil
+}
+
+// SetTimeout sets the timeout of the signing requests sent to the signing service. Default timeout is 30 seconds.
+// Signing services which require manual approval of requests, such as Clef, might need a longer timeout.
+func (s *RemoteSigner) SetTimeout(timeout time.Duration) {
+	s.timeout = timeout
+}
+
+// Close closes the connection to the signing service.
+func (s *RemoteSigner) Close() {
+	s.client.Close()
+}
+
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *RemoteSigner) Domain() *eip712.Domain {
+	return s.domain
+}
+
+func (s *RemoteSigner) PrivateKey() *ecdsa.PrivateKey {
+	return nil
+}
+
+func (s *RemoteSigner) SignHash(_ []byte) ([]byte, error) {
+	return nil, ErrSignHashNotSupported
+}
+
+func (s *RemoteSigner) SignTypedData(domain *eip712.Domain, data eip712.TypedData) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	return s.SignTypedDataContext(ctx, domain, data)
+}
+
+// SignTypedDataContext is like SignTypedData, but with context.
+func (s *RemoteSigner) SignTypedDataContext(ctx context.Context, domain *eip712.Domain, data eip712.TypedData) ([]byte, error) {
+	typedData, err := eip712.NewTypedData(domain, data)
+	if err != nil {
+		return nil, err
+	}
+	// Byte values are sent hex encoded, as expected by the signing services.
+	message := make(apitypes.TypedDataMessage, len(typedData.Message))
+	for k, v := range typedData.Message {
+		message[k] = remoteTypedValue(v)
+	}
+	typedData.Message = message
+
+	var sig hexutil.Bytes
+	if err = s.client.CallContext(ctx, &sig, "eth_signTypedData_v4", s.address, typedData); err != nil {
+		return nil, errors.Wrap(err, "failed to sign typed data with remote signer")
+	}
+	if len(sig) != crypto.SignatureLength {
+		return nil, fmt.Errorf("remote signer returned signature of invalid length %d", len(sig))
+	}
+	if sig[64] < 27 {
+		sig[64] += 27
+	}
+
+	// Make sure that the signature has been made by the expected account.
+	hash, err := eip712.HashTypedData(domain, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hash of typed data: %w", err)
+	}
+	recoverable := common.CopyBytes(sig)
+	recoverable[64] -= 27
+	publicKey, err := crypto.SigToPub(hash, recoverable)
+	if err != nil || crypto.PubkeyToAddress(*publicKey) != s.address {
+		return nil, errors.New("remote signer returned signature which does not match the account")
+	}
+	return sig, nil
+}
+
+// SignMessage signs the message as EIP-191 personal message using personal_sign.
+func (s *RemoteSigner) SignMessage(msg []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	var sig hexutil.Bytes
+	if err := s.client.CallContext(ctx, &sig, "personal_sign", hexutil.Bytes(msg), s.address); err != nil {
+		return nil, errors.Wrap(err, "failed to sign message with remote signer")
+	}
+	if len(sig) != crypto.SignatureLength {
+		return nil, fmt.Errorf("remote signer returned signature of invalid length %d", len(sig))
+	}
+	if sig[64] < 27 {
+		sig[64] += 27
+	}
+	if signer, err := utils.RecoverMessageSigner(msg, sig); err != nil || signer != s.address {
+		return nil, errors.New("remote signer returned signature which does not match the account")
+	}
+	return sig, nil
+}
+
+// SignTx signs the transaction using eth_signTransaction.
+func (s *RemoteSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	return s.SignTxContext(ctx, tx, chainID)
+}
+
+// SignTxContext is like SignTx, but with context.
+func (s *RemoteSigner) SignTxContext(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	data := hexutil.Bytes(tx.Data())
+	args := apitypes.SendTxArgs{
+		From:    common.NewMixedcaseAddress(s.address),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   hexutil.Big(*tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    &data,
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if tx.To() != nil {
+		to := common.NewMixedcaseAddress(*tx.To())
+		args.To = &to
+	}
+	switch tx.Type() {
+	case types.LegacyTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case types.AccessListTxType:
+		accessList := tx.AccessList()
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+		args.AccessList = &accessList
+	case types.DynamicFeeTxType:
+		accessList := tx.AccessList()
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+		args.AccessList = &accessList
+	default:
+		return nil, fmt.Errorf("transaction type %d is not supported by remote signer", tx.Type())
+	}
+
+	var result json.RawMessage
+	if err := s.client.CallContext(ctx, &result, "eth_signTransaction", args); err != nil {
+		return nil, errors.Wrap(err, "failed to sign transaction with remote signer")
+	}
+	raw, err := decodeSignTransactionResult(result)
+	if err != nil {
+		return nil, err
+	}
+	signedTx := new(types.Transaction)
+	if err = signedTx.UnmarshalBinary(raw); err != nil {
+		return nil, errors.Wrap(err, "failed to decode transaction signed by remote signer")
+	}
+	if signedTx.Nonce() != tx.Nonce() || signedTx.Gas() != tx.Gas() || signedTx.Value().Cmp(tx.Value()) != 0 ||
+		!bytes.Equal(signedTx.Data(), tx.Data()) || (signedTx.To() == nil) != (tx.To() == nil) ||
+		tx.To() != nil && *signedTx.To() != *tx.To() {
+		return nil, errors.New("remote signer returned transaction which does not match the requested one")
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signedTx)
+	if err != nil || sender != s.address {
+		return nil, errors.New("remote signer returned transaction which is not signed by the account")
+	}
+	return signedTx, nil
+}
+
+// decodeSignTransactionResult decodes the raw signed transaction from the eth_signTransaction result, which
+// is either the raw transaction itself (e.g. Web3Signer) or an object containing it (e.g. Clef).
+func decodeSignTransactionResult(result json.RawMessage) ([]byte, error) {
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(result, &raw); err == nil {
+		return raw, nil
+	}
+	var signed struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := json.Unmarshal(result, &signed); err != nil || len(signed.Raw) == 0 {
+		return nil, errors.New("failed to decode result of eth_signTransaction")
+	}
+	return signed.Raw, nil
+}
+
+// remoteTypedValue converts the value of the typed data message to its JSON representation
+// expected by the signing services.
+func remoteTypedValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case []byte:
+		return hexutil.Bytes(value)
+	case *big.Int:
+		return value.String()
+	case []interface{}:
+		values := make([]interface{}, len(value))
+		for i := range value {
+			values[i] = remoteTypedValue(value[i])
+		}
+		return values
+	default:
+		return v
+	}
+}
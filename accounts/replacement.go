@@ -0,0 +1,110 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"math/big"
+)
+
+// DefaultFeeBumpPercent is the percentage by which fees are increased when a transaction is canceled.
+// Nodes reject replacement transactions whose fees are not increased enough, which is commonly by 10%.
+const DefaultFeeBumpPercent = 10
+
+// ErrTransactionNotPending is returned when replacing a transaction which is not pending anymore.
+var ErrTransactionNotPending = errors.New("transaction is not pending")
+
+// SpeedUpTransaction replaces the pending transaction with the same one, having the fees increased
+// by the bumpPercent. If the suggested gas price is higher than the increased fee cap, it is used instead.
+// The replaced transaction must be sent by the associated account. Paymaster parameters and factory
+// dependencies of the replaced transaction are not returned by the network, thus they are not preserved.
+func (a *WalletL2) SpeedUpTransaction(ctx context.Context, txHash common.Hash, bumpPercent uint64) (common.Hash, error) {
+	ctx = ensureContext(ctx)
+	pending, err := a.pendingTransaction(ctx, txHash)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := a.replacementTransaction(ctx, pending, bumpPercent)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx.To = &pending.To
+	tx.Data = pending.Data
+	tx.Value = pending.Value.ToInt()
+	tx.Gas = uint64(pending.Gas)
+	return a.SendTransaction(ctx, tx)
+}
+
+// CancelTransaction replaces the pending transaction with the transfer of zero value to the associated
+// account, having the fees increased by DefaultFeeBumpPercent. The replaced transaction must be sent by
+// the associated account.
+func (a *WalletL2) CancelTransaction(ctx context.Context, txHash common.Hash) (common.Hash, error) {
+	ctx = ensureContext(ctx)
+	pending, err := a.pendingTransaction(ctx, txHash)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := a.replacementTransaction(ctx, pending, DefaultFeeBumpPercent)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	to := a.Address()
+	tx.To = &to
+	tx.Value = big.NewInt(0)
+	return a.SendTransaction(ctx, tx)
+}
+
+// pendingTransaction returns the pending transaction sent by the associated account.
+func (a *WalletL2) pendingTransaction(ctx context.Context, txHash common.Hash) (*zkTypes.TransactionResponse, error) {
+	tx, isPending, err := (*a.client).TransactionByHash(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	if !isPending {
+		return nil, fmt.Errorf("%w: %s", ErrTransactionNotPending, txHash)
+	}
+	if tx.From != a.Address() {
+		return nil, fmt.Errorf("transaction %s is not sent by %s", txHash, a.Address())
+	}
+	return tx, nil
+}
+
+// replacementTransaction returns the transaction using the nonce of the pending transaction, with
+// the fees increased by the bumpPercent.
+func (a *WalletL2) replacementTransaction(ctx context.Context, pending *zkTypes.TransactionResponse, bumpPercent uint64) (*Transaction, error) {
+	if bumpPercent == 0 {
+		return nil, errors.New("fee bump percent must be greater than zero")
+	}
+	gasFeeCap := pending.MaxFeePerGas.ToInt()
+	if gasFeeCap.Sign() == 0 {
+		// Legacy transactions only contain the gas price.
+		gasFeeCap = pending.GasPrice.ToInt()
+	}
+	gasFeeCap = bumpFee(gasFeeCap, bumpPercent)
+	gasTipCap := bumpFee(pending.MaxPriorityFeePerGas.ToInt(), bumpPercent)
+
+	suggested, err := gasPrice(ctx, a.gasOracle, *a.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to SuggestGasPrice: %w", err)
+	}
+	if suggested.Cmp(gasFeeCap) > 0 {
+		gasFeeCap = suggested
+	}
+	if gasTipCap.Cmp(gasFeeCap) > 0 {
+		gasTipCap = new(big.Int).Set(gasFeeCap)
+	}
+	return &Transaction{
+		Nonce:     new(big.Int).SetUint64(uint64(pending.Nonce)),
+		GasFeeCap: gasFeeCap,
+		GasTipCap: gasTipCap,
+	}, nil
+}
+
+// bumpFee returns the fee increased by the percent, rounded up.
+func bumpFee(fee *big.Int, percent uint64) *big.Int {
+	bumped := new(big.Int).Mul(fee, new(big.Int).SetUint64(100+percent))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
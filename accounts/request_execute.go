@@ -0,0 +1,67 @@
+package accounts
+
+import (
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+)
+
+// RequestExecuteEstimate contains the fee breakdown of the L1 -> L2 transaction prepared by
+// RequestExecuteTransactionWithEstimate, allowing the costs to be shown before the transaction is sent.
+type RequestExecuteEstimate struct {
+	L2GasLimit        *big.Int // Maximum amount of L2 gas that the transaction can consume during execution on L2.
+	GasPerPubdataByte *big.Int // Maximum amount of L2 gas that the operator may charge for single byte of pubdata.
+	OperatorTip       *big.Int // The tip the operator receives on top of the base cost.
+	BaseCost          *big.Int // The base cost of the L2 transaction, under the L1 gas price of the transaction.
+	L2Value           *big.Int // `msg.value` of the L2 transaction.
+	// The total value sent with the L1 transaction: BaseCost + OperatorTip + L2Value, unless the value
+	// has been explicitly provided.
+	Value *big.Int
+
+	GasPrice  *big.Int // Gas price of the L1 transaction if legacy transaction is used.
+	GasFeeCap *big.Int // MaxFeePerGas of the L1 transaction if 1559 transaction is used.
+	GasTipCap *big.Int // MaxPriorityFeePerGas of the L1 transaction if 1559 transaction is used.
+
+	// The transaction with all fields populated, which is sent by Send.
+	Transaction RequestExecuteTransaction
+
+	wallet *WalletL1
+	opts   *TransactOpts
+}
+
+// Send sends the prepared L1 -> L2 transaction with the estimated fees.
+func (e *RequestExecuteEstimate) Send() (*types.Transaction, error) {
+	return e.wallet.requestExecute(e.opts, &e.Transaction)
+}
+
+// RequestExecuteTransactionWithEstimate prepares the L1 -> L2 transaction the same way as RequestExecute,
+// without sending it, and returns the breakdown of its fee. The transaction can be sent using
+// RequestExecuteEstimate.Send, which uses the estimated L2 gas limit and the L1 gas price.
+func (a *WalletL1) RequestExecuteTransactionWithEstimate(auth *TransactOpts, tx RequestExecuteTransaction) (*RequestExecuteEstimate, error) {
+	opts, requestExecuteTx, err := a.prepareRequestExecuteTx(*ensureTransactOpts(auth), tx)
+	if err != nil {
+		return nil, err
+	}
+	gasPriceForEstimation := opts.GasFeeCap
+	if gasPriceForEstimation == nil {
+		gasPriceForEstimation = opts.GasPrice
+	}
+	baseCost, err := a.BaseCost(&CallOpts{Context: opts.Context},
+		requestExecuteTx.L2GasLimit, requestExecuteTx.GasPerPubdataByte, gasPriceForEstimation)
+	if err != nil {
+		return nil, err
+	}
+	return &RequestExecuteEstimate{
+		L2GasLimit:        requestExecuteTx.L2GasLimit,
+		GasPerPubdataByte: requestExecuteTx.GasPerPubdataByte,
+		OperatorTip:       requestExecuteTx.OperatorTip,
+		BaseCost:          baseCost,
+		L2Value:           requestExecuteTx.L2Value,
+		Value:             opts.Value,
+		GasPrice:          opts.GasPrice,
+		GasFeeCap:         opts.GasFeeCap,
+		GasTipCap:         opts.GasTipCap,
+		Transaction:       *requestExecuteTx,
+		wallet:            a,
+		opts:              opts,
+	}, nil
+}
@@ -0,0 +1,122 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+)
+
+// ErrChainedTransactionFailed is returned by SendChain when the transaction of the step has been included,
+// but it has failed, and SendChainOptions.RequireSuccess is enabled.
+var ErrChainedTransactionFailed = errors.New("chained transaction failed")
+
+// ChainedTransaction is a single step of the sequence of dependent transactions sent by SendChain.
+type ChainedTransaction struct {
+	Name string // Name of the step used in progress reports and errors. Optional.
+	// Transaction sent in the step. Unset fields, including fees and nonce, are prepared right before
+	// the transaction is signed, after the previous steps have been included. It is ignored if Build is set.
+	Transaction Transaction
+	// Build returns the transaction sent in the step, given the receipts of the previous steps, e.g. to use
+	// the address of a contract deployed in a previous step. Optional.
+	Build func(ctx context.Context, receipts []*zkTypes.Receipt) (*Transaction, error)
+}
+
+// SendChainStage is the stage of the step reported to SendChainOptions.Progress.
+type SendChainStage int
+
+const (
+	ChainStepSent     SendChainStage = iota // The transaction of the step has been sent.
+	ChainStepIncluded                       // The transaction of the step has been included in a block.
+)
+
+// SendChainProgress reports the progress of SendChain.
+type SendChainProgress struct {
+	Step    int // Index of the step.
+	Total   int // Total number of steps.
+	Name    string
+	Stage   SendChainStage
+	TxHash  common.Hash
+	Receipt *zkTypes.Receipt // Receipt of the transaction, set in ChainStepIncluded stage.
+}
+
+// SendChainOptions configures SendChain.
+type SendChainOptions struct {
+	// RequireSuccess aborts the chain if the transaction of any step is included, but failed.
+	// Otherwise, the next step is sent once the previous transaction is included, regardless of its status.
+	RequireSuccess bool
+	// Progress is called after each transaction is sent and included. Optional.
+	Progress func(progress SendChainProgress)
+}
+
+// SendChainError is returned by SendChain when the chain is aborted.
+type SendChainError struct {
+	Step   int         // Index of the step which has caused the abort.
+	Name   string      // Name of the step.
+	TxHash common.Hash // Hash of the transaction of the step, zero if the transaction has not been sent.
+	Err    error
+}
+
+func (e *SendChainError) Error() string {
+	step := fmt.Sprintf("step %d", e.Step)
+	if e.Name != "" {
+		step = fmt.Sprintf("step %d (%s)", e.Step, e.Name)
+	}
+	if e.TxHash != (common.Hash{}) {
+		return fmt.Sprintf("%s, transaction %s: %v", step, e.TxHash, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", step, e.Err)
+}
+
+func (e *SendChainError) Unwrap() error {
+	return e.Err
+}
+
+// SendChain sends the sequence of dependent transactions, e.g. approve, swap and bridge. Each transaction
+// is signed and sent only after the transaction of the previous step has been included, so that it is
+// prepared with fresh fees against the state produced by the previous steps. The chain is aborted on
+// the first error, which is returned as *SendChainError along with the receipts of the completed steps.
+func (a *WalletL2) SendChain(ctx context.Context, steps []ChainedTransaction, opts *SendChainOptions) ([]*zkTypes.Receipt, error) {
+	ctx = ensureContext(ctx)
+	if opts == nil {
+		opts = &SendChainOptions{}
+	}
+	receipts := make([]*zkTypes.Receipt, 0, len(steps))
+	report := func(progress SendChainProgress) {
+		if opts.Progress != nil {
+			progress.Total = len(steps)
+			opts.Progress(progress)
+		}
+	}
+	for i, step := range steps {
+		abort := func(txHash common.Hash, err error) ([]*zkTypes.Receipt, error) {
+			return receipts, &SendChainError{Step: i, Name: step.Name, TxHash: txHash, Err: err}
+		}
+		tx := &step.Transaction
+		if step.Build != nil {
+			var err error
+			if tx, err = step.Build(ctx, receipts); err != nil {
+				return abort(common.Hash{}, fmt.Errorf("failed to build transaction: %w", err))
+			}
+		}
+		txHash, err := a.SendTransaction(ctx, tx)
+		if err != nil {
+			return abort(common.Hash{}, err)
+		}
+		report(SendChainProgress{Step: i, Name: step.Name, Stage: ChainStepSent, TxHash: txHash})
+
+		receipt, err := (*a.client).WaitMined(ctx, txHash)
+		a.hooks.minedL2(txHash, receipt, err)
+		if err != nil {
+			return abort(txHash, err)
+		}
+		receipts = append(receipts, receipt)
+		report(SendChainProgress{Step: i, Name: step.Name, Stage: ChainStepIncluded, TxHash: txHash, Receipt: receipt})
+		if opts.RequireSuccess && receipt.Status != types.ReceiptStatusSuccessful {
+			return abort(txHash, ErrChainedTransactionFailed)
+		}
+	}
+	return receipts, nil
+}
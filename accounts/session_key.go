@@ -0,0 +1,273 @@
+package accounts
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/zksync-sdk/zksync2-go/eip712"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// ErrSessionScope is returned by SessionSigner when the transaction is not permitted by the scope of the session key.
+var ErrSessionScope = errors.New("transaction is out of session key scope")
+
+// sessionKeyAccountABI is the ABI of the part of the smart account managing session keys. The account stores the hash
+// of the scope of each registered key, and validates transactions signed by the session key using the signature
+// produced by SessionSigner, whose encoding is described by the arguments of sessionSignature.
+//
+// The ABI is an example interface defined by this package. It is not the ABI of any deployed session key validator,
+// e.g. the SessionKeyValidator module of ZKsync SSO, so the session keys work only with
+// the smart accounts implementing this interface.
+const sessionKeyAccountABI = `[
+	{"type":"function","name":"registerSessionKey","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"sessionKey","type":"address"},
+		{"name":"scope","type":"tuple","components":[
+			{"name":"targets","type":"address[]"},
+			{"name":"selectors","type":"bytes4[]"},
+			{"name":"maxValue","type":"uint256"},
+			{"name":"validAfter","type":"uint64"},
+			{"name":"validUntil","type":"uint64"}]}]},
+	{"type":"function","name":"revokeSessionKey","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"sessionKey","type":"address"}]},
+	{"type":"function","name":"sessionSignature","stateMutability":"pure","outputs":[],"inputs":[
+		{"name":"signature","type":"bytes"},
+		{"name":"sessionKey","type":"address"},
+		{"name":"scope","type":"tuple","components":[
+			{"name":"targets","type":"address[]"},
+			{"name":"selectors","type":"bytes4[]"},
+			{"name":"maxValue","type":"uint256"},
+			{"name":"validAfter","type":"uint64"},
+			{"name":"validUntil","type":"uint64"}]}]}
+]`
+
+// SessionScope limits the transactions which can be signed by the session key.
+type SessionScope struct {
+	Targets    []common.Address // Contracts which can be called. Any contract can be called if empty.
+	Selectors  [][4]byte        // Methods which can be called. Any method can be called if empty.
+	MaxValue   *big.Int         // Maximum value of a single transaction. Nil means no value can be transferred.
+	ValidAfter uint64           // Unix timestamp since which the key is valid.
+	ValidUntil uint64           // Unix timestamp until which the key is valid. Zero means no expiration.
+}
+
+// abiScope returns the scope in the form used by the ABI encoder.
+func (s *SessionScope) abiScope() interface{} {
+	maxValue := s.MaxValue
+	if maxValue == nil {
+		maxValue = big.NewInt(0)
+	}
+	return struct {
+		Targets    []common.Address
+		Selectors  [][4]byte
+		MaxValue   *big.Int
+		ValidAfter uint64
+		ValidUntil uint64
+	}{
+		Targets:    append([]common.Address{}, s.Targets...),
+		Selectors:  append([][4]byte{}, s.Selectors...),
+		MaxValue:   maxValue,
+		ValidAfter: s.ValidAfter,
+		ValidUntil: s.ValidUntil,
+	}
+}
+
+// Hash returns the hash of the ABI-encoded scope, which the account stores for the registered key.
+func (s *SessionScope) Hash() (common.Hash, error) {
+	method, err := sessionKeyMethod("registerSessionKey")
+	if err != nil {
+		return common.Hash{}, err
+	}
+	encoded, err := abi.Arguments{method.Inputs[1]}.Pack(s.abiScope())
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to encode session scope: %w", err)
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+// Permits returns an error wrapping ErrSessionScope if the transaction is not permitted by the scope at the time.
+func (s *SessionScope) Permits(tx *zkTypes.Transaction712, at time.Time) error {
+	now := uint64(at.Unix())
+	if now < s.ValidAfter || s.ValidUntil != 0 && now > s.ValidUntil {
+		return fmt.Errorf("%w: session key is not valid at %s", ErrSessionScope, at.UTC().Format(time.RFC3339))
+	}
+	if tx.To == nil {
+		return fmt.Errorf("%w: contract deployment", ErrSessionScope)
+	}
+	if len(s.Targets) > 0 && !containsAddress(s.Targets, *tx.To) {
+		return fmt.Errorf("%w: target %s", ErrSessionScope, tx.To)
+	}
+	if len(s.Selectors) > 0 {
+		permitted := false
+		for _, selector := range s.Selectors {
+			if len(tx.Data) >= 4 && [4]byte(tx.Data[:4]) == selector {
+				permitted = true
+				break
+			}
+		}
+		if !permitted {
+			return fmt.Errorf("%w: method is not permitted", ErrSessionScope)
+		}
+	}
+	if tx.Value != nil && tx.Value.Sign() > 0 && (s.MaxValue == nil || tx.Value.Cmp(s.MaxValue) > 0) {
+		return fmt.Errorf("%w: value %s exceeds maximum", ErrSessionScope, tx.Value)
+	}
+	return nil
+}
+
+// DeriveSessionKey derives the private key of the session key with the given scope from the signature of
+// the scope hash and the index made by the master signer. The derivation is deterministic, so that the session
+// key can be derived again instead of being stored, and different scopes or indexes derive different keys.
+func DeriveSessionKey(master Signer, scope *SessionScope, index uint64) (*ecdsa.PrivateKey, error) {
+	scopeHash, err := scope.Hash()
+	if err != nil {
+		return nil, err
+	}
+	message := crypto.Keccak256(
+		[]byte("zksync session key"),
+		scopeHash.Bytes(),
+		new(big.Int).SetUint64(index).FillBytes(make([]byte, 32)),
+	)
+	sig, err := master.SignHash(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign session key derivation: %w", err)
+	}
+	key, err := crypto.ToECDSA(crypto.Keccak256(sig))
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive session key: %w", err)
+	}
+	return key, nil
+}
+
+// RegisterSessionKey registers the session key with the given scope in the smart account, by sending
+// the transaction using the wallet of the account, which is signed by the master key. The account must implement
+// the example session key interface of this package, see SessionSigner.
+func RegisterSessionKey(ctx context.Context, wallet AdapterL2, sessionKey common.Address, scope *SessionScope) (common.Hash, error) {
+	data, err := packSessionKeyCall("registerSessionKey", sessionKey, scope.abiScope())
+	if err != nil {
+		return common.Hash{}, err
+	}
+	account := wallet.Address()
+	return wallet.SendTransaction(ctx, &Transaction{To: &account, Data: data})
+}
+
+// RevokeSessionKey revokes the session key registered in the smart account.
+func RevokeSessionKey(ctx context.Context, wallet AdapterL2, sessionKey common.Address) (common.Hash, error) {
+	data, err := packSessionKeyCall("revokeSessionKey", sessionKey)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	account := wallet.Address()
+	return wallet.SendTransaction(ctx, &Transaction{To: &account, Data: data})
+}
+
+// SessionSigner implements the Signer interface for the smart account using the registered session key, so that
+// applications can sign transactions within the scope of the key without access to the master key. Transactions
+// are checked against the scope before they are signed, and the signature is the ABI encoding of the ECDSA signature
+// of the session key, the address of the session key and the scope, which the account validates against
+// the registered scope hash.
+//
+// Since the account is a contract, SessionSigner.PrivateKey returns nil, and the signer can be used only
+// for signing EIP-712 transactions.
+//
+// SessionSigner, RegisterSessionKey and RevokeSessionKey are an example of session keys, which use the interface
+// of the smart account defined by this package rather than the one of a deployed session key validator, such as
+// the SessionKeyValidator module of ZKsync SSO. They can be used with the accounts implementing the interface,
+// or as the starting point for supporting a particular validator.
+type SessionSigner struct {
+	account common.Address
+	key     *ecdsa.PrivateKey
+	address common.Address
+	scope   SessionScope
+	domain  *eip712.Domain
+}
+
+// NewSessionSigner creates an instance of SessionSigner for the account using the session key with the scope.
+func NewSessionSigner(account common.Address, sessionKey *ecdsa.PrivateKey, scope SessionScope, chainId int64) *SessionSigner {
+	return &SessionSigner{
+		account: account,
+		key:     sessionKey,
+		address: crypto.PubkeyToAddress(sessionKey.PublicKey),
+		scope:   scope,
+		domain:  eip712.ZkSyncEraEIP712Domain(chainId),
+	}
+}
+
+// SessionKey returns the address of the session key.
+func (s *SessionSigner) SessionKey() common.Address {
+	return s.address
+}
+
+// Scope returns the scope of the session key.
+func (s *SessionSigner) Scope() SessionScope {
+	return s.scope
+}
+
+func (s *SessionSigner) Address() common.Address {
+	return s.account
+}
+
+func (s *SessionSigner) Domain() *eip712.Domain {
+	return s.domain
+}
+
+func (s *SessionSigner) PrivateKey() *ecdsa.PrivateKey {
+	return nil
+}
+
+// SignHash signs the hash using the session key and encodes the scope proof.
+func (s *SessionSigner) SignHash(msg []byte) ([]byte, error) {
+	sig, err := crypto.Sign(msg, s.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign hash with session key: %w", err)
+	}
+	sig[64] += 27
+	method, err := sessionKeyMethod("sessionSignature")
+	if err != nil {
+		return nil, err
+	}
+	proof, err := method.Inputs.Pack(sig, s.address, s.scope.abiScope())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode session signature: %w", err)
+	}
+	return proof, nil
+}
+
+// SignTypedData signs the typed data, having checked that the transaction is permitted by the scope.
+func (s *SessionSigner) SignTypedData(domain *eip712.Domain, data eip712.TypedData) ([]byte, error) {
+	if tx, ok := data.(*zkTypes.Transaction712); ok {
+		if err := s.scope.Permits(tx, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	hash, err := eip712.HashTypedData(domain, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hash of typed data: %w", err)
+	}
+	return s.SignHash(hash)
+}
+
+func sessionKeyMethod(name string) (abi.Method, error) {
+	parsed, err := abi.JSON(strings.NewReader(sessionKeyAccountABI))
+	if err != nil {
+		return abi.Method{}, fmt.Errorf("failed to load session key account ABI: %w", err)
+	}
+	return parsed.Methods[name], nil
+}
+
+func packSessionKeyCall(name string, args ...interface{}) ([]byte, error) {
+	parsed, err := abi.JSON(strings.NewReader(sessionKeyAccountABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load session key account ABI: %w", err)
+	}
+	data, err := parsed.Pack(name, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	return data, nil
+}
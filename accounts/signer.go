@@ -3,13 +3,13 @@ package accounts
 import (
 	"crypto/ecdsa"
 	"fmt"
-	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
-	"github.com/miguelmota/go-ethereum-hdwallet"
 	"github.com/pkg/errors"
 	"github.com/zksync-sdk/zksync2-go/eip712"
+	"math/big"
 )
 
 // Signer provides support for signing EIP-712 transactions as well as other types of transactions supported by
@@ -29,6 +29,14 @@ type Signer interface {
 	SignTypedData(d *eip712.Domain, data eip712.TypedData) ([]byte, error)
 }
 
+// TransactionSigner is implemented by signers which are not able to sign arbitrary hashes, such as hardware
+// wallets, and sign whole transactions instead. If the Signer implements this interface, it is used for
+// signing transactions other than EIP-712 transactions.
+type TransactionSigner interface {
+	// SignTx signs the transaction for the network with the given chain ID.
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
 // BaseSigner represents basis implementation of Signer interface.
 type BaseSigner struct {
 	pk      *ecdsa.PrivateKey
@@ -37,35 +45,22 @@ type BaseSigner struct {
 }
 
 // NewBaseSignerFromMnemonic creates a new instance of BaseSigner based on the provided mnemonic phrase.
+//
+// Deprecated: Deprecated in favor of v2/accounts.NewSigner with SignerOptions.Mnemonic.
 func NewBaseSignerFromMnemonic(mnemonic string, chainId int64) (*BaseSigner, error) {
 	return NewBaseSignerFromMnemonicAndAccountId(mnemonic, 0, chainId)
 }
 
 // NewBaseSignerFromMnemonicAndAccountId creates a new instance of BaseSigner based on the provided mnemonic phrase and
 // account ID.
+//
+// Deprecated: Deprecated in favor of v2/accounts.NewSigner with SignerOptions.Mnemonic and SignerOptions.DerivationPath.
 func NewBaseSignerFromMnemonicAndAccountId(mnemonic string, accountId uint32, chainId int64) (*BaseSigner, error) {
-	wallet, err := hdwallet.NewFromMnemonic(mnemonic)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create HD wallet from mnemonic")
-	}
-	path, err := accounts.ParseDerivationPath(fmt.Sprintf("m/44'/60'/0'/0/%d", accountId))
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to parse derivation path")
-	}
-	account, err := wallet.Derive(path, true)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to derive account from HD wallet")
-	}
-	pk, err := wallet.PrivateKey(account)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get account's private key from HD wallet")
-	}
-	pub := pk.Public().(*ecdsa.PublicKey)
-	return &BaseSigner{
-		pk:      pk,
-		address: crypto.PubkeyToAddress(*pub),
-		domain:  eip712.ZkSyncEraEIP712Domain(chainId),
-	}, nil
+	return NewSignerWithOptions(SignerOptions{
+		Mnemonic:       mnemonic,
+		DerivationPath: fmt.Sprintf("m/44'/60'/0'/0/%d", accountId),
+		ChainID:        chainId,
+	})
 }
 
 // NewBaseSignerFromRawPrivateKey creates a new instance of BaseSigner based on the provided raw private key.
@@ -112,20 +107,10 @@ func (s *BaseSigner) PrivateKey() *ecdsa.PrivateKey {
 }
 
 func (s *BaseSigner) SignTypedData(domain *eip712.Domain, data eip712.TypedData) ([]byte, error) {
-	// compile TypedData structure
-	eip712Msg, err := data.EIP712Message()
+	typedData, err := eip712.NewTypedData(domain, data)
 	if err != nil {
 		return nil, err
 	}
-	typedData := apitypes.TypedData{
-		Types: apitypes.Types{
-			data.EIP712Type():   data.EIP712Types(),
-			domain.EIP712Type(): domain.EIP712Types(),
-		},
-		PrimaryType: data.EIP712Type(),
-		Domain:      domain.EIP712Domain(),
-		Message:     eip712Msg,
-	}
 	hash, err := s.HashTypedData(typedData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get hash of typed data: %w", err)
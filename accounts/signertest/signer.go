@@ -0,0 +1,169 @@
+// Package signertest provides deterministic signers and signature recording utilities
+// for writing byte-exact golden tests of code which signs zkSync Era transactions.
+//
+// Signatures produced by accounts.BaseSigner are deterministic, since the nonce is derived
+// from the private key and the signed hash as specified by RFC 6979. This package adds
+// fixture-friendly construction of signers with well-known keys and the ability to record
+// and compare produced signatures.
+package signertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/zksync-sdk/zksync2-go/accounts"
+	"github.com/zksync-sdk/zksync2-go/eip712"
+	"os"
+	"sync"
+)
+
+// Mnemonic is the well-known mnemonic used by local development networks.
+// It must never be used to hold real funds.
+const Mnemonic = "test test test test test test test test test test test junk"
+
+// NewSigner creates a signer for the account with the given index derived from Mnemonic
+// using the BIP-44 derivation path m/44'/60'/0'/0/index.
+func NewSigner(index uint32, chainId int64) (*accounts.BaseSigner, error) {
+	return accounts.NewSignerWithOptions(accounts.SignerOptions{
+		Mnemonic:       Mnemonic,
+		DerivationPath: fmt.Sprintf("m/44'/60'/0'/0/%d", index),
+		ChainID:        chainId,
+	})
+}
+
+// NewSignerFromSeed creates a signer whose private key is deterministically derived from the seed,
+// so that each test can use its own stable account, e.g. NewSignerFromSeed("alice", 270).
+func NewSignerFromSeed(seed string, chainId int64) (*accounts.BaseSigner, error) {
+	key := crypto.Keccak256([]byte(seed))
+	for {
+		signer, err := accounts.NewBaseSignerFromRawPrivateKey(key, chainId)
+		if err == nil {
+			return signer, nil
+		}
+		// The hash is not a valid private key, which is extremely unlikely, so keep hashing.
+		key = crypto.Keccak256(key)
+	}
+}
+
+// MustNewSignerFromSeed is like NewSignerFromSeed but panics on error.
+func MustNewSignerFromSeed(seed string, chainId int64) *accounts.BaseSigner {
+	signer, err := NewSignerFromSeed(seed, chainId)
+	if err != nil {
+		panic(err)
+	}
+	return signer
+}
+
+// Record represents a single signature produced by RecordingSigner.
+type Record struct {
+	Method    string        `json:"method"`    // Name of the signing method, SignHash or SignTypedData.
+	Digest    hexutil.Bytes `json:"digest"`    // Signed hash.
+	Signature hexutil.Bytes `json:"signature"` // Produced signature.
+}
+
+// RecordingSigner wraps a Signer and records all produced signatures, which can be stored as golden
+// files and compared in subsequent test runs.
+type RecordingSigner struct {
+	accounts.Signer
+
+	mu      sync.Mutex
+	records []Record
+}
+
+// NewRecordingSigner creates an instance of RecordingSigner which wraps the given signer.
+func NewRecordingSigner(signer accounts.Signer) *RecordingSigner {
+	return &RecordingSigner{Signer: signer}
+}
+
+func (s *RecordingSigner) SignHash(msg []byte) ([]byte, error) {
+	sig, err := s.Signer.SignHash(msg)
+	if err != nil {
+		return nil, err
+	}
+	s.record("SignHash", msg, sig)
+	return sig, nil
+}
+
+func (s *RecordingSigner) SignTypedData(d *eip712.Domain, data eip712.TypedData) ([]byte, error) {
+	hash, err := eip712.HashTypedData(d, data)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := s.Signer.SignTypedData(d, data)
+	if err != nil {
+		return nil, err
+	}
+	s.record("SignTypedData", hash, sig)
+	return sig, nil
+}
+
+// Records returns all signatures produced so far, in the order in which they were produced.
+func (s *RecordingSigner) Records() []Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records := make([]Record, len(s.records))
+	copy(records, s.records)
+	return records
+}
+
+// Reset removes all recorded signatures.
+func (s *RecordingSigner) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = nil
+}
+
+func (s *RecordingSigner) record(method string, digest, sig []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, Record{
+		Method:    method,
+		Digest:    bytes.Clone(digest),
+		Signature: bytes.Clone(sig),
+	})
+}
+
+// SaveRecords stores the records as JSON golden file at the given path.
+func SaveRecords(path string, records []Record) error {
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// LoadRecords loads the records from JSON golden file at the given path.
+func LoadRecords(path string) ([]Record, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var records []Record
+	if err = json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to decode records: %w", err)
+	}
+	return records, nil
+}
+
+// CompareRecords returns an error describing the first difference between the expected and actual records,
+// or nil if they are equal.
+func CompareRecords(expected, actual []Record) error {
+	for i := 0; i < len(expected) && i < len(actual); i++ {
+		e, a := expected[i], actual[i]
+		if e.Method != a.Method {
+			return fmt.Errorf("record %d: expected method %s, got %s", i, e.Method, a.Method)
+		}
+		if !bytes.Equal(e.Digest, a.Digest) {
+			return fmt.Errorf("record %d: expected digest %s, got %s", i, e.Digest, a.Digest)
+		}
+		if !bytes.Equal(e.Signature, a.Signature) {
+			return fmt.Errorf("record %d: expected signature %s, got %s", i, e.Signature, a.Signature)
+		}
+	}
+	if len(expected) != len(actual) {
+		return fmt.Errorf("expected %d records, got %d", len(expected), len(actual))
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package accounts
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/zksync-sdk/zksync2-go/contracts/contractdeployer"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"math/big"
+)
+
+// DeploySmartAccountOptions configures Wallet.DeploySmartAccount.
+type DeploySmartAccountOptions struct {
+	// Calldata of the constructor of the account. Optional, the owners encoded as address[] by default.
+	Calldata []byte
+	// Salt used to deploy the account using CREATE2. Optional, random salt is used by default.
+	Salt []byte
+	// UseCreate deploys the account using CREATE instead of CREATE2, in which case the Salt is ignored.
+	UseCreate bool
+	// Dependencies contains the bytecode of contracts deployed by the account.
+	Dependencies [][]byte
+	// Funding is the amount of the base token transferred to the account once it is deployed. Optional.
+	Funding *big.Int
+	// Signer returns the signer of the deployed account, e.g. MultisigSigner, used to create the wallet of
+	// the account. Optional, the wallet is not created by default.
+	Signer func(account common.Address) (Signer, error)
+}
+
+// SmartAccountDeployment is the result of Wallet.DeploySmartAccount.
+type SmartAccountDeployment struct {
+	Address    common.Address // The address of the deployed account.
+	TxHash     common.Hash    // Hash of the deployment transaction.
+	FundTxHash common.Hash    // Hash of the funding transaction, zero if the account has not been funded.
+	// Wallet of the account, connected to the networks of the deploying wallet, if DeploySmartAccountOptions.Signer
+	// is provided. The configuration of the deploying wallet, such as the nonce manager, is not applied to it.
+	Wallet *Wallet
+}
+
+// DeploySmartAccount deploys the smart account with the given bytecode and owners through ContractDeployer,
+// using create2Account or createAccount, waits for the deployment, funds the account and returns the wallet
+// of the account, ready to send transactions.
+func (w *Wallet) DeploySmartAccount(ctx context.Context, bytecode []byte, owners []common.Address, opts *DeploySmartAccountOptions) (*SmartAccountDeployment, error) {
+	ctx = ensureContext(ctx)
+	if w.clientL2 == nil {
+		return nil, errors.New("wallet is not connected to L2 network")
+	}
+	if opts == nil {
+		opts = &DeploySmartAccountOptions{}
+	}
+	calldata := opts.Calldata
+	if calldata == nil {
+		addressArray, err := abi.NewType("address[]", "", nil)
+		if err != nil {
+			return nil, err
+		}
+		if calldata, err = (abi.Arguments{{Type: addressArray}}).Pack(owners); err != nil {
+			return nil, fmt.Errorf("failed to encode account owners: %w", err)
+		}
+	}
+
+	auth := &TransactOpts{Context: ctx}
+	var (
+		hash common.Hash
+		err  error
+	)
+	if opts.UseCreate {
+		hash, err = w.DeployAccountWithCreate(auth, CreateTransaction{
+			Bytecode:     bytecode,
+			Calldata:     calldata,
+			Dependencies: opts.Dependencies,
+		})
+	} else {
+		salt := opts.Salt
+		if salt == nil {
+			salt = make([]byte, 32)
+			if _, err = rand.Read(salt); err != nil {
+				return nil, fmt.Errorf("failed to generate salt: %w", err)
+			}
+		}
+		hash, err = w.DeployAccount(auth, Create2Transaction{
+			Bytecode:     bytecode,
+			Calldata:     calldata,
+			Salt:         salt,
+			Dependencies: opts.Dependencies,
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy account: %w", err)
+	}
+	receipt, err := (*w.clientL2).WaitMined(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("deployment of account %s failed", hash)
+	}
+	account, err := deployedAddress(receipt, bytecode)
+	if err != nil {
+		return nil, err
+	}
+	deployment := &SmartAccountDeployment{Address: account, TxHash: hash}
+
+	if opts.Funding != nil && opts.Funding.Sign() > 0 {
+		fundTx, err := w.Transfer(auth, TransferTransaction{To: account, Amount: opts.Funding, Token: utils.L2BaseTokenAddress})
+		if err != nil {
+			return deployment, fmt.Errorf("failed to fund account: %w", err)
+		}
+		deployment.FundTxHash = fundTx.Hash()
+		if _, err = (*w.clientL2).WaitMined(ctx, deployment.FundTxHash); err != nil {
+			return deployment, err
+		}
+	}
+
+	if opts.Signer != nil {
+		signer, err := opts.Signer(account)
+		if err != nil {
+			return deployment, err
+		}
+		if deployment.Wallet, err = NewWalletFromSigner(&signer, w.clientL2, w.clientL1); err != nil {
+			return deployment, err
+		}
+	}
+	return deployment, nil
+}
+
+// deployedAddress returns the address of the contract with the bytecode deployed by the transaction.
+func deployedAddress(receipt *zkTypes.Receipt, bytecode []byte) (common.Address, error) {
+	bytecodeHash, err := utils.HashBytecode(bytecode)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to hash bytecode: %w", err)
+	}
+	deployer, err := contractdeployer.NewContractDeployerFilterer(utils.ContractDeployerAddress, nil)
+	if err != nil {
+		return common.Address{}, err
+	}
+	for _, log := range receipt.Logs {
+		if log.Address != utils.ContractDeployerAddress {
+			continue
+		}
+		deployed, err := deployer.ParseContractDeployed(log.Log)
+		if err != nil || common.BytesToHash(bytecodeHash) != deployed.BytecodeHash {
+			continue
+		}
+		return deployed.ContractAddress, nil
+	}
+	return common.Address{}, errors.New("deployed account not found in transaction logs")
+}
@@ -0,0 +1,505 @@
+package accounts
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/zksync-sdk/zksync2-go/contracts/bridgehub"
+	"github.com/zksync-sdk/zksync2-go/contracts/erc1155"
+	"github.com/zksync-sdk/zksync2-go/contracts/erc20"
+	"github.com/zksync-sdk/zksync2-go/contracts/erc721"
+	"github.com/zksync-sdk/zksync2-go/contracts/ethtoken"
+	"github.com/zksync-sdk/zksync2-go/contracts/l1bridge"
+	"github.com/zksync-sdk/zksync2-go/contracts/l2bridge"
+	"github.com/zksync-sdk/zksync2-go/contracts/multicall3"
+	"github.com/zksync-sdk/zksync2-go/contracts/zksync"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"math/big"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrFeeLimitExceeded is matched by SpendingLimitError when the fee of the transaction exceeds the limit.
+	ErrFeeLimitExceeded = errors.New("fee limit exceeded")
+	// ErrValueLimitExceeded is matched by SpendingLimitError when the value of the transaction exceeds the limit.
+	ErrValueLimitExceeded = errors.New("value limit exceeded")
+	// ErrTokenLimitExceeded is matched by SpendingLimitError when the amount of the token transferred
+	// or approved by the transaction exceeds the limit.
+	ErrTokenLimitExceeded = errors.New("token limit exceeded")
+	// ErrDailyVolumeExceeded is matched by SpendingLimitError when the value of the transaction, added to
+	// the value sent within the last 24 hours, exceeds the limit.
+	ErrDailyVolumeExceeded = errors.New("daily volume exceeded")
+	// ErrUnknownTokenCall is returned by SpendingLimits when the transaction calls a token limited by
+	// MaxTokenAmountPerTx, but the calldata is not the one of a token call the amount can be decoded from.
+	ErrUnknownTokenCall = errors.New("unknown token call")
+	// ErrRecipientNotAllowed is matched by RecipientNotAllowedError using errors.Is.
+	ErrRecipientNotAllowed = errors.New("recipient not allowed")
+)
+
+// SpendingLimitError is returned when signing of the transaction is rejected by SpendingLimits, because
+// the amount exceeds the limit. It matches one of ErrFeeLimitExceeded, ErrValueLimitExceeded,
+// ErrTokenLimitExceeded and ErrDailyVolumeExceeded using errors.Is.
+type SpendingLimitError struct {
+	Kind   error    // ErrFeeLimitExceeded, ErrValueLimitExceeded, ErrTokenLimitExceeded or ErrDailyVolumeExceeded.
+	Amount *big.Int // The amount exceeding the limit, including the daily volume for ErrDailyVolumeExceeded.
+	Limit  *big.Int // The exceeded limit.
+}
+
+func (e *SpendingLimitError) Error() string {
+	return fmt.Sprintf("%s: %s exceeds limit %s", e.Kind, e.Amount, e.Limit)
+}
+
+func (e *SpendingLimitError) Unwrap() error {
+	return e.Kind
+}
+
+// RecipientNotAllowedError is returned when signing of the transaction is rejected by SpendingLimits,
+// because the recipient is denied or not allowed.
+type RecipientNotAllowedError struct {
+	Recipient *common.Address // The rejected recipient, nil for contract creations.
+	Denied    bool            // Whether the recipient is in the deny list, rather than not in the allow list.
+}
+
+func (e *RecipientNotAllowedError) Error() string {
+	recipient := "contract creation"
+	if e.Recipient != nil {
+		recipient = e.Recipient.Hex()
+	}
+	if e.Denied {
+		return fmt.Sprintf("%s: %s is denied", ErrRecipientNotAllowed, recipient)
+	}
+	return fmt.Sprintf("%s: %s is not in allow list", ErrRecipientNotAllowed, recipient)
+}
+
+func (e *RecipientNotAllowedError) Unwrap() error {
+	return ErrRecipientNotAllowed
+}
+
+// Spending describes the transaction checked by SpendingGuard.
+type Spending struct {
+	L1    bool            // Whether the transaction is sent on L1 network.
+	To    *common.Address // The recipient of the transaction, i.e. the called contract, nil for contract creations.
+	Value *big.Int        // Funds transferred along the transaction.
+	Fee   *big.Int        // Maximum fee of the transaction, i.e. the gas limit multiplied by the maximum fee per gas.
+	// Recipients are the accounts receiving the funds or allowances of the transaction, decoded from the
+	// calldata of token transfers and approvals, bridge withdrawals and deposits, L1->L2 transactions and
+	// batched transfers. For other transactions, it holds To only, and it is empty for contract creations.
+	Recipients []common.Address
+	// Token is the ERC-20 token transferred or approved by the transaction, i.e. the called contract for
+	// token calls, or the bridged token for bridge withdrawals and deposits. It is nil if the calldata is
+	// not the one of a token call, or cannot be decoded.
+	Token *common.Address
+	// TokenAmount is the amount of the token transferred or approved by the transaction, nil if Token is nil.
+	TokenAmount *big.Int
+}
+
+// transferAndCallABI is the ABI of the transfers of the ERC-677 and ERC-1363 tokens, which call back
+// the recipient with the data.
+const transferAndCallABI = `[
+	{"type":"function","name":"transferAndCall","stateMutability":"nonpayable","outputs":[{"name":"","type":"bool"}],"inputs":[
+		{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"}]},
+	{"type":"function","name":"transferFromAndCall","stateMutability":"nonpayable","outputs":[{"name":"","type":"bool"}],"inputs":[
+		{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"}]}
+]`
+
+// spendingMetaData are the contracts whose calls are decoded by newSpending. ERC-20 comes before ERC-721,
+// so that the calls of transferFrom and approve, shared by both standards, are decoded as token calls.
+var spendingMetaData = []*bind.MetaData{
+	erc20.IERC20MetaData,
+	{ABI: transferAndCallABI},
+	erc721.IERC721MetaData,
+	erc1155.IERC1155MetaData,
+	l2bridge.IL2BridgeMetaData,
+	ethtoken.IEthTokenMetaData,
+	l1bridge.IL1BridgeMetaData,
+	zksync.IZkSyncMetaData,
+	bridgehub.IBridgehubMetaData,
+	multicall3.IMulticall3MetaData,
+}
+
+// newSpending returns the spending of the transaction, decoding the recipients, and the token amount
+// of token calls, from the calldata. Data appended to the arguments of the call is ignored.
+func newSpending(l1 bool, to *common.Address, value *big.Int, data []byte, fee *big.Int) Spending {
+	spending := Spending{L1: l1, To: to, Value: value, Fee: fee}
+	if to == nil {
+		return spending
+	}
+	if !spending.decode(data) {
+		spending.Recipients = []common.Address{*to}
+		spending.Token, spending.TokenAmount = nil, nil
+	}
+	return spending
+}
+
+// decode decodes the calldata of the transaction, reporting whether the call is known and well-formed.
+func (s *Spending) decode(data []byte) bool {
+	if len(data) < 4 {
+		return false
+	}
+	var (
+		method *abi.Method
+		args   []interface{}
+	)
+	for _, metaData := range spendingMetaData {
+		parsed, err := metaData.GetAbi()
+		if err != nil {
+			continue
+		}
+		if m, err := parsed.MethodById(data[:4]); err == nil {
+			if args, err = m.Inputs.Unpack(data[4:]); err != nil {
+				return false
+			}
+			method = m
+			break
+		}
+	}
+	if method == nil {
+		return false
+	}
+
+	tokenCall := func(token common.Address, recipient, amount int) bool {
+		s.Recipients = []common.Address{args[recipient].(common.Address)}
+		s.Token, s.TokenAmount = &token, args[amount].(*big.Int)
+		return true
+	}
+	recipientCall := func(recipient int) bool {
+		s.Recipients = []common.Address{args[recipient].(common.Address)}
+		return true
+	}
+	switch {
+	case method.RawName == "transfer" || method.RawName == "approve" || method.RawName == "transferAndCall":
+		return tokenCall(*s.To, 0, 1)
+	case method.RawName == "transferFrom" || method.RawName == "transferFromAndCall":
+		return tokenCall(*s.To, 1, 2)
+	case method.RawName == "withdraw" && len(args) == 3, method.RawName == "deposit" && len(args) == 6:
+		// The L2 bridge withdraw(l1Receiver, l2Token, amount) and the L1 bridge deposit(l2Receiver, l1Token, amount, ...).
+		return tokenCall(args[1].(common.Address), 0, 2)
+	case method.RawName == "withdraw" && len(args) == 1, method.RawName == "requestL2Transaction",
+		method.RawName == "setApprovalForAll":
+		return recipientCall(0)
+	case method.RawName == "safeTransferFrom" || method.RawName == "safeBatchTransferFrom":
+		return recipientCall(1)
+	case method.RawName == "requestL2TransactionDirect":
+		request := abi.ConvertType(args[0], new(bridgehub.L2TransactionRequestDirect)).(*bridgehub.L2TransactionRequestDirect)
+		s.Recipients = []common.Address{request.L2Contract}
+		return true
+	case method.RawName == "requestL2TransactionTwoBridges":
+		request := abi.ConvertType(args[0], new(bridgehub.L2TransactionRequestTwoBridgesOuter)).(*bridgehub.L2TransactionRequestTwoBridgesOuter)
+		return s.decodeSecondBridgeCalldata(request.SecondBridgeCalldata)
+	case method.RawName == "aggregate3Value":
+		calls := *abi.ConvertType(args[0], new([]multicall3.Multicall3Call3Value)).(*[]multicall3.Multicall3Call3Value)
+		s.Recipients = make([]common.Address, 0, len(calls))
+		for _, call := range calls {
+			if len(call.CallData) > 0 {
+				// Only the batched transfers of the base token are decoded.
+				return false
+			}
+			s.Recipients = append(s.Recipients, call.Target)
+		}
+		return true
+	}
+	return false
+}
+
+// decodeSecondBridgeCalldata decodes the deposit request handled by the shared bridge as the second bridge,
+// encoded by encodeSecondBridgeCalldata.
+func (s *Spending) decodeSecondBridgeCalldata(data []byte) bool {
+	addressType, err := abi.NewType("address", "", nil)
+	if err != nil {
+		return false
+	}
+	uint256Type, err := abi.NewType("uint256", "", nil)
+	if err != nil {
+		return false
+	}
+	args, err := abi.Arguments{{Type: addressType}, {Type: uint256Type}, {Type: addressType}}.Unpack(data)
+	if err != nil {
+		return false
+	}
+	token, amount, to := args[0].(common.Address), args[1].(*big.Int), args[2].(common.Address)
+	s.Recipients = []common.Address{to}
+	if token != utils.EthAddressInContracts {
+		s.Token, s.TokenAmount = &token, amount
+	}
+	return true
+}
+
+// SpendingGuard is the safety net of automated wallets, consulted before every L1 and L2 transaction
+// is signed by the wallet, so that faulty code cannot drain the account.
+type SpendingGuard interface {
+	// ReserveSpending returns an error if the transaction must not be signed. Otherwise, the spending is
+	// accounted, until the returned function releases it.
+	ReserveSpending(spending Spending) (release func(), err error)
+}
+
+// SpendingGuards composes multiple guards, all of which must accept the transaction.
+type SpendingGuards []SpendingGuard
+
+func (g SpendingGuards) ReserveSpending(spending Spending) (func(), error) {
+	releases := make([]func(), 0, len(g))
+	release := func() {
+		for _, r := range releases {
+			r()
+		}
+	}
+	for _, guard := range g {
+		r, err := guard.ReserveSpending(spending)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		releases = append(releases, r)
+	}
+	return release, nil
+}
+
+// SpendingLimits is the SpendingGuard enforcing hard limits on the transactions signed by the wallet.
+// Limits which are not set are not enforced. The value limits apply to the base token sent as the value
+// of transactions only, while ERC-20 tokens are limited by MaxTokenAmountPerTx. The deny list applies to
+// both the called contract and the decoded recipients of the transaction, while the allow list applies
+// to the decoded recipients only, e.g. to the receiver of a token transfer rather than the token contract.
+// It is safe for concurrent use, but must not be modified once in use.
+type SpendingLimits struct {
+	MaxFeePerTx   *big.Int         // Maximum fee of a single transaction.
+	MaxValuePerTx *big.Int         // Maximum value of a single transaction, in the base token.
+	MaxDailyValue *big.Int         // Maximum total value of transactions within the last 24 hours, in the base token.
+	Allowlist     []common.Address // Recipients the funds may be sent to. If empty, all recipients are allowed.
+	Denylist      []common.Address // Recipients the funds must not be sent to, and contracts which must not be called.
+	// MaxTokenAmountPerTx is the maximum amount of the ERC-20 token, by the address of the token, transferred,
+	// approved or bridged by a single transaction. Calls of a limited token which are not decoded as token
+	// calls are rejected with ErrUnknownTokenCall. Tokens which are not in the map are not limited.
+	MaxTokenAmountPerTx map[common.Address]*big.Int
+
+	mu   sync.Mutex
+	sent []*spentValue // Ordered by the time of the reservation.
+}
+
+type spentValue struct {
+	at    time.Time
+	value *big.Int
+}
+
+// dailyWindow is the window of SpendingLimits.MaxDailyValue.
+const dailyWindow = 24 * time.Hour
+
+func (l *SpendingLimits) ReserveSpending(spending Spending) (func(), error) {
+	if err := l.checkRecipients(spending); err != nil {
+		return nil, err
+	}
+	if l.MaxFeePerTx != nil && spending.Fee != nil && spending.Fee.Cmp(l.MaxFeePerTx) > 0 {
+		return nil, &SpendingLimitError{Kind: ErrFeeLimitExceeded, Amount: spending.Fee, Limit: l.MaxFeePerTx}
+	}
+	value := spending.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	if l.MaxValuePerTx != nil && value.Cmp(l.MaxValuePerTx) > 0 {
+		return nil, &SpendingLimitError{Kind: ErrValueLimitExceeded, Amount: value, Limit: l.MaxValuePerTx}
+	}
+	if spending.Token != nil && spending.TokenAmount != nil {
+		if limit, ok := l.MaxTokenAmountPerTx[*spending.Token]; ok && spending.TokenAmount.Cmp(limit) > 0 {
+			return nil, &SpendingLimitError{Kind: ErrTokenLimitExceeded, Amount: spending.TokenAmount, Limit: limit}
+		}
+	}
+	if spending.To != nil && (spending.Token == nil || *spending.Token != *spending.To) {
+		if _, ok := l.MaxTokenAmountPerTx[*spending.To]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownTokenCall, spending.To.Hex())
+		}
+	}
+	if l.MaxDailyValue == nil || value.Sign() == 0 {
+		return func() {}, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	total := new(big.Int).Add(l.prune(now), value)
+	if total.Cmp(l.MaxDailyValue) > 0 {
+		return nil, &SpendingLimitError{Kind: ErrDailyVolumeExceeded, Amount: total, Limit: l.MaxDailyValue}
+	}
+	reserved := &spentValue{at: now, value: new(big.Int).Set(value)}
+	l.sent = append(l.sent, reserved)
+	return func() { l.release(reserved) }, nil
+}
+
+// DailyValue returns the total value of transactions within the last 24 hours.
+func (l *SpendingLimits) DailyValue() *big.Int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.prune(time.Now())
+}
+
+func (l *SpendingLimits) checkRecipients(spending Spending) error {
+	if spending.To != nil && l.denied(*spending.To) {
+		return &RecipientNotAllowedError{Recipient: spending.To, Denied: true}
+	}
+	for i := range spending.Recipients {
+		if l.denied(spending.Recipients[i]) {
+			return &RecipientNotAllowedError{Recipient: &spending.Recipients[i], Denied: true}
+		}
+	}
+	if len(l.Allowlist) == 0 {
+		return nil
+	}
+	if len(spending.Recipients) == 0 {
+		return &RecipientNotAllowedError{}
+	}
+	for i := range spending.Recipients {
+		if !l.allowed(spending.Recipients[i]) {
+			return &RecipientNotAllowedError{Recipient: &spending.Recipients[i]}
+		}
+	}
+	return nil
+}
+
+func (l *SpendingLimits) denied(address common.Address) bool {
+	for _, denied := range l.Denylist {
+		if address == denied {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *SpendingLimits) allowed(address common.Address) bool {
+	for _, allowed := range l.Allowlist {
+		if address == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// prune removes the values sent before the window and returns the total of the remaining ones.
+func (l *SpendingLimits) prune(now time.Time) *big.Int {
+	start := now.Add(-dailyWindow)
+	i := 0
+	for i < len(l.sent) && !l.sent[i].at.After(start) {
+		i++
+	}
+	l.sent = l.sent[i:]
+	total := new(big.Int)
+	for _, s := range l.sent {
+		total.Add(total, s.value)
+	}
+	return total
+}
+
+func (l *SpendingLimits) release(reserved *spentValue) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, s := range l.sent {
+		if s == reserved {
+			l.sent = append(l.sent[:i], l.sent[i+1:]...)
+			return
+		}
+	}
+}
+
+// reserveSpending reserves the spending of the EIP-712 transaction with the spending guard. The returned
+// function releases the reservation; it is not nil even if nothing has been reserved.
+func (a *WalletL2) reserveSpending(tx *zkTypes.Transaction712) (func(), error) {
+	if a.spendingGuard == nil {
+		return func() {}, nil
+	}
+	var fee *big.Int
+	if tx.Gas != nil && tx.GasFeeCap != nil {
+		fee = new(big.Int).Mul(tx.Gas, tx.GasFeeCap)
+	}
+	return a.spendingGuard.ReserveSpending(newSpending(false, tx.To, tx.Value, tx.Data, fee))
+}
+
+// signedSpendings holds the reservations of the transactions signed by WalletL2.SignTransaction, which are
+// sent by the caller. Since the wallet cannot tell whether such a transaction is ever sent, its reservation
+// is held until another transaction with the same nonce is signed, at most one of which can be included,
+// or until it expires with the daily window. The zero value is ready for use.
+type signedSpendings struct {
+	mu       sync.Mutex
+	reserved map[uint64]signedSpending // By the nonce of the signed transaction.
+}
+
+type signedSpending struct {
+	at      time.Time
+	release func()
+}
+
+// hold holds the reservation of the signed transaction with the nonce.
+func (s *signedSpendings) hold(nonce uint64, release func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for n, reserved := range s.reserved {
+		if !reserved.at.After(now.Add(-dailyWindow)) {
+			delete(s.reserved, n)
+		}
+	}
+	if s.reserved == nil {
+		s.reserved = make(map[uint64]signedSpending)
+	}
+	s.reserved[nonce] = signedSpending{at: now, release: release}
+}
+
+// replace releases the reservation held for the transaction with the nonce, which is replaced by another
+// transaction being signed.
+func (s *signedSpendings) replace(nonce uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if reserved, ok := s.reserved[nonce]; ok {
+		reserved.release()
+		delete(s.reserved, nonce)
+	}
+}
+
+// spendingRelease releases the spending reserved by the signer function returned by guardSigner.
+type spendingRelease func()
+
+// sent passes the results of sending the transaction through, releasing the spending if the transaction
+// has not been sent.
+func (r spendingRelease) sent(tx *types.Transaction, err error) (*types.Transaction, error) {
+	if err != nil {
+		r()
+	}
+	return tx, err
+}
+
+// guardSigner wraps the signer function of contract bindings, so that the spending guard is consulted.
+// Since the signed transaction is sent by the caller of the signer function, the spending reserved
+// by the signer function is released by the returned function, which must be called if sending fails.
+func guardSigner(guard SpendingGuard, signer bind.SignerFn, l1 bool) (bind.SignerFn, spendingRelease) {
+	if guard == nil {
+		return signer, func() {}
+	}
+	var (
+		mu       sync.Mutex
+		releases []func()
+	)
+	release := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, r := range releases {
+			r()
+		}
+		releases = nil
+	}
+	return func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(tx.Gas()), tx.GasFeeCap())
+		r, err := guard.ReserveSpending(newSpending(l1, tx.To(), tx.Value(), tx.Data(), fee))
+		if err != nil {
+			return nil, err
+		}
+		signed, err := signer(address, tx)
+		if err != nil {
+			r()
+			return nil, err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		releases = append(releases, r)
+		return signed, nil
+	}, release
+}
@@ -0,0 +1,378 @@
+package accounts
+
+import (
+	"errors"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/zksync-sdk/zksync2-go/contracts/erc20"
+	"github.com/zksync-sdk/zksync2-go/contracts/ethtoken"
+	"github.com/zksync-sdk/zksync2-go/contracts/l1bridge"
+	"github.com/zksync-sdk/zksync2-go/contracts/l2bridge"
+	"github.com/zksync-sdk/zksync2-go/contracts/multicall3"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+// packCall returns the calldata of the contract function called with the arguments.
+func packCall(t *testing.T, metaData *bind.MetaData, method string, args ...interface{}) []byte {
+	t.Helper()
+	parsed, err := metaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := parsed.Pack(method, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestNewSpending(t *testing.T) {
+	token := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	recipient := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	owner := common.HexToAddress("0x3000000000000000000000000000000000000003")
+	bridge := common.HexToAddress("0x4000000000000000000000000000000000000004")
+	transfer := packCall(t, erc20.IERC20MetaData, "transfer", recipient, big.NewInt(100))
+	tests := []struct {
+		name           string
+		to             *common.Address
+		data           []byte
+		wantRecipients []common.Address
+		wantToken      *common.Address
+		wantAmount     *big.Int
+	}{
+		{
+			name:           "transfer",
+			to:             &token,
+			data:           transfer,
+			wantRecipients: []common.Address{recipient},
+			wantToken:      &token,
+			wantAmount:     big.NewInt(100),
+		},
+		{
+			name:           "transfer with appended data",
+			to:             &token,
+			data:           append(append([]byte{}, transfer...), 0xde, 0xad),
+			wantRecipients: []common.Address{recipient},
+			wantToken:      &token,
+			wantAmount:     big.NewInt(100),
+		},
+		{
+			name:           "approve",
+			to:             &token,
+			data:           packCall(t, erc20.IERC20MetaData, "approve", recipient, big.NewInt(200)),
+			wantRecipients: []common.Address{recipient},
+			wantToken:      &token,
+			wantAmount:     big.NewInt(200),
+		},
+		{
+			name:           "transferFrom",
+			to:             &token,
+			data:           packCall(t, erc20.IERC20MetaData, "transferFrom", owner, recipient, big.NewInt(300)),
+			wantRecipients: []common.Address{recipient},
+			wantToken:      &token,
+			wantAmount:     big.NewInt(300),
+		},
+		{
+			name:           "transferAndCall",
+			to:             &token,
+			data:           packCall(t, &bind.MetaData{ABI: transferAndCallABI}, "transferAndCall", recipient, big.NewInt(400), []byte{1}),
+			wantRecipients: []common.Address{recipient},
+			wantToken:      &token,
+			wantAmount:     big.NewInt(400),
+		},
+		{
+			name: "transferFromAndCall",
+			to:   &token,
+			data: packCall(t, &bind.MetaData{ABI: transferAndCallABI}, "transferFromAndCall",
+				owner, recipient, big.NewInt(500), []byte{}),
+			wantRecipients: []common.Address{recipient},
+			wantToken:      &token,
+			wantAmount:     big.NewInt(500),
+		},
+		{
+			name:           "L2 bridge withdrawal",
+			to:             &bridge,
+			data:           packCall(t, l2bridge.IL2BridgeMetaData, "withdraw", recipient, token, big.NewInt(600)),
+			wantRecipients: []common.Address{recipient},
+			wantToken:      &token,
+			wantAmount:     big.NewInt(600),
+		},
+		{
+			name: "L1 bridge deposit",
+			to:   &bridge,
+			data: packCall(t, l1bridge.IL1BridgeMetaData, "deposit",
+				recipient, token, big.NewInt(700), big.NewInt(1), big.NewInt(800), owner),
+			wantRecipients: []common.Address{recipient},
+			wantToken:      &token,
+			wantAmount:     big.NewInt(700),
+		},
+		{
+			name:           "base token withdrawal",
+			to:             &bridge,
+			data:           packCall(t, ethtoken.IEthTokenMetaData, "withdraw", recipient),
+			wantRecipients: []common.Address{recipient},
+		},
+		{
+			name: "batched transfers",
+			to:   &bridge,
+			data: packCall(t, multicall3.IMulticall3MetaData, "aggregate3Value", []multicall3.Multicall3Call3Value{
+				{Target: recipient, Value: big.NewInt(1), CallData: []byte{}},
+				{Target: owner, Value: big.NewInt(2), CallData: []byte{}},
+			}),
+			wantRecipients: []common.Address{recipient, owner},
+		},
+		{
+			name:           "other function",
+			to:             &token,
+			data:           packCall(t, erc20.IERC20MetaData, "balanceOf", recipient),
+			wantRecipients: []common.Address{token},
+		},
+		{
+			name:           "truncated calldata",
+			to:             &token,
+			data:           transfer[:40],
+			wantRecipients: []common.Address{token},
+		},
+		{name: "contract creation", data: transfer},
+		{name: "plain value transfer", to: &recipient, wantRecipients: []common.Address{recipient}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spending := newSpending(false, tt.to, big.NewInt(0), tt.data, nil)
+			if !reflect.DeepEqual(spending.Recipients, tt.wantRecipients) {
+				t.Errorf("Recipients = %v, want %v", spending.Recipients, tt.wantRecipients)
+			}
+			if (spending.Token == nil) != (tt.wantToken == nil) || (spending.Token != nil && *spending.Token != *tt.wantToken) {
+				t.Errorf("Token = %v, want %v", spending.Token, tt.wantToken)
+			}
+			if (spending.TokenAmount == nil) != (tt.wantAmount == nil) ||
+				(spending.TokenAmount != nil && spending.TokenAmount.Cmp(tt.wantAmount) != 0) {
+				t.Errorf("TokenAmount = %v, want %v", spending.TokenAmount, tt.wantAmount)
+			}
+		})
+	}
+}
+
+func TestSpendingLimitsReserveSpending(t *testing.T) {
+	token := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	allowed := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	denied := common.HexToAddress("0x3000000000000000000000000000000000000003")
+	other := common.HexToAddress("0x4000000000000000000000000000000000000004")
+	tokenCall := func(method string, to common.Address, amount int64) []byte {
+		return packCall(t, erc20.IERC20MetaData, method, to, big.NewInt(amount))
+	}
+	tests := []struct {
+		name     string
+		limits   *SpendingLimits
+		spending Spending
+		wantErr  error
+	}{
+		{
+			name:     "no limits",
+			limits:   &SpendingLimits{},
+			spending: newSpending(false, &other, big.NewInt(1_000), nil, big.NewInt(1_000)),
+		},
+		{
+			name:     "fee within limit",
+			limits:   &SpendingLimits{MaxFeePerTx: big.NewInt(100)},
+			spending: newSpending(false, &other, nil, nil, big.NewInt(100)),
+		},
+		{
+			name:     "fee above limit",
+			limits:   &SpendingLimits{MaxFeePerTx: big.NewInt(100)},
+			spending: newSpending(false, &other, nil, nil, big.NewInt(101)),
+			wantErr:  ErrFeeLimitExceeded,
+		},
+		{
+			name:     "value above limit",
+			limits:   &SpendingLimits{MaxValuePerTx: big.NewInt(100)},
+			spending: newSpending(true, &other, big.NewInt(101), nil, nil),
+			wantErr:  ErrValueLimitExceeded,
+		},
+		{
+			name:     "token transfer above limit",
+			limits:   &SpendingLimits{MaxTokenAmountPerTx: map[common.Address]*big.Int{token: big.NewInt(100)}},
+			spending: newSpending(false, &token, big.NewInt(0), tokenCall("transfer", other, 101), nil),
+			wantErr:  ErrTokenLimitExceeded,
+		},
+		{
+			name:     "token approval above limit",
+			limits:   &SpendingLimits{MaxTokenAmountPerTx: map[common.Address]*big.Int{token: big.NewInt(100)}},
+			spending: newSpending(false, &token, big.NewInt(0), tokenCall("approve", other, 101), nil),
+			wantErr:  ErrTokenLimitExceeded,
+		},
+		{
+			name:     "token transfer within limit",
+			limits:   &SpendingLimits{MaxTokenAmountPerTx: map[common.Address]*big.Int{token: big.NewInt(100)}},
+			spending: newSpending(false, &token, big.NewInt(0), tokenCall("transfer", other, 100), nil),
+		},
+		{
+			name:     "unlimited token",
+			limits:   &SpendingLimits{MaxTokenAmountPerTx: map[common.Address]*big.Int{token: big.NewInt(100)}},
+			spending: newSpending(false, &other, big.NewInt(0), tokenCall("transfer", token, 1_000), nil),
+		},
+		{
+			name:   "token withdrawal above limit",
+			limits: &SpendingLimits{MaxTokenAmountPerTx: map[common.Address]*big.Int{token: big.NewInt(100)}},
+			spending: newSpending(false, &other, big.NewInt(0),
+				packCall(t, l2bridge.IL2BridgeMetaData, "withdraw", allowed, token, big.NewInt(101)), nil),
+			wantErr: ErrTokenLimitExceeded,
+		},
+		{
+			name:   "unknown call of limited token",
+			limits: &SpendingLimits{MaxTokenAmountPerTx: map[common.Address]*big.Int{token: big.NewInt(100)}},
+			spending: newSpending(false, &token, big.NewInt(0),
+				append([]byte{0x12, 0x34, 0x56, 0x78}, tokenCall("transfer", other, 1_000)[4:]...), nil),
+			wantErr: ErrUnknownTokenCall,
+		},
+		{
+			name:     "daily volume exceeded",
+			limits:   &SpendingLimits{MaxDailyValue: big.NewInt(100)},
+			spending: newSpending(false, &other, big.NewInt(101), nil, nil),
+			wantErr:  ErrDailyVolumeExceeded,
+		},
+		{
+			name:     "denied recipient",
+			limits:   &SpendingLimits{Denylist: []common.Address{denied}},
+			spending: newSpending(false, &denied, nil, nil, nil),
+			wantErr:  ErrRecipientNotAllowed,
+		},
+		{
+			name:     "token transfer to denied recipient",
+			limits:   &SpendingLimits{Denylist: []common.Address{denied}},
+			spending: newSpending(false, &token, big.NewInt(0), tokenCall("transfer", denied, 1), nil),
+			wantErr:  ErrRecipientNotAllowed,
+		},
+		{
+			name:     "token transfer to allowed recipient",
+			limits:   &SpendingLimits{Allowlist: []common.Address{allowed}},
+			spending: newSpending(false, &token, big.NewInt(0), tokenCall("transfer", allowed, 1), nil),
+		},
+		{
+			name:     "token transfer to recipient not in allow list",
+			limits:   &SpendingLimits{Allowlist: []common.Address{allowed, token}},
+			spending: newSpending(false, &token, big.NewInt(0), tokenCall("approve", other, 1), nil),
+			wantErr:  ErrRecipientNotAllowed,
+		},
+		{
+			name:     "recipient not in allow list",
+			limits:   &SpendingLimits{Allowlist: []common.Address{allowed}},
+			spending: newSpending(false, &other, nil, nil, nil),
+			wantErr:  ErrRecipientNotAllowed,
+		},
+		{
+			name:     "contract creation with allow list",
+			limits:   &SpendingLimits{Allowlist: []common.Address{allowed}},
+			spending: newSpending(false, nil, nil, nil, nil),
+			wantErr:  ErrRecipientNotAllowed,
+		},
+		{
+			name:     "allowed recipient",
+			limits:   &SpendingLimits{Allowlist: []common.Address{allowed}},
+			spending: newSpending(false, &allowed, nil, nil, nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release, err := tt.limits.ReserveSpending(tt.spending)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReserveSpending() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && release == nil {
+				t.Error("ReserveSpending() release = nil")
+			}
+		})
+	}
+}
+
+func TestSpendingLimitsDailyValue(t *testing.T) {
+	to := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	limits := &SpendingLimits{MaxDailyValue: big.NewInt(100)}
+	steps := []struct {
+		value     int64
+		release   bool // Whether the reservation is released after the step.
+		wantErr   error
+		wantDaily int64
+	}{
+		{value: 60, wantDaily: 60},
+		{value: 50, wantErr: ErrDailyVolumeExceeded, wantDaily: 60},
+		{value: 40, release: true, wantDaily: 60},
+		{value: 40, wantDaily: 100},
+		{value: 1, wantErr: ErrDailyVolumeExceeded, wantDaily: 100},
+	}
+	for i, step := range steps {
+		release, err := limits.ReserveSpending(newSpending(false, &to, big.NewInt(step.value), nil, nil))
+		if !errors.Is(err, step.wantErr) {
+			t.Fatalf("step %d: ReserveSpending() error = %v, want %v", i, err, step.wantErr)
+		}
+		if step.release {
+			release()
+		}
+		if got := limits.DailyValue(); got.Cmp(big.NewInt(step.wantDaily)) != 0 {
+			t.Errorf("step %d: DailyValue() = %s, want %d", i, got, step.wantDaily)
+		}
+	}
+}
+
+func TestGuardSignerRelease(t *testing.T) {
+	to := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	signingErr := errors.New("signing failed")
+	tests := []struct {
+		name      string
+		signErr   error
+		release   bool // Whether the release is called, as when sending fails.
+		wantDaily int64
+	}{
+		{name: "sent", wantDaily: 60},
+		{name: "sending failed", release: true, wantDaily: 0},
+		{name: "signing failed", signErr: signingErr, wantDaily: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits := &SpendingLimits{MaxDailyValue: big.NewInt(100)}
+			signer := func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				return tx, tt.signErr
+			}
+			guarded, release := guardSigner(limits, signer, true)
+			tx := types.NewTx(&types.DynamicFeeTx{To: &to, Value: big.NewInt(60), Gas: 21_000, GasFeeCap: big.NewInt(1)})
+			if _, err := guarded(common.Address{}, tx); !errors.Is(err, tt.signErr) {
+				t.Fatalf("signer error = %v, want %v", err, tt.signErr)
+			}
+			if tt.release {
+				release()
+			}
+			if got := limits.DailyValue(); got.Cmp(big.NewInt(tt.wantDaily)) != 0 {
+				t.Errorf("DailyValue() = %s, want %d", got, tt.wantDaily)
+			}
+		})
+	}
+}
+
+func TestSignedSpendings(t *testing.T) {
+	to := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	limits := &SpendingLimits{MaxDailyValue: big.NewInt(150)}
+	var signed signedSpendings
+	steps := []struct {
+		nonce     uint64
+		value     int64
+		wantDaily int64
+	}{
+		{nonce: 1, value: 60, wantDaily: 60},
+		{nonce: 1, value: 70, wantDaily: 70}, // Replaces the unsent transaction with the same nonce.
+		{nonce: 2, value: 30, wantDaily: 100},
+	}
+	for i, step := range steps {
+		release, err := limits.ReserveSpending(newSpending(false, &to, big.NewInt(step.value), nil, nil))
+		if err != nil {
+			t.Fatalf("step %d: ReserveSpending() error = %v", i, err)
+		}
+		signed.replace(step.nonce)
+		signed.hold(step.nonce, release)
+		if got := limits.DailyValue(); got.Cmp(big.NewInt(step.wantDaily)) != 0 {
+			t.Errorf("step %d: DailyValue() = %s, want %d", i, got, step.wantDaily)
+		}
+	}
+}
@@ -0,0 +1,342 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/zksync-sdk/zksync2-go/clients"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"math/big"
+	"time"
+)
+
+// ErrFeeCapExceeded is returned when the fee cap of the transaction exceeds TxManagerConfig.MaxFeeCap.
+var ErrFeeCapExceeded = errors.New("fee cap exceeds maximum")
+
+// DefaultMaxFeeCapMultiplier is the default of TxManagerConfig.MaxFeeCapMultiplier.
+const DefaultMaxFeeCapMultiplier = 3
+
+// ErrTxDeadlineExceeded is returned when the transaction has not been included before TxManagerConfig.Deadline.
+var ErrTxDeadlineExceeded = errors.New("transaction has not been included before deadline")
+
+// TxEventKind is the kind of TxEvent.
+type TxEventKind int
+
+const (
+	TxSubmitted        TxEventKind = iota // The transaction has been submitted for the first time.
+	TxResubmitted                         // The transaction has been resubmitted with increased fees.
+	TxResubmitFailed                      // The resubmission of the transaction has failed.
+	TxIncluded                            // The transaction has been included in a block.
+	TxDeadlineExceeded                    // The transaction has not been included before the deadline.
+)
+
+func (k TxEventKind) String() string {
+	switch k {
+	case TxSubmitted:
+		return "submitted"
+	case TxResubmitted:
+		return "resubmitted"
+	case TxResubmitFailed:
+		return "resubmit failed"
+	case TxIncluded:
+		return "included"
+	case TxDeadlineExceeded:
+		return "deadline exceeded"
+	default:
+		return fmt.Sprintf("TxEventKind(%d)", int(k))
+	}
+}
+
+// TxEvent reports the progress of the transaction sent by TxManager.
+type TxEvent struct {
+	Kind      TxEventKind
+	Hash      common.Hash      // The hash of the transaction the event refers to.
+	Nonce     uint64           // The nonce of the transaction.
+	GasFeeCap *big.Int         // The fee cap of the transaction the event refers to.
+	Receipt   *zkTypes.Receipt // The receipt of the included transaction, set for TxIncluded.
+	Err       error            // The error, set for TxResubmitFailed.
+}
+
+// TxManagerConfig contains the configuration of TxManager.
+type TxManagerConfig struct {
+	// ResubmitInterval is the time after which the transaction, which has not been included,
+	// is resubmitted with increased fees. Defaults to 30 seconds.
+	ResubmitInterval time.Duration
+	// BumpPercent is the percentage by which fees are increased on each resubmission.
+	// Defaults to DefaultFeeBumpPercent.
+	BumpPercent uint64
+	// MaxFeeCap is the maximum fee cap per gas the transaction can be sent with. Fees are not increased
+	// above it. Optional, if nil, the fees are limited by MaxFeeCapMultiplier.
+	MaxFeeCap *big.Int
+	// MaxFeeCapMultiplier limits the fee cap per gas, when MaxFeeCap is nil, to the fee cap the transaction
+	// has been prepared with multiplied by it, so that the fees do not grow indefinitely.
+	// Defaults to DefaultMaxFeeCapMultiplier.
+	MaxFeeCapMultiplier uint64
+	// Deadline is the maximum time to wait for the transaction to be included. Optional, if zero,
+	// the transaction is awaited until the context is canceled.
+	Deadline time.Duration
+	// PollInterval is the interval of checking whether the transaction is included. Defaults to 1 second.
+	PollInterval time.Duration
+	// OnEvent is called synchronously with each lifecycle event of the transaction. Optional.
+	OnEvent func(event TxEvent)
+	// ErrorClassifier classifies the errors of sending transactions, which determines whether the transaction
+	// is sent again. Defaults to clients.DefaultErrorClassifier.
+	ErrorClassifier *clients.ErrorClassifier
+	// IdempotencyStore persists the transactions sent by TxManager.SendIdempotent. Required by SendIdempotent.
+	IdempotencyStore IdempotencyStore
+}
+
+// TxManager sends transactions on L2 network and monitors their inclusion. Transactions which have not been
+// included within TxManagerConfig.ResubmitInterval are resubmitted with the same nonce and increased fees,
+// up to TxManagerConfig.MaxFeeCap or TxManagerConfig.MaxFeeCapMultiplier, until one of the submitted
+// transactions is included.
+type TxManager struct {
+	adapter AdapterL2
+	wallet  *WalletL2 // The wallet backing the adapter, nil if the adapter is not backed by WalletL2.
+	nonces  NonceManager
+	client  clients.Client
+	config  TxManagerConfig
+}
+
+// walletL2Of returns the WalletL2 backing the adapter, or nil if the adapter is not backed by WalletL2.
+func walletL2Of(adapter AdapterL2) *WalletL2 {
+	switch a := adapter.(type) {
+	case *WalletL2:
+		return a
+	case *Wallet:
+		if l2, ok := a.AdapterL2.(*WalletL2); ok {
+			return l2
+		}
+	}
+	return nil
+}
+
+// NewTxManager creates an instance of TxManager which sends the transactions of the account associated with
+// the adapter, e.g. Wallet, and monitors them using the client. The nonces of the transactions without the nonce
+// are reserved using the NonceManager of the wallet, or, if the wallet has none, using the LocalNonceManager
+// of the TxManager, so that the transactions sent concurrently do not replace each other.
+func NewTxManager(adapter AdapterL2, client clients.Client, config TxManagerConfig) *TxManager {
+	if config.ResubmitInterval == 0 {
+		config.ResubmitInterval = 30 * time.Second
+	}
+	if config.BumpPercent == 0 {
+		config.BumpPercent = DefaultFeeBumpPercent
+	}
+	if config.MaxFeeCapMultiplier == 0 {
+		config.MaxFeeCapMultiplier = DefaultMaxFeeCapMultiplier
+	}
+	if config.PollInterval == 0 {
+		config.PollInterval = time.Second
+	}
+	if config.ErrorClassifier == nil {
+		config.ErrorClassifier = clients.DefaultErrorClassifier
+	}
+	return &TxManager{
+		adapter: adapter,
+		wallet:  walletL2Of(adapter),
+		nonces:  NewLocalNonceManager(client),
+		client:  client,
+		config:  config,
+	}
+}
+
+// Send submits the transaction and waits until it, or any of its resubmissions, is included in a block,
+// returning the receipt of the included one. Any unset transaction fields are prepared using
+// AdapterL2.PopulateTransaction.
+func (m *TxManager) Send(ctx context.Context, tx Transaction) (*zkTypes.Receipt, error) {
+	ctx = ensureContext(ctx)
+	if m.config.Deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.config.Deadline)
+		defer cancel()
+	}
+
+	prepared, maxFeeCap, release, err := m.prepare(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := m.submitReplacing(ctx, prepared, maxFeeCap, nil)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	return m.await(ctx, prepared.Nonce.Uint64(), prepared, maxFeeCap, []common.Hash{hash}, nil)
+}
+
+// prepare reserves the nonce of the transaction, populates it and checks its fee cap. It returns the maximum
+// fee cap the fees of the transaction can be increased to, and the function releasing the reserved nonce,
+// which must be called if the transaction is not sent. The nonce is kept by all submissions of the transaction.
+func (m *TxManager) prepare(ctx context.Context, tx Transaction) (_ *zkTypes.Transaction712, _ *big.Int, release func(), err error) {
+	release = func() {}
+	if tx.Nonce == nil {
+		nonces := m.nonceManager()
+		nonce, err := nonces.Next(ctx, m.adapter.Address())
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to reserve nonce: %w", err)
+		}
+		tx.Nonce = new(big.Int).SetUint64(nonce)
+		release = func() {
+			// The release error is ignored in favor of the error which caused the release.
+			_ = nonces.Release(ctx, m.adapter.Address(), nonce)
+		}
+		defer func() {
+			if err != nil {
+				release()
+			}
+		}()
+	}
+	prepared, err := m.adapter.PopulateTransaction(ctx, tx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if m.config.MaxFeeCap == nil {
+		maxFeeCap := new(big.Int).Mul(prepared.GasFeeCap, new(big.Int).SetUint64(m.config.MaxFeeCapMultiplier))
+		return prepared, maxFeeCap, release, nil
+	}
+	if prepared.GasFeeCap.Cmp(m.config.MaxFeeCap) > 0 {
+		return nil, nil, nil, fmt.Errorf("%w: %s > %s", ErrFeeCapExceeded, prepared.GasFeeCap, m.config.MaxFeeCap)
+	}
+	return prepared, m.config.MaxFeeCap, release, nil
+}
+
+// nonceManager returns the NonceManager of the wallet, shared with the other methods of the wallet,
+// or the LocalNonceManager of the TxManager if the wallet has none.
+func (m *TxManager) nonceManager() NonceManager {
+	if m.wallet != nil && m.wallet.nonceManager != nil {
+		return m.wallet.nonceManager
+	}
+	return m.nonces
+}
+
+// submitReplacing submits the transaction for the first time, increasing its fees if it has to replace
+// a pending transaction with the same nonce, e.g. sent by another process.
+func (m *TxManager) submitReplacing(ctx context.Context, tx *zkTypes.Transaction712, maxFeeCap *big.Int,
+	recorder *idempotencyRecorder) (common.Hash, error) {
+	hash, err := m.submit(ctx, tx, recorder, false)
+	for err != nil && m.config.ErrorClassifier.Classify(err) == clients.BroadcastReplacementUnderpriced && m.bumpFees(tx, maxFeeCap) {
+		hash, err = m.submit(ctx, tx, recorder, false)
+	}
+	if err != nil {
+		return common.Hash{}, err
+	}
+	m.emit(TxEvent{Kind: TxSubmitted, Hash: hash, Nonce: tx.Nonce.Uint64(), GasFeeCap: tx.GasFeeCap})
+	return hash, nil
+}
+
+// await waits until any of the submitted transactions with the nonce is included in a block, resubmitting
+// the prepared transaction with the fees increased up to the maxFeeCap. If the prepared transaction is nil,
+// the transactions are only awaited.
+func (m *TxManager) await(ctx context.Context, nonce uint64, prepared *zkTypes.Transaction712, maxFeeCap *big.Int,
+	hashes []common.Hash, recorder *idempotencyRecorder) (*zkTypes.Receipt, error) {
+	resubmit := prepared != nil
+	pollTicker := time.NewTicker(m.config.PollInterval)
+	defer pollTicker.Stop()
+	resubmitTicker := time.NewTicker(m.config.ResubmitInterval)
+	defer resubmitTicker.Stop()
+	for {
+		// Any of the submitted transactions can be included, since they share the nonce.
+		for _, hash := range hashes {
+			receipt, err := m.client.TransactionReceipt(ctx, hash)
+			if err == nil && receipt != nil && receipt.BlockNumber != nil {
+				m.emit(TxEvent{Kind: TxIncluded, Hash: hash, Nonce: nonce, Receipt: receipt})
+				return receipt, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) && m.config.Deadline > 0 {
+				var last common.Hash
+				if len(hashes) > 0 {
+					last = hashes[len(hashes)-1]
+				}
+				event := TxEvent{Kind: TxDeadlineExceeded, Hash: last, Nonce: nonce}
+				if prepared != nil {
+					event.GasFeeCap = prepared.GasFeeCap
+				}
+				m.emit(event)
+				return nil, fmt.Errorf("%w: %s", ErrTxDeadlineExceeded, last)
+			}
+			return nil, ctx.Err()
+		case <-pollTicker.C:
+		case <-resubmitTicker.C:
+			if !resubmit || !m.bumpFees(prepared, maxFeeCap) {
+				// The fees have reached the maximum, so the transaction can only be awaited.
+				continue
+			}
+			hash, err := m.submit(ctx, prepared, recorder, true)
+			if err != nil {
+				switch m.config.ErrorClassifier.Classify(err) {
+				case clients.BroadcastNonceTooLow, clients.BroadcastInsufficientFunds:
+					// Either the previous transaction has been included in the meantime, which is
+					// detected by the next poll, or the fees cannot be increased anymore.
+					resubmit = false
+				}
+				m.emit(TxEvent{Kind: TxResubmitFailed, Nonce: nonce, GasFeeCap: prepared.GasFeeCap, Err: err})
+				continue
+			}
+			hashes = append(hashes, hash)
+			m.emit(TxEvent{Kind: TxResubmitted, Hash: hash, Nonce: nonce, GasFeeCap: prepared.GasFeeCap})
+		}
+	}
+}
+
+// submit signs and sends the transaction. The signed transaction is recorded by the recorder, if any,
+// before it is sent. The replacement of the submitted transaction reuses the reservations of the guards
+// made for it, which are released only if the first submission fails.
+func (m *TxManager) submit(ctx context.Context, tx *zkTypes.Transaction712, recorder *idempotencyRecorder,
+	replacement bool) (common.Hash, error) {
+	var (
+		rawTx   []byte
+		release = func() {}
+		err     error
+	)
+	switch {
+	case m.wallet == nil:
+		rawTx, err = m.adapter.SignTransaction(tx)
+	case replacement:
+		rawTx, err = m.wallet.signReplacement(tx)
+	default:
+		rawTx, release, err = m.wallet.signTransaction(tx)
+	}
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err = recorder.signed(ctx, rawTx); err != nil {
+		release()
+		return common.Hash{}, err
+	}
+	hash, err := m.client.SendRawTransaction(ctx, rawTx)
+	if err != nil {
+		release()
+		return common.Hash{}, err
+	}
+	recorder.sent(ctx, hash)
+	return hash, nil
+}
+
+// bumpFees increases the fees of the transaction, limited by the maxFeeCap. It reports whether the fees
+// have been increased.
+func (m *TxManager) bumpFees(tx *zkTypes.Transaction712, maxFeeCap *big.Int) bool {
+	gasFeeCap := bumpFee(tx.GasFeeCap, m.config.BumpPercent)
+	if gasFeeCap.Cmp(maxFeeCap) > 0 {
+		gasFeeCap = new(big.Int).Set(maxFeeCap)
+	}
+	if gasFeeCap.Cmp(tx.GasFeeCap) <= 0 {
+		return false
+	}
+	gasTipCap := bumpFee(tx.GasTipCap, m.config.BumpPercent)
+	if gasTipCap.Cmp(gasFeeCap) > 0 {
+		gasTipCap = new(big.Int).Set(gasFeeCap)
+	}
+	tx.GasFeeCap = gasFeeCap
+	tx.GasTipCap = gasTipCap
+	return true
+}
+
+func (m *TxManager) emit(event TxEvent) {
+	if m.config.OnEvent != nil {
+		m.config.OnEvent(event)
+	}
+}
@@ -106,6 +106,10 @@ type TransferCallMsg struct {
 	Amount *big.Int       // The amount of the token to transfer.
 	Token  common.Address // The address of the token. ETH by default.
 
+	Mode  clients.TransferMode // The function of the token used by the transfer. TransferPlain by default.
+	Data  []byte               // The data passed to the recipient by the transfer with call. Optional.
+	Owner common.Address       // The owner of the tokens transferred by TransferFromAndCall, required by it only.
+
 	Gas       uint64   // If 0, the call executes with near-infinite gas.
 	GasPrice  *big.Int // Wei <-> gas exchange ratio.
 	GasFeeCap *big.Int // EIP-1559 fee cap per gas.
@@ -120,6 +124,9 @@ func (m *TransferCallMsg) ToTransferCallMsg(from common.Address) clients.Transfe
 		Amount:     m.Amount,
 		Token:      m.Token,
 		From:       from,
+		Mode:       m.Mode,
+		Data:       m.Data,
+		Owner:      m.Owner,
 		Gas:        m.Gas,
 		GasPrice:   m.GasPrice,
 		GasFeeCap:  m.GasFeeCap,
@@ -245,7 +252,7 @@ func (m *DepositCallMsg) PopulateEmptyFields(from common.Address) {
 	if m.GasPerPubdataByte == nil {
 		m.GasPerPubdataByte = utils.RequiredL1ToL2GasPerPubdataLimit
 	}
-	if m.Token == (common.Address{}) {
+	if m.Token == (common.Address{}) || m.Token == utils.EthAddressInContracts {
 		m.Token = utils.EthAddress
 	}
 }
@@ -410,6 +417,17 @@ type TransferTransaction struct {
 	To     common.Address // The address of the recipient.
 	Amount *big.Int       // The amount of the token to transfer.
 	Token  common.Address // The address of the token. ETH by default.
+
+	// The name of the recipient, e.g. alice.eth, resolved into To using the name resolver of the wallet.
+	// If To is also set, the name must resolve to it. Optional.
+	ToName string
+
+	// The function of the token used by the transfer. TransferPlain by default. TransferAndCall and
+	// TransferFromAndCall are supported by the ERC-677 and ERC-1363 tokens, which call back the recipient
+	// with Data, e.g. to pay for a service in a single transaction instead of the approval and the call.
+	Mode  clients.TransferMode
+	Data  []byte         // The data passed to the recipient by the transfer with call. Optional.
+	Owner common.Address // The owner of the tokens transferred by TransferFromAndCall, required by it and rejected by other modes.
 }
 
 func (t *TransferTransaction) ToTransaction(opts *TransactOpts) *Transaction {
@@ -429,6 +447,9 @@ func (t *TransferTransaction) ToTransferCallMsg(from common.Address, opts *Trans
 		Amount:    t.Amount,
 		Token:     t.Token,
 		From:      from,
+		Mode:      t.Mode,
+		Data:      t.Data,
+		Owner:     t.Owner,
 		Gas:       opts.GasLimit,
 		GasPrice:  opts.GasPrice,
 		GasFeeCap: opts.GasFeeCap,
@@ -443,6 +464,10 @@ type WithdrawalTransaction struct {
 	Token  common.Address // The address of the token to withdraw.
 	Amount *big.Int       // The amount of the token to withdraw.
 
+	// The name of the recipient, e.g. alice.eth, resolved into To using the name resolver of the wallet.
+	// If To is also set, the name must resolve to it. Optional.
+	ToName string
+
 	// The address of the bridge contract to be used. Defaults to the default zkSync bridge
 	// (either L2EthBridge or L2Erc20Bridge).
 	BridgeAddress *common.Address
@@ -531,6 +556,10 @@ type DepositTransaction struct {
 	Token  common.Address // The address of the token to deposit.
 	Amount *big.Int       // The amount of the token to be deposited.
 
+	// The name of the recipient on L2, e.g. alice.eth, resolved into To using the name resolver of the wallet.
+	// If To is also set, the name must resolve to it. Optional.
+	ToName string
+
 	// If the ETH value passed with the transaction is not explicitly stated Auth.Value,
 	// this field will be equal to the tip the operator will receive on top of the base cost
 	// of the transaction.
@@ -596,7 +625,7 @@ func (t *DepositTransaction) PopulateEmptyFields(from common.Address) {
 	if t.GasPerPubdataByte == nil {
 		t.GasPerPubdataByte = utils.RequiredL1ToL2GasPerPubdataLimit
 	}
-	if t.Token == (common.Address{}) {
+	if t.Token == (common.Address{}) || t.Token == utils.EthAddressInContracts {
 		t.Token = utils.EthAddress
 	}
 	if t.ApproveERC20 && t.ApproveAuth == nil {
@@ -735,3 +764,21 @@ type FullDepositFee struct {
 	L1GasLimit, // Gas limit of the L1 transaction.
 	L2GasLimit *big.Int // Gas limit of the L2 transaction.
 }
+
+// DepositFee is the breakdown of the fee required for performing the deposit on both L1 and L2 networks,
+// e.g. to display each component of the fee before the deposit is sent.
+type DepositFee struct {
+	L1GasLimit    *big.Int // Gas limit of the L1 transaction.
+	L2GasLimit    *big.Int // Gas limit of the L2 transaction.
+	GasPerPubdata *big.Int // Maximum amount of L2 gas that the operator may charge for single byte of pubdata.
+	BaseCost      *big.Int // Base cost of the L2 transaction, under the L1 gas price of the transaction.
+	OperatorTip   *big.Int // The tip the operator receives on top of the base cost.
+
+	GasPrice  *big.Int // Gas price of the L1 transaction if legacy transaction is used.
+	GasFeeCap *big.Int // MaxFeePerGas of the L1 transaction if 1559 transaction is used.
+	GasTipCap *big.Int // MaxPriorityFeePerGas of the L1 transaction if 1559 transaction is used.
+
+	L1Fee *big.Int // Maximum fee of the L1 transaction, L1GasLimit multiplied by GasFeeCap or GasPrice.
+	// Maximum ETH paid for the deposit on top of the deposited amount: L1Fee + BaseCost + OperatorTip.
+	TotalETH *big.Int
+}
@@ -50,6 +50,9 @@ func newTransactorWithSigner(signer *Signer, chainID *big.Int) (*bind.TransactOp
 			if address != keyAddr {
 				return nil, bind.ErrNotAuthorized
 			}
+			if txSigner, ok := (*signer).(TransactionSigner); ok {
+				return txSigner.SignTx(tx, chainID)
+			}
 			signature, err := (*signer).SignHash(latestSigner.Hash(tx).Bytes())
 			if err != nil {
 				return nil, err
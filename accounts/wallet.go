@@ -8,8 +8,12 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/zksync-sdk/zksync2-go/clients"
+	"github.com/zksync-sdk/zksync2-go/eip712"
+	"github.com/zksync-sdk/zksync2-go/ens"
 	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"github.com/zksync-sdk/zksync2-go/utils"
 	"math/big"
+	"sync"
 )
 
 // Wallet wraps all operations that interact with an associated account.
@@ -22,23 +26,42 @@ type Wallet struct {
 
 	clientL1 *ethclient.Client
 	clientL2 *clients.Client
+
+	paymasterPolicy PaymasterPolicy
+	nonceManager    NonceManager
+	minimumAmounts  *MinimumAmounts
+	recipientGuard  RecipientGuard
+	bridgeMetrics   BridgeMetrics
+	paymasterGuard  PaymasterGuard
+	spendingGuard   SpendingGuard
+	hooks           *Hooks
+	hooksMu         sync.Mutex // Guards hooks, which are created lazily by Wallet.Hooks.
+
+	paymasterProvider   PaymasterParamsProvider
+	factoryDepsResolver FactoryDepsResolver
+	gasScaler           *GasScaler
+	gasOracle           GasOracle
+	nameResolver        ens.NameResolver
+	preflightEnabled    bool
+	multicall3Address   common.Address
+	l1Multicall3Address common.Address
 }
 
 // NewWallet creates an instance of Wallet associated with the account provided by the rawPrivateKey.
 // The clientL1 parameters is optional; if not provided, only method form AdapterL2 and Deployer can be used,
 // as the rest of the functionalities require communication with the L1 network.
 // A Wallet can be configured to communicate with L1 networks by using and Wallet.ConnectL1 method.
+//
+// Deprecated: Deprecated in favor of v2/accounts.NewWallet with WalletOptions.PrivateKey.
 func NewWallet(rawPrivateKey []byte, clientL2 *clients.Client, clientL1 *ethclient.Client) (*Wallet, error) {
-	chainID, err := (*clientL2).ChainID(context.Background())
-	if err != nil {
-		return nil, err
+	if clientL2 == nil {
+		return nil, errors.New("clientL2 must be provided")
 	}
-	signer, err := NewBaseSignerFromRawPrivateKey(rawPrivateKey, chainID.Int64())
-	if err != nil {
-		return nil, err
-	}
-	s := Signer(signer)
-	return NewWalletFromSigner(&s, clientL2, clientL1)
+	return NewWalletWithOptions(WalletOptions{
+		SignerOptions: SignerOptions{PrivateKey: rawPrivateKey},
+		ClientL2:      *clientL2,
+		ClientL1:      clientL1,
+	})
 }
 
 // NewWalletFromSigner creates an instance of Wallet associated with the account provided by the signer.
@@ -65,6 +88,10 @@ func NewWalletFromSigner(signer *Signer, clientL2 *clients.Client, clientL1 *eth
 	if adapterL2, err = NewWalletL2FromSigner(signer, clientL2); err != nil {
 		return nil, err
 	}
+	if clientL1 != nil {
+		// Recipients of L2 transactions are resolved to their L2 addresses using ENS on L1 by default.
+		adapterL2.(*WalletL2).SetNameResolver(ens.NewChainResolver(clientL1, (*signer).Domain().ChainId))
+	}
 	return &Wallet{
 		AdapterL1: adapterL1,
 		AdapterL2: adapterL2,
@@ -74,41 +101,49 @@ func NewWalletFromSigner(signer *Signer, clientL2 *clients.Client, clientL1 *eth
 	}, nil
 }
 
-// NewWalletFromMnemonic creates a new instance of Wallet based on the provided mnemonic phrase.
-// The clientL2 and clientL1 parameters are optional, and can be configured with Wallet.Connect
-// and Wallet.ConnectL1, respectively.
-func NewWalletFromMnemonic(mnemonic string, chainId int64, clientL2 *clients.Client, clientL1 *ethclient.Client) (*Wallet, error) {
-	signer, err := NewBaseSignerFromMnemonic(mnemonic, chainId)
-	if err != nil {
-		return nil, err
+// NewWalletFromMnemonic creates a new instance of Wallet based on the provided BIP-39 mnemonic phrase
+// and BIP-44 derivation path. If the derivation path is empty, DefaultDerivationPath is used.
+// The clientL2 is required, since it is used to fetch the chain ID, while the clientL1 is optional and
+// can be configured with Wallet.ConnectL1.
+// Use HDNode to manage many accounts derived from the same mnemonic.
+//
+// Deprecated: Deprecated in favor of v2/accounts.NewWallet with WalletOptions.Mnemonic.
+func NewWalletFromMnemonic(mnemonic, derivationPath string, clientL2 *clients.Client, clientL1 *ethclient.Client) (*Wallet, error) {
+	if clientL2 == nil {
+		return nil, errors.New("clientL2 must be provided")
 	}
-	s := Signer(signer)
-	return NewWalletFromSigner(&s, clientL2, clientL1)
+	return NewWalletWithOptions(WalletOptions{
+		SignerOptions: SignerOptions{Mnemonic: mnemonic, DerivationPath: derivationPath},
+		ClientL2:      *clientL2,
+		ClientL1:      clientL1,
+	})
 }
 
 // NewWalletFromRawPrivateKey creates a new instance of Wallet based on the provided private key
 // of the account and chain ID.
 // The clientL2 and clientL1 parameters are optional, and can be configured with Wallet.Connect
 // and Wallet.ConnectL1, respectively.
+//
+// Deprecated: Deprecated in favor of v2/accounts.NewWallet with WalletOptions.PrivateKey.
 func NewWalletFromRawPrivateKey(rawPk []byte, chainId int64, clientL2 *clients.Client, clientL1 *ethclient.Client) (*Wallet, error) {
-	signer, err := NewBaseSignerFromRawPrivateKey(rawPk, chainId)
-	if err != nil {
-		return nil, err
-	}
-	s := Signer(signer)
-	return NewWalletFromSigner(&s, clientL2, clientL1)
+	return NewWalletWithOptions(WalletOptions{
+		SignerOptions: SignerOptions{PrivateKey: rawPk, ChainID: chainId},
+		ClientL2:      derefClient(clientL2),
+		ClientL1:      clientL1,
+	})
 }
 
 // NewRandomWallet creates an instance of Wallet with a randomly generated account.
 // // The clientL2 and clientL1 parameters are optional, and can be configured with Wallet.Connect
 // and Wallet.ConnectL1, respectively.
+//
+// Deprecated: Deprecated in favor of v2/accounts.NewWallet with WalletOptions.Random.
 func NewRandomWallet(chainId int64, clientL2 *clients.Client, clientL1 *ethclient.Client) (*Wallet, error) {
-	signer, err := NewRandomBaseSigner(chainId)
-	if err != nil {
-		return nil, err
-	}
-	s := Signer(signer)
-	return NewWalletFromSigner(&s, clientL2, clientL1)
+	return NewWalletWithOptions(WalletOptions{
+		SignerOptions: SignerOptions{Random: true, ChainID: chainId},
+		ClientL2:      derefClient(clientL2),
+		ClientL1:      clientL1,
+	})
 }
 
 // Nonce returns the account nonce of the associated account.
@@ -123,16 +158,370 @@ func (w *Wallet) PendingNonce(ctx context.Context) (uint64, error) {
 	return (*w.clientL2).PendingNonceAt(ctx, w.Address())
 }
 
+// DeploymentNonce returns the deployment nonce of the associated account, which determines the address
+// of the next contract deployed using CREATE. The block number can be nil, in which case the nonce is taken
+// from the latest known block.
+func (w *Wallet) DeploymentNonce(ctx context.Context, blockNumber *big.Int) (*big.Int, error) {
+	return (*w.clientL2).DeploymentNonceAt(ctx, w.Address(), blockNumber)
+}
+
+// ComputeNextCreateAddress returns the address of the contract which will be deployed by the next CREATE
+// deployment of the associated account, computed from the deployment nonce in the latest known block.
+// Deployments which are not yet included in a block are not taken into account.
+func (w *Wallet) ComputeNextCreateAddress(ctx context.Context) (common.Address, error) {
+	nonce, err := w.DeploymentNonce(ctx, nil)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return utils.CreateAddress(w.Address(), nonce)
+}
+
+// SetPaymasterPolicy sets the policy which is enforced when transactions using paymaster are populated
+// and signed, protecting the account from being routed through untrusted paymasters. If the policy is nil,
+// any paymaster can be used. The policy is preserved by Wallet.Connect and Wallet.ConnectL1.
+func (w *Wallet) SetPaymasterPolicy(policy PaymasterPolicy) {
+	w.paymasterPolicy = policy
+	if l2, ok := w.AdapterL2.(*WalletL2); ok {
+		l2.SetPaymasterPolicy(policy)
+	}
+}
+
+// SetNonceManager sets the nonce manager used to assign nonces to L2 transactions, including deployments,
+// whose nonce is not provided. It allows the transactions to be sent concurrently. If the nonce manager is nil,
+// the nonce is fetched from the network for each transaction. Nonces of L1 transactions are not managed.
+// The nonce manager is preserved by Wallet.Connect and Wallet.ConnectL1.
+func (w *Wallet) SetNonceManager(manager NonceManager) {
+	w.nonceManager = manager
+	if l2, ok := w.AdapterL2.(*WalletL2); ok {
+		l2.SetNonceManager(manager)
+	}
+}
+
+// SetMinimumAmounts sets the per-token minimum amounts of L2 transfers and withdrawals, which are validated
+// before the transactions are sent. If the minimum amounts are nil, any amount can be sent.
+// The minimum amounts are preserved by Wallet.Connect and Wallet.ConnectL1.
+func (w *Wallet) SetMinimumAmounts(minimums *MinimumAmounts) {
+	w.minimumAmounts = minimums
+	if l2, ok := w.AdapterL2.(*WalletL2); ok {
+		l2.SetMinimumAmounts(minimums)
+	}
+}
+
+// SetRecipientGuard sets the guard which checks recipients of L2 transfers and withdrawals before
+// the transactions are sent, e.g. LookalikeGuard protecting against address poisoning. If the guard
+// is nil, recipients are not checked. The guard is preserved by Wallet.Connect and Wallet.ConnectL1.
+func (w *Wallet) SetRecipientGuard(guard RecipientGuard) {
+	w.recipientGuard = guard
+	if l2, ok := w.AdapterL2.(*WalletL2); ok {
+		l2.SetRecipientGuard(guard)
+	}
+}
+
+// SetPaymasterGuard sets the guard which limits the fees sponsored by paymasters, enforced before
+// L2 transactions using paymaster are signed, e.g. PaymasterGuards composed of SponsoredFeeLimit and
+// SponsoredFeeBudget. If the guard is nil, the sponsored fees are not limited. The guard is preserved
+// by Wallet.Connect and Wallet.ConnectL1.
+func (w *Wallet) SetPaymasterGuard(guard PaymasterGuard) {
+	w.paymasterGuard = guard
+	if l2, ok := w.AdapterL2.(*WalletL2); ok {
+		l2.SetPaymasterGuard(guard)
+	}
+}
+
+// SetSpendingGuard sets the guard enforcing limits on the L1 and L2 transactions signed by the wallet, such as
+// SpendingLimits with the maximum fee and value per transaction, the recipient lists and the daily volume cap.
+// If the guard is nil, the transactions are not limited. The guard is preserved by Wallet.Connect and
+// Wallet.ConnectL1.
+func (w *Wallet) SetSpendingGuard(guard SpendingGuard) {
+	w.spendingGuard = guard
+	if l1, ok := w.AdapterL1.(*WalletL1); ok {
+		l1.SetSpendingGuard(guard)
+	}
+	if l2, ok := w.AdapterL2.(*WalletL2); ok {
+		l2.SetSpendingGuard(guard)
+	}
+}
+
+// SetPaymasterParamsProvider sets the provider of paymaster parameters, e.g. HTTPPaymasterParamsProvider
+// requesting them from a gas sponsorship service, consulted when L2 transactions which do not specify
+// the paymaster are populated. If the provider is nil, the fee is paid by the account unless the transaction
+// specifies the paymaster. Transfers and withdrawals sent using Wallet.Transfer and Wallet.Withdraw are not
+// sponsored, see PaymasterParamsProvider. The provider is preserved by Wallet.Connect and Wallet.ConnectL1.
+func (w *Wallet) SetPaymasterParamsProvider(provider PaymasterParamsProvider) {
+	w.paymasterProvider = provider
+	if l2, ok := w.AdapterL2.(*WalletL2); ok {
+		l2.SetPaymasterParamsProvider(provider)
+	}
+}
+
+// SetFactoryDepsResolver sets the resolver providing the bytecode of contracts created by deployed contracts,
+// e.g. artifacts.Set loaded from the compiler output. When deployment transactions are populated, the factory
+// dependencies referenced by the deployed bytecode are added to the transaction. If the resolver is nil,
+// the factory dependencies must be set explicitly. The resolver is preserved by Wallet.Connect and Wallet.ConnectL1.
+func (w *Wallet) SetFactoryDepsResolver(resolver FactoryDepsResolver) {
+	w.factoryDepsResolver = resolver
+	if l2, ok := w.AdapterL2.(*WalletL2); ok {
+		l2.SetFactoryDepsResolver(resolver)
+	}
+}
+
+// SetHooks sets the callbacks invoked on the lifecycle of L1 and L2 transactions sent by the wallet.
+// It replaces the callbacks registered using Wallet.OnBeforeSign, Wallet.OnSent, Wallet.OnMined and
+// Wallet.OnFailed. If the hooks are nil, no callbacks are invoked. The hooks are preserved by Wallet.Connect
+// and Wallet.ConnectL1.
+func (w *Wallet) SetHooks(hooks *Hooks) {
+	w.hooksMu.Lock()
+	defer w.hooksMu.Unlock()
+	w.setHooks(hooks)
+}
+
+func (w *Wallet) setHooks(hooks *Hooks) {
+	w.hooks = hooks
+	if l1, ok := w.AdapterL1.(*WalletL1); ok {
+		l1.SetHooks(hooks)
+	}
+	if l2, ok := w.AdapterL2.(*WalletL2); ok {
+		l2.SetHooks(hooks)
+	}
+}
+
+// SetGasScaler sets the scaler applied to the gas limits estimated by the wallet, including the L2 gas limits
+// of deposits, so that the safety margins can be tuned centrally. If the scaler is nil, the estimations
+// are used as is. The scaler is preserved by Wallet.Connect and Wallet.ConnectL1.
+func (w *Wallet) SetGasScaler(scaler *GasScaler) {
+	w.gasScaler = scaler
+	if l1, ok := w.AdapterL1.(*WalletL1); ok {
+		l1.SetGasScaler(scaler)
+	}
+	if l2, ok := w.AdapterL2.(*WalletL2); ok {
+		l2.SetGasScaler(scaler)
+	}
+}
+
+// SetGasOracle sets the oracle providing the gas price of L1 and L2 transactions which do not specify the fee,
+// so that the fee behavior can be controlled globally. If the oracle is nil, the fees suggested by the nodes
+// are used. The oracle is preserved by Wallet.Connect and Wallet.ConnectL1.
+func (w *Wallet) SetGasOracle(oracle GasOracle) {
+	w.gasOracle = oracle
+	if l1, ok := w.AdapterL1.(*WalletL1); ok {
+		l1.SetGasOracle(oracle)
+	}
+	if l2, ok := w.AdapterL2.(*WalletL2); ok {
+		l2.SetGasOracle(oracle)
+	}
+}
+
+// SetPreflight enables or disables the pre-flight simulation of L2 transactions, which are not sent if their
+// simulation fails, see WalletL2.SetPreflight. The setting is preserved by Wallet.Connect and Wallet.ConnectL1.
+func (w *Wallet) SetPreflight(enabled bool) {
+	w.preflightEnabled = enabled
+	if l2, ok := w.AdapterL2.(*WalletL2); ok {
+		l2.SetPreflight(enabled)
+	}
+}
+
+// SetMulticall3Address sets the address of the Multicall3 contract on L2 aggregating the transfers sent by
+// Wallet.BatchTransfer, e.g. the one deployed on a local network. If the address is zero,
+// utils.Multicall3Address is used. The address is preserved by Wallet.Connect and Wallet.ConnectL1.
+func (w *Wallet) SetMulticall3Address(address common.Address) {
+	w.multicall3Address = address
+	if l2, ok := w.AdapterL2.(*WalletL2); ok {
+		l2.SetMulticall3Address(address)
+	}
+}
+
+// SetL1Multicall3Address sets the address of the Multicall3 contract on L1 aggregating the finalizations sent by
+// Wallet.FinalizeWithdrawals. If the address is zero, utils.L1Multicall3Address is used. The address is preserved
+// by Wallet.Connect and Wallet.ConnectL1.
+func (w *Wallet) SetL1Multicall3Address(address common.Address) {
+	w.l1Multicall3Address = address
+	if l1, ok := w.AdapterL1.(*WalletL1); ok {
+		l1.SetMulticall3Address(address)
+	}
+}
+
+// SetNameResolver sets the resolver of the recipient names of transfers, withdrawals and deposits, e.g.
+// ens.Resolvers combining the name service deployed on L2 with ENS. By default, the names are resolved to
+// the addresses on L2 using the address records of ENS on L1, see ens.NewChainResolver, if the wallet is
+// connected to L1. The resolver is preserved by Wallet.Connect and Wallet.ConnectL1.
+func (w *Wallet) SetNameResolver(resolver ens.NameResolver) {
+	w.nameResolver = resolver
+	if l1, ok := w.AdapterL1.(*WalletL1); ok {
+		l1.SetNameResolver(resolver)
+	}
+	if l2, ok := w.AdapterL2.(*WalletL2); ok {
+		l2.SetNameResolver(resolver)
+	}
+}
+
+// ResolveName returns the address the name, e.g. alice.eth, resolves to, using the name resolver of the wallet.
+func (w *Wallet) ResolveName(ctx context.Context, name string) (common.Address, error) {
+	resolver := w.nameResolver
+	if resolver == nil && w.clientL1 != nil {
+		resolver = ens.NewChainResolver(w.clientL1, w.Signer().Domain().ChainId)
+	}
+	var address common.Address
+	err := resolveRecipient(ctx, resolver, name, &address)
+	return address, err
+}
+
+// TokenBalances returns the balances of the tokens held on L2 network, along with the metadata of the tokens
+// and the formatted amounts. The base token is returned first.
+func (w *Wallet) TokenBalances(ctx context.Context, opts *BalanceOptions) ([]TokenBalance, error) {
+	l2, ok := w.AdapterL2.(*WalletL2)
+	if !ok {
+		return nil, errors.New("token balances are supported only by WalletL2")
+	}
+	return l2.TokenBalances(ctx, opts)
+}
+
+// BalancesAt returns the balances of the tokens held on L2 network at the given block, along with
+// the metadata of the tokens. The block number can be nil, in which case the latest known block is used.
+func (w *Wallet) BalancesAt(ctx context.Context, blockNumber *big.Int, opts *BalanceOptions) ([]TokenBalance, error) {
+	l2, ok := w.AdapterL2.(*WalletL2)
+	if !ok {
+		return nil, errors.New("token balances are supported only by WalletL2")
+	}
+	return l2.BalancesAt(ctx, blockNumber, opts)
+}
+
+// Hooks returns the callbacks invoked on the lifecycle of transactions sent by the wallet,
+// creating them if they have not been set.
+func (w *Wallet) Hooks() *Hooks {
+	w.hooksMu.Lock()
+	defer w.hooksMu.Unlock()
+	if w.hooks == nil {
+		w.setHooks(NewHooks())
+	}
+	return w.hooks
+}
+
+// OnBeforeSign registers the callback invoked before each transaction is signed by the wallet.
+// If the callback returns an error, the transaction is not signed. See Hooks.
+func (w *Wallet) OnBeforeSign(callback func(event TransactionEvent) error) {
+	w.Hooks().OnBeforeSign(callback)
+}
+
+// OnSent registers the callback invoked after each transaction is sent by the wallet. See Hooks.
+func (w *Wallet) OnSent(callback func(event TransactionEvent)) {
+	w.Hooks().OnSent(callback)
+}
+
+// OnMined registers the callback invoked after the transaction awaited by the wallet has been
+// successfully included in a block. See Hooks.
+func (w *Wallet) OnMined(callback func(event TransactionEvent)) {
+	w.Hooks().OnMined(callback)
+}
+
+// OnFailed registers the callback invoked when the transaction could not be signed or sent by the wallet,
+// or when the awaited transaction has failed. See Hooks.
+func (w *Wallet) OnFailed(callback func(event TransactionEvent)) {
+	w.Hooks().OnFailed(callback)
+}
+
+// SetBridgeMetrics sets the metrics receiving the latencies of withdrawals completed by Wallet.WithdrawAndWait.
+// If the metrics are nil, latencies are not observed. The metrics are preserved by Wallet.Connect
+// and Wallet.ConnectL1.
+func (w *Wallet) SetBridgeMetrics(metrics BridgeMetrics) {
+	w.bridgeMetrics = metrics
+}
+
 // Connect returns a new instance of Wallet with the provided client for the L2 network.
 func (w *Wallet) Connect(client *clients.Client) (*Wallet, error) {
 	s := w.Signer()
-	return NewWalletFromSigner(&s, client, w.clientL1)
+	wallet, err := NewWalletFromSigner(&s, client, w.clientL1)
+	if err != nil {
+		return nil, err
+	}
+	w.configure(wallet)
+	return wallet, nil
 }
 
 // ConnectL1 returns a new instance of Wallet with the provided client for the L1 network.
 func (w *Wallet) ConnectL1(client *ethclient.Client) (*Wallet, error) {
 	s := w.Signer()
-	return NewWalletFromSigner(&s, w.clientL2, client)
+	wallet, err := NewWalletFromSigner(&s, w.clientL2, client)
+	if err != nil {
+		return nil, err
+	}
+	w.configure(wallet)
+	return wallet, nil
+}
+
+// configure applies the configuration of the wallet to the other wallet.
+func (w *Wallet) configure(other *Wallet) {
+	if w.paymasterPolicy != nil {
+		other.SetPaymasterPolicy(w.paymasterPolicy)
+	}
+	if w.nonceManager != nil {
+		other.SetNonceManager(w.nonceManager)
+	}
+	if w.minimumAmounts != nil {
+		other.SetMinimumAmounts(w.minimumAmounts)
+	}
+	if w.recipientGuard != nil {
+		other.SetRecipientGuard(w.recipientGuard)
+	}
+	if w.bridgeMetrics != nil {
+		other.SetBridgeMetrics(w.bridgeMetrics)
+	}
+	if w.paymasterGuard != nil {
+		other.SetPaymasterGuard(w.paymasterGuard)
+	}
+	if w.spendingGuard != nil {
+		other.SetSpendingGuard(w.spendingGuard)
+	}
+	w.hooksMu.Lock()
+	hooks := w.hooks
+	w.hooksMu.Unlock()
+	if hooks != nil {
+		other.SetHooks(hooks)
+	}
+	if w.paymasterProvider != nil {
+		other.SetPaymasterParamsProvider(w.paymasterProvider)
+	}
+	if w.factoryDepsResolver != nil {
+		other.SetFactoryDepsResolver(w.factoryDepsResolver)
+	}
+	if w.gasScaler != nil {
+		other.SetGasScaler(w.gasScaler)
+	}
+	if w.gasOracle != nil {
+		other.SetGasOracle(w.gasOracle)
+	}
+	if w.nameResolver != nil {
+		other.SetNameResolver(w.nameResolver)
+	}
+	if w.preflightEnabled {
+		other.SetPreflight(true)
+	}
+	if w.multicall3Address != (common.Address{}) {
+		other.SetMulticall3Address(w.multicall3Address)
+	}
+	if w.l1Multicall3Address != (common.Address{}) {
+		other.SetL1Multicall3Address(w.l1Multicall3Address)
+	}
+}
+
+// SignTypedData signs the EIP-712 typed data, such as eip712.Struct for arbitrary application-level structs,
+// using the signer of the wallet, with the same semantics as eth_signTypedData_v4. If the domain is nil,
+// the domain of the signer is used. The signature can be verified using eip712.RecoverTypedDataSigner, or
+// utils.IsTypedDataSignatureValid for smart accounts.
+func (w *Wallet) SignTypedData(domain *eip712.Domain, data eip712.TypedData) ([]byte, error) {
+	signer := w.Signer()
+	if domain == nil {
+		domain = signer.Domain()
+	}
+	return signer.SignTypedData(domain, data)
+}
+
+// ContractBackend returns the backend for contract bindings generated by the standard abigen tool, which sends
+// EIP-712 transactions using the wallet.
+func (w *Wallet) ContractBackend() (*ContractBackend, error) {
+	if w.clientL2 == nil {
+		return nil, errors.New("wallet is not connected to L2 network")
+	}
+	return NewContractBackend(w.clientL2, w.AdapterL2), nil
 }
 
 // Deprecated: Deprecated in favor of Wallet.Signer.
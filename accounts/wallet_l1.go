@@ -1,7 +1,6 @@
 package accounts
 
 import (
-	"bytes"
 	"context"
 	"crypto/ecdsa"
 	"errors"
@@ -16,19 +15,27 @@ import (
 	"github.com/zksync-sdk/zksync2-go/clients"
 	"github.com/zksync-sdk/zksync2-go/contracts/erc20"
 	"github.com/zksync-sdk/zksync2-go/contracts/l1bridge"
-	"github.com/zksync-sdk/zksync2-go/contracts/l1messenger"
 	"github.com/zksync-sdk/zksync2-go/contracts/l2bridge"
 	"github.com/zksync-sdk/zksync2-go/contracts/zksync"
+	"github.com/zksync-sdk/zksync2-go/ens"
 	zkTypes "github.com/zksync-sdk/zksync2-go/types"
 	"github.com/zksync-sdk/zksync2-go/utils"
 	"math/big"
 	"strings"
 )
 
+// ErrCustomBaseTokenNotSupported is returned when an operation requires bridging of custom base token,
+// but the chain is not registered in the Bridgehub.
+var ErrCustomBaseTokenNotSupported = errors.New("bridging of custom base token is not supported")
+
+// ErrDepositNotFailed is returned when claiming the deposit whose L2 transaction has not failed.
+var ErrDepositNotFailed = errors.New("can't claim successful deposit")
+
 // WalletL1 implements the AdapterL1 interface.
 type WalletL1 struct {
 	clientL1 *ethclient.Client
 	clientL2 *clients.Client
+	signer   *Signer
 	auth     *bind.TransactOpts
 
 	mainContractAddress common.Address
@@ -36,6 +43,14 @@ type WalletL1 struct {
 
 	defaultL1BridgeAddress common.Address
 	defaultL1Bridge        *l1bridge.IL1Bridge
+
+	hooks         *Hooks
+	gasScaler     *GasScaler
+	gasOracle     GasOracle
+	spendingGuard SpendingGuard
+	nameResolver  ens.NameResolver
+
+	multicall3Address common.Address
 }
 
 // NewWalletL1 creates an instance of WalletL1 associated with the account provided by the raw private key.
@@ -87,14 +102,62 @@ func NewWalletL1FromSigner(signer *Signer, clientL1 *ethclient.Client, clientL2
 	return &WalletL1{
 		clientL1:               clientL1,
 		clientL2:               clientL2,
+		signer:                 signer,
 		auth:                   auth,
 		mainContractAddress:    mainContractAddress,
 		defaultL1BridgeAddress: bridgeContracts.L1Erc20DefaultBridge,
 		defaultL1Bridge:        iL1Bridge,
 		mainContract:           iZkSync,
+		nameResolver:           ens.NewChainResolver(clientL1, (*signer).Domain().ChainId),
 	}, nil
 }
 
+// SetHooks sets the callbacks invoked on the lifecycle of transactions sent by the wallet.
+// If the hooks are nil, no callbacks are invoked.
+func (a *WalletL1) SetHooks(hooks *Hooks) {
+	a.hooks = hooks
+}
+
+// SetGasScaler sets the scaler applied to the estimated L2 gas limits of deposits and L1 -> L2 transactions.
+// If the scaler is nil, the estimations are used as is.
+func (a *WalletL1) SetGasScaler(scaler *GasScaler) {
+	a.gasScaler = scaler
+}
+
+// SetSpendingGuard sets the guard enforcing limits on the L1 transactions signed by the wallet, such as
+// SpendingLimits. If the guard is nil, the transactions are not limited.
+func (a *WalletL1) SetSpendingGuard(guard SpendingGuard) {
+	a.spendingGuard = guard
+}
+
+// SetGasOracle sets the oracle providing the gas price of L1 transactions which do not specify it, used as
+// the max fee per gas on networks supporting EIP-1559. It is also used as the L1 gas price when computing
+// the base cost of L1 -> L2 transactions. If the oracle is nil, the fees suggested by the node are used.
+func (a *WalletL1) SetGasOracle(oracle GasOracle) {
+	a.gasOracle = oracle
+}
+
+// SetNameResolver sets the resolver of the recipient names of deposits. By default, the names are resolved
+// to the addresses on L2 using ENS on L1, see ens.NewChainResolver. If the resolver is nil, the recipients
+// cannot be specified by name.
+func (a *WalletL1) SetNameResolver(resolver ens.NameResolver) {
+	a.nameResolver = resolver
+}
+
+// SetMulticall3Address sets the address of the Multicall3 contract aggregating the finalizations sent by
+// WalletL1.FinalizeWithdrawals, e.g. on the L1 network where it is not deployed at the canonical address.
+// If the address is zero, utils.L1Multicall3Address is used.
+func (a *WalletL1) SetMulticall3Address(address common.Address) {
+	a.multicall3Address = address
+}
+
+// ResolveName returns the address the name resolves to, using the name resolver of the wallet.
+func (a *WalletL1) ResolveName(ctx context.Context, name string) (common.Address, error) {
+	var address common.Address
+	err := resolveRecipient(ctx, a.nameResolver, name, &address)
+	return address, err
+}
+
 func (a *WalletL1) MainContract(_ context.Context) (*zksync.IZkSync, error) {
 	return a.mainContract, nil
 }
@@ -105,7 +168,7 @@ func (a *WalletL1) L1BridgeContracts(_ context.Context) (*zkTypes.L1BridgeContra
 
 func (a *WalletL1) BalanceL1(opts *CallOpts, token common.Address) (*big.Int, error) {
 	callOpts := ensureCallOpts(opts).ToCallOpts(a.auth.From)
-	if token == utils.EthAddress {
+	if token == utils.EthAddress || token == utils.EthAddressInContracts {
 		return a.clientL1.BalanceAt(callOpts.Context, a.auth.From, callOpts.BlockNumber)
 	} else {
 		erc20Contract, err := erc20.NewIERC20(token, a.clientL1)
@@ -147,14 +210,15 @@ func (a *WalletL1) ApproveERC20(auth *TransactOpts, token common.Address, amount
 	if err != nil {
 		return nil, fmt.Errorf("failed to load IERC20: %w", err)
 	}
-	return erc20Contract.Approve(ensureTransactOpts(auth).ToTransactOpts(a.auth.From, a.auth.Signer), bridgeAddress, amount)
+	opts, release := a.transactOpts(ensureTransactOpts(auth))
+	return a.hooks.sentL1(release.sent(erc20Contract.Approve(opts, bridgeAddress, amount)))
 }
 
 func (a *WalletL1) BaseCost(opts *CallOpts, gasLimit, gasPerPubdataByte, gasPrice *big.Int) (*big.Int, error) {
 	callOpts := ensureCallOpts(opts).ToCallOpts(a.auth.From)
 	if gasPrice == nil {
 		var err error
-		if gasPrice, err = a.clientL1.SuggestGasPrice(callOpts.Context); err != nil {
+		if gasPrice, err = a.gasPrice(callOpts.Context); err != nil {
 			return nil, err
 		}
 	}
@@ -166,6 +230,17 @@ func (a *WalletL1) BaseCost(opts *CallOpts, gasLimit, gasPerPubdataByte, gasPric
 }
 
 func (a *WalletL1) Deposit(auth *TransactOpts, tx DepositTransaction) (*types.Transaction, error) {
+	if err := resolveRecipient(ensureTransactOpts(auth).Context, a.nameResolver, tx.ToName, &tx.To); err != nil {
+		return nil, err
+	}
+	isEthBased, err := (*a.clientL2).IsEthBasedChain(ensureContext(ensureTransactOpts(auth).Context))
+	if err != nil {
+		return nil, err
+	}
+	if !isEthBased {
+		return a.depositThroughBridgehub(auth, tx)
+	}
+
 	opts, depositTx, err := a.prepareDepositTx(*ensureTransactOpts(auth), tx)
 	if err != nil {
 		return nil, err
@@ -184,6 +259,16 @@ func (a *WalletL1) Deposit(auth *TransactOpts, tx DepositTransaction) (*types.Tr
 	}
 }
 
+func (a *WalletL1) DepositBaseToken(auth *TransactOpts, tx DepositTransaction) (*types.Transaction, error) {
+	opts := ensureTransactOpts(auth)
+	baseToken, err := (*a.clientL2).BaseTokenContractAddress(ensureContext(opts.Context))
+	if err != nil {
+		return nil, err
+	}
+	tx.Token = baseToken
+	return a.Deposit(opts, tx)
+}
+
 func (a *WalletL1) EstimateGasDeposit(ctx context.Context, msg DepositCallMsg) (uint64, error) {
 	auth, prepareDepositTx, err := a.prepareDepositTx(msg.ToTransactOpts(), msg.ToDepositTransaction())
 	if err != nil {
@@ -198,7 +283,23 @@ func (a *WalletL1) EstimateGasDeposit(ctx context.Context, msg DepositCallMsg) (
 	}
 }
 
+// Deprecated: Deprecated in favor of EstimateDepositFee.
 func (a *WalletL1) FullRequiredDepositFee(ctx context.Context, msg DepositCallMsg) (*FullDepositFee, error) {
+	fee, err := a.EstimateDepositFee(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	return &FullDepositFee{
+		MaxFeePerGas:         fee.GasFeeCap,
+		MaxPriorityFeePerGas: fee.GasTipCap,
+		GasPrice:             fee.GasPrice,
+		BaseCost:             fee.BaseCost,
+		L1GasLimit:           fee.L1GasLimit,
+		L2GasLimit:           fee.L2GasLimit,
+	}, nil
+}
+
+func (a *WalletL1) EstimateDepositFee(ctx context.Context, msg DepositCallMsg) (*DepositFee, error) {
 	// It is assumed that the L2 fee for the transaction does not depend on its value.
 	dummyAmount := big.NewInt(1)
 	msg.PopulateEmptyFields(a.auth.From)
@@ -229,7 +330,7 @@ func (a *WalletL1) FullRequiredDepositFee(ctx context.Context, msg DepositCallMs
 			if errGas != nil {
 				return nil, errGas
 			}
-			msg.L2GasLimit = new(big.Int).SetUint64(gas)
+			msg.L2GasLimit = a.gasScaler.ScaleBig(GasOperationDeposit, new(big.Int).SetUint64(gas))
 		}
 
 	} else {
@@ -238,7 +339,7 @@ func (a *WalletL1) FullRequiredDepositFee(ctx context.Context, msg DepositCallMs
 		if err != nil {
 			return nil, err
 		}
-		msg.L2GasLimit = new(big.Int).SetUint64(gas)
+		msg.L2GasLimit = a.gasScaler.ScaleBig(GasOperationDeposit, new(big.Int).SetUint64(gas))
 	}
 
 	if err := a.insertGasPriceInDepositMsg(ensureContext(ctx), &msg); err != nil {
@@ -309,106 +410,63 @@ func (a *WalletL1) FullRequiredDepositFee(ctx context.Context, msg DepositCallMs
 		return nil, err
 	}
 
-	fullConst := &FullDepositFee{
-		BaseCost:   baseCost,
-		L1GasLimit: new(big.Int).SetUint64(l1GasLimit),
-		L2GasLimit: msg.L2GasLimit,
+	fee := &DepositFee{
+		L1GasLimit:    new(big.Int).SetUint64(l1GasLimit),
+		L2GasLimit:    msg.L2GasLimit,
+		GasPerPubdata: new(big.Int).Set(msg.GasPerPubdataByte),
+		BaseCost:      baseCost,
+		OperatorTip:   msg.OperatorTip,
 	}
-
 	if msg.GasPrice != nil {
-		fullConst.GasPrice = msg.GasPrice
+		fee.GasPrice = msg.GasPrice
 	} else {
-		fullConst.MaxPriorityFeePerGas = msg.GasTipCap
-		fullConst.MaxFeePerGas = msg.GasFeeCap
+		fee.GasTipCap = msg.GasTipCap
+		fee.GasFeeCap = msg.GasFeeCap
 	}
-	return fullConst, nil
+	fee.L1Fee = new(big.Int).Mul(fee.L1GasLimit, gasPriceForEstimation)
+	fee.TotalETH = new(big.Int).Add(fee.L1Fee, fee.BaseCost)
+	fee.TotalETH.Add(fee.TotalETH, fee.OperatorTip)
+	return fee, nil
 }
 
 func (a *WalletL1) FinalizeWithdraw(auth *TransactOpts, withdrawalHash common.Hash, index int) (*types.Transaction, error) {
 	if a.clientL1 == nil {
 		return nil, errors.New("ethereum provider is not initialized")
 	}
-	var opts *bind.TransactOpts
-	if auth == nil {
-		opts = &bind.TransactOpts{
-			From:    a.auth.From,
-			Signer:  a.auth.Signer,
-			Context: context.Background(),
-		}
-	} else {
-		opts = auth.ToTransactOpts(a.auth.From, a.auth.Signer)
-	}
-
-	log, l1BatchTxId, err := a.getWithdrawalLog(opts.Context, withdrawalHash, index)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get WithdrawalLog: %w", err)
-	}
-	if l1BatchTxId == nil {
-		return nil, errors.New("empty l1BatchTxIndex")
-	}
-	l2ToL1LogIndex, _, err := a.getWithdrawalL2ToL1Log(opts.Context, withdrawalHash, index)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get WithdrawalL2ToL1Log: %w", err)
-	}
-	if len(log.Topics) < 2 {
-		return nil, errors.New("not enough Topics count")
-	}
-	sender := common.BytesToAddress(log.Topics[1].Bytes()[12:])
-	proof, err := (*a.clientL2).LogProof(opts.Context, withdrawalHash, l2ToL1LogIndex)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get L2ToL1LogProof: %w", err)
-	}
+	opts, release := a.transactOpts(ensureTransactOpts(auth))
 
-	l1MessengerAbi, err := abi.JSON(strings.NewReader(l1messenger.IL1MessengerMetaData.ABI))
-	if err != nil {
-		return nil, fmt.Errorf("failed to load l1MessengerAbi: %w", err)
-	}
-	ev, err := l1MessengerAbi.EventByID(log.Topics[0])
-	if err != nil {
-		return nil, fmt.Errorf("failed to get EventByID: %w", err)
-	}
-	dl, err := l1MessengerAbi.Unpack(ev.Name, log.Data)
+	params, err := a.finalizeWithdrawalParams(opts.Context, withdrawalHash, index)
 	if err != nil {
-		return nil, fmt.Errorf("failed to Unpack log data: %w", err)
-	}
-	message := dl[0].([]byte)
-
-	proof32 := make([][32]byte, len(proof.Proof))
-	for i, pr := range proof.Proof {
-		proof32[i] = pr
+		return nil, err
 	}
 
 	// ETH token
-	if sender == utils.L2EthTokenAddress {
-		return a.mainContract.FinalizeEthWithdrawal(opts,
-			log.L1BatchNumber.ToInt(),
-			big.NewInt(int64(proof.Id)),
-			uint16(l1BatchTxId.Uint64()),
-			message,
-			proof32,
-		)
+	if params.sender == utils.L2BaseTokenAddress {
+		return a.hooks.sentL1(release.sent(a.mainContract.FinalizeEthWithdrawal(opts,
+			params.l1BatchNumber,
+			params.l2MessageIndex,
+			params.l2TxNumberInBatch,
+			params.message,
+			params.proof,
+		)))
 	}
 	// other tokens
-	l2Bridge, err := l2bridge.NewIL2Bridge(sender, *a.clientL2)
+	l1BridgeAddress, err := a.withdrawalL1Bridge(opts.Context, params.sender)
 	if err != nil {
-		return nil, fmt.Errorf("failed to init l2Bridge: %w", err)
-	}
-	l1BridgeAddress, err := l2Bridge.L1Bridge(&bind.CallOpts{Context: opts.Context})
-	if err != nil {
-		return nil, fmt.Errorf("failed to get l1BridgeAddress: %w", err)
+		return nil, err
 	}
 	l1Bridge, err := l1bridge.NewIL1Bridge(l1BridgeAddress, a.clientL1)
 	if err != nil {
 		return nil, fmt.Errorf("failed to init l1Bridge: %w", err)
 	}
 
-	return l1Bridge.FinalizeWithdrawal(opts,
-		log.L1BatchNumber.ToInt(),
-		big.NewInt(int64(proof.Id)),
-		uint16(l1BatchTxId.Uint64()),
-		message,
-		proof32,
-	)
+	return a.hooks.sentL1(release.sent(l1Bridge.FinalizeWithdrawal(opts,
+		params.l1BatchNumber,
+		params.l2MessageIndex,
+		params.l2TxNumberInBatch,
+		params.message,
+		params.proof,
+	)))
 }
 
 func (a *WalletL1) IsWithdrawFinalized(opts *CallOpts, withdrawalHash common.Hash, index int) (bool, error) {
@@ -433,7 +491,7 @@ func (a *WalletL1) IsWithdrawFinalized(opts *CallOpts, withdrawalHash common.Has
 		return false, fmt.Errorf("failed to get L2ToL1LogProof: %w", err)
 	}
 	// ETH token
-	if sender == utils.L2EthTokenAddress {
+	if sender == utils.L2BaseTokenAddress {
 		return a.mainContract.IsEthWithdrawalFinalized(callOpts, log.L1BatchNumber.ToInt(), big.NewInt(int64(proof.Id)))
 	}
 	// other tokens
@@ -469,8 +527,11 @@ func (a *WalletL1) ClaimFailedDeposit(auth *TransactOpts, depositHash common.Has
 			successL2ToL1Log = l
 		}
 	}
+	if successL2ToL1Log == nil {
+		return nil, errors.New("transaction is not an L1 -> L2 transaction")
+	}
 	if successL2ToL1Log.Value != (common.Hash{}).String() {
-		return nil, errors.New("can't claim successful deposit")
+		return nil, ErrDepositNotFailed
 	}
 
 	tx, _, err := (*a.clientL2).TransactionByHash(opts.Context, depositHash)
@@ -519,8 +580,9 @@ func (a *WalletL1) ClaimFailedDeposit(auth *TransactOpts, depositHash common.Has
 		proof32[i] = pr
 	}
 
-	return l1Bridge.ClaimFailedDeposit(
-		opts.ToTransactOpts(a.auth.From, a.auth.Signer),
+	transactOpts, release := a.transactOpts(opts)
+	return a.hooks.sentL1(release.sent(l1Bridge.ClaimFailedDeposit(
+		transactOpts,
 		l1Sender,
 		l1Token,
 		depositHash,
@@ -528,7 +590,7 @@ func (a *WalletL1) ClaimFailedDeposit(auth *TransactOpts, depositHash common.Has
 		big.NewInt(int64(proof.Id)),
 		uint16(receipt.L1BatchTxIndex.ToInt().Uint64()),
 		proof32,
-	)
+	)))
 }
 
 func (a *WalletL1) RequestExecute(auth *TransactOpts, tx RequestExecuteTransaction) (*types.Transaction, error) {
@@ -536,8 +598,14 @@ func (a *WalletL1) RequestExecute(auth *TransactOpts, tx RequestExecuteTransacti
 	if err != nil {
 		return nil, err
 	}
-	return a.mainContract.RequestL2Transaction(
-		opts.ToTransactOpts(a.auth.From, a.auth.Signer),
+	return a.requestExecute(opts, requestExecuteTx)
+}
+
+// requestExecute sends the prepared L1 -> L2 transaction.
+func (a *WalletL1) requestExecute(opts *TransactOpts, requestExecuteTx *RequestExecuteTransaction) (*types.Transaction, error) {
+	transactOpts, release := a.transactOpts(opts)
+	return a.hooks.sentL1(release.sent(a.mainContract.RequestL2Transaction(
+		transactOpts,
 		requestExecuteTx.ContractAddress,
 		requestExecuteTx.L2Value,
 		requestExecuteTx.Calldata,
@@ -545,7 +613,7 @@ func (a *WalletL1) RequestExecute(auth *TransactOpts, tx RequestExecuteTransacti
 		requestExecuteTx.GasPerPubdataByte,
 		requestExecuteTx.FactoryDeps,
 		requestExecuteTx.RefundRecipient,
-	)
+	)))
 }
 
 func (a *WalletL1) EstimateGasRequestExecute(ctx context.Context, msg RequestExecuteCallMsg) (uint64, error) {
@@ -668,7 +736,7 @@ func (a *WalletL1) prepareDepositTx(auth TransactOpts, tx DepositTransaction) (*
 			if errGas != nil {
 				return nil, nil, errGas
 			}
-			tx.L2GasLimit = new(big.Int).SetUint64(gas)
+			tx.L2GasLimit = a.gasScaler.ScaleBig(GasOperationDeposit, new(big.Int).SetUint64(gas))
 		}
 
 	} else {
@@ -677,7 +745,7 @@ func (a *WalletL1) prepareDepositTx(auth TransactOpts, tx DepositTransaction) (*
 		if err != nil {
 			return nil, nil, err
 		}
-		tx.L2GasLimit = new(big.Int).SetUint64(gas)
+		tx.L2GasLimit = a.gasScaler.ScaleBig(GasOperationDeposit, new(big.Int).SetUint64(gas))
 	}
 
 	if err := a.insertGasPriceInTransactOpts(&opts); err != nil {
@@ -728,30 +796,31 @@ func (a *WalletL1) depositETH(auth *TransactOpts, tx *DepositTransaction) (*type
 }
 
 func (a *WalletL1) depositERC20(auth *TransactOpts, tx *DepositTransaction) (*types.Transaction, error) {
+	opts, release := a.transactOpts(auth)
 	if tx.BridgeAddress != nil {
 		l1Bridge, err := l1bridge.NewIL1Bridge(*tx.BridgeAddress, a.clientL1)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load IL1Bridge: %w", err)
 		}
-		return l1Bridge.Deposit(
-			auth.ToTransactOpts(a.auth.From, a.auth.Signer),
+		return a.hooks.sentL1(release.sent(l1Bridge.Deposit(
+			opts,
 			tx.To,
 			tx.Token,
 			tx.Amount,
 			tx.L2GasLimit,
 			tx.GasPerPubdataByte,
 			tx.RefundRecipient,
-		)
+		)))
 	} else {
-		return a.defaultL1Bridge.Deposit(
-			auth.ToTransactOpts(a.auth.From, a.auth.Signer),
+		return a.hooks.sentL1(release.sent(a.defaultL1Bridge.Deposit(
+			opts,
 			tx.To,
 			tx.Token,
 			tx.Amount,
 			tx.L2GasLimit,
 			tx.GasPerPubdataByte,
 			tx.RefundRecipient,
-		)
+		)))
 	}
 }
 
@@ -815,7 +884,7 @@ func (a *WalletL1) prepareRequestExecuteTx(auth TransactOpts, tx RequestExecuteT
 		if err != nil {
 			return nil, nil, err
 		}
-		tx.L2GasLimit = new(big.Int).SetUint64(gas)
+		tx.L2GasLimit = a.gasScaler.ScaleBig(GasOperationRequestExecute, new(big.Int).SetUint64(gas))
 	}
 
 	if err := a.insertGasPriceInTransactOpts(&opts); err != nil {
@@ -865,16 +934,14 @@ func (a *WalletL1) insertGasPriceInTransactOpts(opts **TransactOpts) error {
 				}
 				(*opts).GasTipCap = gasTipCap
 			}
-			// geth by default uses multiplication by 2 (abi.BoundContract.createDynamicTx -> basefeeWiggleMultiplier),
-			// but since the price for the L2 part will depend on the L1 part, doubling base fee is typically too much.
-			// BaseFee * 3 / 2 + GasTipCap
-			(*opts).GasFeeCap = new(big.Int).Add(
-				(*opts).GasTipCap,
-				new(big.Int).Div(new(big.Int).Mul(head.BaseFee, big.NewInt(3)), big.NewInt(2)),
-			)
+			gasFeeCap, gasTipCap, err := a.dynamicFees(ensureContext((*opts).Context), head, (*opts).GasTipCap)
+			if err != nil {
+				return err
+			}
+			(*opts).GasFeeCap, (*opts).GasTipCap = gasFeeCap, gasTipCap
 		} else {
 			// Chain is not London ready -> use legacy transaction
-			gasPrice, errGasPrice := a.clientL1.SuggestGasPrice(ensureContext((*opts).Context))
+			gasPrice, errGasPrice := a.gasPrice(ensureContext((*opts).Context))
 			if errGasPrice != nil {
 				return errGasPrice
 			}
@@ -902,16 +969,14 @@ func (a *WalletL1) insertGasPriceInDepositMsg(ctx context.Context, msg *DepositC
 				}
 				msg.GasTipCap = gasTipCap
 			}
-			// geth by default uses multiplication by 2 (abi.BoundContract.createDynamicTx -> basefeeWiggleMultiplier),
-			// but since the price for the L2 part will depend on the L1 part, doubling base fee is typically too much.
-			// BaseFee * 3 / 2 + GasTipCap
-			msg.GasFeeCap = new(big.Int).Add(
-				msg.GasTipCap,
-				new(big.Int).Div(new(big.Int).Mul(head.BaseFee, big.NewInt(3)), big.NewInt(2)),
-			)
+			gasFeeCap, gasTipCap, err := a.dynamicFees(ensureContext(ctx), head, msg.GasTipCap)
+			if err != nil {
+				return err
+			}
+			msg.GasFeeCap, msg.GasTipCap = gasFeeCap, gasTipCap
 		} else {
 			// Chain is not London ready -> use legacy transaction
-			gasPrice, errGasPrice := a.clientL1.SuggestGasPrice(ensureContext(ctx))
+			gasPrice, errGasPrice := a.gasPrice(ensureContext(ctx))
 			if errGasPrice != nil {
 				return errGasPrice
 			}
@@ -921,51 +986,113 @@ func (a *WalletL1) insertGasPriceInDepositMsg(ctx context.Context, msg *DepositC
 	return nil
 }
 
-func (a *WalletL1) getWithdrawalLog(ctx context.Context, withdrawalHash common.Hash, index int) (*zkTypes.Log, *big.Int, error) {
-	receipt, err := (*a.clientL2).TransactionReceipt(ctx, withdrawalHash)
+// gasPrice returns the gas price provided by the gas oracle, or suggested by the node if the oracle is not set.
+func (a *WalletL1) gasPrice(ctx context.Context) (*big.Int, error) {
+	return gasPrice(ctx, a.gasOracle, a.clientL1)
+}
+
+// dynamicFees returns the max fee per gas and the max priority fee per gas of the EIP-1559 transaction.
+// If the gas oracle is set, it provides the max fee per gas, and the max priority fee per gas is limited to it.
+func (a *WalletL1) dynamicFees(ctx context.Context, head *types.Header, gasTipCap *big.Int) (*big.Int, *big.Int, error) {
+	if a.gasOracle != nil {
+		gasFeeCap, err := a.gasOracle.GasPrice(ctx, a.clientL1)
+		if err != nil {
+			return nil, nil, err
+		}
+		if gasTipCap.Cmp(gasFeeCap) > 0 {
+			gasTipCap = new(big.Int).Set(gasFeeCap)
+		}
+		return gasFeeCap, gasTipCap, nil
+	}
+	// geth by default uses multiplication by 2 (abi.BoundContract.createDynamicTx -> basefeeWiggleMultiplier),
+	// but since the price for the L2 part will depend on the L1 part, doubling base fee is typically too much.
+	// BaseFee * 3 / 2 + GasTipCap
+	gasFeeCap := new(big.Int).Add(
+		gasTipCap,
+		new(big.Int).Div(new(big.Int).Mul(head.BaseFee, big.NewInt(3)), big.NewInt(2)),
+	)
+	return gasFeeCap, gasTipCap, nil
+}
+
+// finalizeWithdrawalParams contains the parameters of the withdrawal finalization, which prove the inclusion
+// of the withdrawal message in the L1 batch.
+type finalizeWithdrawalParams struct {
+	sender            common.Address // Sender of the message, utils.L2BaseTokenAddress for the base token or the L2 bridge.
+	l1BatchNumber     *big.Int
+	l2MessageIndex    *big.Int
+	l2TxNumberInBatch uint16
+	message           []byte
+	proof             [][32]byte
+}
+
+func (a *WalletL1) finalizeWithdrawalParams(ctx context.Context, withdrawalHash common.Hash, index int) (*finalizeWithdrawalParams, error) {
+	message, receipt, err := a.getWithdrawalMessage(ctx, withdrawalHash, index)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get TransactionReceipt: %w", err)
+		return nil, fmt.Errorf("failed to get WithdrawalLog: %w", err)
 	}
-	if receipt == nil {
-		return nil, nil, errors.New("transaction receipt not found")
+	if receipt.L1BatchTxIndex == nil {
+		return nil, errors.New("empty l1BatchTxIndex")
 	}
-	fLogs := make([]*zkTypes.Log, 0)
-	for _, l := range receipt.Logs {
-		if l.Address == utils.L1MessengerAddress && len(l.Topics) > 0 &&
-			bytes.Equal(l.Topics[0].Bytes(), crypto.Keccak256([]byte("L1MessageSent(address,bytes32,bytes)"))) {
-			fLogs = append(fLogs, l)
-		}
+	proof, err := (*a.clientL2).LogProof(ctx, withdrawalHash, message.L2ToL1LogIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L2ToL1LogProof: %w", err)
 	}
-	if len(fLogs) < index+1 {
-		return nil, nil, errors.New("withdrawal log not found")
+	proof32 := make([][32]byte, len(proof.Proof))
+	for i, pr := range proof.Proof {
+		proof32[i] = pr
+	}
+	return &finalizeWithdrawalParams{
+		sender:            message.Sender,
+		l1BatchNumber:     message.Log.L1BatchNumber.ToInt(),
+		l2MessageIndex:    big.NewInt(int64(proof.Id)),
+		l2TxNumberInBatch: uint16(receipt.L1BatchTxIndex.ToInt().Uint64()),
+		message:           message.Message,
+		proof:             proof32,
+	}, nil
+}
+
+// withdrawalL1Bridge returns the address of the L1 bridge finalizing the withdrawals of the L2 bridge.
+func (a *WalletL1) withdrawalL1Bridge(ctx context.Context, l2BridgeAddress common.Address) (common.Address, error) {
+	l2Bridge, err := l2bridge.NewIL2Bridge(l2BridgeAddress, *a.clientL2)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to init l2Bridge: %w", err)
+	}
+	l1BridgeAddress, err := l2Bridge.L1Bridge(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to get l1BridgeAddress: %w", err)
+	}
+	return l1BridgeAddress, nil
+}
+
+func (a *WalletL1) getWithdrawalLog(ctx context.Context, withdrawalHash common.Hash, index int) (*zkTypes.Log, *big.Int, error) {
+	message, receipt, err := a.getWithdrawalMessage(ctx, withdrawalHash, index)
+	if err != nil {
+		return nil, nil, err
 	}
-	return fLogs[index], receipt.L1BatchTxIndex.ToInt(), nil
+	return message.Log, receipt.L1BatchTxIndex.ToInt(), nil
 }
 
 func (a *WalletL1) getWithdrawalL2ToL1Log(ctx context.Context, withdrawalHash common.Hash, index int) (int, *zkTypes.L2ToL1Log, error) {
+	message, _, err := a.getWithdrawalMessage(ctx, withdrawalHash, index)
+	if err != nil {
+		return 0, nil, err
+	}
+	return message.L2ToL1LogIndex, message.L2ToL1Log, nil
+}
+
+func (a *WalletL1) getWithdrawalMessage(ctx context.Context, withdrawalHash common.Hash, index int) (*zkTypes.L1Message, *zkTypes.Receipt, error) {
 	receipt, err := (*a.clientL2).TransactionReceipt(ctx, withdrawalHash)
 	if err != nil {
-		return 0, nil, fmt.Errorf("failed to get TransactionReceipt: %w", err)
+		return nil, nil, fmt.Errorf("failed to get TransactionReceipt: %w", err)
 	}
 	if receipt == nil {
-		return 0, nil, errors.New("transaction receipt not found")
-	}
-	fLogs := make([]struct {
-		i int
-		l *zkTypes.L2ToL1Log
-	}, 0)
-	for i, l := range receipt.L2ToL1Logs {
-		if l.Sender == utils.L1MessengerAddress {
-			fLogs = append(fLogs, struct {
-				i int
-				l *zkTypes.L2ToL1Log
-			}{i, l})
-		}
+		return nil, nil, errors.New("transaction receipt not found")
 	}
-	if len(fLogs) < index+1 {
-		return 0, nil, errors.New("withdrawal L2ToL1 log not found")
+	message, err := receipt.WithdrawalMessage(index)
+	if err != nil {
+		return nil, nil, fmt.Errorf("withdrawal log not found: %w", err)
 	}
-	return fLogs[index].i, fLogs[index].l, nil
+	return message, receipt, nil
 }
 
 func (a *WalletL1) checkIfL1ChainIsLondonReady(ctx context.Context) (bool, *types.Header, error) {
@@ -978,3 +1105,17 @@ func (a *WalletL1) checkIfL1ChainIsLondonReady(ctx context.Context) (bool, *type
 		return false, head, nil
 	}
 }
+
+// signerFn returns the signer function of contract bindings, which consults the hooks and the spending guard,
+// and the function releasing the spending reserved by it, which must be called if sending fails.
+func (a *WalletL1) signerFn() (bind.SignerFn, spendingRelease) {
+	signer, release := guardSigner(a.spendingGuard, a.auth.Signer, true)
+	return a.hooks.signer(signer, true), release
+}
+
+// transactOpts converts the options to the options of contract bindings, which sign transactions
+// using the account of the wallet, and returns the function releasing the spending reserved by signing.
+func (a *WalletL1) transactOpts(opts *TransactOpts) (*bind.TransactOpts, spendingRelease) {
+	signer, release := a.signerFn()
+	return opts.ToTransactOpts(a.auth.From, signer), release
+}
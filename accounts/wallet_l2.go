@@ -3,13 +3,17 @@ package accounts
 import (
 	"context"
 	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/zksync-sdk/zksync2-go/clients"
 	"github.com/zksync-sdk/zksync2-go/contracts/erc20"
 	"github.com/zksync-sdk/zksync2-go/contracts/ethtoken"
 	"github.com/zksync-sdk/zksync2-go/contracts/l2bridge"
+	"github.com/zksync-sdk/zksync2-go/ens"
+	"github.com/zksync-sdk/zksync2-go/tokens"
 	zkTypes "github.com/zksync-sdk/zksync2-go/types"
 	"github.com/zksync-sdk/zksync2-go/utils"
 	"math/big"
@@ -23,6 +27,24 @@ type WalletL2 struct {
 
 	defaultL2BridgeAddress common.Address
 	defaultL2Bridge        *l2bridge.IL2Bridge
+
+	paymasterPolicy PaymasterPolicy
+	nonceManager    NonceManager
+	minimumAmounts  *MinimumAmounts
+	recipientGuard  RecipientGuard
+	paymasterGuard  PaymasterGuard
+	spendingGuard   SpendingGuard
+	signedSpendings signedSpendings // Reservations of the transactions signed by SignTransaction.
+	hooks           *Hooks
+
+	paymasterProvider   PaymasterParamsProvider
+	factoryDepsResolver FactoryDepsResolver
+	gasScaler           *GasScaler
+	gasOracle           GasOracle
+	nameResolver        ens.NameResolver
+	preflightEnabled    bool
+	multicall3Address   common.Address
+	tokenRegistry       *tokens.Registry // Caches the metadata of the tokens whose balances are returned.
 }
 
 // NewWalletL2 creates an instance of WalletL2 associated with the account provided by the raw private key.
@@ -68,9 +90,109 @@ func NewWalletL2FromSigner(signer *Signer, client *clients.Client) (*WalletL2, e
 		auth:                   auth,
 		defaultL2BridgeAddress: bridgeContracts.L2Erc20DefaultBridge,
 		defaultL2Bridge:        defaultL2Bridge,
+		tokenRegistry:          tokens.NewRegistry(*client, nil),
 	}, nil
 }
 
+// SetPaymasterPolicy sets the policy which is enforced when transactions using paymaster are populated
+// and again when they are signed, so that the transactions populated elsewhere cannot bypass it.
+// If the policy is nil, any paymaster can be used.
+func (a *WalletL2) SetPaymasterPolicy(policy PaymasterPolicy) {
+	a.paymasterPolicy = policy
+}
+
+// SetNonceManager sets the nonce manager used to assign nonces to transactions whose nonce is not provided,
+// allowing transactions to be sent concurrently. If the nonce manager is nil, the nonce is fetched from
+// the network for each transaction.
+func (a *WalletL2) SetNonceManager(manager NonceManager) {
+	a.nonceManager = manager
+}
+
+// SetMinimumAmounts sets the minimum amounts of transfers and withdrawals, which are validated before
+// the transactions are sent. If the minimum amounts are nil, any amount can be sent.
+func (a *WalletL2) SetMinimumAmounts(minimums *MinimumAmounts) {
+	a.minimumAmounts = minimums
+}
+
+// SetRecipientGuard sets the guard which checks recipients of transfers and withdrawals before the transactions
+// are sent. If the guard is nil, recipients are not checked.
+func (a *WalletL2) SetRecipientGuard(guard RecipientGuard) {
+	a.recipientGuard = guard
+}
+
+// SetPaymasterGuard sets the guard which limits the fees sponsored by paymasters, enforced before
+// transactions using paymaster are signed. If the guard is nil, the sponsored fees are not limited.
+func (a *WalletL2) SetPaymasterGuard(guard PaymasterGuard) {
+	a.paymasterGuard = guard
+}
+
+// SetSpendingGuard sets the guard enforcing limits on the transactions signed by the wallet, such as
+// SpendingLimits. If the guard is nil, the transactions are not limited.
+func (a *WalletL2) SetSpendingGuard(guard SpendingGuard) {
+	a.spendingGuard = guard
+}
+
+// SetPreflight enables or disables the pre-flight simulation of transactions. When enabled, each transaction
+// is simulated with its final payload, including the gas limit, the fees and the paymaster parameters, right
+// before it is signed and sent, and the transaction is not sent if the simulation fails. The failure is returned
+// as *PreflightError with the decoded revert reason, so that no fee is wasted on doomed transactions.
+func (a *WalletL2) SetPreflight(enabled bool) {
+	a.preflightEnabled = enabled
+}
+
+// SetPaymasterParamsProvider sets the provider of paymaster parameters, e.g. a gas sponsorship service,
+// consulted when transactions which do not specify the paymaster are populated. If the provider is nil,
+// the fee is paid by the account unless the transaction specifies the paymaster. Transfers and withdrawals
+// sent using WalletL2.Transfer and WalletL2.Withdraw are not sponsored, see PaymasterParamsProvider.
+func (a *WalletL2) SetPaymasterParamsProvider(provider PaymasterParamsProvider) {
+	a.paymasterProvider = provider
+}
+
+// SetFactoryDepsResolver sets the resolver providing the bytecode of contracts created by deployed contracts.
+// When deployment transactions are populated, the factory dependencies referenced by the deployed bytecode
+// are added to the transaction. If the resolver is nil, the factory dependencies must be set explicitly.
+func (a *WalletL2) SetFactoryDepsResolver(resolver FactoryDepsResolver) {
+	a.factoryDepsResolver = resolver
+}
+
+// SetMulticall3Address sets the address of the Multicall3 contract aggregating the transfers sent by
+// WalletL2.BatchTransfer, e.g. the one deployed on a local network. If the address is zero,
+// utils.Multicall3Address is used.
+func (a *WalletL2) SetMulticall3Address(address common.Address) {
+	a.multicall3Address = address
+}
+
+// SetGasScaler sets the scaler applied to the estimated gas limits of transactions. If the scaler is nil,
+// the estimations are used as is.
+func (a *WalletL2) SetGasScaler(scaler *GasScaler) {
+	a.gasScaler = scaler
+}
+
+// SetGasOracle sets the oracle providing the max fee per gas of transactions which do not specify it.
+// If the oracle is nil, the gas price suggested by the node is used.
+func (a *WalletL2) SetGasOracle(oracle GasOracle) {
+	a.gasOracle = oracle
+}
+
+// SetNameResolver sets the resolver of the recipient names of transfers and withdrawals. If the resolver
+// is nil, the recipients cannot be specified by name.
+func (a *WalletL2) SetNameResolver(resolver ens.NameResolver) {
+	a.nameResolver = resolver
+}
+
+// ResolveName returns the address the name resolves to, using the name resolver of the wallet.
+func (a *WalletL2) ResolveName(ctx context.Context, name string) (common.Address, error) {
+	var address common.Address
+	err := resolveRecipient(ctx, a.nameResolver, name, &address)
+	return address, err
+}
+
+// SetHooks sets the callbacks invoked on the lifecycle of transactions sent by the wallet.
+// If the hooks are nil, no callbacks are invoked.
+func (a *WalletL2) SetHooks(hooks *Hooks) {
+	a.hooks = hooks
+}
+
 func (a *WalletL2) Address() common.Address {
 	return a.auth.From
 }
@@ -80,7 +202,7 @@ func (a *WalletL2) Signer() Signer {
 }
 
 func (a *WalletL2) Balance(ctx context.Context, token common.Address, at *big.Int) (*big.Int, error) {
-	if token == utils.EthAddress {
+	if token == utils.EthAddress || token == utils.L2BaseTokenAddress {
 		return (*a.client).BalanceAt(ensureContext(ctx), a.Address(), at)
 	}
 	erc20Token, err := erc20.NewIERC20(token, *a.client)
@@ -103,15 +225,47 @@ func (a *WalletL2) L2BridgeContracts(_ context.Context) (*zkTypes.L2BridgeContra
 	return &zkTypes.L2BridgeContracts{Erc20: a.defaultL2Bridge}, nil
 }
 
-func (a *WalletL2) Withdraw(auth *TransactOpts, tx WithdrawalTransaction) (*types.Transaction, error) {
-	opts := ensureTransactOpts(auth)
+func (a *WalletL2) Withdraw(auth *TransactOpts, tx WithdrawalTransaction) (_ *types.Transaction, err error) {
+	if a.minimumAmounts != nil {
+		if err := a.minimumAmounts.CheckWithdrawal(tx.Token, tx.Amount); err != nil {
+			return nil, err
+		}
+	}
+	// Options are copied, so that the reserved nonce is not stored in the provided ones.
+	opts := *ensureTransactOpts(auth)
+	if err := resolveRecipient(opts.Context, a.nameResolver, tx.ToName, &tx.To); err != nil {
+		return nil, err
+	}
+	if err := a.checkRecipient(opts.Context, tx.To); err != nil {
+		return nil, err
+	}
+	defer func() { a.recordRecipient(tx.To, err) }()
+	reserved, err := a.reserveNonce(opts.Context, &opts.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { a.settleNonce(opts.Context, reserved, opts.Nonce, err) }()
 
-	if tx.Token == utils.EthAddress {
-		eth, err := ethtoken.NewIEthToken(utils.L2EthTokenAddress, *a.client)
+	if tx.Token, err = a.resolveL2Token(ensureContext(opts.Context), tx.Token); err != nil {
+		return nil, err
+	}
+	if opts.GasLimit == 0 && a.gasScaler != nil {
+		gas, err := (*a.client).EstimateGasWithdraw(ensureContext(opts.Context), *tx.ToWithdrawalCallMsg(a.Address(), &opts))
 		if err != nil {
 			return nil, err
 		}
-		withdrawTx, err := eth.Withdraw(opts.ToTransactOpts(a.Address(), a.auth.Signer), tx.To)
+		opts.GasLimit = a.gasScaler.Scale(GasOperationWithdraw, gas)
+	}
+	if tx.Token == utils.EthAddress || tx.Token == utils.L2BaseTokenAddress {
+		eth, err := ethtoken.NewIEthToken(utils.L2BaseTokenAddress, *a.client)
+		if err != nil {
+			return nil, err
+		}
+		transactOpts, release, err := a.transactOpts(&opts)
+		if err != nil {
+			return nil, err
+		}
+		withdrawTx, err := a.hooks.sentL2(release.sent(eth.Withdraw(transactOpts, tx.To)))
 		if err != nil {
 			return nil, err
 		}
@@ -124,7 +278,11 @@ func (a *WalletL2) Withdraw(auth *TransactOpts, tx WithdrawalTransaction) (*type
 		if err != nil {
 			return nil, err
 		}
-		withdrawTx, err := bridge.Withdraw(opts.ToTransactOpts(a.Address(), a.auth.Signer), tx.To, tx.Token, tx.Amount)
+		transactOpts, release, err := a.transactOpts(&opts)
+		if err != nil {
+			return nil, err
+		}
+		withdrawTx, err := a.hooks.sentL2(release.sent(bridge.Withdraw(transactOpts, tx.To, tx.Token, tx.Amount)))
 		if err != nil {
 			return nil, err
 		}
@@ -132,30 +290,87 @@ func (a *WalletL2) Withdraw(auth *TransactOpts, tx WithdrawalTransaction) (*type
 	}
 }
 
+func (a *WalletL2) BaseToken(ctx context.Context) (common.Address, error) {
+	return (*a.client).BaseTokenContractAddress(ensureContext(ctx))
+}
+
+func (a *WalletL2) IsEthBasedChain(ctx context.Context) (bool, error) {
+	return (*a.client).IsEthBasedChain(ensureContext(ctx))
+}
+
+func (a *WalletL2) WithdrawBaseToken(auth *TransactOpts, tx WithdrawalTransaction) (*types.Transaction, error) {
+	tx.Token = utils.L2BaseTokenAddress
+	tx.BridgeAddress = nil
+	return a.Withdraw(auth, tx)
+}
+
 func (a *WalletL2) EstimateGasWithdraw(ctx context.Context, msg WithdrawalCallMsg) (uint64, error) {
 	return (*a.client).EstimateGasWithdraw(ensureContext(ctx), msg.ToWithdrawalCallMsg(a.Address()))
 }
 
-func (a *WalletL2) Transfer(auth *TransactOpts, tx TransferTransaction) (*types.Transaction, error) {
+func (a *WalletL2) Transfer(auth *TransactOpts, tx TransferTransaction) (_ *types.Transaction, err error) {
+	if a.minimumAmounts != nil {
+		if err := a.minimumAmounts.CheckTransfer(tx.Token, tx.Amount); err != nil {
+			return nil, err
+		}
+	}
 	opts := ensureTransactOpts(auth)
+	if err := resolveRecipient(opts.Context, a.nameResolver, tx.ToName, &tx.To); err != nil {
+		return nil, err
+	}
+	if err := a.checkRecipient(opts.Context, tx.To); err != nil {
+		return nil, err
+	}
+	defer func() { a.recordRecipient(tx.To, err) }()
+	if tx.Token, err = a.resolveL2Token(ensureContext(opts.Context), tx.Token); err != nil {
+		return nil, err
+	}
+	// The message validates the mode and packs the calldata of the transfer with call.
+	transferMsg := tx.ToTransferCallMsg(a.Address(), opts)
+	msg, err := transferMsg.ToCallMsg()
+	if err != nil {
+		return nil, err
+	}
 	if opts.GasLimit == 0 {
-		gas, err := (*a.client).EstimateGasTransfer(opts.Context, tx.ToTransferCallMsg(a.Address(), opts))
+		gas, err := (*a.client).EstimateGasTransfer(opts.Context, transferMsg)
 		if err != nil {
 			return nil, err
 		}
-		opts.GasLimit = gas
+		opts.GasLimit = a.gasScaler.Scale(GasOperationTransfer, gas)
+	}
+	if a.nonceManager != nil && opts.Nonce == nil {
+		// Options are copied, so that the reserved nonce is not stored in the provided ones.
+		copied := *opts
+		opts = &copied
+		var reserved bool
+		if reserved, err = a.reserveNonce(opts.Context, &opts.Nonce); err != nil {
+			return nil, err
+		}
+		defer func() { a.settleNonce(opts.Context, reserved, opts.Nonce, err) }()
 	}
 
-	if tx.Token == utils.EthAddress {
-		return a.transferETH(opts, tx)
+	if tx.Token == utils.EthAddress || tx.Token == utils.L2BaseTokenAddress {
+		return a.hooks.sentL2(a.transferETH(opts, tx))
 	}
 
+	opts.Value = big.NewInt(0)
+	if tx.Mode != clients.TransferPlain {
+		token := bind.NewBoundContract(tx.Token, abi.ABI{}, *a.client, *a.client, *a.client)
+		transactOpts, release, err := a.transactOpts(opts)
+		if err != nil {
+			return nil, err
+		}
+		return a.hooks.sentL2(release.sent(token.RawTransact(transactOpts, msg.Data)))
+	}
 	token, err := erc20.NewIERC20(tx.Token, *a.client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load erc20 contract: %w", err)
 	}
-	opts.Value = big.NewInt(0)
-	return token.Transfer(opts.ToTransactOpts(a.Address(), a.auth.Signer), tx.To, tx.Amount)
+	transactOpts, release, err := a.transactOpts(opts)
+	if err != nil {
+		return nil, err
+	}
+	return a.hooks.sentL2(release.sent(token.Transfer(transactOpts, tx.To, tx.Amount)))
 }
 
 func (a *WalletL2) EstimateGasTransfer(ctx context.Context, msg TransferCallMsg) (uint64, error) {
@@ -167,6 +382,15 @@ func (a *WalletL2) CallContract(ctx context.Context, msg CallMsg, blockNumber *b
 }
 
 func (a *WalletL2) PopulateTransaction(ctx context.Context, tx Transaction) (*zkTypes.Transaction712, error) {
+	if err := a.providePaymasterParams(ensureContext(ctx), &tx); err != nil {
+		return nil, err
+	}
+	if err := a.resolveFactoryDeps(&tx); err != nil {
+		return nil, err
+	}
+	if err := a.checkPaymasterPolicy(ensureContext(ctx), tx.Meta); err != nil {
+		return nil, err
+	}
 	if tx.ChainID == nil {
 		tx.ChainID = (*a.signer).Domain().ChainId
 	}
@@ -178,7 +402,7 @@ func (a *WalletL2) PopulateTransaction(ctx context.Context, tx Transaction) (*zk
 		tx.Nonce = new(big.Int).SetUint64(nonce)
 	}
 	if tx.GasFeeCap == nil {
-		gasFeeCap, err := (*a.client).SuggestGasPrice(ensureContext(ctx))
+		gasFeeCap, err := gasPrice(ensureContext(ctx), a.gasOracle, *a.client)
 		if err != nil {
 			return nil, fmt.Errorf("failed to SuggestGasPrice: %w", err)
 		}
@@ -195,32 +419,134 @@ func (a *WalletL2) PopulateTransaction(ctx context.Context, tx Transaction) (*zk
 	if tx.Gas == 0 {
 		gas, err := (*a.client).EstimateGasL2(ensureContext(ctx), tx.ToCallMsg(a.Address()))
 		if err != nil {
-			return nil, fmt.Errorf("failed to EstimateGasL2: %w", err)
+			return nil, fmt.Errorf("failed to EstimateGasL2: %w", a.missingFactoryDeps(ensureContext(ctx), &tx, err))
 		}
-		tx.Gas = gas
-
+		operation := GasOperationTransaction
+		if tx.To != nil && *tx.To == utils.ContractDeployerAddress {
+			operation = GasOperationDeploy
+		}
+		tx.Gas = a.gasScaler.Scale(operation, gas)
 	}
 	return tx.ToTransaction712(a.auth.From), nil
 }
 
+// SignTransaction signs the transaction, which is sent by the caller. Its spending, reserved with the spending
+// guard, is held until another transaction with the same nonce is signed, or for 24 hours.
 func (a *WalletL2) SignTransaction(tx *zkTypes.Transaction712) ([]byte, error) {
+	rawTx, release, err := a.signTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Nonce != nil && tx.Nonce.IsUint64() {
+		a.signedSpendings.hold(tx.Nonce.Uint64(), release)
+	}
+	return rawTx, nil
+}
+
+// signTransaction signs the transaction, having reserved its fee with the paymaster guard and its spending
+// with the spending guard. The returned function releases the reservations, if the transaction is not sent.
+// The reservations held for a transaction with the same nonce signed by SignTransaction are released.
+func (a *WalletL2) signTransaction(tx *zkTypes.Transaction712) ([]byte, func(), error) {
+	if err := a.checkBeforeSign(tx); err != nil {
+		return nil, nil, err
+	}
+	releaseSpending, err := a.reserveSpending(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	releaseFee, err := a.reserveSponsoredFee(tx)
+	if err != nil {
+		releaseSpending()
+		return nil, nil, err
+	}
+	release := func() {
+		releaseFee()
+		releaseSpending()
+	}
+	rawTx, err := a.sign(tx)
+	if err != nil {
+		release()
+		return nil, nil, err
+	}
+	if tx.Nonce != nil && tx.Nonce.IsUint64() {
+		a.signedSpendings.replace(tx.Nonce.Uint64())
+	}
+	return rawTx, release, nil
+}
+
+// signReplacement signs the transaction replacing the sent one with the same nonce, e.g. with increased fees.
+// Nothing is reserved, since the replaced transaction holds the reservations which are reused by the replacement.
+func (a *WalletL2) signReplacement(tx *zkTypes.Transaction712) ([]byte, error) {
+	if err := a.checkBeforeSign(tx); err != nil {
+		return nil, err
+	}
+	return a.sign(tx)
+}
+
+// checkBeforeSign enforces the paymaster policy on the transaction, which may have been populated without it,
+// and invokes OnBeforeSign hooks.
+func (a *WalletL2) checkBeforeSign(tx *zkTypes.Transaction712) error {
+	if err := a.checkPaymasterPolicy(context.Background(), tx.Meta); err != nil {
+		return err
+	}
+	return a.hooks.checkBeforeSign(transaction712Event(tx))
+}
+
+// checkPaymasterPolicy returns the error of the paymaster policy, if the transaction uses the rejected paymaster.
+func (a *WalletL2) checkPaymasterPolicy(ctx context.Context, meta *zkTypes.Eip712Meta) error {
+	if a.paymasterPolicy == nil || meta == nil || meta.PaymasterParams == nil {
+		return nil
+	}
+	return a.paymasterPolicy.CheckPaymaster(ctx, meta.PaymasterParams)
+}
+
+// sign signs the transaction and encodes it for sending.
+func (a *WalletL2) sign(tx *zkTypes.Transaction712) ([]byte, error) {
 	signature, err := (*a.signer).SignTypedData((*a.signer).Domain(), tx)
 	if err != nil {
 		return nil, err
 	}
+	if len(signature) != crypto.SignatureLength && (tx.Meta == nil || len(tx.Meta.CustomSignature) == 0) {
+		// Signatures of smart accounts, e.g. the one made by MultisigSigner, are sent as the custom signature.
+		signed := *tx
+		meta := zkTypes.Eip712Meta{}
+		if tx.Meta != nil {
+			meta = *tx.Meta
+		}
+		meta.CustomSignature = signature
+		signed.Meta = &meta
+		tx, signature = &signed, nil
+	}
 	return tx.RLPValues(signature)
 }
 
-func (a *WalletL2) SendTransaction(ctx context.Context, tx *Transaction) (common.Hash, error) {
-	preparedTx, err := a.PopulateTransaction(ensureContext(ctx), *tx)
+func (a *WalletL2) SendTransaction(ctx context.Context, tx *Transaction) (_ common.Hash, err error) {
+	ctx = ensureContext(ctx)
+	populated := *tx
+	reserved, err := a.reserveNonce(ctx, &populated.Nonce)
 	if err != nil {
 		return common.Hash{}, err
 	}
-	rawTx, err := a.SignTransaction(preparedTx)
+	defer func() { a.settleNonce(ctx, reserved, populated.Nonce, err) }()
+
+	preparedTx, err := a.PopulateTransaction(ctx, populated)
 	if err != nil {
 		return common.Hash{}, err
 	}
-	return (*a.client).SendRawTransaction(context.Background(), rawTx)
+	if err = a.preflight712(ctx, preparedTx); err != nil {
+		return common.Hash{}, err
+	}
+	rawTx, release, err := a.signTransaction(preparedTx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	hash, err := (*a.client).SendRawTransaction(ctx, rawTx)
+	a.hooks.sent712(preparedTx, hash, err)
+	if err != nil {
+		release()
+		return common.Hash{}, err
+	}
+	return hash, nil
 }
 
 func (a *WalletL2) transferETH(auth *TransactOpts, tx TransferTransaction) (*types.Transaction, error) {
@@ -242,9 +568,14 @@ func (a *WalletL2) transferETH(auth *TransactOpts, tx TransferTransaction) (*typ
 				Value:    tx.Amount,
 			})
 
-		signedTx, _ := types.SignTx(transaction, types.NewLondonSigner((*a.signer).Domain().ChainId), (*a.signer).PrivateKey())
-		err := (*a.client).SendTransaction(auth.Context, signedTx)
+		signer, release := a.signerFn(auth.Context)
+		signedTx, err := signer(a.Address(), transaction)
+		if err != nil {
+			return nil, err
+		}
+		err = (*a.client).SendTransaction(auth.Context, signedTx)
 		if err != nil {
+			release()
 			return nil, err
 		}
 		return signedTx, nil
@@ -263,11 +594,51 @@ func (a *WalletL2) transferETH(auth *TransactOpts, tx TransferTransaction) (*typ
 				To:        preparedTx.To,
 				Value:     preparedTx.Value,
 			})
-		signedTx, _ := types.SignTx(transaction, types.NewLondonSigner((*a.signer).Domain().ChainId), (*a.signer).PrivateKey())
+		signer, release := a.signerFn(auth.Context)
+		signedTx, err := signer(a.Address(), transaction)
+		if err != nil {
+			return nil, err
+		}
 		err = (*a.client).SendTransaction(auth.Context, signedTx)
 		if err != nil {
+			release()
 			return nil, err
 		}
 		return signedTx, nil
 	}
 }
+
+// signerFn returns the signer function of contract bindings, which consults the hooks and the spending guard,
+// and simulates the transaction if the pre-flight simulation is enabled, and the function releasing
+// the spending reserved by it, which must be called if sending fails.
+func (a *WalletL2) signerFn(ctx context.Context) (bind.SignerFn, spendingRelease) {
+	signer, release := guardSigner(a.spendingGuard, a.auth.Signer, false)
+	return a.hooks.signer(a.preflightSigner(ctx, signer), false), release
+}
+
+// transactOpts converts the options to the options of contract bindings, which sign transactions
+// using the account of the wallet, and returns the function releasing the spending reserved by signing.
+// If the options specify neither the gas price nor the max fee per gas, the max fee per gas is provided
+// by the gas oracle, if set, and the max priority fee per gas is limited to it.
+func (a *WalletL2) transactOpts(opts *TransactOpts) (*bind.TransactOpts, spendingRelease, error) {
+	signer, release := a.signerFn(opts.Context)
+	transactOpts := opts.ToTransactOpts(a.Address(), signer)
+	if a.gasOracle != nil && opts.GasPrice == nil && opts.GasFeeCap == nil {
+		ctx := ensureContext(opts.Context)
+		gasFeeCap, err := a.gasOracle.GasPrice(ctx, *a.client)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get gas price: %w", err)
+		}
+		gasTipCap := opts.GasTipCap
+		if gasTipCap == nil {
+			if gasTipCap, err = (*a.client).SuggestGasTipCap(ctx); err != nil {
+				return nil, nil, fmt.Errorf("failed to get gas tip cap: %w", err)
+			}
+		}
+		if gasTipCap.Cmp(gasFeeCap) > 0 {
+			gasTipCap = new(big.Int).Set(gasFeeCap)
+		}
+		transactOpts.GasFeeCap, transactOpts.GasTipCap = gasFeeCap, gasTipCap
+	}
+	return transactOpts, release, nil
+}
@@ -0,0 +1,138 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"time"
+)
+
+// WithdrawalStage is the stage of the withdrawal performed by Wallet.WithdrawAndWait.
+type WithdrawalStage int
+
+const (
+	WithdrawalSubmitted   WithdrawalStage = iota // The withdrawal has been sent on L2.
+	WithdrawalIncluded                           // The withdrawal has been included in an L2 block.
+	WithdrawalFinalizable                        // The L2 block with the withdrawal has been finalized on L1.
+	WithdrawalFinalizing                         // The finalization has been sent on L1.
+	WithdrawalFinalized                          // The finalization has been included in an L1 block.
+)
+
+func (s WithdrawalStage) String() string {
+	switch s {
+	case WithdrawalSubmitted:
+		return "submitted"
+	case WithdrawalIncluded:
+		return "included"
+	case WithdrawalFinalizable:
+		return "finalizable"
+	case WithdrawalFinalizing:
+		return "finalizing"
+	case WithdrawalFinalized:
+		return "finalized"
+	default:
+		return fmt.Sprintf("WithdrawalStage(%d)", int(s))
+	}
+}
+
+// WithdrawalProgress reports the progress of the withdrawal performed by Wallet.WithdrawAndWait.
+type WithdrawalProgress struct {
+	Stage          WithdrawalStage
+	WithdrawalHash common.Hash // The hash of the L2 withdrawal transaction.
+	FinalizeHash   common.Hash // The hash of the L1 finalization transaction, set from WithdrawalFinalizing.
+}
+
+// WithdrawalResult contains the transactions of the completed withdrawal.
+type WithdrawalResult struct {
+	WithdrawalReceipt *zkTypes.Receipt // The receipt of the L2 withdrawal transaction.
+	// The receipt of the L1 finalization transaction. It is nil if the withdrawal has been finalized by
+	// another account in the meantime.
+	FinalizeReceipt *types.Receipt
+}
+
+// WithdrawAndWait withdraws the token from the associated account on L2 network to the target account on L1
+// network, waits until the L2 block containing the withdrawal is finalized on L1 and finalizes the withdrawal.
+// The onProgress function is called when the withdrawal reaches each stage, it can be nil. Since the
+// finalization of L2 blocks can take hours, the context should not have a short deadline; if the waiting
+// is interrupted, the withdrawal can be finalized later using FinalizeWithdraw.
+// The latency of the completed withdrawal is observed by the metrics set using Wallet.SetBridgeMetrics.
+func (w *Wallet) WithdrawAndWait(ctx context.Context, tx WithdrawalTransaction, onProgress func(WithdrawalProgress)) (*WithdrawalResult, error) {
+	if w.AdapterL1 == nil || w.clientL1 == nil {
+		return nil, errors.New("wallet is not connected to L1 network")
+	}
+	ctx = ensureContext(ctx)
+	progress := func(p WithdrawalProgress) {
+		if onProgress != nil {
+			onProgress(p)
+		}
+	}
+
+	start := time.Now()
+	withdrawTx, err := w.Withdraw(&TransactOpts{Context: ctx}, tx)
+	if err != nil {
+		return nil, err
+	}
+	hash := withdrawTx.Hash()
+	observe := func(l1TxHash common.Hash, failed bool) {
+		if w.bridgeMetrics != nil {
+			w.bridgeMetrics.ObserveBridgeLatency(BridgeLatency{
+				Flow:     BridgeWithdrawal,
+				L1TxHash: l1TxHash,
+				L2TxHash: hash,
+				Latency:  time.Since(start),
+				Failed:   failed,
+			})
+		}
+	}
+	progress(WithdrawalProgress{Stage: WithdrawalSubmitted, WithdrawalHash: hash})
+
+	receipt, err := (*w.clientL2).WaitMined(ctx, hash)
+	w.hooks.minedL2(hash, receipt, err)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		observe(common.Hash{}, true)
+		return nil, fmt.Errorf("withdrawal transaction %s failed", hash)
+	}
+	progress(WithdrawalProgress{Stage: WithdrawalIncluded, WithdrawalHash: hash})
+
+	if _, err = (*w.clientL2).WaitFinalized(ctx, hash); err != nil {
+		return nil, err
+	}
+	progress(WithdrawalProgress{Stage: WithdrawalFinalizable, WithdrawalHash: hash})
+
+	result := &WithdrawalResult{WithdrawalReceipt: receipt}
+	finalized, err := w.IsWithdrawFinalized(&CallOpts{Context: ctx}, hash, 0)
+	if err != nil {
+		return nil, err
+	}
+	if finalized {
+		observe(common.Hash{}, false)
+		progress(WithdrawalProgress{Stage: WithdrawalFinalized, WithdrawalHash: hash})
+		return result, nil
+	}
+
+	finalizeTx, err := w.FinalizeWithdraw(&TransactOpts{Context: ctx}, hash, 0)
+	if err != nil {
+		return nil, err
+	}
+	progress(WithdrawalProgress{Stage: WithdrawalFinalizing, WithdrawalHash: hash, FinalizeHash: finalizeTx.Hash()})
+
+	result.FinalizeReceipt, err = bind.WaitMined(ctx, w.clientL1, finalizeTx)
+	if err != nil {
+		return nil, err
+	}
+	w.hooks.Mined(transactionEvent(finalizeTx, true), result.FinalizeReceipt)
+	if result.FinalizeReceipt.Status != types.ReceiptStatusSuccessful {
+		observe(finalizeTx.Hash(), true)
+		return nil, fmt.Errorf("finalization transaction %s failed", finalizeTx.Hash())
+	}
+	observe(finalizeTx.Hash(), false)
+	progress(WithdrawalProgress{Stage: WithdrawalFinalized, WithdrawalHash: hash, FinalizeHash: finalizeTx.Hash()})
+	return result, nil
+}
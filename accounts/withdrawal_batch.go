@@ -0,0 +1,263 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/zksync-sdk/zksync2-go/clients"
+	"github.com/zksync-sdk/zksync2-go/contracts/l1bridge"
+	"github.com/zksync-sdk/zksync2-go/contracts/multicall3"
+	"github.com/zksync-sdk/zksync2-go/contracts/zksync"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"github.com/zksync-sdk/zksync2-go/utils"
+)
+
+var (
+	// ErrWithdrawalsFinalized is returned by AdapterL1.FinalizeWithdrawals when none of the messages of the
+	// provided withdrawals is left to be finalized, so no transaction is sent.
+	ErrWithdrawalsFinalized = errors.New("no withdrawals left to finalize")
+	// ErrWithdrawalFinalized is the outcome of the message skipped by AdapterL1.FinalizeWithdrawals,
+	// because it is already finalized.
+	ErrWithdrawalFinalized = errors.New("withdrawal already finalized")
+	// ErrUnknownWithdrawalSender is the outcome of the message skipped by AdapterL1.FinalizeWithdrawals,
+	// because it is sent by a contract which is neither the base token nor a known L2 bridge.
+	ErrUnknownWithdrawalSender = errors.New("message not sent by the base token or a known bridge")
+	// ErrFinalizationFailed is the outcome of the finalization batched by AdapterL1.FinalizeWithdrawals,
+	// which fails when the transaction is simulated before it is sent.
+	ErrFinalizationFailed = errors.New("withdrawal finalization failed")
+)
+
+// WithdrawalFinalization is the outcome of the message sent to L1 by the withdrawal transaction,
+// as batched by AdapterL1.FinalizeWithdrawals.
+type WithdrawalFinalization struct {
+	Hash  common.Hash // Hash of the withdrawal transaction on L2.
+	Index int         // Index of the message among the messages sent to L1 by the transaction.
+	// Err is nil if the finalization is batched and succeeds when the transaction is simulated before it
+	// is sent, which AdapterL1.IsWithdrawFinalized confirms once the transaction is included. Otherwise,
+	// it matches ErrWithdrawalFinalized or ErrUnknownWithdrawalSender for the skipped messages, or
+	// ErrFinalizationFailed for the batched finalization failing in the simulation.
+	Err error
+}
+
+func (a *WalletL1) FinalizeWithdrawals(auth *TransactOpts, withdrawalHashes []common.Hash) (*types.Transaction, []WithdrawalFinalization, error) {
+	if a.clientL1 == nil {
+		return nil, nil, errors.New("ethereum provider is not initialized")
+	}
+	if len(withdrawalHashes) == 0 {
+		return nil, nil, errors.New("no withdrawals provided")
+	}
+	opts := ensureTransactOpts(auth)
+	callOpts := &bind.CallOpts{Context: opts.Context}
+
+	mainContractAbi, err := zksync.IZkSyncMetaData.GetAbi()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load IZkSync ABI: %w", err)
+	}
+	l1BridgeAbi, err := l1bridge.IL1BridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load IL1Bridge ABI: %w", err)
+	}
+	multicallAbi, err := multicall3.IMulticall3MetaData.GetAbi()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load multicall3 ABI: %w", err)
+	}
+	l2Bridges, err := a.knownL2Bridges(opts.Context)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The L1 bridges are resolved once for all withdrawals through the same L2 bridge.
+	l1Bridges := make(map[common.Address]common.Address)
+	seen := make(map[common.Hash]bool, len(withdrawalHashes))
+	var (
+		finalizations []WithdrawalFinalization
+		calls         []multicall3.Multicall3Call3
+		batched       []int // Indexes of the finalizations of the calls.
+	)
+	for _, hash := range withdrawalHashes {
+		if seen[hash] {
+			continue
+		}
+		seen[hash] = true
+		count, err := a.withdrawalCount(opts.Context, hash)
+		if err != nil {
+			return nil, nil, fmt.Errorf("withdrawal %s: %w", hash, err)
+		}
+		for index := 0; index < count; index++ {
+			call, err := a.finalizeWithdrawalCall(callOpts, hash, index, l2Bridges, l1Bridges, mainContractAbi, l1BridgeAbi)
+			if err != nil && !errors.Is(err, ErrWithdrawalFinalized) && !errors.Is(err, ErrUnknownWithdrawalSender) {
+				return nil, nil, fmt.Errorf("withdrawal %s, message %d: %w", hash, index, err)
+			}
+			if call != nil {
+				calls = append(calls, *call)
+				batched = append(batched, len(finalizations))
+			}
+			finalizations = append(finalizations, WithdrawalFinalization{Hash: hash, Index: index, Err: err})
+		}
+	}
+	if len(calls) == 0 {
+		return nil, finalizations, ErrWithdrawalsFinalized
+	}
+
+	multicallAddress := a.multicall3()
+	calldata, err := multicallAbi.Pack("aggregate3", calls)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to pack aggregate3: %w", err)
+	}
+	output, err := a.clientL1.CallContract(opts.Context, ethereum.CallMsg{
+		From: a.auth.From,
+		To:   &multicallAddress,
+		Data: calldata,
+	}, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to simulate finalizations: %w", err)
+	}
+	unpacked, err := multicallAbi.Unpack("aggregate3", output)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unpack aggregate3 results: %w", err)
+	}
+	results := *abi.ConvertType(unpacked[0], new([]multicall3.Multicall3Result)).(*[]multicall3.Multicall3Result)
+	if len(results) != len(calls) {
+		return nil, nil, fmt.Errorf("aggregate3 returned %d results for %d calls", len(results), len(calls))
+	}
+	for i, result := range results {
+		if !result.Success {
+			finalizations[batched[i]].Err = failedFinalization(result.ReturnData)
+		}
+	}
+
+	multicall, err := multicall3.NewIMulticall3(multicallAddress, a.clientL1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to init multicall3: %w", err)
+	}
+	transactOpts, release := a.transactOpts(opts)
+	tx, err := a.hooks.sentL1(release.sent(multicall.Aggregate3(transactOpts, calls)))
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, finalizations, nil
+}
+
+// failedFinalization returns the outcome of the batched finalization which has failed with the revert data.
+func failedFinalization(returnData []byte) error {
+	if decoded, err := utils.DecodeRevert(returnData); err == nil {
+		return fmt.Errorf("%w: %s", ErrFinalizationFailed, decoded)
+	}
+	return fmt.Errorf("%w: %s", ErrFinalizationFailed, hexutil.Encode(returnData))
+}
+
+// multicall3 returns the address of the Multicall3 contract on L1 used by the wallet.
+func (a *WalletL1) multicall3() common.Address {
+	if a.multicall3Address != (common.Address{}) {
+		return a.multicall3Address
+	}
+	return utils.L1Multicall3Address
+}
+
+// withdrawalCount returns the number of messages sent to L1 by the withdrawal transaction.
+func (a *WalletL1) withdrawalCount(ctx context.Context, withdrawalHash common.Hash) (int, error) {
+	receipt, err := (*a.clientL2).TransactionReceipt(ctx, withdrawalHash)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get TransactionReceipt: %w", err)
+	}
+	if receipt == nil {
+		return 0, errors.New("transaction receipt not found")
+	}
+	messages, err := receipt.L1Messages()
+	if err != nil {
+		return 0, err
+	}
+	if len(messages) == 0 {
+		return 0, fmt.Errorf("withdrawal log not found: %w", zkTypes.ErrL1MessageNotFound)
+	}
+	return len(messages), nil
+}
+
+// knownL2Bridges returns the default L2 bridges and the custom bridges of the L2 client, whose messages
+// are finalized by FinalizeWithdrawals.
+func (a *WalletL1) knownL2Bridges(ctx context.Context) (map[common.Address]bool, error) {
+	bridgeContracts, err := (*a.clientL2).BridgeContracts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bridge contracts: %w", err)
+	}
+	bridges := map[common.Address]bool{bridgeContracts.L2Erc20DefaultBridge: true}
+	if bridgeContracts.L2SharedDefaultBridge != (common.Address{}) {
+		bridges[bridgeContracts.L2SharedDefaultBridge] = true
+	}
+	type bridgesClient interface {
+		Bridges() *clients.BridgeRegistry
+	}
+	if c, ok := (*a.clientL2).(bridgesClient); ok && c.Bridges() != nil {
+		for _, bridge := range c.Bridges().Bridges() {
+			bridges[bridge.L2Bridge] = true
+		}
+	}
+	return bridges, nil
+}
+
+// finalizeWithdrawalCall returns the call finalizing the withdrawal message with the index. The message
+// is skipped with ErrWithdrawalFinalized if it is already finalized, or with ErrUnknownWithdrawalSender if
+// the sender is neither the base token nor one of the L2 bridges. The resolved L1 bridges are cached by
+// the addresses of L2 bridges.
+func (a *WalletL1) finalizeWithdrawalCall(callOpts *bind.CallOpts, hash common.Hash, index int,
+	l2Bridges map[common.Address]bool, l1Bridges map[common.Address]common.Address,
+	mainContractAbi, l1BridgeAbi *abi.ABI) (*multicall3.Multicall3Call3, error) {
+	params, err := a.finalizeWithdrawalParams(callOpts.Context, hash, index)
+	if err != nil {
+		return nil, err
+	}
+	if params.sender != utils.L2BaseTokenAddress && !l2Bridges[params.sender] {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownWithdrawalSender, params.sender.Hex())
+	}
+
+	var (
+		target    common.Address
+		finalized bool
+		calldata  []byte
+	)
+	if params.sender == utils.L2BaseTokenAddress {
+		target = a.mainContractAddress
+		finalized, err = a.mainContract.IsEthWithdrawalFinalized(callOpts, params.l1BatchNumber, params.l2MessageIndex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check finalization: %w", err)
+		}
+		calldata, err = mainContractAbi.Pack("finalizeEthWithdrawal", params.l1BatchNumber, params.l2MessageIndex,
+			params.l2TxNumberInBatch, params.message, params.proof)
+	} else {
+		var ok bool
+		if target, ok = l1Bridges[params.sender]; !ok {
+			if target, err = a.withdrawalL1Bridge(callOpts.Context, params.sender); err != nil {
+				return nil, err
+			}
+			l1Bridges[params.sender] = target
+		}
+		l1Bridge, bindErr := l1bridge.NewIL1Bridge(target, a.clientL1)
+		if bindErr != nil {
+			return nil, fmt.Errorf("failed to init l1Bridge: %w", bindErr)
+		}
+		finalized, err = l1Bridge.IsWithdrawalFinalized(callOpts, params.l1BatchNumber, params.l2MessageIndex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check finalization: %w", err)
+		}
+		calldata, err = l1BridgeAbi.Pack("finalizeWithdrawal", params.l1BatchNumber, params.l2MessageIndex,
+			params.l2TxNumberInBatch, params.message, params.proof)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack finalization: %w", err)
+	}
+	if finalized {
+		// The finalization of the finalized withdrawal reverts, wasting the gas of the call.
+		return nil, ErrWithdrawalFinalized
+	}
+	return &multicall3.Multicall3Call3{
+		Target:       target,
+		AllowFailure: true,
+		CallData:     calldata,
+	}, nil
+}
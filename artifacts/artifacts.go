@@ -0,0 +1,227 @@
+// Package artifacts loads the ABI, bytecode and factory dependencies of contracts from the output of zkSync
+// compilers: the combined JSON of zksolc, the artifacts of hardhat-zksync and the output of foundry-zksync.
+// The factory dependencies are resolved transitively, so that the loaded contracts can be deployed directly
+// using accounts.CreateTransaction or accounts.Create2Transaction.
+package artifacts
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/zksync-sdk/zksync2-go/accounts"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"sort"
+	"strings"
+)
+
+// ErrNotFound is returned by Set.Get when the contract is not in the set.
+var ErrNotFound = errors.New("artifact not found")
+
+// Artifact is the compiled contract.
+type Artifact struct {
+	Name     string  // Fully qualified name of the contract, e.g. contracts/Token.sol:Token.
+	ABI      abi.ABI // ABI of the contract.
+	Bytecode []byte  // EraVM bytecode of the contract, empty for abstract contracts and interfaces.
+	// FactoryDeps maps the bytecode hashes of the contracts which can be deployed by the contract
+	// to their fully qualified names, as reported by the compiler.
+	FactoryDeps map[common.Hash]string
+	// Dependencies contains the bytecode of all factory dependencies, including the transitive ones,
+	// which must be passed along with the bytecode when the contract is deployed.
+	Dependencies [][]byte
+}
+
+// Calldata returns the ABI-encoded arguments of the constructor.
+func (a *Artifact) Calldata(args ...interface{}) ([]byte, error) {
+	calldata, err := a.ABI.Pack("", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode constructor arguments of %s: %w", a.Name, err)
+	}
+	return calldata, nil
+}
+
+// CreateTransaction returns the transaction deploying the contract using CREATE, with the constructor arguments.
+func (a *Artifact) CreateTransaction(args ...interface{}) (*accounts.CreateTransaction, error) {
+	calldata, err := a.Calldata(args...)
+	if err != nil {
+		return nil, err
+	}
+	return &accounts.CreateTransaction{
+		Bytecode:     a.Bytecode,
+		Calldata:     calldata,
+		Dependencies: a.Dependencies,
+	}, nil
+}
+
+// Create2Transaction returns the transaction deploying the contract using CREATE2 with the salt,
+// with the constructor arguments.
+func (a *Artifact) Create2Transaction(salt []byte, args ...interface{}) (*accounts.Create2Transaction, error) {
+	calldata, err := a.Calldata(args...)
+	if err != nil {
+		return nil, err
+	}
+	return &accounts.Create2Transaction{
+		Bytecode:     a.Bytecode,
+		Calldata:     calldata,
+		Salt:         salt,
+		Dependencies: a.Dependencies,
+	}, nil
+}
+
+// Set is the set of artifacts loaded from the compiler output, in which the factory dependencies are resolved.
+type Set struct {
+	artifacts  map[string]*Artifact
+	byHash     map[common.Hash]*Artifact
+	unresolved map[string]error // Errors of the artifacts whose dependencies are missing from the output.
+}
+
+var _ accounts.FactoryDepsResolver = (*Set)(nil)
+
+func newSet() *Set {
+	return &Set{
+		artifacts:  make(map[string]*Artifact),
+		byHash:     make(map[common.Hash]*Artifact),
+		unresolved: make(map[string]error),
+	}
+}
+
+// add adds the artifact to the set.
+func (s *Set) add(artifact *Artifact) error {
+	if _, ok := s.artifacts[artifact.Name]; ok {
+		return fmt.Errorf("duplicate artifact %s", artifact.Name)
+	}
+	s.artifacts[artifact.Name] = artifact
+	if len(artifact.Bytecode) == 0 {
+		return nil
+	}
+	hash, err := utils.HashBytecode(artifact.Bytecode)
+	if err != nil {
+		return fmt.Errorf("invalid bytecode of %s: %w", artifact.Name, err)
+	}
+	s.byHash[common.BytesToHash(hash)] = artifact
+	return nil
+}
+
+// resolve resolves the factory dependencies of all artifacts. The dependencies are found by their bytecode
+// hashes rather than by their names, which are reported differently by the compilers. The artifacts whose
+// dependencies are missing are reported by Get, so that the rest of the output can still be used.
+func (s *Set) resolve() {
+	for _, artifact := range s.artifacts {
+		seen := make(map[common.Hash]bool)
+		var visit func(a *Artifact) error
+		visit = func(a *Artifact) error {
+			for _, hash := range sortedHashes(a.FactoryDeps) {
+				if seen[hash] {
+					continue
+				}
+				seen[hash] = true
+				dep, ok := s.byHash[hash]
+				if !ok {
+					return fmt.Errorf("factory dependency %s (%s) of %s not found", a.FactoryDeps[hash], hash, artifact.Name)
+				}
+				artifact.Dependencies = append(artifact.Dependencies, dep.Bytecode)
+				if err := visit(dep); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		artifact.Dependencies = nil
+		if err := visit(artifact); err != nil {
+			s.unresolved[artifact.Name] = err
+		}
+	}
+}
+
+// Get returns the artifact of the contract, identified either by its fully qualified name,
+// e.g. contracts/Token.sol:Token, or by the contract name, if it is unique within the set.
+func (s *Set) Get(name string) (*Artifact, error) {
+	found, err := s.find(name)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.unresolved[found.Name]; err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+func (s *Set) find(name string) (*Artifact, error) {
+	if artifact, ok := s.artifacts[name]; ok {
+		return artifact, nil
+	}
+	var found *Artifact
+	for fullName, artifact := range s.artifacts {
+		if fullName[strings.LastIndex(fullName, ":")+1:] != name {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("ambiguous contract name %s: %s, %s", name, found.Name, artifact.Name)
+		}
+		found = artifact
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return found, nil
+}
+
+// FactoryDep returns the bytecode of the contract with the bytecode hash, so that the set can be used
+// as accounts.FactoryDepsResolver.
+func (s *Set) FactoryDep(hash common.Hash) ([]byte, bool) {
+	artifact, ok := s.byHash[hash]
+	if !ok {
+		return nil, false
+	}
+	return artifact.Bytecode, true
+}
+
+// ProxyBytecodes returns the bytecode of the OpenZeppelin proxy contracts in the set, used by the proxy
+// deployment of accounts.Wallet. The contracts missing in the set are left empty, and the error is returned
+// only if none of them is found.
+func (s *Set) ProxyBytecodes() (*accounts.ProxyBytecodes, error) {
+	bytecodes := &accounts.ProxyBytecodes{}
+	found := false
+	for name, bytecode := range map[string]*[]byte{
+		"ERC1967Proxy":                &bytecodes.ERC1967Proxy,
+		"TransparentUpgradeableProxy": &bytecodes.TransparentUpgradeableProxy,
+		"ProxyAdmin":                  &bytecodes.ProxyAdmin,
+		"BeaconProxy":                 &bytecodes.BeaconProxy,
+		"UpgradeableBeacon":           &bytecodes.UpgradeableBeacon,
+	} {
+		artifact, err := s.Get(name)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		*bytecode = artifact.Bytecode
+		found = true
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: OpenZeppelin proxy contracts", ErrNotFound)
+	}
+	return bytecodes, nil
+}
+
+// Names returns the sorted fully qualified names of the contracts in the set.
+func (s *Set) Names() []string {
+	names := make([]string, 0, len(s.artifacts))
+	for name := range s.artifacts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedHashes(deps map[common.Hash]string) []common.Hash {
+	hashes := make([]common.Hash, 0, len(deps))
+	for hash := range deps {
+		hashes = append(hashes, hash)
+	}
+	sort.Slice(hashes, func(i, j int) bool {
+		return hashes[i].Big().Cmp(hashes[j].Big()) < 0
+	})
+	return hashes
+}
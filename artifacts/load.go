@@ -0,0 +1,222 @@
+package artifacts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// hardhatFormat is the prefix of the format of artifacts produced by hardhat-zksync.
+const hardhatFormat = "hh-zksolc-artifact"
+
+// Load loads the artifacts from the path, which is either the directory with the artifacts produced by
+// hardhat-zksync (artifacts-zk) or foundry-zksync (zkout), or the combined JSON file produced by zksolc.
+func Load(path string) (*Set, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return LoadDir(path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return LoadCombinedJSON(data)
+}
+
+// LoadCombinedJSON parses the output of zksolc --combined-json abi,bin.
+func LoadCombinedJSON(data []byte) (*Set, error) {
+	var output struct {
+		Contracts map[string]struct {
+			ABI         json.RawMessage   `json:"abi"`
+			Bin         json.RawMessage   `json:"bin"`
+			FactoryDeps map[string]string `json:"factory-deps"`
+		} `json:"contracts"`
+	}
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, fmt.Errorf("failed to decode combined JSON: %w", err)
+	}
+	if output.Contracts == nil {
+		return nil, fmt.Errorf("combined JSON contains no contracts")
+	}
+	set := newSet()
+	for name, contract := range output.Contracts {
+		artifact, err := newArtifact(name, contract.ABI, contract.Bin, contract.FactoryDeps)
+		if err != nil {
+			return nil, err
+		}
+		if err = set.add(artifact); err != nil {
+			return nil, err
+		}
+	}
+	set.resolve()
+	return set, nil
+}
+
+// LoadDir loads the artifacts from the directory produced by hardhat-zksync (artifacts-zk)
+// or foundry-zksync (zkout). JSON files which are not zkSync artifacts, such as debug files
+// and build info, are skipped.
+func LoadDir(dir string) (*Set, error) {
+	set := newSet()
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == "build-info" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".json" || strings.HasSuffix(path, ".dbg.json") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		artifact, err := parseArtifactFile(path, data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if artifact == nil {
+			return nil
+		}
+		return set.add(artifact)
+	})
+	if err != nil {
+		return nil, err
+	}
+	set.resolve()
+	return set, nil
+}
+
+// artifactFile is the artifact of a single contract produced by hardhat-zksync or foundry-zksync.
+type artifactFile struct {
+	Format       string          `json:"_format"`
+	ContractName string          `json:"contractName"`
+	SourceName   string          `json:"sourceName"`
+	ABI          json.RawMessage `json:"abi"`
+	Bytecode     json.RawMessage `json:"bytecode"`
+	// FactoryDeps are reported by hardhat-zksync.
+	FactoryDeps map[string]string `json:"factoryDeps"`
+	// FactoryDependencies are reported by foundry-zksync.
+	FactoryDependencies map[string]string `json:"factoryDependencies"`
+	Metadata            json.RawMessage   `json:"metadata"`
+}
+
+// parseArtifactFile parses the artifact file, returning nil if it is not the zkSync artifact.
+func parseArtifactFile(path string, data []byte) (*Artifact, error) {
+	var file artifactFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		// Other JSON files, e.g. caches containing arrays, may be present in the output.
+		return nil, nil
+	}
+	switch {
+	case strings.HasPrefix(file.Format, hardhatFormat):
+		return newArtifact(file.SourceName+":"+file.ContractName, file.ABI, file.Bytecode, file.FactoryDeps)
+	case file.Format == "" && file.ABI != nil && file.Bytecode != nil:
+		return newArtifact(foundryName(path, file.Metadata), file.ABI, file.Bytecode, file.FactoryDependencies)
+	default:
+		return nil, nil
+	}
+}
+
+// foundryName returns the fully qualified name of the contract in the foundry output. It is taken
+// from the compilation target in the metadata, and falls back to the layout of the output directory,
+// in which the artifact of the contract C in the source file S.sol is stored in S.sol/C.json.
+func foundryName(path string, metadata json.RawMessage) string {
+	var meta struct {
+		Settings struct {
+			CompilationTarget map[string]string `json:"compilationTarget"`
+		} `json:"settings"`
+	}
+	if json.Unmarshal(metadata, &meta) == nil && len(meta.Settings.CompilationTarget) == 1 {
+		for source, contract := range meta.Settings.CompilationTarget {
+			return source + ":" + contract
+		}
+	}
+	return filepath.Base(filepath.Dir(path)) + ":" + strings.TrimSuffix(filepath.Base(path), ".json")
+}
+
+func newArtifact(name string, rawABI, rawBytecode json.RawMessage, factoryDeps map[string]string) (*Artifact, error) {
+	parsedABI, err := parseABI(rawABI)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ABI of %s: %w", name, err)
+	}
+	bytecode, err := parseBytecode(rawBytecode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bytecode of %s: %w", name, err)
+	}
+	artifact := &Artifact{
+		Name:        name,
+		ABI:         parsedABI,
+		Bytecode:    bytecode,
+		FactoryDeps: make(map[common.Hash]string, len(factoryDeps)),
+	}
+	for hash, dep := range factoryDeps {
+		decoded, err := hexutil.Decode(with0x(hash))
+		if err != nil || len(decoded) != common.HashLength {
+			return nil, fmt.Errorf("invalid hash of factory dependency %s of %s", dep, name)
+		}
+		artifact.FactoryDeps[common.BytesToHash(decoded)] = dep
+	}
+	return artifact, nil
+}
+
+// parseABI parses the ABI, which is either the JSON array or the string containing it.
+func parseABI(raw json.RawMessage) (abi.ABI, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return abi.ABI{}, nil
+	}
+	if raw[0] == '"' {
+		var text string
+		if err := json.Unmarshal(raw, &text); err != nil {
+			return abi.ABI{}, err
+		}
+		raw = []byte(text)
+	}
+	return abi.JSON(bytes.NewReader(raw))
+}
+
+// parseBytecode parses the bytecode, which is either the hex string, with or without the 0x prefix,
+// or the object containing it, as produced by foundry.
+func parseBytecode(raw json.RawMessage) ([]byte, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, nil
+	}
+	var text string
+	if raw[0] == '{' {
+		var object struct {
+			Object string `json:"object"`
+		}
+		if err := json.Unmarshal(raw, &object); err != nil {
+			return nil, err
+		}
+		text = object.Object
+	} else if err := json.Unmarshal(raw, &text); err != nil {
+		return nil, err
+	}
+	if text == "" || text == "0x" {
+		return nil, nil
+	}
+	return hexutil.Decode(with0x(text))
+}
+
+func with0x(s string) string {
+	if strings.HasPrefix(s, "0x") {
+		return s
+	}
+	return "0x" + s
+}
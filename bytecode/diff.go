@@ -0,0 +1,113 @@
+// Package bytecode compares deployed contract bytecode with local compiler output, ignoring the metadata
+// appended by compilers, and verifies that proxy upgrades changed the implementation code as intended.
+package bytecode
+
+import (
+	"bytes"
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"math/big"
+)
+
+// wordSize is the size of EraVM bytecode word.
+const wordSize = 32
+
+// Backend provides the code and storage of deployed contracts. Both clients.Client
+// and ethclient.Client implement it.
+type Backend interface {
+	// CodeAt returns the contract code of the given account.
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+	// StorageAt returns the value of key in the contract storage of the given account.
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+}
+
+// Normalize returns the bytecode without the compiler metadata, which differs between builds of the
+// same source code, e.g. due to different file paths or comments. For EVM bytecode produced by solc,
+// the CBOR-encoded metadata is removed. For EraVM bytecode produced by zksolc, the last word containing
+// the metadata hash is zeroed. The returned bytecode is a copy.
+func Normalize(code []byte) []byte {
+	if stripped, ok := stripCBORMetadata(code); ok {
+		return stripped
+	}
+	normalized := common.CopyBytes(code)
+	if isEraVMBytecode(normalized) {
+		copy(normalized[len(normalized)-wordSize:], make([]byte, wordSize))
+	}
+	return normalized
+}
+
+// stripCBORMetadata removes the CBOR-encoded metadata appended by solc, whose length is stored
+// in the last two bytes of the bytecode.
+func stripCBORMetadata(code []byte) ([]byte, bool) {
+	if len(code) < 2 {
+		return nil, false
+	}
+	length := int(code[len(code)-2])<<8 | int(code[len(code)-1])
+	start := len(code) - 2 - length
+	if length == 0 || start < 0 {
+		return nil, false
+	}
+	metadata := code[start : len(code)-2]
+	// The metadata is a CBOR map, which contains the compiler version.
+	if metadata[0] < 0xa1 || metadata[0] > 0xb7 || !bytes.Contains(metadata, []byte("solc")) {
+		return nil, false
+	}
+	return common.CopyBytes(code[:start]), true
+}
+
+// isEraVMBytecode reports whether the bytecode has the EraVM format: an odd number of 32-byte words.
+func isEraVMBytecode(code []byte) bool {
+	return len(code) > 0 && len(code)%wordSize == 0 && (len(code)/wordSize)%2 == 1
+}
+
+// Diff is the result of comparing two bytecodes.
+type Diff struct {
+	Identical  bool // Whether the bytecodes are byte-for-byte identical.
+	Equivalent bool // Whether the bytecodes are identical after removing the compiler metadata.
+	// Offset of the first differing byte of the normalized bytecodes, or -1 if they are equivalent.
+	FirstDifference int
+	SizeA, SizeB    int         // Sizes of the bytecodes, including the metadata.
+	HashA, HashB    common.Hash // Keccak-256 hashes of the normalized bytecodes.
+}
+
+// Compare compares the bytecodes, ignoring the compiler metadata.
+func Compare(a, b []byte) Diff {
+	normalizedA, normalizedB := Normalize(a), Normalize(b)
+	diff := Diff{
+		Identical:       bytes.Equal(a, b),
+		Equivalent:      bytes.Equal(normalizedA, normalizedB),
+		FirstDifference: -1,
+		SizeA:           len(a),
+		SizeB:           len(b),
+		HashA:           crypto.Keccak256Hash(normalizedA),
+		HashB:           crypto.Keccak256Hash(normalizedB),
+	}
+	if !diff.Equivalent {
+		diff.FirstDifference = firstDifference(normalizedA, normalizedB)
+	}
+	return diff
+}
+
+func firstDifference(a, b []byte) int {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
+
+// CompareDeployed fetches the code of the contract at the latest block and compares it
+// with the local bytecode, e.g. the one from the compiler artifact.
+func CompareDeployed(ctx context.Context, backend Backend, contract common.Address, local []byte) (Diff, error) {
+	code, err := backend.CodeAt(ctx, contract, nil)
+	if err != nil {
+		return Diff{}, err
+	}
+	return Compare(code, local), nil
+}
@@ -0,0 +1,104 @@
+package bytecode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+)
+
+// ImplementationSlot is the EIP-1967 storage slot holding the address of the proxy implementation.
+var ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+// AdminSlot is the EIP-1967 storage slot holding the address of the admin of the transparent proxy.
+var AdminSlot = common.HexToHash("0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103")
+
+// BeaconSlot is the EIP-1967 storage slot holding the address of the beacon of the beacon proxy.
+var BeaconSlot = common.HexToHash("0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50")
+
+// UpgradeReport is the result of verifying the proxy upgrade.
+type UpgradeReport struct {
+	Proxy                  common.Address
+	PreviousImplementation common.Address // The implementation before the upgrade.
+	Implementation         common.Address // The implementation after the upgrade.
+	// Whether the proxy points to a different implementation address after the upgrade.
+	ImplementationChanged bool
+	// Whether the implementation code differs from the previous one, ignoring the compiler metadata.
+	// An upgrade to a new address with the same code does not change the behavior of the proxy.
+	CodeChanged bool
+	// The comparison of the implementation code with the expected bytecode.
+	Artifact Diff
+}
+
+// Verified reports whether the upgrade landed as intended: the proxy points to the implementation
+// whose code matches the expected bytecode and differs from the previous implementation.
+func (r *UpgradeReport) Verified() bool {
+	return r.CodeChanged && r.Artifact.Equivalent
+}
+
+// Implementation returns the address of the EIP-1967 proxy implementation at the given block.
+// The block number can be nil, in which case the latest block is used.
+func Implementation(ctx context.Context, backend Backend, proxy common.Address, blockNumber *big.Int) (common.Address, error) {
+	value, err := backend.StorageAt(ctx, proxy, ImplementationSlot, blockNumber)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to read implementation slot: %w", err)
+	}
+	implementation := common.BytesToAddress(value)
+	if implementation == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("%s is not an EIP-1967 proxy", proxy)
+	}
+	return implementation, nil
+}
+
+// Admin returns the address of the admin of the EIP-1967 proxy at the given block, which is zero if the proxy
+// has no admin, e.g. the UUPS or beacon proxy. The block number can be nil, in which case the latest block is used.
+func Admin(ctx context.Context, backend Backend, proxy common.Address, blockNumber *big.Int) (common.Address, error) {
+	value, err := backend.StorageAt(ctx, proxy, AdminSlot, blockNumber)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to read admin slot: %w", err)
+	}
+	return common.BytesToAddress(value), nil
+}
+
+// Beacon returns the address of the beacon of the EIP-1967 proxy at the given block, which is zero if the proxy
+// is not the beacon proxy. The block number can be nil, in which case the latest block is used.
+func Beacon(ctx context.Context, backend Backend, proxy common.Address, blockNumber *big.Int) (common.Address, error) {
+	value, err := backend.StorageAt(ctx, proxy, BeaconSlot, blockNumber)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to read beacon slot: %w", err)
+	}
+	return common.BytesToAddress(value), nil
+}
+
+// VerifyUpgrade verifies the upgrade of the EIP-1967 proxy, by comparing its implementation before the upgrade
+// (at the given block) with the current one, and the current implementation code with the expected bytecode.
+func VerifyUpgrade(ctx context.Context, backend Backend, proxy common.Address, beforeBlock *big.Int, expected []byte) (*UpgradeReport, error) {
+	if beforeBlock == nil {
+		return nil, errors.New("block before the upgrade must be provided")
+	}
+	previous, err := Implementation(ctx, backend, proxy, beforeBlock)
+	if err != nil {
+		return nil, err
+	}
+	current, err := Implementation(ctx, backend, proxy, nil)
+	if err != nil {
+		return nil, err
+	}
+	previousCode, err := backend.CodeAt(ctx, previous, beforeBlock)
+	if err != nil {
+		return nil, err
+	}
+	currentCode, err := backend.CodeAt(ctx, current, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &UpgradeReport{
+		Proxy:                  proxy,
+		PreviousImplementation: previous,
+		Implementation:         current,
+		ImplementationChanged:  previous != current,
+		CodeChanged:            !Compare(previousCode, currentCode).Equivalent,
+		Artifact:               Compare(currentCode, expected),
+	}, nil
+}
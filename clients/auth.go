@@ -0,0 +1,196 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Auth represents an authentication scheme used to access RPC endpoints of managed node providers.
+type Auth interface {
+	// Apply adds credentials to the headers of the outgoing request.
+	Apply(h http.Header) error
+	// Refresh is called when the node responds with 401 Unauthorized status. It reports whether
+	// credentials have been renewed and the request should be retried.
+	Refresh(ctx context.Context) (bool, error)
+}
+
+// HeaderAuth authenticates requests by setting a static header, e.g. an API key.
+type HeaderAuth struct {
+	Name  string // Name of the header, e.g. "X-API-Key".
+	Value string // Value of the header.
+}
+
+// NewHeaderAuth creates an instance of HeaderAuth.
+func NewHeaderAuth(name, value string) *HeaderAuth {
+	return &HeaderAuth{Name: name, Value: value}
+}
+
+func (a *HeaderAuth) Apply(h http.Header) error {
+	h.Set(a.Name, a.Value)
+	return nil
+}
+
+func (a *HeaderAuth) Refresh(_ context.Context) (bool, error) {
+	return false, nil
+}
+
+// BasicAuth authenticates requests using HTTP basic authentication.
+type BasicAuth struct {
+	Username string // Username used for authentication.
+	Password string // Password used for authentication.
+}
+
+// NewBasicAuth creates an instance of BasicAuth.
+func NewBasicAuth(username, password string) *BasicAuth {
+	return &BasicAuth{Username: username, Password: password}
+}
+
+func (a *BasicAuth) Apply(h http.Header) error {
+	credentials := base64.StdEncoding.EncodeToString([]byte(a.Username + ":" + a.Password))
+	h.Set("Authorization", "Basic "+credentials)
+	return nil
+}
+
+func (a *BasicAuth) Refresh(_ context.Context) (bool, error) {
+	return false, nil
+}
+
+// TokenSource fetches a new bearer token (e.g. JWT) along with its expiration time.
+// Zero expiration time means that the token does not expire and is only renewed on 401 response.
+type TokenSource func(ctx context.Context) (token string, expiry time.Time, err error)
+
+// JWTAuth authenticates requests using a bearer token obtained from TokenSource.
+// The token is cached and renewed when it is about to expire or when the node responds
+// with 401 Unauthorized status.
+type JWTAuth struct {
+	source    TokenSource
+	leeway    time.Duration
+	timeout   time.Duration
+	mu        sync.Mutex
+	token     string
+	expiry    time.Time
+	refreshed time.Time
+}
+
+// NewJWTAuth creates an instance of JWTAuth which fetches tokens from the given source.
+// The token is renewed leeway before its expiration.
+func NewJWTAuth(source TokenSource, leeway time.Duration) *JWTAuth {
+	return &JWTAuth{
+		source:  source,
+		leeway:  leeway,
+		timeout: 30 * time.Second,
+	}
+}
+
+// NewStaticJWTAuth creates an instance of JWTAuth that always uses the given token.
+func NewStaticJWTAuth(token string) *JWTAuth {
+	return NewJWTAuth(func(context.Context) (string, time.Time, error) {
+		return token, time.Time{}, nil
+	}, 0)
+}
+
+func (a *JWTAuth) Apply(h http.Header) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.token == "" || (!a.expiry.IsZero() && time.Now().Add(a.leeway).After(a.expiry)) {
+		ctx, cancel := context.WithTimeout(context.Background(), a.timeout)
+		defer cancel()
+		if err := a.fetch(ctx); err != nil {
+			return err
+		}
+	}
+	h.Set("Authorization", "Bearer "+a.token)
+	return nil
+}
+
+func (a *JWTAuth) Refresh(ctx context.Context) (bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	// Avoid refreshing the token for each of the concurrent requests that failed with the same token.
+	if time.Since(a.refreshed) < time.Second {
+		return true, nil
+	}
+	if err := a.fetch(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (a *JWTAuth) fetch(ctx context.Context) error {
+	token, expiry, err := a.source(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch auth token: %w", err)
+	}
+	if token == "" {
+		return errors.New("auth token source returned empty token")
+	}
+	a.token, a.expiry, a.refreshed = token, expiry, time.Now()
+	return nil
+}
+
+// authTransport applies credentials to HTTP requests and retries a request once
+// if the node responds with 401 Unauthorized status and credentials have been refreshed.
+// If the refresh fails, its error is returned instead of the response.
+type authTransport struct {
+	base http.RoundTripper
+	auth Auth
+}
+
+func withAuthTransport(client *http.Client, auth Auth) *http.Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	c := *client
+	base := c.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	c.Transport = &authTransport{base: base, auth: auth}
+	return &c
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	resp, err := t.send(req, body)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	retry, err := t.auth.Refresh(req.Context())
+	if err == nil && !retry {
+		return resp, nil
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		// The stale credentials would be rejected again, so the failure of the refresh is reported instead.
+		return nil, fmt.Errorf("failed to refresh credentials: %w", err)
+	}
+	return t.send(req, body)
+}
+
+func (t *authTransport) send(req *http.Request, body []byte) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	if body != nil {
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+	}
+	if err := t.auth.Apply(r.Header); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(r)
+}
@@ -15,9 +15,11 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/zksync-sdk/zksync2-go/contracts/contractdeployer"
 	"github.com/zksync-sdk/zksync2-go/contracts/l2bridge"
+	"github.com/zksync-sdk/zksync2-go/contracts/nonceholder"
 	"github.com/zksync-sdk/zksync2-go/contracts/zksync"
 	zkTypes "github.com/zksync-sdk/zksync2-go/types"
 	"github.com/zksync-sdk/zksync2-go/utils"
+	"log/slog"
 	"math/big"
 	"time"
 )
@@ -26,8 +28,23 @@ type BaseClient struct {
 	rpcClient *rpc.Client
 	ethClient *ethclient.Client
 
-	mainContractAddress common.Address
-	mainContract        *zksync.IZkSync
+	defaultTimeout time.Duration
+	// privateRpcClient is used for submission of transactions in privacy mode.
+	privateRpcClient *rpc.Client
+
+	cache  chainCache
+	poller poller
+	// chaos injects failures for resilience testing, it is nil unless configured.
+	chaos *chaosInjector
+	// errorDecoder decodes revert data, utils.DefaultErrorDecoder is used if it is nil.
+	errorDecoder *utils.ErrorDecoder
+	// bridges contains the custom bridges consulted when mapping tokens between L1 and L2.
+	bridges *BridgeRegistry
+	// logger receives the structured logs of the client, nothing is logged if it is nil.
+	logger *slog.Logger
+	// resubscribeBackoff is the maximum backoff of reconnecting dropped subscriptions, which are not
+	// reconnected if it is zero.
+	resubscribeBackoff time.Duration
 }
 
 // Dial connects a client to the given URL.
@@ -46,49 +63,95 @@ func DialContext(ctx context.Context, rawUrl string) (Client, error) {
 
 // NewClient creates a client that uses the given RPC client.
 func NewClient(c *rpc.Client) Client {
-	return &BaseClient{
+	return NewClientWithOptions(c, nil)
+}
+
+// NewClientWithOptions creates a client that uses the given RPC client and options.
+// Only options which are not related to the connection, such as ClientOptions.DefaultTimeout, are applied.
+func NewClientWithOptions(c *rpc.Client, opts *ClientOptions) Client {
+	return newBaseClient(c, opts)
+}
+
+func newBaseClient(c *rpc.Client, opts *ClientOptions) *BaseClient {
+	client := &BaseClient{
 		rpcClient: c,
 		ethClient: ethclient.NewClient(c),
 	}
+	if opts != nil {
+		client.defaultTimeout = opts.DefaultTimeout
+		client.poller.interval = opts.PollInterval
+		client.poller.adaptive = opts.AdaptivePolling
+		client.errorDecoder = opts.ErrorDecoder
+		client.bridges = opts.Bridges
+		client.logger = opts.Logger
+		client.resubscribeBackoff = opts.ResubscribeBackoff
+	}
+	return client
 }
 
 func (c *BaseClient) Client() *rpc.Client {
 	return c.rpcClient
 }
 
+// Bridges returns the custom bridges of ClientOptions.Bridges, nil if none are set.
+func (c *BaseClient) Bridges() *BridgeRegistry {
+	return c.bridges
+}
+
 func (c *BaseClient) Close() {
 	c.ethClient.Close()
+	if c.privateRpcClient != nil {
+		c.privateRpcClient.Close()
+	}
 }
 
 func (c *BaseClient) ChainID(ctx context.Context) (*big.Int, error) {
-	return c.ethClient.ChainID(ctx)
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	return c.cache.bigInt(&c.cache.chainID, func() (*big.Int, error) {
+		return c.ethClient.ChainID(ctx)
+	})
 }
 
 func (c *BaseClient) BlockByHash(ctx context.Context, hash common.Hash) (*zkTypes.Block, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	return c.getBlock(ctx, "eth_getBlockByHash", hash, true)
 }
 
 func (c *BaseClient) BlockByNumber(ctx context.Context, number *big.Int) (*zkTypes.Block, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	return c.getBlock(ctx, "eth_getBlockByNumber", toBlockNumArg(number), true)
 }
 
 func (c *BaseClient) BlockNumber(ctx context.Context) (uint64, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	return c.ethClient.BlockNumber(ctx)
 }
 
 func (c *BaseClient) PeerCount(ctx context.Context) (uint64, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	return c.ethClient.PeerCount(ctx)
 }
 
 func (c *BaseClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	return c.ethClient.HeaderByHash(ctx, hash)
 }
 
 func (c *BaseClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	return c.ethClient.HeaderByNumber(ctx, number)
 }
 
 func (c *BaseClient) TransactionByHash(ctx context.Context, hash common.Hash) (tx *zkTypes.TransactionResponse, isPending bool, err error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	var resp *zkTypes.TransactionResponse
 	err = c.rpcClient.CallContext(ctx, &resp, "eth_getTransactionByHash", hash)
 	if err != nil {
@@ -102,6 +165,8 @@ func (c *BaseClient) TransactionByHash(ctx context.Context, hash common.Hash) (t
 }
 
 func (c *BaseClient) TransactionSender(ctx context.Context, tx *zkTypes.TransactionResponse, block common.Hash, index uint) (common.Address, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	var meta struct {
 		Hash *common.Hash
 		From common.Address
@@ -116,10 +181,14 @@ func (c *BaseClient) TransactionSender(ctx context.Context, tx *zkTypes.Transact
 }
 
 func (c *BaseClient) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	return c.ethClient.TransactionCount(ctx, blockHash)
 }
 
 func (c *BaseClient) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*zkTypes.TransactionResponse, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	var tx *zkTypes.TransactionResponse
 	err := c.rpcClient.CallContext(ctx, &tx, "eth_getTransactionByBlockHashAndIndex", blockHash, hexutil.Uint64(index))
 	if err != nil {
@@ -134,6 +203,8 @@ func (c *BaseClient) TransactionInBlock(ctx context.Context, blockHash common.Ha
 }
 
 func (c *BaseClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*zkTypes.Receipt, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	var resp *zkTypes.Receipt
 	err := c.rpcClient.CallContext(ctx, &resp, "eth_getTransactionReceipt", txHash)
 	if err != nil {
@@ -145,38 +216,87 @@ func (c *BaseClient) TransactionReceipt(ctx context.Context, txHash common.Hash)
 }
 
 func (c *BaseClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	return c.ethClient.SyncProgress(ctx)
 }
 
 func (c *BaseClient) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	if c.resubscribeBackoff > 0 {
+		return c.resubscribeNewHead(ctx, ch)
+	}
+	return c.subscribeNewHead(ctx, ch)
+}
+
+func (c *BaseClient) subscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	if c.chaos != nil && c.chaos.config.ReorgRate > 0 {
+		in := make(chan *types.Header)
+		sub, err := c.ethClient.SubscribeNewHead(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		return forward[*types.Header](sub, in, ch, c.chaos.reorgHeader), nil
+	}
 	return c.ethClient.SubscribeNewHead(ctx, ch)
 }
 
 func (c *BaseClient) NetworkID(ctx context.Context) (*big.Int, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	return c.ethClient.NetworkID(ctx)
 }
 
 func (c *BaseClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	blockNumber, err := c.readBlock(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
 	return c.ethClient.BalanceAt(ctx, account, blockNumber)
 }
 
 func (c *BaseClient) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	blockNumber, err := c.readBlock(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
 	return c.ethClient.StorageAt(ctx, account, key, blockNumber)
 }
 
 func (c *BaseClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	blockNumber, err := c.readBlock(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
 	return c.ethClient.CodeAt(ctx, account, blockNumber)
 }
 
 func (c *BaseClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	blockNumber, err := c.readBlock(ctx, blockNumber)
+	if err != nil {
+		return 0, err
+	}
 	return c.ethClient.NonceAt(ctx, account, blockNumber)
 }
 
 func (c *BaseClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	return c.ethClient.FilterLogs(ctx, query)
 }
 
 func (c *BaseClient) FilterLogsL2(ctx context.Context, q ethereum.FilterQuery) ([]zkTypes.Log, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	var result []zkTypes.Log
 	arg, err := toFilterArg(q)
 	if err != nil {
@@ -187,14 +307,48 @@ func (c *BaseClient) FilterLogsL2(ctx context.Context, q ethereum.FilterQuery) (
 }
 
 func (c *BaseClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	if c.resubscribeBackoff > 0 {
+		return c.resubscribeFilterLogs(ctx, query, ch)
+	}
+	return c.subscribeFilterLogs(ctx, query, ch)
+}
+
+func (c *BaseClient) subscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	if c.chaos != nil && c.chaos.config.ReorgRate > 0 {
+		in := make(chan types.Log)
+		sub, err := c.ethClient.SubscribeFilterLogs(ctx, query, in)
+		if err != nil {
+			return nil, err
+		}
+		return forward[types.Log](sub, in, ch, c.chaos.reorgLog), nil
+	}
 	return c.ethClient.SubscribeFilterLogs(ctx, query, ch)
 }
 
 func (c *BaseClient) SubscribeFilterLogsL2(ctx context.Context, query ethereum.FilterQuery, ch chan<- zkTypes.Log) (ethereum.Subscription, error) {
+	if c.resubscribeBackoff > 0 {
+		return c.resubscribeFilterLogsL2(ctx, query, ch)
+	}
+	return c.subscribeFilterLogsL2(ctx, query, ch)
+}
+
+func (c *BaseClient) subscribeFilterLogsL2(ctx context.Context, query ethereum.FilterQuery, ch chan<- zkTypes.Log) (ethereum.Subscription, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	arg, err := toFilterArg(query)
 	if err != nil {
 		return nil, err
 	}
+	if c.chaos != nil && c.chaos.config.ReorgRate > 0 {
+		in := make(chan zkTypes.Log)
+		sub, err := c.rpcClient.EthSubscribe(ctx, in, "logs", arg)
+		if err != nil {
+			return nil, err
+		}
+		return forward[zkTypes.Log](sub, in, ch, c.chaos.reorgLogL2), nil
+	}
 	sub, err := c.rpcClient.EthSubscribe(ctx, ch, "logs", arg)
 	if err != nil {
 		// Defensively prefer returning nil interface explicitly on error-path, instead
@@ -206,62 +360,97 @@ func (c *BaseClient) SubscribeFilterLogsL2(ctx context.Context, query ethereum.F
 }
 
 func (c *BaseClient) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	return c.ethClient.PendingBalanceAt(ctx, account)
 }
 func (c *BaseClient) PendingStorageAt(ctx context.Context, account common.Address, key common.Hash) ([]byte, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	return c.ethClient.PendingStorageAt(ctx, account, key)
 }
 func (c *BaseClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	return c.ethClient.PendingCodeAt(ctx, account)
 }
 func (c *BaseClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	return c.ethClient.PendingNonceAt(ctx, account)
 }
 
 func (c *BaseClient) PendingTransactionCount(ctx context.Context) (uint, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	return c.ethClient.PendingTransactionCount(ctx)
 }
 
 func (c *BaseClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
-	return c.ethClient.CallContract(ctx, msg, blockNumber)
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	blockNumber, err := c.readBlock(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.ethClient.CallContract(ctx, msg, blockNumber)
+	return res, c.decodeRevert(err)
 }
 
 func (c *BaseClient) CallContractL2(ctx context.Context, msg zkTypes.CallMsg, blockNumber *big.Int) ([]byte, error) {
-	var hex hexutil.Bytes
-	err := c.rpcClient.CallContext(ctx, &hex, "eth_call", msg, toBlockNumArg(blockNumber))
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	blockNumber, err := c.readBlock(ctx, blockNumber)
 	if err != nil {
 		return nil, err
 	}
+	var hex hexutil.Bytes
+	err = c.rpcClient.CallContext(ctx, &hex, "eth_call", msg, toBlockNumArg(blockNumber))
+	if err != nil {
+		return nil, c.decodeRevert(err)
+	}
 	return hex, nil
 }
 
 func (c *BaseClient) CallContractAtHash(ctx context.Context, msg ethereum.CallMsg, blockHash common.Hash) ([]byte, error) {
-	return c.ethClient.CallContractAtHash(ctx, msg, blockHash)
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	res, err := c.ethClient.CallContractAtHash(ctx, msg, blockHash)
+	return res, c.decodeRevert(err)
 }
 
 func (c *BaseClient) CallContractAtHashL2(ctx context.Context, msg zkTypes.CallMsg, blockHash common.Hash) ([]byte, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	var hex hexutil.Bytes
 	err := c.rpcClient.CallContext(ctx, &hex, "eth_call", msg, rpc.BlockNumberOrHashWithHash(blockHash, false))
 	if err != nil {
-		return nil, err
+		return nil, c.decodeRevert(err)
 	}
 	return hex, nil
 }
 
 func (c *BaseClient) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
-	return c.ethClient.PendingCallContract(ctx, msg)
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	res, err := c.ethClient.PendingCallContract(ctx, msg)
+	return res, c.decodeRevert(err)
 }
 
 func (c *BaseClient) PendingCallContractL2(ctx context.Context, msg zkTypes.CallMsg) ([]byte, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	var hex hexutil.Bytes
 	err := c.rpcClient.CallContext(ctx, &hex, "eth_call", msg, "pending")
 	if err != nil {
-		return nil, err
+		return nil, c.decodeRevert(err)
 	}
 	return hex, nil
 }
 
 func (c *BaseClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	return c.ethClient.SuggestGasPrice(ctx)
 }
 
@@ -270,37 +459,85 @@ func (c *BaseClient) SuggestGasTipCap(_ context.Context) (*big.Int, error) {
 }
 
 func (c *BaseClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
-	return c.ethClient.EstimateGas(ctx, call)
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	gas, err := c.ethClient.EstimateGas(ctx, call)
+	c.logEstimate(ctx, "eth_estimateGas", gas, err)
+	return gas, c.decodeRevert(err)
 }
 
 func (c *BaseClient) EstimateGasL2(ctx context.Context, msg zkTypes.CallMsg) (uint64, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	var hex hexutil.Uint64
 	err := c.rpcClient.CallContext(ctx, &hex, "eth_estimateGas", msg)
+	c.logEstimate(ctx, "eth_estimateGas", uint64(hex), err)
 	if err != nil {
-		return 0, fmt.Errorf("failed to query eth_estimateGas: %w", err)
+		return 0, fmt.Errorf("failed to query eth_estimateGas: %w", c.decodeRevert(err))
 	}
 	return uint64(hex), nil
 }
 
+// decodeRevert returns utils.RevertError if the error carries the revert data decoded by the error decoder.
+func (c *BaseClient) decodeRevert(err error) error {
+	return utils.DecodeRevertError(c.errorDecoder, err)
+}
+
 func (c *BaseClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
-	return c.ethClient.SendTransaction(ctx, tx)
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	if privacyModeFrom(ctx) != PrivacyModeOff {
+		rawTx, err := tx.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		_, err = c.sendPrivateRawTransaction(ctx, rawTx)
+		c.logSent(ctx, tx.Hash(), err)
+		return err
+	}
+	err := c.ethClient.SendTransaction(ctx, tx)
+	c.logSent(ctx, tx.Hash(), err)
+	return err
 }
 
 func (c *BaseClient) SendRawTransaction(ctx context.Context, tx []byte) (common.Hash, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	if privacyModeFrom(ctx) != PrivacyModeOff {
+		hash, err := c.sendPrivateRawTransaction(ctx, tx)
+		c.logSent(ctx, hash, err)
+		return hash, err
+	}
 	var res string
 	err := c.rpcClient.CallContext(ctx, &res, "eth_sendRawTransaction", hexutil.Encode(tx))
+	c.logSent(ctx, common.HexToHash(res), err)
 	if err != nil {
 		return common.Hash{}, fmt.Errorf("failed to call eth_sendRawTransaction: %w", err)
 	}
 	return common.HexToHash(res), nil
 }
 
+func (c *BaseClient) SendRawTransactionWithDetailedOutput(ctx context.Context, tx []byte) (*zkTypes.TransactionDetailedResult, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	var res zkTypes.TransactionDetailedResult
+	err := c.rpcClient.CallContext(ctx, &res, "zks_sendRawTransactionWithDetailedOutput", hexutil.Encode(tx))
+	c.logSent(ctx, res.TransactionHash, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call zks_sendRawTransactionWithDetailedOutput: %w", err)
+	}
+	return &res, nil
+}
+
 func (c *BaseClient) WaitMined(ctx context.Context, txHash common.Hash) (*zkTypes.Receipt, error) {
-	queryTicker := time.NewTicker(time.Second)
+	c.log(ctx, slog.LevelDebug, "Waiting for transaction to be mined", "hash", txHash)
+	queryTicker := time.NewTicker(c.pollInterval(ctx))
 	defer queryTicker.Stop()
 	for {
 		receipt, err := c.TransactionReceipt(ctx, txHash)
 		if err == nil && receipt.BlockNumber != nil {
+			c.log(ctx, slog.LevelInfo, "Transaction mined", "hash", txHash, "block", receipt.BlockNumber,
+				"status", receipt.Status, "gasUsed", receipt.GasUsed)
 			return receipt, nil
 		}
 		// Wait for the next round.
@@ -320,7 +557,8 @@ func (c *BaseClient) WaitFinalized(ctx context.Context, txHash common.Hash) (*zk
 	if receipt.BlockNumber == nil {
 		return nil, errors.New("empty tx block number")
 	}
-	queryTicker := time.NewTicker(time.Second)
+	c.log(ctx, slog.LevelDebug, "Waiting for transaction to be finalized", "hash", txHash, "block", receipt.BlockNumber)
+	queryTicker := time.NewTicker(c.pollInterval(ctx))
 	defer queryTicker.Stop()
 	var blockHead *types.Header
 	for {
@@ -332,6 +570,7 @@ func (c *BaseClient) WaitFinalized(ctx context.Context, txHash common.Hash) (*zk
 			return nil, fmt.Errorf("failed to get finalized block: %w", err)
 		}
 		if blockHead.Number.Cmp(receipt.BlockNumber) >= 0 {
+			c.log(ctx, slog.LevelInfo, "Transaction finalized", "hash", txHash, "block", receipt.BlockNumber)
 			return receipt, nil
 		}
 		// Wait for the next round.
@@ -344,6 +583,14 @@ func (c *BaseClient) WaitFinalized(ctx context.Context, txHash common.Hash) (*zk
 }
 
 func (c *BaseClient) MainContractAddress(ctx context.Context) (common.Address, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	return c.cache.address(&c.cache.mainContractAddress, func() (common.Address, error) {
+		return c.mainContractAddress(ctx)
+	})
+}
+
+func (c *BaseClient) mainContractAddress(ctx context.Context) (common.Address, error) {
 	var res string
 	err := c.rpcClient.CallContext(ctx, &res, "zks_getMainContract")
 	if err != nil {
@@ -353,6 +600,14 @@ func (c *BaseClient) MainContractAddress(ctx context.Context) (common.Address, e
 }
 
 func (c *BaseClient) TestnetPaymaster(ctx context.Context) (common.Address, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	return c.cache.address(&c.cache.testnetPaymaster, func() (common.Address, error) {
+		return c.testnetPaymaster(ctx)
+	})
+}
+
+func (c *BaseClient) testnetPaymaster(ctx context.Context) (common.Address, error) {
 	var res string
 	err := c.rpcClient.CallContext(ctx, &res, "zks_getTestnetPaymaster")
 	if err != nil {
@@ -361,7 +616,72 @@ func (c *BaseClient) TestnetPaymaster(ctx context.Context) (common.Address, erro
 	return common.HexToAddress(res), nil
 }
 
+func (c *BaseClient) BaseTokenContractAddress(ctx context.Context) (common.Address, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	return c.cache.address(&c.cache.baseToken, func() (common.Address, error) {
+		return c.baseTokenContractAddress(ctx)
+	})
+}
+
+func (c *BaseClient) baseTokenContractAddress(ctx context.Context) (common.Address, error) {
+	var res string
+	err := c.rpcClient.CallContext(ctx, &res, "zks_getBaseTokenL1Address")
+	if err != nil {
+		// Nodes which do not support custom base tokens are always ETH-based.
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == methodNotFoundErrorCode {
+			return utils.EthAddressInContracts, nil
+		}
+		return common.Address{}, fmt.Errorf("failed to query zks_getBaseTokenL1Address: %w", err)
+	}
+	return common.HexToAddress(res), nil
+}
+
+func (c *BaseClient) BridgehubContractAddress(ctx context.Context) (common.Address, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	return c.cache.address(&c.cache.bridgehub, func() (common.Address, error) {
+		var res common.Address
+		err := c.rpcClient.CallContext(ctx, &res, "zks_getBridgehubContract")
+		if err != nil {
+			return common.Address{}, fmt.Errorf("failed to query zks_getBridgehubContract: %w", err)
+		}
+		return res, nil
+	})
+}
+
+func (c *BaseClient) IsEthBasedChain(ctx context.Context) (bool, error) {
+	baseToken, err := c.BaseTokenContractAddress(ctx)
+	if err != nil {
+		return false, err
+	}
+	return baseToken == utils.EthAddressInContracts, nil
+}
+
+func (c *BaseClient) IsBaseToken(ctx context.Context, token common.Address) (bool, error) {
+	if token == utils.L2BaseTokenAddress {
+		return true, nil
+	}
+	baseToken, err := c.BaseTokenContractAddress(ctx)
+	if err != nil {
+		return false, err
+	}
+	if baseToken == utils.EthAddressInContracts {
+		return token == utils.EthAddress || token == utils.EthAddressInContracts, nil
+	}
+	return token == baseToken, nil
+}
+
 func (c *BaseClient) BridgeContracts(ctx context.Context) (*zkTypes.BridgeContracts, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	return c.cache.bridges(func() (*zkTypes.BridgeContracts, error) {
+		return c.bridgeContracts(ctx)
+	})
+}
+
+func (c *BaseClient) bridgeContracts(ctx context.Context) (*zkTypes.BridgeContracts, error) {
 	res := zkTypes.BridgeContracts{}
 	err := c.rpcClient.CallContext(ctx, &res, "zks_getBridgeContracts")
 	if err != nil {
@@ -371,6 +691,8 @@ func (c *BaseClient) BridgeContracts(ctx context.Context) (*zkTypes.BridgeContra
 }
 
 func (c *BaseClient) ContractAccountInfo(ctx context.Context, address common.Address) (*zkTypes.ContractAccountInfo, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	contractDeployer, err := contractdeployer.NewContractDeployerCaller(utils.ContractDeployerAddress, c)
 	if err != nil {
 		return nil, err
@@ -385,7 +707,29 @@ func (c *BaseClient) ContractAccountInfo(ctx context.Context, address common.Add
 	}, nil
 }
 
+func (c *BaseClient) DeploymentNonceAt(ctx context.Context, address common.Address, blockNumber *big.Int) (*big.Int, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	nonceHolder, err := nonceholder.NewNonceHolderCaller(utils.NonceHolderAddress, c)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := nonceHolder.GetDeploymentNonce(&bind.CallOpts{Context: ctx, BlockNumber: blockNumber}, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment nonce: %w", err)
+	}
+	return nonce, nil
+}
+
 func (c *BaseClient) L1ChainID(ctx context.Context) (*big.Int, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	return c.cache.bigInt(&c.cache.l1ChainID, func() (*big.Int, error) {
+		return c.l1ChainID(ctx)
+	})
+}
+
+func (c *BaseClient) l1ChainID(ctx context.Context) (*big.Int, error) {
 	var res string
 	err := c.rpcClient.CallContext(ctx, &res, "zks_L1ChainId")
 	if err != nil {
@@ -399,6 +743,8 @@ func (c *BaseClient) L1ChainID(ctx context.Context) (*big.Int, error) {
 }
 
 func (c *BaseClient) L1BatchNumber(ctx context.Context) (*big.Int, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	var res string
 	err := c.rpcClient.CallContext(ctx, &res, "zks_L1BatchNumber")
 	if err != nil {
@@ -412,6 +758,8 @@ func (c *BaseClient) L1BatchNumber(ctx context.Context) (*big.Int, error) {
 }
 
 func (c *BaseClient) L1BatchBlockRange(ctx context.Context, l1BatchNumber *big.Int) (*BlockRange, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	var resp *BlockRange
 	err := c.rpcClient.CallContext(ctx, &resp, "zks_getL1BatchBlockRange", l1BatchNumber)
 	if err != nil {
@@ -423,6 +771,8 @@ func (c *BaseClient) L1BatchBlockRange(ctx context.Context, l1BatchNumber *big.I
 }
 
 func (c *BaseClient) L1BatchDetails(ctx context.Context, l1BatchNumber *big.Int) (*zkTypes.BatchDetails, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	var resp *zkTypes.BatchDetails
 	err := c.rpcClient.CallContext(ctx, &resp, "zks_getL1BatchDetails", l1BatchNumber)
 	if err != nil {
@@ -434,6 +784,8 @@ func (c *BaseClient) L1BatchDetails(ctx context.Context, l1BatchNumber *big.Int)
 }
 
 func (c *BaseClient) BlockDetails(ctx context.Context, block uint32) (*zkTypes.BlockDetails, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	var resp *zkTypes.BlockDetails
 	err := c.rpcClient.CallContext(ctx, &resp, "zks_getBlockDetails", block)
 	if err != nil {
@@ -444,7 +796,49 @@ func (c *BaseClient) BlockDetails(ctx context.Context, block uint32) (*zkTypes.B
 	return resp, nil
 }
 
+func (c *BaseClient) RawBlockTransactions(ctx context.Context, block uint32) ([]zkTypes.RawBlockTransaction, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	var resp []zkTypes.RawBlockTransaction
+	err := c.rpcClient.CallContext(ctx, &resp, "zks_getRawBlockTransactions", block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query zks_getRawBlockTransactions: %w", err)
+	}
+	return resp, nil
+}
+
+func (c *BaseClient) Proof(ctx context.Context, address common.Address, keys []common.Hash, l1BatchNumber *big.Int) (*zkTypes.StorageProof, error) {
+	if l1BatchNumber == nil {
+		return nil, errors.New("l1BatchNumber must be provided")
+	}
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	var resp *zkTypes.StorageProof
+	err := c.rpcClient.CallContext(ctx, &resp, "zks_getProof", address, keys, l1BatchNumber.Uint64())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query zks_getProof: %w", err)
+	} else if resp == nil {
+		return nil, ethereum.NotFound
+	}
+	return resp, nil
+}
+
+func (c *BaseClient) ProtocolVersion(ctx context.Context, version *uint16) (*zkTypes.ProtocolVersion, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	var resp *zkTypes.ProtocolVersion
+	err := c.rpcClient.CallContext(ctx, &resp, "zks_getProtocolVersion", version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query zks_getProtocolVersion: %w", err)
+	} else if resp == nil {
+		return nil, ethereum.NotFound
+	}
+	return resp, nil
+}
+
 func (c *BaseClient) TransactionDetails(ctx context.Context, txHash common.Hash) (*zkTypes.TransactionDetails, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	var resp *zkTypes.TransactionDetails
 	err := c.rpcClient.CallContext(ctx, &resp, "zks_getTransactionDetails", txHash)
 	if err != nil {
@@ -456,6 +850,8 @@ func (c *BaseClient) TransactionDetails(ctx context.Context, txHash common.Hash)
 }
 
 func (c *BaseClient) LogProof(ctx context.Context, txHash common.Hash, logIndex int) (*zkTypes.MessageProof, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	var resp *zkTypes.MessageProof
 	err := c.rpcClient.CallContext(ctx, &resp, "zks_getL2ToL1LogProof", txHash, logIndex)
 	if err != nil {
@@ -468,6 +864,8 @@ func (c *BaseClient) LogProof(ctx context.Context, txHash common.Hash, logIndex
 
 // Deprecated: Endpoint will be deprecated in favor of LogProof
 func (c *BaseClient) MsgProof(ctx context.Context, block uint32, sender common.Address, msg common.Hash) (*zkTypes.MessageProof, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	var resp *zkTypes.MessageProof
 	err := c.rpcClient.CallContext(ctx, &resp, "zks_getL2ToL1MsgProof", block, sender, msg)
 	if err != nil {
@@ -479,15 +877,19 @@ func (c *BaseClient) MsgProof(ctx context.Context, block uint32, sender common.A
 }
 
 func (c *BaseClient) L2TransactionFromPriorityOp(ctx context.Context, l1TxReceipt *types.Receipt) (*zkTypes.TransactionResponse, error) {
-	if c.mainContract == nil {
-		if err := c.cacheMainContract(ctx); err != nil {
-			return nil, err
-		}
+	mainContractAddress, err := c.MainContractAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// parsing events does not require backend to be set
+	mainContract, err := zksync.NewIZkSync(mainContractAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load IZkSync: %w", err)
 	}
 
 	for _, l := range l1TxReceipt.Logs {
-		if l.Address == c.mainContractAddress {
-			req, err := c.mainContract.ParseNewPriorityRequest(*l)
+		if l.Address == mainContractAddress {
+			req, err := mainContract.ParseNewPriorityRequest(*l)
 			if err != nil {
 				return nil, fmt.Errorf("failed to ParseNewPriorityRequest: %w", err)
 			}
@@ -503,6 +905,8 @@ func (c *BaseClient) L2TransactionFromPriorityOp(ctx context.Context, l1TxReceip
 }
 
 func (c *BaseClient) ConfirmedTokens(ctx context.Context, from uint32, limit uint8) ([]*zkTypes.Token, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	res := make([]*zkTypes.Token, 0)
 	err := c.rpcClient.CallContext(ctx, &res, "zks_getConfirmedTokens", from, limit)
 	if err != nil {
@@ -512,6 +916,8 @@ func (c *BaseClient) ConfirmedTokens(ctx context.Context, from uint32, limit uin
 }
 
 func (c *BaseClient) TokenPrice(ctx context.Context, address common.Address) (*big.Float, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	var res string
 	err := c.rpcClient.CallContext(ctx, &res, "zks_getTokenPrice", address)
 	if err != nil {
@@ -525,9 +931,14 @@ func (c *BaseClient) TokenPrice(ctx context.Context, address common.Address) (*b
 }
 
 func (c *BaseClient) L2TokenAddress(ctx context.Context, token common.Address) (common.Address, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	if token == utils.EthAddress {
 		return utils.EthAddress, nil
 	} else {
+		if address, ok, err := c.customL2TokenAddress(ctx, token); err != nil || ok {
+			return address, err
+		}
 		bridgeContracts, err := c.BridgeContracts(ctx)
 		if err != nil {
 			return common.Address{}, err
@@ -545,9 +956,14 @@ func (c *BaseClient) L2TokenAddress(ctx context.Context, token common.Address) (
 }
 
 func (c *BaseClient) L1TokenAddress(ctx context.Context, token common.Address) (common.Address, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	if token == utils.EthAddress {
 		return utils.EthAddress, nil
 	} else {
+		if address, ok, err := c.customL1TokenAddress(ctx, token); err != nil || ok {
+			return address, err
+		}
 		bridgeContracts, err := c.BridgeContracts(ctx)
 		if err != nil {
 			return common.Address{}, err
@@ -565,6 +981,12 @@ func (c *BaseClient) L1TokenAddress(ctx context.Context, token common.Address) (
 }
 
 func (c *BaseClient) AllAccountBalances(ctx context.Context, address common.Address) (map[common.Address]*big.Int, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	// Balances are always returned at the latest block, so only the node progress can be ensured.
+	if _, err := c.readBlock(ctx, nil); err != nil {
+		return nil, err
+	}
 	res := make(map[common.Address]string)
 	err := c.rpcClient.CallContext(ctx, &res, "zks_getAllAccountBalances", address)
 	if err != nil {
@@ -581,15 +1003,66 @@ func (c *BaseClient) AllAccountBalances(ctx context.Context, address common.Addr
 }
 
 func (c *BaseClient) EstimateFee(ctx context.Context, msg zkTypes.CallMsg) (*zkTypes.Fee, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	var res zkTypes.Fee
 	err := c.rpcClient.CallContext(ctx, &res, "zks_estimateFee", msg)
 	if err != nil {
+		c.log(ctx, slog.LevelDebug, "Fee estimation failed", "err", err)
 		return nil, fmt.Errorf("failed to query zks_estimateFee: %w", err)
 	}
+	c.log(ctx, slog.LevelDebug, "Fee estimated", "gasLimit", res.GasLimit, "maxFeePerGas", res.MaxFeePerGas,
+		"maxPriorityFeePerGas", res.MaxPriorityFeePerGas, "gasPerPubdataLimit", res.GasPerPubdataLimit)
+	return &res, nil
+}
+
+func (c *BaseClient) FeeParams(ctx context.Context) (*zkTypes.FeeParams, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	var res zkTypes.FeeParams
+	err := c.rpcClient.CallContext(ctx, &res, "zks_getFeeParams")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query zks_getFeeParams: %w", err)
+	}
 	return &res, nil
 }
 
+func (c *BaseClient) L1GasPrice(ctx context.Context) (*big.Int, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	var res hexutil.Big
+	err := c.rpcClient.CallContext(ctx, &res, "zks_getL1GasPrice")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query zks_getL1GasPrice: %w", err)
+	}
+	return (*big.Int)(&res), nil
+}
+
+func (c *BaseClient) BatchFeeInput(ctx context.Context) (*zkTypes.BatchFeeInput, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	var res zkTypes.BatchFeeInput
+	err := c.rpcClient.CallContext(ctx, &res, "zks_getBatchFeeInput")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query zks_getBatchFeeInput: %w", err)
+	}
+	return &res, nil
+}
+
+func (c *BaseClient) GasPerPubdata(ctx context.Context) (*big.Int, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	var res hexutil.Big
+	err := c.rpcClient.CallContext(ctx, &res, "zks_gasPerPubdata")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query zks_gasPerPubdata: %w", err)
+	}
+	return (*big.Int)(&res), nil
+}
+
 func (c *BaseClient) EstimateGasL1(ctx context.Context, msg zkTypes.CallMsg) (uint64, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	var res hexutil.Uint64
 	err := c.rpcClient.CallContext(ctx, &res, "zks_estimateGasL1ToL2", msg)
 	if err != nil {
@@ -599,6 +1072,8 @@ func (c *BaseClient) EstimateGasL1(ctx context.Context, msg zkTypes.CallMsg) (ui
 }
 
 func (c *BaseClient) EstimateGasTransfer(ctx context.Context, msg TransferCallMsg) (uint64, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	callMsg, err := msg.ToCallMsg()
 	if err != nil {
 		return 0, err
@@ -607,6 +1082,8 @@ func (c *BaseClient) EstimateGasTransfer(ctx context.Context, msg TransferCallMs
 }
 
 func (c *BaseClient) EstimateGasWithdraw(ctx context.Context, msg WithdrawalCallMsg) (uint64, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	var (
 		callMsg *ethereum.CallMsg
 		err     error
@@ -628,6 +1105,8 @@ func (c *BaseClient) EstimateGasWithdraw(ctx context.Context, msg WithdrawalCall
 }
 
 func (c *BaseClient) EstimateL1ToL2Execute(ctx context.Context, msg zkTypes.CallMsg) (uint64, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	if msg.Meta == nil || msg.Meta.GasPerPubdata == nil {
 		msg.Meta = &zkTypes.Eip712Meta{GasPerPubdata: utils.NewBig(utils.RequiredL1ToL2GasPerPubdataLimit.Int64())}
 	}
@@ -651,19 +1130,11 @@ func (c *BaseClient) EstimateL1ToL2Execute(ctx context.Context, msg zkTypes.Call
 	return c.EstimateGasL1(ctx, msg)
 }
 
-func (c *BaseClient) cacheMainContract(ctx context.Context) error {
-	mainContractAddress, err := c.MainContractAddress(ctx)
-	if err != nil {
-		return err
-	}
-	// parsing events does not require backend to be set
-	mainContract, err := zksync.NewIZkSync(mainContractAddress, nil)
-	if err != nil {
-		return fmt.Errorf("failed to load IZkSync: %w", err)
-	}
-	c.mainContract = mainContract
-	c.mainContractAddress = mainContractAddress
-	return nil
+// InvalidateCache clears the cached chain facts (chain IDs, main contract, bridge contracts and
+// testnet paymaster addresses), forcing them to be fetched from the node on next use.
+// It should be called when the client is reused after the node has been reconfigured or replaced.
+func (c *BaseClient) InvalidateCache() {
+	c.cache.invalidate()
 }
 
 func (c *BaseClient) getBlock(ctx context.Context, method string, args ...interface{}) (*zkTypes.Block, error) {
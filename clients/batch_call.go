@@ -0,0 +1,54 @@
+package clients
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"math/big"
+)
+
+// CallResult is the result of a single call executed by BatchCallContractL2.
+type CallResult struct {
+	Data []byte // Data returned by the call.
+	Err  error  // Error of the call, e.g. a revert. Errors of individual calls do not fail the whole batch.
+}
+
+func (c *BaseClient) BatchCallContractL2(ctx context.Context, msgs []zkTypes.CallMsg, blockNumber *big.Int) ([]CallResult, *big.Int, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	blockNumber, err := c.readBlock(ctx, blockNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+	if blockNumber == nil {
+		// The latest block is resolved once, so that all calls are executed at the same block,
+		// even if new blocks are produced while the batch is being processed.
+		head, err := c.BlockNumber(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		blockNumber = new(big.Int).SetUint64(head)
+	}
+	if len(msgs) == 0 {
+		return []CallResult{}, blockNumber, nil
+	}
+
+	data := make([]hexutil.Bytes, len(msgs))
+	reqs := make([]rpc.BatchElem, len(msgs))
+	for i := range msgs {
+		reqs[i] = rpc.BatchElem{
+			Method: "eth_call",
+			Args:   []interface{}{msgs[i], toBlockNumArg(blockNumber)},
+			Result: &data[i],
+		}
+	}
+	if err = c.rpcClient.BatchCallContext(ctx, reqs); err != nil {
+		return nil, nil, err
+	}
+	results := make([]CallResult, len(msgs))
+	for i := range reqs {
+		results[i] = CallResult{Data: data[i], Err: reqs[i].Error}
+	}
+	return results, blockNumber, nil
+}
@@ -0,0 +1,241 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"math/big"
+	"time"
+)
+
+// BatchBlockIterator iterates over the blocks of the L1 batch, which are fetched one by one:
+//
+//	it, err := clients.BatchBlocks(ctx, client, batchNumber)
+//	for it.Next(ctx) {
+//		block := it.Block()
+//	}
+//	err = it.Err()
+type BatchBlockIterator struct {
+	client Client
+	next   uint64 // The number of the next block.
+	end    uint64 // The number of the last block of the batch.
+	block  *zkTypes.Block
+	err    error
+}
+
+// BatchBlocks returns the iterator over the blocks of the L1 batch, determined by Client.L1BatchBlockRange.
+func BatchBlocks(ctx context.Context, client Client, batchNumber *big.Int) (*BatchBlockIterator, error) {
+	blockRange, err := client.L1BatchBlockRange(ctx, batchNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchBlockIterator{
+		client: client,
+		next:   blockRange.Beginning.Uint64(),
+		end:    blockRange.End.Uint64(),
+	}, nil
+}
+
+// Next fetches the next block of the batch. It returns false when there are no more blocks or fetching
+// of the block has failed, which is reported by Err.
+func (it *BatchBlockIterator) Next(ctx context.Context) bool {
+	if it.err != nil || it.next > it.end {
+		it.block = nil
+		return false
+	}
+	block, err := it.client.BlockByNumber(ctx, new(big.Int).SetUint64(it.next))
+	if err != nil {
+		it.block, it.err = nil, fmt.Errorf("failed to get block %d: %w", it.next, err)
+		return false
+	}
+	it.block = block
+	it.next++
+	return true
+}
+
+// Block returns the block fetched by the last call to Next.
+func (it *BatchBlockIterator) Block() *zkTypes.Block {
+	return it.block
+}
+
+// Err returns the error which has stopped the iteration, if any.
+func (it *BatchBlockIterator) Err() error {
+	return it.err
+}
+
+// BatchTransactionIterator iterates over the transactions of the L1 batch, in the order of their execution.
+type BatchTransactionIterator struct {
+	blocks  *BatchBlockIterator
+	pending []*zkTypes.TransactionResponse // The remaining transactions of the current block.
+	tx      *zkTypes.TransactionResponse
+}
+
+// BatchTransactions returns the iterator over the transactions of the L1 batch, which are fetched along
+// with the blocks of the batch.
+func BatchTransactions(ctx context.Context, client Client, batchNumber *big.Int) (*BatchTransactionIterator, error) {
+	blocks, err := BatchBlocks(ctx, client, batchNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchTransactionIterator{blocks: blocks}, nil
+}
+
+// Next advances to the next transaction of the batch, fetching the following block if needed. It returns
+// false when there are no more transactions or fetching of the block has failed, which is reported by Err.
+func (it *BatchTransactionIterator) Next(ctx context.Context) bool {
+	for len(it.pending) == 0 {
+		if !it.blocks.Next(ctx) {
+			it.tx = nil
+			return false
+		}
+		it.pending = it.blocks.Block().Transactions
+	}
+	it.tx, it.pending = it.pending[0], it.pending[1:]
+	return true
+}
+
+// Transaction returns the transaction of the last call to Next.
+func (it *BatchTransactionIterator) Transaction() *zkTypes.TransactionResponse {
+	return it.tx
+}
+
+// Err returns the error which has stopped the iteration, if any.
+func (it *BatchTransactionIterator) Err() error {
+	return it.blocks.Err()
+}
+
+// BatchStage is the stage of the L1 batch settlement.
+type BatchStage int
+
+const (
+	BatchSealed    BatchStage = iota // The batch has been sealed on L2, but not committed to L1 yet.
+	BatchCommitted                   // The batch has been committed to L1.
+	BatchProven                      // The proof of the batch has been verified on L1.
+	BatchExecuted                    // The batch has been executed on L1, so it is final.
+)
+
+func (s BatchStage) String() string {
+	switch s {
+	case BatchSealed:
+		return "sealed"
+	case BatchCommitted:
+		return "committed"
+	case BatchProven:
+		return "proven"
+	case BatchExecuted:
+		return "executed"
+	default:
+		return fmt.Sprintf("BatchStage(%d)", int(s))
+	}
+}
+
+// BatchStageOf returns the stage of the batch, determined by the L1 transactions which have processed it.
+func BatchStageOf(details *zkTypes.BatchDetails) BatchStage {
+	switch {
+	case details.ExecuteTxHash != (common.Hash{}):
+		return BatchExecuted
+	case details.ProveTxHash != (common.Hash{}):
+		return BatchProven
+	case details.CommitTxHash != (common.Hash{}):
+		return BatchCommitted
+	default:
+		return BatchSealed
+	}
+}
+
+// BatchUpdate is the stage of the batch reported by BatchWatcher.
+type BatchUpdate struct {
+	Stage   BatchStage
+	Details *zkTypes.BatchDetails
+}
+
+// BatchWatcher reports the L1 batches as they are sealed, committed, proven and executed, e.g. to reconcile
+// the settlement of the transactions.
+type BatchWatcher struct {
+	client   Client
+	interval time.Duration
+}
+
+// NewBatchWatcher creates an instance of BatchWatcher.
+func NewBatchWatcher(client Client) *BatchWatcher {
+	return &BatchWatcher{
+		client:   client,
+		interval: 10 * time.Second,
+	}
+}
+
+// SetPollInterval sets the interval in which Watch polls the batches. Defaults to 10 seconds.
+func (w *BatchWatcher) SetPollInterval(interval time.Duration) {
+	w.interval = interval
+}
+
+// Watch polls the batches from the given batch number on, or from the latest batch if the number is nil,
+// and sends the update to the returned channel whenever a batch is sealed or moves to a later stage.
+// Since the batches are polled, the update may skip stages. The batches are reported in order within each
+// poll, and are no longer polled once executed. The channel is closed once the context is canceled.
+// Errors of polling are not fatal, the batches are polled again after the interval.
+func (w *BatchWatcher) Watch(ctx context.Context, from *big.Int) <-chan BatchUpdate {
+	updates := make(chan BatchUpdate, 1)
+	go func() {
+		defer close(updates)
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		stages := make(map[uint64]BatchStage) // The reported stages of the batches which are not executed yet.
+		var pending uint64                    // The first batch which is not executed yet.
+		started := from != nil
+		if started {
+			pending = from.Uint64()
+		}
+		for {
+			latest, err := w.client.L1BatchNumber(ctx)
+			if err == nil && !started {
+				pending, started = latest.Uint64(), true
+			}
+			if err == nil && !w.poll(ctx, pending, latest.Uint64(), stages, updates) {
+				return
+			}
+			for stages[pending] == BatchExecuted {
+				delete(stages, pending)
+				pending++
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return updates
+}
+
+// poll reports the batches in the range [from, to] whose stages have changed. It returns false if the context
+// is canceled.
+func (w *BatchWatcher) poll(ctx context.Context, from, to uint64, stages map[uint64]BatchStage, updates chan<- BatchUpdate) bool {
+	// The batches are committed in order, so the batches following the sealed one cannot move forward.
+	blocked := false
+	for number := from; number <= to; number++ {
+		last, seen := stages[number]
+		if blocked && seen {
+			continue
+		}
+		details, err := w.client.L1BatchDetails(ctx, new(big.Int).SetUint64(number))
+		if err != nil {
+			return ctx.Err() == nil
+		}
+		stage := BatchStageOf(details)
+		if stage == BatchSealed {
+			blocked = true
+		}
+		if seen && stage == last {
+			continue
+		}
+		stages[number] = stage
+		select {
+		case updates <- BatchUpdate{Stage: stage, Details: details}:
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,144 @@
+package clients
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/zksync-sdk/zksync2-go/contracts/l2bridge"
+	"sync"
+)
+
+// TokenPair is the pair of addresses of the same token on L1 and L2.
+type TokenPair struct {
+	L1Token common.Address // Address of the token on L1.
+	L2Token common.Address // Address of the token on L2.
+}
+
+// CustomBridge is the bridge deployed besides the default bridges, which bridges specific tokens,
+// such as the wstETH bridge of Lido.
+type CustomBridge struct {
+	Name     string         // Name of the bridge, used for display purposes only.
+	L1Bridge common.Address // Address of the bridge on L1.
+	L2Bridge common.Address // Address of the bridge on L2.
+	// Tokens are the tokens bridged by the bridge. If empty, the L2 bridge is queried for the token addresses
+	// using the interface of the default L2 bridge, which must then be implemented by the bridge.
+	Tokens []TokenPair
+}
+
+// BridgeRegistry contains the custom bridges consulted by Client.L2TokenAddress and Client.L1TokenAddress
+// before the default bridge, so that the tokens bridged by non-standard bridges are mapped to their actual
+// addresses instead of the addresses computed by the default bridge. It is safe for concurrent use.
+type BridgeRegistry struct {
+	mu      sync.RWMutex
+	bridges []CustomBridge
+}
+
+// NewBridgeRegistry creates an instance of BridgeRegistry containing the given bridges.
+func NewBridgeRegistry(bridges ...CustomBridge) *BridgeRegistry {
+	return &BridgeRegistry{bridges: append([]CustomBridge(nil), bridges...)}
+}
+
+// Register adds the bridge to the registry. Bridges are consulted in the order of registration.
+func (r *BridgeRegistry) Register(bridge CustomBridge) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bridges = append(r.bridges, bridge)
+}
+
+// Bridges returns the registered bridges.
+func (r *BridgeRegistry) Bridges() []CustomBridge {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]CustomBridge(nil), r.bridges...)
+}
+
+// BridgeOfL1Token returns the custom bridge with the static token pair of the L1 token.
+func (r *BridgeRegistry) BridgeOfL1Token(token common.Address) (*CustomBridge, *TokenPair, bool) {
+	return r.find(func(p TokenPair) bool { return p.L1Token == token })
+}
+
+// BridgeOfL2Token returns the custom bridge with the static token pair of the L2 token.
+func (r *BridgeRegistry) BridgeOfL2Token(token common.Address) (*CustomBridge, *TokenPair, bool) {
+	return r.find(func(p TokenPair) bool { return p.L2Token == token })
+}
+
+func (r *BridgeRegistry) find(match func(TokenPair) bool) (*CustomBridge, *TokenPair, bool) {
+	if r == nil {
+		return nil, nil, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for i := range r.bridges {
+		for j := range r.bridges[i].Tokens {
+			if match(r.bridges[i].Tokens[j]) {
+				bridge, pair := r.bridges[i], r.bridges[i].Tokens[j]
+				return &bridge, &pair, true
+			}
+		}
+	}
+	return nil, nil, false
+}
+
+// dynamicBridges returns the L2 addresses of the bridges without static token pairs.
+func (r *BridgeRegistry) dynamicBridges() []common.Address {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var bridges []common.Address
+	for _, b := range r.bridges {
+		if len(b.Tokens) == 0 {
+			bridges = append(bridges, b.L2Bridge)
+		}
+	}
+	return bridges
+}
+
+// customL2TokenAddress returns the L2 address of the L1 token bridged by the custom bridge. The bridges
+// without static token pairs may compute the address of any token, so the address is accepted only if
+// the bridge maps it back to the L1 token.
+func (c *BaseClient) customL2TokenAddress(ctx context.Context, token common.Address) (common.Address, bool, error) {
+	if _, pair, ok := c.bridges.BridgeOfL1Token(token); ok {
+		return pair.L2Token, true, nil
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	for _, address := range c.bridges.dynamicBridges() {
+		bridge, err := l2bridge.NewIL2Bridge(address, c)
+		if err != nil {
+			return common.Address{}, false, err
+		}
+		l2Token, err := bridge.L2TokenAddress(opts, token)
+		if err != nil || l2Token == (common.Address{}) {
+			continue
+		}
+		if l1Token, err := bridge.L1TokenAddress(opts, l2Token); err == nil && l1Token == token {
+			return l2Token, true, nil
+		}
+	}
+	return common.Address{}, false, nil
+}
+
+// customL1TokenAddress returns the L1 address of the L2 token bridged by the custom bridge. As with
+// customL2TokenAddress, the address returned by the bridge without static token pairs is accepted only if
+// the bridge maps it back to the L2 token.
+func (c *BaseClient) customL1TokenAddress(ctx context.Context, token common.Address) (common.Address, bool, error) {
+	if _, pair, ok := c.bridges.BridgeOfL2Token(token); ok {
+		return pair.L1Token, true, nil
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	for _, address := range c.bridges.dynamicBridges() {
+		bridge, err := l2bridge.NewIL2Bridge(address, c)
+		if err != nil {
+			return common.Address{}, false, err
+		}
+		l1Token, err := bridge.L1TokenAddress(opts, token)
+		if err != nil || l1Token == (common.Address{}) {
+			continue
+		}
+		if l2Token, err := bridge.L2TokenAddress(opts, l1Token); err == nil && l2Token == token {
+			return l1Token, true, nil
+		}
+	}
+	return common.Address{}, false, nil
+}
@@ -0,0 +1,90 @@
+package clients
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"math/big"
+	"sync"
+)
+
+// chainCache holds chain facts which do not change during the lifetime of the network,
+// so that they are fetched from the node only once per client instance. It is safe for
+// concurrent use, and returned values are copies, so callers cannot modify cached values.
+type chainCache struct {
+	mu                  sync.RWMutex
+	chainID             *big.Int
+	l1ChainID           *big.Int
+	mainContractAddress *common.Address
+	testnetPaymaster    *common.Address
+	baseToken           *common.Address
+	bridgehub           *common.Address
+	bridgeContracts     *zkTypes.BridgeContracts
+}
+
+func (c *chainCache) bigInt(field **big.Int, fetch func() (*big.Int, error)) (*big.Int, error) {
+	c.mu.RLock()
+	value := *field
+	c.mu.RUnlock()
+	if value != nil {
+		return new(big.Int).Set(value), nil
+	}
+
+	value, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	*field = new(big.Int).Set(value)
+	c.mu.Unlock()
+	return value, nil
+}
+
+func (c *chainCache) address(field **common.Address, fetch func() (common.Address, error)) (common.Address, error) {
+	c.mu.RLock()
+	value := *field
+	c.mu.RUnlock()
+	if value != nil {
+		return *value, nil
+	}
+
+	address, err := fetch()
+	if err != nil {
+		return common.Address{}, err
+	}
+	c.mu.Lock()
+	*field = &address
+	c.mu.Unlock()
+	return address, nil
+}
+
+func (c *chainCache) bridges(fetch func() (*zkTypes.BridgeContracts, error)) (*zkTypes.BridgeContracts, error) {
+	c.mu.RLock()
+	value := c.bridgeContracts
+	c.mu.RUnlock()
+	if value != nil {
+		bridges := *value
+		return &bridges, nil
+	}
+
+	value, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	bridges := *value
+	c.mu.Lock()
+	c.bridgeContracts = &bridges
+	c.mu.Unlock()
+	return value, nil
+}
+
+func (c *chainCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.chainID = nil
+	c.l1ChainID = nil
+	c.mainContractAddress = nil
+	c.testnetPaymaster = nil
+	c.baseToken = nil
+	c.bridgehub = nil
+	c.bridgeContracts = nil
+}
@@ -0,0 +1,248 @@
+package clients
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrChaosDropped is returned when the response of the request is dropped by the injected failure.
+var ErrChaosDropped = errors.New("chaos: response dropped")
+
+// Chaos configures failures injected by the client, so that applications can test their recovery paths against
+// realistic RPC misbehavior without a custom proxy. Dropped responses and delayed receipts are injected into
+// requests sent to HTTP(S) endpoints, while forged reorgs are injected into subscriptions. It must not be used
+// in production.
+type Chaos struct {
+	// DropRate is the probability, in range [0, 1], that the response of the request is dropped after
+	// the request has been processed by the node, in which case ErrChaosDropped is returned. It simulates
+	// the loss of connection, e.g. a transaction which has been sent while the client reports the failure.
+	DropRate float64
+	// DropMethods restricts dropping of responses to the requests of the RPC methods. Optional, the responses
+	// of requests of any method are dropped by default.
+	DropMethods []string
+	// ReceiptDelay is the duration since the receipt of the transaction has been requested for the first time,
+	// during which the receipt is reported as not found, even if the transaction has been included.
+	ReceiptDelay time.Duration
+	// ReorgRate is the probability, in range [0, 1], that the log delivered by a subscription is followed by
+	// its removal and redelivery, and that the header delivered by a subscription is preceded by a forged
+	// header of the same block number, as it happens when the chain is reorganized.
+	ReorgRate float64
+	// Seed of the source of randomness, used to reproduce the failures. Optional, the current time is used
+	// by default.
+	Seed int64
+}
+
+// chaosInjector injects the failures configured by Chaos. It is safe for concurrent use.
+type chaosInjector struct {
+	config Chaos
+
+	mu       sync.Mutex
+	rand     *rand.Rand
+	receipts map[string]time.Time // Time of the first request of the receipt by the transaction hash.
+}
+
+func newChaosInjector(config *Chaos) *chaosInjector {
+	if config == nil {
+		return nil
+	}
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &chaosInjector{
+		config:   *config,
+		rand:     rand.New(rand.NewSource(seed)),
+		receipts: make(map[string]time.Time),
+	}
+}
+
+// chance reports whether the event with the given probability happens.
+func (c *chaosInjector) chance(probability float64) bool {
+	if probability <= 0 {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rand.Float64() < probability
+}
+
+func (c *chaosInjector) dropsMethod(method string) bool {
+	if len(c.config.DropMethods) == 0 {
+		return true
+	}
+	for _, m := range c.config.DropMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// delaysReceipt reports whether the receipt of the transaction must be reported as not found.
+func (c *chaosInjector) delaysReceipt(txHash string) bool {
+	if c.config.ReceiptDelay <= 0 {
+		return false
+	}
+	txHash = strings.ToLower(txHash)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	first, ok := c.receipts[txHash]
+	if !ok {
+		first = time.Now()
+		c.receipts[txHash] = first
+	}
+	return time.Since(first) < c.config.ReceiptDelay
+}
+
+// transport wraps the HTTP client, so that failures are injected into its requests.
+func (c *chaosInjector) transport(client *http.Client) *http.Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	cl := *client
+	base := cl.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	cl.Transport = &chaosTransport{base: base, chaos: c}
+	return &cl
+}
+
+// chaosTransport is an http.RoundTripper injecting failures into JSON-RPC requests.
+type chaosTransport struct {
+	base  http.RoundTripper
+	chaos *chaosInjector
+}
+
+type chaosRequest struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params []interface{}   `json:"params"`
+}
+
+func (t *chaosTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	requests := decodeRequests(body)
+
+	// Receipts are delayed only for single requests, since batches are answered as a whole.
+	if len(requests) == 1 && requests[0].Method == "eth_getTransactionReceipt" && len(requests[0].Params) > 0 {
+		if txHash, ok := requests[0].Params[0].(string); ok && t.chaos.delaysReceipt(txHash) {
+			return nullResponse(req, requests[0].ID), nil
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range requests {
+		if t.chaos.dropsMethod(r.Method) && t.chaos.chance(t.chaos.config.DropRate) {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			return nil, fmt.Errorf("%w: %s", ErrChaosDropped, r.Method)
+		}
+	}
+	return resp, nil
+}
+
+// nullResponse returns the JSON-RPC response with the null result, which is returned for missing receipts.
+func nullResponse(req *http.Request, id json.RawMessage) *http.Response {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	body := fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"result":null}`, id)
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// forward delivers the items received by the subscription to the channel, injecting forged reorgs using
+// the reorg function, which returns the items to be delivered before and after the item.
+func forward[T any](sub ethereum.Subscription, in <-chan T, out chan<- T, reorg func(item T) (before, after []T)) ethereum.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		send := func(item T) bool {
+			select {
+			case out <- item:
+				return true
+			case <-quit:
+				return false
+			}
+		}
+		for {
+			select {
+			case item := <-in:
+				before, after := reorg(item)
+				for _, forged := range before {
+					if !send(forged) {
+						return nil
+					}
+				}
+				if !send(item) {
+					return nil
+				}
+				for _, forged := range after {
+					if !send(forged) {
+						return nil
+					}
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	})
+}
+
+// reorgLog returns the removal and redelivery of the log, if the reorg is injected.
+func (c *chaosInjector) reorgLog(log types.Log) ([]types.Log, []types.Log) {
+	if !c.chance(c.config.ReorgRate) {
+		return nil, nil
+	}
+	removed := log
+	removed.Removed = true
+	return nil, []types.Log{removed, log}
+}
+
+// reorgLogL2 returns the removal and redelivery of the L2 log, if the reorg is injected.
+func (c *chaosInjector) reorgLogL2(log zkTypes.Log) ([]zkTypes.Log, []zkTypes.Log) {
+	if !c.chance(c.config.ReorgRate) {
+		return nil, nil
+	}
+	removed := log
+	removed.Removed = true
+	return nil, []zkTypes.Log{removed, log}
+}
+
+// reorgHeader returns the forged header of the same block number, if the reorg is injected.
+func (c *chaosInjector) reorgHeader(header *types.Header) ([]*types.Header, []*types.Header) {
+	if !c.chance(c.config.ReorgRate) {
+		return nil, nil
+	}
+	forged := types.CopyHeader(header)
+	forged.Extra = append(forged.Extra, []byte("chaos")...)
+	return []*types.Header{forged}, nil
+}
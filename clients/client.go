@@ -115,6 +115,11 @@ type EthereumClient interface {
 	// CallContractL2 is almost the same as CallContract except that it executes a message call
 	// for EIP-712 transaction.
 	CallContractL2(ctx context.Context, msg zkTypes.CallMsg, blockNumber *big.Int) ([]byte, error)
+	// BatchCallContractL2 executes the message calls in a single batch request, all at the same block,
+	// so that the results reflect one consistent state. If blockNumber is nil, the latest block is resolved
+	// once and used for all calls. It returns the results in the order of the messages along with the block
+	// at which the calls have been executed.
+	BatchCallContractL2(ctx context.Context, msgs []zkTypes.CallMsg, blockNumber *big.Int) ([]CallResult, *big.Int, error)
 	// CallContractAtHash is almost the same as CallContract except that it selects
 	// the block by block hash instead of block height.
 	CallContractAtHash(ctx context.Context, msg ethereum.CallMsg, blockHash common.Hash) ([]byte, error)
@@ -154,6 +159,9 @@ type EthereumClient interface {
 	// WaitFinalized waits for tx to be finalized on the blockchain.
 	// It stops waiting when the context is canceled.
 	WaitFinalized(ctx context.Context, txHash common.Hash) (*zkTypes.Receipt, error)
+	// WaitForBlock waits until the node has processed the block with the given number, which must not be nil.
+	// It stops waiting when the context is canceled.
+	WaitForBlock(ctx context.Context, blockNumber *big.Int) error
 }
 
 // ZkSyncEraClient provides the API to zkSync Era features and
@@ -166,9 +174,27 @@ type ZkSyncEraClient interface {
 	// BridgeContracts returns the addresses of the default zkSync Era bridge
 	// contracts on both L1 and L2.
 	BridgeContracts(ctx context.Context) (*zkTypes.BridgeContracts, error)
+	// BaseTokenContractAddress returns the L1 address of the base token of the chain, in which fees
+	// are paid. For ETH-based chains, utils.EthAddressInContracts is returned.
+	BaseTokenContractAddress(ctx context.Context) (common.Address, error)
+	// BridgehubContractAddress returns the address of the Bridgehub contract on L1, through which
+	// L1 -> L2 transactions are requested on chains supporting shared bridges.
+	BridgehubContractAddress(ctx context.Context) (common.Address, error)
+	// IsEthBasedChain reports whether the base token of the chain is ETH.
+	IsEthBasedChain(ctx context.Context) (bool, error)
+	// IsBaseToken reports whether the token is the base token of the chain. The token can be
+	// given by either its L1 or its L2 address.
+	IsBaseToken(ctx context.Context, token common.Address) (bool, error)
 	// ContractAccountInfo returns the version of the supported account abstraction
 	// and nonce ordering from a given contract address.
 	ContractAccountInfo(ctx context.Context, address common.Address) (*zkTypes.ContractAccountInfo, error)
+	// DeploymentNonceAt returns the deployment nonce of the given account, which is the number of contracts
+	// deployed by the account using CREATE, read from the NonceHolder system contract. The block number can
+	// be nil, in which case the nonce is taken from the latest known block.
+	DeploymentNonceAt(ctx context.Context, address common.Address, blockNumber *big.Int) (*big.Int, error)
+	// ProtocolVersion returns the information about the version of the protocol with the given minor version,
+	// or about the latest version if the version is nil.
+	ProtocolVersion(ctx context.Context, version *uint16) (*zkTypes.ProtocolVersion, error)
 
 	// L1ChainID returns the chain id of the underlying L1.
 	L1ChainID(ctx context.Context) (*big.Int, error)
@@ -185,6 +211,9 @@ type ZkSyncEraClient interface {
 	// TransactionDetails returns data from a specific transaction given by the
 	// transaction hash.
 	TransactionDetails(ctx context.Context, txHash common.Hash) (*zkTypes.TransactionDetails, error)
+	// RawBlockTransactions returns the transactions of the L2 block in the format of the node, including
+	// the data specific to the type of the transaction, such as the priority operation ID.
+	RawBlockTransactions(ctx context.Context, block uint32) ([]zkTypes.RawBlockTransaction, error)
 	// LogProof returns the proof for a transaction's L2 to L1 log sent via the
 	// L1Messenger system contract.
 	LogProof(ctx context.Context, txHash common.Hash, logIndex int) (*zkTypes.MessageProof, error)
@@ -193,6 +222,12 @@ type ZkSyncEraClient interface {
 	// L2TransactionFromPriorityOp returns transaction on L2 network from transaction
 	// receipt on L1 network.
 	L2TransactionFromPriorityOp(ctx context.Context, l1TxReceipt *types.Receipt) (*zkTypes.TransactionResponse, error)
+	// Proof returns the Merkle proofs of the storage slots of the account against the root hash
+	// of the L1 batch, which allow the values of the slots to be verified. The L1 batch number is required.
+	Proof(ctx context.Context, address common.Address, keys []common.Hash, l1BatchNumber *big.Int) (*zkTypes.StorageProof, error)
+	// SendRawTransactionWithDetailedOutput injects a signed raw transaction into the pending pool for
+	// execution, and returns the storage writes and events of its execution in the sandbox.
+	SendRawTransactionWithDetailedOutput(ctx context.Context, tx []byte) (*zkTypes.TransactionDetailedResult, error)
 
 	// ConfirmedTokens returns [address, symbol, name, and decimal] information of
 	// all tokens within a range of ids given by parameters from and limit.
@@ -200,10 +235,12 @@ type ZkSyncEraClient interface {
 	// Deprecated: Method is deprecated and will be removed in the near future.
 	TokenPrice(ctx context.Context, address common.Address) (*big.Float, error)
 	// L2TokenAddress returns the L2 token address equivalent for a L1 token address
-	// as they are not equal. ETH address is set to zero address.
+	// as they are not equal. ETH address is set to zero address. The custom bridges of
+	// ClientOptions.Bridges are consulted before the default bridge.
 	L2TokenAddress(ctx context.Context, token common.Address) (common.Address, error)
 	// L1TokenAddress returns the L1 token address equivalent for a L2 token address
-	// as they are not equal. ETH address is set to zero address.
+	// as they are not equal. ETH address is set to zero address. The custom bridges of
+	// ClientOptions.Bridges are consulted before the default bridge.
 	L1TokenAddress(ctx context.Context, token common.Address) (common.Address, error)
 	// AllAccountBalances returns all balances for confirmed tokens given by an
 	// account address.
@@ -211,6 +248,15 @@ type ZkSyncEraClient interface {
 
 	// EstimateFee Returns the fee for the transaction.
 	EstimateFee(ctx context.Context, tx zkTypes.CallMsg) (*zkTypes.Fee, error)
+	// FeeParams returns the current parameters of the fee model, which allow fees to be estimated
+	// locally using utils.FeeEstimator.
+	FeeParams(ctx context.Context) (*zkTypes.FeeParams, error)
+	// L1GasPrice returns the price of L1 gas used by the sequencer.
+	L1GasPrice(ctx context.Context) (*big.Int, error)
+	// BatchFeeInput returns the prices used by the sequencer for the current L1 batch.
+	BatchFeeInput(ctx context.Context) (*zkTypes.BatchFeeInput, error)
+	// GasPerPubdata returns the current amount of gas charged for a byte of pubdata.
+	GasPerPubdata(ctx context.Context) (*big.Int, error)
 	// EstimateGasL1 estimates the amount of gas required to submit a transaction
 	// from L1 to L2.
 	EstimateGasL1(ctx context.Context, tx zkTypes.CallMsg) (uint64, error)
@@ -229,4 +275,9 @@ type ZkSyncEraClient interface {
 type Client interface {
 	EthereumClient
 	ZkSyncEraClient
+
+	// InvalidateCache clears the cached chain facts which are considered immutable (chain IDs,
+	// main contract, bridge contracts, base token and testnet paymaster addresses), forcing them to be
+	// fetched from the node on next use.
+	InvalidateCache()
 }
@@ -0,0 +1,580 @@
+// Package clientsmock provides a scriptable in-memory implementation of the clients.Client interface,
+// allowing code which depends on a zkSync Era client to be unit-tested without running a node.
+package clientsmock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/zksync-sdk/zksync2-go/clients"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"math/big"
+	"sync"
+)
+
+// ErrNoResponse is returned by a method of Client for which no response has been configured.
+var ErrNoResponse = errors.New("no response configured")
+
+// HandlerFunc computes the response of a mocked method from its arguments.
+// The returned values must be in the same order and of the same types as the
+// return values of the mocked method, excluding the trailing error.
+type HandlerFunc func(ctx context.Context, args ...interface{}) ([]interface{}, error)
+
+// Call represents a single recorded invocation of a Client method.
+type Call struct {
+	Method string        // Name of the invoked method.
+	Args   []interface{} // Arguments passed to the method, excluding the context.
+}
+
+// Client is a scriptable in-memory implementation of clients.Client.
+//
+// Responses are configured per method name using On, OnError and OnFunc. Responses configured for the
+// same method are returned in the order in which they were configured, and the last one is repeated
+// for all subsequent calls. Every method invocation is recorded and can be inspected using Calls.
+// Methods without a configured response return zero values and ErrNoResponse.
+type Client struct {
+	mu        sync.Mutex
+	handlers  map[string][]HandlerFunc
+	calls     []Call
+	rpcClient *rpc.Client
+	closed    bool
+}
+
+var _ clients.Client = (*Client)(nil)
+
+// NewClient creates a new mocked client without any configured responses.
+func NewClient() *Client {
+	return &Client{
+		handlers: make(map[string][]HandlerFunc),
+	}
+}
+
+// On appends a canned response for the given method. The values must be in the same order and of the same
+// types as the return values of the method, excluding the trailing error.
+func (c *Client) On(method string, values ...interface{}) *Client {
+	return c.OnFunc(method, func(context.Context, ...interface{}) ([]interface{}, error) {
+		return values, nil
+	})
+}
+
+// OnError appends a response for the given method that returns the given error.
+func (c *Client) OnError(method string, err error) *Client {
+	return c.OnFunc(method, func(context.Context, ...interface{}) ([]interface{}, error) {
+		return nil, err
+	})
+}
+
+// OnFunc appends a handler which computes the response of the given method from its arguments.
+func (c *Client) OnFunc(method string, handler HandlerFunc) *Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.handlers[method] = append(c.handlers[method], handler)
+	return c
+}
+
+// WithRPCClient sets the RPC client returned by the Client method.
+func (c *Client) WithRPCClient(rpcClient *rpc.Client) *Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rpcClient = rpcClient
+	return c
+}
+
+// Calls returns all recorded method invocations in the order in which they occurred.
+func (c *Client) Calls() []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	calls := make([]Call, len(c.calls))
+	copy(calls, c.calls)
+	return calls
+}
+
+// CallsTo returns recorded invocations of the given method in the order in which they occurred.
+func (c *Client) CallsTo(method string) []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var calls []Call
+	for _, call := range c.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Closed reports whether the Close method has been called.
+func (c *Client) Closed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+// Reset removes all configured responses and recorded invocations.
+func (c *Client) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.handlers = make(map[string][]HandlerFunc)
+	c.calls = nil
+	c.closed = false
+}
+
+func (c *Client) handle(ctx context.Context, method string, args ...interface{}) ([]interface{}, error) {
+	c.mu.Lock()
+	c.calls = append(c.calls, Call{Method: method, Args: args})
+	var handler HandlerFunc
+	if queue := c.handlers[method]; len(queue) > 0 {
+		handler = queue[0]
+		if len(queue) > 1 {
+			c.handlers[method] = queue[1:]
+		}
+	}
+	c.mu.Unlock()
+
+	if ctx != nil {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+	if handler == nil {
+		return nil, fmt.Errorf("%s: %w", method, ErrNoResponse)
+	}
+	return handler(ctx, args...)
+}
+
+// result returns the i-th value from the response converted to type T, or zero value of T
+// if the value is not present or is nil.
+func result[T any](values []interface{}, i int) T {
+	var zero T
+	if i >= len(values) || values[i] == nil {
+		return zero
+	}
+	v, ok := values[i].(T)
+	if !ok {
+		panic(fmt.Sprintf("clientsmock: response value %d has type %T, expected %T", i, values[i], zero))
+	}
+	return v
+}
+
+func (c *Client) Client() *rpc.Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, Call{Method: "Client"})
+	return c.rpcClient
+}
+
+func (c *Client) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, Call{Method: "Close"})
+	c.closed = true
+}
+
+func (c *Client) InvalidateCache() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, Call{Method: "InvalidateCache"})
+}
+
+func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	res, err := c.handle(ctx, "ChainID")
+	return result[*big.Int](res, 0), err
+}
+
+func (c *Client) BlockByHash(ctx context.Context, hash common.Hash) (*zkTypes.Block, error) {
+	res, err := c.handle(ctx, "BlockByHash", hash)
+	return result[*zkTypes.Block](res, 0), err
+}
+
+func (c *Client) BlockByNumber(ctx context.Context, number *big.Int) (*zkTypes.Block, error) {
+	res, err := c.handle(ctx, "BlockByNumber", number)
+	return result[*zkTypes.Block](res, 0), err
+}
+
+func (c *Client) BlockNumber(ctx context.Context) (uint64, error) {
+	res, err := c.handle(ctx, "BlockNumber")
+	return result[uint64](res, 0), err
+}
+
+func (c *Client) PeerCount(ctx context.Context) (uint64, error) {
+	res, err := c.handle(ctx, "PeerCount")
+	return result[uint64](res, 0), err
+}
+
+func (c *Client) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	res, err := c.handle(ctx, "HeaderByHash", hash)
+	return result[*types.Header](res, 0), err
+}
+
+func (c *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	res, err := c.handle(ctx, "HeaderByNumber", number)
+	return result[*types.Header](res, 0), err
+}
+
+func (c *Client) TransactionByHash(ctx context.Context, hash common.Hash) (*zkTypes.TransactionResponse, bool, error) {
+	res, err := c.handle(ctx, "TransactionByHash", hash)
+	return result[*zkTypes.TransactionResponse](res, 0), result[bool](res, 1), err
+}
+
+func (c *Client) TransactionSender(ctx context.Context, tx *zkTypes.TransactionResponse, block common.Hash, index uint) (common.Address, error) {
+	res, err := c.handle(ctx, "TransactionSender", tx, block, index)
+	return result[common.Address](res, 0), err
+}
+
+func (c *Client) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	res, err := c.handle(ctx, "TransactionCount", blockHash)
+	return result[uint](res, 0), err
+}
+
+func (c *Client) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*zkTypes.TransactionResponse, error) {
+	res, err := c.handle(ctx, "TransactionInBlock", blockHash, index)
+	return result[*zkTypes.TransactionResponse](res, 0), err
+}
+
+func (c *Client) TransactionReceipt(ctx context.Context, txHash common.Hash) (*zkTypes.Receipt, error) {
+	res, err := c.handle(ctx, "TransactionReceipt", txHash)
+	return result[*zkTypes.Receipt](res, 0), err
+}
+
+func (c *Client) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	res, err := c.handle(ctx, "SyncProgress")
+	return result[*ethereum.SyncProgress](res, 0), err
+}
+
+func (c *Client) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	res, err := c.handle(ctx, "SubscribeNewHead", ch)
+	return result[ethereum.Subscription](res, 0), err
+}
+
+func (c *Client) NetworkID(ctx context.Context) (*big.Int, error) {
+	res, err := c.handle(ctx, "NetworkID")
+	return result[*big.Int](res, 0), err
+}
+
+func (c *Client) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	res, err := c.handle(ctx, "BalanceAt", account, blockNumber)
+	return result[*big.Int](res, 0), err
+}
+
+func (c *Client) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	res, err := c.handle(ctx, "StorageAt", account, key, blockNumber)
+	return result[[]byte](res, 0), err
+}
+
+func (c *Client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	res, err := c.handle(ctx, "CodeAt", account, blockNumber)
+	return result[[]byte](res, 0), err
+}
+
+func (c *Client) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	res, err := c.handle(ctx, "NonceAt", account, blockNumber)
+	return result[uint64](res, 0), err
+}
+
+func (c *Client) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	res, err := c.handle(ctx, "FilterLogs", query)
+	return result[[]types.Log](res, 0), err
+}
+
+func (c *Client) FilterLogsL2(ctx context.Context, query ethereum.FilterQuery) ([]zkTypes.Log, error) {
+	res, err := c.handle(ctx, "FilterLogsL2", query)
+	return result[[]zkTypes.Log](res, 0), err
+}
+
+func (c *Client) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	res, err := c.handle(ctx, "SubscribeFilterLogs", query, ch)
+	return result[ethereum.Subscription](res, 0), err
+}
+
+func (c *Client) SubscribeFilterLogsL2(ctx context.Context, query ethereum.FilterQuery, ch chan<- zkTypes.Log) (ethereum.Subscription, error) {
+	res, err := c.handle(ctx, "SubscribeFilterLogsL2", query, ch)
+	return result[ethereum.Subscription](res, 0), err
+}
+
+func (c *Client) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	res, err := c.handle(ctx, "PendingBalanceAt", account)
+	return result[*big.Int](res, 0), err
+}
+
+func (c *Client) PendingStorageAt(ctx context.Context, account common.Address, key common.Hash) ([]byte, error) {
+	res, err := c.handle(ctx, "PendingStorageAt", account, key)
+	return result[[]byte](res, 0), err
+}
+
+func (c *Client) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	res, err := c.handle(ctx, "PendingCodeAt", account)
+	return result[[]byte](res, 0), err
+}
+
+func (c *Client) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	res, err := c.handle(ctx, "PendingNonceAt", account)
+	return result[uint64](res, 0), err
+}
+
+func (c *Client) PendingTransactionCount(ctx context.Context) (uint, error) {
+	res, err := c.handle(ctx, "PendingTransactionCount")
+	return result[uint](res, 0), err
+}
+
+func (c *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	res, err := c.handle(ctx, "CallContract", msg, blockNumber)
+	return result[[]byte](res, 0), err
+}
+
+func (c *Client) CallContractL2(ctx context.Context, msg zkTypes.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	res, err := c.handle(ctx, "CallContractL2", msg, blockNumber)
+	return result[[]byte](res, 0), err
+}
+
+func (c *Client) BatchCallContractL2(ctx context.Context, msgs []zkTypes.CallMsg, blockNumber *big.Int) ([]clients.CallResult, *big.Int, error) {
+	res, err := c.handle(ctx, "BatchCallContractL2", msgs, blockNumber)
+	return result[[]clients.CallResult](res, 0), result[*big.Int](res, 1), err
+}
+
+func (c *Client) CallContractAtHash(ctx context.Context, msg ethereum.CallMsg, blockHash common.Hash) ([]byte, error) {
+	res, err := c.handle(ctx, "CallContractAtHash", msg, blockHash)
+	return result[[]byte](res, 0), err
+}
+
+func (c *Client) CallContractAtHashL2(ctx context.Context, msg zkTypes.CallMsg, blockHash common.Hash) ([]byte, error) {
+	res, err := c.handle(ctx, "CallContractAtHashL2", msg, blockHash)
+	return result[[]byte](res, 0), err
+}
+
+func (c *Client) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	res, err := c.handle(ctx, "PendingCallContract", msg)
+	return result[[]byte](res, 0), err
+}
+
+func (c *Client) PendingCallContractL2(ctx context.Context, msg zkTypes.CallMsg) ([]byte, error) {
+	res, err := c.handle(ctx, "PendingCallContractL2", msg)
+	return result[[]byte](res, 0), err
+}
+
+func (c *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	res, err := c.handle(ctx, "SuggestGasPrice")
+	return result[*big.Int](res, 0), err
+}
+
+func (c *Client) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	res, err := c.handle(ctx, "SuggestGasTipCap")
+	return result[*big.Int](res, 0), err
+}
+
+func (c *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	res, err := c.handle(ctx, "EstimateGas", msg)
+	return result[uint64](res, 0), err
+}
+
+func (c *Client) EstimateGasL2(ctx context.Context, msg zkTypes.CallMsg) (uint64, error) {
+	res, err := c.handle(ctx, "EstimateGasL2", msg)
+	return result[uint64](res, 0), err
+}
+
+func (c *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	_, err := c.handle(ctx, "SendTransaction", tx)
+	return err
+}
+
+func (c *Client) SendRawTransaction(ctx context.Context, tx []byte) (common.Hash, error) {
+	res, err := c.handle(ctx, "SendRawTransaction", tx)
+	return result[common.Hash](res, 0), err
+}
+
+func (c *Client) WaitMined(ctx context.Context, txHash common.Hash) (*zkTypes.Receipt, error) {
+	res, err := c.handle(ctx, "WaitMined", txHash)
+	return result[*zkTypes.Receipt](res, 0), err
+}
+
+func (c *Client) WaitFinalized(ctx context.Context, txHash common.Hash) (*zkTypes.Receipt, error) {
+	res, err := c.handle(ctx, "WaitFinalized", txHash)
+	return result[*zkTypes.Receipt](res, 0), err
+}
+
+func (c *Client) WaitForBlock(ctx context.Context, blockNumber *big.Int) error {
+	_, err := c.handle(ctx, "WaitForBlock", blockNumber)
+	return err
+}
+
+func (c *Client) MainContractAddress(ctx context.Context) (common.Address, error) {
+	res, err := c.handle(ctx, "MainContractAddress")
+	return result[common.Address](res, 0), err
+}
+
+func (c *Client) TestnetPaymaster(ctx context.Context) (common.Address, error) {
+	res, err := c.handle(ctx, "TestnetPaymaster")
+	return result[common.Address](res, 0), err
+}
+
+func (c *Client) BridgehubContractAddress(ctx context.Context) (common.Address, error) {
+	res, err := c.handle(ctx, "BridgehubContractAddress")
+	return result[common.Address](res, 0), err
+}
+
+func (c *Client) BaseTokenContractAddress(ctx context.Context) (common.Address, error) {
+	res, err := c.handle(ctx, "BaseTokenContractAddress")
+	return result[common.Address](res, 0), err
+}
+
+func (c *Client) IsEthBasedChain(ctx context.Context) (bool, error) {
+	res, err := c.handle(ctx, "IsEthBasedChain")
+	return result[bool](res, 0), err
+}
+
+func (c *Client) IsBaseToken(ctx context.Context, token common.Address) (bool, error) {
+	res, err := c.handle(ctx, "IsBaseToken", token)
+	return result[bool](res, 0), err
+}
+
+func (c *Client) BridgeContracts(ctx context.Context) (*zkTypes.BridgeContracts, error) {
+	res, err := c.handle(ctx, "BridgeContracts")
+	return result[*zkTypes.BridgeContracts](res, 0), err
+}
+
+func (c *Client) ContractAccountInfo(ctx context.Context, address common.Address) (*zkTypes.ContractAccountInfo, error) {
+	res, err := c.handle(ctx, "ContractAccountInfo", address)
+	return result[*zkTypes.ContractAccountInfo](res, 0), err
+}
+
+func (c *Client) DeploymentNonceAt(ctx context.Context, address common.Address, blockNumber *big.Int) (*big.Int, error) {
+	res, err := c.handle(ctx, "DeploymentNonceAt", address, blockNumber)
+	return result[*big.Int](res, 0), err
+}
+
+func (c *Client) L1ChainID(ctx context.Context) (*big.Int, error) {
+	res, err := c.handle(ctx, "L1ChainID")
+	return result[*big.Int](res, 0), err
+}
+
+func (c *Client) L1BatchNumber(ctx context.Context) (*big.Int, error) {
+	res, err := c.handle(ctx, "L1BatchNumber")
+	return result[*big.Int](res, 0), err
+}
+
+func (c *Client) L1BatchBlockRange(ctx context.Context, l1BatchNumber *big.Int) (*clients.BlockRange, error) {
+	res, err := c.handle(ctx, "L1BatchBlockRange", l1BatchNumber)
+	return result[*clients.BlockRange](res, 0), err
+}
+
+func (c *Client) L1BatchDetails(ctx context.Context, l1BatchNumber *big.Int) (*zkTypes.BatchDetails, error) {
+	res, err := c.handle(ctx, "L1BatchDetails", l1BatchNumber)
+	return result[*zkTypes.BatchDetails](res, 0), err
+}
+
+func (c *Client) BlockDetails(ctx context.Context, block uint32) (*zkTypes.BlockDetails, error) {
+	res, err := c.handle(ctx, "BlockDetails", block)
+	return result[*zkTypes.BlockDetails](res, 0), err
+}
+
+func (c *Client) TransactionDetails(ctx context.Context, txHash common.Hash) (*zkTypes.TransactionDetails, error) {
+	res, err := c.handle(ctx, "TransactionDetails", txHash)
+	return result[*zkTypes.TransactionDetails](res, 0), err
+}
+
+func (c *Client) RawBlockTransactions(ctx context.Context, block uint32) ([]zkTypes.RawBlockTransaction, error) {
+	res, err := c.handle(ctx, "RawBlockTransactions", block)
+	return result[[]zkTypes.RawBlockTransaction](res, 0), err
+}
+
+func (c *Client) Proof(ctx context.Context, address common.Address, keys []common.Hash, l1BatchNumber *big.Int) (*zkTypes.StorageProof, error) {
+	res, err := c.handle(ctx, "Proof", address, keys, l1BatchNumber)
+	return result[*zkTypes.StorageProof](res, 0), err
+}
+
+func (c *Client) ProtocolVersion(ctx context.Context, version *uint16) (*zkTypes.ProtocolVersion, error) {
+	res, err := c.handle(ctx, "ProtocolVersion", version)
+	return result[*zkTypes.ProtocolVersion](res, 0), err
+}
+
+func (c *Client) SendRawTransactionWithDetailedOutput(ctx context.Context, tx []byte) (*zkTypes.TransactionDetailedResult, error) {
+	res, err := c.handle(ctx, "SendRawTransactionWithDetailedOutput", tx)
+	return result[*zkTypes.TransactionDetailedResult](res, 0), err
+}
+
+func (c *Client) LogProof(ctx context.Context, txHash common.Hash, logIndex int) (*zkTypes.MessageProof, error) {
+	res, err := c.handle(ctx, "LogProof", txHash, logIndex)
+	return result[*zkTypes.MessageProof](res, 0), err
+}
+
+func (c *Client) MsgProof(ctx context.Context, block uint32, sender common.Address, msg common.Hash) (*zkTypes.MessageProof, error) {
+	res, err := c.handle(ctx, "MsgProof", block, sender, msg)
+	return result[*zkTypes.MessageProof](res, 0), err
+}
+
+func (c *Client) L2TransactionFromPriorityOp(ctx context.Context, l1TxReceipt *types.Receipt) (*zkTypes.TransactionResponse, error) {
+	res, err := c.handle(ctx, "L2TransactionFromPriorityOp", l1TxReceipt)
+	return result[*zkTypes.TransactionResponse](res, 0), err
+}
+
+func (c *Client) ConfirmedTokens(ctx context.Context, from uint32, limit uint8) ([]*zkTypes.Token, error) {
+	res, err := c.handle(ctx, "ConfirmedTokens", from, limit)
+	return result[[]*zkTypes.Token](res, 0), err
+}
+
+func (c *Client) TokenPrice(ctx context.Context, address common.Address) (*big.Float, error) {
+	res, err := c.handle(ctx, "TokenPrice", address)
+	return result[*big.Float](res, 0), err
+}
+
+func (c *Client) L2TokenAddress(ctx context.Context, token common.Address) (common.Address, error) {
+	res, err := c.handle(ctx, "L2TokenAddress", token)
+	return result[common.Address](res, 0), err
+}
+
+func (c *Client) L1TokenAddress(ctx context.Context, token common.Address) (common.Address, error) {
+	res, err := c.handle(ctx, "L1TokenAddress", token)
+	return result[common.Address](res, 0), err
+}
+
+func (c *Client) AllAccountBalances(ctx context.Context, address common.Address) (map[common.Address]*big.Int, error) {
+	res, err := c.handle(ctx, "AllAccountBalances", address)
+	return result[map[common.Address]*big.Int](res, 0), err
+}
+
+func (c *Client) EstimateFee(ctx context.Context, tx zkTypes.CallMsg) (*zkTypes.Fee, error) {
+	res, err := c.handle(ctx, "EstimateFee", tx)
+	return result[*zkTypes.Fee](res, 0), err
+}
+
+func (c *Client) FeeParams(ctx context.Context) (*zkTypes.FeeParams, error) {
+	res, err := c.handle(ctx, "FeeParams")
+	return result[*zkTypes.FeeParams](res, 0), err
+}
+
+func (c *Client) L1GasPrice(ctx context.Context) (*big.Int, error) {
+	res, err := c.handle(ctx, "L1GasPrice")
+	return result[*big.Int](res, 0), err
+}
+
+func (c *Client) BatchFeeInput(ctx context.Context) (*zkTypes.BatchFeeInput, error) {
+	res, err := c.handle(ctx, "BatchFeeInput")
+	return result[*zkTypes.BatchFeeInput](res, 0), err
+}
+
+func (c *Client) GasPerPubdata(ctx context.Context) (*big.Int, error) {
+	res, err := c.handle(ctx, "GasPerPubdata")
+	return result[*big.Int](res, 0), err
+}
+
+func (c *Client) EstimateGasL1(ctx context.Context, tx zkTypes.CallMsg) (uint64, error) {
+	res, err := c.handle(ctx, "EstimateGasL1", tx)
+	return result[uint64](res, 0), err
+}
+
+func (c *Client) EstimateGasTransfer(ctx context.Context, msg clients.TransferCallMsg) (uint64, error) {
+	res, err := c.handle(ctx, "EstimateGasTransfer", msg)
+	return result[uint64](res, 0), err
+}
+
+func (c *Client) EstimateGasWithdraw(ctx context.Context, msg clients.WithdrawalCallMsg) (uint64, error) {
+	res, err := c.handle(ctx, "EstimateGasWithdraw", msg)
+	return result[uint64](res, 0), err
+}
+
+func (c *Client) EstimateL1ToL2Execute(ctx context.Context, msg zkTypes.CallMsg) (uint64, error) {
+	res, err := c.handle(ctx, "EstimateL1ToL2Execute", msg)
+	return result[uint64](res, 0), err
+}
@@ -0,0 +1,122 @@
+package clientsmock
+
+import (
+	"context"
+	"errors"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestClientResponses(t *testing.T) {
+	failure := errors.New("node is down")
+	tests := []struct {
+		name      string
+		configure func(c *Client)
+		want      []uint64 // Block numbers returned by the consecutive calls.
+		wantErrs  []error  // Errors returned by the consecutive calls.
+	}{
+		{
+			name:      "no response",
+			configure: func(c *Client) {},
+			want:      []uint64{0},
+			wantErrs:  []error{ErrNoResponse},
+		},
+		{
+			name:      "last response repeated",
+			configure: func(c *Client) { c.On("BlockNumber", uint64(1)).On("BlockNumber", uint64(2)) },
+			want:      []uint64{1, 2, 2},
+			wantErrs:  []error{nil, nil, nil},
+		},
+		{
+			name:      "error then response",
+			configure: func(c *Client) { c.OnError("BlockNumber", failure).On("BlockNumber", uint64(7)) },
+			want:      []uint64{0, 7},
+			wantErrs:  []error{failure, nil},
+		},
+		{
+			name: "handler",
+			configure: func(c *Client) {
+				var calls uint64
+				c.OnFunc("BlockNumber", func(context.Context, ...interface{}) ([]interface{}, error) {
+					calls++
+					return []interface{}{calls * 10}, nil
+				})
+			},
+			want:     []uint64{10, 20},
+			wantErrs: []error{nil, nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient()
+			tt.configure(c)
+			for i := range tt.want {
+				got, err := c.BlockNumber(context.Background())
+				if !errors.Is(err, tt.wantErrs[i]) {
+					t.Fatalf("call %d: error = %v, want %v", i, err, tt.wantErrs[i])
+				}
+				if got != tt.want[i] {
+					t.Errorf("call %d: BlockNumber() = %d, want %d", i, got, tt.want[i])
+				}
+			}
+			if calls := c.CallsTo("BlockNumber"); len(calls) != len(tt.want) {
+				t.Errorf("recorded %d calls, want %d", len(calls), len(tt.want))
+			}
+		})
+	}
+}
+
+func TestClientRecordsCalls(t *testing.T) {
+	account := common.HexToAddress("0x36615Cf349d7F6344891B1e7CA7C72883F5dc049")
+	block := big.NewInt(5)
+	c := NewClient().On("BalanceAt", big.NewInt(100))
+
+	balance, err := c.BalanceAt(context.Background(), account, block)
+	if err != nil {
+		t.Fatalf("BalanceAt() error = %v", err)
+	}
+	if balance.Cmp(big.NewInt(100)) != 0 {
+		t.Errorf("BalanceAt() = %s, want 100", balance)
+	}
+	c.Close()
+
+	want := []Call{
+		{Method: "BalanceAt", Args: []interface{}{account, block}},
+		{Method: "Close"},
+	}
+	if got := c.Calls(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Calls() = %v, want %v", got, want)
+	}
+	if !c.Closed() {
+		t.Error("Closed() = false, want true")
+	}
+
+	c.Reset()
+	if calls := c.Calls(); len(calls) != 0 {
+		t.Errorf("Calls() after Reset = %v, want none", calls)
+	}
+	if _, err = c.BalanceAt(context.Background(), account, block); !errors.Is(err, ErrNoResponse) {
+		t.Errorf("BalanceAt() after Reset error = %v, want %v", err, ErrNoResponse)
+	}
+}
+
+func TestClientCanceledContext(t *testing.T) {
+	c := NewClient().On("ChainID", big.NewInt(324))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.ChainID(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("ChainID() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestClientWrongResponseType(t *testing.T) {
+	c := NewClient().On("ChainID", "324")
+	defer func() {
+		if recover() == nil {
+			t.Error("ChainID() did not panic on response of wrong type")
+		}
+	}()
+	_, _ = c.ChainID(context.Background())
+}
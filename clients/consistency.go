@@ -0,0 +1,89 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"log/slog"
+	"math/big"
+	"time"
+)
+
+type readConsistencyKey struct{}
+
+// readConsistency contains the read consistency requirements attached to the context.
+type readConsistency struct {
+	block *big.Int // Block which the node must have processed before reading.
+	pin   bool     // Whether the reads are performed at the block instead of the latest one.
+}
+
+// WithMinBlock returns a context which instructs the client to wait until the node has processed the given
+// block, before reads made with it at the latest block (i.e. with nil block number) are performed.
+// It provides read-your-writes consistency when the reads follow a transaction included in the block.
+//
+// Behind load-balanced RPC endpoints, each request might be served by a different node, so the node
+// serving the read might still be behind the one which has been checked. In such a case, WithPinnedBlock
+// should be used instead.
+func WithMinBlock(ctx context.Context, blockNumber *big.Int) context.Context {
+	return context.WithValue(ctx, readConsistencyKey{}, readConsistency{block: blockNumber})
+}
+
+// WithPinnedBlock returns a context which instructs the client to perform reads made with it at the latest
+// block (i.e. with nil block number) at the given block instead, after waiting until the node has processed it.
+// Nodes which have not processed the block yet fail the reads instead of returning stale state.
+func WithPinnedBlock(ctx context.Context, blockNumber *big.Int) context.Context {
+	return context.WithValue(ctx, readConsistencyKey{}, readConsistency{block: blockNumber, pin: true})
+}
+
+// ReadAfter returns a context which ensures that reads made with it observe the effects of the transaction
+// with the given receipt, e.g. one returned by WaitMined. See WithMinBlock.
+func ReadAfter(ctx context.Context, receipt *zkTypes.Receipt) context.Context {
+	if receipt == nil || receipt.BlockNumber == nil {
+		return ctx
+	}
+	return WithMinBlock(ctx, receipt.BlockNumber)
+}
+
+func (c *BaseClient) WaitForBlock(ctx context.Context, blockNumber *big.Int) error {
+	if blockNumber == nil {
+		return errors.New("blockNumber must be provided")
+	}
+	c.log(ctx, slog.LevelDebug, "Waiting for block", "block", blockNumber)
+	queryTicker := time.NewTicker(c.pollInterval(ctx))
+	defer queryTicker.Stop()
+	for {
+		head, err := c.BlockNumber(ctx)
+		if err == nil && new(big.Int).SetUint64(head).Cmp(blockNumber) >= 0 {
+			return nil
+		}
+		// Wait for the next round.
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return fmt.Errorf("failed to wait for block %s: %w", blockNumber, err)
+			}
+			return ctx.Err()
+		case <-queryTicker.C:
+		}
+	}
+}
+
+// readBlock returns the block number at which the read is performed, applying the read consistency
+// requirements of the context to reads at the latest block.
+func (c *BaseClient) readBlock(ctx context.Context, blockNumber *big.Int) (*big.Int, error) {
+	if blockNumber != nil {
+		return blockNumber, nil
+	}
+	consistency, ok := ctx.Value(readConsistencyKey{}).(readConsistency)
+	if !ok || consistency.block == nil {
+		return nil, nil
+	}
+	if err := c.WaitForBlock(ctx, consistency.block); err != nil {
+		return nil, err
+	}
+	if consistency.pin {
+		return new(big.Int).Set(consistency.block), nil
+	}
+	return nil, nil
+}
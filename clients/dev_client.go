@@ -0,0 +1,120 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"math/big"
+)
+
+// ErrSnapshotNotFound is returned by DevClient.Revert when the node does not know the snapshot,
+// e.g. because it has already been reverted to.
+var ErrSnapshotNotFound = errors.New("snapshot not found")
+
+// DevClient extends Client with the methods manipulating the state of the local development node,
+// such as era_test_node or anvil-zksync, using the anvil and hardhat compatible RPC methods. These methods
+// are not available on public networks.
+type DevClient struct {
+	Client
+	rpcClient *rpc.Client
+}
+
+// NewDevClient creates an instance of DevClient using the client, which must be connected to the node
+// through the RPC client.
+func NewDevClient(client Client) (*DevClient, error) {
+	if client == nil || client.Client() == nil {
+		return nil, errors.New("client must be connected to the node through the RPC client")
+	}
+	return &DevClient{Client: client, rpcClient: client.Client()}, nil
+}
+
+// DialDevClient connects the DevClient to the given URL with context.
+func DialDevClient(ctx context.Context, rawUrl string) (*DevClient, error) {
+	client, err := DialContext(ctx, rawUrl)
+	if err != nil {
+		return nil, err
+	}
+	return NewDevClient(client)
+}
+
+// Snapshot snapshots the state of the chain, and returns the ID of the snapshot, which can be used
+// to revert the chain to the snapshot using Revert.
+func (c *DevClient) Snapshot(ctx context.Context) (string, error) {
+	var id string
+	if err := c.rpcClient.CallContext(ctx, &id, "evm_snapshot"); err != nil {
+		return "", fmt.Errorf("failed to query evm_snapshot: %w", err)
+	}
+	return id, nil
+}
+
+// Revert reverts the state of the chain to the snapshot. The snapshot, and all snapshots taken after it,
+// are removed, so a new snapshot must be taken to revert to the same state again.
+func (c *DevClient) Revert(ctx context.Context, id string) error {
+	var reverted bool
+	if err := c.rpcClient.CallContext(ctx, &reverted, "evm_revert", id); err != nil {
+		return fmt.Errorf("failed to query evm_revert: %w", err)
+	}
+	if !reverted {
+		return fmt.Errorf("%w: %s", ErrSnapshotNotFound, id)
+	}
+	// Cached values, such as the addresses of contracts deployed after the snapshot, may be no longer valid.
+	c.InvalidateCache()
+	return nil
+}
+
+// SetNextBlockTimestamp sets the timestamp of the next block. Blocks mined afterwards have increasing
+// timestamps starting from it.
+func (c *DevClient) SetNextBlockTimestamp(ctx context.Context, timestamp uint64) error {
+	if err := c.rpcClient.CallContext(ctx, nil, "evm_setNextBlockTimestamp", hexutil.Uint64(timestamp)); err != nil {
+		return fmt.Errorf("failed to query evm_setNextBlockTimestamp: %w", err)
+	}
+	return nil
+}
+
+// IncreaseTime increases the timestamp of the next block by the number of seconds.
+func (c *DevClient) IncreaseTime(ctx context.Context, seconds uint64) error {
+	if err := c.rpcClient.CallContext(ctx, nil, "evm_increaseTime", hexutil.Uint64(seconds)); err != nil {
+		return fmt.Errorf("failed to query evm_increaseTime: %w", err)
+	}
+	return nil
+}
+
+// Mine mines the number of empty blocks.
+func (c *DevClient) Mine(ctx context.Context, blocks uint64) error {
+	if blocks == 0 {
+		return nil
+	}
+	if err := c.rpcClient.CallContext(ctx, nil, "hardhat_mine", hexutil.Uint64(blocks)); err != nil {
+		return fmt.Errorf("failed to query hardhat_mine: %w", err)
+	}
+	return nil
+}
+
+// SetBalance sets the balance of the base token of the account.
+func (c *DevClient) SetBalance(ctx context.Context, account common.Address, balance *big.Int) error {
+	if err := c.rpcClient.CallContext(ctx, nil, "hardhat_setBalance", account, (*hexutil.Big)(balance)); err != nil {
+		return fmt.Errorf("failed to query hardhat_setBalance: %w", err)
+	}
+	return nil
+}
+
+// ImpersonateAccount starts impersonating the account, so that the node accepts transactions sent from it
+// without verifying their signatures. Any account can be impersonated, including contracts, e.g. to act
+// as the holder of tokens on the forked network. See accounts.ImpersonatedSigner for sending such transactions.
+func (c *DevClient) ImpersonateAccount(ctx context.Context, account common.Address) error {
+	if err := c.rpcClient.CallContext(ctx, nil, "hardhat_impersonateAccount", account); err != nil {
+		return fmt.Errorf("failed to query hardhat_impersonateAccount: %w", err)
+	}
+	return nil
+}
+
+// StopImpersonatingAccount stops impersonating the account.
+func (c *DevClient) StopImpersonatingAccount(ctx context.Context, account common.Address) error {
+	if err := c.rpcClient.CallContext(ctx, nil, "hardhat_stopImpersonatingAccount", account); err != nil {
+		return fmt.Errorf("failed to query hardhat_stopImpersonatingAccount: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/rpc"
+	"strings"
+	"sync"
+)
+
+// BroadcastErrorKind is the class of the error returned by the node when a transaction is sent.
+type BroadcastErrorKind int
+
+const (
+	BroadcastErrorUnknown           BroadcastErrorKind = iota // The error is not recognized.
+	BroadcastNonceTooLow                                      // The nonce has already been used by an included transaction.
+	BroadcastAlreadyKnown                                     // The same transaction is already in the mempool.
+	BroadcastReplacementUnderpriced                           // The fees are not high enough to replace a pending transaction.
+	BroadcastInsufficientFunds                                // The account cannot pay for the value and fees of the transaction.
+	BroadcastTxExpired                                        // The transaction has not been accepted in time, and must be sent again.
+)
+
+func (k BroadcastErrorKind) String() string {
+	switch k {
+	case BroadcastErrorUnknown:
+		return "unknown"
+	case BroadcastNonceTooLow:
+		return "nonce too low"
+	case BroadcastAlreadyKnown:
+		return "already known"
+	case BroadcastReplacementUnderpriced:
+		return "replacement underpriced"
+	case BroadcastInsufficientFunds:
+		return "insufficient funds"
+	case BroadcastTxExpired:
+		return "transaction expired"
+	default:
+		return fmt.Sprintf("BroadcastErrorKind(%d)", int(k))
+	}
+}
+
+// Retryable reports whether sending the transaction again, possibly with increased fees, can succeed.
+func (k BroadcastErrorKind) Retryable() bool {
+	return k == BroadcastReplacementUnderpriced || k == BroadcastTxExpired
+}
+
+// ErrorClassifier classifies the errors returned by nodes when transactions are sent, by matching the messages
+// of errors against the patterns of each class. Since nodes and RPC providers phrase errors differently,
+// patterns can be added for the messages of the provider in use. It is safe for concurrent use.
+type ErrorClassifier struct {
+	mu       sync.RWMutex
+	patterns []errorPattern
+	codes    map[int]BroadcastErrorKind
+}
+
+type errorPattern struct {
+	kind    BroadcastErrorKind
+	pattern string // Lowercase substring of the error message.
+}
+
+// DefaultErrorClassifier is the ErrorClassifier used by ClassifyBroadcastError. It recognizes the messages of
+// zkSync Era and go-ethereum nodes; patterns added to it affect the whole process.
+var DefaultErrorClassifier = NewErrorClassifier()
+
+// NewErrorClassifier creates an instance of ErrorClassifier recognizing the messages of zkSync Era
+// and go-ethereum nodes.
+func NewErrorClassifier() *ErrorClassifier {
+	c := &ErrorClassifier{codes: make(map[int]BroadcastErrorKind)}
+	for _, p := range []struct {
+		kind     BroadcastErrorKind
+		patterns []string
+	}{
+		{BroadcastNonceTooLow, []string{"nonce too low", "nonce has already been used"}},
+		{BroadcastAlreadyKnown, []string{"already known", "known transaction", "already imported", "already in mempool"}},
+		{BroadcastReplacementUnderpriced, []string{"replacement transaction underpriced", "replacement underpriced"}},
+		{BroadcastInsufficientFunds, []string{"insufficient funds", "not enough balance"}},
+		{BroadcastTxExpired, []string{"transaction expired", "tx expired"}},
+	} {
+		for _, pattern := range p.patterns {
+			c.AddPattern(p.kind, pattern)
+		}
+	}
+	return c
+}
+
+// AddPattern adds the pattern of the class, which is matched case-insensitively as a substring of
+// the error message. Patterns added later take precedence over the earlier ones.
+func (c *ErrorClassifier) AddPattern(kind BroadcastErrorKind, pattern string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.patterns = append([]errorPattern{{kind: kind, pattern: strings.ToLower(pattern)}}, c.patterns...)
+}
+
+// AddCode adds the JSON-RPC error code of the class. Codes take precedence over patterns.
+func (c *ErrorClassifier) AddCode(kind BroadcastErrorKind, code int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.codes[code] = kind
+}
+
+// Classify returns the class of the error. BroadcastErrorUnknown is returned if the error
+// is nil or not recognized.
+func (c *ErrorClassifier) Classify(err error) BroadcastErrorKind {
+	if err == nil {
+		return BroadcastErrorUnknown
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		if kind, ok := c.codes[rpcErr.ErrorCode()]; ok {
+			return kind
+		}
+	}
+	message := strings.ToLower(err.Error())
+	for _, p := range c.patterns {
+		if strings.Contains(message, p.pattern) {
+			return p.kind
+		}
+	}
+	return BroadcastErrorUnknown
+}
+
+// ClassifyBroadcastError returns the class of the error returned by the node when a transaction is sent,
+// using DefaultErrorClassifier.
+func ClassifyBroadcastError(err error) BroadcastErrorKind {
+	return DefaultErrorClassifier.Classify(err)
+}
@@ -0,0 +1,220 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"math/big"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ErrImplausibleFee is recorded in EstimationReport.Errors when the estimated fee is out of the plausible
+// bounds set by FeeFallback.
+var ErrImplausibleFee = errors.New("implausible fee estimate")
+
+// EstimationPath is the way the fee has been estimated by EstimateFeeWithFallback.
+type EstimationPath string
+
+const (
+	EstimationPathEstimateFee EstimationPath = "zks_estimateFee" // The fee estimated by zks_estimateFee.
+	EstimationPathEstimateGas EstimationPath = "eth_estimateGas" // The gas limit estimated by eth_estimateGas.
+	EstimationPathStatic      EstimationPath = "static"          // The gas limit set by FeeFallback.StaticGasLimit.
+)
+
+// FeeFallback configures the fallbacks of EstimateFeeWithFallback, which are used when zks_estimateFee fails
+// with the transient error, such as the timeout or the rate limit of the node, or returns the implausible fee.
+// The fallbacks are tried in the order of the fields. The revert of the transaction is never recovered from,
+// since the fallbacks would fail as well.
+type FeeFallback struct {
+	// Retries is the number of times zks_estimateFee is called again after the transient error. Optional,
+	// it is not retried by default.
+	Retries int
+	// RetryBackoff is the delay before the first retry, doubled before each following one. Defaults to 500ms.
+	RetryBackoff time.Duration
+	// EstimateGas enables the estimation of the gas limit using eth_estimateGas, with the fee cap from
+	// eth_gasPrice, with a margin, and the default gas per pubdata limit.
+	EstimateGas bool
+	// StaticGasLimit is the gas limit used, with the fee cap from eth_gasPrice, with a margin, when the estimations fail.
+	// Optional.
+	StaticGasLimit uint64
+	// MinGasLimit and MaxGasLimit bound the plausible gas limit. If no path provides the plausible fee,
+	// the gas limit of the implausible one is clamped to the bounds. The gas limit clamped down to MaxGasLimit
+	// is likely to be insufficient, so the fee is returned along with the error wrapping ErrImplausibleFee.
+	// Optional, only the zero gas limit and the zero fee cap are implausible by default.
+	MinGasLimit uint64
+	MaxGasLimit uint64
+}
+
+// EstimationReport explains how the fee has been estimated by EstimateFeeWithFallback.
+type EstimationReport struct {
+	Path     EstimationPath // The path which has provided the fee.
+	Attempts int            // Number of calls of zks_estimateFee, including the retries.
+	Errors   []error        // Errors of the failed attempts and paths, in the order they have occurred.
+	Clamped  bool           // Whether the gas limit has been clamped to FeeFallback.MinGasLimit or MaxGasLimit.
+}
+
+// staticFeeMarginPercent is the headroom added to eth_gasPrice to get the fee cap of the static fee, so that
+// the transaction is not stalled if the base fee rises before it is included. Only the base fee is charged.
+const staticFeeMarginPercent = 50
+
+// EstimateFeeWithFallback estimates the fee of the transaction using zks_estimateFee, falling back to
+// the strategies configured by the fallback, and reports which of them has provided the fee. The fallback
+// is optional, in which case the fee of zks_estimateFee is returned unless it is implausible. If all strategies
+// fail, the error joins the errors of the report, which is returned along with it. If the gas limit has been
+// clamped down to FeeFallback.MaxGasLimit, the clamped fee is returned with the error wrapping ErrImplausibleFee.
+func EstimateFeeWithFallback(ctx context.Context, client Client, msg zkTypes.CallMsg, fallback *FeeFallback) (*zkTypes.Fee, *EstimationReport, error) {
+	if fallback == nil {
+		fallback = &FeeFallback{}
+	}
+	report := &EstimationReport{}
+	var implausible *zkTypes.Fee // The first implausible fee, clamped if no path provides the plausible one.
+	accept := func(path EstimationPath, fee *zkTypes.Fee) bool {
+		if err := fallback.check(fee); err != nil {
+			report.Errors = append(report.Errors, fmt.Errorf("%s: %w", path, err))
+			if implausible == nil && fee.GasLimit != nil && fee.GasLimit.ToInt().Sign() > 0 &&
+				fee.MaxFeePerGas != nil && fee.MaxFeePerGas.ToInt().Sign() > 0 {
+				implausible, report.Path = fee, path
+			}
+			return false
+		}
+		report.Path = path
+		return true
+	}
+
+	backoff := fallback.RetryBackoff
+	if backoff <= 0 {
+		backoff = 500 * time.Millisecond
+	}
+	for {
+		report.Attempts++
+		fee, err := client.EstimateFee(ctx, msg)
+		if err == nil {
+			if accept(EstimationPathEstimateFee, fee) {
+				return fee, report, nil
+			}
+			break
+		}
+		report.Errors = append(report.Errors, err)
+		if ctx.Err() != nil || !isTransientError(err) {
+			return nil, report, err
+		}
+		if report.Attempts > fallback.Retries {
+			break
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, report, ctx.Err()
+		}
+		backoff *= 2
+	}
+
+	if fallback.EstimateGas {
+		gas, err := client.EstimateGasL2(ctx, msg)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Errorf("%s: %w", EstimationPathEstimateGas, err))
+			if ctx.Err() != nil || !isTransientError(err) {
+				return nil, report, err
+			}
+		} else {
+			fee, err := staticFee(ctx, client, gas)
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Errorf("%s: %w", EstimationPathEstimateGas, err))
+			} else if accept(EstimationPathEstimateGas, fee) {
+				return fee, report, nil
+			}
+		}
+	}
+
+	if fallback.StaticGasLimit > 0 {
+		fee, err := staticFee(ctx, client, fallback.StaticGasLimit)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Errorf("%s: %w", EstimationPathStatic, err))
+		} else {
+			// The static gas limit is configured, so it is used even if it is out of bounds.
+			report.Path = EstimationPathStatic
+			return fee, report, nil
+		}
+	}
+
+	if implausible != nil {
+		gas := implausible.GasLimit.ToInt()
+		var err error
+		if fallback.MinGasLimit > 0 && gas.Uint64() < fallback.MinGasLimit {
+			gas = new(big.Int).SetUint64(fallback.MinGasLimit)
+		}
+		if fallback.MaxGasLimit > 0 && (!gas.IsUint64() || gas.Uint64() > fallback.MaxGasLimit) {
+			err = fmt.Errorf("%w: gas limit %s clamped down to %d", ErrImplausibleFee, gas, fallback.MaxGasLimit)
+			gas = new(big.Int).SetUint64(fallback.MaxGasLimit)
+		}
+		clamped := *implausible
+		clamped.GasLimit = (*hexutil.Big)(gas)
+		report.Clamped = true
+		return &clamped, report, err
+	}
+	report.Path = ""
+	return nil, report, errors.Join(report.Errors...)
+}
+
+// check returns ErrImplausibleFee if the fee is out of the bounds.
+func (f *FeeFallback) check(fee *zkTypes.Fee) error {
+	if fee.GasLimit == nil || fee.GasLimit.ToInt().Sign() <= 0 {
+		return fmt.Errorf("%w: zero gas limit", ErrImplausibleFee)
+	}
+	if fee.MaxFeePerGas == nil || fee.MaxFeePerGas.ToInt().Sign() <= 0 {
+		return fmt.Errorf("%w: zero fee cap", ErrImplausibleFee)
+	}
+	gas := fee.GasLimit.ToInt()
+	if f.MinGasLimit > 0 && gas.Cmp(new(big.Int).SetUint64(f.MinGasLimit)) < 0 {
+		return fmt.Errorf("%w: gas limit %s below %d", ErrImplausibleFee, gas, f.MinGasLimit)
+	}
+	if f.MaxGasLimit > 0 && gas.Cmp(new(big.Int).SetUint64(f.MaxGasLimit)) > 0 {
+		return fmt.Errorf("%w: gas limit %s above %d", ErrImplausibleFee, gas, f.MaxGasLimit)
+	}
+	return nil
+}
+
+// staticFee returns the fee with the gas limit, the fee cap from eth_gasPrice increased by
+// staticFeeMarginPercent and no tip, as estimated by zks_estimateFee.
+func staticFee(ctx context.Context, client Client, gas uint64) (*zkTypes.Fee, error) {
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+	feeCap := new(big.Int).Mul(gasPrice, big.NewInt(100+staticFeeMarginPercent))
+	feeCap.Div(feeCap, big.NewInt(100))
+	return &zkTypes.Fee{
+		GasLimit:             (*hexutil.Big)(new(big.Int).SetUint64(gas)),
+		GasPerPubdataLimit:   (*hexutil.Big)(new(big.Int).Set(utils.DefaultGasPerPubdataLimit)),
+		MaxFeePerGas:         (*hexutil.Big)(feeCap),
+		MaxPriorityFeePerGas: (*hexutil.Big)(big.NewInt(0)),
+	}, nil
+}
+
+// isTransientError reports whether the call can succeed if made again, e.g. when the node is overloaded
+// or unreachable, as opposed to the revert of the transaction.
+func isTransientError(err error) bool {
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= http.StatusInternalServerError
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		// Internal error and limit exceeded, returned by the overloaded nodes and RPC providers.
+		return rpcErr.ErrorCode() == -32603 || rpcErr.ErrorCode() == -32005
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package clients_test
+
+import (
+	"context"
+	"errors"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/zksync-sdk/zksync2-go/clients"
+	"github.com/zksync-sdk/zksync2-go/clients/clientsmock"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func fee(gas, maxFeePerGas int64) *zkTypes.Fee {
+	return &zkTypes.Fee{
+		GasLimit:             (*hexutil.Big)(big.NewInt(gas)),
+		GasPerPubdataLimit:   (*hexutil.Big)(big.NewInt(50_000)),
+		MaxFeePerGas:         (*hexutil.Big)(big.NewInt(maxFeePerGas)),
+		MaxPriorityFeePerGas: (*hexutil.Big)(big.NewInt(0)),
+	}
+}
+
+// response is the result of the mocked method, either the value or the error.
+type response struct {
+	value interface{}
+	err   error
+}
+
+func TestEstimateFeeWithFallback(t *testing.T) {
+	unavailable := rpc.HTTPError{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable"}
+	reverted := errors.New("execution reverted")
+	tests := []struct {
+		name         string
+		estimateFee  []response
+		estimateGas  []response
+		fallback     *clients.FeeFallback
+		wantPath     clients.EstimationPath
+		wantAttempts int
+		wantGas      int64
+		wantFeeCap   int64
+		wantClamped  bool
+		wantErr      error
+		wantAnyErr   bool // Whether any error is expected, when the joined errors are returned.
+	}{
+		{
+			name:         "estimated fee",
+			estimateFee:  []response{{value: fee(100_000, 1_000)}},
+			wantPath:     clients.EstimationPathEstimateFee,
+			wantAttempts: 1,
+			wantGas:      100_000,
+			wantFeeCap:   1_000,
+		},
+		{
+			name:         "retried after transient error",
+			estimateFee:  []response{{err: unavailable}, {value: fee(100_000, 1_000)}},
+			fallback:     &clients.FeeFallback{Retries: 1, RetryBackoff: time.Millisecond},
+			wantPath:     clients.EstimationPathEstimateFee,
+			wantAttempts: 2,
+			wantGas:      100_000,
+			wantFeeCap:   1_000,
+		},
+		{
+			name:         "revert is not recovered from",
+			estimateFee:  []response{{err: reverted}},
+			fallback:     &clients.FeeFallback{Retries: 1, EstimateGas: true, StaticGasLimit: 500_000},
+			wantAttempts: 1,
+			wantErr:      reverted,
+		},
+		{
+			name:         "eth_estimateGas after retries",
+			estimateFee:  []response{{err: unavailable}},
+			estimateGas:  []response{{value: uint64(200_000)}},
+			fallback:     &clients.FeeFallback{Retries: 1, RetryBackoff: time.Millisecond, EstimateGas: true, StaticGasLimit: 500_000},
+			wantPath:     clients.EstimationPathEstimateGas,
+			wantAttempts: 2,
+			wantGas:      200_000,
+			wantFeeCap:   150,
+		},
+		{
+			name:         "static gas limit after eth_estimateGas",
+			estimateFee:  []response{{err: unavailable}},
+			estimateGas:  []response{{err: unavailable}},
+			fallback:     &clients.FeeFallback{EstimateGas: true, StaticGasLimit: 500_000},
+			wantPath:     clients.EstimationPathStatic,
+			wantAttempts: 1,
+			wantGas:      500_000,
+			wantFeeCap:   150,
+		},
+		{
+			name:         "implausible fee replaced by eth_estimateGas",
+			estimateFee:  []response{{value: fee(50_000_000, 1_000)}},
+			estimateGas:  []response{{value: uint64(200_000)}},
+			fallback:     &clients.FeeFallback{EstimateGas: true, MaxGasLimit: 1_000_000},
+			wantPath:     clients.EstimationPathEstimateGas,
+			wantAttempts: 1,
+			wantGas:      200_000,
+			wantFeeCap:   150,
+		},
+		{
+			name:         "implausible fee clamped up",
+			estimateFee:  []response{{value: fee(10_000, 1_000)}},
+			fallback:     &clients.FeeFallback{MinGasLimit: 21_000},
+			wantPath:     clients.EstimationPathEstimateFee,
+			wantAttempts: 1,
+			wantGas:      21_000,
+			wantFeeCap:   1_000,
+			wantClamped:  true,
+		},
+		{
+			name:         "implausible fee clamped down",
+			estimateFee:  []response{{value: fee(50_000_000, 1_000)}},
+			fallback:     &clients.FeeFallback{MaxGasLimit: 1_000_000},
+			wantPath:     clients.EstimationPathEstimateFee,
+			wantAttempts: 1,
+			wantGas:      1_000_000,
+			wantFeeCap:   1_000,
+			wantClamped:  true,
+			wantErr:      clients.ErrImplausibleFee,
+		},
+		{
+			name:         "all paths failed",
+			estimateFee:  []response{{err: unavailable}},
+			wantAttempts: 1,
+			wantAnyErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := clientsmock.NewClient().On("SuggestGasPrice", big.NewInt(100))
+			for _, r := range tt.estimateFee {
+				if r.err != nil {
+					client.OnError("EstimateFee", r.err)
+				} else {
+					client.On("EstimateFee", r.value)
+				}
+			}
+			for _, r := range tt.estimateGas {
+				if r.err != nil {
+					client.OnError("EstimateGasL2", r.err)
+				} else {
+					client.On("EstimateGasL2", r.value)
+				}
+			}
+
+			got, report, err := clients.EstimateFeeWithFallback(context.Background(), client, zkTypes.CallMsg{}, tt.fallback)
+			if tt.wantAnyErr {
+				if err == nil {
+					t.Fatal("EstimateFeeWithFallback() error = nil, want error")
+				}
+			} else if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("EstimateFeeWithFallback() error = %v, want %v", err, tt.wantErr)
+			}
+			if report.Path != tt.wantPath {
+				t.Errorf("Path = %q, want %q", report.Path, tt.wantPath)
+			}
+			if report.Attempts != tt.wantAttempts {
+				t.Errorf("Attempts = %d, want %d", report.Attempts, tt.wantAttempts)
+			}
+			if report.Clamped != tt.wantClamped {
+				t.Errorf("Clamped = %t, want %t", report.Clamped, tt.wantClamped)
+			}
+			if tt.wantGas == 0 {
+				if got != nil {
+					t.Errorf("EstimateFeeWithFallback() fee = %+v, want nil", got)
+				}
+				return
+			}
+			if got.GasLimit.ToInt().Cmp(big.NewInt(tt.wantGas)) != 0 {
+				t.Errorf("GasLimit = %s, want %d", got.GasLimit.ToInt(), tt.wantGas)
+			}
+			if got.MaxFeePerGas.ToInt().Cmp(big.NewInt(tt.wantFeeCap)) != 0 {
+				t.Errorf("MaxFeePerGas = %s, want %d", got.MaxFeePerGas.ToInt(), tt.wantFeeCap)
+			}
+		})
+	}
+}
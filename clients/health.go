@@ -0,0 +1,180 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"sync"
+	"time"
+)
+
+// HealthStatus represents the health state of a node as observed by HealthWatcher.
+type HealthStatus uint8
+
+const (
+	HealthUnknown      HealthStatus = iota // No check has been performed yet.
+	HealthHealthy                          // Node responds in time and its head block is recent.
+	HealthSyncing                          // Node reports that it is still syncing.
+	HealthLagging                          // Node responds, but its head block is older than allowed.
+	HealthUnresponsive                     // Node does not respond or responds too slowly.
+)
+
+func (s HealthStatus) String() string {
+	switch s {
+	case HealthHealthy:
+		return "healthy"
+	case HealthSyncing:
+		return "syncing"
+	case HealthLagging:
+		return "lagging"
+	case HealthUnresponsive:
+		return "unresponsive"
+	default:
+		return "unknown"
+	}
+}
+
+// HealthReport contains the result of a single node health check.
+type HealthReport struct {
+	Status      HealthStatus           // Overall status derived from the check.
+	BlockNumber uint64                 // Number of the latest block known to the node.
+	BlockTime   time.Time              // Timestamp of the latest block known to the node.
+	BlockLag    time.Duration          // Difference between the wall clock and BlockTime.
+	Latency     time.Duration          // Time it took the node to respond to the check.
+	Sync        *ethereum.SyncProgress // Sync progress if the node is syncing, nil otherwise.
+	CheckedAt   time.Time              // Time when the check has been performed.
+	Err         error                  // Error that occurred during the check, if any.
+}
+
+// HealthWatcherOptions contains the options used by HealthWatcher.
+type HealthWatcherOptions struct {
+	Interval    time.Duration // Interval between two checks. Defaults to 5 seconds.
+	MaxBlockAge time.Duration // Maximum allowed age of the latest block. Defaults to 1 minute.
+	MaxLatency  time.Duration // Maximum allowed response time of the node. Defaults to 5 seconds.
+}
+
+// HealthWatcher periodically checks the responsiveness of the node and the lag of its latest block
+// against the wall clock, and notifies the subscribers whenever the health status changes.
+// It is intended to be used for failover orchestration and readiness probes.
+type HealthWatcher struct {
+	client EthereumClient
+	opts   HealthWatcherOptions
+
+	mu   sync.RWMutex
+	last *HealthReport
+}
+
+// NewHealthWatcher creates an instance of HealthWatcher for the given client.
+// The opts parameter is optional; if not provided, default options are used.
+func NewHealthWatcher(client EthereumClient, opts *HealthWatcherOptions) *HealthWatcher {
+	o := HealthWatcherOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Interval <= 0 {
+		o.Interval = 5 * time.Second
+	}
+	if o.MaxBlockAge <= 0 {
+		o.MaxBlockAge = time.Minute
+	}
+	if o.MaxLatency <= 0 {
+		o.MaxLatency = 5 * time.Second
+	}
+	return &HealthWatcher{
+		client: client,
+		opts:   o,
+	}
+}
+
+// Status returns the report of the most recent check, or a report with
+// HealthUnknown status if no check has been performed yet.
+func (w *HealthWatcher) Status() HealthReport {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.last == nil {
+		return HealthReport{Status: HealthUnknown}
+	}
+	return *w.last
+}
+
+// Check performs a single health check and stores its result as the latest status.
+func (w *HealthWatcher) Check(ctx context.Context) HealthReport {
+	report := w.check(ctx)
+	w.mu.Lock()
+	w.last = &report
+	w.mu.Unlock()
+	return report
+}
+
+// Watch performs health checks periodically and sends a report to the given channel
+// every time the health status changes. The first report is always sent.
+// It blocks until the context is canceled and returns the context error.
+func (w *HealthWatcher) Watch(ctx context.Context, ch chan<- HealthReport) error {
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+
+	previous := HealthUnknown
+	for {
+		report := w.Check(ctx)
+		if report.Status != previous {
+			previous = report.Status
+			select {
+			case ch <- report:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		// Wait for the next round.
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *HealthWatcher) check(ctx context.Context) HealthReport {
+	checkCtx, cancel := context.WithTimeout(ctx, w.opts.MaxLatency)
+	defer cancel()
+
+	started := time.Now()
+	report := HealthReport{CheckedAt: started}
+
+	head, err := w.client.HeaderByNumber(checkCtx, nil)
+	report.Latency = time.Since(started)
+	if err != nil {
+		report.Status = HealthUnresponsive
+		report.Err = fmt.Errorf("failed to get latest block header: %w", err)
+		return report
+	}
+	if head == nil || head.Number == nil {
+		report.Status = HealthUnresponsive
+		report.Err = errors.New("node returned empty block header")
+		return report
+	}
+	report.BlockNumber = head.Number.Uint64()
+	report.BlockTime = time.Unix(int64(head.Time), 0)
+	report.BlockLag = started.Sub(report.BlockTime)
+
+	progress, err := w.client.SyncProgress(checkCtx)
+	report.Latency = time.Since(started)
+	if err != nil {
+		report.Status = HealthUnresponsive
+		report.Err = fmt.Errorf("failed to get sync progress: %w", err)
+		return report
+	}
+
+	switch {
+	case report.Latency > w.opts.MaxLatency:
+		report.Status = HealthUnresponsive
+	case progress != nil:
+		report.Status = HealthSyncing
+		report.Sync = progress
+	case report.BlockLag > w.opts.MaxBlockAge:
+		report.Status = HealthLagging
+	default:
+		report.Status = HealthHealthy
+	}
+	return report
+}
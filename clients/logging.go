@@ -0,0 +1,113 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// loggingTransport is an http.RoundTripper logging JSON-RPC requests at debug level.
+type loggingTransport struct {
+	base   http.RoundTripper
+	logger *slog.Logger
+}
+
+// withLoggingTransport wraps the HTTP client, so that its requests are logged.
+func withLoggingTransport(client *http.Client, logger *slog.Logger) *http.Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	cl := *client
+	base := cl.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	cl.Transport = &loggingTransport{base: base, logger: logger}
+	return &cl
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if !t.logger.Enabled(ctx, slog.LevelDebug) {
+		return t.base.RoundTrip(req)
+	}
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	methods := make([]string, 0, 1)
+	for _, r := range decodeRequests(body) {
+		methods = append(methods, r.Method)
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.logger.DebugContext(ctx, "RPC request failed", "methods", methods, "duration", time.Since(start), "err", err)
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	attrs := []interface{}{"methods", methods, "duration", time.Since(start), "status", resp.StatusCode}
+	var single struct {
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(respBody, &single) == nil && single.Error != nil {
+		attrs = append(attrs, "code", single.Error.Code, "err", single.Error.Message)
+	}
+	t.logger.DebugContext(ctx, "RPC request", attrs...)
+	return resp, nil
+}
+
+// decodeRequests decodes the single or batch JSON-RPC request. Invalid requests are ignored.
+func decodeRequests(body []byte) []chaosRequest {
+	var requests []chaosRequest
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		_ = json.Unmarshal(trimmed, &requests)
+	} else {
+		var single chaosRequest
+		if json.Unmarshal(trimmed, &single) == nil {
+			requests = []chaosRequest{single}
+		}
+	}
+	return requests
+}
+
+// log logs the message using the logger of the client, if configured.
+func (c *BaseClient) log(ctx context.Context, level slog.Level, msg string, args ...interface{}) {
+	if c.logger != nil {
+		c.logger.Log(ctx, level, msg, args...)
+	}
+}
+
+// logSent logs the result of the transaction submission.
+func (c *BaseClient) logSent(ctx context.Context, hash common.Hash, err error) {
+	if err != nil {
+		// The hash is not known if the raw transaction has been rejected.
+		if hash == (common.Hash{}) {
+			c.log(ctx, slog.LevelWarn, "Failed to send transaction", "err", err)
+		} else {
+			c.log(ctx, slog.LevelWarn, "Failed to send transaction", "hash", hash, "err", err)
+		}
+		return
+	}
+	c.log(ctx, slog.LevelInfo, "Transaction sent", "hash", hash)
+}
+
+// logEstimate logs the result of the gas estimation.
+func (c *BaseClient) logEstimate(ctx context.Context, method string, gas uint64, err error) {
+	if err != nil {
+		c.log(ctx, slog.LevelDebug, "Gas estimation failed", "method", method, "err", err)
+		return
+	}
+	c.log(ctx, slog.LevelDebug, "Gas estimated", "method", method, "gas", gas)
+}
@@ -0,0 +1,143 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ClientOptions contains options used when connecting a client to the node.
+type ClientOptions struct {
+	Auth       Auth         // Authentication scheme applied to every request. Optional.
+	Headers    http.Header  // Additional HTTP headers sent with every request. Optional.
+	HTTPClient *http.Client // HTTP client used for HTTP(S) endpoints. Optional, http.DefaultClient is used by default.
+	// Timeout applied to every RPC call whose context has no deadline. It can be overridden
+	// per call using WithTimeout. Zero value means no timeout.
+	DefaultTimeout time.Duration
+	// URL of the private (protected) transaction submission endpoint, used by transactions sent
+	// in privacy mode. See WithPrivacyMode. Optional.
+	PrivateBroadcastURL string
+	// PollInterval is the interval in which methods waiting for the chain progress, such as WaitMined,
+	// poll the node. Defaults to 1 second.
+	PollInterval time.Duration
+	// AdaptivePolling enables tuning of the poll interval to the observed block time of the chain, so that
+	// fast devnets are polled more often than networks with longer block time. PollInterval is used
+	// when the block time cannot be measured.
+	AdaptivePolling bool
+	// Chaos configures failures injected by the client for resilience testing. Optional, it must not be
+	// used in production.
+	Chaos *Chaos
+	// ErrorDecoder decodes the revert data of failed calls and gas estimations, which are returned
+	// as utils.RevertError. Optional, utils.DefaultErrorDecoder is used by default.
+	ErrorDecoder *utils.ErrorDecoder
+	// Bridges contains the custom bridges, such as the wstETH bridge, consulted by L2TokenAddress and
+	// L1TokenAddress before the default bridge. Optional.
+	Bridges *BridgeRegistry
+	// Logger receives the structured logs of the client: transaction submissions at info level, and RPC
+	// requests, gas estimations and waiting for transactions at debug level. RPC requests are logged only
+	// for HTTP(S) endpoints. Optional, nothing is logged by default.
+	Logger *slog.Logger
+	// ResubscribeBackoff enables automatic reconnection of the head and log subscriptions dropped by the node,
+	// e.g. when the WebSocket connection is lost, and is the maximum backoff between the attempts. Once
+	// reconnected, the heads and logs emitted since the last delivered one are backfilled before the live
+	// delivery resumes, so that they are delivered at least once. Optional, the dropped subscriptions
+	// fail by default.
+	ResubscribeBackoff time.Duration
+}
+
+type timeoutKey struct{}
+
+// WithTimeout returns a context which instructs the client to apply the given timeout to each RPC call
+// made with it, instead of ClientOptions.DefaultTimeout. The timeout is applied only if the context has
+// no deadline. For methods which issue multiple RPC calls, such as WaitMined, the timeout is applied to
+// every call separately. Zero timeout disables the default timeout.
+func WithTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, timeoutKey{}, timeout)
+}
+
+// DialContextWithOptions connects a client to the given URL with context using the provided options.
+func DialContextWithOptions(ctx context.Context, rawUrl string, opts *ClientOptions) (Client, error) {
+	var chaos *chaosInjector
+	if opts != nil {
+		chaos = newChaosInjector(opts.Chaos)
+	}
+	rpcOpts, err := opts.rpcOptions(rawUrl, chaos)
+	if err != nil {
+		return nil, err
+	}
+	c, err := rpc.DialOptions(ctx, rawUrl, rpcOpts...)
+	if err != nil {
+		return nil, err
+	}
+	client := newBaseClient(c, opts)
+	client.chaos = chaos
+	if opts != nil && opts.PrivateBroadcastURL != "" {
+		privateOpts, err := opts.rpcOptions(opts.PrivateBroadcastURL, chaos)
+		if err != nil {
+			c.Close()
+			return nil, err
+		}
+		client.privateRpcClient, err = rpc.DialOptions(ctx, opts.PrivateBroadcastURL, privateOpts...)
+		if err != nil {
+			c.Close()
+			return nil, fmt.Errorf("failed to connect to private broadcast endpoint: %w", err)
+		}
+	}
+	return client, nil
+}
+
+func (o *ClientOptions) rpcOptions(rawUrl string, chaos *chaosInjector) ([]rpc.ClientOption, error) {
+	if o == nil {
+		return nil, nil
+	}
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, err
+	}
+	isHTTP := u.Scheme == "http" || u.Scheme == "https"
+
+	var opts []rpc.ClientOption
+	if len(o.Headers) > 0 {
+		opts = append(opts, rpc.WithHeaders(o.Headers))
+	}
+	httpClient := o.HTTPClient
+	if o.Auth != nil {
+		if isHTTP {
+			// HTTP requests are authenticated by the transport, which is also able
+			// to refresh credentials and retry the request on 401 response.
+			httpClient = withAuthTransport(httpClient, o.Auth)
+		} else {
+			opts = append(opts, rpc.WithHTTPAuth(o.Auth.Apply))
+		}
+	}
+	if chaos != nil && isHTTP {
+		httpClient = chaos.transport(httpClient)
+	}
+	if o.Logger != nil && isHTTP {
+		httpClient = withLoggingTransport(httpClient, o.Logger)
+	}
+	if httpClient != nil && isHTTP {
+		opts = append(opts, rpc.WithHTTPClient(httpClient))
+	}
+	return opts, nil
+}
+
+// withTimeout applies the per-call or default timeout to the context if it has no deadline.
+func (c *BaseClient) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	timeout := c.defaultTimeout
+	if t, ok := ctx.Value(timeoutKey{}).(time.Duration); ok {
+		timeout = t
+	}
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
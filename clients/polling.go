@@ -0,0 +1,105 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+)
+
+const (
+	defaultPollInterval = time.Second
+
+	minAdaptivePollInterval = 100 * time.Millisecond
+	maxAdaptivePollInterval = 10 * time.Second
+	// blockTimeSampleSize is the number of recent blocks whose average time is used by adaptive polling,
+	// which compensates for the one-second resolution of block timestamps.
+	blockTimeSampleSize = 20
+	// blockTimeRefreshInterval is the time after which the block time is measured again.
+	blockTimeRefreshInterval = 5 * time.Minute
+)
+
+// poller determines the interval in which methods waiting for the chain progress, such as WaitMined,
+// poll the node.
+type poller struct {
+	interval time.Duration // Fixed interval, or fallback interval if adaptive polling is enabled.
+	adaptive bool
+
+	mu         sync.Mutex // Guards the fields below, but is not held while the block time is measured.
+	blockTime  time.Duration
+	measuredAt time.Time
+	measuring  bool // Whether the block time is being measured, in which case the last one is used meanwhile.
+}
+
+// pollInterval returns the interval in which the node is polled. With adaptive polling, it is half
+// of the observed block time, so that the awaited block is noticed soon after it is produced.
+func (c *BaseClient) pollInterval(ctx context.Context) time.Duration {
+	interval := c.poller.interval
+	if interval == 0 {
+		interval = defaultPollInterval
+	}
+	if !c.poller.adaptive {
+		return interval
+	}
+
+	c.poller.mu.Lock()
+	stale := c.poller.measuredAt.IsZero() || time.Since(c.poller.measuredAt) > blockTimeRefreshInterval
+	measure := stale && !c.poller.measuring
+	if measure {
+		c.poller.measuring = true
+	}
+	c.poller.mu.Unlock()
+
+	if measure {
+		// The node is called without the lock, so that the slow node does not block the other waiters.
+		blockTime, err := c.measureBlockTime(ctx)
+		c.poller.mu.Lock()
+		c.poller.measuring = false
+		if err == nil {
+			c.poller.blockTime = blockTime
+			c.poller.measuredAt = time.Now()
+		}
+		c.poller.mu.Unlock()
+	}
+
+	c.poller.mu.Lock()
+	blockTime, measured := c.poller.blockTime, !c.poller.measuredAt.IsZero()
+	c.poller.mu.Unlock()
+	if !measured {
+		// The fixed interval is used until the block time is measured; afterwards, the last measured
+		// block time is preferred over it, even if measuring it again fails.
+		return interval
+	}
+
+	interval = blockTime / 2
+	if interval < minAdaptivePollInterval {
+		interval = minAdaptivePollInterval
+	} else if interval > maxAdaptivePollInterval {
+		interval = maxAdaptivePollInterval
+	}
+	return interval
+}
+
+// measureBlockTime returns the average time between recent blocks.
+func (c *BaseClient) measureBlockTime(ctx context.Context) (time.Duration, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	latest, err := c.ethClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	blocks := int64(blockTimeSampleSize)
+	if latest.Number.Int64() < blocks {
+		blocks = latest.Number.Int64()
+	}
+	if blocks == 0 {
+		return 0, errors.New("not enough blocks to measure block time")
+	}
+	earlier, err := c.ethClient.HeaderByNumber(ctx, new(big.Int).Sub(latest.Number, big.NewInt(blocks)))
+	if err != nil {
+		return 0, err
+	}
+	elapsed := time.Duration(latest.Time-earlier.Time) * time.Second
+	return elapsed / time.Duration(blocks), nil
+}
@@ -0,0 +1,58 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ErrPrivateBroadcastUnavailable is returned when a transaction is sent in privacy mode,
+// but the client has not been configured with ClientOptions.PrivateBroadcastURL.
+var ErrPrivateBroadcastUnavailable = errors.New("private broadcast endpoint is not configured")
+
+// PrivacyMode specifies how transactions are submitted to the network.
+type PrivacyMode uint8
+
+const (
+	// PrivacyModeOff submits transactions to the public endpoint.
+	PrivacyModeOff PrivacyMode = iota
+	// PrivacyModePrivate submits transactions only to the private endpoint,
+	// returning an error if the submission fails.
+	PrivacyModePrivate
+	// PrivacyModePreferPrivate submits transactions to the private endpoint, falling back to
+	// the public endpoint if the private endpoint is not configured or the submission fails.
+	PrivacyModePreferPrivate
+)
+
+type privacyModeKey struct{}
+
+// WithPrivacyMode returns a context which instructs the client to submit transactions sent with it
+// (using SendTransaction and SendRawTransaction) according to the privacy mode.
+// The private endpoint is configured using ClientOptions.PrivateBroadcastURL.
+func WithPrivacyMode(ctx context.Context, mode PrivacyMode) context.Context {
+	return context.WithValue(ctx, privacyModeKey{}, mode)
+}
+
+func privacyModeFrom(ctx context.Context) PrivacyMode {
+	mode, _ := ctx.Value(privacyModeKey{}).(PrivacyMode)
+	return mode
+}
+
+func (c *BaseClient) sendPrivateRawTransaction(ctx context.Context, tx []byte) (common.Hash, error) {
+	mode := privacyModeFrom(ctx)
+	err := ErrPrivateBroadcastUnavailable
+	if c.privateRpcClient != nil {
+		var res string
+		err = c.privateRpcClient.CallContext(ctx, &res, "eth_sendRawTransaction", hexutil.Encode(tx))
+		if err == nil {
+			return common.HexToHash(res), nil
+		}
+	}
+	if mode != PrivacyModePreferPrivate || ctx.Err() != nil {
+		return common.Hash{}, fmt.Errorf("failed to submit transaction privately: %w", err)
+	}
+	// Fall back to public submission.
+	return c.SendRawTransaction(WithPrivacyMode(ctx, PrivacyModeOff), tx)
+}
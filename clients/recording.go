@@ -0,0 +1,293 @@
+package clients
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// Interaction represents a single recorded JSON-RPC request and the response received from the node.
+type Interaction struct {
+	Request  json.RawMessage `json:"request"`  // JSON-RPC request, single or batch.
+	Response json.RawMessage `json:"response"` // JSON-RPC response, single or batch.
+}
+
+// RecordingTransport is an http.RoundTripper which forwards JSON-RPC requests to the node
+// and records the request/response pairs to a file, which can later be served by ReplayTransport.
+// It can be used with ClientOptions.HTTPClient to record the interaction of a test suite with the node.
+//
+// Each interaction is appended to the file as a JSON line as soon as it is recorded, so that the interactions
+// recorded before a crash are kept. The file is truncated by the first recorded interaction, and
+// RecordingTransport.Close should be called once the recording is finished.
+type RecordingTransport struct {
+	base http.RoundTripper
+	path string
+
+	mu           sync.Mutex
+	file         *os.File // Opened by the first recorded interaction.
+	interactions []Interaction
+}
+
+// NewRecordingTransport creates an instance of RecordingTransport which stores recorded interactions
+// in the file at the given path. The base transport is used to send requests;
+// if not provided, http.DefaultTransport is used.
+func NewRecordingTransport(path string, base http.RoundTripper) *RecordingTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RecordingTransport{
+		base: base,
+		path: path,
+	}
+}
+
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK && json.Valid(reqBody) && json.Valid(respBody) {
+		t.mu.Lock()
+		err = t.record(Interaction{Request: reqBody, Response: respBody})
+		t.mu.Unlock()
+		if err != nil {
+			return nil, fmt.Errorf("failed to save recorded interactions: %w", err)
+		}
+	}
+	return resp, nil
+}
+
+// Interactions returns all interactions recorded so far.
+func (t *RecordingTransport) Interactions() []Interaction {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	interactions := make([]Interaction, len(t.interactions))
+	copy(interactions, t.interactions)
+	return interactions
+}
+
+// Close closes the file with the recorded interactions.
+func (t *RecordingTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.file == nil {
+		return nil
+	}
+	err := t.file.Close()
+	t.file = nil
+	return err
+}
+
+// record appends the interaction to the file, opening it if needed. The caller must hold the mutex.
+func (t *RecordingTransport) record(interaction Interaction) error {
+	line, err := json.Marshal(interaction)
+	if err != nil {
+		return err
+	}
+	if t.file == nil {
+		flags := os.O_WRONLY | os.O_CREATE | os.O_APPEND
+		if len(t.interactions) == 0 {
+			flags |= os.O_TRUNC
+		}
+		if t.file, err = os.OpenFile(t.path, flags, 0o644); err != nil {
+			return err
+		}
+	}
+	if _, err = t.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	t.interactions = append(t.interactions, interaction)
+	return nil
+}
+
+// ReplayTransport is an http.RoundTripper which serves JSON-RPC responses previously
+// recorded by RecordingTransport, without sending any request to the node.
+// Requests are matched by their content, ignoring the JSON-RPC request IDs. If the same request
+// has been recorded multiple times, the responses are served in the recorded order, and the last
+// one is repeated once all of them have been served.
+type ReplayTransport struct {
+	mu           sync.Mutex
+	interactions map[string][]Interaction
+}
+
+// NewReplayTransport creates an instance of ReplayTransport which serves interactions
+// stored in the file at the given path, either as JSON lines written by RecordingTransport,
+// or as a JSON array.
+func NewReplayTransport(path string) (*ReplayTransport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recorded interactions: %w", err)
+	}
+	var interactions []Interaction
+	if data = bytes.TrimSpace(data); len(data) > 0 && data[0] == '[' {
+		err = json.Unmarshal(data, &interactions)
+	} else {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		for err == nil && decoder.More() {
+			var interaction Interaction
+			if err = decoder.Decode(&interaction); err == nil {
+				interactions = append(interactions, interaction)
+			}
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode recorded interactions: %w", err)
+	}
+	return NewReplayTransportFromInteractions(interactions)
+}
+
+// NewReplayTransportFromInteractions creates an instance of ReplayTransport which serves
+// the given interactions.
+func NewReplayTransportFromInteractions(interactions []Interaction) (*ReplayTransport, error) {
+	t := &ReplayTransport{interactions: make(map[string][]Interaction)}
+	for _, i := range interactions {
+		key, err := requestKey(i.Request)
+		if err != nil {
+			return nil, err
+		}
+		t.interactions[key] = append(t.interactions[key], i)
+	}
+	return t, nil
+}
+
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	key, err := requestKey(reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	queue := t.interactions[key]
+	if len(queue) == 0 {
+		t.mu.Unlock()
+		return nil, fmt.Errorf("no recorded response for request: %s", reqBody)
+	}
+	interaction := queue[0]
+	if len(queue) > 1 {
+		t.interactions[key] = queue[1:]
+	}
+	t.mu.Unlock()
+
+	respBody, err := replaceResponseIDs(interaction, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(respBody)),
+		ContentLength: int64(len(respBody)),
+		Request:       req,
+	}, nil
+}
+
+// rpcMessage contains fields of JSON-RPC message relevant for matching requests and responses.
+type rpcMessage struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// decodeMessages decodes single or batch JSON-RPC message. All fields are kept in
+// the returned maps, while the returned messages contain only fields used for matching.
+func decodeMessages(data []byte) ([]map[string]json.RawMessage, bool, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var batch []map[string]json.RawMessage
+		err := json.Unmarshal(data, &batch)
+		return batch, true, err
+	}
+	var msg map[string]json.RawMessage
+	err := json.Unmarshal(data, &msg)
+	return []map[string]json.RawMessage{msg}, false, err
+}
+
+// requestKey returns a key identifying the request by its methods and parameters.
+func requestKey(request []byte) (string, error) {
+	msgs, _, err := decodeMessages(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode JSON-RPC request: %w", err)
+	}
+	keys := make([]rpcMessage, len(msgs))
+	for i, msg := range msgs {
+		keys[i].Method = string(bytes.Trim(msg["method"], `"`))
+		if params, ok := msg["params"]; ok {
+			var buf bytes.Buffer
+			if err = json.Compact(&buf, params); err != nil {
+				return "", err
+			}
+			keys[i].Params = buf.Bytes()
+		}
+	}
+	key, err := json.Marshal(keys)
+	return string(key), err
+}
+
+// replaceResponseIDs replaces IDs in the recorded response with the IDs of the current request.
+func replaceResponseIDs(interaction Interaction, request []byte) ([]byte, error) {
+	recorded, _, err := decodeMessages(interaction.Request)
+	if err != nil {
+		return nil, err
+	}
+	current, _, err := decodeMessages(request)
+	if err != nil {
+		return nil, err
+	}
+	if len(recorded) != len(current) {
+		return nil, errors.New("recorded request does not match current request")
+	}
+	ids := make(map[string]json.RawMessage, len(recorded))
+	for i := range recorded {
+		ids[string(recorded[i]["id"])] = current[i]["id"]
+	}
+
+	responses, isBatch, err := decodeMessages(interaction.Response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode recorded JSON-RPC response: %w", err)
+	}
+	for _, resp := range responses {
+		if id, ok := ids[string(resp["id"])]; ok {
+			resp["id"] = id
+		}
+	}
+	if isBatch {
+		return json.Marshal(responses)
+	}
+	return json.Marshal(responses[0])
+}
+
+// readBody reads the whole body and replaces it with a reader of the read content,
+// so that it can be read again.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
@@ -0,0 +1,207 @@
+package clients
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"log/slog"
+	"math/big"
+	"time"
+)
+
+// minResubscribeBackoff is the backoff of the first attempt to reconnect the dropped subscription.
+const minResubscribeBackoff = 100 * time.Millisecond
+
+// position is the position of the head or log in the chain.
+type position struct {
+	block uint64
+	index uint
+}
+
+func (p position) before(other position) bool {
+	return p.block < other.block || (p.block == other.block && p.index < other.index)
+}
+
+// resubscription delivers the items of the subscription, which is reconnected when it is dropped.
+type resubscription[T any] struct {
+	client    *BaseClient
+	kind      string // Kind of the subscription, used in logs.
+	out       chan<- T
+	subscribe func(ctx context.Context, ch chan<- T) (ethereum.Subscription, error)
+	// backfill returns the items from the block up to the head of the chain.
+	backfill func(ctx context.Context, from uint64) ([]T, error)
+	// position returns the position of the item, and whether the item has been removed by a reorg.
+	position func(item T) (position, bool)
+
+	next position // The position following the last delivered item.
+}
+
+// resubscribe creates the subscription which is reconnected when it is dropped. The items emitted while
+// the subscription has been disconnected are backfilled.
+func resubscribe[T any](ctx context.Context, r *resubscription[T]) (ethereum.Subscription, error) {
+	head, err := r.client.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.next = position{block: head + 1}
+	in := make(chan T)
+	sub, err := r.subscribe(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	// The context of the caller may be canceled once the subscription is created, but its values,
+	// such as the timeout of the calls, apply to the reconnection.
+	ctx = context.WithoutCancel(ctx)
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		for {
+			err := r.forward(sub, in, quit)
+			if err == nil {
+				return nil
+			}
+			r.client.log(ctx, slog.LevelWarn, "Subscription dropped, reconnecting", "subscription", r.kind, "err", err)
+			if sub = r.reconnect(ctx, in, quit); sub == nil {
+				return nil
+			}
+		}
+	}), nil
+}
+
+// forward delivers the items of the subscription until it is dropped, in which case the error is returned.
+// It returns nil once the subscription is unsubscribed, or closed by the node without the error.
+func (r *resubscription[T]) forward(sub ethereum.Subscription, in <-chan T, quit <-chan struct{}) error {
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case item := <-in:
+			if !r.send(item, quit) {
+				return nil
+			}
+		case err := <-sub.Err():
+			return err
+		case <-quit:
+			return nil
+		}
+	}
+}
+
+// reconnect subscribes again with the exponential backoff, and delivers the items emitted since the last
+// delivered one. It returns nil if the subscription is unsubscribed in the meantime.
+func (r *resubscription[T]) reconnect(ctx context.Context, in chan<- T, quit <-chan struct{}) ethereum.Subscription {
+	for backoff := min(minResubscribeBackoff, r.client.resubscribeBackoff); ; backoff = min(2*backoff, r.client.resubscribeBackoff) {
+		select {
+		case <-time.After(backoff):
+		case <-quit:
+			return nil
+		}
+		sub, err := r.subscribe(ctx, in)
+		if err != nil {
+			r.client.log(ctx, slog.LevelDebug, "Failed to resubscribe", "subscription", r.kind, "err", err)
+			continue
+		}
+		// The subscription is created before the backfill, so that no item is missed.
+		items, err := r.backfill(ctx, r.next.block)
+		if err != nil {
+			sub.Unsubscribe()
+			r.client.log(ctx, slog.LevelDebug, "Failed to backfill subscription", "subscription", r.kind, "err", err)
+			continue
+		}
+		for _, item := range items {
+			if pos, _ := r.position(item); pos.before(r.next) {
+				continue
+			}
+			if !r.send(item, quit) {
+				sub.Unsubscribe()
+				return nil
+			}
+		}
+		r.client.log(ctx, slog.LevelInfo, "Subscription reconnected", "subscription", r.kind, "backfilled", len(items))
+		return sub
+	}
+}
+
+// send delivers the item and tracks its position. It returns false if the subscription is unsubscribed.
+func (r *resubscription[T]) send(item T, quit <-chan struct{}) bool {
+	select {
+	case r.out <- item:
+	case <-quit:
+		return false
+	}
+	pos, removed := r.position(item)
+	if !removed {
+		r.next = position{block: pos.block, index: pos.index + 1}
+	} else if pos.before(r.next) {
+		// The items of the reorganized blocks are delivered again.
+		r.next = pos
+	}
+	return true
+}
+
+func (c *BaseClient) resubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return resubscribe(ctx, &resubscription[*types.Header]{
+		client:    c,
+		kind:      "newHeads",
+		out:       ch,
+		subscribe: c.subscribeNewHead,
+		backfill: func(ctx context.Context, from uint64) ([]*types.Header, error) {
+			head, err := c.BlockNumber(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var headers []*types.Header
+			for number := from; number <= head; number++ {
+				header, err := c.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+				if err != nil {
+					return nil, err
+				}
+				headers = append(headers, header)
+			}
+			return headers, nil
+		},
+		position: func(header *types.Header) (position, bool) {
+			return position{block: header.Number.Uint64()}, false
+		},
+	})
+}
+
+func (c *BaseClient) resubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return resubscribe(ctx, &resubscription[types.Log]{
+		client: c,
+		kind:   "logs",
+		out:    ch,
+		subscribe: func(ctx context.Context, ch chan<- types.Log) (ethereum.Subscription, error) {
+			return c.subscribeFilterLogs(ctx, query, ch)
+		},
+		backfill: func(ctx context.Context, from uint64) ([]types.Log, error) {
+			return c.FilterLogs(ctx, backfillQuery(query, from))
+		},
+		position: func(log types.Log) (position, bool) {
+			return position{block: log.BlockNumber, index: log.Index}, log.Removed
+		},
+	})
+}
+
+func (c *BaseClient) resubscribeFilterLogsL2(ctx context.Context, query ethereum.FilterQuery, ch chan<- zkTypes.Log) (ethereum.Subscription, error) {
+	return resubscribe(ctx, &resubscription[zkTypes.Log]{
+		client: c,
+		kind:   "logs",
+		out:    ch,
+		subscribe: func(ctx context.Context, ch chan<- zkTypes.Log) (ethereum.Subscription, error) {
+			return c.subscribeFilterLogsL2(ctx, query, ch)
+		},
+		backfill: func(ctx context.Context, from uint64) ([]zkTypes.Log, error) {
+			return c.FilterLogsL2(ctx, backfillQuery(query, from))
+		},
+		position: func(log zkTypes.Log) (position, bool) {
+			return position{block: log.BlockNumber, index: log.Index}, log.Removed
+		},
+	})
+}
+
+// backfillQuery returns the query of the logs matching the subscription from the block up to the head.
+func backfillQuery(query ethereum.FilterQuery, from uint64) ethereum.FilterQuery {
+	query.BlockHash = nil
+	query.FromBlock, query.ToBlock = new(big.Int).SetUint64(from), nil
+	return query
+}
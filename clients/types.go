@@ -2,6 +2,7 @@ package clients
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
@@ -16,6 +17,27 @@ import (
 	"strings"
 )
 
+// transferAndCallABI is the ABI of the transfers of the ERC-677 and ERC-1363 tokens, which call back
+// the recipient with the data.
+const transferAndCallABI = `[
+	{"type":"function","name":"transferAndCall","stateMutability":"nonpayable","outputs":[{"name":"","type":"bool"}],"inputs":[
+		{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"}]},
+	{"type":"function","name":"transferFromAndCall","stateMutability":"nonpayable","outputs":[{"name":"","type":"bool"}],"inputs":[
+		{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"}]}
+]`
+
+// TransferMode is the function of the token used by the transfer.
+type TransferMode int
+
+const (
+	TransferPlain TransferMode = iota // transfer(to, value) of the ERC20 tokens.
+	// transferAndCall(to, value, data) of the ERC-677 and ERC-1363 tokens, which calls back the recipient.
+	TransferAndCall
+	// transferFromAndCall(from, to, value, data) of the ERC-1363 tokens, which transfers the tokens of Owner,
+	// using the allowance given to the sender, and calls back the recipient.
+	TransferFromAndCall
+)
+
 // TransferCallMsg contains parameters for transfer call.
 type TransferCallMsg struct {
 	To     common.Address // The address of the recipient.
@@ -23,6 +45,12 @@ type TransferCallMsg struct {
 	Token  common.Address // The address of the token. ETH by default.
 	From   common.Address // The address of the sender.
 
+	// The function of the token used by the transfer. TransferPlain by default. Other modes are not
+	// supported by the base token.
+	Mode  TransferMode
+	Data  []byte         // The data passed to the recipient by TransferAndCall and TransferFromAndCall. Optional.
+	Owner common.Address // The owner of the tokens transferred by TransferFromAndCall, required by it and rejected by other modes.
+
 	Gas       uint64   // If 0, the call executes with near-infinite gas.
 	GasPrice  *big.Int // Wei <-> gas exchange ratio.
 	GasFeeCap *big.Int // EIP-1559 fee cap per gas.
@@ -38,19 +66,18 @@ func (m *TransferCallMsg) ToCallMsg() (*ethereum.CallMsg, error) {
 		to    *common.Address
 	)
 
-	if m.Token == utils.EthAddress {
+	if m.Token == utils.EthAddress || m.Token == utils.L2BaseTokenAddress {
+		if m.Mode != TransferPlain || len(m.Data) > 0 || m.Owner != (common.Address{}) {
+			return nil, errors.New("base token does not support transfer with call")
+		}
 		value = m.Amount
 		to = &m.To
 	} else {
 		value = big.NewInt(0)
 		to = &m.Token
-		erc20abi, err := abi.JSON(strings.NewReader(erc20.IERC20MetaData.ABI))
-		if err != nil {
-			return nil, fmt.Errorf("failed to load erc20abi: %w", err)
-		}
-		data, err = erc20abi.Pack("transfer", m.To, m.Amount)
-		if err != nil {
-			return nil, fmt.Errorf("failed to pack transfer function: %w", err)
+		var err error
+		if data, err = m.tokenCalldata(); err != nil {
+			return nil, err
 		}
 	}
 
@@ -66,6 +93,52 @@ func (m *TransferCallMsg) ToCallMsg() (*ethereum.CallMsg, error) {
 	}, nil
 }
 
+// tokenCalldata packs the call of the token function determined by the mode.
+func (m *TransferCallMsg) tokenCalldata() ([]byte, error) {
+	if m.Mode == TransferFromAndCall && m.Owner == (common.Address{}) {
+		return nil, errors.New("owner is required by TransferFromAndCall mode")
+	}
+	if m.Mode != TransferFromAndCall && m.Owner != (common.Address{}) {
+		return nil, errors.New("owner requires TransferFromAndCall mode")
+	}
+	if m.Mode == TransferPlain {
+		if len(m.Data) > 0 {
+			return nil, errors.New("data requires TransferAndCall or TransferFromAndCall mode")
+		}
+		erc20abi, err := abi.JSON(strings.NewReader(erc20.IERC20MetaData.ABI))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load erc20abi: %w", err)
+		}
+		data, err := erc20abi.Pack("transfer", m.To, m.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack transfer function: %w", err)
+		}
+		return data, nil
+	}
+
+	callData := m.Data
+	if callData == nil {
+		callData = []byte{}
+	}
+	parsed, err := abi.JSON(strings.NewReader(transferAndCallABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transferAndCallABI: %w", err)
+	}
+	var data []byte
+	switch m.Mode {
+	case TransferAndCall:
+		data, err = parsed.Pack("transferAndCall", m.To, m.Amount, callData)
+	case TransferFromAndCall:
+		data, err = parsed.Pack("transferFromAndCall", m.Owner, m.To, m.Amount, callData)
+	default:
+		return nil, fmt.Errorf("unknown transfer mode %d", m.Mode)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack transfer function: %w", err)
+	}
+	return data, nil
+}
+
 // WithdrawalCallMsg contains parameters for withdrawal call.
 type WithdrawalCallMsg struct {
 	To            common.Address  // The address of the recipient on L1.
@@ -83,7 +156,7 @@ type WithdrawalCallMsg struct {
 }
 
 func (m *WithdrawalCallMsg) ToCallMsg(defaultL2Bridge *common.Address) (*ethereum.CallMsg, error) {
-	if m.Token == utils.EthAddress {
+	if m.Token == utils.EthAddress || m.Token == utils.L2BaseTokenAddress {
 		ethTokenAbi, err := abi.JSON(strings.NewReader(ethtoken.IEthTokenMetaData.ABI))
 		if err != nil {
 			return nil, fmt.Errorf("failed to load ethTokenAbi: %w", err)
@@ -95,7 +168,7 @@ func (m *WithdrawalCallMsg) ToCallMsg(defaultL2Bridge *common.Address) (*ethereu
 		}
 		return &ethereum.CallMsg{
 			From:      m.From,
-			To:        &utils.L2EthTokenAddress,
+			To:        &utils.L2BaseTokenAddress,
 			Gas:       m.Gas,
 			GasPrice:  m.GasPrice,
 			GasFeeCap: m.GasFeeCap,
@@ -0,0 +1,134 @@
+package clients
+
+import (
+	"bytes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"math/big"
+	"testing"
+)
+
+// calldata returns the ABI encoding of the call of the function with the static words and the trailing bytes argument.
+func calldata(signature string, words [][]byte, data []byte) []byte {
+	encoded := append([]byte{}, crypto.Keccak256([]byte(signature))[:4]...)
+	for _, word := range words {
+		encoded = append(encoded, common.LeftPadBytes(word, 32)...)
+	}
+	if data == nil {
+		return encoded
+	}
+	offset := big.NewInt(int64(32 * (len(words) + 1)))
+	encoded = append(encoded, common.LeftPadBytes(offset.Bytes(), 32)...)
+	encoded = append(encoded, common.LeftPadBytes(big.NewInt(int64(len(data))).Bytes(), 32)...)
+	return append(encoded, common.RightPadBytes(data, (len(data)+31)/32*32)...)
+}
+
+func TestTransferCallMsgToCallMsg(t *testing.T) {
+	token := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	to := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	owner := common.HexToAddress("0x3000000000000000000000000000000000000003")
+	amount := big.NewInt(1_000)
+	data := []byte{0x01, 0x02}
+	tests := []struct {
+		name      string
+		msg       TransferCallMsg
+		wantTo    common.Address
+		wantValue *big.Int
+		wantData  []byte
+		wantErr   bool
+	}{
+		{
+			name:      "base token",
+			msg:       TransferCallMsg{To: to, Amount: amount, Token: utils.L2BaseTokenAddress},
+			wantTo:    to,
+			wantValue: amount,
+		},
+		{
+			name:    "base token with call",
+			msg:     TransferCallMsg{To: to, Amount: amount, Token: utils.EthAddress, Mode: TransferAndCall},
+			wantErr: true,
+		},
+		{
+			name:    "base token with owner",
+			msg:     TransferCallMsg{To: to, Amount: amount, Token: utils.EthAddress, Owner: owner},
+			wantErr: true,
+		},
+		{
+			name:      "plain",
+			msg:       TransferCallMsg{To: to, Amount: amount, Token: token},
+			wantTo:    token,
+			wantValue: big.NewInt(0),
+			wantData:  calldata("transfer(address,uint256)", [][]byte{to.Bytes(), amount.Bytes()}, nil),
+		},
+		{
+			name:    "plain with data",
+			msg:     TransferCallMsg{To: to, Amount: amount, Token: token, Data: data},
+			wantErr: true,
+		},
+		{
+			name:    "plain with owner",
+			msg:     TransferCallMsg{To: to, Amount: amount, Token: token, Owner: owner},
+			wantErr: true,
+		},
+		{
+			name:      "transfer and call",
+			msg:       TransferCallMsg{To: to, Amount: amount, Token: token, Mode: TransferAndCall, Data: data},
+			wantTo:    token,
+			wantValue: big.NewInt(0),
+			wantData:  calldata("transferAndCall(address,uint256,bytes)", [][]byte{to.Bytes(), amount.Bytes()}, data),
+		},
+		{
+			name:      "transfer and call without data",
+			msg:       TransferCallMsg{To: to, Amount: amount, Token: token, Mode: TransferAndCall},
+			wantTo:    token,
+			wantValue: big.NewInt(0),
+			wantData:  calldata("transferAndCall(address,uint256,bytes)", [][]byte{to.Bytes(), amount.Bytes()}, []byte{}),
+		},
+		{
+			name:    "transfer and call with owner",
+			msg:     TransferCallMsg{To: to, Amount: amount, Token: token, Mode: TransferAndCall, Owner: owner},
+			wantErr: true,
+		},
+		{
+			name:      "transfer from and call",
+			msg:       TransferCallMsg{To: to, Amount: amount, Token: token, Mode: TransferFromAndCall, Owner: owner, Data: data},
+			wantTo:    token,
+			wantValue: big.NewInt(0),
+			wantData:  calldata("transferFromAndCall(address,address,uint256,bytes)", [][]byte{owner.Bytes(), to.Bytes(), amount.Bytes()}, data),
+		},
+		{
+			name:    "transfer from and call without owner",
+			msg:     TransferCallMsg{To: to, Amount: amount, Token: token, Mode: TransferFromAndCall, Data: data},
+			wantErr: true,
+		},
+		{
+			name:    "unknown mode",
+			msg:     TransferCallMsg{To: to, Amount: amount, Token: token, Mode: TransferMode(3)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := tt.msg.ToCallMsg()
+			if tt.wantErr {
+				if err == nil {
+					t.Error("ToCallMsg() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ToCallMsg() error = %v", err)
+			}
+			if msg.To == nil || *msg.To != tt.wantTo {
+				t.Errorf("To = %v, want %s", msg.To, tt.wantTo)
+			}
+			if msg.Value.Cmp(tt.wantValue) != 0 {
+				t.Errorf("Value = %s, want %s", msg.Value, tt.wantValue)
+			}
+			if !bytes.Equal(msg.Data, tt.wantData) {
+				t.Errorf("Data = %x, want %x", msg.Data, tt.wantData)
+			}
+		})
+	}
+}
@@ -9,6 +9,9 @@ import (
 	"math/big"
 )
 
+// methodNotFoundErrorCode is the JSON-RPC error code returned when the method is not supported by the node.
+const methodNotFoundErrorCode = -32601
+
 func toFilterArg(q ethereum.FilterQuery) (interface{}, error) {
 	arg := map[string]interface{}{
 		"address": q.Addresses,
@@ -0,0 +1,59 @@
+// Command zkabigen generates Go bindings of zkSync contracts, whose transact methods send EIP-712 transactions
+// with paymaster parameters, factory dependencies and custom gas per pubdata. It is usually run by go:generate:
+//
+//	//go:generate go run github.com/zksync-sdk/zksync2-go/cmd/zkabigen -abi Storage.abi -bin Storage.zbin -type Storage -pkg storage -out storage.go
+//
+// The bytecode produced by zksolc is optional, and is either hex-encoded or raw.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/zksync-sdk/zksync2-go/zkabigen"
+	"os"
+)
+
+func main() {
+	var (
+		abiFile  = flag.String("abi", "", "Path to the ABI of the contract")
+		binFile  = flag.String("bin", "", "Path to the zkSync bytecode of the contract (optional)")
+		typeName = flag.String("type", "", "Name of the generated Go type of the contract")
+		pkg      = flag.String("pkg", "", "Name of the package of the generated binding")
+		out      = flag.String("out", "", "Output file of the generated binding (default: stdout)")
+	)
+	flag.Parse()
+	if *abiFile == "" || *typeName == "" || *pkg == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(*abiFile, *binFile, *typeName, *pkg, *out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(abiFile, binFile, typeName, pkg, out string) error {
+	abiJSON, err := os.ReadFile(abiFile)
+	if err != nil {
+		return err
+	}
+	var bytecode []byte
+	if binFile != "" {
+		data, err := os.ReadFile(binFile)
+		if err != nil {
+			return err
+		}
+		if bytecode, err = zkabigen.ParseBytecode(data); err != nil {
+			return fmt.Errorf("%s: %w", binFile, err)
+		}
+	}
+	code, err := zkabigen.Bind(typeName, string(abiJSON), bytecode, pkg)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = fmt.Print(code)
+		return err
+	}
+	return os.WriteFile(out, []byte(code), 0o644)
+}
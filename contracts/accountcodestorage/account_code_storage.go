@@ -0,0 +1,337 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package accountcodestorage
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// AccountCodeStorageMetaData contains all meta data concerning the AccountCodeStorage contract.
+var AccountCodeStorageMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"getCodeHash\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"_input\",\"type\":\"uint256\"}],\"outputs\":[{\"name\":\"codeHash\",\"type\":\"bytes32\"}]},{\"type\":\"function\",\"name\":\"getCodeSize\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"_input\",\"type\":\"uint256\"}],\"outputs\":[{\"name\":\"codeSize\",\"type\":\"uint256\"}]},{\"type\":\"function\",\"name\":\"getRawCodeHash\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"_address\",\"type\":\"address\"}],\"outputs\":[{\"name\":\"codeHash\",\"type\":\"bytes32\"}]},{\"type\":\"function\",\"name\":\"markAccountCodeHashAsConstructed\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"_address\",\"type\":\"address\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"storeAccountConstructedCodeHash\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"_address\",\"type\":\"address\"},{\"name\":\"_hash\",\"type\":\"bytes32\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"storeAccountConstructingCodeHash\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"_address\",\"type\":\"address\"},{\"name\":\"_hash\",\"type\":\"bytes32\"}],\"outputs\":[]}]",
+}
+
+// AccountCodeStorageABI is the input ABI used to generate the binding from.
+// Deprecated: Use AccountCodeStorageMetaData.ABI instead.
+var AccountCodeStorageABI = AccountCodeStorageMetaData.ABI
+
+// AccountCodeStorage is an auto generated Go binding around an Ethereum contract.
+type AccountCodeStorage struct {
+	AccountCodeStorageCaller     // Read-only binding to the contract
+	AccountCodeStorageTransactor // Write-only binding to the contract
+	AccountCodeStorageFilterer   // Log filterer for contract events
+}
+
+// AccountCodeStorageCaller is an auto generated read-only Go binding around an Ethereum contract.
+type AccountCodeStorageCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AccountCodeStorageTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type AccountCodeStorageTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AccountCodeStorageFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type AccountCodeStorageFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AccountCodeStorageSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type AccountCodeStorageSession struct {
+	Contract     *AccountCodeStorage // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts       // Call options to use throughout this session
+	TransactOpts bind.TransactOpts   // Transaction auth options to use throughout this session
+}
+
+// AccountCodeStorageCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type AccountCodeStorageCallerSession struct {
+	Contract *AccountCodeStorageCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts             // Call options to use throughout this session
+}
+
+// AccountCodeStorageTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type AccountCodeStorageTransactorSession struct {
+	Contract     *AccountCodeStorageTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts             // Transaction auth options to use throughout this session
+}
+
+// AccountCodeStorageRaw is an auto generated low-level Go binding around an Ethereum contract.
+type AccountCodeStorageRaw struct {
+	Contract *AccountCodeStorage // Generic contract binding to access the raw methods on
+}
+
+// AccountCodeStorageCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type AccountCodeStorageCallerRaw struct {
+	Contract *AccountCodeStorageCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// AccountCodeStorageTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type AccountCodeStorageTransactorRaw struct {
+	Contract *AccountCodeStorageTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewAccountCodeStorage creates a new instance of AccountCodeStorage, bound to a specific deployed contract.
+func NewAccountCodeStorage(address common.Address, backend bind.ContractBackend) (*AccountCodeStorage, error) {
+	contract, err := bindAccountCodeStorage(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountCodeStorage{AccountCodeStorageCaller: AccountCodeStorageCaller{contract: contract}, AccountCodeStorageTransactor: AccountCodeStorageTransactor{contract: contract}, AccountCodeStorageFilterer: AccountCodeStorageFilterer{contract: contract}}, nil
+}
+
+// NewAccountCodeStorageCaller creates a new read-only instance of AccountCodeStorage, bound to a specific deployed contract.
+func NewAccountCodeStorageCaller(address common.Address, caller bind.ContractCaller) (*AccountCodeStorageCaller, error) {
+	contract, err := bindAccountCodeStorage(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountCodeStorageCaller{contract: contract}, nil
+}
+
+// NewAccountCodeStorageTransactor creates a new write-only instance of AccountCodeStorage, bound to a specific deployed contract.
+func NewAccountCodeStorageTransactor(address common.Address, transactor bind.ContractTransactor) (*AccountCodeStorageTransactor, error) {
+	contract, err := bindAccountCodeStorage(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountCodeStorageTransactor{contract: contract}, nil
+}
+
+// NewAccountCodeStorageFilterer creates a new log filterer instance of AccountCodeStorage, bound to a specific deployed contract.
+func NewAccountCodeStorageFilterer(address common.Address, filterer bind.ContractFilterer) (*AccountCodeStorageFilterer, error) {
+	contract, err := bindAccountCodeStorage(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountCodeStorageFilterer{contract: contract}, nil
+}
+
+// bindAccountCodeStorage binds a generic wrapper to an already deployed contract.
+func bindAccountCodeStorage(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := AccountCodeStorageMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_AccountCodeStorage *AccountCodeStorageRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _AccountCodeStorage.Contract.AccountCodeStorageCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_AccountCodeStorage *AccountCodeStorageRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _AccountCodeStorage.Contract.AccountCodeStorageTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_AccountCodeStorage *AccountCodeStorageRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _AccountCodeStorage.Contract.AccountCodeStorageTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_AccountCodeStorage *AccountCodeStorageCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _AccountCodeStorage.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_AccountCodeStorage *AccountCodeStorageTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _AccountCodeStorage.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_AccountCodeStorage *AccountCodeStorageTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _AccountCodeStorage.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetCodeHash is a free data retrieval call binding the contract method 0xe03fe177.
+//
+// Solidity: function getCodeHash(uint256 _input) view returns(bytes32 codeHash)
+func (_AccountCodeStorage *AccountCodeStorageCaller) GetCodeHash(opts *bind.CallOpts, _input *big.Int) ([32]byte, error) {
+	var out []interface{}
+	err := _AccountCodeStorage.contract.Call(opts, &out, "getCodeHash", _input)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// GetCodeHash is a free data retrieval call binding the contract method 0xe03fe177.
+//
+// Solidity: function getCodeHash(uint256 _input) view returns(bytes32 codeHash)
+func (_AccountCodeStorage *AccountCodeStorageSession) GetCodeHash(_input *big.Int) ([32]byte, error) {
+	return _AccountCodeStorage.Contract.GetCodeHash(&_AccountCodeStorage.CallOpts, _input)
+}
+
+// GetCodeHash is a free data retrieval call binding the contract method 0xe03fe177.
+//
+// Solidity: function getCodeHash(uint256 _input) view returns(bytes32 codeHash)
+func (_AccountCodeStorage *AccountCodeStorageCallerSession) GetCodeHash(_input *big.Int) ([32]byte, error) {
+	return _AccountCodeStorage.Contract.GetCodeHash(&_AccountCodeStorage.CallOpts, _input)
+}
+
+// GetCodeSize is a free data retrieval call binding the contract method 0x1806aa18.
+//
+// Solidity: function getCodeSize(uint256 _input) view returns(uint256 codeSize)
+func (_AccountCodeStorage *AccountCodeStorageCaller) GetCodeSize(opts *bind.CallOpts, _input *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _AccountCodeStorage.contract.Call(opts, &out, "getCodeSize", _input)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetCodeSize is a free data retrieval call binding the contract method 0x1806aa18.
+//
+// Solidity: function getCodeSize(uint256 _input) view returns(uint256 codeSize)
+func (_AccountCodeStorage *AccountCodeStorageSession) GetCodeSize(_input *big.Int) (*big.Int, error) {
+	return _AccountCodeStorage.Contract.GetCodeSize(&_AccountCodeStorage.CallOpts, _input)
+}
+
+// GetCodeSize is a free data retrieval call binding the contract method 0x1806aa18.
+//
+// Solidity: function getCodeSize(uint256 _input) view returns(uint256 codeSize)
+func (_AccountCodeStorage *AccountCodeStorageCallerSession) GetCodeSize(_input *big.Int) (*big.Int, error) {
+	return _AccountCodeStorage.Contract.GetCodeSize(&_AccountCodeStorage.CallOpts, _input)
+}
+
+// GetRawCodeHash is a free data retrieval call binding the contract method 0x4de2e468.
+//
+// Solidity: function getRawCodeHash(address _address) view returns(bytes32 codeHash)
+func (_AccountCodeStorage *AccountCodeStorageCaller) GetRawCodeHash(opts *bind.CallOpts, _address common.Address) ([32]byte, error) {
+	var out []interface{}
+	err := _AccountCodeStorage.contract.Call(opts, &out, "getRawCodeHash", _address)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// GetRawCodeHash is a free data retrieval call binding the contract method 0x4de2e468.
+//
+// Solidity: function getRawCodeHash(address _address) view returns(bytes32 codeHash)
+func (_AccountCodeStorage *AccountCodeStorageSession) GetRawCodeHash(_address common.Address) ([32]byte, error) {
+	return _AccountCodeStorage.Contract.GetRawCodeHash(&_AccountCodeStorage.CallOpts, _address)
+}
+
+// GetRawCodeHash is a free data retrieval call binding the contract method 0x4de2e468.
+//
+// Solidity: function getRawCodeHash(address _address) view returns(bytes32 codeHash)
+func (_AccountCodeStorage *AccountCodeStorageCallerSession) GetRawCodeHash(_address common.Address) ([32]byte, error) {
+	return _AccountCodeStorage.Contract.GetRawCodeHash(&_AccountCodeStorage.CallOpts, _address)
+}
+
+// MarkAccountCodeHashAsConstructed is a paid mutator transaction binding the contract method 0xc2e4ff97.
+//
+// Solidity: function markAccountCodeHashAsConstructed(address _address) returns()
+func (_AccountCodeStorage *AccountCodeStorageTransactor) MarkAccountCodeHashAsConstructed(opts *bind.TransactOpts, _address common.Address) (*types.Transaction, error) {
+	return _AccountCodeStorage.contract.Transact(opts, "markAccountCodeHashAsConstructed", _address)
+}
+
+// MarkAccountCodeHashAsConstructed is a paid mutator transaction binding the contract method 0xc2e4ff97.
+//
+// Solidity: function markAccountCodeHashAsConstructed(address _address) returns()
+func (_AccountCodeStorage *AccountCodeStorageSession) MarkAccountCodeHashAsConstructed(_address common.Address) (*types.Transaction, error) {
+	return _AccountCodeStorage.Contract.MarkAccountCodeHashAsConstructed(&_AccountCodeStorage.TransactOpts, _address)
+}
+
+// MarkAccountCodeHashAsConstructed is a paid mutator transaction binding the contract method 0xc2e4ff97.
+//
+// Solidity: function markAccountCodeHashAsConstructed(address _address) returns()
+func (_AccountCodeStorage *AccountCodeStorageTransactorSession) MarkAccountCodeHashAsConstructed(_address common.Address) (*types.Transaction, error) {
+	return _AccountCodeStorage.Contract.MarkAccountCodeHashAsConstructed(&_AccountCodeStorage.TransactOpts, _address)
+}
+
+// StoreAccountConstructedCodeHash is a paid mutator transaction binding the contract method 0x0d4651aa.
+//
+// Solidity: function storeAccountConstructedCodeHash(address _address, bytes32 _hash) returns()
+func (_AccountCodeStorage *AccountCodeStorageTransactor) StoreAccountConstructedCodeHash(opts *bind.TransactOpts, _address common.Address, _hash [32]byte) (*types.Transaction, error) {
+	return _AccountCodeStorage.contract.Transact(opts, "storeAccountConstructedCodeHash", _address, _hash)
+}
+
+// StoreAccountConstructedCodeHash is a paid mutator transaction binding the contract method 0x0d4651aa.
+//
+// Solidity: function storeAccountConstructedCodeHash(address _address, bytes32 _hash) returns()
+func (_AccountCodeStorage *AccountCodeStorageSession) StoreAccountConstructedCodeHash(_address common.Address, _hash [32]byte) (*types.Transaction, error) {
+	return _AccountCodeStorage.Contract.StoreAccountConstructedCodeHash(&_AccountCodeStorage.TransactOpts, _address, _hash)
+}
+
+// StoreAccountConstructedCodeHash is a paid mutator transaction binding the contract method 0x0d4651aa.
+//
+// Solidity: function storeAccountConstructedCodeHash(address _address, bytes32 _hash) returns()
+func (_AccountCodeStorage *AccountCodeStorageTransactorSession) StoreAccountConstructedCodeHash(_address common.Address, _hash [32]byte) (*types.Transaction, error) {
+	return _AccountCodeStorage.Contract.StoreAccountConstructedCodeHash(&_AccountCodeStorage.TransactOpts, _address, _hash)
+}
+
+// StoreAccountConstructingCodeHash is a paid mutator transaction binding the contract method 0x4f1e1be0.
+//
+// Solidity: function storeAccountConstructingCodeHash(address _address, bytes32 _hash) returns()
+func (_AccountCodeStorage *AccountCodeStorageTransactor) StoreAccountConstructingCodeHash(opts *bind.TransactOpts, _address common.Address, _hash [32]byte) (*types.Transaction, error) {
+	return _AccountCodeStorage.contract.Transact(opts, "storeAccountConstructingCodeHash", _address, _hash)
+}
+
+// StoreAccountConstructingCodeHash is a paid mutator transaction binding the contract method 0x4f1e1be0.
+//
+// Solidity: function storeAccountConstructingCodeHash(address _address, bytes32 _hash) returns()
+func (_AccountCodeStorage *AccountCodeStorageSession) StoreAccountConstructingCodeHash(_address common.Address, _hash [32]byte) (*types.Transaction, error) {
+	return _AccountCodeStorage.Contract.StoreAccountConstructingCodeHash(&_AccountCodeStorage.TransactOpts, _address, _hash)
+}
+
+// StoreAccountConstructingCodeHash is a paid mutator transaction binding the contract method 0x4f1e1be0.
+//
+// Solidity: function storeAccountConstructingCodeHash(address _address, bytes32 _hash) returns()
+func (_AccountCodeStorage *AccountCodeStorageTransactorSession) StoreAccountConstructingCodeHash(_address common.Address, _hash [32]byte) (*types.Transaction, error) {
+	return _AccountCodeStorage.Contract.StoreAccountConstructingCodeHash(&_AccountCodeStorage.TransactOpts, _address, _hash)
+}
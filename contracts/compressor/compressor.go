@@ -0,0 +1,223 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package compressor
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// CompressorMetaData contains all meta data concerning the Compressor contract.
+var CompressorMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"publishCompressedBytecode\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"_bytecode\",\"type\":\"bytes\"},{\"name\":\"_rawCompressedData\",\"type\":\"bytes\"}],\"outputs\":[{\"name\":\"bytecodeHash\",\"type\":\"bytes32\"}]},{\"type\":\"function\",\"name\":\"verifyCompressedStateDiffs\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"_numberOfStateDiffs\",\"type\":\"uint256\"},{\"name\":\"_enumerationIndexSize\",\"type\":\"uint256\"},{\"name\":\"_stateDiffs\",\"type\":\"bytes\"},{\"name\":\"_compressedStateDiffs\",\"type\":\"bytes\"}],\"outputs\":[{\"name\":\"stateDiffHash\",\"type\":\"bytes32\"}]}]",
+}
+
+// CompressorABI is the input ABI used to generate the binding from.
+// Deprecated: Use CompressorMetaData.ABI instead.
+var CompressorABI = CompressorMetaData.ABI
+
+// Compressor is an auto generated Go binding around an Ethereum contract.
+type Compressor struct {
+	CompressorCaller     // Read-only binding to the contract
+	CompressorTransactor // Write-only binding to the contract
+	CompressorFilterer   // Log filterer for contract events
+}
+
+// CompressorCaller is an auto generated read-only Go binding around an Ethereum contract.
+type CompressorCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// CompressorTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type CompressorTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// CompressorFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type CompressorFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// CompressorSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type CompressorSession struct {
+	Contract     *Compressor       // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// CompressorCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type CompressorCallerSession struct {
+	Contract *CompressorCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts     // Call options to use throughout this session
+}
+
+// CompressorTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type CompressorTransactorSession struct {
+	Contract     *CompressorTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts     // Transaction auth options to use throughout this session
+}
+
+// CompressorRaw is an auto generated low-level Go binding around an Ethereum contract.
+type CompressorRaw struct {
+	Contract *Compressor // Generic contract binding to access the raw methods on
+}
+
+// CompressorCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type CompressorCallerRaw struct {
+	Contract *CompressorCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// CompressorTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type CompressorTransactorRaw struct {
+	Contract *CompressorTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewCompressor creates a new instance of Compressor, bound to a specific deployed contract.
+func NewCompressor(address common.Address, backend bind.ContractBackend) (*Compressor, error) {
+	contract, err := bindCompressor(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Compressor{CompressorCaller: CompressorCaller{contract: contract}, CompressorTransactor: CompressorTransactor{contract: contract}, CompressorFilterer: CompressorFilterer{contract: contract}}, nil
+}
+
+// NewCompressorCaller creates a new read-only instance of Compressor, bound to a specific deployed contract.
+func NewCompressorCaller(address common.Address, caller bind.ContractCaller) (*CompressorCaller, error) {
+	contract, err := bindCompressor(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &CompressorCaller{contract: contract}, nil
+}
+
+// NewCompressorTransactor creates a new write-only instance of Compressor, bound to a specific deployed contract.
+func NewCompressorTransactor(address common.Address, transactor bind.ContractTransactor) (*CompressorTransactor, error) {
+	contract, err := bindCompressor(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &CompressorTransactor{contract: contract}, nil
+}
+
+// NewCompressorFilterer creates a new log filterer instance of Compressor, bound to a specific deployed contract.
+func NewCompressorFilterer(address common.Address, filterer bind.ContractFilterer) (*CompressorFilterer, error) {
+	contract, err := bindCompressor(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &CompressorFilterer{contract: contract}, nil
+}
+
+// bindCompressor binds a generic wrapper to an already deployed contract.
+func bindCompressor(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := CompressorMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Compressor *CompressorRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Compressor.Contract.CompressorCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Compressor *CompressorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Compressor.Contract.CompressorTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Compressor *CompressorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Compressor.Contract.CompressorTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Compressor *CompressorCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Compressor.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Compressor *CompressorTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Compressor.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Compressor *CompressorTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Compressor.Contract.contract.Transact(opts, method, params...)
+}
+
+// PublishCompressedBytecode is a paid mutator transaction binding the contract method 0xf5e69a47.
+//
+// Solidity: function publishCompressedBytecode(bytes _bytecode, bytes _rawCompressedData) returns(bytes32 bytecodeHash)
+func (_Compressor *CompressorTransactor) PublishCompressedBytecode(opts *bind.TransactOpts, _bytecode []byte, _rawCompressedData []byte) (*types.Transaction, error) {
+	return _Compressor.contract.Transact(opts, "publishCompressedBytecode", _bytecode, _rawCompressedData)
+}
+
+// PublishCompressedBytecode is a paid mutator transaction binding the contract method 0xf5e69a47.
+//
+// Solidity: function publishCompressedBytecode(bytes _bytecode, bytes _rawCompressedData) returns(bytes32 bytecodeHash)
+func (_Compressor *CompressorSession) PublishCompressedBytecode(_bytecode []byte, _rawCompressedData []byte) (*types.Transaction, error) {
+	return _Compressor.Contract.PublishCompressedBytecode(&_Compressor.TransactOpts, _bytecode, _rawCompressedData)
+}
+
+// PublishCompressedBytecode is a paid mutator transaction binding the contract method 0xf5e69a47.
+//
+// Solidity: function publishCompressedBytecode(bytes _bytecode, bytes _rawCompressedData) returns(bytes32 bytecodeHash)
+func (_Compressor *CompressorTransactorSession) PublishCompressedBytecode(_bytecode []byte, _rawCompressedData []byte) (*types.Transaction, error) {
+	return _Compressor.Contract.PublishCompressedBytecode(&_Compressor.TransactOpts, _bytecode, _rawCompressedData)
+}
+
+// VerifyCompressedStateDiffs is a paid mutator transaction binding the contract method 0x6006d8b5.
+//
+// Solidity: function verifyCompressedStateDiffs(uint256 _numberOfStateDiffs, uint256 _enumerationIndexSize, bytes _stateDiffs, bytes _compressedStateDiffs) returns(bytes32 stateDiffHash)
+func (_Compressor *CompressorTransactor) VerifyCompressedStateDiffs(opts *bind.TransactOpts, _numberOfStateDiffs *big.Int, _enumerationIndexSize *big.Int, _stateDiffs []byte, _compressedStateDiffs []byte) (*types.Transaction, error) {
+	return _Compressor.contract.Transact(opts, "verifyCompressedStateDiffs", _numberOfStateDiffs, _enumerationIndexSize, _stateDiffs, _compressedStateDiffs)
+}
+
+// VerifyCompressedStateDiffs is a paid mutator transaction binding the contract method 0x6006d8b5.
+//
+// Solidity: function verifyCompressedStateDiffs(uint256 _numberOfStateDiffs, uint256 _enumerationIndexSize, bytes _stateDiffs, bytes _compressedStateDiffs) returns(bytes32 stateDiffHash)
+func (_Compressor *CompressorSession) VerifyCompressedStateDiffs(_numberOfStateDiffs *big.Int, _enumerationIndexSize *big.Int, _stateDiffs []byte, _compressedStateDiffs []byte) (*types.Transaction, error) {
+	return _Compressor.Contract.VerifyCompressedStateDiffs(&_Compressor.TransactOpts, _numberOfStateDiffs, _enumerationIndexSize, _stateDiffs, _compressedStateDiffs)
+}
+
+// VerifyCompressedStateDiffs is a paid mutator transaction binding the contract method 0x6006d8b5.
+//
+// Solidity: function verifyCompressedStateDiffs(uint256 _numberOfStateDiffs, uint256 _enumerationIndexSize, bytes _stateDiffs, bytes _compressedStateDiffs) returns(bytes32 stateDiffHash)
+func (_Compressor *CompressorTransactorSession) VerifyCompressedStateDiffs(_numberOfStateDiffs *big.Int, _enumerationIndexSize *big.Int, _stateDiffs []byte, _compressedStateDiffs []byte) (*types.Transaction, error) {
+	return _Compressor.Contract.VerifyCompressedStateDiffs(&_Compressor.TransactOpts, _numberOfStateDiffs, _enumerationIndexSize, _stateDiffs, _compressedStateDiffs)
+}
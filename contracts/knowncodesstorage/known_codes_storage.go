@@ -0,0 +1,407 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package knowncodesstorage
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// KnownCodesStorageMetaData contains all meta data concerning the KnownCodesStorage contract.
+var KnownCodesStorageMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"event\",\"name\":\"MarkedAsKnown\",\"anonymous\":false,\"inputs\":[{\"name\":\"bytecodeHash\",\"type\":\"bytes32\",\"indexed\":true},{\"name\":\"sendBytecodeToL1\",\"type\":\"bool\",\"indexed\":true}]},{\"type\":\"function\",\"name\":\"getMarker\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"_hash\",\"type\":\"bytes32\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}]},{\"type\":\"function\",\"name\":\"markBytecodeAsPublished\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"_bytecodeHash\",\"type\":\"bytes32\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"markFactoryDeps\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"_shouldSendToL1\",\"type\":\"bool\"},{\"name\":\"_hashes\",\"type\":\"bytes32[]\"}],\"outputs\":[]}]",
+}
+
+// KnownCodesStorageABI is the input ABI used to generate the binding from.
+// Deprecated: Use KnownCodesStorageMetaData.ABI instead.
+var KnownCodesStorageABI = KnownCodesStorageMetaData.ABI
+
+// KnownCodesStorage is an auto generated Go binding around an Ethereum contract.
+type KnownCodesStorage struct {
+	KnownCodesStorageCaller     // Read-only binding to the contract
+	KnownCodesStorageTransactor // Write-only binding to the contract
+	KnownCodesStorageFilterer   // Log filterer for contract events
+}
+
+// KnownCodesStorageCaller is an auto generated read-only Go binding around an Ethereum contract.
+type KnownCodesStorageCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// KnownCodesStorageTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type KnownCodesStorageTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// KnownCodesStorageFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type KnownCodesStorageFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// KnownCodesStorageSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type KnownCodesStorageSession struct {
+	Contract     *KnownCodesStorage // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts      // Call options to use throughout this session
+	TransactOpts bind.TransactOpts  // Transaction auth options to use throughout this session
+}
+
+// KnownCodesStorageCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type KnownCodesStorageCallerSession struct {
+	Contract *KnownCodesStorageCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts            // Call options to use throughout this session
+}
+
+// KnownCodesStorageTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type KnownCodesStorageTransactorSession struct {
+	Contract     *KnownCodesStorageTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts            // Transaction auth options to use throughout this session
+}
+
+// KnownCodesStorageRaw is an auto generated low-level Go binding around an Ethereum contract.
+type KnownCodesStorageRaw struct {
+	Contract *KnownCodesStorage // Generic contract binding to access the raw methods on
+}
+
+// KnownCodesStorageCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type KnownCodesStorageCallerRaw struct {
+	Contract *KnownCodesStorageCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// KnownCodesStorageTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type KnownCodesStorageTransactorRaw struct {
+	Contract *KnownCodesStorageTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewKnownCodesStorage creates a new instance of KnownCodesStorage, bound to a specific deployed contract.
+func NewKnownCodesStorage(address common.Address, backend bind.ContractBackend) (*KnownCodesStorage, error) {
+	contract, err := bindKnownCodesStorage(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &KnownCodesStorage{KnownCodesStorageCaller: KnownCodesStorageCaller{contract: contract}, KnownCodesStorageTransactor: KnownCodesStorageTransactor{contract: contract}, KnownCodesStorageFilterer: KnownCodesStorageFilterer{contract: contract}}, nil
+}
+
+// NewKnownCodesStorageCaller creates a new read-only instance of KnownCodesStorage, bound to a specific deployed contract.
+func NewKnownCodesStorageCaller(address common.Address, caller bind.ContractCaller) (*KnownCodesStorageCaller, error) {
+	contract, err := bindKnownCodesStorage(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &KnownCodesStorageCaller{contract: contract}, nil
+}
+
+// NewKnownCodesStorageTransactor creates a new write-only instance of KnownCodesStorage, bound to a specific deployed contract.
+func NewKnownCodesStorageTransactor(address common.Address, transactor bind.ContractTransactor) (*KnownCodesStorageTransactor, error) {
+	contract, err := bindKnownCodesStorage(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &KnownCodesStorageTransactor{contract: contract}, nil
+}
+
+// NewKnownCodesStorageFilterer creates a new log filterer instance of KnownCodesStorage, bound to a specific deployed contract.
+func NewKnownCodesStorageFilterer(address common.Address, filterer bind.ContractFilterer) (*KnownCodesStorageFilterer, error) {
+	contract, err := bindKnownCodesStorage(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &KnownCodesStorageFilterer{contract: contract}, nil
+}
+
+// bindKnownCodesStorage binds a generic wrapper to an already deployed contract.
+func bindKnownCodesStorage(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := KnownCodesStorageMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_KnownCodesStorage *KnownCodesStorageRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _KnownCodesStorage.Contract.KnownCodesStorageCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_KnownCodesStorage *KnownCodesStorageRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _KnownCodesStorage.Contract.KnownCodesStorageTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_KnownCodesStorage *KnownCodesStorageRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _KnownCodesStorage.Contract.KnownCodesStorageTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_KnownCodesStorage *KnownCodesStorageCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _KnownCodesStorage.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_KnownCodesStorage *KnownCodesStorageTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _KnownCodesStorage.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_KnownCodesStorage *KnownCodesStorageTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _KnownCodesStorage.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetMarker is a free data retrieval call binding the contract method 0x4c6314f0.
+//
+// Solidity: function getMarker(bytes32 _hash) view returns(uint256)
+func (_KnownCodesStorage *KnownCodesStorageCaller) GetMarker(opts *bind.CallOpts, _hash [32]byte) (*big.Int, error) {
+	var out []interface{}
+	err := _KnownCodesStorage.contract.Call(opts, &out, "getMarker", _hash)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetMarker is a free data retrieval call binding the contract method 0x4c6314f0.
+//
+// Solidity: function getMarker(bytes32 _hash) view returns(uint256)
+func (_KnownCodesStorage *KnownCodesStorageSession) GetMarker(_hash [32]byte) (*big.Int, error) {
+	return _KnownCodesStorage.Contract.GetMarker(&_KnownCodesStorage.CallOpts, _hash)
+}
+
+// GetMarker is a free data retrieval call binding the contract method 0x4c6314f0.
+//
+// Solidity: function getMarker(bytes32 _hash) view returns(uint256)
+func (_KnownCodesStorage *KnownCodesStorageCallerSession) GetMarker(_hash [32]byte) (*big.Int, error) {
+	return _KnownCodesStorage.Contract.GetMarker(&_KnownCodesStorage.CallOpts, _hash)
+}
+
+// MarkBytecodeAsPublished is a paid mutator transaction binding the contract method 0x79c4f929.
+//
+// Solidity: function markBytecodeAsPublished(bytes32 _bytecodeHash) returns()
+func (_KnownCodesStorage *KnownCodesStorageTransactor) MarkBytecodeAsPublished(opts *bind.TransactOpts, _bytecodeHash [32]byte) (*types.Transaction, error) {
+	return _KnownCodesStorage.contract.Transact(opts, "markBytecodeAsPublished", _bytecodeHash)
+}
+
+// MarkBytecodeAsPublished is a paid mutator transaction binding the contract method 0x79c4f929.
+//
+// Solidity: function markBytecodeAsPublished(bytes32 _bytecodeHash) returns()
+func (_KnownCodesStorage *KnownCodesStorageSession) MarkBytecodeAsPublished(_bytecodeHash [32]byte) (*types.Transaction, error) {
+	return _KnownCodesStorage.Contract.MarkBytecodeAsPublished(&_KnownCodesStorage.TransactOpts, _bytecodeHash)
+}
+
+// MarkBytecodeAsPublished is a paid mutator transaction binding the contract method 0x79c4f929.
+//
+// Solidity: function markBytecodeAsPublished(bytes32 _bytecodeHash) returns()
+func (_KnownCodesStorage *KnownCodesStorageTransactorSession) MarkBytecodeAsPublished(_bytecodeHash [32]byte) (*types.Transaction, error) {
+	return _KnownCodesStorage.Contract.MarkBytecodeAsPublished(&_KnownCodesStorage.TransactOpts, _bytecodeHash)
+}
+
+// MarkFactoryDeps is a paid mutator transaction binding the contract method 0xe516761e.
+//
+// Solidity: function markFactoryDeps(bool _shouldSendToL1, bytes32[] _hashes) returns()
+func (_KnownCodesStorage *KnownCodesStorageTransactor) MarkFactoryDeps(opts *bind.TransactOpts, _shouldSendToL1 bool, _hashes [][32]byte) (*types.Transaction, error) {
+	return _KnownCodesStorage.contract.Transact(opts, "markFactoryDeps", _shouldSendToL1, _hashes)
+}
+
+// MarkFactoryDeps is a paid mutator transaction binding the contract method 0xe516761e.
+//
+// Solidity: function markFactoryDeps(bool _shouldSendToL1, bytes32[] _hashes) returns()
+func (_KnownCodesStorage *KnownCodesStorageSession) MarkFactoryDeps(_shouldSendToL1 bool, _hashes [][32]byte) (*types.Transaction, error) {
+	return _KnownCodesStorage.Contract.MarkFactoryDeps(&_KnownCodesStorage.TransactOpts, _shouldSendToL1, _hashes)
+}
+
+// MarkFactoryDeps is a paid mutator transaction binding the contract method 0xe516761e.
+//
+// Solidity: function markFactoryDeps(bool _shouldSendToL1, bytes32[] _hashes) returns()
+func (_KnownCodesStorage *KnownCodesStorageTransactorSession) MarkFactoryDeps(_shouldSendToL1 bool, _hashes [][32]byte) (*types.Transaction, error) {
+	return _KnownCodesStorage.Contract.MarkFactoryDeps(&_KnownCodesStorage.TransactOpts, _shouldSendToL1, _hashes)
+}
+
+// KnownCodesStorageMarkedAsKnownIterator is returned from FilterMarkedAsKnown and is used to iterate over the raw logs and unpacked data for MarkedAsKnown events raised by the KnownCodesStorage contract.
+type KnownCodesStorageMarkedAsKnownIterator struct {
+	Event *KnownCodesStorageMarkedAsKnown // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *KnownCodesStorageMarkedAsKnownIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(KnownCodesStorageMarkedAsKnown)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(KnownCodesStorageMarkedAsKnown)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *KnownCodesStorageMarkedAsKnownIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *KnownCodesStorageMarkedAsKnownIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// KnownCodesStorageMarkedAsKnown represents a MarkedAsKnown event raised by the KnownCodesStorage contract.
+type KnownCodesStorageMarkedAsKnown struct {
+	BytecodeHash     [32]byte
+	SendBytecodeToL1 bool
+	Raw              types.Log // Blockchain specific contextual infos
+}
+
+// FilterMarkedAsKnown is a free log retrieval operation binding the contract event 0xc94722ff13eacf53547c4741dab5228353a05938ffcdd5d4a2d533ae0e618287.
+//
+// Solidity: event MarkedAsKnown(bytes32 indexed bytecodeHash, bool indexed sendBytecodeToL1)
+func (_KnownCodesStorage *KnownCodesStorageFilterer) FilterMarkedAsKnown(opts *bind.FilterOpts, bytecodeHash [][32]byte, sendBytecodeToL1 []bool) (*KnownCodesStorageMarkedAsKnownIterator, error) {
+
+	var bytecodeHashRule []interface{}
+	for _, bytecodeHashItem := range bytecodeHash {
+		bytecodeHashRule = append(bytecodeHashRule, bytecodeHashItem)
+	}
+	var sendBytecodeToL1Rule []interface{}
+	for _, sendBytecodeToL1Item := range sendBytecodeToL1 {
+		sendBytecodeToL1Rule = append(sendBytecodeToL1Rule, sendBytecodeToL1Item)
+	}
+
+	logs, sub, err := _KnownCodesStorage.contract.FilterLogs(opts, "MarkedAsKnown", bytecodeHashRule, sendBytecodeToL1Rule)
+	if err != nil {
+		return nil, err
+	}
+	return &KnownCodesStorageMarkedAsKnownIterator{contract: _KnownCodesStorage.contract, event: "MarkedAsKnown", logs: logs, sub: sub}, nil
+}
+
+// WatchMarkedAsKnown is a free log subscription operation binding the contract event 0xc94722ff13eacf53547c4741dab5228353a05938ffcdd5d4a2d533ae0e618287.
+//
+// Solidity: event MarkedAsKnown(bytes32 indexed bytecodeHash, bool indexed sendBytecodeToL1)
+func (_KnownCodesStorage *KnownCodesStorageFilterer) WatchMarkedAsKnown(opts *bind.WatchOpts, sink chan<- *KnownCodesStorageMarkedAsKnown, bytecodeHash [][32]byte, sendBytecodeToL1 []bool) (event.Subscription, error) {
+
+	var bytecodeHashRule []interface{}
+	for _, bytecodeHashItem := range bytecodeHash {
+		bytecodeHashRule = append(bytecodeHashRule, bytecodeHashItem)
+	}
+	var sendBytecodeToL1Rule []interface{}
+	for _, sendBytecodeToL1Item := range sendBytecodeToL1 {
+		sendBytecodeToL1Rule = append(sendBytecodeToL1Rule, sendBytecodeToL1Item)
+	}
+
+	logs, sub, err := _KnownCodesStorage.contract.WatchLogs(opts, "MarkedAsKnown", bytecodeHashRule, sendBytecodeToL1Rule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(KnownCodesStorageMarkedAsKnown)
+				if err := _KnownCodesStorage.contract.UnpackLog(event, "MarkedAsKnown", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseMarkedAsKnown is a log parse operation binding the contract event 0xc94722ff13eacf53547c4741dab5228353a05938ffcdd5d4a2d533ae0e618287.
+//
+// Solidity: event MarkedAsKnown(bytes32 indexed bytecodeHash, bool indexed sendBytecodeToL1)
+func (_KnownCodesStorage *KnownCodesStorageFilterer) ParseMarkedAsKnown(log types.Log) (*KnownCodesStorageMarkedAsKnown, error) {
+	event := new(KnownCodesStorageMarkedAsKnown)
+	if err := _KnownCodesStorage.contract.UnpackLog(event, "MarkedAsKnown", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,603 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package nonceholder
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// NonceHolderMetaData contains all meta data concerning the NonceHolder contract.
+var NonceHolderMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"event\",\"name\":\"ValueSetUnderNonce\",\"anonymous\":false,\"inputs\":[{\"name\":\"accountAddress\",\"type\":\"address\",\"indexed\":true},{\"name\":\"key\",\"type\":\"uint256\",\"indexed\":true},{\"name\":\"value\",\"type\":\"uint256\",\"indexed\":false}]},{\"type\":\"function\",\"name\":\"getDeploymentNonce\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"_address\",\"type\":\"address\"}],\"outputs\":[{\"name\":\"deploymentNonce\",\"type\":\"uint256\"}]},{\"type\":\"function\",\"name\":\"getMinNonce\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"_address\",\"type\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}]},{\"type\":\"function\",\"name\":\"getRawNonce\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"_address\",\"type\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}]},{\"type\":\"function\",\"name\":\"getValueUnderNonce\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"_key\",\"type\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}]},{\"type\":\"function\",\"name\":\"increaseMinNonce\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"_value\",\"type\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}]},{\"type\":\"function\",\"name\":\"incrementDeploymentNonce\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"_address\",\"type\":\"address\"}],\"outputs\":[{\"name\":\"prevDeploymentNonce\",\"type\":\"uint256\"}]},{\"type\":\"function\",\"name\":\"incrementMinNonceIfEquals\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"_expectedNonce\",\"type\":\"uint256\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"isNonceUsed\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"_address\",\"type\":\"address\"},{\"name\":\"_nonce\",\"type\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}]},{\"type\":\"function\",\"name\":\"setValueUnderNonce\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"_key\",\"type\":\"uint256\"},{\"name\":\"_value\",\"type\":\"uint256\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"validateNonceUsage\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"_address\",\"type\":\"address\"},{\"name\":\"_key\",\"type\":\"uint256\"},{\"name\":\"_shouldBeUsed\",\"type\":\"bool\"}],\"outputs\":[]}]",
+}
+
+// NonceHolderABI is the input ABI used to generate the binding from.
+// Deprecated: Use NonceHolderMetaData.ABI instead.
+var NonceHolderABI = NonceHolderMetaData.ABI
+
+// NonceHolder is an auto generated Go binding around an Ethereum contract.
+type NonceHolder struct {
+	NonceHolderCaller     // Read-only binding to the contract
+	NonceHolderTransactor // Write-only binding to the contract
+	NonceHolderFilterer   // Log filterer for contract events
+}
+
+// NonceHolderCaller is an auto generated read-only Go binding around an Ethereum contract.
+type NonceHolderCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NonceHolderTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type NonceHolderTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NonceHolderFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type NonceHolderFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NonceHolderSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type NonceHolderSession struct {
+	Contract     *NonceHolder      // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// NonceHolderCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type NonceHolderCallerSession struct {
+	Contract *NonceHolderCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts      // Call options to use throughout this session
+}
+
+// NonceHolderTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type NonceHolderTransactorSession struct {
+	Contract     *NonceHolderTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts      // Transaction auth options to use throughout this session
+}
+
+// NonceHolderRaw is an auto generated low-level Go binding around an Ethereum contract.
+type NonceHolderRaw struct {
+	Contract *NonceHolder // Generic contract binding to access the raw methods on
+}
+
+// NonceHolderCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type NonceHolderCallerRaw struct {
+	Contract *NonceHolderCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// NonceHolderTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type NonceHolderTransactorRaw struct {
+	Contract *NonceHolderTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewNonceHolder creates a new instance of NonceHolder, bound to a specific deployed contract.
+func NewNonceHolder(address common.Address, backend bind.ContractBackend) (*NonceHolder, error) {
+	contract, err := bindNonceHolder(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &NonceHolder{NonceHolderCaller: NonceHolderCaller{contract: contract}, NonceHolderTransactor: NonceHolderTransactor{contract: contract}, NonceHolderFilterer: NonceHolderFilterer{contract: contract}}, nil
+}
+
+// NewNonceHolderCaller creates a new read-only instance of NonceHolder, bound to a specific deployed contract.
+func NewNonceHolderCaller(address common.Address, caller bind.ContractCaller) (*NonceHolderCaller, error) {
+	contract, err := bindNonceHolder(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &NonceHolderCaller{contract: contract}, nil
+}
+
+// NewNonceHolderTransactor creates a new write-only instance of NonceHolder, bound to a specific deployed contract.
+func NewNonceHolderTransactor(address common.Address, transactor bind.ContractTransactor) (*NonceHolderTransactor, error) {
+	contract, err := bindNonceHolder(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &NonceHolderTransactor{contract: contract}, nil
+}
+
+// NewNonceHolderFilterer creates a new log filterer instance of NonceHolder, bound to a specific deployed contract.
+func NewNonceHolderFilterer(address common.Address, filterer bind.ContractFilterer) (*NonceHolderFilterer, error) {
+	contract, err := bindNonceHolder(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &NonceHolderFilterer{contract: contract}, nil
+}
+
+// bindNonceHolder binds a generic wrapper to an already deployed contract.
+func bindNonceHolder(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := NonceHolderMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_NonceHolder *NonceHolderRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _NonceHolder.Contract.NonceHolderCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_NonceHolder *NonceHolderRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _NonceHolder.Contract.NonceHolderTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_NonceHolder *NonceHolderRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _NonceHolder.Contract.NonceHolderTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_NonceHolder *NonceHolderCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _NonceHolder.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_NonceHolder *NonceHolderTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _NonceHolder.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_NonceHolder *NonceHolderTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _NonceHolder.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetDeploymentNonce is a free data retrieval call binding the contract method 0xfb1a9a57.
+//
+// Solidity: function getDeploymentNonce(address _address) view returns(uint256 deploymentNonce)
+func (_NonceHolder *NonceHolderCaller) GetDeploymentNonce(opts *bind.CallOpts, _address common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _NonceHolder.contract.Call(opts, &out, "getDeploymentNonce", _address)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetDeploymentNonce is a free data retrieval call binding the contract method 0xfb1a9a57.
+//
+// Solidity: function getDeploymentNonce(address _address) view returns(uint256 deploymentNonce)
+func (_NonceHolder *NonceHolderSession) GetDeploymentNonce(_address common.Address) (*big.Int, error) {
+	return _NonceHolder.Contract.GetDeploymentNonce(&_NonceHolder.CallOpts, _address)
+}
+
+// GetDeploymentNonce is a free data retrieval call binding the contract method 0xfb1a9a57.
+//
+// Solidity: function getDeploymentNonce(address _address) view returns(uint256 deploymentNonce)
+func (_NonceHolder *NonceHolderCallerSession) GetDeploymentNonce(_address common.Address) (*big.Int, error) {
+	return _NonceHolder.Contract.GetDeploymentNonce(&_NonceHolder.CallOpts, _address)
+}
+
+// GetMinNonce is a free data retrieval call binding the contract method 0x896909dc.
+//
+// Solidity: function getMinNonce(address _address) view returns(uint256)
+func (_NonceHolder *NonceHolderCaller) GetMinNonce(opts *bind.CallOpts, _address common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _NonceHolder.contract.Call(opts, &out, "getMinNonce", _address)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetMinNonce is a free data retrieval call binding the contract method 0x896909dc.
+//
+// Solidity: function getMinNonce(address _address) view returns(uint256)
+func (_NonceHolder *NonceHolderSession) GetMinNonce(_address common.Address) (*big.Int, error) {
+	return _NonceHolder.Contract.GetMinNonce(&_NonceHolder.CallOpts, _address)
+}
+
+// GetMinNonce is a free data retrieval call binding the contract method 0x896909dc.
+//
+// Solidity: function getMinNonce(address _address) view returns(uint256)
+func (_NonceHolder *NonceHolderCallerSession) GetMinNonce(_address common.Address) (*big.Int, error) {
+	return _NonceHolder.Contract.GetMinNonce(&_NonceHolder.CallOpts, _address)
+}
+
+// GetRawNonce is a free data retrieval call binding the contract method 0x5aa9b6b5.
+//
+// Solidity: function getRawNonce(address _address) view returns(uint256)
+func (_NonceHolder *NonceHolderCaller) GetRawNonce(opts *bind.CallOpts, _address common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _NonceHolder.contract.Call(opts, &out, "getRawNonce", _address)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetRawNonce is a free data retrieval call binding the contract method 0x5aa9b6b5.
+//
+// Solidity: function getRawNonce(address _address) view returns(uint256)
+func (_NonceHolder *NonceHolderSession) GetRawNonce(_address common.Address) (*big.Int, error) {
+	return _NonceHolder.Contract.GetRawNonce(&_NonceHolder.CallOpts, _address)
+}
+
+// GetRawNonce is a free data retrieval call binding the contract method 0x5aa9b6b5.
+//
+// Solidity: function getRawNonce(address _address) view returns(uint256)
+func (_NonceHolder *NonceHolderCallerSession) GetRawNonce(_address common.Address) (*big.Int, error) {
+	return _NonceHolder.Contract.GetRawNonce(&_NonceHolder.CallOpts, _address)
+}
+
+// GetValueUnderNonce is a free data retrieval call binding the contract method 0x55d35d18.
+//
+// Solidity: function getValueUnderNonce(uint256 _key) view returns(uint256)
+func (_NonceHolder *NonceHolderCaller) GetValueUnderNonce(opts *bind.CallOpts, _key *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _NonceHolder.contract.Call(opts, &out, "getValueUnderNonce", _key)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetValueUnderNonce is a free data retrieval call binding the contract method 0x55d35d18.
+//
+// Solidity: function getValueUnderNonce(uint256 _key) view returns(uint256)
+func (_NonceHolder *NonceHolderSession) GetValueUnderNonce(_key *big.Int) (*big.Int, error) {
+	return _NonceHolder.Contract.GetValueUnderNonce(&_NonceHolder.CallOpts, _key)
+}
+
+// GetValueUnderNonce is a free data retrieval call binding the contract method 0x55d35d18.
+//
+// Solidity: function getValueUnderNonce(uint256 _key) view returns(uint256)
+func (_NonceHolder *NonceHolderCallerSession) GetValueUnderNonce(_key *big.Int) (*big.Int, error) {
+	return _NonceHolder.Contract.GetValueUnderNonce(&_NonceHolder.CallOpts, _key)
+}
+
+// IsNonceUsed is a free data retrieval call binding the contract method 0xcab7e8eb.
+//
+// Solidity: function isNonceUsed(address _address, uint256 _nonce) view returns(bool)
+func (_NonceHolder *NonceHolderCaller) IsNonceUsed(opts *bind.CallOpts, _address common.Address, _nonce *big.Int) (bool, error) {
+	var out []interface{}
+	err := _NonceHolder.contract.Call(opts, &out, "isNonceUsed", _address, _nonce)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsNonceUsed is a free data retrieval call binding the contract method 0xcab7e8eb.
+//
+// Solidity: function isNonceUsed(address _address, uint256 _nonce) view returns(bool)
+func (_NonceHolder *NonceHolderSession) IsNonceUsed(_address common.Address, _nonce *big.Int) (bool, error) {
+	return _NonceHolder.Contract.IsNonceUsed(&_NonceHolder.CallOpts, _address, _nonce)
+}
+
+// IsNonceUsed is a free data retrieval call binding the contract method 0xcab7e8eb.
+//
+// Solidity: function isNonceUsed(address _address, uint256 _nonce) view returns(bool)
+func (_NonceHolder *NonceHolderCallerSession) IsNonceUsed(_address common.Address, _nonce *big.Int) (bool, error) {
+	return _NonceHolder.Contract.IsNonceUsed(&_NonceHolder.CallOpts, _address, _nonce)
+}
+
+// ValidateNonceUsage is a free data retrieval call binding the contract method 0x6ee1dc20.
+//
+// Solidity: function validateNonceUsage(address _address, uint256 _key, bool _shouldBeUsed) view returns()
+func (_NonceHolder *NonceHolderCaller) ValidateNonceUsage(opts *bind.CallOpts, _address common.Address, _key *big.Int, _shouldBeUsed bool) error {
+	var out []interface{}
+	err := _NonceHolder.contract.Call(opts, &out, "validateNonceUsage", _address, _key, _shouldBeUsed)
+
+	if err != nil {
+		return err
+	}
+
+	return err
+
+}
+
+// ValidateNonceUsage is a free data retrieval call binding the contract method 0x6ee1dc20.
+//
+// Solidity: function validateNonceUsage(address _address, uint256 _key, bool _shouldBeUsed) view returns()
+func (_NonceHolder *NonceHolderSession) ValidateNonceUsage(_address common.Address, _key *big.Int, _shouldBeUsed bool) error {
+	return _NonceHolder.Contract.ValidateNonceUsage(&_NonceHolder.CallOpts, _address, _key, _shouldBeUsed)
+}
+
+// ValidateNonceUsage is a free data retrieval call binding the contract method 0x6ee1dc20.
+//
+// Solidity: function validateNonceUsage(address _address, uint256 _key, bool _shouldBeUsed) view returns()
+func (_NonceHolder *NonceHolderCallerSession) ValidateNonceUsage(_address common.Address, _key *big.Int, _shouldBeUsed bool) error {
+	return _NonceHolder.Contract.ValidateNonceUsage(&_NonceHolder.CallOpts, _address, _key, _shouldBeUsed)
+}
+
+// IncreaseMinNonce is a paid mutator transaction binding the contract method 0x38a78092.
+//
+// Solidity: function increaseMinNonce(uint256 _value) returns(uint256)
+func (_NonceHolder *NonceHolderTransactor) IncreaseMinNonce(opts *bind.TransactOpts, _value *big.Int) (*types.Transaction, error) {
+	return _NonceHolder.contract.Transact(opts, "increaseMinNonce", _value)
+}
+
+// IncreaseMinNonce is a paid mutator transaction binding the contract method 0x38a78092.
+//
+// Solidity: function increaseMinNonce(uint256 _value) returns(uint256)
+func (_NonceHolder *NonceHolderSession) IncreaseMinNonce(_value *big.Int) (*types.Transaction, error) {
+	return _NonceHolder.Contract.IncreaseMinNonce(&_NonceHolder.TransactOpts, _value)
+}
+
+// IncreaseMinNonce is a paid mutator transaction binding the contract method 0x38a78092.
+//
+// Solidity: function increaseMinNonce(uint256 _value) returns(uint256)
+func (_NonceHolder *NonceHolderTransactorSession) IncreaseMinNonce(_value *big.Int) (*types.Transaction, error) {
+	return _NonceHolder.Contract.IncreaseMinNonce(&_NonceHolder.TransactOpts, _value)
+}
+
+// IncrementDeploymentNonce is a paid mutator transaction binding the contract method 0x306395c6.
+//
+// Solidity: function incrementDeploymentNonce(address _address) returns(uint256 prevDeploymentNonce)
+func (_NonceHolder *NonceHolderTransactor) IncrementDeploymentNonce(opts *bind.TransactOpts, _address common.Address) (*types.Transaction, error) {
+	return _NonceHolder.contract.Transact(opts, "incrementDeploymentNonce", _address)
+}
+
+// IncrementDeploymentNonce is a paid mutator transaction binding the contract method 0x306395c6.
+//
+// Solidity: function incrementDeploymentNonce(address _address) returns(uint256 prevDeploymentNonce)
+func (_NonceHolder *NonceHolderSession) IncrementDeploymentNonce(_address common.Address) (*types.Transaction, error) {
+	return _NonceHolder.Contract.IncrementDeploymentNonce(&_NonceHolder.TransactOpts, _address)
+}
+
+// IncrementDeploymentNonce is a paid mutator transaction binding the contract method 0x306395c6.
+//
+// Solidity: function incrementDeploymentNonce(address _address) returns(uint256 prevDeploymentNonce)
+func (_NonceHolder *NonceHolderTransactorSession) IncrementDeploymentNonce(_address common.Address) (*types.Transaction, error) {
+	return _NonceHolder.Contract.IncrementDeploymentNonce(&_NonceHolder.TransactOpts, _address)
+}
+
+// IncrementMinNonceIfEquals is a paid mutator transaction binding the contract method 0xe1239cd8.
+//
+// Solidity: function incrementMinNonceIfEquals(uint256 _expectedNonce) returns()
+func (_NonceHolder *NonceHolderTransactor) IncrementMinNonceIfEquals(opts *bind.TransactOpts, _expectedNonce *big.Int) (*types.Transaction, error) {
+	return _NonceHolder.contract.Transact(opts, "incrementMinNonceIfEquals", _expectedNonce)
+}
+
+// IncrementMinNonceIfEquals is a paid mutator transaction binding the contract method 0xe1239cd8.
+//
+// Solidity: function incrementMinNonceIfEquals(uint256 _expectedNonce) returns()
+func (_NonceHolder *NonceHolderSession) IncrementMinNonceIfEquals(_expectedNonce *big.Int) (*types.Transaction, error) {
+	return _NonceHolder.Contract.IncrementMinNonceIfEquals(&_NonceHolder.TransactOpts, _expectedNonce)
+}
+
+// IncrementMinNonceIfEquals is a paid mutator transaction binding the contract method 0xe1239cd8.
+//
+// Solidity: function incrementMinNonceIfEquals(uint256 _expectedNonce) returns()
+func (_NonceHolder *NonceHolderTransactorSession) IncrementMinNonceIfEquals(_expectedNonce *big.Int) (*types.Transaction, error) {
+	return _NonceHolder.Contract.IncrementMinNonceIfEquals(&_NonceHolder.TransactOpts, _expectedNonce)
+}
+
+// SetValueUnderNonce is a paid mutator transaction binding the contract method 0x155fd27a.
+//
+// Solidity: function setValueUnderNonce(uint256 _key, uint256 _value) returns()
+func (_NonceHolder *NonceHolderTransactor) SetValueUnderNonce(opts *bind.TransactOpts, _key *big.Int, _value *big.Int) (*types.Transaction, error) {
+	return _NonceHolder.contract.Transact(opts, "setValueUnderNonce", _key, _value)
+}
+
+// SetValueUnderNonce is a paid mutator transaction binding the contract method 0x155fd27a.
+//
+// Solidity: function setValueUnderNonce(uint256 _key, uint256 _value) returns()
+func (_NonceHolder *NonceHolderSession) SetValueUnderNonce(_key *big.Int, _value *big.Int) (*types.Transaction, error) {
+	return _NonceHolder.Contract.SetValueUnderNonce(&_NonceHolder.TransactOpts, _key, _value)
+}
+
+// SetValueUnderNonce is a paid mutator transaction binding the contract method 0x155fd27a.
+//
+// Solidity: function setValueUnderNonce(uint256 _key, uint256 _value) returns()
+func (_NonceHolder *NonceHolderTransactorSession) SetValueUnderNonce(_key *big.Int, _value *big.Int) (*types.Transaction, error) {
+	return _NonceHolder.Contract.SetValueUnderNonce(&_NonceHolder.TransactOpts, _key, _value)
+}
+
+// NonceHolderValueSetUnderNonceIterator is returned from FilterValueSetUnderNonce and is used to iterate over the raw logs and unpacked data for ValueSetUnderNonce events raised by the NonceHolder contract.
+type NonceHolderValueSetUnderNonceIterator struct {
+	Event *NonceHolderValueSetUnderNonce // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NonceHolderValueSetUnderNonceIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NonceHolderValueSetUnderNonce)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NonceHolderValueSetUnderNonce)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NonceHolderValueSetUnderNonceIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NonceHolderValueSetUnderNonceIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NonceHolderValueSetUnderNonce represents a ValueSetUnderNonce event raised by the NonceHolder contract.
+type NonceHolderValueSetUnderNonce struct {
+	AccountAddress common.Address
+	Key            *big.Int
+	Value          *big.Int
+	Raw            types.Log // Blockchain specific contextual infos
+}
+
+// FilterValueSetUnderNonce is a free log retrieval operation binding the contract event 0xda2b716e5a5d5f602b9a5842bcd89c215b125258dfea271a03e5e0e801d93a8c.
+//
+// Solidity: event ValueSetUnderNonce(address indexed accountAddress, uint256 indexed key, uint256 value)
+func (_NonceHolder *NonceHolderFilterer) FilterValueSetUnderNonce(opts *bind.FilterOpts, accountAddress []common.Address, key []*big.Int) (*NonceHolderValueSetUnderNonceIterator, error) {
+
+	var accountAddressRule []interface{}
+	for _, accountAddressItem := range accountAddress {
+		accountAddressRule = append(accountAddressRule, accountAddressItem)
+	}
+	var keyRule []interface{}
+	for _, keyItem := range key {
+		keyRule = append(keyRule, keyItem)
+	}
+
+	logs, sub, err := _NonceHolder.contract.FilterLogs(opts, "ValueSetUnderNonce", accountAddressRule, keyRule)
+	if err != nil {
+		return nil, err
+	}
+	return &NonceHolderValueSetUnderNonceIterator{contract: _NonceHolder.contract, event: "ValueSetUnderNonce", logs: logs, sub: sub}, nil
+}
+
+// WatchValueSetUnderNonce is a free log subscription operation binding the contract event 0xda2b716e5a5d5f602b9a5842bcd89c215b125258dfea271a03e5e0e801d93a8c.
+//
+// Solidity: event ValueSetUnderNonce(address indexed accountAddress, uint256 indexed key, uint256 value)
+func (_NonceHolder *NonceHolderFilterer) WatchValueSetUnderNonce(opts *bind.WatchOpts, sink chan<- *NonceHolderValueSetUnderNonce, accountAddress []common.Address, key []*big.Int) (event.Subscription, error) {
+
+	var accountAddressRule []interface{}
+	for _, accountAddressItem := range accountAddress {
+		accountAddressRule = append(accountAddressRule, accountAddressItem)
+	}
+	var keyRule []interface{}
+	for _, keyItem := range key {
+		keyRule = append(keyRule, keyItem)
+	}
+
+	logs, sub, err := _NonceHolder.contract.WatchLogs(opts, "ValueSetUnderNonce", accountAddressRule, keyRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NonceHolderValueSetUnderNonce)
+				if err := _NonceHolder.contract.UnpackLog(event, "ValueSetUnderNonce", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseValueSetUnderNonce is a log parse operation binding the contract event 0xda2b716e5a5d5f602b9a5842bcd89c215b125258dfea271a03e5e0e801d93a8c.
+//
+// Solidity: event ValueSetUnderNonce(address indexed accountAddress, uint256 indexed key, uint256 value)
+func (_NonceHolder *NonceHolderFilterer) ParseValueSetUnderNonce(log types.Log) (*NonceHolderValueSetUnderNonce, error) {
+	event := new(NonceHolderValueSetUnderNonce)
+	if err := _NonceHolder.contract.UnpackLog(event, "ValueSetUnderNonce", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
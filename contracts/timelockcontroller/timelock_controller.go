@@ -0,0 +1,992 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package timelockcontroller
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// ITimelockControllerMetaData contains all meta data concerning the ITimelockController contract.
+var ITimelockControllerMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"getMinDelay\",\"stateMutability\":\"view\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}]},{\"type\":\"function\",\"name\":\"getTimestamp\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"id\",\"type\":\"bytes32\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}]},{\"type\":\"function\",\"name\":\"isOperation\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"id\",\"type\":\"bytes32\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}]},{\"type\":\"function\",\"name\":\"isOperationPending\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"id\",\"type\":\"bytes32\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}]},{\"type\":\"function\",\"name\":\"isOperationReady\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"id\",\"type\":\"bytes32\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}]},{\"type\":\"function\",\"name\":\"isOperationDone\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"id\",\"type\":\"bytes32\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}]},{\"type\":\"function\",\"name\":\"hashOperation\",\"stateMutability\":\"pure\",\"inputs\":[{\"name\":\"target\",\"type\":\"address\"},{\"name\":\"value\",\"type\":\"uint256\"},{\"name\":\"data\",\"type\":\"bytes\"},{\"name\":\"predecessor\",\"type\":\"bytes32\"},{\"name\":\"salt\",\"type\":\"bytes32\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes32\"}]},{\"type\":\"function\",\"name\":\"hashOperationBatch\",\"stateMutability\":\"pure\",\"inputs\":[{\"name\":\"targets\",\"type\":\"address[]\"},{\"name\":\"values\",\"type\":\"uint256[]\"},{\"name\":\"payloads\",\"type\":\"bytes[]\"},{\"name\":\"predecessor\",\"type\":\"bytes32\"},{\"name\":\"salt\",\"type\":\"bytes32\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes32\"}]},{\"type\":\"function\",\"name\":\"schedule\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"target\",\"type\":\"address\"},{\"name\":\"value\",\"type\":\"uint256\"},{\"name\":\"data\",\"type\":\"bytes\"},{\"name\":\"predecessor\",\"type\":\"bytes32\"},{\"name\":\"salt\",\"type\":\"bytes32\"},{\"name\":\"delay\",\"type\":\"uint256\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"scheduleBatch\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"targets\",\"type\":\"address[]\"},{\"name\":\"values\",\"type\":\"uint256[]\"},{\"name\":\"payloads\",\"type\":\"bytes[]\"},{\"name\":\"predecessor\",\"type\":\"bytes32\"},{\"name\":\"salt\",\"type\":\"bytes32\"},{\"name\":\"delay\",\"type\":\"uint256\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"execute\",\"stateMutability\":\"payable\",\"inputs\":[{\"name\":\"target\",\"type\":\"address\"},{\"name\":\"value\",\"type\":\"uint256\"},{\"name\":\"payload\",\"type\":\"bytes\"},{\"name\":\"predecessor\",\"type\":\"bytes32\"},{\"name\":\"salt\",\"type\":\"bytes32\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"executeBatch\",\"stateMutability\":\"payable\",\"inputs\":[{\"name\":\"targets\",\"type\":\"address[]\"},{\"name\":\"values\",\"type\":\"uint256[]\"},{\"name\":\"payloads\",\"type\":\"bytes[]\"},{\"name\":\"predecessor\",\"type\":\"bytes32\"},{\"name\":\"salt\",\"type\":\"bytes32\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"cancel\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"id\",\"type\":\"bytes32\"}],\"outputs\":[]},{\"type\":\"event\",\"name\":\"CallScheduled\",\"anonymous\":false,\"inputs\":[{\"name\":\"id\",\"type\":\"bytes32\",\"indexed\":true},{\"name\":\"index\",\"type\":\"uint256\",\"indexed\":true},{\"name\":\"target\",\"type\":\"address\",\"indexed\":false},{\"name\":\"value\",\"type\":\"uint256\",\"indexed\":false},{\"name\":\"data\",\"type\":\"bytes\",\"indexed\":false},{\"name\":\"predecessor\",\"type\":\"bytes32\",\"indexed\":false},{\"name\":\"delay\",\"type\":\"uint256\",\"indexed\":false}]},{\"type\":\"event\",\"name\":\"CallExecuted\",\"anonymous\":false,\"inputs\":[{\"name\":\"id\",\"type\":\"bytes32\",\"indexed\":true},{\"name\":\"index\",\"type\":\"uint256\",\"indexed\":true},{\"name\":\"target\",\"type\":\"address\",\"indexed\":false},{\"name\":\"value\",\"type\":\"uint256\",\"indexed\":false},{\"name\":\"data\",\"type\":\"bytes\",\"indexed\":false}]},{\"type\":\"event\",\"name\":\"Cancelled\",\"anonymous\":false,\"inputs\":[{\"name\":\"id\",\"type\":\"bytes32\",\"indexed\":true}]}]",
+}
+
+// ITimelockControllerABI is the input ABI used to generate the binding from.
+// Deprecated: Use ITimelockControllerMetaData.ABI instead.
+var ITimelockControllerABI = ITimelockControllerMetaData.ABI
+
+// ITimelockController is an auto generated Go binding around an Ethereum contract.
+type ITimelockController struct {
+	ITimelockControllerCaller     // Read-only binding to the contract
+	ITimelockControllerTransactor // Write-only binding to the contract
+	ITimelockControllerFilterer   // Log filterer for contract events
+}
+
+// ITimelockControllerCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ITimelockControllerCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ITimelockControllerTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ITimelockControllerTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ITimelockControllerFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ITimelockControllerFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ITimelockControllerSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ITimelockControllerSession struct {
+	Contract     *ITimelockController // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts        // Call options to use throughout this session
+	TransactOpts bind.TransactOpts    // Transaction auth options to use throughout this session
+}
+
+// ITimelockControllerCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ITimelockControllerCallerSession struct {
+	Contract *ITimelockControllerCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts              // Call options to use throughout this session
+}
+
+// ITimelockControllerTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ITimelockControllerTransactorSession struct {
+	Contract     *ITimelockControllerTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts              // Transaction auth options to use throughout this session
+}
+
+// ITimelockControllerRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ITimelockControllerRaw struct {
+	Contract *ITimelockController // Generic contract binding to access the raw methods on
+}
+
+// ITimelockControllerCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ITimelockControllerCallerRaw struct {
+	Contract *ITimelockControllerCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ITimelockControllerTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ITimelockControllerTransactorRaw struct {
+	Contract *ITimelockControllerTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewITimelockController creates a new instance of ITimelockController, bound to a specific deployed contract.
+func NewITimelockController(address common.Address, backend bind.ContractBackend) (*ITimelockController, error) {
+	contract, err := bindITimelockController(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ITimelockController{ITimelockControllerCaller: ITimelockControllerCaller{contract: contract}, ITimelockControllerTransactor: ITimelockControllerTransactor{contract: contract}, ITimelockControllerFilterer: ITimelockControllerFilterer{contract: contract}}, nil
+}
+
+// NewITimelockControllerCaller creates a new read-only instance of ITimelockController, bound to a specific deployed contract.
+func NewITimelockControllerCaller(address common.Address, caller bind.ContractCaller) (*ITimelockControllerCaller, error) {
+	contract, err := bindITimelockController(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ITimelockControllerCaller{contract: contract}, nil
+}
+
+// NewITimelockControllerTransactor creates a new write-only instance of ITimelockController, bound to a specific deployed contract.
+func NewITimelockControllerTransactor(address common.Address, transactor bind.ContractTransactor) (*ITimelockControllerTransactor, error) {
+	contract, err := bindITimelockController(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ITimelockControllerTransactor{contract: contract}, nil
+}
+
+// NewITimelockControllerFilterer creates a new log filterer instance of ITimelockController, bound to a specific deployed contract.
+func NewITimelockControllerFilterer(address common.Address, filterer bind.ContractFilterer) (*ITimelockControllerFilterer, error) {
+	contract, err := bindITimelockController(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ITimelockControllerFilterer{contract: contract}, nil
+}
+
+// bindITimelockController binds a generic wrapper to an already deployed contract.
+func bindITimelockController(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := ITimelockControllerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ITimelockController *ITimelockControllerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ITimelockController.Contract.ITimelockControllerCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ITimelockController *ITimelockControllerRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ITimelockController.Contract.ITimelockControllerTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ITimelockController *ITimelockControllerRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ITimelockController.Contract.ITimelockControllerTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ITimelockController *ITimelockControllerCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ITimelockController.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ITimelockController *ITimelockControllerTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ITimelockController.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ITimelockController *ITimelockControllerTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ITimelockController.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetMinDelay is a free data retrieval call binding the contract method 0xf27a0c92.
+//
+// Solidity: function getMinDelay() view returns(uint256)
+func (_ITimelockController *ITimelockControllerCaller) GetMinDelay(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _ITimelockController.contract.Call(opts, &out, "getMinDelay")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetMinDelay is a free data retrieval call binding the contract method 0xf27a0c92.
+//
+// Solidity: function getMinDelay() view returns(uint256)
+func (_ITimelockController *ITimelockControllerSession) GetMinDelay() (*big.Int, error) {
+	return _ITimelockController.Contract.GetMinDelay(&_ITimelockController.CallOpts)
+}
+
+// GetMinDelay is a free data retrieval call binding the contract method 0xf27a0c92.
+//
+// Solidity: function getMinDelay() view returns(uint256)
+func (_ITimelockController *ITimelockControllerCallerSession) GetMinDelay() (*big.Int, error) {
+	return _ITimelockController.Contract.GetMinDelay(&_ITimelockController.CallOpts)
+}
+
+// GetTimestamp is a free data retrieval call binding the contract method 0xd45c4435.
+//
+// Solidity: function getTimestamp(bytes32 id) view returns(uint256)
+func (_ITimelockController *ITimelockControllerCaller) GetTimestamp(opts *bind.CallOpts, id [32]byte) (*big.Int, error) {
+	var out []interface{}
+	err := _ITimelockController.contract.Call(opts, &out, "getTimestamp", id)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetTimestamp is a free data retrieval call binding the contract method 0xd45c4435.
+//
+// Solidity: function getTimestamp(bytes32 id) view returns(uint256)
+func (_ITimelockController *ITimelockControllerSession) GetTimestamp(id [32]byte) (*big.Int, error) {
+	return _ITimelockController.Contract.GetTimestamp(&_ITimelockController.CallOpts, id)
+}
+
+// GetTimestamp is a free data retrieval call binding the contract method 0xd45c4435.
+//
+// Solidity: function getTimestamp(bytes32 id) view returns(uint256)
+func (_ITimelockController *ITimelockControllerCallerSession) GetTimestamp(id [32]byte) (*big.Int, error) {
+	return _ITimelockController.Contract.GetTimestamp(&_ITimelockController.CallOpts, id)
+}
+
+// HashOperation is a free data retrieval call binding the contract method 0x8065657f.
+//
+// Solidity: function hashOperation(address target, uint256 value, bytes data, bytes32 predecessor, bytes32 salt) pure returns(bytes32)
+func (_ITimelockController *ITimelockControllerCaller) HashOperation(opts *bind.CallOpts, target common.Address, value *big.Int, data []byte, predecessor [32]byte, salt [32]byte) ([32]byte, error) {
+	var out []interface{}
+	err := _ITimelockController.contract.Call(opts, &out, "hashOperation", target, value, data, predecessor, salt)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// HashOperation is a free data retrieval call binding the contract method 0x8065657f.
+//
+// Solidity: function hashOperation(address target, uint256 value, bytes data, bytes32 predecessor, bytes32 salt) pure returns(bytes32)
+func (_ITimelockController *ITimelockControllerSession) HashOperation(target common.Address, value *big.Int, data []byte, predecessor [32]byte, salt [32]byte) ([32]byte, error) {
+	return _ITimelockController.Contract.HashOperation(&_ITimelockController.CallOpts, target, value, data, predecessor, salt)
+}
+
+// HashOperation is a free data retrieval call binding the contract method 0x8065657f.
+//
+// Solidity: function hashOperation(address target, uint256 value, bytes data, bytes32 predecessor, bytes32 salt) pure returns(bytes32)
+func (_ITimelockController *ITimelockControllerCallerSession) HashOperation(target common.Address, value *big.Int, data []byte, predecessor [32]byte, salt [32]byte) ([32]byte, error) {
+	return _ITimelockController.Contract.HashOperation(&_ITimelockController.CallOpts, target, value, data, predecessor, salt)
+}
+
+// HashOperationBatch is a free data retrieval call binding the contract method 0xb1c5f427.
+//
+// Solidity: function hashOperationBatch(address[] targets, uint256[] values, bytes[] payloads, bytes32 predecessor, bytes32 salt) pure returns(bytes32)
+func (_ITimelockController *ITimelockControllerCaller) HashOperationBatch(opts *bind.CallOpts, targets []common.Address, values []*big.Int, payloads [][]byte, predecessor [32]byte, salt [32]byte) ([32]byte, error) {
+	var out []interface{}
+	err := _ITimelockController.contract.Call(opts, &out, "hashOperationBatch", targets, values, payloads, predecessor, salt)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// HashOperationBatch is a free data retrieval call binding the contract method 0xb1c5f427.
+//
+// Solidity: function hashOperationBatch(address[] targets, uint256[] values, bytes[] payloads, bytes32 predecessor, bytes32 salt) pure returns(bytes32)
+func (_ITimelockController *ITimelockControllerSession) HashOperationBatch(targets []common.Address, values []*big.Int, payloads [][]byte, predecessor [32]byte, salt [32]byte) ([32]byte, error) {
+	return _ITimelockController.Contract.HashOperationBatch(&_ITimelockController.CallOpts, targets, values, payloads, predecessor, salt)
+}
+
+// HashOperationBatch is a free data retrieval call binding the contract method 0xb1c5f427.
+//
+// Solidity: function hashOperationBatch(address[] targets, uint256[] values, bytes[] payloads, bytes32 predecessor, bytes32 salt) pure returns(bytes32)
+func (_ITimelockController *ITimelockControllerCallerSession) HashOperationBatch(targets []common.Address, values []*big.Int, payloads [][]byte, predecessor [32]byte, salt [32]byte) ([32]byte, error) {
+	return _ITimelockController.Contract.HashOperationBatch(&_ITimelockController.CallOpts, targets, values, payloads, predecessor, salt)
+}
+
+// IsOperation is a free data retrieval call binding the contract method 0x31d50750.
+//
+// Solidity: function isOperation(bytes32 id) view returns(bool)
+func (_ITimelockController *ITimelockControllerCaller) IsOperation(opts *bind.CallOpts, id [32]byte) (bool, error) {
+	var out []interface{}
+	err := _ITimelockController.contract.Call(opts, &out, "isOperation", id)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsOperation is a free data retrieval call binding the contract method 0x31d50750.
+//
+// Solidity: function isOperation(bytes32 id) view returns(bool)
+func (_ITimelockController *ITimelockControllerSession) IsOperation(id [32]byte) (bool, error) {
+	return _ITimelockController.Contract.IsOperation(&_ITimelockController.CallOpts, id)
+}
+
+// IsOperation is a free data retrieval call binding the contract method 0x31d50750.
+//
+// Solidity: function isOperation(bytes32 id) view returns(bool)
+func (_ITimelockController *ITimelockControllerCallerSession) IsOperation(id [32]byte) (bool, error) {
+	return _ITimelockController.Contract.IsOperation(&_ITimelockController.CallOpts, id)
+}
+
+// IsOperationDone is a free data retrieval call binding the contract method 0x2ab0f529.
+//
+// Solidity: function isOperationDone(bytes32 id) view returns(bool)
+func (_ITimelockController *ITimelockControllerCaller) IsOperationDone(opts *bind.CallOpts, id [32]byte) (bool, error) {
+	var out []interface{}
+	err := _ITimelockController.contract.Call(opts, &out, "isOperationDone", id)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsOperationDone is a free data retrieval call binding the contract method 0x2ab0f529.
+//
+// Solidity: function isOperationDone(bytes32 id) view returns(bool)
+func (_ITimelockController *ITimelockControllerSession) IsOperationDone(id [32]byte) (bool, error) {
+	return _ITimelockController.Contract.IsOperationDone(&_ITimelockController.CallOpts, id)
+}
+
+// IsOperationDone is a free data retrieval call binding the contract method 0x2ab0f529.
+//
+// Solidity: function isOperationDone(bytes32 id) view returns(bool)
+func (_ITimelockController *ITimelockControllerCallerSession) IsOperationDone(id [32]byte) (bool, error) {
+	return _ITimelockController.Contract.IsOperationDone(&_ITimelockController.CallOpts, id)
+}
+
+// IsOperationPending is a free data retrieval call binding the contract method 0x584b153e.
+//
+// Solidity: function isOperationPending(bytes32 id) view returns(bool)
+func (_ITimelockController *ITimelockControllerCaller) IsOperationPending(opts *bind.CallOpts, id [32]byte) (bool, error) {
+	var out []interface{}
+	err := _ITimelockController.contract.Call(opts, &out, "isOperationPending", id)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsOperationPending is a free data retrieval call binding the contract method 0x584b153e.
+//
+// Solidity: function isOperationPending(bytes32 id) view returns(bool)
+func (_ITimelockController *ITimelockControllerSession) IsOperationPending(id [32]byte) (bool, error) {
+	return _ITimelockController.Contract.IsOperationPending(&_ITimelockController.CallOpts, id)
+}
+
+// IsOperationPending is a free data retrieval call binding the contract method 0x584b153e.
+//
+// Solidity: function isOperationPending(bytes32 id) view returns(bool)
+func (_ITimelockController *ITimelockControllerCallerSession) IsOperationPending(id [32]byte) (bool, error) {
+	return _ITimelockController.Contract.IsOperationPending(&_ITimelockController.CallOpts, id)
+}
+
+// IsOperationReady is a free data retrieval call binding the contract method 0x13bc9f20.
+//
+// Solidity: function isOperationReady(bytes32 id) view returns(bool)
+func (_ITimelockController *ITimelockControllerCaller) IsOperationReady(opts *bind.CallOpts, id [32]byte) (bool, error) {
+	var out []interface{}
+	err := _ITimelockController.contract.Call(opts, &out, "isOperationReady", id)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsOperationReady is a free data retrieval call binding the contract method 0x13bc9f20.
+//
+// Solidity: function isOperationReady(bytes32 id) view returns(bool)
+func (_ITimelockController *ITimelockControllerSession) IsOperationReady(id [32]byte) (bool, error) {
+	return _ITimelockController.Contract.IsOperationReady(&_ITimelockController.CallOpts, id)
+}
+
+// IsOperationReady is a free data retrieval call binding the contract method 0x13bc9f20.
+//
+// Solidity: function isOperationReady(bytes32 id) view returns(bool)
+func (_ITimelockController *ITimelockControllerCallerSession) IsOperationReady(id [32]byte) (bool, error) {
+	return _ITimelockController.Contract.IsOperationReady(&_ITimelockController.CallOpts, id)
+}
+
+// Cancel is a paid mutator transaction binding the contract method 0xc4d252f5.
+//
+// Solidity: function cancel(bytes32 id) returns()
+func (_ITimelockController *ITimelockControllerTransactor) Cancel(opts *bind.TransactOpts, id [32]byte) (*types.Transaction, error) {
+	return _ITimelockController.contract.Transact(opts, "cancel", id)
+}
+
+// Cancel is a paid mutator transaction binding the contract method 0xc4d252f5.
+//
+// Solidity: function cancel(bytes32 id) returns()
+func (_ITimelockController *ITimelockControllerSession) Cancel(id [32]byte) (*types.Transaction, error) {
+	return _ITimelockController.Contract.Cancel(&_ITimelockController.TransactOpts, id)
+}
+
+// Cancel is a paid mutator transaction binding the contract method 0xc4d252f5.
+//
+// Solidity: function cancel(bytes32 id) returns()
+func (_ITimelockController *ITimelockControllerTransactorSession) Cancel(id [32]byte) (*types.Transaction, error) {
+	return _ITimelockController.Contract.Cancel(&_ITimelockController.TransactOpts, id)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x134008d3.
+//
+// Solidity: function execute(address target, uint256 value, bytes payload, bytes32 predecessor, bytes32 salt) payable returns()
+func (_ITimelockController *ITimelockControllerTransactor) Execute(opts *bind.TransactOpts, target common.Address, value *big.Int, payload []byte, predecessor [32]byte, salt [32]byte) (*types.Transaction, error) {
+	return _ITimelockController.contract.Transact(opts, "execute", target, value, payload, predecessor, salt)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x134008d3.
+//
+// Solidity: function execute(address target, uint256 value, bytes payload, bytes32 predecessor, bytes32 salt) payable returns()
+func (_ITimelockController *ITimelockControllerSession) Execute(target common.Address, value *big.Int, payload []byte, predecessor [32]byte, salt [32]byte) (*types.Transaction, error) {
+	return _ITimelockController.Contract.Execute(&_ITimelockController.TransactOpts, target, value, payload, predecessor, salt)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x134008d3.
+//
+// Solidity: function execute(address target, uint256 value, bytes payload, bytes32 predecessor, bytes32 salt) payable returns()
+func (_ITimelockController *ITimelockControllerTransactorSession) Execute(target common.Address, value *big.Int, payload []byte, predecessor [32]byte, salt [32]byte) (*types.Transaction, error) {
+	return _ITimelockController.Contract.Execute(&_ITimelockController.TransactOpts, target, value, payload, predecessor, salt)
+}
+
+// ExecuteBatch is a paid mutator transaction binding the contract method 0xe38335e5.
+//
+// Solidity: function executeBatch(address[] targets, uint256[] values, bytes[] payloads, bytes32 predecessor, bytes32 salt) payable returns()
+func (_ITimelockController *ITimelockControllerTransactor) ExecuteBatch(opts *bind.TransactOpts, targets []common.Address, values []*big.Int, payloads [][]byte, predecessor [32]byte, salt [32]byte) (*types.Transaction, error) {
+	return _ITimelockController.contract.Transact(opts, "executeBatch", targets, values, payloads, predecessor, salt)
+}
+
+// ExecuteBatch is a paid mutator transaction binding the contract method 0xe38335e5.
+//
+// Solidity: function executeBatch(address[] targets, uint256[] values, bytes[] payloads, bytes32 predecessor, bytes32 salt) payable returns()
+func (_ITimelockController *ITimelockControllerSession) ExecuteBatch(targets []common.Address, values []*big.Int, payloads [][]byte, predecessor [32]byte, salt [32]byte) (*types.Transaction, error) {
+	return _ITimelockController.Contract.ExecuteBatch(&_ITimelockController.TransactOpts, targets, values, payloads, predecessor, salt)
+}
+
+// ExecuteBatch is a paid mutator transaction binding the contract method 0xe38335e5.
+//
+// Solidity: function executeBatch(address[] targets, uint256[] values, bytes[] payloads, bytes32 predecessor, bytes32 salt) payable returns()
+func (_ITimelockController *ITimelockControllerTransactorSession) ExecuteBatch(targets []common.Address, values []*big.Int, payloads [][]byte, predecessor [32]byte, salt [32]byte) (*types.Transaction, error) {
+	return _ITimelockController.Contract.ExecuteBatch(&_ITimelockController.TransactOpts, targets, values, payloads, predecessor, salt)
+}
+
+// Schedule is a paid mutator transaction binding the contract method 0x01d5062a.
+//
+// Solidity: function schedule(address target, uint256 value, bytes data, bytes32 predecessor, bytes32 salt, uint256 delay) returns()
+func (_ITimelockController *ITimelockControllerTransactor) Schedule(opts *bind.TransactOpts, target common.Address, value *big.Int, data []byte, predecessor [32]byte, salt [32]byte, delay *big.Int) (*types.Transaction, error) {
+	return _ITimelockController.contract.Transact(opts, "schedule", target, value, data, predecessor, salt, delay)
+}
+
+// Schedule is a paid mutator transaction binding the contract method 0x01d5062a.
+//
+// Solidity: function schedule(address target, uint256 value, bytes data, bytes32 predecessor, bytes32 salt, uint256 delay) returns()
+func (_ITimelockController *ITimelockControllerSession) Schedule(target common.Address, value *big.Int, data []byte, predecessor [32]byte, salt [32]byte, delay *big.Int) (*types.Transaction, error) {
+	return _ITimelockController.Contract.Schedule(&_ITimelockController.TransactOpts, target, value, data, predecessor, salt, delay)
+}
+
+// Schedule is a paid mutator transaction binding the contract method 0x01d5062a.
+//
+// Solidity: function schedule(address target, uint256 value, bytes data, bytes32 predecessor, bytes32 salt, uint256 delay) returns()
+func (_ITimelockController *ITimelockControllerTransactorSession) Schedule(target common.Address, value *big.Int, data []byte, predecessor [32]byte, salt [32]byte, delay *big.Int) (*types.Transaction, error) {
+	return _ITimelockController.Contract.Schedule(&_ITimelockController.TransactOpts, target, value, data, predecessor, salt, delay)
+}
+
+// ScheduleBatch is a paid mutator transaction binding the contract method 0x8f2a0bb0.
+//
+// Solidity: function scheduleBatch(address[] targets, uint256[] values, bytes[] payloads, bytes32 predecessor, bytes32 salt, uint256 delay) returns()
+func (_ITimelockController *ITimelockControllerTransactor) ScheduleBatch(opts *bind.TransactOpts, targets []common.Address, values []*big.Int, payloads [][]byte, predecessor [32]byte, salt [32]byte, delay *big.Int) (*types.Transaction, error) {
+	return _ITimelockController.contract.Transact(opts, "scheduleBatch", targets, values, payloads, predecessor, salt, delay)
+}
+
+// ScheduleBatch is a paid mutator transaction binding the contract method 0x8f2a0bb0.
+//
+// Solidity: function scheduleBatch(address[] targets, uint256[] values, bytes[] payloads, bytes32 predecessor, bytes32 salt, uint256 delay) returns()
+func (_ITimelockController *ITimelockControllerSession) ScheduleBatch(targets []common.Address, values []*big.Int, payloads [][]byte, predecessor [32]byte, salt [32]byte, delay *big.Int) (*types.Transaction, error) {
+	return _ITimelockController.Contract.ScheduleBatch(&_ITimelockController.TransactOpts, targets, values, payloads, predecessor, salt, delay)
+}
+
+// ScheduleBatch is a paid mutator transaction binding the contract method 0x8f2a0bb0.
+//
+// Solidity: function scheduleBatch(address[] targets, uint256[] values, bytes[] payloads, bytes32 predecessor, bytes32 salt, uint256 delay) returns()
+func (_ITimelockController *ITimelockControllerTransactorSession) ScheduleBatch(targets []common.Address, values []*big.Int, payloads [][]byte, predecessor [32]byte, salt [32]byte, delay *big.Int) (*types.Transaction, error) {
+	return _ITimelockController.Contract.ScheduleBatch(&_ITimelockController.TransactOpts, targets, values, payloads, predecessor, salt, delay)
+}
+
+// ITimelockControllerCallExecutedIterator is returned from FilterCallExecuted and is used to iterate over the raw logs and unpacked data for CallExecuted events raised by the ITimelockController contract.
+type ITimelockControllerCallExecutedIterator struct {
+	Event *ITimelockControllerCallExecuted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ITimelockControllerCallExecutedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ITimelockControllerCallExecuted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ITimelockControllerCallExecuted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ITimelockControllerCallExecutedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ITimelockControllerCallExecutedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ITimelockControllerCallExecuted represents a CallExecuted event raised by the ITimelockController contract.
+type ITimelockControllerCallExecuted struct {
+	Id     [32]byte
+	Index  *big.Int
+	Target common.Address
+	Value  *big.Int
+	Data   []byte
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterCallExecuted is a free log retrieval operation binding the contract event 0xc2617efa69bab66782fa219543714338489c4e9e178271560a91b82c3f612b58.
+//
+// Solidity: event CallExecuted(bytes32 indexed id, uint256 indexed index, address target, uint256 value, bytes data)
+func (_ITimelockController *ITimelockControllerFilterer) FilterCallExecuted(opts *bind.FilterOpts, id [][32]byte, index []*big.Int) (*ITimelockControllerCallExecutedIterator, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+	var indexRule []interface{}
+	for _, indexItem := range index {
+		indexRule = append(indexRule, indexItem)
+	}
+
+	logs, sub, err := _ITimelockController.contract.FilterLogs(opts, "CallExecuted", idRule, indexRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ITimelockControllerCallExecutedIterator{contract: _ITimelockController.contract, event: "CallExecuted", logs: logs, sub: sub}, nil
+}
+
+// WatchCallExecuted is a free log subscription operation binding the contract event 0xc2617efa69bab66782fa219543714338489c4e9e178271560a91b82c3f612b58.
+//
+// Solidity: event CallExecuted(bytes32 indexed id, uint256 indexed index, address target, uint256 value, bytes data)
+func (_ITimelockController *ITimelockControllerFilterer) WatchCallExecuted(opts *bind.WatchOpts, sink chan<- *ITimelockControllerCallExecuted, id [][32]byte, index []*big.Int) (event.Subscription, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+	var indexRule []interface{}
+	for _, indexItem := range index {
+		indexRule = append(indexRule, indexItem)
+	}
+
+	logs, sub, err := _ITimelockController.contract.WatchLogs(opts, "CallExecuted", idRule, indexRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ITimelockControllerCallExecuted)
+				if err := _ITimelockController.contract.UnpackLog(event, "CallExecuted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseCallExecuted is a log parse operation binding the contract event 0xc2617efa69bab66782fa219543714338489c4e9e178271560a91b82c3f612b58.
+//
+// Solidity: event CallExecuted(bytes32 indexed id, uint256 indexed index, address target, uint256 value, bytes data)
+func (_ITimelockController *ITimelockControllerFilterer) ParseCallExecuted(log types.Log) (*ITimelockControllerCallExecuted, error) {
+	event := new(ITimelockControllerCallExecuted)
+	if err := _ITimelockController.contract.UnpackLog(event, "CallExecuted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ITimelockControllerCallScheduledIterator is returned from FilterCallScheduled and is used to iterate over the raw logs and unpacked data for CallScheduled events raised by the ITimelockController contract.
+type ITimelockControllerCallScheduledIterator struct {
+	Event *ITimelockControllerCallScheduled // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ITimelockControllerCallScheduledIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ITimelockControllerCallScheduled)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ITimelockControllerCallScheduled)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ITimelockControllerCallScheduledIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ITimelockControllerCallScheduledIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ITimelockControllerCallScheduled represents a CallScheduled event raised by the ITimelockController contract.
+type ITimelockControllerCallScheduled struct {
+	Id          [32]byte
+	Index       *big.Int
+	Target      common.Address
+	Value       *big.Int
+	Data        []byte
+	Predecessor [32]byte
+	Delay       *big.Int
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterCallScheduled is a free log retrieval operation binding the contract event 0x4cf4410cc57040e44862ef0f45f3dd5a5e02db8eb8add648d4b0e236f1d07dca.
+//
+// Solidity: event CallScheduled(bytes32 indexed id, uint256 indexed index, address target, uint256 value, bytes data, bytes32 predecessor, uint256 delay)
+func (_ITimelockController *ITimelockControllerFilterer) FilterCallScheduled(opts *bind.FilterOpts, id [][32]byte, index []*big.Int) (*ITimelockControllerCallScheduledIterator, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+	var indexRule []interface{}
+	for _, indexItem := range index {
+		indexRule = append(indexRule, indexItem)
+	}
+
+	logs, sub, err := _ITimelockController.contract.FilterLogs(opts, "CallScheduled", idRule, indexRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ITimelockControllerCallScheduledIterator{contract: _ITimelockController.contract, event: "CallScheduled", logs: logs, sub: sub}, nil
+}
+
+// WatchCallScheduled is a free log subscription operation binding the contract event 0x4cf4410cc57040e44862ef0f45f3dd5a5e02db8eb8add648d4b0e236f1d07dca.
+//
+// Solidity: event CallScheduled(bytes32 indexed id, uint256 indexed index, address target, uint256 value, bytes data, bytes32 predecessor, uint256 delay)
+func (_ITimelockController *ITimelockControllerFilterer) WatchCallScheduled(opts *bind.WatchOpts, sink chan<- *ITimelockControllerCallScheduled, id [][32]byte, index []*big.Int) (event.Subscription, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+	var indexRule []interface{}
+	for _, indexItem := range index {
+		indexRule = append(indexRule, indexItem)
+	}
+
+	logs, sub, err := _ITimelockController.contract.WatchLogs(opts, "CallScheduled", idRule, indexRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ITimelockControllerCallScheduled)
+				if err := _ITimelockController.contract.UnpackLog(event, "CallScheduled", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseCallScheduled is a log parse operation binding the contract event 0x4cf4410cc57040e44862ef0f45f3dd5a5e02db8eb8add648d4b0e236f1d07dca.
+//
+// Solidity: event CallScheduled(bytes32 indexed id, uint256 indexed index, address target, uint256 value, bytes data, bytes32 predecessor, uint256 delay)
+func (_ITimelockController *ITimelockControllerFilterer) ParseCallScheduled(log types.Log) (*ITimelockControllerCallScheduled, error) {
+	event := new(ITimelockControllerCallScheduled)
+	if err := _ITimelockController.contract.UnpackLog(event, "CallScheduled", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ITimelockControllerCancelledIterator is returned from FilterCancelled and is used to iterate over the raw logs and unpacked data for Cancelled events raised by the ITimelockController contract.
+type ITimelockControllerCancelledIterator struct {
+	Event *ITimelockControllerCancelled // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ITimelockControllerCancelledIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ITimelockControllerCancelled)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ITimelockControllerCancelled)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ITimelockControllerCancelledIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ITimelockControllerCancelledIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ITimelockControllerCancelled represents a Cancelled event raised by the ITimelockController contract.
+type ITimelockControllerCancelled struct {
+	Id  [32]byte
+	Raw types.Log // Blockchain specific contextual infos
+}
+
+// FilterCancelled is a free log retrieval operation binding the contract event 0xbaa1eb22f2a492ba1a5fea61b8df4d27c6c8b5f3971e63bb58fa14ff72eedb70.
+//
+// Solidity: event Cancelled(bytes32 indexed id)
+func (_ITimelockController *ITimelockControllerFilterer) FilterCancelled(opts *bind.FilterOpts, id [][32]byte) (*ITimelockControllerCancelledIterator, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+
+	logs, sub, err := _ITimelockController.contract.FilterLogs(opts, "Cancelled", idRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ITimelockControllerCancelledIterator{contract: _ITimelockController.contract, event: "Cancelled", logs: logs, sub: sub}, nil
+}
+
+// WatchCancelled is a free log subscription operation binding the contract event 0xbaa1eb22f2a492ba1a5fea61b8df4d27c6c8b5f3971e63bb58fa14ff72eedb70.
+//
+// Solidity: event Cancelled(bytes32 indexed id)
+func (_ITimelockController *ITimelockControllerFilterer) WatchCancelled(opts *bind.WatchOpts, sink chan<- *ITimelockControllerCancelled, id [][32]byte) (event.Subscription, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+
+	logs, sub, err := _ITimelockController.contract.WatchLogs(opts, "Cancelled", idRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ITimelockControllerCancelled)
+				if err := _ITimelockController.contract.UnpackLog(event, "Cancelled", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseCancelled is a log parse operation binding the contract event 0xbaa1eb22f2a492ba1a5fea61b8df4d27c6c8b5f3971e63bb58fa14ff72eedb70.
+//
+// Solidity: event Cancelled(bytes32 indexed id)
+func (_ITimelockController *ITimelockControllerFilterer) ParseCancelled(log types.Log) (*ITimelockControllerCancelled, error) {
+	event := new(ITimelockControllerCancelled)
+	if err := _ITimelockController.contract.UnpackLog(event, "Cancelled", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
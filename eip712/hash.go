@@ -0,0 +1,75 @@
+package eip712
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// referencingTypedData is implemented by typed data whose type references other struct types, such as Struct.
+type referencingTypedData interface {
+	// EIP712ReferencedTypes returns the definitions of the referenced types.
+	EIP712ReferencedTypes() apitypes.Types
+}
+
+// NewTypedData compiles the EIP-712 typed data structure for the given domain and data.
+func NewTypedData(domain *Domain, data TypedData) (apitypes.TypedData, error) {
+	msg, err := data.EIP712Message()
+	if err != nil {
+		return apitypes.TypedData{}, err
+	}
+	types := apitypes.Types{
+		data.EIP712Type():   data.EIP712Types(),
+		domain.EIP712Type(): domain.EIP712Types(),
+	}
+	if referencing, ok := data.(referencingTypedData); ok {
+		for name, fields := range referencing.EIP712ReferencedTypes() {
+			types[name] = fields
+		}
+	}
+	return apitypes.TypedData{
+		Types:       types,
+		PrimaryType: data.EIP712Type(),
+		Domain:      domain.EIP712Domain(),
+		Message:     msg,
+	}, nil
+}
+
+// HashTypedData returns the EIP-712 hash of the data for the given domain, which is the hash
+// that is signed: keccak256(0x1901 || domainSeparator || hashStruct(message)).
+func HashTypedData(domain *Domain, data TypedData) ([]byte, error) {
+	typedData, err := NewTypedData(domain, data)
+	if err != nil {
+		return nil, err
+	}
+	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hash of typed data domain: %w", err)
+	}
+	dataHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hash of typed message: %w", err)
+	}
+	return crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator, dataHash), nil
+}
+
+// RecoverTypedDataSigner returns the address of the account which has signed the typed data for the given domain.
+func RecoverTypedDataSigner(domain *Domain, data TypedData, signature []byte) (common.Address, error) {
+	if len(signature) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("invalid length of signature: %d", len(signature))
+	}
+	hash, err := HashTypedData(domain, data)
+	if err != nil {
+		return common.Address{}, err
+	}
+	recoverable := common.CopyBytes(signature)
+	if recoverable[64] >= 27 {
+		recoverable[64] -= 27
+	}
+	publicKey, err := crypto.SigToPub(hash, recoverable)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to recover signer: %w", err)
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
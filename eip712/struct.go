@@ -0,0 +1,76 @@
+package eip712
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"regexp"
+)
+
+var (
+	// arraySuffix matches the array dimensions of the type, e.g. [] and [2] of Person[][2].
+	arraySuffix = regexp.MustCompile(`(\[\d*\])+$`)
+	// sizedAtomicType matches the atomic types having the size suffix, e.g. uint256 and bytes32.
+	sizedAtomicType = regexp.MustCompile(`^(u?int|bytes)\d*$`)
+)
+
+// Struct represents the typed data of an arbitrary application-level struct, whose type definitions may
+// reference other struct types, as supported by eth_signTypedData_v4.
+type Struct struct {
+	PrimaryType string                    // Name of the type of the message.
+	Types       apitypes.Types            // Definitions of the primary type and the types it references.
+	Message     apitypes.TypedDataMessage // Values of the fields of the message.
+}
+
+// NewStruct creates an instance of Struct, having checked that the primary type and all the referenced
+// struct types are defined.
+func NewStruct(primaryType string, types apitypes.Types, message apitypes.TypedDataMessage) (*Struct, error) {
+	if primaryType == "EIP712Domain" {
+		return nil, fmt.Errorf("primary type must not be EIP712Domain")
+	}
+	if _, ok := types[primaryType]; !ok {
+		return nil, fmt.Errorf("primary type %s is not defined", primaryType)
+	}
+	for name, fields := range types {
+		for _, field := range fields {
+			referenced := arraySuffix.ReplaceAllString(field.Type, "")
+			if isStructType(referenced) {
+				if _, ok := types[referenced]; !ok {
+					return nil, fmt.Errorf("type %s of field %s.%s is not defined", referenced, name, field.Name)
+				}
+			}
+		}
+	}
+	return &Struct{PrimaryType: primaryType, Types: types, Message: message}, nil
+}
+
+func (s *Struct) EIP712Type() string {
+	return s.PrimaryType
+}
+
+func (s *Struct) EIP712Types() []apitypes.Type {
+	return s.Types[s.PrimaryType]
+}
+
+func (s *Struct) EIP712Message() (apitypes.TypedDataMessage, error) {
+	return s.Message, nil
+}
+
+// EIP712ReferencedTypes returns the definitions of the types referenced by the primary type.
+func (s *Struct) EIP712ReferencedTypes() apitypes.Types {
+	types := make(apitypes.Types, len(s.Types))
+	for name, fields := range s.Types {
+		if name != s.PrimaryType && name != "EIP712Domain" {
+			types[name] = fields
+		}
+	}
+	return types
+}
+
+// isStructType reports whether the type is not one of the atomic or dynamic types of EIP-712.
+func isStructType(t string) bool {
+	switch t {
+	case "address", "bool", "string":
+		return false
+	}
+	return !sizedAtomicType.MatchString(t)
+}
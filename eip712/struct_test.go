@@ -0,0 +1,105 @@
+package eip712
+
+import (
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"testing"
+)
+
+func TestStructEncodeType(t *testing.T) {
+	person := []apitypes.Type{{Name: "name", Type: "string"}, {Name: "wallet", Type: "address"}}
+	tests := []struct {
+		name        string
+		primaryType string
+		types       apitypes.Types
+		want        string
+	}{
+		{
+			name:        "referenced struct",
+			primaryType: "Mail",
+			types: apitypes.Types{
+				"Mail": {
+					{Name: "from", Type: "Person"},
+					{Name: "to", Type: "Person"},
+					{Name: "contents", Type: "string"},
+				},
+				"Person": person,
+			},
+			want: "Mail(Person from,Person to,string contents)Person(string name,address wallet)",
+		},
+		{
+			name:        "struct name ending with digit",
+			primaryType: "Batch",
+			types: apitypes.Types{
+				"Batch":  {{Name: "orders", Type: "Order2[]"}},
+				"Order2": {{Name: "amount", Type: "uint256"}},
+			},
+			want: "Batch(Order2[] orders)Order2(uint256 amount)",
+		},
+		{
+			name:        "array of structs",
+			primaryType: "Group",
+			types: apitypes.Types{
+				"Group":  {{Name: "members", Type: "Person[]"}, {Name: "owner", Type: "Person"}},
+				"Person": person,
+			},
+			want: "Group(Person[] members,Person owner)Person(string name,address wallet)",
+		},
+		{
+			name:        "arrays of atomic types",
+			primaryType: "Data",
+			types: apitypes.Types{
+				"Data": {{Name: "values", Type: "uint256[]"}, {Name: "hashes", Type: "bytes32[3]"}, {Name: "flags", Type: "bool[]"}},
+			},
+			want: "Data(uint256[] values,bytes32[3] hashes,bool[] flags)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewStruct(tt.primaryType, tt.types, apitypes.TypedDataMessage{})
+			if err != nil {
+				t.Fatalf("NewStruct() error = %v", err)
+			}
+			typedData, err := NewTypedData(ZkSyncEraEIP712Domain(270), s)
+			if err != nil {
+				t.Fatalf("NewTypedData() error = %v", err)
+			}
+			if got := string(typedData.EncodeType(tt.primaryType)); got != tt.want {
+				t.Errorf("EncodeType() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewStructErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		primaryType string
+		types       apitypes.Types
+	}{
+		{
+			name:        "domain as primary type",
+			primaryType: "EIP712Domain",
+			types:       apitypes.Types{"EIP712Domain": {{Name: "name", Type: "string"}}},
+		},
+		{
+			name:        "undefined primary type",
+			primaryType: "Mail",
+			types:       apitypes.Types{"Person": {{Name: "name", Type: "string"}}},
+		},
+		{
+			name:        "undefined referenced type",
+			primaryType: "Batch",
+			types: apitypes.Types{
+				"Batch": {{Name: "orders", Type: "Order2[]"}},
+				"Order": {{Name: "amount", Type: "uint256"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewStruct(tt.primaryType, tt.types, apitypes.TypedDataMessage{}); err == nil {
+				t.Error("NewStruct() error = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+// Package ens resolves human-readable names, such as alice.eth, to addresses using the Ethereum Name Service
+// deployed on L1, and defines the NameResolver interface, through which other name services, e.g. the ones
+// deployed on L2, can be plugged into the wallet.
+//
+// Names are normalized by lowercasing only, full UTS-46 normalization is not performed. Wildcard resolution
+// (ENSIP-10) and off-chain resolution (EIP-3668) are not supported.
+//
+// The addresses on L2 are resolved using the multichain address records (ENSIP-11), keyed by the coin type
+// derived from the chain ID of L2, see NewChainResolver, since the owner of the name may not control the same
+// address on L2 as on L1, e.g. if it is a smart contract account.
+package ens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"math/big"
+	"strings"
+)
+
+// RegistryAddress is the address of the ENS registry, which is the same on Ethereum mainnet and testnets.
+var RegistryAddress = common.HexToAddress("0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e")
+
+// ErrNameNotFound is returned when the name is not registered or does not resolve to an address.
+var ErrNameNotFound = errors.New("name not found")
+
+const ensABI = `[
+	{"inputs":[{"name":"node","type":"bytes32"}],"name":"resolver","outputs":[{"name":"","type":"address"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"name":"node","type":"bytes32"}],"name":"addr","outputs":[{"name":"","type":"address"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"name":"node","type":"bytes32"}],"name":"name","outputs":[{"name":"","type":"string"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"name":"node","type":"bytes32"},{"name":"coinType","type":"uint256"}],"name":"addr","outputs":[{"name":"","type":"bytes"}],"stateMutability":"view","type":"function"}
+]`
+
+// addrCoinTypeMethod is the name under which the overloaded addr(bytes32,uint256) is available in parsedABI.
+const addrCoinTypeMethod = "addr0"
+
+var parsedABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(ensABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// NameResolver resolves names to addresses.
+type NameResolver interface {
+	// ResolveName returns the address the name resolves to, or ErrNameNotFound if the name is not known
+	// to the resolver.
+	ResolveName(ctx context.Context, name string) (common.Address, error)
+}
+
+// NameResolverFunc is an adapter allowing an ordinary function to be used as NameResolver.
+type NameResolverFunc func(ctx context.Context, name string) (common.Address, error)
+
+// ResolveName calls f(ctx, name).
+func (f NameResolverFunc) ResolveName(ctx context.Context, name string) (common.Address, error) {
+	return f(ctx, name)
+}
+
+// Resolvers is the NameResolver which consults the resolvers in order, returning the address from the first
+// one which knows the name, e.g. the name service deployed on L2 before ENS.
+type Resolvers []NameResolver
+
+// ResolveName returns the address from the first resolver which does not return ErrNameNotFound.
+func (r Resolvers) ResolveName(ctx context.Context, name string) (common.Address, error) {
+	for _, resolver := range r {
+		address, err := resolver.ResolveName(ctx, name)
+		if errors.Is(err, ErrNameNotFound) {
+			continue
+		}
+		return address, err
+	}
+	return common.Address{}, fmt.Errorf("%w: %s", ErrNameNotFound, name)
+}
+
+// Resolver resolves ENS names using the ENS registry on L1.
+type Resolver struct {
+	registry *bind.BoundContract
+	caller   bind.ContractCaller
+	coinType *big.Int // The coin type of the address records, nil for the address on L1.
+}
+
+var _ NameResolver = (*Resolver)(nil)
+
+// NewResolver creates an instance of Resolver using the ENS registry at RegistryAddress.
+func NewResolver(caller bind.ContractCaller) *Resolver {
+	return NewResolverWithRegistry(caller, RegistryAddress)
+}
+
+// NewResolverWithRegistry creates an instance of Resolver using the ENS registry at the given address,
+// e.g. the registry deployed on a local network.
+func NewResolverWithRegistry(caller bind.ContractCaller, registry common.Address) *Resolver {
+	return &Resolver{
+		registry: bind.NewBoundContract(registry, parsedABI, caller, nil, nil),
+		caller:   caller,
+	}
+}
+
+// NewChainResolver creates an instance of Resolver using the ENS registry at RegistryAddress, which
+// resolves the names to the addresses on the EVM chain with the given ID, e.g. L2, using the address
+// records of the coin type 0x80000000 | chainID, as defined by ENSIP-11. The names which have no address
+// record for the chain are not found, rather than resolved to the address on L1.
+func NewChainResolver(caller bind.ContractCaller, chainID *big.Int) *Resolver {
+	resolver := NewResolver(caller)
+	resolver.coinType = CoinType(chainID)
+	return resolver
+}
+
+// CoinType returns the coin type of the address records of the EVM chain with the given ID, as defined
+// by ENSIP-11.
+func CoinType(chainID *big.Int) *big.Int {
+	return new(big.Int).Or(big.NewInt(0x80000000), chainID)
+}
+
+// ResolveName returns the address the ENS name resolves to.
+func (r *Resolver) ResolveName(ctx context.Context, name string) (common.Address, error) {
+	node, err := Namehash(name)
+	if err != nil {
+		return common.Address{}, err
+	}
+	resolver, err := r.resolver(ctx, node)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to resolve %s: %w", name, err)
+	}
+	var address common.Address
+	if r.coinType != nil {
+		var out []interface{}
+		if err = resolver.Call(&bind.CallOpts{Context: ctx}, &out, addrCoinTypeMethod, node, r.coinType); err != nil {
+			return common.Address{}, fmt.Errorf("failed to resolve %s: %w", name, err)
+		}
+		record := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+		if len(record) != 0 && len(record) != common.AddressLength {
+			return common.Address{}, fmt.Errorf("failed to resolve %s: invalid address record of coin type %s: %x",
+				name, r.coinType, record)
+		}
+		address = common.BytesToAddress(record)
+	} else {
+		var out []interface{}
+		if err = resolver.Call(&bind.CallOpts{Context: ctx}, &out, "addr", node); err != nil {
+			return common.Address{}, fmt.Errorf("failed to resolve %s: %w", name, err)
+		}
+		address = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	}
+	if address == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("%w: %s", ErrNameNotFound, name)
+	}
+	return address, nil
+}
+
+// LookupAddress returns the primary name of the address, set using the reverse registrar. The name is
+// returned only if it resolves back to the address, as the reverse record can be set to any name.
+func (r *Resolver) LookupAddress(ctx context.Context, address common.Address) (string, error) {
+	node, err := Namehash(strings.ToLower(address.Hex()[2:]) + ".addr.reverse")
+	if err != nil {
+		return "", err
+	}
+	resolver, err := r.resolver(ctx, node)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up %s: %w", address, err)
+	}
+	var out []interface{}
+	if err = resolver.Call(&bind.CallOpts{Context: ctx}, &out, "name", node); err != nil {
+		return "", fmt.Errorf("failed to look up %s: %w", address, err)
+	}
+	name := *abi.ConvertType(out[0], new(string)).(*string)
+	if name == "" {
+		return "", fmt.Errorf("%w: no primary name of %s", ErrNameNotFound, address)
+	}
+	resolved, err := r.ResolveName(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if resolved != address {
+		return "", fmt.Errorf("%w: primary name %s of %s resolves to %s", ErrNameNotFound, name, address, resolved)
+	}
+	return name, nil
+}
+
+// resolver returns the resolver contract of the node, as set in the registry.
+func (r *Resolver) resolver(ctx context.Context, node common.Hash) (*bind.BoundContract, error) {
+	var out []interface{}
+	if err := r.registry.Call(&bind.CallOpts{Context: ctx}, &out, "resolver", node); err != nil {
+		return nil, err
+	}
+	address := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	if address == (common.Address{}) {
+		return nil, ErrNameNotFound
+	}
+	return bind.NewBoundContract(address, parsedABI, r.caller, nil, nil), nil
+}
+
+// Namehash returns the node of the name, as defined by EIP-137. The name is lowercased before hashing.
+func Namehash(name string) (common.Hash, error) {
+	var node common.Hash
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return node, nil
+	}
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		if labels[i] == "" {
+			return common.Hash{}, fmt.Errorf("invalid name %q: empty label", name)
+		}
+		node = crypto.Keccak256Hash(node.Bytes(), crypto.Keccak256([]byte(labels[i])))
+	}
+	return node, nil
+}
+
+// IsName reports whether the string looks like a name rather than a hex address, i.e. it contains the dot
+// and is not the hex address.
+func IsName(s string) bool {
+	return strings.Contains(s, ".") && !common.IsHexAddress(s)
+}
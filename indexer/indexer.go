@@ -0,0 +1,405 @@
+// Package indexer streams the events of contracts deployed on L2 network to the application, e.g. to
+// maintain the database of token transfers. The indexer fetches the logs matching the declared filters from
+// the start block up to the head of the chain, decodes them, and keeps following the chain:
+//
+//	idx, err := indexer.New(client, indexer.Config{
+//		Filters: []indexer.Filter{{Name: "transfers", Addresses: []common.Address{token}, ABI: &erc20ABI, Events: []string{"Transfer"}}},
+//		Store:   store,
+//	})
+//	err = idx.Run(ctx, indexer.HandlerFuncs{
+//		Events: func(ctx context.Context, events []indexer.Event, cursor indexer.Cursor) error { ... },
+//		Reorg:  func(ctx context.Context, block uint64) error { ... },
+//	})
+//
+// The position of the indexer is persisted as the cursor using the Store, once the events up to it have been
+// handled, so that the indexer resumes after restarts. The events are delivered at least once. If the indexed
+// blocks are reorganized, the handler is notified, and the blocks are indexed again.
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/zksync-sdk/zksync2-go/clients"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// Filter declares the events indexed from the contracts.
+type Filter struct {
+	Name      string           // Name of the filter, delivered with the events. Optional.
+	Addresses []common.Address // Contracts emitting the events. Optional, the events of any contract are indexed by default.
+	ABI       *abi.ABI         // ABI used to decode the events. Optional, the events are not decoded by default.
+	// Names of the events of the ABI. Optional, all events of the ABI, or all events if the ABI is not provided,
+	// are indexed by default.
+	Events []string
+	// Topics filter the indexed arguments of the events, after the event signature. Optional.
+	Topics [][]common.Hash
+}
+
+// query returns the log filter query of the filter.
+func (f *Filter) query() (ethereum.FilterQuery, error) {
+	var signatures []common.Hash
+	if f.ABI != nil {
+		if len(f.Events) == 0 {
+			for _, event := range f.ABI.Events {
+				signatures = append(signatures, event.ID)
+			}
+		}
+		for _, name := range f.Events {
+			event, ok := f.ABI.Events[name]
+			if !ok {
+				return ethereum.FilterQuery{}, fmt.Errorf("event %s of filter %s is not defined by ABI", name, f.Name)
+			}
+			signatures = append(signatures, event.ID)
+		}
+	} else if len(f.Events) > 0 {
+		return ethereum.FilterQuery{}, fmt.Errorf("ABI of filter %s is required to filter events by name", f.Name)
+	}
+	query := ethereum.FilterQuery{Addresses: f.Addresses}
+	if len(signatures) > 0 || len(f.Topics) > 0 {
+		query.Topics = append([][]common.Hash{signatures}, f.Topics...)
+	}
+	return query, nil
+}
+
+// Event is the event indexed by the filter. If the filter provides the ABI, the event is decoded,
+// otherwise only its log is set. The log which cannot be decoded, e.g. because the contract emits the event
+// with the same signature but different indexed arguments, is delivered undecoded along with the error,
+// rather than stopping the indexer.
+type Event struct {
+	Filter string // Name of the filter.
+	zkTypes.DecodedEvent
+	DecodeErr error // Error of decoding the log, nil if the log has been decoded or the filter provides no ABI.
+}
+
+// Cursor is the position of the indexer: the last indexed block and its hash.
+type Cursor struct {
+	Block uint64      `json:"block"`
+	Hash  common.Hash `json:"hash"`
+}
+
+// Handler handles the events streamed by the indexer.
+type Handler interface {
+	// HandleEvents handles the events of the blocks up to the cursor, ordered by their position in the chain.
+	// The cursor is persisted once the method returns nil, otherwise the indexer stops with the error.
+	// It is also called without events, so that the progress of the indexer can be persisted atomically
+	// with the handled events.
+	HandleEvents(ctx context.Context, events []Event, cursor Cursor) error
+	// HandleReorg is called when the indexed blocks after the block have been reorganized, so that the events
+	// of those blocks, which have already been handled, are no longer valid. The blocks are indexed again.
+	HandleReorg(ctx context.Context, block uint64) error
+}
+
+// HandlerFuncs is an adapter allowing the use of ordinary functions as Handler. Nil functions are ignored.
+type HandlerFuncs struct {
+	Events func(ctx context.Context, events []Event, cursor Cursor) error
+	Reorg  func(ctx context.Context, block uint64) error
+}
+
+func (h HandlerFuncs) HandleEvents(ctx context.Context, events []Event, cursor Cursor) error {
+	if h.Events == nil {
+		return nil
+	}
+	return h.Events(ctx, events, cursor)
+}
+
+func (h HandlerFuncs) HandleReorg(ctx context.Context, block uint64) error {
+	if h.Reorg == nil {
+		return nil
+	}
+	return h.Reorg(ctx, block)
+}
+
+// Config contains the configuration of Indexer.
+type Config struct {
+	// ID identifies the cursor of the indexer in the store, so that multiple indexers can share it.
+	// Optional, defaults to "default".
+	ID string
+	// Filters declare the indexed events.
+	Filters []Filter
+	// StartBlock is the first indexed block, used unless the cursor is persisted.
+	StartBlock uint64
+	// Confirmations is the number of blocks after the head of the chain that are not indexed yet, which
+	// reduces reorgs of the indexed blocks. Optional.
+	Confirmations uint64
+	// BatchSize is the maximum number of blocks whose logs are fetched in a single request. Defaults to 1000.
+	BatchSize uint64
+	// PollInterval is the interval of checking for new blocks once the indexer has reached the head.
+	// Defaults to 2 seconds.
+	PollInterval time.Duration
+	// ReorgDepth is the maximum number of reorganized blocks. It is also the number of recently indexed batches
+	// whose hashes are tracked to find the common ancestor when the chain is reorganized. If the common ancestor
+	// is not tracked, e.g. after restart, the indexer is rewound by this number of blocks. Defaults to 64.
+	ReorgDepth int
+	// Store persists the cursor. Optional, the cursor is kept in memory by default.
+	Store Store
+}
+
+// Indexer streams the events matching the filters to the handler. It is not safe for concurrent use.
+type Indexer struct {
+	client  clients.Client
+	config  Config
+	queries []ethereum.FilterQuery
+
+	cursor *Cursor  // The last indexed block, nil until the cursor is loaded.
+	recent []Cursor // The recently indexed blocks, used to find the common ancestor on reorgs.
+}
+
+// New creates an instance of Indexer fetching the events using the client.
+func New(client clients.Client, config Config) (*Indexer, error) {
+	if len(config.Filters) == 0 {
+		return nil, errors.New("at least one filter must be provided")
+	}
+	if config.ID == "" {
+		config.ID = "default"
+	}
+	if config.BatchSize == 0 {
+		config.BatchSize = 1000
+	}
+	if config.PollInterval == 0 {
+		config.PollInterval = 2 * time.Second
+	}
+	if config.ReorgDepth <= 0 {
+		config.ReorgDepth = 64
+	}
+	if config.Store == nil {
+		config.Store = NewMemoryStore()
+	}
+	queries := make([]ethereum.FilterQuery, len(config.Filters))
+	for i := range config.Filters {
+		query, err := config.Filters[i].query()
+		if err != nil {
+			return nil, err
+		}
+		queries[i] = query
+	}
+	return &Indexer{client: client, config: config, queries: queries}, nil
+}
+
+// Run indexes the events until the context is canceled or the handler returns an error.
+func (i *Indexer) Run(ctx context.Context, handler Handler) error {
+	ticker := time.NewTicker(i.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := i.Sync(ctx, handler); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync indexes the events up to the head of the chain, less the confirmations, and returns the cursor.
+func (i *Indexer) Sync(ctx context.Context, handler Handler) (Cursor, error) {
+	if err := i.load(ctx); err != nil {
+		return Cursor{}, err
+	}
+	if err := i.checkReorg(ctx, handler); err != nil {
+		return Cursor{}, err
+	}
+	head, err := i.client.BlockNumber(ctx)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("failed to get head block: %w", err)
+	}
+	if head < i.config.Confirmations {
+		return *i.cursor, nil
+	}
+	target := head - i.config.Confirmations
+	for i.next() <= target {
+		from := i.next()
+		to := from + i.config.BatchSize - 1
+		if to > target || to < from {
+			to = target
+		}
+		indexed, err := i.index(ctx, handler, from, to)
+		if err != nil {
+			return Cursor{}, err
+		}
+		if !indexed {
+			// The chain is being reorganized, which is handled by the next sync.
+			break
+		}
+	}
+	return *i.cursor, nil
+}
+
+// Cursor returns the last indexed block, or nil if nothing has been indexed yet.
+func (i *Indexer) Cursor(ctx context.Context) (*Cursor, error) {
+	if err := i.load(ctx); err != nil {
+		return nil, err
+	}
+	if i.cursor.Hash == (common.Hash{}) {
+		return nil, nil
+	}
+	cursor := *i.cursor
+	return &cursor, nil
+}
+
+// load loads the persisted cursor, or initializes it from the start block.
+func (i *Indexer) load(ctx context.Context) error {
+	if i.cursor != nil {
+		return nil
+	}
+	cursor, err := i.config.Store.LoadCursor(ctx, i.config.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load cursor: %w", err)
+	}
+	if cursor == nil {
+		// The zero hash marks that the start block has not been indexed yet.
+		cursor = &Cursor{Block: i.config.StartBlock}
+	}
+	i.cursor = cursor
+	return nil
+}
+
+// next returns the next block to index.
+func (i *Indexer) next() uint64 {
+	if i.cursor.Hash == (common.Hash{}) {
+		return i.cursor.Block
+	}
+	return i.cursor.Block + 1
+}
+
+// index indexes the events of the blocks in the range [from, to]. It reports false if the blocks have
+// been reorganized while they were indexed.
+func (i *Indexer) index(ctx context.Context, handler Handler, from, to uint64) (bool, error) {
+	hash, err := i.blockHash(ctx, to)
+	if err != nil {
+		return false, err
+	}
+	var events []Event
+	for j, query := range i.queries {
+		query.FromBlock, query.ToBlock = new(big.Int).SetUint64(from), new(big.Int).SetUint64(to)
+		logs, err := i.client.FilterLogsL2(ctx, query)
+		if err != nil {
+			return false, fmt.Errorf("failed to get logs of blocks [%d, %d]: %w", from, to, err)
+		}
+		filter := &i.config.Filters[j]
+		for k := range logs {
+			events = append(events, decode(filter, &logs[k]))
+		}
+	}
+	// The block could have been reorganized while the logs were fetched.
+	if after, err := i.blockHash(ctx, to); err != nil {
+		return false, err
+	} else if after != hash {
+		return false, nil
+	}
+	for _, event := range events {
+		if event.Log.BlockNumber == to && event.Log.BlockHash != hash {
+			return false, nil
+		}
+	}
+	sort.SliceStable(events, func(a, b int) bool {
+		if events[a].Log.BlockNumber != events[b].Log.BlockNumber {
+			return events[a].Log.BlockNumber < events[b].Log.BlockNumber
+		}
+		return events[a].Log.Index < events[b].Log.Index
+	})
+
+	cursor := Cursor{Block: to, Hash: hash}
+	if err = handler.HandleEvents(ctx, events, cursor); err != nil {
+		return false, err
+	}
+	if err = i.save(ctx, cursor); err != nil {
+		return false, err
+	}
+	i.recent = append(i.recent, cursor)
+	if len(i.recent) > i.config.ReorgDepth {
+		i.recent = i.recent[len(i.recent)-i.config.ReorgDepth:]
+	}
+	return true, nil
+}
+
+// checkReorg checks whether the last indexed block has been reorganized, in which case the indexer is
+// rewound to the common ancestor and the handler is notified.
+func (i *Indexer) checkReorg(ctx context.Context, handler Handler) error {
+	if i.cursor.Hash == (common.Hash{}) {
+		return nil
+	}
+	hash, err := i.blockHash(ctx, i.cursor.Block)
+	if err != nil {
+		return err
+	}
+	if hash == i.cursor.Hash {
+		return nil
+	}
+	// The cursor is the last of the recent blocks, unless the indexer has been restarted.
+	var ancestor Cursor
+	for j := len(i.recent) - 1; j >= 0 && ancestor.Hash == (common.Hash{}); j-- {
+		candidate := i.recent[j]
+		if candidate.Block >= i.cursor.Block {
+			continue
+		}
+		if hash, err = i.blockHash(ctx, candidate.Block); err != nil {
+			return err
+		}
+		if hash == candidate.Hash {
+			ancestor = candidate
+			i.recent = i.recent[:j+1]
+		}
+	}
+	valid := ancestor.Block
+	if ancestor.Hash == (common.Hash{}) {
+		// The common ancestor is not tracked, e.g. after restart, so the indexer is rewound by the reorg depth.
+		i.recent = nil
+		ancestor.Block = i.config.StartBlock
+		if depth := uint64(i.config.ReorgDepth); i.cursor.Block > ancestor.Block+depth {
+			ancestor.Block = i.cursor.Block - depth
+		}
+		// The block is not indexed yet, so only the blocks before it remain valid.
+		valid = ancestor.Block
+		if valid > 0 {
+			valid--
+		}
+	}
+	if err = handler.HandleReorg(ctx, valid); err != nil {
+		return err
+	}
+	return i.save(ctx, ancestor)
+}
+
+// save persists the cursor.
+func (i *Indexer) save(ctx context.Context, cursor Cursor) error {
+	if err := i.config.Store.SaveCursor(ctx, i.config.ID, cursor); err != nil {
+		return fmt.Errorf("failed to save cursor: %w", err)
+	}
+	i.cursor = &cursor
+	return nil
+}
+
+// blockHash returns the hash of the block, as reported by the node.
+func (i *Indexer) blockHash(ctx context.Context, number uint64) (common.Hash, error) {
+	block, err := i.client.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to get block %d: %w", number, err)
+	}
+	return block.Hash, nil
+}
+
+// decode decodes the event of the log using the ABI of the filter, if provided. If the log cannot be
+// decoded, only its log and the error are set.
+func decode(filter *Filter, log *zkTypes.Log) Event {
+	event := Event{Filter: filter.Name, DecodedEvent: zkTypes.DecodedEvent{Log: log}}
+	if filter.ABI == nil {
+		return event
+	}
+	decoded, err := zkTypes.DecodeLog(*filter.ABI, log)
+	if err != nil {
+		event.DecodeErr = fmt.Errorf("failed to decode log %d of transaction %s: %w", log.Index, log.TxHash, err)
+		return event
+	}
+	if decoded != nil {
+		event.DecodedEvent = *decoded
+	}
+	return event
+}
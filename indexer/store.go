@@ -0,0 +1,129 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store persists the cursors of the indexers, identified by Config.ID. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	// LoadCursor returns the cursor of the indexer, or nil if the cursor has not been saved yet.
+	LoadCursor(ctx context.Context, id string) (*Cursor, error)
+	// SaveCursor replaces the cursor of the indexer.
+	SaveCursor(ctx context.Context, id string, cursor Cursor) error
+}
+
+// MemoryStore is the Store keeping the cursors in memory, so the indexer does not resume after restarts.
+type MemoryStore struct {
+	mu      sync.Mutex
+	cursors map[string]Cursor
+}
+
+// NewMemoryStore creates an instance of MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{cursors: make(map[string]Cursor)}
+}
+
+func (s *MemoryStore) LoadCursor(_ context.Context, id string) (*Cursor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cursor, ok := s.cursors[id]
+	if !ok {
+		return nil, nil
+	}
+	return &cursor, nil
+}
+
+func (s *MemoryStore) SaveCursor(_ context.Context, id string, cursor Cursor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cursors[id] = cursor
+	return nil
+}
+
+// FileStore is the Store keeping the cursors in a JSON file, mapping the IDs of the indexers to their cursors.
+// The file is written atomically, so that the cursors survive process crashes.
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileStore creates an instance of FileStore, creating the directory of the file if it does not exist.
+func NewFileStore(path string) (*FileStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cursor store directory: %w", err)
+	}
+	return &FileStore{path: path}, nil
+}
+
+func (s *FileStore) LoadCursor(_ context.Context, id string) (*Cursor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cursors, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	cursor, ok := cursors[id]
+	if !ok {
+		return nil, nil
+	}
+	return &cursor, nil
+}
+
+func (s *FileStore) SaveCursor(_ context.Context, id string, cursor Cursor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cursors, err := s.read()
+	if err != nil {
+		return err
+	}
+	cursors[id] = cursor
+	return s.write(cursors)
+}
+
+func (s *FileStore) read() (map[string]Cursor, error) {
+	cursors := make(map[string]Cursor)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cursors, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &cursors); err != nil {
+		return nil, fmt.Errorf("failed to decode cursors: %w", err)
+	}
+	return cursors, nil
+}
+
+// write writes the cursors to the synced temporary file, which then replaces the file.
+func (s *FileStore) write(cursors map[string]Cursor) error {
+	data, err := json.Marshal(cursors)
+	if err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(s.path), ".cursors-*")
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), s.path)
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+	}
+	return err
+}
@@ -0,0 +1,195 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/zksync-sdk/zksync2-go/clients"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"math/big"
+	"reflect"
+	"time"
+)
+
+// watchPollInterval is the interval of polling the logs, if the client does not support subscriptions,
+// and of reconnecting the dropped subscription.
+const watchPollInterval = 2 * time.Second
+
+// WatchEvents delivers the events emitted by the contract after the call, decoded into T, to the sink. T is
+// the struct whose fields match the arguments of the event, e.g. the event type generated by abigen, or
+// the pointer to it. If T has the field Raw of type types.Log or zkTypes.Log, it is set to the log.
+//
+// The logs are received using the subscription, or by polling if the client does not support subscriptions.
+// The dropped subscription is reconnected, and the logs emitted in the meantime are backfilled, so that
+// each event is delivered once, in the order of the chain. The logs removed by reorgs are not delivered.
+//
+// Watching stops when the context is canceled or the subscription is unsubscribed. The error of decoding
+// the event, or the cancellation of the context, is delivered by the Err channel of the subscription.
+func WatchEvents[T any](ctx context.Context, client clients.Client, contract common.Address, abiEvent abi.Event, sink chan<- T) (ethereum.Subscription, error) {
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get head block: %w", err)
+	}
+	w := &watcher[T]{
+		client: client,
+		event:  abiEvent,
+		query:  ethereum.FilterQuery{Addresses: []common.Address{contract}, Topics: [][]common.Hash{{abiEvent.ID}}},
+		sink:   sink,
+		block:  head + 1,
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		return w.run(ctx, quit)
+	}), nil
+}
+
+// watcher implements WatchEvents.
+type watcher[T any] struct {
+	client clients.Client
+	event  abi.Event
+	query  ethereum.FilterQuery
+	sink   chan<- T
+
+	// The position of the next log to deliver.
+	block uint64
+	index uint
+}
+
+// run subscribes to the logs, backfills the logs emitted before the subscription and delivers the received
+// ones, reconnecting the subscription when it is dropped.
+func (w *watcher[T]) run(ctx context.Context, quit <-chan struct{}) error {
+	for {
+		logs := make(chan zkTypes.Log)
+		sub, err := w.client.SubscribeFilterLogsL2(ctx, w.query, logs)
+		if err != nil {
+			// The client does not support subscriptions, so the logs are polled.
+			sub = nil
+		}
+		stop, err := w.backfill(ctx, quit)
+		if sub != nil {
+			if stop || err != nil {
+				sub.Unsubscribe()
+			} else {
+				stop, err = w.receive(ctx, quit, sub, logs)
+			}
+		}
+		if stop {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-quit:
+			return nil
+		case <-time.After(watchPollInterval):
+		}
+	}
+}
+
+// backfill delivers the logs emitted since the last delivered one. It reports whether watching should stop.
+func (w *watcher[T]) backfill(ctx context.Context, quit <-chan struct{}) (bool, error) {
+	query := w.query
+	query.FromBlock = new(big.Int).SetUint64(w.block)
+	logs, err := w.client.FilterLogsL2(ctx, query)
+	if err != nil {
+		// The logs are fetched again after the poll interval.
+		return false, err
+	}
+	for k := range logs {
+		if stop, err := w.deliver(ctx, quit, &logs[k]); stop {
+			return true, err
+		}
+	}
+	return false, nil
+}
+
+// receive delivers the logs received by the subscription until it is dropped. It reports whether watching
+// should stop.
+func (w *watcher[T]) receive(ctx context.Context, quit <-chan struct{}, sub ethereum.Subscription, logs <-chan zkTypes.Log) (bool, error) {
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case log := <-logs:
+			if stop, err := w.deliver(ctx, quit, &log); stop {
+				return true, err
+			}
+		case err := <-sub.Err():
+			return false, err
+		case <-ctx.Done():
+			return true, ctx.Err()
+		case <-quit:
+			return true, nil
+		}
+	}
+}
+
+// deliver decodes the log and sends the event to the sink, unless the log has already been delivered or
+// has been removed. It reports whether watching should stop.
+func (w *watcher[T]) deliver(ctx context.Context, quit <-chan struct{}, log *zkTypes.Log) (bool, error) {
+	if log.Removed || log.BlockNumber < w.block || (log.BlockNumber == w.block && log.Index < w.index) {
+		return false, nil
+	}
+	value, err := decodeEvent[T](w.event, log)
+	if err != nil {
+		return true, err
+	}
+	select {
+	case w.sink <- value:
+		w.block, w.index = log.BlockNumber, log.Index+1
+		return false, nil
+	case <-ctx.Done():
+		return true, ctx.Err()
+	case <-quit:
+		return true, nil
+	}
+}
+
+// decodeEvent decodes the arguments of the event emitted by the log into T.
+func decodeEvent[T any](abiEvent abi.Event, log *zkTypes.Log) (T, error) {
+	var value T
+	out := reflect.ValueOf(&value)
+	if typ := reflect.TypeOf(value); typ != nil && typ.Kind() == reflect.Pointer {
+		out = reflect.New(typ.Elem())
+		reflect.ValueOf(&value).Elem().Set(out)
+	}
+
+	topics := log.Topics
+	if !abiEvent.Anonymous {
+		if len(topics) == 0 || topics[0] != abiEvent.ID {
+			return value, fmt.Errorf("log %s:%d is not event %s", log.TxHash, log.Index, abiEvent.Name)
+		}
+		topics = topics[1:]
+	}
+	// Like the ABI methods, the arguments unpack and copy the non-indexed arguments only.
+	values, err := abiEvent.Inputs.Unpack(log.Data)
+	if err != nil {
+		return value, fmt.Errorf("failed to decode event %s: %w", abiEvent.Name, err)
+	}
+	if err = abiEvent.Inputs.Copy(out.Interface(), values); err != nil {
+		return value, fmt.Errorf("failed to decode event %s: %w", abiEvent.Name, err)
+	}
+	var indexed abi.Arguments
+	for _, arg := range abiEvent.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err = abi.ParseTopics(out.Interface(), indexed, topics); err != nil {
+		return value, fmt.Errorf("failed to decode event %s: %w", abiEvent.Name, err)
+	}
+
+	if elem := out.Elem(); elem.Kind() == reflect.Struct {
+		if raw := elem.FieldByName("Raw"); raw.IsValid() && raw.CanSet() {
+			switch raw.Type() {
+			case reflect.TypeOf(types.Log{}):
+				raw.Set(reflect.ValueOf(log.Log))
+			case reflect.TypeOf(zkTypes.Log{}):
+				raw.Set(reflect.ValueOf(*log))
+			}
+		}
+	}
+	return value, nil
+}
@@ -0,0 +1,233 @@
+// Package lightclient provides the primitives for trust-minimized verification of the L2 chain: fetching
+// of L2 block headers, verification that they form a chain, and verification that the blocks belong
+// to L1 batches which have been executed on L1 network, using the getters of the zkSync Era contract.
+//
+// The verification does not link the headers to the data committed on L1 network: the block range of
+// the batch and the L1 batch numbers of the headers are reported by the node, and the hash of the batch
+// stored by the contract is not recomputed, as it commits to the batch info which the node does not expose.
+// The headers are therefore only as trustworthy as the node reporting them, and the checks detect
+// inconsistent responses rather than a malicious node.
+package lightclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/zksync-sdk/zksync2-go/clients"
+	"github.com/zksync-sdk/zksync2-go/contracts/zksync"
+	"math/big"
+)
+
+// maxBatchSize is the maximum number of headers requested in a single RPC batch.
+const maxBatchSize = 100
+
+var (
+	// ErrBrokenChain is returned when the headers do not form a chain.
+	ErrBrokenChain = errors.New("headers do not form a chain")
+	// ErrBatchNotExecuted is returned when the L1 batch has not been executed on L1 network.
+	ErrBatchNotExecuted = errors.New("batch is not executed")
+	// ErrBatchMismatch is returned when the header does not belong to the L1 batch.
+	ErrBatchMismatch = errors.New("header does not belong to batch")
+)
+
+// Header is the header of L2 block, containing the fields required for the verification.
+type Header struct {
+	Number        uint64
+	Hash          common.Hash
+	ParentHash    common.Hash
+	Timestamp     uint64
+	L1BatchNumber *big.Int // L1 batch containing the block, nil if the block has not been sealed into a batch yet.
+}
+
+type rpcHeader struct {
+	Number        hexutil.Uint64 `json:"number"`
+	Hash          common.Hash    `json:"hash"`
+	ParentHash    common.Hash    `json:"parentHash"`
+	Timestamp     hexutil.Uint64 `json:"timestamp"`
+	L1BatchNumber *hexutil.Big   `json:"l1BatchNumber"`
+}
+
+// Batch is the L1 batch executed on L1 network.
+type Batch struct {
+	Number         *big.Int
+	FirstBlock     uint64      // Number of the first L2 block of the batch.
+	LastBlock      uint64      // Number of the last L2 block of the batch.
+	RootHash       common.Hash // State root hash of the batch, as reported by the node.
+	StoredHash     common.Hash // Hash of the batch info stored by the zkSync Era contract on L1 network, not verified.
+	L2LogsRootHash common.Hash // Root hash of the L2 logs of the batch, stored by the zkSync Era contract.
+	ExecuteTxHash  common.Hash // Hash of the L1 transaction which executed the batch, as reported by the node.
+}
+
+// Contains checks whether the L2 block is part of the batch.
+func (b *Batch) Contains(blockNumber uint64) bool {
+	return blockNumber >= b.FirstBlock && blockNumber <= b.LastBlock
+}
+
+// FetchHeaders returns the headers of L2 blocks in the range [from, to], fetched using batched RPC requests.
+func FetchHeaders(ctx context.Context, client clients.Client, from, to uint64) ([]*Header, error) {
+	if from > to {
+		return nil, fmt.Errorf("invalid block range [%d, %d]", from, to)
+	}
+	headers := make([]*Header, 0, to-from+1)
+	for start := from; start <= to; start += maxBatchSize {
+		end := start + maxBatchSize - 1
+		if end > to || end < start {
+			end = to
+		}
+		raw := make([]*rpcHeader, end-start+1)
+		reqs := make([]rpc.BatchElem, len(raw))
+		for i := range reqs {
+			reqs[i] = rpc.BatchElem{
+				Method: "eth_getBlockByNumber",
+				Args:   []interface{}{hexutil.EncodeUint64(start + uint64(i)), false},
+				Result: &raw[i],
+			}
+		}
+		if err := client.Client().BatchCallContext(ctx, reqs); err != nil {
+			return nil, fmt.Errorf("failed to query headers: %w", err)
+		}
+		for i := range reqs {
+			if reqs[i].Error != nil {
+				return nil, fmt.Errorf("failed to query header %d: %w", start+uint64(i), reqs[i].Error)
+			}
+			if raw[i] == nil {
+				return nil, fmt.Errorf("header %d not found", start+uint64(i))
+			}
+			headers = append(headers, raw[i].toHeader())
+		}
+		if end == to {
+			break
+		}
+	}
+	return headers, nil
+}
+
+func (h *rpcHeader) toHeader() *Header {
+	header := &Header{
+		Number:     uint64(h.Number),
+		Hash:       h.Hash,
+		ParentHash: h.ParentHash,
+		Timestamp:  uint64(h.Timestamp),
+	}
+	if h.L1BatchNumber != nil {
+		header.L1BatchNumber = h.L1BatchNumber.ToInt()
+	}
+	return header
+}
+
+// VerifyChain checks that the headers, in ascending order of block numbers, form a chain: each header
+// references the hash of the previous one, and neither timestamps nor L1 batch numbers decrease.
+// The first header itself is not verified, the chain is only as trustworthy as its source.
+func VerifyChain(headers []*Header) error {
+	for i := 1; i < len(headers); i++ {
+		prev, next := headers[i-1], headers[i]
+		switch {
+		case next.Number != prev.Number+1:
+			return fmt.Errorf("%w: block %d follows block %d", ErrBrokenChain, next.Number, prev.Number)
+		case next.ParentHash != prev.Hash:
+			return fmt.Errorf("%w: parent hash of block %d is %s, expected %s",
+				ErrBrokenChain, next.Number, next.ParentHash, prev.Hash)
+		case next.Timestamp < prev.Timestamp:
+			return fmt.Errorf("%w: timestamp of block %d decreases", ErrBrokenChain, next.Number)
+		case prev.L1BatchNumber == nil && next.L1BatchNumber != nil:
+			return fmt.Errorf("%w: block %d is sealed into batch, but block %d is not",
+				ErrBrokenChain, next.Number, prev.Number)
+		case prev.L1BatchNumber != nil && next.L1BatchNumber != nil && next.L1BatchNumber.Cmp(prev.L1BatchNumber) < 0:
+			return fmt.Errorf("%w: batch number of block %d decreases", ErrBrokenChain, next.Number)
+		}
+	}
+	return nil
+}
+
+// FetchExecutedBatch returns the L1 batch, having checked that it has been executed on L1 network. The batch
+// data stored on L1 network is read using l1Caller from the zkSync Era contract, and ErrBatchNotExecuted
+// is returned if the batch has not been executed yet. The block range and the root hash of the batch are
+// reported by the node.
+func FetchExecutedBatch(ctx context.Context, client clients.Client, l1Caller bind.ContractCaller, number *big.Int) (*Batch, error) {
+	if number == nil {
+		return nil, errors.New("batch number must be provided")
+	}
+	mainContractAddress, err := client.MainContractAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mainContract, err := zksync.NewIZkSyncCaller(mainContractAddress, l1Caller)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load IZkSync: %w", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	executed, err := mainContract.GetTotalBlocksExecuted(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get number of executed batches: %w", err)
+	}
+	if number.Sign() <= 0 || number.Cmp(executed) > 0 {
+		return nil, fmt.Errorf("%w: batch %s, executed batches %s", ErrBatchNotExecuted, number, executed)
+	}
+	storedHash, err := mainContract.StoredBlockHash(opts, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored batch hash: %w", err)
+	}
+	logsRootHash, err := mainContract.L2LogsRootHash(opts, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L2 logs root hash: %w", err)
+	}
+
+	blockRange, err := client.L1BatchBlockRange(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	details, err := client.L1BatchDetails(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	return &Batch{
+		Number:         new(big.Int).Set(number),
+		FirstBlock:     blockRange.Beginning.Uint64(),
+		LastBlock:      blockRange.End.Uint64(),
+		RootHash:       details.RootHash,
+		StoredHash:     storedHash,
+		L2LogsRootHash: logsRootHash,
+		ExecuteTxHash:  details.ExecuteTxHash,
+	}, nil
+}
+
+// VerifyBatchHeaders checks that the headers belong to the batch, both by the block range of the batch
+// and by the L1 batch numbers of the headers.
+func VerifyBatchHeaders(batch *Batch, headers []*Header) error {
+	for _, h := range headers {
+		if !batch.Contains(h.Number) {
+			return fmt.Errorf("%w: block %d is out of range [%d, %d] of batch %s",
+				ErrBatchMismatch, h.Number, batch.FirstBlock, batch.LastBlock, batch.Number)
+		}
+		if h.L1BatchNumber == nil || h.L1BatchNumber.Cmp(batch.Number) != 0 {
+			return fmt.Errorf("%w: block %d has batch number %v, expected %s",
+				ErrBatchMismatch, h.Number, h.L1BatchNumber, batch.Number)
+		}
+	}
+	return nil
+}
+
+// VerifyExecuted fetches the headers of all blocks of the executed L1 batch, and checks that they form
+// a chain belonging to the batch, as reported by the node. The headers are not proven against the data
+// committed on L1 network, so they must not be used as trusted checkpoints unless the node is trusted.
+func VerifyExecuted(ctx context.Context, client clients.Client, l1Caller bind.ContractCaller, number *big.Int) (*Batch, []*Header, error) {
+	batch, err := FetchExecutedBatch(ctx, client, l1Caller, number)
+	if err != nil {
+		return nil, nil, err
+	}
+	headers, err := FetchHeaders(ctx, client, batch.FirstBlock, batch.LastBlock)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = VerifyChain(headers); err != nil {
+		return nil, nil, err
+	}
+	if err = VerifyBatchHeaders(batch, headers); err != nil {
+		return nil, nil, err
+	}
+	return batch, headers, nil
+}
@@ -0,0 +1,179 @@
+// Package scenarios provides a runner of end-to-end flows, such as fund -> deploy -> interact -> withdraw,
+// composed of reusable steps and assertions. Scenarios can be run against local or test networks to validate
+// infrastructure against new networks and protocol versions. Custom steps can be created using NewStep.
+package scenarios
+
+import (
+	"context"
+	"fmt"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/zksync-sdk/zksync2-go/accounts"
+	"github.com/zksync-sdk/zksync2-go/clients"
+	"time"
+)
+
+// Env is the environment in which the scenario is run. Steps share data, e.g. addresses of deployed contracts,
+// by storing them in the environment.
+type Env struct {
+	Context  context.Context   // Context of the scenario run, set by Scenario.Run.
+	Wallet   *accounts.Wallet  // Wallet used to send transactions.
+	ClientL2 clients.Client    // Client of the L2 network.
+	ClientL1 *ethclient.Client // Client of the L1 network. Required only by steps interacting with L1.
+
+	values map[string]interface{}
+}
+
+// NewEnv creates an instance of Env.
+func NewEnv(wallet *accounts.Wallet, clientL2 clients.Client, clientL1 *ethclient.Client) *Env {
+	return &Env{
+		Context:  context.Background(),
+		Wallet:   wallet,
+		ClientL2: clientL2,
+		ClientL1: clientL1,
+		values:   make(map[string]interface{}),
+	}
+}
+
+// Set stores the value under the key.
+func (e *Env) Set(key string, value interface{}) {
+	e.values[key] = value
+}
+
+// Get returns the value stored under the key.
+func (e *Env) Get(key string) (interface{}, bool) {
+	value, ok := e.values[key]
+	return value, ok
+}
+
+// Value returns the value of type T stored under the key.
+func Value[T any](env *Env, key string) (T, error) {
+	var zero T
+	value, ok := env.values[key]
+	if !ok {
+		return zero, fmt.Errorf("value %q is not set", key)
+	}
+	typed, ok := value.(T)
+	if !ok {
+		return zero, fmt.Errorf("value %q has type %T, expected %T", key, value, zero)
+	}
+	return typed, nil
+}
+
+// Step is a single action or assertion of the scenario.
+type Step interface {
+	// Name returns the name of the step, used in reports.
+	Name() string
+	// Run executes the step in the environment.
+	Run(env *Env) error
+}
+
+type funcStep struct {
+	name string
+	run  func(env *Env) error
+}
+
+func (s *funcStep) Name() string {
+	return s.name
+}
+
+func (s *funcStep) Run(env *Env) error {
+	return s.run(env)
+}
+
+// NewStep creates a Step with the given name, which executes the function.
+func NewStep(name string, run func(env *Env) error) Step {
+	return &funcStep{name: name, run: run}
+}
+
+// StepResult is the result of the executed step.
+type StepResult struct {
+	Name     string
+	Duration time.Duration
+	Err      error
+}
+
+// Report is the result of the scenario run.
+type Report struct {
+	Scenario string
+	Steps    []StepResult
+	Duration time.Duration
+}
+
+// Failed reports whether any of the steps has failed.
+func (r *Report) Failed() bool {
+	for _, step := range r.Steps {
+		if step.Err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Report) String() string {
+	s := fmt.Sprintf("scenario %q (%s)\n", r.Scenario, r.Duration.Round(time.Millisecond))
+	for _, step := range r.Steps {
+		status := "ok"
+		if step.Err != nil {
+			status = "FAIL: " + step.Err.Error()
+		}
+		s += fmt.Sprintf("  %-40s %10s  %s\n", step.Name, step.Duration.Round(time.Millisecond), status)
+	}
+	return s
+}
+
+// Scenario is a sequence of steps run in order. The run stops at the first failed step.
+type Scenario struct {
+	Name  string
+	Steps []Step
+}
+
+// New creates a Scenario with the given steps.
+func New(name string, steps ...Step) *Scenario {
+	return &Scenario{Name: name, Steps: steps}
+}
+
+// Then appends the steps to the scenario, and returns the scenario.
+func (s *Scenario) Then(steps ...Step) *Scenario {
+	s.Steps = append(s.Steps, steps...)
+	return s
+}
+
+// Extend appends the steps of other scenarios to the scenario, and returns the scenario.
+func (s *Scenario) Extend(scenarios ...*Scenario) *Scenario {
+	for _, other := range scenarios {
+		s.Steps = append(s.Steps, other.Steps...)
+	}
+	return s
+}
+
+// Run executes the steps of the scenario in the environment. The returned report contains the results
+// of the executed steps, while the error is the one of the failed step, if any.
+func (s *Scenario) Run(ctx context.Context, env *Env) (*Report, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	env.Context = ctx
+	if env.values == nil {
+		env.values = make(map[string]interface{})
+	}
+
+	report := &Report{Scenario: s.Name}
+	start := time.Now()
+	defer func() { report.Duration = time.Since(start) }()
+	for _, step := range s.Steps {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		stepStart := time.Now()
+		err := step.Run(env)
+		report.Steps = append(report.Steps, StepResult{
+			Name:     step.Name(),
+			Duration: time.Since(stepStart),
+			Err:      err,
+		})
+		if err != nil {
+			return report, fmt.Errorf("step %q failed: %w", step.Name(), err)
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,216 @@
+package scenarios
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/zksync-sdk/zksync2-go/accounts"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"math/big"
+)
+
+// Deposit deposits the amount of the token from L1 to the wallet on L2, and waits until the deposit
+// is executed on L2. The hash of the L2 transaction is stored under the key.
+func Deposit(key string, token common.Address, amount *big.Int) Step {
+	return NewStep(fmt.Sprintf("deposit %s of %s", amount, token), func(env *Env) error {
+		if env.ClientL1 == nil {
+			return errors.New("L1 client is required")
+		}
+		tx, err := env.Wallet.Deposit(&accounts.TransactOpts{Context: env.Context}, accounts.DepositTransaction{
+			To:           env.Wallet.Address(),
+			Token:        token,
+			Amount:       amount,
+			ApproveERC20: true,
+		})
+		if err != nil {
+			return err
+		}
+		l1Receipt, err := bind.WaitMined(env.Context, env.ClientL1, tx)
+		if err != nil {
+			return err
+		}
+		if l1Receipt.Status != types.ReceiptStatusSuccessful {
+			return fmt.Errorf("L1 transaction %s failed", tx.Hash())
+		}
+		l2Tx, err := env.ClientL2.L2TransactionFromPriorityOp(env.Context, l1Receipt)
+		if err != nil {
+			return err
+		}
+		if _, err = waitSuccessful(env, l2Tx.Hash); err != nil {
+			return err
+		}
+		env.Set(key, l2Tx.Hash)
+		return nil
+	})
+}
+
+// Transfer transfers the amount of the token from the wallet to the recipient on L2.
+func Transfer(to common.Address, token common.Address, amount *big.Int) Step {
+	return NewStep(fmt.Sprintf("transfer %s of %s to %s", amount, token, to), func(env *Env) error {
+		tx, err := env.Wallet.Transfer(&accounts.TransactOpts{Context: env.Context}, accounts.TransferTransaction{
+			To:     to,
+			Token:  token,
+			Amount: amount,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = waitSuccessful(env, tx.Hash())
+		return err
+	})
+}
+
+// Deploy deploys the smart contract using CREATE opcode, and stores its address under the key.
+func Deploy(key string, bytecode, calldata []byte, dependencies ...[]byte) Step {
+	return NewStep(fmt.Sprintf("deploy %s", key), func(env *Env) error {
+		hash, err := env.Wallet.DeployWithCreate(&accounts.TransactOpts{Context: env.Context}, accounts.CreateTransaction{
+			Bytecode:     bytecode,
+			Calldata:     calldata,
+			Dependencies: dependencies,
+		})
+		if err != nil {
+			return err
+		}
+		receipt, err := waitSuccessful(env, hash)
+		if err != nil {
+			return err
+		}
+		if receipt.ContractAddress == (common.Address{}) {
+			return fmt.Errorf("transaction %s did not create a contract", hash)
+		}
+		env.Set(key, receipt.ContractAddress)
+		return nil
+	})
+}
+
+// Transact sends the transaction with the calldata to the contract whose address is stored under the key.
+func Transact(key string, calldata []byte, value *big.Int) Step {
+	return NewStep(fmt.Sprintf("transact with %s", key), func(env *Env) error {
+		contract, err := Value[common.Address](env, key)
+		if err != nil {
+			return err
+		}
+		hash, err := env.Wallet.SendTransaction(env.Context, &accounts.Transaction{
+			To:    &contract,
+			Data:  calldata,
+			Value: value,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = waitSuccessful(env, hash)
+		return err
+	})
+}
+
+// AssertCall calls the contract whose address is stored under the key with the calldata,
+// and checks that the call returns the expected data.
+func AssertCall(key string, calldata, expected []byte) Step {
+	return NewStep(fmt.Sprintf("assert call to %s", key), func(env *Env) error {
+		contract, err := Value[common.Address](env, key)
+		if err != nil {
+			return err
+		}
+		result, err := env.Wallet.CallContract(env.Context, accounts.CallMsg{To: &contract, Data: calldata}, nil)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(result, expected) {
+			return fmt.Errorf("call returned %x, expected %x", result, expected)
+		}
+		return nil
+	})
+}
+
+// AssertBalanceAtLeast checks that the L2 balance of the token of the wallet is at least the minimum.
+func AssertBalanceAtLeast(token common.Address, minimum *big.Int) Step {
+	return NewStep(fmt.Sprintf("assert balance of %s >= %s", token, minimum), func(env *Env) error {
+		balance, err := env.Wallet.Balance(env.Context, token, nil)
+		if err != nil {
+			return err
+		}
+		if balance.Cmp(minimum) < 0 {
+			return fmt.Errorf("balance %s is below %s", balance, minimum)
+		}
+		return nil
+	})
+}
+
+// Withdraw withdraws the amount of the token from the wallet on L2 to the same account on L1, and waits
+// until the withdrawal is included in a block. The hash of the withdrawal is stored under the key.
+func Withdraw(key string, token common.Address, amount *big.Int) Step {
+	return NewStep(fmt.Sprintf("withdraw %s of %s", amount, token), func(env *Env) error {
+		tx, err := env.Wallet.Withdraw(&accounts.TransactOpts{Context: env.Context}, accounts.WithdrawalTransaction{
+			To:     env.Wallet.Address(),
+			Token:  token,
+			Amount: amount,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err = waitSuccessful(env, tx.Hash()); err != nil {
+			return err
+		}
+		env.Set(key, tx.Hash())
+		return nil
+	})
+}
+
+// FinalizeWithdraw waits until the withdrawal whose hash is stored under the key is finalized on L2,
+// and finalizes it on L1. Since the finalization of L2 blocks can take hours on test networks,
+// the step is intended for local networks.
+func FinalizeWithdraw(key string) Step {
+	return NewStep(fmt.Sprintf("finalize withdrawal %s", key), func(env *Env) error {
+		if env.ClientL1 == nil {
+			return errors.New("L1 client is required")
+		}
+		hash, err := Value[common.Hash](env, key)
+		if err != nil {
+			return err
+		}
+		if _, err = env.ClientL2.WaitFinalized(env.Context, hash); err != nil {
+			return err
+		}
+		tx, err := env.Wallet.FinalizeWithdraw(&accounts.TransactOpts{Context: env.Context}, hash, 0)
+		if err != nil {
+			return err
+		}
+		receipt, err := bind.WaitMined(env.Context, env.ClientL1, tx)
+		if err != nil {
+			return err
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			return fmt.Errorf("L1 transaction %s failed", tx.Hash())
+		}
+		return nil
+	})
+}
+
+// FundDeployInteractWithdraw returns the scenario which deposits the deposit amount of ETH, deploys the contract,
+// sends the transaction with the calldata to it and withdraws the withdrawal amount of ETH back to L1, which
+// must leave enough for fees. The scenario can be extended with further steps, e.g. FinalizeWithdraw.
+func FundDeployInteractWithdraw(deposit, withdrawal *big.Int, bytecode, constructor, calldata []byte) *Scenario {
+	return New("fund, deploy, interact, withdraw",
+		Deposit("deposit", utils.EthAddress, deposit),
+		AssertBalanceAtLeast(utils.EthAddress, deposit),
+		Deploy("contract", bytecode, constructor),
+		Transact("contract", calldata, nil),
+		Withdraw("withdrawal", utils.EthAddress, withdrawal),
+	)
+}
+
+// waitSuccessful waits until the L2 transaction is included in a block, and checks that it has succeeded.
+func waitSuccessful(env *Env, hash common.Hash) (*zkTypes.Receipt, error) {
+	receipt, err := env.ClientL2.WaitMined(env.Context, hash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("L2 transaction %s failed", hash)
+	}
+	return receipt, nil
+}
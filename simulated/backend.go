@@ -0,0 +1,364 @@
+// Package simulated provides the in-process zkSync Era chain implementing clients.Client, analogous to
+// SimulatedBackend of go-ethereum, so that code using the client and wallets can be unit-tested fast,
+// without running era_test_node.
+//
+// Contracts are executed by the EVM rather than EraVM, so they must be compiled using solc instead of zksolc.
+// The zkSync Era specifics are emulated approximately:
+//   - EIP-712 (0x71) transactions are accepted along with the Ethereum ones. The signature is verified for
+//     accounts without code, while the transactions of smart accounts are accepted without validation.
+//   - Factory dependencies of transactions are registered as known bytecodes. Deployments through
+//     ContractDeployer execute the known bytecode, as the creation code followed by the constructor input,
+//     and place the contract at the address computed the same as on zkSync Era. Bytecodes must be padded
+//     to the multiple of 32 bytes, see PadBytecode. Contracts created by other contracts using CREATE and
+//     CREATE2 opcodes get the addresses computed the same as on Ethereum.
+//   - The fee of the transaction using the paymaster is paid by the paymaster. For the approval-based flow,
+//     the allowance of the token is approved for the paymaster, but the paymaster itself is not called.
+//   - NonceHolder, ContractDeployer, KnownCodesStorage and L2BaseToken system contracts are emulated when
+//     called directly by transactions and calls. When called by other contracts, their stub code does nothing
+//     and returns no data. Withdrawals of the base token emit the L2 to L1 messages, as on zkSync Era.
+//   - Each transaction is mined in its own block, which is also its own L1 batch and is final immediately.
+//     L1 and bridges are not simulated, so the methods depending on them return ErrNotSupported.
+package simulated
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/zksync-sdk/zksync2-go/clients"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"math/big"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultChainID       = 260        // Default chain ID, the same as of era_test_node.
+	DefaultL1ChainID     = 9          // Default chain ID of L1, the same as of era_test_node.
+	DefaultBlockGasLimit = 80_000_000 // Default gas limit of blocks, which caps the gas limit of transactions.
+)
+
+// DefaultBaseFee is the default base fee of the chain.
+var DefaultBaseFee = big.NewInt(100_000_000)
+
+// systemContracts are the system contracts emulated by the backend.
+var systemContracts = []common.Address{
+	utils.ContractDeployerAddress,
+	utils.NonceHolderAddress,
+	utils.KnownCodesStorageAddress,
+	utils.L1MessengerAddress,
+	utils.L2BaseTokenAddress,
+}
+
+var (
+	// ErrNotSupported is returned by methods which depend on features which are not simulated, such as L1.
+	ErrNotSupported = errors.New("not supported by simulated backend")
+	// ErrClosed is returned when the closed backend is used.
+	ErrClosed = errors.New("simulated backend is closed")
+)
+
+// Options contains the options of Backend.
+type Options struct {
+	ChainID       *big.Int // Chain ID. Optional, DefaultChainID is used by default.
+	L1ChainID     *big.Int // Chain ID of L1. Optional, DefaultL1ChainID is used by default.
+	BaseFee       *big.Int // Base fee, which is constant. Optional, DefaultBaseFee is used by default.
+	BlockGasLimit uint64   // Gas limit of blocks. Optional, DefaultBlockGasLimit is used by default.
+}
+
+// Backend is the in-process zkSync Era chain, whose state is kept in memory. It is safe for concurrent use.
+type Backend struct {
+	mu sync.Mutex
+
+	config    *params.ChainConfig
+	l1ChainID *big.Int
+	baseFee   *big.Int
+	gasLimit  uint64
+
+	database  state.Database
+	blocks    []*block
+	byHash    map[common.Hash]*block
+	txs       map[common.Hash]txLookup
+	bytecodes map[common.Hash][]byte // Known bytecodes by their versioned hashes.
+	// timeOffset is added to the wall clock time when blocks are mined, see AdjustTime.
+	timeOffset time.Duration
+
+	headFeed event.Feed
+	logsFeed event.Feed
+	scope    event.SubscriptionScope
+	closed   bool
+}
+
+var _ clients.Client = (*Backend)(nil)
+
+// block is the mined block along with its transactions and receipts.
+type block struct {
+	header   *types.Header
+	hash     common.Hash
+	txs      []*zkTypes.TransactionResponse
+	receipts []*zkTypes.Receipt
+}
+
+type txLookup struct {
+	block *block
+	index int
+}
+
+// NewBackend creates an instance of Backend, whose genesis state contains the given accounts.
+// The options are optional.
+func NewBackend(alloc core.GenesisAlloc, opts *Options) (*Backend, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	config := *params.AllEthashProtocolChanges
+	config.ChainID = big.NewInt(DefaultChainID)
+	if opts.ChainID != nil {
+		config.ChainID = new(big.Int).Set(opts.ChainID)
+	}
+	config.TerminalTotalDifficulty = common.Big0
+	config.ShanghaiTime = new(uint64)
+	b := &Backend{
+		config:    &config,
+		l1ChainID: big.NewInt(DefaultL1ChainID),
+		baseFee:   new(big.Int).Set(DefaultBaseFee),
+		gasLimit:  DefaultBlockGasLimit,
+		database:  state.NewDatabase(rawdb.NewMemoryDatabase()),
+		byHash:    make(map[common.Hash]*block),
+		txs:       make(map[common.Hash]txLookup),
+		bytecodes: make(map[common.Hash][]byte),
+	}
+	if opts.L1ChainID != nil {
+		b.l1ChainID = new(big.Int).Set(opts.L1ChainID)
+	}
+	if opts.BaseFee != nil {
+		b.baseFee = new(big.Int).Set(opts.BaseFee)
+	}
+	if opts.BlockGasLimit != 0 {
+		b.gasLimit = opts.BlockGasLimit
+	}
+
+	statedb, err := state.New(types.EmptyRootHash, b.database, nil)
+	if err != nil {
+		return nil, err
+	}
+	for address, account := range alloc {
+		if account.Balance != nil {
+			statedb.AddBalance(address, account.Balance)
+		}
+		statedb.SetCode(address, account.Code)
+		statedb.SetNonce(address, account.Nonce)
+		for key, value := range account.Storage {
+			statedb.SetState(address, key, value)
+		}
+	}
+	// The emulated system contracts get the stub code, which makes them look deployed to the bindings
+	// and keeps their storage from being removed together with empty accounts.
+	for _, address := range systemContracts {
+		if statedb.GetCodeSize(address) == 0 {
+			statedb.SetCode(address, []byte{byte(vm.STOP)})
+		}
+	}
+	root, err := statedb.Commit(true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to commit genesis state: %w", err)
+	}
+	b.appendBlock(&types.Header{
+		Number:     new(big.Int),
+		Root:       root,
+		Coinbase:   utils.BootloaderFormalAddress,
+		Difficulty: new(big.Int),
+		GasLimit:   b.gasLimit,
+		Time:       uint64(time.Now().Unix()),
+		BaseFee:    new(big.Int).Set(b.baseFee),
+	}, nil, nil)
+	return b, nil
+}
+
+// PadBytecode returns the bytecode padded with zero bytes to the multiple of 32 bytes, as required
+// for the bytecode hash, so that the bytecode compiled with solc can be deployed through ContractDeployer.
+// The trailing zero bytes of known bytecodes are removed before they are executed.
+func PadBytecode(bytecode []byte) []byte {
+	padded := make([]byte, (len(bytecode)+31)/32*32)
+	copy(padded, bytecode)
+	return padded
+}
+
+// SetBalance sets the balance of the base token of the account. The change is mined in a new block.
+func (b *Backend) SetBalance(account common.Address, balance *big.Int) error {
+	return b.modifyState(func(statedb *state.StateDB) {
+		statedb.SetBalance(account, balance)
+	})
+}
+
+// SetCode sets the code of the account. The change is mined in a new block.
+func (b *Backend) SetCode(account common.Address, code []byte) error {
+	return b.modifyState(func(statedb *state.StateDB) {
+		statedb.SetCode(account, code)
+	})
+}
+
+// SetStorageAt sets the value of the storage slot of the account. The change is mined in a new block.
+func (b *Backend) SetStorageAt(account common.Address, key, value common.Hash) error {
+	return b.modifyState(func(statedb *state.StateDB) {
+		statedb.SetState(account, key, value)
+	})
+}
+
+// Commit mines the empty block, e.g. to advance the block number.
+func (b *Backend) Commit() error {
+	return b.modifyState(func(*state.StateDB) {})
+}
+
+// AdjustTime advances the time of the chain by the duration, and mines the empty block with the new time.
+func (b *Backend) AdjustTime(d time.Duration) error {
+	if d < 0 {
+		return errors.New("time cannot be moved backwards")
+	}
+	b.mu.Lock()
+	b.timeOffset += d
+	b.mu.Unlock()
+	return b.Commit()
+}
+
+// RegisterBytecode registers the bytecode as known, as if it was published as the factory dependency,
+// and returns its hash.
+func (b *Backend) RegisterBytecode(bytecode []byte) (common.Hash, error) {
+	var hash common.Hash
+	err := b.modifyState(func(statedb *state.StateDB) {
+		hash, _ = b.registerBytecode(statedb, bytecode)
+	})
+	if err == nil && hash == (common.Hash{}) {
+		err = errors.New("invalid bytecode: length must be divisible by 32")
+	}
+	return hash, err
+}
+
+// modifyState applies the modification to the latest state and mines it in a new block.
+func (b *Backend) modifyState(modify func(statedb *state.StateDB)) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrClosed
+	}
+	statedb, err := b.headState()
+	if err != nil {
+		b.mu.Unlock()
+		return err
+	}
+	modify(statedb)
+	root, err := statedb.Commit(true)
+	if err != nil {
+		b.mu.Unlock()
+		return err
+	}
+	header := b.nextHeader()
+	header.Root = root
+	mined := b.appendBlock(header, nil, nil)
+	b.mu.Unlock()
+
+	b.headFeed.Send(types.CopyHeader(mined.header))
+	return nil
+}
+
+// head returns the latest block. The lock must be held.
+func (b *Backend) head() *block {
+	return b.blocks[len(b.blocks)-1]
+}
+
+// headState returns the copy of the latest state. The lock must be held.
+func (b *Backend) headState() (*state.StateDB, error) {
+	return state.New(b.head().header.Root, b.database, nil)
+}
+
+// blockByNumber returns the block with the number, or the latest block if the number is nil or negative,
+// as used for the block tags. The lock must be held.
+func (b *Backend) blockByNumber(number *big.Int) (*block, error) {
+	if number == nil || number.Sign() < 0 {
+		return b.head(), nil
+	}
+	if !number.IsUint64() || number.Uint64() >= uint64(len(b.blocks)) {
+		return nil, fmt.Errorf("block %s not found", number)
+	}
+	return b.blocks[number.Uint64()], nil
+}
+
+// stateAt returns the copy of the state after the block with the number. The lock must be held.
+func (b *Backend) stateAt(number *big.Int) (*state.StateDB, *types.Header, error) {
+	blk, err := b.blockByNumber(number)
+	if err != nil {
+		return nil, nil, err
+	}
+	statedb, err := state.New(blk.header.Root, b.database, nil)
+	return statedb, blk.header, err
+}
+
+// nextHeader returns the header of the next block, without the state root. The lock must be held.
+func (b *Backend) nextHeader() *types.Header {
+	parent := b.head().header
+	timestamp := uint64(time.Now().Add(b.timeOffset).Unix())
+	if timestamp <= parent.Time {
+		timestamp = parent.Time + 1
+	}
+	return &types.Header{
+		ParentHash: b.head().hash,
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		Coinbase:   utils.BootloaderFormalAddress,
+		Difficulty: new(big.Int),
+		GasLimit:   b.gasLimit,
+		Time:       timestamp,
+		BaseFee:    new(big.Int).Set(b.baseFee),
+	}
+}
+
+// appendBlock appends the block with the transactions and receipts to the chain, filling the block
+// related fields of the transactions, receipts and logs. The lock must be held.
+func (b *Backend) appendBlock(header *types.Header, txs []*zkTypes.TransactionResponse, receipts []*zkTypes.Receipt) *block {
+	var ethReceipts types.Receipts
+	for _, r := range receipts {
+		header.GasUsed += r.GasUsed
+		ethReceipts = append(ethReceipts, &r.Receipt)
+	}
+	header.Bloom = types.CreateBloom(ethReceipts)
+	mined := &block{header: header, hash: header.Hash(), txs: txs, receipts: receipts}
+	batch := (*hexutil.Big)(new(big.Int).Set(header.Number))
+	for i, r := range receipts {
+		txs[i].BlockHash = &mined.hash
+		txs[i].BlockNumber = (*hexutil.Big)(new(big.Int).Set(header.Number))
+		txs[i].L1BatchNumber = hexutil.Big(*header.Number)
+		txs[i].TransactionIndex = hexutil.Uint(i)
+		txs[i].L1BatchTxIndex = hexutil.Big(*big.NewInt(int64(i)))
+		r.BlockHash = mined.hash
+		r.BlockNumber = new(big.Int).Set(header.Number)
+		r.TransactionIndex = uint(i)
+		r.L1BatchNumber = batch
+		r.L1BatchTxIndex = (*hexutil.Big)(big.NewInt(int64(i)))
+		for _, l := range r.Logs {
+			l.BlockHash = mined.hash
+			l.BlockNumber = header.Number.Uint64()
+			l.TxIndex = uint(i)
+			l.L1BatchNumber = batch
+		}
+		for _, l := range r.Receipt.Logs {
+			l.BlockHash = mined.hash
+			l.BlockNumber = header.Number.Uint64()
+			l.TxIndex = uint(i)
+		}
+		for _, l := range r.L2ToL1Logs {
+			l.BlockHash = mined.hash
+			l.BlockNumber = (*hexutil.Big)(new(big.Int).Set(header.Number))
+			l.L1BatchNumber = batch
+			l.TransactionIndex = (*hexutil.Uint)(&r.TransactionIndex)
+		}
+		b.txs[r.TxHash] = txLookup{block: mined, index: i}
+	}
+	b.blocks = append(b.blocks, mined)
+	b.byHash[mined.hash] = mined
+	return mined
+}
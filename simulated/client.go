@@ -0,0 +1,1030 @@
+package simulated
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/zksync-sdk/zksync2-go/clients"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"math/big"
+	"time"
+)
+
+// pollInterval is the interval at which WaitMined and WaitForBlock check the chain.
+const pollInterval = 10 * time.Millisecond
+
+// Client returns nil, as the backend is not connected to the RPC server.
+func (b *Backend) Client() *rpc.Client {
+	return nil
+}
+
+// Close closes the backend and terminates the subscriptions.
+func (b *Backend) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.closed {
+		b.closed = true
+		b.scope.Close()
+	}
+}
+
+// InvalidateCache does nothing, as the backend does not cache anything.
+func (b *Backend) InvalidateCache() {}
+
+func (b *Backend) ChainID(_ context.Context) (*big.Int, error) {
+	return new(big.Int).Set(b.config.ChainID), nil
+}
+
+func (b *Backend) BlockByHash(_ context.Context, hash common.Hash) (*zkTypes.Block, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	blk, ok := b.byHash[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return blk.toBlock(), nil
+}
+
+func (b *Backend) BlockByNumber(_ context.Context, number *big.Int) (*zkTypes.Block, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	blk, err := b.blockByNumber(number)
+	if err != nil {
+		return nil, ethereum.NotFound
+	}
+	return blk.toBlock(), nil
+}
+
+func (b *Backend) BlockNumber(_ context.Context) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.head().header.Number.Uint64(), nil
+}
+
+func (b *Backend) PeerCount(_ context.Context) (uint64, error) {
+	return 0, nil
+}
+
+func (b *Backend) HeaderByHash(_ context.Context, hash common.Hash) (*types.Header, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	blk, ok := b.byHash[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return types.CopyHeader(blk.header), nil
+}
+
+func (b *Backend) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	blk, err := b.blockByNumber(number)
+	if err != nil {
+		return nil, ethereum.NotFound
+	}
+	return types.CopyHeader(blk.header), nil
+}
+
+func (b *Backend) TransactionByHash(_ context.Context, hash common.Hash) (*zkTypes.TransactionResponse, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	lookup, ok := b.txs[hash]
+	if !ok {
+		return nil, false, ethereum.NotFound
+	}
+	tx := *lookup.block.txs[lookup.index]
+	return &tx, false, nil
+}
+
+func (b *Backend) TransactionSender(_ context.Context, tx *zkTypes.TransactionResponse, _ common.Hash, _ uint) (common.Address, error) {
+	return tx.From, nil
+}
+
+func (b *Backend) TransactionCount(_ context.Context, blockHash common.Hash) (uint, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	blk, ok := b.byHash[blockHash]
+	if !ok {
+		return 0, ethereum.NotFound
+	}
+	return uint(len(blk.txs)), nil
+}
+
+func (b *Backend) TransactionInBlock(_ context.Context, blockHash common.Hash, index uint) (*zkTypes.TransactionResponse, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	blk, ok := b.byHash[blockHash]
+	if !ok || index >= uint(len(blk.txs)) {
+		return nil, ethereum.NotFound
+	}
+	tx := *blk.txs[index]
+	return &tx, nil
+}
+
+func (b *Backend) TransactionReceipt(_ context.Context, txHash common.Hash) (*zkTypes.Receipt, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	lookup, ok := b.txs[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	receipt := *lookup.block.receipts[lookup.index]
+	return &receipt, nil
+}
+
+// SyncProgress returns nil, as the backend is always in sync.
+func (b *Backend) SyncProgress(_ context.Context) (*ethereum.SyncProgress, error) {
+	return nil, nil
+}
+
+func (b *Backend) SubscribeNewHead(_ context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, ErrClosed
+	}
+	return b.scope.Track(b.headFeed.Subscribe(ch)), nil
+}
+
+func (b *Backend) NetworkID(_ context.Context) (*big.Int, error) {
+	return new(big.Int).Set(b.config.ChainID), nil
+}
+
+func (b *Backend) BalanceAt(_ context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	var balance *big.Int
+	err := b.readState(blockNumber, func(statedb *state.StateDB, _ *types.Header) {
+		balance = statedb.GetBalance(account)
+	})
+	return balance, err
+}
+
+func (b *Backend) StorageAt(_ context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	var value common.Hash
+	err := b.readState(blockNumber, func(statedb *state.StateDB, _ *types.Header) {
+		value = statedb.GetState(account, key)
+	})
+	return value.Bytes(), err
+}
+
+func (b *Backend) CodeAt(_ context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	var code []byte
+	err := b.readState(blockNumber, func(statedb *state.StateDB, _ *types.Header) {
+		code = statedb.GetCode(account)
+	})
+	return code, err
+}
+
+func (b *Backend) NonceAt(_ context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	var nonce uint64
+	err := b.readState(blockNumber, func(statedb *state.StateDB, _ *types.Header) {
+		nonce = statedb.GetNonce(account)
+	})
+	return nonce, err
+}
+
+func (b *Backend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	logs, err := b.FilterLogsL2(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]types.Log, len(logs))
+	for i := range logs {
+		res[i] = logs[i].Log
+	}
+	return res, nil
+}
+
+func (b *Backend) FilterLogsL2(_ context.Context, query ethereum.FilterQuery) ([]zkTypes.Log, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var blocks []*block
+	if query.BlockHash != nil {
+		blk, ok := b.byHash[*query.BlockHash]
+		if !ok {
+			return nil, ethereum.NotFound
+		}
+		blocks = []*block{blk}
+	} else {
+		from, to := b.head().header.Number.Uint64(), b.head().header.Number.Uint64()
+		if query.FromBlock != nil && query.FromBlock.Sign() >= 0 {
+			from = query.FromBlock.Uint64()
+		}
+		if query.ToBlock != nil && query.ToBlock.Sign() >= 0 && query.ToBlock.Uint64() < to {
+			to = query.ToBlock.Uint64()
+		}
+		for i := from; i <= to; i++ {
+			blocks = append(blocks, b.blocks[i])
+		}
+	}
+	res := make([]zkTypes.Log, 0)
+	for _, blk := range blocks {
+		for _, r := range blk.receipts {
+			for _, l := range r.Logs {
+				if matchLog(query, &l.Log) {
+					res = append(res, *l)
+				}
+			}
+		}
+	}
+	return res, nil
+}
+
+func (b *Backend) SubscribeFilterLogs(_ context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return b.subscribeLogs(query, func(l *zkTypes.Log, quit <-chan struct{}) bool {
+		select {
+		case ch <- l.Log:
+			return true
+		case <-quit:
+			return false
+		}
+	})
+}
+
+func (b *Backend) SubscribeFilterLogsL2(_ context.Context, query ethereum.FilterQuery, ch chan<- zkTypes.Log) (ethereum.Subscription, error) {
+	return b.subscribeLogs(query, func(l *zkTypes.Log, quit <-chan struct{}) bool {
+		select {
+		case ch <- *l:
+			return true
+		case <-quit:
+			return false
+		}
+	})
+}
+
+// subscribeLogs subscribes to the logs of the mined blocks matching the query, which are passed to send.
+func (b *Backend) subscribeLogs(query ethereum.FilterQuery, send func(l *zkTypes.Log, quit <-chan struct{}) bool) (ethereum.Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, ErrClosed
+	}
+	sink := make(chan []*zkTypes.Log, 16)
+	sub := b.scope.Track(b.logsFeed.Subscribe(sink))
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case logs := <-sink:
+				for _, l := range logs {
+					if matchLog(query, &l.Log) && !send(l, quit) {
+						return nil
+					}
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// matchLog reports whether the log matches the query.
+func matchLog(query ethereum.FilterQuery, l *types.Log) bool {
+	if query.BlockHash != nil && l.BlockHash != *query.BlockHash {
+		return false
+	}
+	if query.BlockHash == nil {
+		if query.FromBlock != nil && query.FromBlock.Sign() >= 0 && l.BlockNumber < query.FromBlock.Uint64() {
+			return false
+		}
+		if query.ToBlock != nil && query.ToBlock.Sign() >= 0 && l.BlockNumber > query.ToBlock.Uint64() {
+			return false
+		}
+	}
+	if len(query.Addresses) > 0 {
+		found := false
+		for _, address := range query.Addresses {
+			if l.Address == address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(query.Topics) > len(l.Topics) {
+		return false
+	}
+	for i, topics := range query.Topics {
+		if len(topics) == 0 {
+			continue
+		}
+		found := false
+		for _, topic := range topics {
+			if l.Topics[i] == topic {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *Backend) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	return b.BalanceAt(ctx, account, nil)
+}
+
+func (b *Backend) PendingStorageAt(ctx context.Context, account common.Address, key common.Hash) ([]byte, error) {
+	return b.StorageAt(ctx, account, key, nil)
+}
+
+func (b *Backend) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return b.CodeAt(ctx, account, nil)
+}
+
+func (b *Backend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return b.NonceAt(ctx, account, nil)
+}
+
+// PendingTransactionCount returns 0, as transactions are mined immediately.
+func (b *Backend) PendingTransactionCount(_ context.Context) (uint, error) {
+	return 0, nil
+}
+
+func (b *Backend) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return b.CallContractL2(ctx, zkTypes.CallMsg{CallMsg: msg}, blockNumber)
+}
+
+func (b *Backend) CallContractL2(_ context.Context, msg zkTypes.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var (
+		res *result
+		err error
+	)
+	if stateErr := b.readState(blockNumber, func(statedb *state.StateDB, header *types.Header) {
+		res, err = b.apply(statedb, header, callMessage(msg))
+	}); stateErr != nil {
+		return nil, stateErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res.returnData, res.callError()
+}
+
+func (b *Backend) BatchCallContractL2(_ context.Context, msgs []zkTypes.CallMsg, blockNumber *big.Int) ([]clients.CallResult, *big.Int, error) {
+	results := make([]clients.CallResult, len(msgs))
+	var number *big.Int
+	err := b.readState(blockNumber, func(statedb *state.StateDB, header *types.Header) {
+		number = new(big.Int).Set(header.Number)
+		for i, msg := range msgs {
+			res, err := b.apply(statedb.Copy(), header, callMessage(msg))
+			if err != nil {
+				results[i].Err = err
+				continue
+			}
+			results[i] = clients.CallResult{Data: res.returnData, Err: res.callError()}
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return results, number, nil
+}
+
+func (b *Backend) CallContractAtHash(ctx context.Context, msg ethereum.CallMsg, blockHash common.Hash) ([]byte, error) {
+	return b.CallContractAtHashL2(ctx, zkTypes.CallMsg{CallMsg: msg}, blockHash)
+}
+
+func (b *Backend) CallContractAtHashL2(ctx context.Context, msg zkTypes.CallMsg, blockHash common.Hash) ([]byte, error) {
+	header, err := b.HeaderByHash(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	return b.CallContractL2(ctx, msg, header.Number)
+}
+
+func (b *Backend) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	return b.CallContractL2(ctx, zkTypes.CallMsg{CallMsg: msg}, nil)
+}
+
+func (b *Backend) PendingCallContractL2(ctx context.Context, msg zkTypes.CallMsg) ([]byte, error) {
+	return b.CallContractL2(ctx, msg, nil)
+}
+
+// SuggestGasPrice returns the base fee, which is constant.
+func (b *Backend) SuggestGasPrice(_ context.Context) (*big.Int, error) {
+	return new(big.Int).Set(b.baseFee), nil
+}
+
+// SuggestGasTipCap returns 0, as priority fees are not used.
+func (b *Backend) SuggestGasTipCap(_ context.Context) (*big.Int, error) {
+	return new(big.Int), nil
+}
+
+func (b *Backend) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return b.EstimateGasL2(ctx, zkTypes.CallMsg{CallMsg: msg})
+}
+
+// EstimateGasL2 returns the lowest gas limit, with which the call succeeds, found using the binary search.
+func (b *Backend) EstimateGasL2(_ context.Context, msg zkTypes.CallMsg) (uint64, error) {
+	var (
+		gas uint64
+		err error
+	)
+	if stateErr := b.readState(nil, func(statedb *state.StateDB, header *types.Header) {
+		gas, err = b.estimateGas(statedb, header, msg)
+	}); stateErr != nil {
+		return 0, stateErr
+	}
+	return gas, err
+}
+
+func (b *Backend) estimateGas(statedb *state.StateDB, header *types.Header, msg zkTypes.CallMsg) (uint64, error) {
+	run := func(gas uint64) (*result, error) {
+		m := callMessage(msg)
+		m.gas = gas
+		return b.apply(statedb.Copy(), header, m)
+	}
+	hi := header.GasLimit
+	if msg.Gas != 0 && msg.Gas < hi {
+		hi = msg.Gas
+	}
+	res, err := run(hi)
+	if err != nil {
+		return 0, err
+	}
+	if err = res.callError(); err != nil {
+		return 0, err
+	}
+	lo := res.usedGas - 1
+	for lo+1 < hi {
+		mid := lo + (hi-lo)/2
+		res, err = run(mid)
+		if err == nil && res.err == nil {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi, nil
+}
+
+// SendTransaction executes the Ethereum transaction and mines it in a new block.
+func (b *Backend) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	if tx.ChainId().Cmp(b.config.ChainID) != 0 && tx.Protected() {
+		return fmt.Errorf("invalid chain id %s, expected %s", tx.ChainId(), b.config.ChainID)
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(b.config.ChainID), tx)
+	if err != nil {
+		return fmt.Errorf("invalid transaction: %w", err)
+	}
+	v, r, s := tx.RawSignatureValues()
+	response := &zkTypes.TransactionResponse{
+		ChainID:              hexutil.Big(*b.config.ChainID),
+		From:                 from,
+		Gas:                  hexutil.Uint64(tx.Gas()),
+		Hash:                 tx.Hash(),
+		Data:                 tx.Data(),
+		MaxFeePerGas:         hexutil.Big(*tx.GasFeeCap()),
+		MaxPriorityFeePerGas: hexutil.Big(*tx.GasTipCap()),
+		Nonce:                hexutil.Uint64(tx.Nonce()),
+		V:                    (*hexutil.Big)(v),
+		R:                    (*hexutil.Big)(r),
+		S:                    (*hexutil.Big)(s),
+		Type:                 hexutil.Uint64(tx.Type()),
+		Value:                hexutil.Big(*tx.Value()),
+	}
+	if tx.To() != nil {
+		response.To = *tx.To()
+	}
+	return b.mine(&message{
+		from:       from,
+		to:         tx.To(),
+		nonce:      tx.Nonce(),
+		value:      tx.Value(),
+		gas:        tx.Gas(),
+		gasFeeCap:  tx.GasFeeCap(),
+		data:       tx.Data(),
+		accessList: tx.AccessList(),
+	}, response, nil)
+}
+
+// SendRawTransaction executes the raw transaction, either EIP-712 or Ethereum one, and mines it in a new block.
+func (b *Backend) SendRawTransaction(ctx context.Context, raw []byte) (common.Hash, error) {
+	if len(raw) == 0 || raw[0] != 0x71 {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(raw); err != nil {
+			return common.Hash{}, fmt.Errorf("invalid transaction: %w", err)
+		}
+		return tx.Hash(), b.SendTransaction(ctx, tx)
+	}
+
+	tx, err := zkTypes.ParseEip712Transaction(raw)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("invalid transaction: %w", err)
+	}
+	if tx.ChainID == nil || tx.ChainID.Cmp(b.config.ChainID) != 0 {
+		return common.Hash{}, fmt.Errorf("invalid chain id %v, expected %s", tx.ChainID, b.config.ChainID)
+	}
+	if tx.From == nil {
+		return common.Hash{}, errors.New("invalid transaction: sender is not set")
+	}
+	msg := &message{
+		from:       *tx.From,
+		to:         tx.To,
+		nonce:      tx.Nonce.Uint64(),
+		value:      tx.Value,
+		gasFeeCap:  tx.GasFeeCap,
+		data:       tx.Data,
+		accessList: tx.AccessList,
+		paymaster:  tx.Meta.PaymasterParams,
+	}
+	if tx.Gas != nil {
+		msg.gas = tx.Gas.Uint64()
+	}
+	for _, dep := range tx.Meta.FactoryDeps {
+		msg.factoryDeps = append(msg.factoryDeps, dep)
+	}
+	hash := crypto.Keccak256Hash(raw)
+	response := &zkTypes.TransactionResponse{
+		ChainID:              hexutil.Big(*tx.ChainID),
+		From:                 *tx.From,
+		Gas:                  hexutil.Uint64(msg.gas),
+		Hash:                 hash,
+		Data:                 tx.Data,
+		MaxFeePerGas:         hexutil.Big(*bigOrZero(tx.GasFeeCap)),
+		MaxPriorityFeePerGas: hexutil.Big(*bigOrZero(tx.GasTipCap)),
+		Nonce:                hexutil.Uint64(msg.nonce),
+		Type:                 hexutil.Uint64(0x71),
+		Value:                hexutil.Big(*bigOrZero(tx.Value)),
+	}
+	if tx.To != nil {
+		response.To = *tx.To
+	}
+	// The signature is verified only for the accounts without code, as the smart accounts validate
+	// transactions themselves, which is not simulated.
+	verify := func(statedb *state.StateDB) error {
+		if statedb.GetCodeSize(*tx.From) != 0 {
+			return nil
+		}
+		signer, err := tx.RecoverSender()
+		if err != nil {
+			return fmt.Errorf("invalid signature: %w", err)
+		}
+		if signer != *tx.From {
+			return fmt.Errorf("invalid signature: signed by %s instead of %s", signer, *tx.From)
+		}
+		return nil
+	}
+	return hash, b.mine(msg, response, verify)
+}
+
+// mine executes the transaction and mines it in a new block. The transaction is rejected, and no block is
+// mined, if it is invalid or verify fails.
+func (b *Backend) mine(msg *message, response *zkTypes.TransactionResponse, verify func(*state.StateDB) error) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrClosed
+	}
+	if _, known := b.txs[response.Hash]; known {
+		b.mu.Unlock()
+		return fmt.Errorf("already known: %s", response.Hash)
+	}
+	statedb, err := b.headState()
+	if err != nil {
+		b.mu.Unlock()
+		return err
+	}
+	if verify != nil {
+		if err = verify(statedb); err != nil {
+			b.mu.Unlock()
+			return err
+		}
+	}
+	header := b.nextHeader()
+	statedb.SetTxContext(response.Hash, 0)
+	res, err := b.apply(statedb, header, msg)
+	if err != nil {
+		b.mu.Unlock()
+		return err
+	}
+	root, err := statedb.Commit(true)
+	if err != nil {
+		b.mu.Unlock()
+		return err
+	}
+	header.Root = root
+
+	status := types.ReceiptStatusSuccessful
+	if res.err != nil {
+		status = types.ReceiptStatusFailed
+	}
+	receipt := &zkTypes.Receipt{
+		Receipt: types.Receipt{
+			Type:              uint8(response.Type),
+			Status:            status,
+			CumulativeGasUsed: res.usedGas,
+			Logs:              statedb.GetLogs(response.Hash, header.Number.Uint64(), common.Hash{}),
+			TxHash:            response.Hash,
+			ContractAddress:   res.contractAddress,
+			GasUsed:           res.usedGas,
+			EffectiveGasPrice: res.gasPrice,
+		},
+		From:              msg.from,
+		EffectiveGasPrice: (*hexutil.Big)(res.gasPrice),
+		L2ToL1Logs:        res.l2ToL1Logs,
+	}
+	if receipt.Receipt.Logs == nil {
+		receipt.Receipt.Logs = []*types.Log{}
+	}
+	receipt.Logs = make([]*zkTypes.Log, len(receipt.Receipt.Logs))
+	for i, l := range receipt.Receipt.Logs {
+		receipt.Logs[i] = &zkTypes.Log{Log: *l}
+	}
+	receipt.Bloom = types.CreateBloom(types.Receipts{&receipt.Receipt})
+	if msg.to != nil {
+		receipt.To = *msg.to
+	}
+	for _, l := range receipt.L2ToL1Logs {
+		l.TxHash = response.Hash
+	}
+	response.GasPrice = hexutil.Big(*res.gasPrice)
+	mined := b.appendBlock(header, []*zkTypes.TransactionResponse{response}, []*zkTypes.Receipt{receipt})
+	b.mu.Unlock()
+
+	b.headFeed.Send(types.CopyHeader(mined.header))
+	if len(receipt.Logs) > 0 {
+		b.logsFeed.Send(receipt.Logs)
+	}
+	return nil
+}
+
+func bigOrZero(x *big.Int) *big.Int {
+	if x == nil {
+		return new(big.Int)
+	}
+	return x
+}
+
+// callMessage returns the message of the call.
+func callMessage(msg zkTypes.CallMsg) *message {
+	m := &message{
+		from:       msg.From,
+		to:         msg.To,
+		value:      msg.Value,
+		gas:        msg.Gas,
+		gasFeeCap:  msg.GasFeeCap,
+		data:       msg.Data,
+		accessList: msg.AccessList,
+		isCall:     true,
+	}
+	if m.gasFeeCap == nil {
+		m.gasFeeCap = msg.GasPrice
+	}
+	if msg.Meta != nil {
+		for _, dep := range msg.Meta.FactoryDeps {
+			m.factoryDeps = append(m.factoryDeps, dep)
+		}
+		m.paymaster = msg.Meta.PaymasterParams
+	}
+	return m
+}
+
+// readState calls read with the copy of the state after the block with the number.
+func (b *Backend) readState(blockNumber *big.Int, read func(statedb *state.StateDB, header *types.Header)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return ErrClosed
+	}
+	statedb, header, err := b.stateAt(blockNumber)
+	if err != nil {
+		return err
+	}
+	read(statedb, header)
+	return nil
+}
+
+func (b *Backend) WaitMined(ctx context.Context, txHash common.Hash) (*zkTypes.Receipt, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		receipt, err := b.TransactionReceipt(ctx, txHash)
+		if err == nil {
+			return receipt, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// WaitFinalized is the same as WaitMined, as blocks are final immediately.
+func (b *Backend) WaitFinalized(ctx context.Context, txHash common.Hash) (*zkTypes.Receipt, error) {
+	return b.WaitMined(ctx, txHash)
+}
+
+func (b *Backend) WaitForBlock(ctx context.Context, blockNumber *big.Int) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		number, err := b.BlockNumber(ctx)
+		if err != nil {
+			return err
+		}
+		if blockNumber == nil || new(big.Int).SetUint64(number).Cmp(blockNumber) >= 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (b *Backend) MainContractAddress(_ context.Context) (common.Address, error) {
+	return common.Address{}, ErrNotSupported
+}
+
+func (b *Backend) TestnetPaymaster(_ context.Context) (common.Address, error) {
+	return common.Address{}, ErrNotSupported
+}
+
+// BridgeContracts returns zero addresses, as bridges are not deployed.
+func (b *Backend) BridgeContracts(_ context.Context) (*zkTypes.BridgeContracts, error) {
+	return &zkTypes.BridgeContracts{}, nil
+}
+
+func (b *Backend) BaseTokenContractAddress(_ context.Context) (common.Address, error) {
+	return utils.EthAddressInContracts, nil
+}
+
+func (b *Backend) BridgehubContractAddress(_ context.Context) (common.Address, error) {
+	return common.Address{}, ErrNotSupported
+}
+
+// IsEthBasedChain returns true, as the simulated chain uses ETH as the base token.
+func (b *Backend) IsEthBasedChain(_ context.Context) (bool, error) {
+	return true, nil
+}
+
+func (b *Backend) IsBaseToken(_ context.Context, token common.Address) (bool, error) {
+	return isBaseToken(token), nil
+}
+
+func isBaseToken(token common.Address) bool {
+	return token == utils.EthAddress || token == utils.EthAddressInContracts || token == utils.L2BaseTokenAddress
+}
+
+func (b *Backend) ContractAccountInfo(_ context.Context, address common.Address) (*zkTypes.ContractAccountInfo, error) {
+	var info zkTypes.ContractAccountInfo
+	err := b.readState(nil, func(statedb *state.StateDB, _ *types.Header) {
+		info.SupportedAAVersion = zkTypes.AccountAbstractionVersion(accountVersion(statedb, address))
+	})
+	return &info, err
+}
+
+func (b *Backend) DeploymentNonceAt(_ context.Context, address common.Address, blockNumber *big.Int) (*big.Int, error) {
+	var nonce *big.Int
+	err := b.readState(blockNumber, func(statedb *state.StateDB, _ *types.Header) {
+		nonce = deploymentNonce(statedb, address)
+	})
+	return nonce, err
+}
+
+func (b *Backend) L1ChainID(_ context.Context) (*big.Int, error) {
+	return new(big.Int).Set(b.l1ChainID), nil
+}
+
+// L1BatchNumber returns the latest block number, as each block is its own L1 batch.
+func (b *Backend) L1BatchNumber(ctx context.Context) (*big.Int, error) {
+	number, err := b.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetUint64(number), nil
+}
+
+func (b *Backend) L1BatchBlockRange(_ context.Context, l1BatchNumber *big.Int) (*clients.BlockRange, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	blk, err := b.blockByNumber(l1BatchNumber)
+	if err != nil {
+		return nil, ethereum.NotFound
+	}
+	return &clients.BlockRange{
+		Beginning: new(big.Int).Set(blk.header.Number),
+		End:       new(big.Int).Set(blk.header.Number),
+	}, nil
+}
+
+func (b *Backend) L1BatchDetails(_ context.Context, l1BatchNumber *big.Int) (*zkTypes.BatchDetails, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	blk, err := b.blockByNumber(l1BatchNumber)
+	if err != nil {
+		return nil, ethereum.NotFound
+	}
+	return &zkTypes.BatchDetails{
+		L2FairGasPrice: uint(blk.header.BaseFee.Uint64()),
+		L2TxCount:      uint(len(blk.txs)),
+		Number:         uint(blk.header.Number.Uint64()),
+		RootHash:       blk.header.Root,
+		Status:         "sealed",
+		Timestamp:      uint(blk.header.Time),
+	}, nil
+}
+
+func (b *Backend) BlockDetails(_ context.Context, number uint32) (*zkTypes.BlockDetails, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	blk, err := b.blockByNumber(new(big.Int).SetUint64(uint64(number)))
+	if err != nil {
+		return nil, ethereum.NotFound
+	}
+	return &zkTypes.BlockDetails{
+		L2TxCount: uint(len(blk.txs)),
+		Number:    uint(number),
+		RootHash:  blk.header.Root,
+		Status:    "sealed",
+		Timestamp: uint(blk.header.Time),
+	}, nil
+}
+
+func (b *Backend) RawBlockTransactions(_ context.Context, _ uint32) ([]zkTypes.RawBlockTransaction, error) {
+	return nil, ErrNotSupported
+}
+
+func (b *Backend) Proof(_ context.Context, _ common.Address, _ []common.Hash, _ *big.Int) (*zkTypes.StorageProof, error) {
+	return nil, ErrNotSupported
+}
+
+func (b *Backend) ProtocolVersion(_ context.Context, _ *uint16) (*zkTypes.ProtocolVersion, error) {
+	return nil, ErrNotSupported
+}
+
+func (b *Backend) SendRawTransactionWithDetailedOutput(_ context.Context, _ []byte) (*zkTypes.TransactionDetailedResult, error) {
+	return nil, ErrNotSupported
+}
+
+func (b *Backend) TransactionDetails(_ context.Context, txHash common.Hash) (*zkTypes.TransactionDetails, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	lookup, ok := b.txs[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	tx, receipt := lookup.block.txs[lookup.index], lookup.block.receipts[lookup.index]
+	status := "included"
+	if receipt.Status == types.ReceiptStatusFailed {
+		status = "failed"
+	}
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice.ToInt())
+	return &zkTypes.TransactionDetails{
+		Fee:              hexutil.Big(*fee),
+		InitiatorAddress: tx.From,
+		ReceivedAt:       time.Unix(int64(lookup.block.header.Time), 0),
+		Status:           status,
+	}, nil
+}
+
+func (b *Backend) LogProof(_ context.Context, _ common.Hash, _ int) (*zkTypes.MessageProof, error) {
+	return nil, ErrNotSupported
+}
+
+func (b *Backend) MsgProof(_ context.Context, _ uint32, _ common.Address, _ common.Hash) (*zkTypes.MessageProof, error) {
+	return nil, ErrNotSupported
+}
+
+func (b *Backend) L2TransactionFromPriorityOp(_ context.Context, _ *types.Receipt) (*zkTypes.TransactionResponse, error) {
+	return nil, ErrNotSupported
+}
+
+// ConfirmedTokens returns ETH, which is the only token known to the backend.
+func (b *Backend) ConfirmedTokens(_ context.Context, from uint32, limit uint8) ([]*zkTypes.Token, error) {
+	tokens := make([]*zkTypes.Token, 0)
+	if from == 0 && limit > 0 {
+		tokens = append(tokens, &zkTypes.Token{
+			L1Address: utils.EthAddress,
+			L2Address: utils.EthAddress,
+			Name:      "Ether",
+			Symbol:    "ETH",
+			Decimals:  utils.EtherDecimals,
+		})
+	}
+	return tokens, nil
+}
+
+func (b *Backend) TokenPrice(_ context.Context, _ common.Address) (*big.Float, error) {
+	return nil, ErrNotSupported
+}
+
+// L2TokenAddress returns the address of the base token on L2. Other tokens are not supported, as bridges
+// are not deployed.
+func (b *Backend) L2TokenAddress(_ context.Context, token common.Address) (common.Address, error) {
+	if isBaseToken(token) {
+		return utils.L2BaseTokenAddress, nil
+	}
+	return common.Address{}, ErrNotSupported
+}
+
+// L1TokenAddress returns the address of the base token on L1. Other tokens are not supported, as bridges
+// are not deployed.
+func (b *Backend) L1TokenAddress(_ context.Context, token common.Address) (common.Address, error) {
+	if isBaseToken(token) {
+		return utils.EthAddress, nil
+	}
+	return common.Address{}, ErrNotSupported
+}
+
+// AllAccountBalances returns the balance of the base token only.
+func (b *Backend) AllAccountBalances(ctx context.Context, address common.Address) (map[common.Address]*big.Int, error) {
+	balance, err := b.BalanceAt(ctx, address, nil)
+	if err != nil {
+		return nil, err
+	}
+	return map[common.Address]*big.Int{utils.EthAddress: balance}, nil
+}
+
+func (b *Backend) EstimateFee(ctx context.Context, msg zkTypes.CallMsg) (*zkTypes.Fee, error) {
+	gas, err := b.EstimateGasL2(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	return &zkTypes.Fee{
+		GasLimit:             (*hexutil.Big)(new(big.Int).SetUint64(gas)),
+		GasPerPubdataLimit:   (*hexutil.Big)(new(big.Int).Set(utils.DefaultGasPerPubdataLimit)),
+		MaxFeePerGas:         (*hexutil.Big)(new(big.Int).Set(b.baseFee)),
+		MaxPriorityFeePerGas: (*hexutil.Big)(new(big.Int)),
+	}, nil
+}
+
+func (b *Backend) FeeParams(_ context.Context) (*zkTypes.FeeParams, error) {
+	return nil, ErrNotSupported
+}
+
+func (b *Backend) L1GasPrice(_ context.Context) (*big.Int, error) {
+	return nil, ErrNotSupported
+}
+
+func (b *Backend) BatchFeeInput(_ context.Context) (*zkTypes.BatchFeeInput, error) {
+	return nil, ErrNotSupported
+}
+
+func (b *Backend) GasPerPubdata(_ context.Context) (*big.Int, error) {
+	return nil, ErrNotSupported
+}
+
+func (b *Backend) EstimateGasL1(_ context.Context, _ zkTypes.CallMsg) (uint64, error) {
+	return 0, ErrNotSupported
+}
+
+func (b *Backend) EstimateGasTransfer(ctx context.Context, msg clients.TransferCallMsg) (uint64, error) {
+	callMsg, err := msg.ToCallMsg()
+	if err != nil {
+		return 0, err
+	}
+	return b.EstimateGas(ctx, *callMsg)
+}
+
+// EstimateGasWithdraw estimates the gas of the withdrawal of the base token, or of the withdrawal through
+// the given bridge, as the default bridge is not deployed.
+func (b *Backend) EstimateGasWithdraw(ctx context.Context, msg clients.WithdrawalCallMsg) (uint64, error) {
+	if !isBaseToken(msg.Token) && msg.BridgeAddress == nil {
+		return 0, ErrNotSupported
+	}
+	callMsg, err := msg.ToCallMsg(nil)
+	if err != nil {
+		return 0, err
+	}
+	return b.EstimateGas(ctx, *callMsg)
+}
+
+func (b *Backend) EstimateL1ToL2Execute(_ context.Context, _ zkTypes.CallMsg) (uint64, error) {
+	return 0, ErrNotSupported
+}
+
+// toBlock returns the block in the format returned by the client.
+func (blk *block) toBlock() *zkTypes.Block {
+	txs := make([]*zkTypes.TransactionResponse, len(blk.txs))
+	for i, tx := range blk.txs {
+		cpy := *tx
+		txs[i] = &cpy
+	}
+	return &zkTypes.Block{
+		Header:           types.CopyHeader(blk.header),
+		Transactions:     txs,
+		Hash:             blk.hash,
+		TotalDifficulty:  new(big.Int),
+		L1BatchNumber:    new(big.Int).Set(blk.header.Number),
+		L1BatchTimestamp: new(big.Int).SetUint64(blk.header.Time),
+	}
+}
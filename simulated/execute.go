@@ -0,0 +1,585 @@
+package simulated
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/zksync-sdk/zksync2-go/contracts/contractdeployer"
+	"github.com/zksync-sdk/zksync2-go/contracts/ethtoken"
+	"github.com/zksync-sdk/zksync2-go/contracts/knowncodesstorage"
+	"github.com/zksync-sdk/zksync2-go/contracts/nonceholder"
+	"github.com/zksync-sdk/zksync2-go/contracts/paymasterflow"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"math"
+	"math/big"
+)
+
+var (
+	contractDeployerABI  = mustParseABI(contractdeployer.ContractDeployerMetaData.GetAbi())
+	nonceHolderABI       = mustParseABI(nonceholder.NonceHolderMetaData.GetAbi())
+	knownCodesStorageABI = mustParseABI(knowncodesstorage.KnownCodesStorageMetaData.GetAbi())
+	baseTokenABI         = mustParseABI(ethtoken.IEthTokenMetaData.GetAbi())
+	paymasterFlowABI     = mustParseABI(paymasterflow.IPaymasterFlowMetaData.GetAbi())
+	errorABI             = mustParseABI(abi.JSON(bytes.NewReader([]byte(`[{"inputs":[{"name":"","type":"string"}],"name":"Error","type":"error"}]`))))
+	erc20ApproveABI      = mustParseABI(abi.JSON(bytes.NewReader([]byte(`[{"inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],"name":"approve","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"}]`))))
+
+	l1MessageSentTopic = crypto.Keccak256Hash([]byte("L1MessageSent(address,bytes32,bytes)"))
+	// finalizeEthWithdrawalSelector prefixes the message of the base token withdrawal, which is
+	// processed by the finalizeEthWithdrawal method on L1.
+	finalizeEthWithdrawalSelector = crypto.Keccak256([]byte("finalizeEthWithdrawal(uint256,uint256,uint16,bytes,bytes32[])"))[:4]
+)
+
+func mustParseABI(parsed interface{}, err error) *abi.ABI {
+	if err != nil {
+		panic(err)
+	}
+	switch a := parsed.(type) {
+	case *abi.ABI:
+		return a
+	case abi.ABI:
+		return &a
+	}
+	panic("unexpected ABI type")
+}
+
+// message is the transaction or call executed by the backend.
+type message struct {
+	from        common.Address
+	to          *common.Address
+	nonce       uint64
+	value       *big.Int
+	gas         uint64
+	gasFeeCap   *big.Int
+	data        []byte
+	accessList  types.AccessList
+	factoryDeps [][]byte
+	paymaster   *zkTypes.PaymasterParams
+	// isCall skips the nonce and fee checks, as done by eth_call and eth_estimateGas.
+	isCall bool
+}
+
+// result is the result of the executed message.
+type result struct {
+	usedGas         uint64
+	gasPrice        *big.Int
+	err             error // Execution error, e.g. the revert, which does not invalidate the transaction.
+	returnData      []byte
+	contractAddress common.Address
+	l2ToL1Logs      []*zkTypes.L2ToL1Log
+}
+
+// revertError is the error of the reverted call, which carries the revert data the same as RPC errors,
+// so that it can be decoded by utils.DecodeRevertError.
+type revertError struct {
+	reason string
+	data   []byte
+}
+
+func newRevertError(data []byte) *revertError {
+	reason, err := abi.UnpackRevert(data)
+	if err != nil {
+		return &revertError{reason: "execution reverted", data: data}
+	}
+	return &revertError{reason: "execution reverted: " + reason, data: data}
+}
+
+func (e *revertError) Error() string {
+	return e.reason
+}
+
+// ErrorCode returns the error code of the reverted call, the same as of go-ethereum.
+func (e *revertError) ErrorCode() int {
+	return 3
+}
+
+// ErrorData returns the hex-encoded revert data.
+func (e *revertError) ErrorData() interface{} {
+	return hexutil.Encode(e.data)
+}
+
+// callError returns the error of the executed call, or nil if it has succeeded.
+func (r *result) callError() error {
+	if r.err == nil {
+		return nil
+	}
+	if errors.Is(r.err, vm.ErrExecutionReverted) {
+		return newRevertError(r.returnData)
+	}
+	return r.err
+}
+
+// apply executes the message on the state, as the transaction of the block with the header. The error is
+// returned if the message is invalid, e.g. because of the wrong nonce, in which case the state must be
+// discarded, while the execution errors are returned in the result.
+func (b *Backend) apply(statedb *state.StateDB, header *types.Header, msg *message) (*result, error) {
+	if msg.value == nil {
+		msg.value = new(big.Int)
+	}
+	gasPrice := new(big.Int).Set(header.BaseFee)
+	if msg.isCall && (msg.gasFeeCap == nil || msg.gasFeeCap.Sign() == 0) {
+		gasPrice = new(big.Int)
+	} else if msg.gasFeeCap == nil || msg.gasFeeCap.Cmp(header.BaseFee) < 0 {
+		return nil, fmt.Errorf("%w: maxFeePerGas: %v, baseFee: %s", core.ErrFeeCapTooLow, msg.gasFeeCap, header.BaseFee)
+	}
+	if msg.gas > header.GasLimit {
+		return nil, fmt.Errorf("%w: gas limit %d exceeds block gas limit %d", core.ErrGasLimitReached, msg.gas, header.GasLimit)
+	}
+	if !msg.isCall {
+		if nonce := statedb.GetNonce(msg.from); nonce != msg.nonce {
+			return nil, fmt.Errorf("%w: address %s, tx: %d, state: %d", nonceError(nonce, msg.nonce), msg.from, msg.nonce, nonce)
+		}
+	}
+	for _, dep := range msg.factoryDeps {
+		if _, err := b.registerBytecode(statedb, dep); err != nil {
+			return nil, err
+		}
+	}
+
+	// The fee of the transaction using the paymaster is lent to the sender before the execution,
+	// and what is left of it after the refund is returned to the paymaster afterwards.
+	var prefund *big.Int
+	if msg.paymaster != nil && msg.paymaster.Paymaster != (common.Address{}) {
+		prefund = new(big.Int).Mul(new(big.Int).SetUint64(msg.gas), gasPrice)
+		if statedb.GetBalance(msg.paymaster.Paymaster).Cmp(prefund) < 0 {
+			return nil, fmt.Errorf("%w: paymaster %s cannot pay fee %s", core.ErrInsufficientFunds, msg.paymaster.Paymaster, prefund)
+		}
+		statedb.SubBalance(msg.paymaster.Paymaster, prefund)
+		statedb.AddBalance(msg.from, prefund)
+		if err := b.approvePaymaster(statedb, header, msg); err != nil {
+			return nil, err
+		}
+	}
+
+	evm := b.newEVM(statedb, header, msg.from, gasPrice, msg.isCall)
+	var (
+		res *result
+		err error
+	)
+	if handler := b.systemHandler(msg); handler != nil {
+		res, err = b.applySystem(evm, statedb, msg, gasPrice, handler)
+	} else {
+		res, err = b.applyEVM(evm, statedb, msg, gasPrice)
+	}
+	if err != nil {
+		return nil, err
+	}
+	res.gasPrice = gasPrice
+
+	if prefund != nil {
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(res.usedGas), gasPrice)
+		refund := new(big.Int).Sub(prefund, fee)
+		statedb.SubBalance(msg.from, refund)
+		statedb.AddBalance(msg.paymaster.Paymaster, refund)
+	}
+	return res, nil
+}
+
+func nonceError(stateNonce, txNonce uint64) error {
+	if txNonce > stateNonce {
+		return core.ErrNonceTooHigh
+	}
+	return core.ErrNonceTooLow
+}
+
+func (b *Backend) newEVM(statedb *state.StateDB, header *types.Header, origin common.Address, gasPrice *big.Int, isCall bool) *vm.EVM {
+	blockCtx := vm.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		GetHash:     b.blockHash,
+		Coinbase:    header.Coinbase,
+		GasLimit:    header.GasLimit,
+		BlockNumber: new(big.Int).Set(header.Number),
+		Time:        header.Time,
+		Difficulty:  new(big.Int),
+		BaseFee:     new(big.Int).Set(header.BaseFee),
+		Random:      &common.Hash{},
+	}
+	txCtx := vm.TxContext{Origin: origin, GasPrice: gasPrice}
+	return vm.NewEVM(blockCtx, txCtx, statedb, b.config, vm.Config{NoBaseFee: isCall})
+}
+
+// blockHash returns the hash of the block with the number, used by BLOCKHASH opcode. The lock must be held.
+func (b *Backend) blockHash(number uint64) common.Hash {
+	if number >= uint64(len(b.blocks)) {
+		return common.Hash{}
+	}
+	return b.blocks[number].hash
+}
+
+// applyEVM executes the message using the EVM.
+func (b *Backend) applyEVM(evm *vm.EVM, statedb *state.StateDB, msg *message, gasPrice *big.Int) (*result, error) {
+	gas := msg.gas
+	if gas == 0 && msg.isCall {
+		gas = evm.Context.GasLimit
+	}
+	coreMsg := &core.Message{
+		To:         msg.to,
+		From:       msg.from,
+		Nonce:      msg.nonce,
+		Value:      msg.value,
+		GasLimit:   gas,
+		GasPrice:   gasPrice,
+		GasFeeCap:  gasPrice,
+		GasTipCap:  new(big.Int),
+		Data:       msg.data,
+		AccessList: msg.accessList,
+		// The nonce is checked by apply, and the transactions of smart accounts are allowed.
+		SkipAccountChecks: true,
+	}
+	nonce := statedb.GetNonce(msg.from)
+	res, err := core.ApplyMessage(evm, coreMsg, new(core.GasPool).AddGas(gas))
+	if err != nil {
+		return nil, err
+	}
+	r := &result{usedGas: res.UsedGas, err: res.Err, returnData: res.ReturnData}
+	if msg.to == nil {
+		// The nonce is incremented by the EVM, unless the creation fails before it, e.g. because of
+		// the insufficient balance, while it must be incremented by any transaction.
+		statedb.SetNonce(msg.from, nonce+1)
+		if res.Err == nil {
+			r.contractAddress = crypto.CreateAddress(msg.from, nonce)
+		}
+	}
+	return r, nil
+}
+
+// systemHandler emulates the method of the system contract called by the message. It returns the data
+// returned by the method, the gas left, and the execution error.
+type systemHandler func(evm *vm.EVM, statedb *state.StateDB, msg *message, gas uint64, res *result) ([]byte, uint64, error)
+
+// systemHandler returns the handler of the system contract method called by the message, or nil if the method
+// is not emulated.
+func (b *Backend) systemHandler(msg *message) systemHandler {
+	if msg.to == nil || len(msg.data) < 4 {
+		return nil
+	}
+	var contractABI *abi.ABI
+	switch *msg.to {
+	case utils.ContractDeployerAddress:
+		contractABI = contractDeployerABI
+	case utils.NonceHolderAddress:
+		contractABI = nonceHolderABI
+	case utils.KnownCodesStorageAddress:
+		contractABI = knownCodesStorageABI
+	case utils.L2BaseTokenAddress:
+		contractABI = baseTokenABI
+	default:
+		return nil
+	}
+	method, err := contractABI.MethodById(msg.data[:4])
+	if err != nil {
+		return nil
+	}
+	switch method.Name {
+	case "create", "create2", "createAccount", "create2Account":
+		return b.deploy
+	case "withdraw":
+		return b.withdraw
+	case "getDeploymentNonce", "getMinNonce", "getRawNonce", "isNonceUsed", "getAccountInfo",
+		"extendedAccountVersion", "getMarker", "balanceOf", "name", "symbol", "decimals":
+		return b.view
+	}
+	return nil
+}
+
+// applySystem executes the message calling the emulated system contract, charging the gas
+// the same as core.ApplyMessage.
+func (b *Backend) applySystem(evm *vm.EVM, statedb *state.StateDB, msg *message, gasPrice *big.Int, handler systemHandler) (*result, error) {
+	gas := msg.gas
+	if gas == 0 && msg.isCall {
+		gas = evm.Context.GasLimit
+	}
+	cost := new(big.Int).Mul(new(big.Int).SetUint64(gas), gasPrice)
+	if statedb.GetBalance(msg.from).Cmp(new(big.Int).Add(cost, msg.value)) < 0 {
+		return nil, fmt.Errorf("%w: address %s", core.ErrInsufficientFunds, msg.from)
+	}
+	statedb.SubBalance(msg.from, cost)
+	rules := evm.ChainConfig().Rules(evm.Context.BlockNumber, true, evm.Context.Time)
+	intrinsic, err := core.IntrinsicGas(msg.data, msg.accessList, false, true, true, true)
+	if err != nil {
+		return nil, err
+	}
+	if gas < intrinsic {
+		return nil, fmt.Errorf("%w: have %d, want %d", core.ErrIntrinsicGas, gas, intrinsic)
+	}
+	statedb.Prepare(rules, msg.from, evm.Context.Coinbase, msg.to, vm.ActivePrecompiles(rules), msg.accessList)
+	statedb.SetNonce(msg.from, statedb.GetNonce(msg.from)+1)
+
+	res := &result{}
+	snapshot := statedb.Snapshot()
+	ret, left, err := handler(evm, statedb, msg, gas-intrinsic, res)
+	if err != nil {
+		statedb.RevertToSnapshot(snapshot)
+		res.contractAddress = common.Address{}
+		res.l2ToL1Logs = nil
+		if !errors.Is(err, vm.ErrExecutionReverted) {
+			left = 0
+		}
+	}
+	used := gas - left
+	refund := statedb.GetRefund()
+	if refund > used/params.RefundQuotientEIP3529 {
+		refund = used / params.RefundQuotientEIP3529
+	}
+	used -= refund
+	statedb.AddBalance(msg.from, new(big.Int).Mul(new(big.Int).SetUint64(gas-used), gasPrice))
+	res.usedGas, res.err, res.returnData = used, err, ret
+	return res, nil
+}
+
+// deploy emulates the deployment methods of ContractDeployer.
+func (b *Backend) deploy(evm *vm.EVM, statedb *state.StateDB, msg *message, gas uint64, res *result) ([]byte, uint64, error) {
+	method, _ := contractDeployerABI.MethodById(msg.data[:4])
+	args, err := method.Inputs.Unpack(msg.data[4:])
+	if err != nil {
+		return revert(gas, "invalid input of "+method.Name)
+	}
+	salt, bytecodeHash, input := args[0].([32]byte), common.Hash(args[1].([32]byte)), args[2].([]byte)
+	var aaVersion uint8
+	if len(args) > 3 {
+		aaVersion = args[3].(uint8)
+	}
+	bytecode, known := b.bytecodes[bytecodeHash]
+	if !known || statedb.GetState(utils.KnownCodesStorageAddress, bytecodeHash) == (common.Hash{}) {
+		return revert(gas, "The code hash is not known")
+	}
+
+	var address common.Address
+	switch method.Name {
+	case "create", "createAccount":
+		nonce := deploymentNonce(statedb, msg.from)
+		address, _ = utils.CreateAddress(msg.from, nonce)
+		setDeploymentNonce(statedb, msg.from, nonce.Add(nonce, common.Big1))
+	default:
+		address, _ = utils.Create2Address(msg.from, bytecode, input, salt[:])
+	}
+	if statedb.GetCodeSize(address) != 0 || statedb.GetNonce(address) != 0 {
+		return revert(gas, "Code hash is non-zero")
+	}
+	if !evm.Context.CanTransfer(statedb, msg.from, msg.value) {
+		return nil, 0, vm.ErrInsufficientBalance
+	}
+
+	statedb.AddAddressToAccessList(address)
+	statedb.CreateAccount(address)
+	statedb.SetNonce(address, 1)
+	evm.Context.Transfer(statedb, msg.from, address, msg.value)
+	initCode := append(bytes.TrimRight(bytecode, "\x00"), input...)
+	contract := vm.NewContract(vm.AccountRef(msg.from), vm.AccountRef(address), msg.value, gas)
+	contract.SetCallCode(&address, crypto.Keccak256Hash(initCode), initCode)
+	code, err := evm.Interpreter().Run(contract, nil, false)
+	if err != nil {
+		return code, contract.Gas, err
+	}
+	if !contract.UseGas(uint64(len(code)) * params.CreateDataGas) {
+		return nil, 0, vm.ErrCodeStoreOutOfGas
+	}
+	statedb.SetCode(address, code)
+	if aaVersion != 0 {
+		statedb.SetState(utils.ContractDeployerAddress, common.BytesToHash(address.Bytes()), common.BigToHash(big.NewInt(int64(aaVersion))))
+	}
+	statedb.AddLog(&types.Log{
+		Address: utils.ContractDeployerAddress,
+		Topics: []common.Hash{
+			contractDeployerABI.Events["ContractDeployed"].ID,
+			common.BytesToHash(msg.from.Bytes()),
+			bytecodeHash,
+			common.BytesToHash(address.Bytes()),
+		},
+	})
+	res.contractAddress = address
+	ret, err := method.Outputs.Pack(address)
+	return ret, contract.Gas, err
+}
+
+// withdraw emulates the withdrawal of the base token through L2BaseToken, which burns the value and sends
+// the message to L1.
+func (b *Backend) withdraw(evm *vm.EVM, statedb *state.StateDB, msg *message, gas uint64, res *result) ([]byte, uint64, error) {
+	args, err := baseTokenABI.Methods["withdraw"].Inputs.Unpack(msg.data[4:])
+	if err != nil {
+		return revert(gas, "invalid input of withdraw")
+	}
+	receiver := args[0].(common.Address)
+	if !evm.Context.CanTransfer(statedb, msg.from, msg.value) {
+		return nil, 0, vm.ErrInsufficientBalance
+	}
+	statedb.SubBalance(msg.from, msg.value)
+
+	message := append(append(append([]byte{}, finalizeEthWithdrawalSelector...), receiver.Bytes()...), common.BigToHash(msg.value).Bytes()...)
+	sendL1Message(statedb, res, utils.L2BaseTokenAddress, message)
+	statedb.AddLog(&types.Log{
+		Address: utils.L2BaseTokenAddress,
+		Topics: []common.Hash{
+			baseTokenABI.Events["Withdrawal"].ID,
+			common.BytesToHash(msg.from.Bytes()),
+			common.BytesToHash(receiver.Bytes()),
+		},
+		Data: common.BigToHash(msg.value).Bytes(),
+	})
+	return nil, gas, nil
+}
+
+// sendL1Message emits L1MessageSent event and records the L2 to L1 log carrying the message, as done by L1Messenger.
+func sendL1Message(statedb *state.StateDB, res *result, sender common.Address, message []byte) {
+	hash := crypto.Keccak256Hash(message)
+	data, _ := abi.Arguments{{Type: mustType("bytes")}}.Pack(message)
+	statedb.AddLog(&types.Log{
+		Address: utils.L1MessengerAddress,
+		Topics:  []common.Hash{l1MessageSentTopic, common.BytesToHash(sender.Bytes()), hash},
+		Data:    data,
+	})
+	shardID := hexutil.Uint(0)
+	index := hexutil.Uint(len(res.l2ToL1Logs))
+	res.l2ToL1Logs = append(res.l2ToL1Logs, &zkTypes.L2ToL1Log{
+		ShardId:   &shardID,
+		IsService: true,
+		Sender:    utils.L1MessengerAddress,
+		Key:       common.BytesToHash(sender.Bytes()).Hex(),
+		Value:     hash.Hex(),
+		Index:     &index,
+	})
+}
+
+func mustType(t string) abi.Type {
+	typ, err := abi.NewType(t, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
+
+// view emulates the view methods of system contracts.
+func (b *Backend) view(_ *vm.EVM, statedb *state.StateDB, msg *message, gas uint64, _ *result) ([]byte, uint64, error) {
+	var contractABI *abi.ABI
+	switch *msg.to {
+	case utils.ContractDeployerAddress:
+		contractABI = contractDeployerABI
+	case utils.NonceHolderAddress:
+		contractABI = nonceHolderABI
+	case utils.KnownCodesStorageAddress:
+		contractABI = knownCodesStorageABI
+	default:
+		contractABI = baseTokenABI
+	}
+	method, _ := contractABI.MethodById(msg.data[:4])
+	args, err := method.Inputs.Unpack(msg.data[4:])
+	if err != nil {
+		return revert(gas, "invalid input of "+method.Name)
+	}
+	var values []interface{}
+	switch method.Name {
+	case "getDeploymentNonce":
+		values = []interface{}{deploymentNonce(statedb, args[0].(common.Address))}
+	case "getMinNonce":
+		values = []interface{}{new(big.Int).SetUint64(statedb.GetNonce(args[0].(common.Address)))}
+	case "getRawNonce":
+		account := args[0].(common.Address)
+		raw := new(big.Int).Lsh(deploymentNonce(statedb, account), 128)
+		values = []interface{}{raw.Add(raw, new(big.Int).SetUint64(statedb.GetNonce(account)))}
+	case "isNonceUsed":
+		nonce := args[1].(*big.Int)
+		values = []interface{}{nonce.IsUint64() && nonce.Uint64() < statedb.GetNonce(args[0].(common.Address))}
+	case "getAccountInfo":
+		values = []interface{}{struct {
+			SupportedAAVersion uint8
+			NonceOrdering      uint8
+		}{SupportedAAVersion: accountVersion(statedb, args[0].(common.Address))}}
+	case "extendedAccountVersion":
+		account := args[0].(common.Address)
+		version := accountVersion(statedb, account)
+		if version == 0 && statedb.GetCodeSize(account) == 0 {
+			// Accounts without code are treated as the default accounts.
+			version = 1
+		}
+		values = []interface{}{version}
+	case "getMarker":
+		values = []interface{}{statedb.GetState(utils.KnownCodesStorageAddress, args[0].([32]byte)).Big()}
+	case "balanceOf":
+		values = []interface{}{statedb.GetBalance(common.BigToAddress(args[0].(*big.Int)))}
+	case "name":
+		values = []interface{}{"Ether"}
+	case "symbol":
+		values = []interface{}{"ETH"}
+	case "decimals":
+		values = []interface{}{uint8(utils.EtherDecimals)}
+	}
+	ret, err := method.Outputs.Pack(values...)
+	return ret, gas, err
+}
+
+// approvePaymaster approves the allowance of the token for the paymaster, if the transaction uses
+// the approval-based paymaster flow, as done by the bootloader.
+func (b *Backend) approvePaymaster(statedb *state.StateDB, header *types.Header, msg *message) error {
+	input := msg.paymaster.PaymasterInput
+	if len(input) < 4 {
+		return nil
+	}
+	method, err := paymasterFlowABI.MethodById(input[:4])
+	if err != nil || method.Name != "approvalBased" {
+		return nil
+	}
+	args, err := method.Inputs.Unpack(input[4:])
+	if err != nil {
+		return fmt.Errorf("invalid approval-based paymaster input: %w", err)
+	}
+	token, allowance := args[0].(common.Address), args[1].(*big.Int)
+	data, err := erc20ApproveABI.Pack("approve", msg.paymaster.Paymaster, allowance)
+	if err != nil {
+		return err
+	}
+	evm := b.newEVM(statedb, header, msg.from, new(big.Int), true)
+	_, _, err = evm.Call(vm.AccountRef(msg.from), token, data, header.GasLimit, new(big.Int))
+	if err != nil {
+		return fmt.Errorf("failed to approve token %s for paymaster: %w", token, err)
+	}
+	return nil
+}
+
+// registerBytecode marks the bytecode as known and returns its hash.
+func (b *Backend) registerBytecode(statedb *state.StateDB, bytecode []byte) (common.Hash, error) {
+	hash, err := utils.HashBytecode(bytecode)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("invalid factory dependency: %w", err)
+	}
+	b.bytecodes[common.BytesToHash(hash)] = common.CopyBytes(bytecode)
+	statedb.SetState(utils.KnownCodesStorageAddress, common.BytesToHash(hash), common.BigToHash(common.Big1))
+	return common.BytesToHash(hash), nil
+}
+
+// deploymentNonce returns the deployment nonce of the account, which is kept in the storage of NonceHolder.
+func deploymentNonce(statedb *state.StateDB, account common.Address) *big.Int {
+	return statedb.GetState(utils.NonceHolderAddress, common.BytesToHash(account.Bytes())).Big()
+}
+
+func setDeploymentNonce(statedb *state.StateDB, account common.Address, nonce *big.Int) {
+	statedb.SetState(utils.NonceHolderAddress, common.BytesToHash(account.Bytes()), common.BigToHash(nonce))
+}
+
+// accountVersion returns the version of account abstraction supported by the account, which is kept
+// in the storage of ContractDeployer.
+func accountVersion(statedb *state.StateDB, account common.Address) uint8 {
+	version := statedb.GetState(utils.ContractDeployerAddress, common.BytesToHash(account.Bytes())).Big()
+	if !version.IsUint64() || version.Uint64() > math.MaxUint8 {
+		return 0
+	}
+	return uint8(version.Uint64())
+}
+
+// revert returns the revert of the system contract method with the reason.
+func revert(gas uint64, reason string) ([]byte, uint64, error) {
+	data, err := errorABI.Errors["Error"].Inputs.Pack(reason)
+	if err != nil {
+		return nil, 0, err
+	}
+	return append(append([]byte{}, errorABI.Errors["Error"].ID.Bytes()[:4]...), data...), gas, vm.ErrExecutionReverted
+}
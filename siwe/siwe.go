@@ -0,0 +1,330 @@
+// Package siwe implements Sign-In with Ethereum (EIP-4361): construction and parsing of the messages, signing
+// using the signer of the account, and server-side verification of the signatures, which supports both EOAs
+// and smart accounts implementing EIP-1271, so that backends can authenticate users of zkSync native accounts.
+package siwe
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/zksync-sdk/zksync2-go/accounts"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidMessage is returned when the message does not conform to EIP-4361.
+	ErrInvalidMessage = errors.New("invalid SIWE message")
+	// ErrInvalidSignature is returned when the message is not signed by the account.
+	ErrInvalidSignature = errors.New("invalid SIWE signature")
+	// ErrDomainMismatch is returned when the message is issued for another domain.
+	ErrDomainMismatch = errors.New("SIWE domain mismatch")
+	// ErrNonceMismatch is returned when the message has another nonce than the one issued by the server.
+	ErrNonceMismatch = errors.New("SIWE nonce mismatch")
+	// ErrExpired is returned when the message has expired.
+	ErrExpired = errors.New("SIWE message expired")
+	// ErrNotYetValid is returned when the message is not valid yet.
+	ErrNotYetValid = errors.New("SIWE message not yet valid")
+)
+
+const (
+	header         = " wants you to sign in with your Ethereum account:"
+	nonceAlphabet  = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	nonceMinLength = 8
+)
+
+// Message is the Sign-In with Ethereum message.
+type Message struct {
+	Scheme         string         // URI scheme of the origin of the request. Optional.
+	Domain         string         // RFC 3986 authority requesting the signing.
+	Address        common.Address // The address of the account performing the signing.
+	Statement      string         // Human-readable assertion that the user signs. Optional, must not contain new lines.
+	URI            string         // RFC 3986 URI referring to the resource that is the subject of the signing.
+	Version        string         // Version of the message, must be "1".
+	ChainID        *big.Int       // Chain ID of the network where the account lives.
+	Nonce          string         // Randomized token used to prevent replay attacks, at least 8 alphanumeric characters.
+	IssuedAt       time.Time      // Time when the message was generated.
+	ExpirationTime *time.Time     // Time when the signed authentication message is no longer valid. Optional.
+	NotBefore      *time.Time     // Time when the signed authentication message will become valid. Optional.
+	RequestID      string         // System-specific identifier used to uniquely refer to the sign-in request. Optional.
+	Resources      []string       // List of URIs the user wishes to have resolved as part of authentication. Optional.
+}
+
+// NewMessage creates the message with a random nonce, issued at the current time.
+func NewMessage(domain string, address common.Address, uri string, chainID *big.Int, statement string) (*Message, error) {
+	nonce, err := GenerateNonce()
+	if err != nil {
+		return nil, err
+	}
+	return &Message{
+		Domain:    domain,
+		Address:   address,
+		Statement: statement,
+		URI:       uri,
+		Version:   "1",
+		ChainID:   chainID,
+		Nonce:     nonce,
+		IssuedAt:  time.Now().UTC(),
+	}, nil
+}
+
+// GenerateNonce returns a random alphanumeric nonce of 16 characters.
+func GenerateNonce() (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	nonce := make([]byte, len(random))
+	for i, b := range random {
+		nonce[i] = nonceAlphabet[int(b)%len(nonceAlphabet)]
+	}
+	return string(nonce), nil
+}
+
+// Validate checks that the fields of the message conform to EIP-4361.
+func (m *Message) Validate() error {
+	switch {
+	case m.Domain == "":
+		return fmt.Errorf("%w: domain is required", ErrInvalidMessage)
+	case m.URI == "":
+		return fmt.Errorf("%w: URI is required", ErrInvalidMessage)
+	case m.Version != "1":
+		return fmt.Errorf("%w: unsupported version %q", ErrInvalidMessage, m.Version)
+	case m.ChainID == nil:
+		return fmt.Errorf("%w: chain ID is required", ErrInvalidMessage)
+	case len(m.Nonce) < nonceMinLength || strings.Trim(m.Nonce, nonceAlphabet) != "":
+		return fmt.Errorf("%w: nonce must contain at least %d alphanumeric characters", ErrInvalidMessage, nonceMinLength)
+	case strings.Contains(m.Statement, "\n"):
+		return fmt.Errorf("%w: statement must not contain new lines", ErrInvalidMessage)
+	case m.IssuedAt.IsZero():
+		return fmt.Errorf("%w: issued at is required", ErrInvalidMessage)
+	}
+	return nil
+}
+
+// String returns the message in the format defined by EIP-4361, which is the text signed by the account.
+func (m *Message) String() string {
+	var b strings.Builder
+	if m.Scheme != "" {
+		b.WriteString(m.Scheme + "://")
+	}
+	b.WriteString(m.Domain + header + "\n")
+	b.WriteString(m.Address.Hex() + "\n\n")
+	if m.Statement != "" {
+		b.WriteString(m.Statement + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString("URI: " + m.URI + "\n")
+	b.WriteString("Version: " + m.Version + "\n")
+	b.WriteString("Chain ID: " + m.ChainID.String() + "\n")
+	b.WriteString("Nonce: " + m.Nonce + "\n")
+	b.WriteString("Issued At: " + m.IssuedAt.Format(time.RFC3339Nano))
+	if m.ExpirationTime != nil {
+		b.WriteString("\nExpiration Time: " + m.ExpirationTime.Format(time.RFC3339Nano))
+	}
+	if m.NotBefore != nil {
+		b.WriteString("\nNot Before: " + m.NotBefore.Format(time.RFC3339Nano))
+	}
+	if m.RequestID != "" {
+		b.WriteString("\nRequest ID: " + m.RequestID)
+	}
+	if len(m.Resources) > 0 {
+		b.WriteString("\nResources:")
+		for _, resource := range m.Resources {
+			b.WriteString("\n- " + resource)
+		}
+	}
+	return b.String()
+}
+
+// ParseMessage parses the message in the format defined by EIP-4361.
+func ParseMessage(text string) (*Message, error) {
+	lines := strings.Split(text, "\n")
+	invalid := func(reason string) (*Message, error) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidMessage, reason)
+	}
+	if len(lines) < 9 {
+		return invalid("message is too short")
+	}
+
+	m := &Message{}
+	origin, ok := strings.CutSuffix(lines[0], header)
+	if !ok {
+		return invalid("missing header")
+	}
+	if scheme, domain, ok := strings.Cut(origin, "://"); ok {
+		m.Scheme, m.Domain = scheme, domain
+	} else {
+		m.Domain = origin
+	}
+	if !common.IsHexAddress(lines[1]) {
+		return invalid("invalid address")
+	}
+	m.Address = common.HexToAddress(lines[1])
+	if lines[2] != "" {
+		return invalid("missing empty line after address")
+	}
+	i := 3
+	if lines[i] != "" {
+		m.Statement = lines[i]
+		i++
+	}
+	if lines[i] != "" {
+		return invalid("missing empty line after statement")
+	}
+	i++
+
+	field := func(name string, optional bool) (string, bool, error) {
+		if i < len(lines) {
+			if value, ok := strings.CutPrefix(lines[i], name+": "); ok {
+				i++
+				return value, true, nil
+			}
+		}
+		if optional {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("%w: missing %s", ErrInvalidMessage, name)
+	}
+	timeField := func(name string, optional bool) (*time.Time, error) {
+		value, ok, err := field(name, optional)
+		if err != nil || !ok {
+			return nil, err
+		}
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid %s: %v", ErrInvalidMessage, name, err)
+		}
+		return &t, nil
+	}
+
+	var err error
+	if m.URI, _, err = field("URI", false); err != nil {
+		return nil, err
+	}
+	if m.Version, _, err = field("Version", false); err != nil {
+		return nil, err
+	}
+	chainID, _, err := field("Chain ID", false)
+	if err != nil {
+		return nil, err
+	}
+	var valid bool
+	if m.ChainID, valid = new(big.Int).SetString(chainID, 10); !valid {
+		return invalid("invalid chain ID")
+	}
+	if m.Nonce, _, err = field("Nonce", false); err != nil {
+		return nil, err
+	}
+	issuedAt, err := timeField("Issued At", false)
+	if err != nil {
+		return nil, err
+	}
+	m.IssuedAt = *issuedAt
+	if m.ExpirationTime, err = timeField("Expiration Time", true); err != nil {
+		return nil, err
+	}
+	if m.NotBefore, err = timeField("Not Before", true); err != nil {
+		return nil, err
+	}
+	if m.RequestID, _, err = field("Request ID", true); err != nil {
+		return nil, err
+	}
+	if i < len(lines) && lines[i] == "Resources:" {
+		for i++; i < len(lines); i++ {
+			resource, ok := strings.CutPrefix(lines[i], "- ")
+			if !ok {
+				return invalid("invalid resource")
+			}
+			m.Resources = append(m.Resources, resource)
+		}
+	}
+	if i != len(lines) {
+		return invalid("unexpected line " + strconv.Quote(lines[i]))
+	}
+	if err = m.Validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Sign signs the message as EIP-191 personal message using the signer of the account.
+func Sign(signer accounts.Signer, m *Message) ([]byte, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return accounts.SignMessage(signer, []byte(m.String()))
+}
+
+// VerifyOptions configures Verify.
+type VerifyOptions struct {
+	// Domain expected by the server. Required unless SkipDomainCheck is set.
+	Domain string
+	// Nonce issued by the server for the sign-in request. Required unless SkipNonceCheck is set.
+	Nonce string
+	// SkipDomainCheck disables the check of the domain, e.g. when the domain is checked by the caller.
+	SkipDomainCheck bool
+	// SkipNonceCheck disables the check of the nonce, in which case the server must prevent replay attacks
+	// by other means.
+	SkipNonceCheck bool
+	// Time at which the validity of the message is checked. Optional, the current time is used by default.
+	Time time.Time
+	// Caller used to verify signatures of smart accounts using EIP-1271, e.g. the client of the L2 network.
+	// Optional, only signatures of EOAs are verified by default.
+	Caller bind.ContractCaller
+}
+
+// Verify parses the signed message and verifies that it has been signed by the account, and that it is valid
+// for the server. It returns the verified message.
+func Verify(ctx context.Context, text string, signature []byte, opts VerifyOptions) (*Message, error) {
+	if opts.Domain == "" && !opts.SkipDomainCheck {
+		return nil, errors.New("expected domain must be provided unless SkipDomainCheck is set")
+	}
+	if opts.Nonce == "" && !opts.SkipNonceCheck {
+		return nil, errors.New("expected nonce must be provided unless SkipNonceCheck is set")
+	}
+	m, err := ParseMessage(text)
+	if err != nil {
+		return nil, err
+	}
+	if !opts.SkipDomainCheck && m.Domain != opts.Domain {
+		return nil, fmt.Errorf("%w: expected %s, got %s", ErrDomainMismatch, opts.Domain, m.Domain)
+	}
+	if !opts.SkipNonceCheck && m.Nonce != opts.Nonce {
+		return nil, ErrNonceMismatch
+	}
+	now := opts.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if m.ExpirationTime != nil && !now.Before(*m.ExpirationTime) {
+		return nil, ErrExpired
+	}
+	if m.NotBefore != nil && now.Before(*m.NotBefore) {
+		return nil, ErrNotYetValid
+	}
+
+	if opts.Caller == nil {
+		if !utils.VerifyMessage(m.Address, []byte(text), signature) {
+			return nil, ErrInvalidSignature
+		}
+		return m, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	valid, err := utils.IsMessageSignatureValid(ctx, opts.Caller, m.Address, []byte(text), signature)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, ErrInvalidSignature
+	}
+	return m, nil
+}
@@ -0,0 +1,137 @@
+package siwe
+
+import (
+	"context"
+	"errors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/zksync-sdk/zksync2-go/accounts"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testMessage(address common.Address) *Message {
+	issuedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &Message{
+		Domain:   "example.com",
+		Address:  address,
+		URI:      "https://example.com/login",
+		Version:  "1",
+		ChainID:  big.NewInt(324),
+		Nonce:    "abcdef1234",
+		IssuedAt: issuedAt,
+	}
+}
+
+func TestParseMessage(t *testing.T) {
+	address := common.HexToAddress("0x36615Cf349d7F6344891B1e7CA7C72883F5dc049")
+	expiration := time.Date(2024, 1, 3, 3, 4, 5, 0, time.UTC)
+	notBefore := time.Date(2024, 1, 2, 4, 0, 0, 0, time.UTC)
+	full := testMessage(address)
+	full.Scheme = "https"
+	full.Statement = "Sign in to Example."
+	full.ExpirationTime = &expiration
+	full.NotBefore = &notBefore
+	full.RequestID = "request-1"
+	full.Resources = []string{"ipfs://bafybeiemxf5abjwjbikoz4mc3a3dla6ual3jsgpdr4cjr3oz3evfyavhwq", "https://example.com/terms"}
+
+	for name, m := range map[string]*Message{"minimal": testMessage(address), "full": full} {
+		t.Run(name, func(t *testing.T) {
+			parsed, err := ParseMessage(m.String())
+			if err != nil {
+				t.Fatalf("ParseMessage() error = %v", err)
+			}
+			if !reflect.DeepEqual(parsed, m) {
+				t.Errorf("ParseMessage() = %+v, want %+v", parsed, m)
+			}
+		})
+	}
+}
+
+func TestParseMessageInvalid(t *testing.T) {
+	valid := testMessage(common.HexToAddress("0x36615Cf349d7F6344891B1e7CA7C72883F5dc049")).String()
+	tests := []struct {
+		name string
+		text string
+	}{
+		{name: "missing header", text: strings.Replace(valid, " wants you to sign in", " asks you to sign in", 1)},
+		{name: "invalid address", text: strings.Replace(valid, "0x36615Cf349d7F6344891B1e7CA7C72883F5dc049", "0x1234", 1)},
+		{name: "unsupported version", text: strings.Replace(valid, "Version: 1", "Version: 2", 1)},
+		{name: "invalid chain ID", text: strings.Replace(valid, "Chain ID: 324", "Chain ID: zksync", 1)},
+		{name: "short nonce", text: strings.Replace(valid, "Nonce: abcdef1234", "Nonce: abc", 1)},
+		{name: "non-alphanumeric nonce", text: strings.Replace(valid, "Nonce: abcdef1234", "Nonce: abcdef-1234", 1)},
+		{name: "missing nonce", text: strings.Replace(valid, "Nonce: abcdef1234\n", "", 1)},
+		{name: "invalid issued at", text: strings.Replace(valid, "2024-01-02T03:04:05Z", "yesterday", 1)},
+		{name: "unexpected line", text: valid + "\nUnknown: field"},
+		{name: "too short", text: "example.com wants you to sign in with your Ethereum account:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseMessage(tt.text); !errors.Is(err, ErrInvalidMessage) {
+				t.Errorf("ParseMessage() error = %v, want %v", err, ErrInvalidMessage)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	signer, err := accounts.NewBaseSignerFromRawPrivateKey(common.FromHex("0x7726827caac94a7f9e1b160f7ea819f172f7b6f9d2a97f992c38edeab82d4110"), 324)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := accounts.NewBaseSignerFromRawPrivateKey(common.FromHex("0xac1e735be8536c6534bb4f17f06f6afc73b2b5ba84ac2cfb12f7461b20c0bbe3"), 324)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := testMessage(signer.Address())
+	expiration := m.IssuedAt.Add(time.Hour)
+	m.ExpirationTime = &expiration
+	notBefore := m.IssuedAt.Add(time.Minute)
+	m.NotBefore = &notBefore
+	signature, err := Sign(signer, m)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	otherSignature, err := Sign(other, m)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	now := m.IssuedAt.Add(30 * time.Minute)
+
+	tests := []struct {
+		name      string
+		signature []byte
+		opts      VerifyOptions
+		wantErr   error
+		wantAny   bool // Whether any error is expected, for errors which are not exported.
+	}{
+		{name: "valid", signature: signature, opts: VerifyOptions{Domain: "example.com", Nonce: "abcdef1234", Time: now}},
+		{name: "checks skipped", signature: signature, opts: VerifyOptions{SkipDomainCheck: true, SkipNonceCheck: true, Time: now}},
+		{name: "missing domain", signature: signature, opts: VerifyOptions{Nonce: "abcdef1234", Time: now}, wantAny: true},
+		{name: "missing nonce", signature: signature, opts: VerifyOptions{Domain: "example.com", Time: now}, wantAny: true},
+		{name: "domain mismatch", signature: signature, opts: VerifyOptions{Domain: "evil.com", Nonce: "abcdef1234", Time: now}, wantErr: ErrDomainMismatch},
+		{name: "nonce mismatch", signature: signature, opts: VerifyOptions{Domain: "example.com", Nonce: "abcdef9999", Time: now}, wantErr: ErrNonceMismatch},
+		{name: "expired", signature: signature, opts: VerifyOptions{Domain: "example.com", Nonce: "abcdef1234", Time: expiration}, wantErr: ErrExpired},
+		{name: "not yet valid", signature: signature, opts: VerifyOptions{Domain: "example.com", Nonce: "abcdef1234", Time: m.IssuedAt}, wantErr: ErrNotYetValid},
+		{name: "signed by other account", signature: otherSignature, opts: VerifyOptions{Domain: "example.com", Nonce: "abcdef1234", Time: now}, wantErr: ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verified, err := Verify(context.Background(), m.String(), tt.signature, tt.opts)
+			if tt.wantAny {
+				if err == nil {
+					t.Error("Verify() error = nil, want error")
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && verified.Address != signer.Address() {
+				t.Errorf("Verify() address = %s, want %s", verified.Address, signer.Address())
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// Package storage provides reading of contract state variables directly from the contract storage
+// using the storage layout emitted by solc and zksolc compilers, without the need for getter functions.
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// Encoding represents how the value of a type is stored.
+type Encoding string
+
+const (
+	EncodingInplace      Encoding = "inplace"       // Value is stored in place, possibly packed with other values.
+	EncodingMapping      Encoding = "mapping"       // Values are stored at keccak256(key . slot).
+	EncodingDynamicArray Encoding = "dynamic_array" // Length is stored in place and elements at keccak256(slot).
+	EncodingBytes        Encoding = "bytes"         // Value is stored in place if short, otherwise at keccak256(slot).
+)
+
+// Layout represents the storage layout of a contract as emitted by the compiler
+// in the storageLayout output selection.
+type Layout struct {
+	Storage []Variable      `json:"storage"` // State variables of the contract.
+	Types   map[string]Type `json:"types"`   // Types of the state variables, indexed by type identifier.
+}
+
+// Variable represents a state variable or a member of a struct.
+type Variable struct {
+	Label    string `json:"label"`    // Name of the variable.
+	Contract string `json:"contract"` // Name of the contract including its path as prefix.
+	Slot     Slot   `json:"slot"`     // Storage slot where the variable begins.
+	Offset   int    `json:"offset"`   // Offset in bytes within the slot, counted from the right.
+	Type     string `json:"type"`     // Identifier of the variable type.
+}
+
+// Type represents a type of state variable.
+type Type struct {
+	Encoding      Encoding   `json:"encoding"`          // Encoding of the type.
+	Label         string     `json:"label"`             // Canonical type name, e.g. uint256 or struct S.
+	NumberOfBytes Slot       `json:"numberOfBytes"`     // Number of used bytes.
+	Key           string     `json:"key,omitempty"`     // Type identifier of mapping key.
+	Value         string     `json:"value,omitempty"`   // Type identifier of mapping value.
+	Base          string     `json:"base,omitempty"`    // Type identifier of array elements.
+	Members       []Variable `json:"members,omitempty"` // Members of struct.
+}
+
+// Slot represents a decimal number encoded as string in the storage layout.
+type Slot struct {
+	*big.Int
+}
+
+func (s *Slot) UnmarshalJSON(input []byte) error {
+	var str string
+	if err := json.Unmarshal(input, &str); err != nil {
+		// Some tools emit plain numbers.
+		str = string(input)
+	}
+	value, ok := new(big.Int).SetString(str, 10)
+	if !ok {
+		return fmt.Errorf("invalid number in storage layout: %s", input)
+	}
+	s.Int = value
+	return nil
+}
+
+func (s Slot) MarshalJSON() ([]byte, error) {
+	if s.Int == nil {
+		return json.Marshal("0")
+	}
+	return json.Marshal(s.Int.String())
+}
+
+// ParseLayout parses the storage layout. The input can be either the storage layout itself or the
+// compiler output of a contract (or its metadata), containing the storage layout under the storageLayout key.
+func ParseLayout(data []byte) (*Layout, error) {
+	var output struct {
+		StorageLayout *Layout `json:"storageLayout"`
+		*Layout
+	}
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, fmt.Errorf("failed to decode storage layout: %w", err)
+	}
+	layout := output.StorageLayout
+	if layout == nil {
+		layout = output.Layout
+	}
+	if layout == nil || layout.Types == nil && len(layout.Storage) == 0 {
+		return nil, errors.New("storage layout not found")
+	}
+	return layout, nil
+}
+
+// Variable returns the state variable with the given name.
+func (l *Layout) Variable(label string) (Variable, bool) {
+	for _, v := range l.Storage {
+		if v.Label == label {
+			return v, true
+		}
+	}
+	return Variable{}, false
+}
+
+// size returns the number of bytes used by the type.
+func (t Type) size() int {
+	if t.NumberOfBytes.Int == nil || !t.NumberOfBytes.IsInt64() {
+		return 32
+	}
+	return int(t.NumberOfBytes.Int64())
+}
+
+// staticArrayLength returns the length of the static array type, parsed from its label, e.g. uint256[3].
+func (t Type) staticArrayLength() (int, bool) {
+	if t.Encoding != EncodingInplace || t.Base == "" || len(t.Label) < 3 || t.Label[len(t.Label)-1] != ']' {
+		return 0, false
+	}
+	start := len(t.Label) - 2
+	for start >= 0 && t.Label[start] != '[' {
+		start--
+	}
+	length, err := strconv.Atoi(t.Label[start+1 : len(t.Label)-1])
+	if err != nil {
+		return 0, false
+	}
+	return length, true
+}
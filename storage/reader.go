@@ -0,0 +1,421 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Backend is the interface used by Reader to read the contract storage.
+// It is implemented by clients.Client and ethclient.Client.
+type Backend interface {
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+}
+
+// Location represents the location of a value in the contract storage.
+type Location struct {
+	Slot   common.Hash // Storage slot where the value begins.
+	Offset int         // Offset in bytes within the slot, counted from the right.
+	Type   Type        // Type of the value.
+}
+
+// Reader reads state variables of a contract directly from its storage, based on the storage layout.
+// Variables are referenced by paths, which consist of the variable name followed by struct member
+// accessors and mapping keys or array indexes, e.g.:
+//   - owner
+//   - balances[0x36615Cf349d7F6344891B1e7CA7C72883F5dc049]
+//   - allowances[0x36615Cf349d7F6344891B1e7CA7C72883F5dc049][0xa61464658AfeAf65CccaaFD3a512b69A83B77618]
+//   - config.fee
+//   - orders[3].amount
+//   - names["alice"]
+//
+// Values are decoded as follows: unsigned and signed integers and enums as *big.Int, bool as bool,
+// addresses and contracts as common.Address, fixed-size byte arrays and bytes as []byte,
+// strings as string, structs as map[string]interface{} and arrays as []interface{}.
+type Reader struct {
+	backend Backend
+	address common.Address
+	layout  *Layout
+}
+
+// NewReader creates an instance of Reader for the contract deployed at the given address.
+func NewReader(backend Backend, address common.Address, layout *Layout) *Reader {
+	return &Reader{
+		backend: backend,
+		address: address,
+		layout:  layout,
+	}
+}
+
+// Read reads the value of the variable at the given path.
+// The block number can be nil, in which case the value is taken from the latest known block.
+func (r *Reader) Read(ctx context.Context, path string, blockNumber *big.Int) (interface{}, error) {
+	loc, err := r.Locate(path)
+	if err != nil {
+		return nil, err
+	}
+	return r.readValue(ctx, loc, blockNumber)
+}
+
+// ReadSlot reads the raw content of the storage slot.
+// The block number can be nil, in which case the value is taken from the latest known block.
+func (r *Reader) ReadSlot(ctx context.Context, slot common.Hash, blockNumber *big.Int) (common.Hash, error) {
+	value, err := r.backend.StorageAt(ctx, r.address, slot, blockNumber)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to read storage slot %s: %w", slot, err)
+	}
+	return common.BytesToHash(value), nil
+}
+
+// Locate resolves the storage location of the variable at the given path without reading the storage.
+func (r *Reader) Locate(path string) (Location, error) {
+	name, accessors, err := parsePath(path)
+	if err != nil {
+		return Location{}, err
+	}
+	variable, ok := r.layout.Variable(name)
+	if !ok {
+		return Location{}, fmt.Errorf("variable %s not found in storage layout", name)
+	}
+	loc, err := r.location(variable, common.Hash{})
+	if err != nil {
+		return Location{}, err
+	}
+	for _, a := range accessors {
+		if loc, err = r.access(loc, a); err != nil {
+			return Location{}, fmt.Errorf("invalid path %s: %w", path, err)
+		}
+	}
+	return loc, nil
+}
+
+// location returns the location of the variable relative to the given base slot.
+func (r *Reader) location(v Variable, base common.Hash) (Location, error) {
+	t, ok := r.layout.Types[v.Type]
+	if !ok {
+		return Location{}, fmt.Errorf("type %s not found in storage layout", v.Type)
+	}
+	slot := new(big.Int).SetBytes(base.Bytes())
+	if v.Slot.Int != nil {
+		slot.Add(slot, v.Slot.Int)
+	}
+	return Location{Slot: toHash(slot), Offset: v.Offset, Type: t}, nil
+}
+
+// access resolves the location of the struct member, mapping value or array element.
+func (r *Reader) access(loc Location, a accessor) (Location, error) {
+	t := loc.Type
+	if a.member != "" {
+		if len(t.Members) == 0 {
+			return Location{}, fmt.Errorf("member %s accessed on non-struct type %s", a.member, t.Label)
+		}
+		for _, m := range t.Members {
+			if m.Label == a.member {
+				return r.location(m, loc.Slot)
+			}
+		}
+		return Location{}, fmt.Errorf("member %s not found in %s", a.member, t.Label)
+	}
+
+	switch t.Encoding {
+	case EncodingMapping:
+		keyType, ok := r.layout.Types[t.Key]
+		if !ok {
+			return Location{}, fmt.Errorf("type %s not found in storage layout", t.Key)
+		}
+		key, err := encodeKey(keyType, a.key)
+		if err != nil {
+			return Location{}, err
+		}
+		return r.location(Variable{Type: t.Value}, crypto.Keccak256Hash(key, loc.Slot.Bytes()))
+	case EncodingDynamicArray:
+		index, err := parseIndex(a.key)
+		if err != nil {
+			return Location{}, err
+		}
+		return r.element(t, crypto.Keccak256Hash(loc.Slot.Bytes()), index)
+	case EncodingInplace:
+		length, ok := t.staticArrayLength()
+		if !ok {
+			return Location{}, fmt.Errorf("index accessed on type %s", t.Label)
+		}
+		index, err := parseIndex(a.key)
+		if err != nil {
+			return Location{}, err
+		}
+		if index >= uint64(length) {
+			return Location{}, fmt.Errorf("index %d out of bounds of %s", index, t.Label)
+		}
+		return r.element(t, loc.Slot, index)
+	default:
+		return Location{}, fmt.Errorf("index accessed on type %s", t.Label)
+	}
+}
+
+// element returns the location of the array element, where elements smaller than 16 bytes
+// are packed together in a single slot.
+func (r *Reader) element(array Type, data common.Hash, index uint64) (Location, error) {
+	base, ok := r.layout.Types[array.Base]
+	if !ok {
+		return Location{}, fmt.Errorf("type %s not found in storage layout", array.Base)
+	}
+	slot := new(big.Int).SetBytes(data.Bytes())
+	offset := 0
+	if size := base.size(); size <= 16 {
+		perSlot := uint64(32 / size)
+		slot.Add(slot, new(big.Int).SetUint64(index/perSlot))
+		offset = int(index%perSlot) * size
+	} else {
+		slots := new(big.Int).SetUint64(uint64((size + 31) / 32))
+		slot.Add(slot, slots.Mul(slots, new(big.Int).SetUint64(index)))
+	}
+	return Location{Slot: toHash(slot), Offset: offset, Type: base}, nil
+}
+
+func (r *Reader) readValue(ctx context.Context, loc Location, blockNumber *big.Int) (interface{}, error) {
+	t := loc.Type
+	switch t.Encoding {
+	case EncodingMapping:
+		return nil, fmt.Errorf("mapping %s cannot be read without a key", t.Label)
+	case EncodingBytes:
+		data, err := r.readBytes(ctx, loc.Slot, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		if t.Label == "string" {
+			return string(data), nil
+		}
+		return data, nil
+	case EncodingDynamicArray:
+		word, err := r.ReadSlot(ctx, loc.Slot, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		length := word.Big()
+		if !length.IsUint64() {
+			return nil, fmt.Errorf("invalid length of %s", t.Label)
+		}
+		return r.readArray(ctx, t, crypto.Keccak256Hash(loc.Slot.Bytes()), length.Uint64(), blockNumber)
+	}
+
+	if len(t.Members) > 0 {
+		values := make(map[string]interface{}, len(t.Members))
+		for _, m := range t.Members {
+			member, err := r.location(m, loc.Slot)
+			if err != nil {
+				return nil, err
+			}
+			if values[m.Label], err = r.readValue(ctx, member, blockNumber); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	}
+	if length, ok := t.staticArrayLength(); ok {
+		return r.readArray(ctx, t, loc.Slot, uint64(length), blockNumber)
+	}
+
+	word, err := r.ReadSlot(ctx, loc.Slot, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	size := t.size()
+	if loc.Offset+size > 32 {
+		return nil, fmt.Errorf("value of type %s exceeds the storage slot", t.Label)
+	}
+	return decodeValue(t, word[32-loc.Offset-size:32-loc.Offset])
+}
+
+func (r *Reader) readArray(ctx context.Context, array Type, data common.Hash, length uint64, blockNumber *big.Int) ([]interface{}, error) {
+	values := make([]interface{}, length)
+	for i := range values {
+		loc, err := r.element(array, data, uint64(i))
+		if err != nil {
+			return nil, err
+		}
+		if values[i], err = r.readValue(ctx, loc, blockNumber); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// readBytes reads bytes or string value. Values shorter than 32 bytes are stored in the slot itself
+// along with length*2, while longer values store length*2+1 in the slot and data at keccak256(slot).
+func (r *Reader) readBytes(ctx context.Context, slot common.Hash, blockNumber *big.Int) ([]byte, error) {
+	word, err := r.ReadSlot(ctx, slot, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if word[31]&1 == 0 {
+		length := int(word[31] / 2)
+		if length > 31 {
+			return nil, errors.New("invalid short bytes encoding")
+		}
+		return common.CopyBytes(word[:length]), nil
+	}
+	length := new(big.Int).Rsh(word.Big(), 1)
+	if !length.IsInt64() || length.Int64() > 1<<24 {
+		return nil, errors.New("invalid long bytes encoding")
+	}
+	data := make([]byte, 0, length.Int64())
+	start := new(big.Int).SetBytes(crypto.Keccak256(slot.Bytes()))
+	for i := int64(0); int64(len(data)) < length.Int64(); i++ {
+		chunk, err := r.ReadSlot(ctx, toHash(new(big.Int).Add(start, big.NewInt(i))), blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, chunk.Bytes()...)
+	}
+	return data[:length.Int64()], nil
+}
+
+// decodeValue decodes the value type from its bytes.
+func decodeValue(t Type, data []byte) (interface{}, error) {
+	label := t.Label
+	switch {
+	case label == "bool":
+		return data[len(data)-1] != 0, nil
+	case label == "address" || label == "address payable" || strings.HasPrefix(label, "contract "):
+		return common.BytesToAddress(data), nil
+	case strings.HasPrefix(label, "uint") || strings.HasPrefix(label, "enum "):
+		return new(big.Int).SetBytes(data), nil
+	case strings.HasPrefix(label, "int"):
+		value := new(big.Int).SetBytes(data)
+		if len(data) > 0 && data[0]&0x80 != 0 {
+			value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(len(data)*8)))
+		}
+		return value, nil
+	default:
+		return common.CopyBytes(data), nil
+	}
+}
+
+// encodeKey encodes the mapping key, which is padded to 32 bytes for value types,
+// while string and bytes keys are used unpadded.
+func encodeKey(t Type, key string) ([]byte, error) {
+	label := t.Label
+	switch {
+	case t.Encoding == EncodingBytes:
+		if label == "string" {
+			return []byte(key), nil
+		}
+		return decodeHex(key)
+	case label == "bool":
+		b, err := strconv.ParseBool(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bool key %s", key)
+		}
+		if b {
+			return common.LeftPadBytes([]byte{1}, 32), nil
+		}
+		return make([]byte, 32), nil
+	case label == "address" || label == "address payable" || strings.HasPrefix(label, "contract "):
+		if !common.IsHexAddress(key) {
+			return nil, fmt.Errorf("invalid address key %s", key)
+		}
+		return common.LeftPadBytes(common.HexToAddress(key).Bytes(), 32), nil
+	case strings.HasPrefix(label, "uint") || strings.HasPrefix(label, "enum "):
+		value, ok := math.ParseBig256(key)
+		if !ok || value.Sign() < 0 {
+			return nil, fmt.Errorf("invalid unsigned integer key %s", key)
+		}
+		return math.U256Bytes(value), nil
+	case strings.HasPrefix(label, "int"):
+		value, ok := new(big.Int).SetString(key, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer key %s", key)
+		}
+		return math.U256Bytes(value), nil
+	case strings.HasPrefix(label, "bytes"):
+		data, err := decodeHex(key)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > 32 {
+			return nil, fmt.Errorf("invalid %s key %s", label, key)
+		}
+		return common.RightPadBytes(data, 32), nil
+	default:
+		return nil, fmt.Errorf("unsupported mapping key type %s", label)
+	}
+}
+
+func decodeHex(s string) ([]byte, error) {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if len(s)%2 == 1 {
+		s = "0" + s
+	}
+	data := common.FromHex(s)
+	if len(s) > 0 && len(data) == 0 {
+		return nil, fmt.Errorf("invalid hex key %s", s)
+	}
+	return data, nil
+}
+
+func parseIndex(key string) (uint64, error) {
+	index, err := strconv.ParseUint(key, 0, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid array index %s", key)
+	}
+	return index, nil
+}
+
+func toHash(slot *big.Int) common.Hash {
+	return common.BigToHash(new(big.Int).And(slot, math.MaxBig256))
+}
+
+// accessor represents either struct member or mapping key/array index in the variable path.
+type accessor struct {
+	member string
+	key    string
+}
+
+// parsePath splits the path into the variable name and the list of accessors.
+func parsePath(path string) (string, []accessor, error) {
+	path = strings.TrimSpace(path)
+	end := strings.IndexAny(path, ".[")
+	if end == -1 {
+		end = len(path)
+	}
+	name := path[:end]
+	if name == "" {
+		return "", nil, fmt.Errorf("invalid path %q: missing variable name", path)
+	}
+	var accessors []accessor
+	for rest := path[end:]; rest != ""; {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end = strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return "", nil, fmt.Errorf("invalid path %q: missing member name", path)
+			}
+			accessors = append(accessors, accessor{member: rest[:end]})
+			rest = rest[end:]
+		case '[':
+			end = strings.IndexByte(rest, ']')
+			if end == -1 {
+				return "", nil, fmt.Errorf("invalid path %q: missing closing bracket", path)
+			}
+			key := strings.TrimSpace(rest[1:end])
+			if len(key) >= 2 && key[0] == '"' && key[len(key)-1] == '"' {
+				key = key[1 : len(key)-1]
+			}
+			accessors = append(accessors, accessor{key: key})
+			rest = rest[end+1:]
+		default:
+			return "", nil, fmt.Errorf("invalid path %q", path)
+		}
+	}
+	return name, accessors, nil
+}
@@ -0,0 +1,126 @@
+// Package system provides the bindings of the zkSync system contracts bound to their canonical addresses,
+// along with typed helpers for the common low-level interactions with them.
+//
+// MsgValueSimulator, located at utils.MsgValueSimulatorAddress, has no ABI: it is invoked by the compiler
+// using system calls, which pass the value and the recipient in registers, and cannot be called through
+// the binding.
+package system
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/zksync-sdk/zksync2-go/contracts/accountcodestorage"
+	"github.com/zksync-sdk/zksync2-go/contracts/compressor"
+	"github.com/zksync-sdk/zksync2-go/contracts/contractdeployer"
+	"github.com/zksync-sdk/zksync2-go/contracts/knowncodesstorage"
+	"github.com/zksync-sdk/zksync2-go/contracts/l1messenger"
+	"github.com/zksync-sdk/zksync2-go/contracts/nonceholder"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"math/big"
+)
+
+// maxSystemContractAddress is the last address of the kernel space, reserved for the system contracts.
+var maxSystemContractAddress = common.HexToAddress("0x000000000000000000000000000000000000ffff")
+
+// nonceModulo separates the transaction nonce, stored in the lower 128 bits of the raw nonce,
+// from the deployment nonce stored in the upper 128 bits.
+var nonceModulo = new(big.Int).Lsh(big.NewInt(1), 128)
+
+// Contracts contains the bindings of the system contracts.
+type Contracts struct {
+	ContractDeployer   *contractdeployer.ContractDeployer
+	NonceHolder        *nonceholder.NonceHolder
+	L1Messenger        *l1messenger.IL1Messenger
+	AccountCodeStorage *accountcodestorage.AccountCodeStorage
+	KnownCodesStorage  *knowncodesstorage.KnownCodesStorage
+	Compressor         *compressor.Compressor
+}
+
+// NewContracts creates the bindings of the system contracts using the backend, which is usually
+// the L2 client.
+func NewContracts(backend bind.ContractBackend) (*Contracts, error) {
+	contractDeployer, err := contractdeployer.NewContractDeployer(utils.ContractDeployerAddress, backend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ContractDeployer: %w", err)
+	}
+	nonceHolder, err := nonceholder.NewNonceHolder(utils.NonceHolderAddress, backend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load NonceHolder: %w", err)
+	}
+	l1Messenger, err := l1messenger.NewIL1Messenger(utils.L1MessengerAddress, backend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load L1Messenger: %w", err)
+	}
+	accountCodeStorage, err := accountcodestorage.NewAccountCodeStorage(utils.AccountCodeStorageAddress, backend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AccountCodeStorage: %w", err)
+	}
+	knownCodesStorage, err := knowncodesstorage.NewKnownCodesStorage(utils.KnownCodesStorageAddress, backend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load KnownCodesStorage: %w", err)
+	}
+	compressorContract, err := compressor.NewCompressor(utils.CompressorAddress, backend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Compressor: %w", err)
+	}
+	return &Contracts{
+		ContractDeployer:   contractDeployer,
+		NonceHolder:        nonceHolder,
+		L1Messenger:        l1Messenger,
+		AccountCodeStorage: accountCodeStorage,
+		KnownCodesStorage:  knownCodesStorage,
+		Compressor:         compressorContract,
+	}, nil
+}
+
+// Nonces returns the transaction nonce and the deployment nonce of the account, read at once
+// from the raw nonce stored by NonceHolder.
+func (c *Contracts) Nonces(opts *bind.CallOpts, account common.Address) (txNonce, deploymentNonce *big.Int, err error) {
+	raw, err := c.NonceHolder.GetRawNonce(opts, account)
+	if err != nil {
+		return nil, nil, err
+	}
+	txNonce, deploymentNonce = SplitRawNonce(raw)
+	return txNonce, deploymentNonce, nil
+}
+
+// IsNonceUsed reports whether the nonce is used by the account, which supports the arbitrary
+// nonce ordering.
+func (c *Contracts) IsNonceUsed(opts *bind.CallOpts, account common.Address, nonce *big.Int) (bool, error) {
+	return c.NonceHolder.IsNonceUsed(opts, account, nonce)
+}
+
+// IsBytecodeKnown reports whether the bytecode with the hash, as returned by utils.HashBytecode,
+// is known on the network, so that it can be deployed without providing it as factory dependency.
+func (c *Contracts) IsBytecodeKnown(opts *bind.CallOpts, bytecodeHash common.Hash) (bool, error) {
+	marker, err := c.KnownCodesStorage.GetMarker(opts, bytecodeHash)
+	if err != nil {
+		return false, err
+	}
+	return marker.Sign() != 0, nil
+}
+
+// CodeHash returns the bytecode hash of the account stored by AccountCodeStorage, which is zero
+// for accounts without code. Unlike the EXTCODEHASH, the hash is in the format of utils.HashBytecode.
+func (c *Contracts) CodeHash(opts *bind.CallOpts, account common.Address) (common.Hash, error) {
+	return c.AccountCodeStorage.GetRawCodeHash(opts, account)
+}
+
+// AccountInfo returns the account abstraction version and the nonce ordering of the account,
+// as stored by ContractDeployer.
+func (c *Contracts) AccountInfo(opts *bind.CallOpts, account common.Address) (contractdeployer.IContractDeployerAccountInfo, error) {
+	return c.ContractDeployer.GetAccountInfo(opts, account)
+}
+
+// SplitRawNonce splits the raw nonce stored by NonceHolder into the transaction nonce
+// and the deployment nonce.
+func SplitRawNonce(raw *big.Int) (txNonce, deploymentNonce *big.Int) {
+	deploymentNonce, txNonce = new(big.Int).DivMod(raw, nonceModulo, new(big.Int))
+	return txNonce, deploymentNonce
+}
+
+// IsSystemContract reports whether the address belongs to the kernel space reserved for the system contracts.
+func IsSystemContract(address common.Address) bool {
+	return address.Big().Cmp(maxSystemContractAddress.Big()) <= 0
+}
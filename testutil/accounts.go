@@ -0,0 +1,127 @@
+package testutil
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/zksync-sdk/zksync2-go/accounts"
+	"math/big"
+	"testing"
+)
+
+// DefaultFunding is the balance of the base token set for the named accounts.
+var DefaultFunding = new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18))
+
+// RichPrivateKeys are the private keys of the accounts which are funded by era_test_node and anvil-zksync
+// at the start.
+var RichPrivateKeys = []string{
+	"0x3d3cbc973389cb26f657686445bcc75662b415b656078503592ac8c1abb8810e",
+	"0x509ca2e9e6acf0ba086477910950125e698d4ea70fa6f63e000c5a22bda9361c",
+	"0x71781d3a358e7a65150e894264ccc594993fbc0ea12d69508a340bc1d4f5bfbc",
+	"0x379d31d4a7031ead87397f332aab69ef5cd843ba3898249ca1046633c0c7eefe",
+	"0x105de4e75fe465d075e1daae5647a02e3aad54b8d23cf1f70ba382b9f9bee839",
+	"0x7becc4a46e0c3b512d380ca73a4c868f790d1055a7698f38fb3ca2b2ac97efbb",
+	"0xe0415469c10f3b1142ce0262497fe5c7a0795f0cbfd466a6bfa31968d0f70841",
+	"0x4d91647d0a8429ac4433c83254fb9625332693c848e578062fe96362f32bfe91",
+	"0x41c9f9518aa07b50cb1c0cc160d45547f57638dd824a8d85b5eb3bf99ed2bdeb",
+	"0xb0680d66303a0163a19294f1ef8c95cd69a9d7902a4aca99c05f3e134e68a11a",
+}
+
+// Account is the account of the test.
+type Account struct {
+	Name       string            // Name of the account, used for display purposes only.
+	Address    common.Address    // Address of the account.
+	PrivateKey *ecdsa.PrivateKey // Private key of the account.
+}
+
+// NamedAccount returns the account, whose private key is derived from the name, so that the same name
+// always refers to the same account.
+func NamedAccount(name string) *Account {
+	// The hash is the valid private key unless it exceeds the order of the curve, which is negligibly unlikely.
+	key, err := crypto.ToECDSA(crypto.Keccak256([]byte("zksync2-go/testutil:" + name)))
+	if err != nil {
+		panic(fmt.Sprintf("failed to derive key of account %s: %v", name, err))
+	}
+	return &Account{Name: name, Address: crypto.PubkeyToAddress(key.PublicKey), PrivateKey: key}
+}
+
+// RichAccount returns the rich account with the index in RichPrivateKeys.
+func RichAccount(index int) *Account {
+	key, err := crypto.HexToECDSA(RichPrivateKeys[index][2:])
+	if err != nil {
+		panic(fmt.Sprintf("invalid rich private key %d: %v", index, err))
+	}
+	return &Account{Name: fmt.Sprintf("rich-%d", index), Address: crypto.PubkeyToAddress(key.PublicKey), PrivateKey: key}
+}
+
+// Account returns the named account, which is funded with DefaultFunding when it is requested first.
+func (n *Node) Account(ctx context.Context, name string) (*Account, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if account, ok := n.accounts[name]; ok {
+		return account, nil
+	}
+	account := NamedAccount(name)
+	if err := n.Dev.SetBalance(ctx, account.Address, DefaultFunding); err != nil {
+		return nil, err
+	}
+	n.accounts[name] = account
+	return account, nil
+}
+
+// NewWallet creates the wallet of the account, connected to the node.
+func (n *Node) NewWallet(account *Account) (*accounts.Wallet, error) {
+	return accounts.NewWalletWithOptions(accounts.WalletOptions{
+		SignerOptions: accounts.SignerOptions{PrivateKey: crypto.FromECDSA(account.PrivateKey)},
+		ClientL2:      n.Client,
+	})
+}
+
+// Wallet returns the wallet of the named account, funded with DefaultFunding, failing the test on error.
+func (n *Node) Wallet(tb testing.TB, name string) *accounts.Wallet {
+	tb.Helper()
+	account, err := n.Account(context.Background(), name)
+	if err != nil {
+		tb.Fatalf("failed to fund account %s: %v", name, err)
+	}
+	wallet, err := n.NewWallet(account)
+	if err != nil {
+		tb.Fatalf("failed to create wallet of account %s: %v", name, err)
+	}
+	return wallet
+}
+
+// RichWallet returns the wallet of the rich account with the index in RichPrivateKeys, failing the test on error.
+func (n *Node) RichWallet(tb testing.TB, index int) *accounts.Wallet {
+	tb.Helper()
+	wallet, err := n.NewWallet(RichAccount(index))
+	if err != nil {
+		tb.Fatalf("failed to create wallet of rich account %d: %v", index, err)
+	}
+	return wallet
+}
+
+// Balance returns the balance of the base token of the account, failing the test on error.
+func (n *Node) Balance(tb testing.TB, address common.Address) *big.Int {
+	tb.Helper()
+	balance, err := n.Client.BalanceAt(context.Background(), address, nil)
+	if err != nil {
+		tb.Fatalf("failed to get balance of %s: %v", address, err)
+	}
+	return balance
+}
+
+// WaitMined waits until the transaction is mined, failing the test on error or if the transaction has failed.
+func (n *Node) WaitMined(tb testing.TB, txHash common.Hash) {
+	tb.Helper()
+	receipt, err := n.Client.WaitMined(context.Background(), txHash)
+	if err != nil {
+		tb.Fatalf("failed to wait for transaction %s: %v", txHash, err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		tb.Fatalf("transaction %s has failed", txHash)
+	}
+}
@@ -0,0 +1,284 @@
+// Package testutil provides the harness for integration tests, which starts and stops the local node,
+// either era_test_node or anvil-zksync, run as the binary or the Docker container, and provides the clients
+// and wallets of funded accounts connected to it:
+//
+//	func TestTransfer(t *testing.T) {
+//		node := testutil.StartNode(t, nil)
+//		alice, bob := node.Wallet(t, "alice"), node.Wallet(t, "bob")
+//		...
+//	}
+//
+// If the URLEnv environment variable is set, the node running at that URL is used instead of starting one.
+package testutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/zksync-sdk/zksync2-go/clients"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	// URLEnv is the environment variable containing the URL of the already running node, which is used
+	// by StartNode instead of starting a new one.
+	URLEnv = "ZKSYNC_TEST_NODE_URL"
+	// DefaultImage is the Docker image of the node, used when Options.Docker is set.
+	DefaultImage = "ghcr.io/matter-labs/anvil-zksync:latest"
+	// DefaultStartTimeout is the default time the node has to start accepting requests.
+	DefaultStartTimeout = time.Minute
+	// containerPort is the port the node listens on in the container.
+	containerPort = 8011
+)
+
+// Binaries are the names of the node binaries looked up in PATH, in order of preference.
+var Binaries = []string{"anvil-zksync", "era_test_node"}
+
+// ErrNodeNotFound is returned when the node binary is not specified and none of Binaries is found in PATH.
+var ErrNodeNotFound = errors.New("neither anvil-zksync nor era_test_node binary is found")
+
+// Options contains the options of the started node.
+type Options struct {
+	// Binary is the path to the node binary. Optional, the binaries from Binaries are looked up in PATH by default.
+	Binary string
+	// Docker runs the node in the Docker container instead of the binary.
+	Docker bool
+	// Image is the Docker image of the node. Optional, DefaultImage is used by default.
+	Image string
+	// Port is the port the node listens on. Optional, a free port is used by default.
+	Port int
+	// Args are the additional arguments of the node, e.g. to fork the network.
+	Args []string
+	// StartTimeout is the time the node has to start accepting requests. Optional, DefaultStartTimeout is used
+	// by default.
+	StartTimeout time.Duration
+	// Output receives the output of the node. Optional, the output is discarded by default.
+	Output io.Writer
+}
+
+// Node is the local node, started by Start or StartNode.
+type Node struct {
+	URL    string             // URL of the node RPC.
+	Client clients.Client     // Client connected to the node.
+	Dev    *clients.DevClient // Client manipulating the state of the node, e.g. to snapshot and revert it.
+
+	cmd       *exec.Cmd
+	exited    chan struct{} // Closed when the started process exits.
+	container string
+	stopOnce  sync.Once
+	stopErr   error
+
+	mu       sync.Mutex
+	accounts map[string]*Account // Named accounts which are already funded.
+}
+
+// Start starts the node and waits until it accepts requests. The node must be stopped using Node.Stop.
+func Start(ctx context.Context, opts *Options) (*Node, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	port := opts.Port
+	if port == 0 {
+		var err error
+		if port, err = freePort(); err != nil {
+			return nil, err
+		}
+	}
+	output := opts.Output
+	if output == nil {
+		output = io.Discard
+	}
+
+	node := &Node{URL: fmt.Sprintf("http://127.0.0.1:%d", port), accounts: make(map[string]*Account)}
+	if opts.Docker {
+		image := opts.Image
+		if image == "" {
+			image = DefaultImage
+		}
+		args := append([]string{"run", "-d", "--rm", "-p", fmt.Sprintf("127.0.0.1:%d:%d", port, containerPort), image}, opts.Args...)
+		out, err := exec.CommandContext(ctx, "docker", args...).Output()
+		if err != nil {
+			return nil, fmt.Errorf("failed to start container of %s: %w", image, commandError(err))
+		}
+		node.container = strings.TrimSpace(string(out))
+	} else {
+		binary := opts.Binary
+		if binary == "" {
+			for _, name := range Binaries {
+				if path, err := exec.LookPath(name); err == nil {
+					binary = path
+					break
+				}
+			}
+			if binary == "" {
+				return nil, ErrNodeNotFound
+			}
+		}
+		args := append([]string{"--port", strconv.Itoa(port)}, opts.Args...)
+		// era_test_node requires the command, while anvil-zksync runs the node by default.
+		if strings.Contains(binary, "era_test_node") && !hasCommand(opts.Args) {
+			args = append(args, "run")
+		}
+		node.cmd = exec.Command(binary, args...)
+		node.cmd.Stdout, node.cmd.Stderr = output, output
+		if err := node.cmd.Start(); err != nil {
+			return nil, fmt.Errorf("failed to start %s: %w", binary, err)
+		}
+		node.exited = make(chan struct{})
+		go func() {
+			_ = node.cmd.Wait()
+			close(node.exited)
+		}()
+	}
+
+	timeout := opts.StartTimeout
+	if timeout == 0 {
+		timeout = DefaultStartTimeout
+	}
+	if err := node.waitReady(ctx, timeout); err != nil {
+		_ = node.Stop()
+		return nil, err
+	}
+	return node, nil
+}
+
+// Connect connects to the node running at the URL, which is not stopped by Node.Stop.
+func Connect(ctx context.Context, url string) (*Node, error) {
+	client, err := clients.DialDevClient(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = client.ChainID(ctx); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("node at %s is not available: %w", url, err)
+	}
+	return &Node{URL: url, Client: client.Client, Dev: client, accounts: make(map[string]*Account)}, nil
+}
+
+// StartNode connects to the node at the URL from URLEnv, if set, or starts the node otherwise, failing
+// the test on error. The started node is stopped when the test finishes.
+func StartNode(tb testing.TB, opts *Options) *Node {
+	tb.Helper()
+	if url := os.Getenv(URLEnv); url != "" {
+		node, err := Connect(context.Background(), url)
+		if err != nil {
+			tb.Fatalf("failed to connect to node: %v", err)
+		}
+		tb.Cleanup(func() { _ = node.Stop() })
+		return node
+	}
+	node, err := Start(context.Background(), opts)
+	if errors.Is(err, ErrNodeNotFound) {
+		tb.Skipf("skipping test: %v", err)
+	}
+	if err != nil {
+		tb.Fatalf("failed to start node: %v", err)
+	}
+	tb.Cleanup(func() {
+		if err := node.Stop(); err != nil {
+			tb.Logf("failed to stop node: %v", err)
+		}
+	})
+	return node
+}
+
+// Stop closes the client and stops the node, if it was started by Start.
+func (n *Node) Stop() error {
+	n.stopOnce.Do(func() {
+		if n.Client != nil {
+			n.Client.Close()
+		}
+		switch {
+		case n.container != "":
+			if err := exec.Command("docker", "stop", n.container).Run(); err != nil {
+				n.stopErr = fmt.Errorf("failed to stop container %s: %w", n.container, commandError(err))
+			}
+		case n.cmd != nil:
+			n.stopErr = n.stopProcess()
+		}
+	})
+	return n.stopErr
+}
+
+// waitReady waits until the node accepts requests, and connects the client to it.
+func (n *Node) waitReady(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		client, err := clients.DialDevClient(ctx, n.URL)
+		if err == nil {
+			if _, err = client.ChainID(ctx); err == nil {
+				n.Client, n.Dev = client.Client, client
+				return nil
+			}
+			client.Close()
+		}
+		select {
+		case <-n.exited:
+			return fmt.Errorf("node exited before accepting requests: %s", n.cmd.ProcessState)
+		case <-ctx.Done():
+			return fmt.Errorf("node did not start accepting requests at %s: %w", n.URL, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// stopProcess interrupts the started process and kills it if it does not exit in time.
+func (n *Node) stopProcess() error {
+	select {
+	case <-n.exited:
+		return nil
+	default:
+	}
+	if err := n.cmd.Process.Signal(os.Interrupt); err == nil {
+		select {
+		case <-n.exited:
+			return nil
+		case <-time.After(5 * time.Second):
+		}
+	}
+	if err := n.cmd.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return err
+	}
+	<-n.exited
+	return nil
+}
+
+// freePort returns the port which is currently free.
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find free port: %w", err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// hasCommand reports whether the arguments contain the command of era_test_node, e.g. fork.
+func hasCommand(args []string) bool {
+	for _, arg := range args {
+		if arg == "run" || arg == "fork" || arg == "replay_tx" {
+			return true
+		}
+	}
+	return false
+}
+
+// commandError returns the error of the command, including its error output.
+func commandError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return err
+}
@@ -0,0 +1,207 @@
+// Package timelock builds transactions targeting OpenZeppelin TimelockController contracts, used by DAO-operated
+// deployments to delay privileged operations, and checks the state of scheduled operations.
+package timelock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/zksync-sdk/zksync2-go/accounts"
+	"github.com/zksync-sdk/zksync2-go/contracts/timelockcontroller"
+	"math/big"
+	"time"
+)
+
+// doneTimestamp is the timestamp which TimelockController stores for executed operations.
+var doneTimestamp = big.NewInt(1)
+
+// Operation is a single call scheduled by the timelock.
+type Operation struct {
+	Target      common.Address
+	Value       *big.Int // Value sent with the call, nil means zero.
+	Data        []byte
+	Predecessor common.Hash // ID of the operation which must be executed before this one, zero if none.
+	Salt        common.Hash // Salt distinguishing otherwise identical operations.
+}
+
+// BatchOperation is a batch of calls scheduled by the timelock, which are executed atomically.
+type BatchOperation struct {
+	Targets     []common.Address
+	Values      []*big.Int // Values sent with the calls, nil entries mean zero.
+	Payloads    [][]byte
+	Predecessor common.Hash // ID of the operation which must be executed before this one, zero if none.
+	Salt        common.Hash // Salt distinguishing otherwise identical operations.
+}
+
+// ID returns the operation ID, computed the same way as TimelockController.hashOperation.
+func (op *Operation) ID() (common.Hash, error) {
+	return hash("hashOperation", op.Target, value(op.Value), op.Data, op.Predecessor, op.Salt)
+}
+
+// Schedule returns the transaction scheduling the operation with the given delay in seconds, which must be
+// at least the minimum delay of the timelock.
+func (op *Operation) Schedule(timelock common.Address, delay *big.Int) (*accounts.Transaction, error) {
+	return transaction(timelock, nil, "schedule", op.Target, value(op.Value), op.Data, op.Predecessor, op.Salt, delay)
+}
+
+// Execute returns the transaction executing the ready operation. The value of the operation
+// is sent with the transaction.
+func (op *Operation) Execute(timelock common.Address) (*accounts.Transaction, error) {
+	return transaction(timelock, value(op.Value), "execute", op.Target, value(op.Value), op.Data, op.Predecessor, op.Salt)
+}
+
+// ID returns the operation ID, computed the same way as TimelockController.hashOperationBatch.
+func (op *BatchOperation) ID() (common.Hash, error) {
+	if err := op.validate(); err != nil {
+		return common.Hash{}, err
+	}
+	return hash("hashOperationBatch", op.Targets, op.values(), op.Payloads, op.Predecessor, op.Salt)
+}
+
+// Schedule returns the transaction scheduling the batch with the given delay in seconds, which must be
+// at least the minimum delay of the timelock.
+func (op *BatchOperation) Schedule(timelock common.Address, delay *big.Int) (*accounts.Transaction, error) {
+	if err := op.validate(); err != nil {
+		return nil, err
+	}
+	return transaction(timelock, nil, "scheduleBatch", op.Targets, op.values(), op.Payloads, op.Predecessor, op.Salt, delay)
+}
+
+// Execute returns the transaction executing the ready batch. The sum of the values of the calls
+// is sent with the transaction.
+func (op *BatchOperation) Execute(timelock common.Address) (*accounts.Transaction, error) {
+	if err := op.validate(); err != nil {
+		return nil, err
+	}
+	values := op.values()
+	total := new(big.Int)
+	for _, v := range values {
+		total.Add(total, v)
+	}
+	return transaction(timelock, total, "executeBatch", op.Targets, values, op.Payloads, op.Predecessor, op.Salt)
+}
+
+func (op *BatchOperation) validate() error {
+	if len(op.Targets) != len(op.Values) || len(op.Targets) != len(op.Payloads) {
+		return errors.New("targets, values and payloads must have the same length")
+	}
+	return nil
+}
+
+func (op *BatchOperation) values() []*big.Int {
+	values := make([]*big.Int, len(op.Values))
+	for i, v := range op.Values {
+		values[i] = value(v)
+	}
+	return values
+}
+
+// Cancel returns the transaction canceling the pending operation with the given ID.
+func Cancel(timelock common.Address, id common.Hash) (*accounts.Transaction, error) {
+	return transaction(timelock, nil, "cancel", id)
+}
+
+// State is the state of the operation in the timelock.
+type State int
+
+const (
+	Unset   State = iota // The operation has not been scheduled, or it has been canceled.
+	Waiting              // The operation has been scheduled, but the delay has not passed yet.
+	Ready                // The operation can be executed.
+	Done                 // The operation has been executed.
+)
+
+func (s State) String() string {
+	switch s {
+	case Unset:
+		return "unset"
+	case Waiting:
+		return "waiting"
+	case Ready:
+		return "ready"
+	case Done:
+		return "done"
+	default:
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+}
+
+// Status is the status of the operation in the timelock.
+type Status struct {
+	State   State
+	ReadyAt time.Time // Time since which the operation can be executed, zero for Unset and Done operations.
+}
+
+// OperationStatus returns the status of the operation with the given ID in the timelock.
+func OperationStatus(ctx context.Context, backend bind.ContractCaller, timelock common.Address, id common.Hash) (*Status, error) {
+	controller, err := timelockcontroller.NewITimelockControllerCaller(timelock, backend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ITimelockController: %w", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	timestamp, err := controller.GetTimestamp(opts, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get timestamp of operation: %w", err)
+	}
+	switch {
+	case timestamp.Sign() == 0:
+		return &Status{State: Unset}, nil
+	case timestamp.Cmp(doneTimestamp) == 0:
+		return &Status{State: Done}, nil
+	}
+	// Readiness is checked by the contract, since it depends on the timestamp of the block.
+	ready, err := controller.IsOperationReady(opts, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check operation readiness: %w", err)
+	}
+	status := &Status{State: Waiting, ReadyAt: time.Unix(timestamp.Int64(), 0)}
+	if ready {
+		status.State = Ready
+	}
+	return status, nil
+}
+
+// MinDelay returns the minimum delay of operations in seconds.
+func MinDelay(ctx context.Context, backend bind.ContractCaller, timelock common.Address) (*big.Int, error) {
+	controller, err := timelockcontroller.NewITimelockControllerCaller(timelock, backend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ITimelockController: %w", err)
+	}
+	return controller.GetMinDelay(&bind.CallOpts{Context: ctx})
+}
+
+func value(v *big.Int) *big.Int {
+	if v == nil {
+		return big.NewInt(0)
+	}
+	return v
+}
+
+// hash computes the operation ID by hashing the inputs of the method encoded the same way as the contract does.
+func hash(method string, args ...interface{}) (common.Hash, error) {
+	timelockAbi, err := timelockcontroller.ITimelockControllerMetaData.GetAbi()
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to load timelock ABI: %w", err)
+	}
+	encoded, err := timelockAbi.Methods[method].Inputs.Pack(args...)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to encode operation: %w", err)
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+// transaction returns the transaction calling the timelock method.
+func transaction(timelock common.Address, value *big.Int, method string, args ...interface{}) (*accounts.Transaction, error) {
+	timelockAbi, err := timelockcontroller.ITimelockControllerMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timelock ABI: %w", err)
+	}
+	data, err := timelockAbi.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s call: %w", method, err)
+	}
+	return &accounts.Transaction{To: &timelock, Data: data, Value: value}, nil
+}
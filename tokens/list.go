@@ -0,0 +1,133 @@
+package tokens
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+)
+
+// TokenList is the curated list of tokens in the format defined by https://tokenlists.org.
+type TokenList struct {
+	Name      string       `json:"name"`
+	Timestamp string       `json:"timestamp"`
+	Version   ListVersion  `json:"version"`
+	Tokens    []TokenInfo  `json:"tokens"`
+	Keywords  []string     `json:"keywords,omitempty"`
+	LogoURI   string       `json:"logoURI,omitempty"`
+	Tags      ListTagsInfo `json:"tags,omitempty"`
+}
+
+// ListVersion is the semantic version of the token list.
+type ListVersion struct {
+	Major int `json:"major"`
+	Minor int `json:"minor"`
+	Patch int `json:"patch"`
+}
+
+// String returns the version formatted as major.minor.patch.
+func (v ListVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// ListTagsInfo contains the definitions of the tags used by the tokens of the list, by tag identifiers.
+type ListTagsInfo map[string]struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// TokenInfo is the token of the token list.
+type TokenInfo struct {
+	ChainID    int64           `json:"chainId"`
+	Address    common.Address  `json:"address"`
+	Name       string          `json:"name"`
+	Symbol     string          `json:"symbol"`
+	Decimals   uint8           `json:"decimals"`
+	LogoURI    string          `json:"logoURI,omitempty"`
+	Tags       []string        `json:"tags,omitempty"`
+	Extensions *TokenExtension `json:"extensions,omitempty"`
+}
+
+// TokenExtension contains the extensions of the token used to describe bridged tokens.
+type TokenExtension struct {
+	// BridgeInfo contains the addresses of the token on other chains, by chain IDs.
+	BridgeInfo map[string]BridgeInfo `json:"bridgeInfo,omitempty"`
+}
+
+// BridgeInfo is the address of the bridged token on the other chain.
+type BridgeInfo struct {
+	TokenAddress common.Address `json:"tokenAddress"`
+}
+
+// BridgedAddress returns the address of the token on the chain with the given ID, as specified by
+// the bridgeInfo extension.
+func (t *TokenInfo) BridgedAddress(chainID int64) (common.Address, bool) {
+	if t.Extensions == nil {
+		return common.Address{}, false
+	}
+	info, ok := t.Extensions.BridgeInfo[strconv.FormatInt(chainID, 10)]
+	if !ok {
+		return common.Address{}, false
+	}
+	return info.TokenAddress, true
+}
+
+// ChainTokens returns the tokens of the list deployed on the chain with the given ID.
+func (l *TokenList) ChainTokens(chainID int64) []TokenInfo {
+	var tokens []TokenInfo
+	for _, t := range l.Tokens {
+		if t.ChainID == chainID {
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens
+}
+
+// ParseTokenList decodes the token list from JSON.
+func ParseTokenList(r io.Reader) (*TokenList, error) {
+	var list TokenList
+	if err := json.NewDecoder(r).Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to decode token list: %w", err)
+	}
+	if len(list.Tokens) == 0 {
+		return nil, errors.New("token list contains no tokens")
+	}
+	return &list, nil
+}
+
+// ReadTokenList reads the token list from the JSON file.
+func ReadTokenList(path string) (*TokenList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseTokenList(f)
+}
+
+// FetchTokenList downloads the token list from the URL. The HTTP client is optional,
+// http.DefaultClient is used by default.
+func FetchTokenList(ctx context.Context, url string, client *http.Client) (*TokenList, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch token list: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch token list: unexpected status %s", resp.Status)
+	}
+	return ParseTokenList(resp.Body)
+}
@@ -0,0 +1,353 @@
+// Package tokens provides the registry of token metadata, which resolves the name, symbol and decimals
+// of tokens using on-chain calls, maps the tokens between L1 and L2 using the bridges, and caches the results,
+// so that token balances can be displayed without querying the network for each token repeatedly. The registry
+// can be seeded from curated token lists in the format defined by https://tokenlists.org.
+package tokens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/zksync-sdk/zksync2-go/clients"
+	"github.com/zksync-sdk/zksync2-go/contracts/erc20"
+	"github.com/zksync-sdk/zksync2-go/types"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"sort"
+	"sync"
+)
+
+// ErrL1NotConfigured is returned when the L1 token is resolved by the registry created without the L1 client.
+var ErrL1NotConfigured = errors.New("L1 client is not configured")
+
+// Metadata is the metadata of the token on a single chain.
+type Metadata struct {
+	Address  common.Address // Address of the token.
+	Name     string         // Name of the token.
+	Symbol   string         // Symbol of the token.
+	Decimals uint8          // Number of decimals of the token.
+	LogoURI  string         // URI of the token logo, if the token is loaded from the token list.
+}
+
+// Registry resolves and caches the metadata of tokens and the mapping of tokens between L1 and L2.
+// The metadata of the tokens is immutable, so the cached entries never expire. The registry is safe
+// for concurrent use.
+type Registry struct {
+	client clients.Client
+	l1     bind.ContractCaller
+
+	mu         sync.RWMutex
+	metadata   map[common.Address]*Metadata // L2 token metadata.
+	l1Metadata map[common.Address]*Metadata // L1 token metadata.
+	l1ToL2     map[common.Address]common.Address
+	l2ToL1     map[common.Address]common.Address
+}
+
+// NewRegistry creates an instance of Registry. The L1 client is optional and is used to resolve
+// the metadata of L1 tokens, including the base token of non-ETH-based chains.
+func NewRegistry(client clients.Client, l1 bind.ContractCaller) *Registry {
+	return &Registry{
+		client:     client,
+		l1:         l1,
+		metadata:   make(map[common.Address]*Metadata),
+		l1Metadata: make(map[common.Address]*Metadata),
+		l1ToL2:     make(map[common.Address]common.Address),
+		l2ToL1:     make(map[common.Address]common.Address),
+	}
+}
+
+// Metadata returns the metadata of the L2 token. The base token can be specified either by
+// utils.L2BaseTokenAddress or, on ETH-based chains, by utils.EthAddress.
+func (r *Registry) Metadata(ctx context.Context, token common.Address) (*Metadata, error) {
+	if m, ok := r.cached(r.metadata, token); ok {
+		return m, nil
+	}
+	isBaseToken, err := r.client.IsBaseToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	var m *Metadata
+	if isBaseToken {
+		m, err = r.baseTokenMetadata(ctx, token)
+	} else {
+		m, err = queryMetadata(ctx, r.client, token)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.store(r.metadata, m), nil
+}
+
+// MetadataOf returns the metadata of the L2 tokens by their addresses. The metadata of the tokens which
+// are not cached is queried in a single batch request. The base token is resolved the same way as by Metadata.
+func (r *Registry) MetadataOf(ctx context.Context, tokens []common.Address) (map[common.Address]*Metadata, error) {
+	result := make(map[common.Address]*Metadata, len(tokens))
+	var missing []common.Address
+	for _, token := range tokens {
+		if _, ok := result[token]; ok {
+			continue
+		}
+		if m, ok := r.cached(r.metadata, token); ok {
+			result[token] = m
+			continue
+		}
+		if token == utils.EthAddress || token == utils.L2BaseTokenAddress {
+			m, err := r.Metadata(ctx, token)
+			if err != nil {
+				return nil, err
+			}
+			result[token] = m
+			continue
+		}
+		result[token] = nil
+		missing = append(missing, token)
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	erc20ABI, err := erc20.IERC20MetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load erc20ABI: %w", err)
+	}
+	methods := []string{"name", "symbol", "decimals"}
+	msgs := make([]types.CallMsg, 0, len(missing)*len(methods))
+	for i := range missing {
+		for _, method := range methods {
+			data, err := erc20ABI.Pack(method)
+			if err != nil {
+				return nil, err
+			}
+			msgs = append(msgs, types.CallMsg{CallMsg: ethereum.CallMsg{To: &missing[i], Data: data}})
+		}
+	}
+	results, _, err := r.client.BatchCallContractL2(ctx, msgs, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i, token := range missing {
+		values := make([]interface{}, len(methods))
+		for j, method := range methods {
+			res := results[i*len(methods)+j]
+			if res.Err != nil {
+				return nil, fmt.Errorf("failed to query %s of token %s: %w", method, token, res.Err)
+			}
+			out, err := erc20ABI.Unpack(method, res.Data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode %s of token %s: %w", method, token, err)
+			}
+			values[j] = out[0]
+		}
+		name, okName := values[0].(string)
+		symbol, okSymbol := values[1].(string)
+		decimals, okDecimals := values[2].(uint8)
+		if !okName || !okSymbol || !okDecimals {
+			return nil, fmt.Errorf("unexpected metadata of token %s", token)
+		}
+		result[token] = r.store(r.metadata, &Metadata{Address: token, Name: name, Symbol: symbol, Decimals: decimals})
+	}
+	return result, nil
+}
+
+// L1Metadata returns the metadata of the L1 token. Requires the L1 client, except for ETH.
+func (r *Registry) L1Metadata(ctx context.Context, token common.Address) (*Metadata, error) {
+	if m, ok := r.cached(r.l1Metadata, token); ok {
+		return m, nil
+	}
+	if token == utils.EthAddress || token == utils.EthAddressInContracts {
+		return r.store(r.l1Metadata, etherMetadata(token)), nil
+	}
+	if r.l1 == nil {
+		return nil, ErrL1NotConfigured
+	}
+	m, err := queryMetadata(ctx, r.l1, token)
+	if err != nil {
+		return nil, err
+	}
+	return r.store(r.l1Metadata, m), nil
+}
+
+// L2Address returns the address on L2 of the L1 token, as reported by the default bridge.
+func (r *Registry) L2Address(ctx context.Context, l1Token common.Address) (common.Address, error) {
+	r.mu.RLock()
+	address, ok := r.l1ToL2[l1Token]
+	r.mu.RUnlock()
+	if ok {
+		return address, nil
+	}
+	address, err := r.client.L2TokenAddress(ctx, l1Token)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to resolve L2 address of token %s: %w", l1Token, err)
+	}
+	if address != (common.Address{}) || l1Token == utils.EthAddress {
+		r.storeMapping(l1Token, address)
+	}
+	return address, nil
+}
+
+// L1Address returns the address on L1 of the L2 token, as reported by the default bridge.
+func (r *Registry) L1Address(ctx context.Context, l2Token common.Address) (common.Address, error) {
+	r.mu.RLock()
+	address, ok := r.l2ToL1[l2Token]
+	r.mu.RUnlock()
+	if ok {
+		return address, nil
+	}
+	address, err := r.client.L1TokenAddress(ctx, l2Token)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to resolve L1 address of token %s: %w", l2Token, err)
+	}
+	if address != (common.Address{}) || l2Token == utils.EthAddress {
+		r.storeMapping(address, l2Token)
+	}
+	return address, nil
+}
+
+// Token returns the L2 token along with its L1 address. The L1 address is zero for the tokens
+// which are native to L2.
+func (r *Registry) Token(ctx context.Context, l2Token common.Address) (*types.Token, error) {
+	m, err := r.Metadata(ctx, l2Token)
+	if err != nil {
+		return nil, err
+	}
+	l1Address, err := r.L1Address(ctx, l2Token)
+	if err != nil {
+		return nil, err
+	}
+	return &types.Token{
+		L1Address: l1Address,
+		L2Address: l2Token,
+		Name:      m.Name,
+		Symbol:    m.Symbol,
+		Decimals:  uint(m.Decimals),
+	}, nil
+}
+
+// Tokens returns the metadata of all L2 tokens known to the registry, sorted by symbol,
+// e.g. to display the balances of the tokens loaded from the token list.
+func (r *Registry) Tokens() []*Metadata {
+	r.mu.RLock()
+	tokens := make([]*Metadata, 0, len(r.metadata))
+	for _, m := range r.metadata {
+		tokens = append(tokens, m)
+	}
+	r.mu.RUnlock()
+	sort.Slice(tokens, func(i, j int) bool {
+		if tokens[i].Symbol != tokens[j].Symbol {
+			return tokens[i].Symbol < tokens[j].Symbol
+		}
+		return tokens[i].Address.Hex() < tokens[j].Address.Hex()
+	})
+	return tokens
+}
+
+// LoadList seeds the registry with the tokens of the token list deployed on L1 and L2, without querying
+// the network for their metadata. The mapping between L1 and L2 tokens is taken from the bridgeInfo
+// extension. Tokens on other chains are ignored. Returns the number of loaded L2 tokens.
+func (r *Registry) LoadList(ctx context.Context, list *TokenList) (int, error) {
+	l2ChainID, err := r.client.ChainID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	l1ChainID, err := r.client.L1ChainID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if !l2ChainID.IsInt64() || !l1ChainID.IsInt64() {
+		return 0, errors.New("chain ID out of range")
+	}
+	l1, l2 := l1ChainID.Int64(), l2ChainID.Int64()
+
+	loaded := 0
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range list.Tokens {
+		t := &list.Tokens[i]
+		m := &Metadata{Address: t.Address, Name: t.Name, Symbol: t.Symbol, Decimals: t.Decimals, LogoURI: t.LogoURI}
+		switch t.ChainID {
+		case l2:
+			r.metadata[t.Address] = m
+			if l1Token, ok := t.BridgedAddress(l1); ok {
+				r.l1ToL2[l1Token] = t.Address
+				r.l2ToL1[t.Address] = l1Token
+			}
+			loaded++
+		case l1:
+			r.l1Metadata[t.Address] = m
+			if l2Token, ok := t.BridgedAddress(l2); ok {
+				r.l1ToL2[t.Address] = l2Token
+				r.l2ToL1[l2Token] = t.Address
+			}
+		}
+	}
+	return loaded, nil
+}
+
+func (r *Registry) baseTokenMetadata(ctx context.Context, token common.Address) (*Metadata, error) {
+	baseToken, err := r.client.BaseTokenContractAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if baseToken == utils.EthAddressInContracts {
+		return etherMetadata(token), nil
+	}
+	l1Metadata, err := r.L1Metadata(ctx, baseToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve metadata of base token: %w", err)
+	}
+	m := *l1Metadata
+	m.Address = token
+	return &m, nil
+}
+
+func (r *Registry) cached(cache map[common.Address]*Metadata, token common.Address) (*Metadata, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	m, ok := cache[token]
+	return m, ok
+}
+
+// store caches the metadata, unless the concurrent call has already cached it, and returns the cached entry.
+func (r *Registry) store(cache map[common.Address]*Metadata, m *Metadata) *Metadata {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := cache[m.Address]; ok {
+		return existing
+	}
+	cache[m.Address] = m
+	return m
+}
+
+func (r *Registry) storeMapping(l1Token, l2Token common.Address) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.l1ToL2[l1Token] = l2Token
+	r.l2ToL1[l2Token] = l1Token
+}
+
+func etherMetadata(address common.Address) *Metadata {
+	return &Metadata{Address: address, Name: "Ether", Symbol: "ETH", Decimals: utils.EtherDecimals}
+}
+
+func queryMetadata(ctx context.Context, caller bind.ContractCaller, token common.Address) (*Metadata, error) {
+	contract, err := erc20.NewIERC20Caller(token, caller)
+	if err != nil {
+		return nil, err
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	name, err := contract.Name(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query name of token %s: %w", token, err)
+	}
+	symbol, err := contract.Symbol(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query symbol of token %s: %w", token, err)
+	}
+	decimals, err := contract.Decimals(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query decimals of token %s: %w", token, err)
+	}
+	return &Metadata{Address: token, Name: name, Symbol: symbol, Decimals: decimals}, nil
+}
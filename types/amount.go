@@ -0,0 +1,342 @@
+package types
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+// AmountEncoding specifies how big integer values (amounts, fees, gas values, etc.)
+// are represented when structures are marshaled to JSON.
+type AmountEncoding uint8
+
+const (
+	// AmountEncodingHex represents amounts as 0x-prefixed hex strings. This is the encoding used
+	// by the JSON-RPC API and the default encoding of all structures.
+	AmountEncodingHex AmountEncoding = iota
+	// AmountEncodingDecimal represents amounts as decimal strings, e.g. "1000000000000000000".
+	AmountEncodingDecimal
+	// AmountEncodingNumber represents amounts as JSON numbers. Note that many JSON parsers,
+	// including JavaScript ones, lose precision for values greater than 2^53.
+	AmountEncodingNumber
+)
+
+var (
+	bigIntType     = reflect.TypeOf(big.Int{})
+	hexutilBigType = reflect.TypeOf(hexutil.Big{})
+	textMarshaler  = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// MarshalJSONWithAmountEncoding returns the JSON encoding of v, in which all big integer values
+// (big.Int and hexutil.Big fields) are encoded according to the given amount encoding.
+// All other values are encoded the same way as with json.Marshal.
+// It is intended for exposing SDK structures via REST APIs whose consumers
+// cannot handle hex encoded amounts.
+func MarshalJSONWithAmountEncoding(v interface{}, enc AmountEncoding) ([]byte, error) {
+	if enc == AmountEncodingHex {
+		return json.Marshal(v)
+	}
+	e := amountEncoder{enc: enc, cache: make(map[reflect.Type]bool)}
+	out, err := e.encode(reflect.ValueOf(v))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(out)
+}
+
+// WithAmountEncoding wraps v so that its big integer values are encoded according to the given
+// amount encoding when it is marshaled to JSON, e.g. using json.NewEncoder.
+func WithAmountEncoding(v interface{}, enc AmountEncoding) json.Marshaler {
+	return amountJSON{value: v, enc: enc}
+}
+
+type amountJSON struct {
+	value interface{}
+	enc   AmountEncoding
+}
+
+func (a amountJSON) MarshalJSON() ([]byte, error) {
+	return MarshalJSONWithAmountEncoding(a.value, a.enc)
+}
+
+type amountEncoder struct {
+	enc   AmountEncoding
+	cache map[reflect.Type]bool
+}
+
+func (e *amountEncoder) encode(v reflect.Value) (interface{}, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+	if amount, ok := asBigInt(v); ok {
+		return e.encodeAmount(amount), nil
+	}
+	if !e.containsAmount(v.Type()) {
+		return marshalRaw(v)
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return e.encode(v.Elem())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, nil
+		}
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			item, err := e.encode(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		items := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, err := mapKey(iter.Key())
+			if err != nil {
+				return nil, err
+			}
+			item, err := e.encode(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			items[key] = item
+		}
+		return items, nil
+	case reflect.Struct:
+		return e.encodeStruct(v)
+	default:
+		return marshalRaw(v)
+	}
+}
+
+// encodeStruct marshals the structure using its standard (or custom) JSON marshaller and afterwards
+// replaces the values of the fields which contain amounts. This way, custom marshallers of the
+// structure and of its embedded structures are respected.
+func (e *amountEncoder) encodeStruct(v reflect.Value) (interface{}, error) {
+	raw, err := marshalRaw(v)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := decodeObject(raw)
+	if err != nil || obj == nil {
+		return raw, err
+	}
+	if err = e.replaceFields(v, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (e *amountEncoder) replaceFields(v reflect.Value, obj *orderedObject) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, ok := jsonFieldName(f)
+		if !ok || !e.containsAmount(f.Type) {
+			continue
+		}
+		fv := v.Field(i)
+		if f.Anonymous && name == "" {
+			// Fields of embedded structures are promoted to the outer object.
+			for fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					break
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				if err := e.replaceFields(fv, obj); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if _, exists := obj.values[name]; !exists {
+			continue
+		}
+		value, err := e.encode(fv)
+		if err != nil {
+			return err
+		}
+		obj.values[name] = value
+	}
+	return nil
+}
+
+func (e *amountEncoder) encodeAmount(amount *big.Int) interface{} {
+	if amount == nil {
+		return nil
+	}
+	switch e.enc {
+	case AmountEncodingDecimal:
+		return amount.String()
+	case AmountEncodingNumber:
+		return json.Number(amount.String())
+	default:
+		return (*hexutil.Big)(amount)
+	}
+}
+
+// containsAmount reports whether the values of the given type can contain big integers.
+func (e *amountEncoder) containsAmount(t reflect.Type) bool {
+	if contains, ok := e.cache[t]; ok {
+		return contains
+	}
+	// Prevent infinite recursion for recursive types.
+	e.cache[t] = false
+	contains := false
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		contains = e.containsAmount(t.Elem())
+	case reflect.Map:
+		contains = e.containsAmount(t.Elem())
+	case reflect.Interface:
+		contains = true
+	case reflect.Struct:
+		if t == bigIntType || t == hexutilBigType {
+			contains = true
+			break
+		}
+		for i := 0; i < t.NumField(); i++ {
+			if _, ok := jsonFieldName(t.Field(i)); ok && e.containsAmount(t.Field(i).Type) {
+				contains = true
+				break
+			}
+		}
+	}
+	e.cache[t] = contains
+	return contains
+}
+
+func asBigInt(v reflect.Value) (*big.Int, bool) {
+	t := v.Type()
+	if t.Kind() == reflect.Pointer {
+		if t.Elem() != bigIntType && t.Elem() != hexutilBigType {
+			return nil, false
+		}
+		if v.IsNil() {
+			return nil, true
+		}
+		v = v.Elem()
+		t = t.Elem()
+	}
+	switch t {
+	case bigIntType:
+		amount := v.Interface().(big.Int)
+		return &amount, true
+	case hexutilBigType:
+		amount := v.Interface().(hexutil.Big)
+		return amount.ToInt(), true
+	}
+	return nil, false
+}
+
+// jsonFieldName returns the JSON name of the field as specified by its tag,
+// and reports whether the field is marshaled at all.
+func jsonFieldName(f reflect.StructField) (string, bool) {
+	if !f.IsExported() && !f.Anonymous {
+		return "", false
+	}
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name, true
+}
+
+func mapKey(k reflect.Value) (string, error) {
+	if k.Type().Implements(textMarshaler) {
+		b, err := k.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(b), err
+	}
+	if k.Kind() == reflect.String {
+		return k.String(), nil
+	}
+	return fmt.Sprint(k.Interface()), nil
+}
+
+// marshalRaw marshals the value using json.Marshal. The value is marshaled through a pointer,
+// so that marshallers defined on pointer receivers are used as well.
+func marshalRaw(v reflect.Value) (json.RawMessage, error) {
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	b, err := json.Marshal(p.Interface())
+	return b, err
+}
+
+// orderedObject is a JSON object that preserves the order of its keys.
+type orderedObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// decodeObject decodes the JSON object preserving the order of its keys.
+// It returns nil if the input is not a JSON object.
+func decodeObject(raw []byte) (*orderedObject, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, nil
+	}
+	obj := &orderedObject{values: make(map[string]interface{})}
+	for dec.More() {
+		tok, err = dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key := tok.(string)
+		var value json.RawMessage
+		if err = dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		if _, exists := obj.values[key]; !exists {
+			obj.keys = append(obj.keys, key)
+		}
+		obj.values[key] = value
+	}
+	return obj, nil
+}
+
+func (o *orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		v, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
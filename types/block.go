@@ -1,7 +1,9 @@
 package types
 
 import (
+	"encoding/json"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"math/big"
 	"time"
@@ -71,3 +73,31 @@ type BlockDetails struct {
 	Status        string      `json:"status"`
 	Timestamp     uint        `json:"timestamp"`
 }
+
+// RawBlockTransaction is the transaction of the L2 block in the format of the node, as returned by
+// zks_getRawBlockTransactions.
+type RawBlockTransaction struct {
+	// CommonData contains the data specific to the type of the transaction: the L1 transaction (priority
+	// operation), the L2 transaction or the protocol upgrade. It is keyed by "L1", "L2" or "ProtocolUpgrade".
+	CommonData map[string]json.RawMessage `json:"common_data"`
+	Execute    struct {
+		ContractAddress *common.Address `json:"contractAddress"` // Nil for contract creations.
+		Calldata        hexutil.Bytes   `json:"calldata"`
+		Value           *hexutil.Big    `json:"value"`
+		FactoryDeps     []hexutil.Bytes `json:"factoryDeps"`
+	} `json:"execute"`
+	ReceivedTimestampMs uint64         `json:"received_timestamp_ms"`
+	RawBytes            *hexutil.Bytes `json:"raw_bytes"` // The signed transaction, nil for L1 transactions.
+}
+
+// ProtocolVersion contains the information about the version of the protocol, as returned by
+// zks_getProtocolVersion.
+type ProtocolVersion struct {
+	MinorVersion           uint16       `json:"minorVersion"`
+	Timestamp              uint64       `json:"timestamp"` // Time from which the version is used.
+	BootloaderCodeHash     common.Hash  `json:"bootloaderCodeHash"`
+	DefaultAccountCodeHash common.Hash  `json:"defaultAccountCodeHash"`
+	EvmEmulatorCodeHash    *common.Hash `json:"evmEmulatorCodeHash"` // Nil if the EVM emulator is not supported.
+	// Hash of the L2 transaction upgrading the system contracts to the version, nil if there has been none.
+	L2SystemUpgradeTxHash *common.Hash `json:"l2SystemUpgradeTxHash"`
+}
@@ -10,6 +10,10 @@ import (
 type BridgeContracts struct {
 	L1Erc20DefaultBridge common.Address `json:"l1Erc20DefaultBridge"` // Default L1Bridge contract address.
 	L2Erc20DefaultBridge common.Address `json:"l2Erc20DefaultBridge"` // Default L2Bridge contract address.
+	// Default L1 shared bridge contract address, used through the Bridgehub. Empty if not supported by the chain.
+	L1SharedDefaultBridge common.Address `json:"l1SharedDefaultBridge"`
+	// Default L2 shared bridge contract address. Empty if not supported by the chain.
+	L2SharedDefaultBridge common.Address `json:"l2SharedDefaultBridge"`
 }
 
 // L1BridgeContracts represents the L1 bridge contracts.
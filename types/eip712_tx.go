@@ -8,8 +8,10 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/zksync-sdk/zksync2-go/eip712"
 	"math/big"
 )
 
@@ -87,6 +89,121 @@ func (tx *Transaction712) RLPValues(sig []byte) ([]byte, error) {
 	return append([]byte{0x71}, res...), nil
 }
 
+// ParseEip712Transaction decodes the raw EIP-712 transaction, as returned by Transaction712.RLPValues
+// and submitted using eth_sendRawTransaction, including the 0x71 type prefix. The signature of the transaction
+// is returned in Meta.CustomSignature, so that the transaction is re-encoded unchanged by RLPValues(nil).
+// The signature which is provided as the legacy v, r and s fields instead is converted to the 65-byte form.
+func ParseEip712Transaction(raw []byte) (*Transaction712, error) {
+	if len(raw) == 0 || raw[0] != 0x71 {
+		return nil, errors.New("not an EIP-712 transaction: invalid type prefix")
+	}
+	var txRLP struct {
+		Nonce                uint64
+		MaxPriorityFeePerGas *big.Int
+		MaxFeePerGas         *big.Int
+		GasLimit             *big.Int
+		To                   *common.Address `rlp:"nil"`
+		Value                *big.Int
+		Data                 []byte
+		// Either the chain ID followed by empty values, or the legacy signature.
+		V               *big.Int
+		R               []byte
+		S               []byte
+		ChainID         *big.Int
+		From            *common.Address `rlp:"nil"`
+		GasPerPubdata   *big.Int
+		FactoryDeps     [][]byte
+		CustomSignature []byte
+		PaymasterParams rlp.RawValue
+	}
+	if err := rlp.DecodeBytes(raw[1:], &txRLP); err != nil {
+		return nil, fmt.Errorf("failed to decode RLP bytes: %w", err)
+	}
+	tx := &Transaction712{
+		Nonce:     new(big.Int).SetUint64(txRLP.Nonce),
+		GasTipCap: txRLP.MaxPriorityFeePerGas,
+		GasFeeCap: txRLP.MaxFeePerGas,
+		Gas:       txRLP.GasLimit,
+		To:        txRLP.To,
+		Value:     txRLP.Value,
+		Data:      txRLP.Data,
+		ChainID:   txRLP.ChainID,
+		From:      txRLP.From,
+		Meta: &Eip712Meta{
+			GasPerPubdata:   (*hexutil.Big)(txRLP.GasPerPubdata),
+			CustomSignature: txRLP.CustomSignature,
+		},
+	}
+	for _, dep := range txRLP.FactoryDeps {
+		tx.Meta.FactoryDeps = append(tx.Meta.FactoryDeps, dep)
+	}
+	if len(tx.Meta.CustomSignature) == 0 && len(txRLP.R) > 0 && len(txRLP.S) > 0 {
+		if len(txRLP.R) > 32 || len(txRLP.S) > 32 || txRLP.V == nil || txRLP.V.Cmp(big.NewInt(1)) > 0 {
+			return nil, errors.New("invalid legacy signature")
+		}
+		sig := make([]byte, 65)
+		copy(sig[32-len(txRLP.R):32], txRLP.R)
+		copy(sig[64-len(txRLP.S):64], txRLP.S)
+		sig[64] = byte(txRLP.V.Uint64()) + 27
+		tx.Meta.CustomSignature = sig
+	}
+	params, err := parsePaymasterParams(txRLP.PaymasterParams)
+	if err != nil {
+		return nil, err
+	}
+	tx.Meta.PaymasterParams = params
+	return tx, nil
+}
+
+// parsePaymasterParams decodes the RLP-encoded paymaster parameters, which are the empty list
+// if the transaction does not use the paymaster.
+func parsePaymasterParams(raw rlp.RawValue) (*PaymasterParams, error) {
+	content, _, err := rlp.SplitList(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode paymaster params: %w", err)
+	}
+	if len(content) == 0 {
+		return nil, nil
+	}
+	var params PaymasterParams
+	if err = rlp.DecodeBytes(raw, &params); err != nil {
+		return nil, fmt.Errorf("failed to decode paymaster params: %w", err)
+	}
+	return &params, nil
+}
+
+// RecoverSender returns the address of the account which has signed the transaction, recovered from
+// the ECDSA signature in Meta.CustomSignature. For smart accounts, which use custom signatures,
+// the recovered address generally differs from the sender, or the recovery fails.
+func (tx *Transaction712) RecoverSender() (common.Address, error) {
+	if tx.Meta == nil || len(tx.Meta.CustomSignature) == 0 {
+		return common.Address{}, errors.New("transaction is not signed")
+	}
+	if tx.ChainID == nil {
+		return common.Address{}, errors.New("chain ID of transaction is not set")
+	}
+	if tx.From == nil {
+		return common.Address{}, errors.New("sender of transaction is not set")
+	}
+	return eip712.RecoverTypedDataSigner(eip712.ZkSyncEraEIP712Domain(tx.ChainID.Int64()), tx, tx.Meta.CustomSignature)
+}
+
+// Hash returns the hash under which the signed transaction is known by the network, which commits to both
+// the EIP-712 hash of the transaction and its signature in Meta.CustomSignature.
+func (tx *Transaction712) Hash() (common.Hash, error) {
+	if tx.Meta == nil || len(tx.Meta.CustomSignature) == 0 {
+		return common.Hash{}, errors.New("transaction is not signed")
+	}
+	if tx.ChainID == nil {
+		return common.Hash{}, errors.New("chain ID of transaction is not set")
+	}
+	signedHash, err := eip712.HashTypedData(eip712.ZkSyncEraEIP712Domain(tx.ChainID.Int64()), tx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(signedHash, crypto.Keccak256(tx.Meta.CustomSignature)), nil
+}
+
 func (tx *Transaction712) EIP712Type() string {
 	return "Transaction"
 }
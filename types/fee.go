@@ -1,6 +1,9 @@
 package types
 
-import "github.com/ethereum/go-ethereum/common/hexutil"
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
 
 // Fee represents the transaction fee parameters.
 type Fee struct {
@@ -10,3 +13,53 @@ type Fee struct {
 	MaxFeePerGas         *hexutil.Big `json:"max_fee_per_gas"`          // EIP-1559 fee cap per gas.
 	MaxPriorityFeePerGas *hexutil.Big `json:"max_priority_fee_per_gas"` // EIP-1559 tip per gas.
 }
+
+// FeeParams contains the parameters of the fee model of the network, as returned by zks_getFeeParams.
+// Exactly one version of the parameters is set.
+type FeeParams struct {
+	V1 *FeeParamsV1 `json:"V1,omitempty"` // Parameters of the legacy fee model, used before protocol version 1.4.1.
+	V2 *FeeParamsV2 `json:"V2,omitempty"` // Parameters of the fee model with independent pubdata pricing.
+}
+
+// FeeParamsV1 contains the parameters of the legacy fee model.
+type FeeParamsV1 struct {
+	Config struct {
+		MinimalL2GasPrice *big.Int `json:"minimal_l2_gas_price"`
+	} `json:"config"`
+	L1GasPrice *big.Int `json:"l1_gas_price"`
+}
+
+// FeeParamsV2 contains the parameters of the fee model with independent pubdata pricing.
+type FeeParamsV2 struct {
+	Config          FeeModelConfigV2          `json:"config"`
+	L1GasPrice      *big.Int                  `json:"l1_gas_price"`               // Price of L1 gas in wei.
+	L1PubdataPrice  *big.Int                  `json:"l1_pubdata_price"`           // Price of publishing a byte of pubdata on L1 in wei.
+	ConversionRatio *BaseTokenConversionRatio `json:"conversion_ratio,omitempty"` // Ratio of ETH to the base token.
+}
+
+// FeeModelConfigV2 contains the configuration of the fee model of the operator.
+type FeeModelConfigV2 struct {
+	MinimalL2GasPrice *big.Int `json:"minimal_l2_gas_price"` // Minimal price of L2 gas, covering the computation.
+	// ComputeOverheadPart is the part of the batch overhead covered by the computation, between 0 and 1.
+	ComputeOverheadPart float64 `json:"compute_overhead_part"`
+	// PubdataOverheadPart is the part of the batch overhead covered by the pubdata, between 0 and 1.
+	PubdataOverheadPart float64 `json:"pubdata_overhead_part"`
+	BatchOverheadL1Gas  uint64  `json:"batch_overhead_l1_gas"` // L1 gas spent on committing, proving and executing the batch.
+	MaxGasPerBatch      uint64  `json:"max_gas_per_batch"`
+	MaxPubdataPerBatch  uint64  `json:"max_pubdata_per_batch"`
+}
+
+// BaseTokenConversionRatio is the ratio used to convert the prices in ETH to the prices in the base token
+// of the chain: price in base token = price in ETH * Numerator / Denominator.
+type BaseTokenConversionRatio struct {
+	Numerator   *big.Int `json:"numerator"`
+	Denominator *big.Int `json:"denominator"`
+}
+
+// BatchFeeInput contains the prices used by the sequencer for the current L1 batch, as returned by
+// zks_getBatchFeeInput.
+type BatchFeeInput struct {
+	L1GasPrice       *hexutil.Big `json:"l1_gas_price"`       // Price of L1 gas in wei.
+	FairL2GasPrice   *hexutil.Big `json:"fair_l2_gas_price"`  // Price of L2 gas covering the computation.
+	FairPubdataPrice *hexutil.Big `json:"fair_pubdata_price"` // Price of publishing a byte of pubdata.
+}
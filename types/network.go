@@ -170,3 +170,18 @@ type TransactionDetails struct {
 	ReceivedAt       time.Time      `json:"receivedAt"`
 	Status           string         `json:"status"`
 }
+
+// TransactionDetailedResult is the outcome of the transaction execution in the sandbox, returned by
+// zks_sendRawTransactionWithDetailedOutput along with the hash of the sent transaction.
+type TransactionDetailedResult struct {
+	TransactionHash common.Hash  `json:"transactionHash"`
+	StorageLogs     []StorageLog `json:"storageLogs"` // Storage slots written by the transaction.
+	Events          []Log        `json:"events"`      // Events emitted by the transaction.
+}
+
+// StorageLog is the write of the storage slot.
+type StorageLog struct {
+	Address      common.Address `json:"address"`
+	Key          common.Hash    `json:"key"`
+	WrittenValue common.Hash    `json:"writtenValue"`
+}
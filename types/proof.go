@@ -8,3 +8,17 @@ type MessageProof struct {
 	Proof []common.Hash `json:"proof"`
 	Root  common.Hash   `json:"root"`
 }
+
+// StorageProof contains the Merkle proofs of the storage slots of the account, as returned by zks_getProof.
+type StorageProof struct {
+	Address      common.Address      `json:"address"`
+	StorageProof []StorageProofEntry `json:"storageProof"`
+}
+
+// StorageProofEntry contains the Merkle proof of the storage slot against the root hash of the L1 batch.
+type StorageProofEntry struct {
+	Key   common.Hash   `json:"key"`
+	Proof []common.Hash `json:"proof"`
+	Value common.Hash   `json:"value"`
+	Index uint64        `json:"index"` // Enumeration index of the slot, zero if the slot has not been written.
+}
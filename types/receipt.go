@@ -0,0 +1,197 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// l1MessengerAddress is the address of the L1Messenger system contract, which sends messages to L1.
+	l1MessengerAddress = common.HexToAddress("0x0000000000000000000000000000000000008008")
+	// l1MessageSentTopic is the topic of L1MessageSent(address,bytes32,bytes) event emitted by L1Messenger.
+	l1MessageSentTopic = crypto.Keccak256Hash([]byte("L1MessageSent(address,bytes32,bytes)"))
+)
+
+// ErrL1MessageNotFound is returned when the receipt does not contain the requested L2 to L1 message.
+var ErrL1MessageNotFound = errors.New("L2 to L1 message not found")
+
+// KeyHash returns the key of the log as hash. For messages sent through L1Messenger, the key
+// contains the address of the sender.
+func (l *L2ToL1Log) KeyHash() common.Hash {
+	return common.HexToHash(l.Key)
+}
+
+// ValueHash returns the value of the log as hash. For messages sent through L1Messenger, the value
+// is the keccak256 hash of the message.
+func (l *L2ToL1Log) ValueHash() common.Hash {
+	return common.HexToHash(l.Value)
+}
+
+// IsUserLog reports whether the log carries the message sent by the account or contract through
+// L1Messenger, e.g. the withdrawal message, as opposed to the logs emitted by the system, such as
+// the status of the L1 to L2 transaction sent by the bootloader.
+func (l *L2ToL1Log) IsUserLog() bool {
+	return l.Sender == l1MessengerAddress
+}
+
+// L1Message is the message sent from L2 to L1 through L1Messenger.
+type L1Message struct {
+	Sender         common.Address // Account or contract which has sent the message.
+	Hash           common.Hash    // Keccak256 hash of the message.
+	Message        []byte         // The message.
+	Log            *Log           // L1MessageSent event emitted by L1Messenger.
+	L2ToL1Log      *L2ToL1Log     // L2 to L1 log carrying the hash of the message.
+	L2ToL1LogIndex int            // Index of L2ToL1Log in the receipt, used to prove the inclusion of the message.
+}
+
+// UserL2ToL1Logs returns the L2 to L1 logs carrying the messages sent through L1Messenger.
+func (r *Receipt) UserL2ToL1Logs() []*L2ToL1Log {
+	var logs []*L2ToL1Log
+	for _, l := range r.L2ToL1Logs {
+		if l.IsUserLog() {
+			logs = append(logs, l)
+		}
+	}
+	return logs
+}
+
+// SystemL2ToL1Logs returns the L2 to L1 logs emitted by the system, such as the logs carrying
+// the status of L1 to L2 transactions.
+func (r *Receipt) SystemL2ToL1Logs() []*L2ToL1Log {
+	var logs []*L2ToL1Log
+	for _, l := range r.L2ToL1Logs {
+		if !l.IsUserLog() {
+			logs = append(logs, l)
+		}
+	}
+	return logs
+}
+
+// L1Messages returns the messages sent to L1 by the transaction, in the order they were sent. Each message
+// is matched with the L2 to L1 log carrying it, by the sender in the key of the log and the hash of the message
+// in its value, so that the inclusion of the message can be proven. L2 to L1 logs which do not carry any
+// of the messages are skipped.
+func (r *Receipt) L1Messages() ([]*L1Message, error) {
+	var messages []*L1Message
+	matched := make(map[int]bool)
+	for _, event := range r.Logs {
+		if event.Address != l1MessengerAddress || len(event.Topics) != 3 || event.Topics[0] != l1MessageSentTopic {
+			continue
+		}
+		sender := common.BytesToAddress(event.Topics[1].Bytes())
+		hash := event.Topics[2]
+		index := -1
+		for i, l := range r.L2ToL1Logs {
+			if !matched[i] && l.IsUserLog() && l.KeyHash() == common.BytesToHash(sender.Bytes()) && l.ValueHash() == hash {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("%w: no L2 to L1 log carries message %s sent by %s", ErrL1MessageNotFound, hash, sender)
+		}
+		matched[index] = true
+		message, err := unpackL1Message(event.Data)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, &L1Message{
+			Sender:         sender,
+			Hash:           hash,
+			Message:        message,
+			Log:            event,
+			L2ToL1Log:      r.L2ToL1Logs[index],
+			L2ToL1LogIndex: index,
+		})
+	}
+	return messages, nil
+}
+
+// WithdrawalMessage returns the message with the given index among the messages sent to L1
+// by the withdrawal transaction. It is usually 0, unless the transaction has performed multiple
+// withdrawals or sent other messages.
+func (r *Receipt) WithdrawalMessage(index int) (*L1Message, error) {
+	messages, err := r.L1Messages()
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= len(messages) {
+		return nil, fmt.Errorf("%w: index %d", ErrL1MessageNotFound, index)
+	}
+	return messages[index], nil
+}
+
+// DecodedEvent is the event decoded using the contract ABI.
+type DecodedEvent struct {
+	Name string                 // Name of the event.
+	Args map[string]interface{} // Decoded indexed and non-indexed arguments of the event by their names.
+	Log  *Log                   // The log of the event.
+}
+
+// DecodeEvents decodes the events of the receipt which are defined by the contract ABI. If the address
+// is provided, only the events emitted by the contract at the address are decoded. Logs of events which
+// are not defined by the ABI are skipped.
+func (r *Receipt) DecodeEvents(contractABI abi.ABI, address *common.Address) ([]*DecodedEvent, error) {
+	var events []*DecodedEvent
+	for _, l := range r.Logs {
+		if address != nil && l.Address != *address {
+			continue
+		}
+		event, err := DecodeLog(contractABI, l)
+		if err != nil {
+			return nil, err
+		}
+		if event != nil {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// DecodeLog decodes the event of the log, which is defined by the contract ABI. It returns nil if the event
+// is not defined by the ABI.
+func DecodeLog(contractABI abi.ABI, l *Log) (*DecodedEvent, error) {
+	if len(l.Topics) == 0 {
+		return nil, nil
+	}
+	event, err := contractABI.EventByID(l.Topics[0])
+	if err != nil {
+		return nil, nil
+	}
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if len(indexed) != len(l.Topics)-1 {
+		// The event has the same signature, but different indexed arguments, e.g. ERC-20 and ERC-721 Transfer.
+		return nil, nil
+	}
+	args := make(map[string]interface{})
+	if len(l.Data) > 0 {
+		if err = contractABI.UnpackIntoMap(args, event.Name, l.Data); err != nil {
+			return nil, fmt.Errorf("failed to decode event %s: %w", event.Name, err)
+		}
+	}
+	if err = abi.ParseTopicsIntoMap(args, indexed, l.Topics[1:]); err != nil {
+		return nil, fmt.Errorf("failed to decode indexed arguments of event %s: %w", event.Name, err)
+	}
+	return &DecodedEvent{Name: event.Name, Args: args, Log: l}, nil
+}
+
+// unpackL1Message decodes the message from the data of L1MessageSent event.
+func unpackL1Message(data []byte) ([]byte, error) {
+	bytesType, err := abi.NewType("bytes", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	values, err := abi.Arguments{{Type: bytesType}}.Unpack(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode L1MessageSent event: %w", err)
+	}
+	return values[0].([]byte), nil
+}
@@ -24,6 +24,10 @@ func CreateETH() *types.Token {
 
 // Erc20DefaultBridgeData Returns the data needed for correct initialization of an L1 token counterpart on L2.
 func Erc20DefaultBridgeData(l1TokenAddress common.Address, backend bind.ContractBackend) ([]byte, error) {
+	// ETH is bridged as an ERC20 token to chains whose base token is not ETH.
+	if l1TokenAddress == EthAddress || l1TokenAddress == EthAddressInContracts {
+		return encodeBridgeData("Ether", "ETH", 18)
+	}
 	token, err := erc20.NewIERC20(l1TokenAddress, backend)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load IERC20: %w", err)
@@ -41,6 +45,11 @@ func Erc20DefaultBridgeData(l1TokenAddress common.Address, backend bind.Contract
 		return nil, err
 	}
 
+	return encodeBridgeData(name, symbol, decimals)
+}
+
+// encodeBridgeData encodes the token metadata, which is used to deploy the token on L2.
+func encodeBridgeData(name, symbol string, decimals uint8) ([]byte, error) {
 	stringAbiType, err := abi.NewType("string", "", nil)
 	if err != nil {
 		return nil, err
@@ -18,7 +18,27 @@ var (
 	BootloaderFormalAddress = common.HexToAddress("0x0000000000000000000000000000000000008001")
 	ContractDeployerAddress = common.HexToAddress("0x0000000000000000000000000000000000008006")
 	L1MessengerAddress      = common.HexToAddress("0x0000000000000000000000000000000000008008")
-	L2EthTokenAddress       = common.HexToAddress("0x000000000000000000000000000000000000800a")
+	// L2BaseTokenAddress is the address of the system contract holding balances of the base token on L2,
+	// which is ETH on ETH-based chains.
+	L2BaseTokenAddress = common.HexToAddress("0x000000000000000000000000000000000000800a")
+	// L2EthTokenAddress is the former name of L2BaseTokenAddress.
+	//
+	// Deprecated: Use L2BaseTokenAddress instead.
+	L2EthTokenAddress = L2BaseTokenAddress
+
+	AccountCodeStorageAddress = common.HexToAddress("0x0000000000000000000000000000000000008002")
+	NonceHolderAddress        = common.HexToAddress("0x0000000000000000000000000000000000008003")
+	KnownCodesStorageAddress  = common.HexToAddress("0x0000000000000000000000000000000000008004")
+	MsgValueSimulatorAddress  = common.HexToAddress("0x0000000000000000000000000000000000008009")
+	CompressorAddress         = common.HexToAddress("0x000000000000000000000000000000000000800e")
+	// EthAddressInContracts is the address used by L1 contracts (and returned by zks_getBaseTokenL1Address)
+	// to represent ETH as the base token.
+	EthAddressInContracts = common.HexToAddress("0x0000000000000000000000000000000000000001")
+
+	// Multicall3Address is the address of the Multicall3 contract, deployed on zkSync Era mainnet and testnet.
+	Multicall3Address = common.HexToAddress("0xF9cda624FBC7e059355ce98a31693d299FACd963")
+	// L1Multicall3Address is the address of the Multicall3 contract, deployed on Ethereum mainnet and testnets.
+	L1Multicall3Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
 
 	// L1ToL2AliasOffset Used for applying and undoing aliases on contract addresses during bridging from L1 to L2.
 	L1ToL2AliasOffset = common.HexToAddress("0x1111000000000000000000000000000000001111")
@@ -0,0 +1,182 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/zksync-sdk/zksync2-go/types"
+	"math/big"
+)
+
+const (
+	// TxSlotOverheadGas is the overhead charged to every transaction for occupying the slot in the batch.
+	TxSlotOverheadGas = 10_000
+	// TxMemoryOverheadGas is the overhead charged to the transaction per byte of its encoding in the bootloader memory.
+	TxMemoryOverheadGas = 10
+)
+
+// ErrUnsupportedFeeModel is returned by NewFeeEstimator when the network uses the fee model which cannot
+// be computed locally.
+var ErrUnsupportedFeeModel = errors.New("unsupported fee model")
+
+// BatchFeeInput contains the prices from which the base fee and the gas per pubdata of the batch are derived.
+type BatchFeeInput struct {
+	L1GasPrice       *big.Int // Price of L1 gas, scaled and converted to the base token.
+	FairL2GasPrice   *big.Int // Price of L2 gas covering the computation and its part of the batch overhead.
+	FairPubdataPrice *big.Int // Price of the byte of pubdata covering publishing and its part of the batch overhead.
+}
+
+// FeeEstimator implements the fee model of zkSync Era locally, so that the fees of many transactions
+// can be estimated from the fee parameters, as returned by Client.FeeParams, without an RPC call each.
+// The estimation requires the computational gas and the pubdata of the transaction to be known,
+// e.g. from the previous executions of similar transactions. The parameters change with L1 prices,
+// so the estimator should be recreated periodically.
+type FeeEstimator struct {
+	input         BatchFeeInput
+	baseFee       *big.Int
+	gasPerPubdata *big.Int
+}
+
+// FeeEstimatorOptions contains the options of FeeEstimator.
+type FeeEstimatorOptions struct {
+	// L1GasPriceScaleFactor scales the L1 gas price, protecting the estimation against the growth of L1 prices
+	// before the transaction is included. The operator uses 1.5 when estimating fees. Defaults to 1.
+	L1GasPriceScaleFactor float64
+	// L1PubdataPriceScaleFactor scales the L1 pubdata price, same as L1GasPriceScaleFactor. Defaults to 1.
+	L1PubdataPriceScaleFactor float64
+}
+
+// NewFeeEstimator creates an instance of FeeEstimator from the fee parameters. The options are optional.
+func NewFeeEstimator(params *types.FeeParams, opts *FeeEstimatorOptions) (*FeeEstimator, error) {
+	if params == nil || params.V2 == nil {
+		return nil, fmt.Errorf("%w: only fee parameters V2 are supported", ErrUnsupportedFeeModel)
+	}
+	v2 := params.V2
+	if v2.L1GasPrice == nil || v2.L1PubdataPrice == nil || v2.Config.MinimalL2GasPrice == nil {
+		return nil, errors.New("incomplete fee parameters")
+	}
+	if v2.Config.MaxGasPerBatch == 0 || v2.Config.MaxPubdataPerBatch == 0 {
+		return nil, errors.New("invalid fee model config: zero batch limits")
+	}
+	gasScale, pubdataScale := 1.0, 1.0
+	if opts != nil {
+		if opts.L1GasPriceScaleFactor > 0 {
+			gasScale = opts.L1GasPriceScaleFactor
+		}
+		if opts.L1PubdataPriceScaleFactor > 0 {
+			pubdataScale = opts.L1PubdataPriceScaleFactor
+		}
+	}
+
+	l1GasPrice := scale(v2.L1GasPrice, gasScale)
+	l1PubdataPrice := scale(v2.L1PubdataPrice, pubdataScale)
+	minimalL2GasPrice := new(big.Int).Set(v2.Config.MinimalL2GasPrice)
+	if ratio := v2.ConversionRatio; ratio != nil && ratio.Numerator != nil && ratio.Denominator != nil && ratio.Denominator.Sign() > 0 {
+		// The prices, including the minimal L2 gas price, are configured in ETH and converted to the base token.
+		l1GasPrice = convertToBaseToken(l1GasPrice, ratio)
+		l1PubdataPrice = convertToBaseToken(l1PubdataPrice, ratio)
+		minimalL2GasPrice = convertToBaseToken(minimalL2GasPrice, ratio)
+	}
+
+	// The overhead of the batch is covered by both computation and pubdata, in the configured parts,
+	// and is amortized over the maximum amount of gas and pubdata in the batch.
+	batchOverhead := new(big.Int).Mul(l1GasPrice, new(big.Int).SetUint64(v2.Config.BatchOverheadL1Gas))
+	overheadPerGas := ceilDiv(batchOverhead, new(big.Int).SetUint64(v2.Config.MaxGasPerBatch))
+	overheadPerPubdata := ceilDiv(batchOverhead, new(big.Int).SetUint64(v2.Config.MaxPubdataPerBatch))
+	input := BatchFeeInput{
+		L1GasPrice:       l1GasPrice,
+		FairL2GasPrice:   minimalL2GasPrice.Add(minimalL2GasPrice, scale(overheadPerGas, v2.Config.ComputeOverheadPart)),
+		FairPubdataPrice: new(big.Int).Add(l1PubdataPrice, scale(overheadPerPubdata, v2.Config.PubdataOverheadPart)),
+	}
+
+	// The base fee is set so that the transaction is always able to publish enough pubdata
+	// within the maximum gas per pubdata byte.
+	baseFee := ceilDiv(input.FairPubdataPrice, DefaultGasPerPubdataLimit)
+	if input.FairL2GasPrice.Cmp(baseFee) > 0 {
+		baseFee = new(big.Int).Set(input.FairL2GasPrice)
+	}
+	if baseFee.Sign() == 0 {
+		return nil, errors.New("invalid fee parameters: zero base fee")
+	}
+	return &FeeEstimator{
+		input:         input,
+		baseFee:       baseFee,
+		gasPerPubdata: ceilDiv(input.FairPubdataPrice, baseFee),
+	}, nil
+}
+
+// BatchFeeInput returns the prices from which the base fee and the gas per pubdata are derived.
+func (e *FeeEstimator) BatchFeeInput() BatchFeeInput {
+	return BatchFeeInput{
+		L1GasPrice:       new(big.Int).Set(e.input.L1GasPrice),
+		FairL2GasPrice:   new(big.Int).Set(e.input.FairL2GasPrice),
+		FairPubdataPrice: new(big.Int).Set(e.input.FairPubdataPrice),
+	}
+}
+
+// BaseFee returns the base fee of L2 gas.
+func (e *FeeEstimator) BaseFee() *big.Int {
+	return new(big.Int).Set(e.baseFee)
+}
+
+// GasPerPubdata returns the amount of gas charged for each byte of pubdata published by the transaction.
+func (e *FeeEstimator) GasPerPubdata() *big.Int {
+	return new(big.Int).Set(e.gasPerPubdata)
+}
+
+// TxOverhead returns the gas charged to the transaction for its part of the batch overhead, which depends
+// on the length of the transaction encoded in the bootloader memory.
+func (e *FeeEstimator) TxOverhead(encodedLength int) uint64 {
+	memoryOverhead := uint64(TxMemoryOverheadGas) * uint64(encodedLength)
+	if memoryOverhead > TxSlotOverheadGas {
+		return memoryOverhead
+	}
+	return TxSlotOverheadGas
+}
+
+// GasLimit returns the gas limit of the transaction which consumes the computational gas, publishes
+// the pubdata bytes, and whose encoding in the bootloader memory has the given length.
+func (e *FeeEstimator) GasLimit(computationalGas, pubdataBytes uint64, encodedLength int) *big.Int {
+	gasLimit := new(big.Int).Mul(e.gasPerPubdata, new(big.Int).SetUint64(pubdataBytes))
+	gasLimit.Add(gasLimit, new(big.Int).SetUint64(computationalGas))
+	return gasLimit.Add(gasLimit, new(big.Int).SetUint64(e.TxOverhead(encodedLength)))
+}
+
+// EstimateFee returns the fee of the transaction, as GasLimit, with the max fee per gas set to the base fee,
+// and without the priority fee, which is not used by the operator.
+func (e *FeeEstimator) EstimateFee(computationalGas, pubdataBytes uint64, encodedLength int) *types.Fee {
+	return &types.Fee{
+		GasLimit:             (*hexutil.Big)(e.GasLimit(computationalGas, pubdataBytes, encodedLength)),
+		GasPerPubdataLimit:   (*hexutil.Big)(e.GasPerPubdata()),
+		MaxFeePerGas:         (*hexutil.Big)(e.BaseFee()),
+		MaxPriorityFeePerGas: (*hexutil.Big)(big.NewInt(0)),
+	}
+}
+
+// Cost returns the total cost of the transaction in the base token, which is the gas limit multiplied
+// by the base fee.
+func (e *FeeEstimator) Cost(computationalGas, pubdataBytes uint64, encodedLength int) *big.Int {
+	return new(big.Int).Mul(e.GasLimit(computationalGas, pubdataBytes, encodedLength), e.baseFee)
+}
+
+// scale multiplies the value by the factor, truncating the result, the same as the operator.
+func scale(value *big.Int, factor float64) *big.Int {
+	if factor == 1 {
+		return new(big.Int).Set(value)
+	}
+	scaled, _ := new(big.Float).Mul(new(big.Float).SetInt(value), big.NewFloat(factor)).Int(nil)
+	return scaled
+}
+
+func convertToBaseToken(price *big.Int, ratio *types.BaseTokenConversionRatio) *big.Int {
+	converted := new(big.Int).Mul(price, ratio.Numerator)
+	return converted.Quo(converted, ratio.Denominator)
+}
+
+func ceilDiv(a, b *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(a, b, new(big.Int))
+	if remainder.Sign() > 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	return quotient
+}
@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"github.com/zksync-sdk/zksync2-go/types"
+	"math/big"
+	"testing"
+)
+
+func feeParamsV2(computeOverheadPart, pubdataOverheadPart float64, minimalL2GasPrice int64, ratio *types.BaseTokenConversionRatio) *types.FeeParams {
+	return &types.FeeParams{V2: &types.FeeParamsV2{
+		Config: types.FeeModelConfigV2{
+			MinimalL2GasPrice:   big.NewInt(minimalL2GasPrice),
+			ComputeOverheadPart: computeOverheadPart,
+			PubdataOverheadPart: pubdataOverheadPart,
+			BatchOverheadL1Gas:  800_000,
+			MaxGasPerBatch:      200_000_000,
+			MaxPubdataPerBatch:  500_000,
+		},
+		L1GasPrice:      big.NewInt(10_000_000_000),
+		L1PubdataPrice:  big.NewInt(20_000_000_000),
+		ConversionRatio: ratio,
+	}}
+}
+
+func TestNewFeeEstimator(t *testing.T) {
+	tests := []struct {
+		name              string
+		params            *types.FeeParams
+		opts              *FeeEstimatorOptions
+		wantL1GasPrice    int64
+		wantFairL2        int64
+		wantFairPubdata   int64
+		wantBaseFee       int64
+		wantGasPerPubdata int64
+	}{
+		{
+			name:              "pubdata covers overhead",
+			params:            feeParamsV2(0, 1, 25_000_000, nil),
+			wantL1GasPrice:    10_000_000_000,
+			wantFairL2:        25_000_000,
+			wantFairPubdata:   36_000_000_000,
+			wantBaseFee:       25_000_000,
+			wantGasPerPubdata: 1440,
+		},
+		{
+			name:              "overhead split between computation and pubdata",
+			params:            feeParamsV2(0.5, 0.5, 25_000_000, nil),
+			wantL1GasPrice:    10_000_000_000,
+			wantFairL2:        45_000_000,
+			wantFairPubdata:   28_000_000_000,
+			wantBaseFee:       45_000_000,
+			wantGasPerPubdata: 623,
+		},
+		{
+			name:              "base fee bounded by gas per pubdata limit",
+			params:            feeParamsV2(0, 1, 100_000, nil),
+			wantL1GasPrice:    10_000_000_000,
+			wantFairL2:        100_000,
+			wantFairPubdata:   36_000_000_000,
+			wantBaseFee:       720_000,
+			wantGasPerPubdata: 50_000,
+		},
+		{
+			name:              "scaled L1 prices",
+			params:            feeParamsV2(0, 1, 25_000_000, nil),
+			opts:              &FeeEstimatorOptions{L1GasPriceScaleFactor: 1.5, L1PubdataPriceScaleFactor: 1.5},
+			wantL1GasPrice:    15_000_000_000,
+			wantFairL2:        25_000_000,
+			wantFairPubdata:   54_000_000_000,
+			wantBaseFee:       25_000_000,
+			wantGasPerPubdata: 2160,
+		},
+		{
+			name:              "prices converted to base token",
+			params:            feeParamsV2(0, 1, 25_000_000, &types.BaseTokenConversionRatio{Numerator: big.NewInt(2), Denominator: big.NewInt(1)}),
+			wantL1GasPrice:    20_000_000_000,
+			wantFairL2:        50_000_000,
+			wantFairPubdata:   72_000_000_000,
+			wantBaseFee:       50_000_000,
+			wantGasPerPubdata: 1440,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estimator, err := NewFeeEstimator(tt.params, tt.opts)
+			if err != nil {
+				t.Fatalf("NewFeeEstimator() error = %v", err)
+			}
+			input := estimator.BatchFeeInput()
+			for _, check := range []struct {
+				name string
+				got  *big.Int
+				want int64
+			}{
+				{"L1GasPrice", input.L1GasPrice, tt.wantL1GasPrice},
+				{"FairL2GasPrice", input.FairL2GasPrice, tt.wantFairL2},
+				{"FairPubdataPrice", input.FairPubdataPrice, tt.wantFairPubdata},
+				{"BaseFee", estimator.BaseFee(), tt.wantBaseFee},
+				{"GasPerPubdata", estimator.GasPerPubdata(), tt.wantGasPerPubdata},
+			} {
+				if check.got.Cmp(big.NewInt(check.want)) != 0 {
+					t.Errorf("%s = %s, want %d", check.name, check.got, check.want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewFeeEstimatorErrors(t *testing.T) {
+	zeroLimits := feeParamsV2(0, 1, 25_000_000, nil)
+	zeroLimits.V2.Config.MaxGasPerBatch = 0
+	incomplete := feeParamsV2(0, 1, 25_000_000, nil)
+	incomplete.V2.L1PubdataPrice = nil
+	tests := []struct {
+		name   string
+		params *types.FeeParams
+	}{
+		{name: "nil parameters", params: nil},
+		{name: "legacy parameters", params: &types.FeeParams{V1: &types.FeeParamsV1{}}},
+		{name: "zero batch limits", params: zeroLimits},
+		{name: "incomplete parameters", params: incomplete},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewFeeEstimator(tt.params, nil); err == nil {
+				t.Error("NewFeeEstimator() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestFeeEstimatorGasLimit(t *testing.T) {
+	estimator, err := NewFeeEstimator(feeParamsV2(0, 1, 25_000_000, nil), nil)
+	if err != nil {
+		t.Fatalf("NewFeeEstimator() error = %v", err)
+	}
+	tests := []struct {
+		name             string
+		computationalGas uint64
+		pubdataBytes     uint64
+		encodedLength    int
+		want             int64
+	}{
+		{name: "slot overhead", computationalGas: 100_000, pubdataBytes: 200, encodedLength: 500, want: 398_000},
+		{name: "memory overhead", computationalGas: 100_000, pubdataBytes: 200, encodedLength: 2000, want: 408_000},
+		{name: "no pubdata", computationalGas: 50_000, pubdataBytes: 0, encodedLength: 0, want: 60_000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := estimator.GasLimit(tt.computationalGas, tt.pubdataBytes, tt.encodedLength)
+			if got.Cmp(big.NewInt(tt.want)) != 0 {
+				t.Errorf("GasLimit() = %s, want %d", got, tt.want)
+			}
+			cost := estimator.Cost(tt.computationalGas, tt.pubdataBytes, tt.encodedLength)
+			if want := new(big.Int).Mul(big.NewInt(tt.want), estimator.BaseFee()); cost.Cmp(want) != 0 {
+				t.Errorf("Cost() = %s, want %s", cost, want)
+			}
+		})
+	}
+}
@@ -24,6 +24,9 @@ var (
 	L1RecommendedMinEthDepositGasLimit = big.NewInt(200000)
 )
 
+// ScaleGasLimit multiplies the gas limit by 1.2 in place.
+//
+// Deprecated: Deprecated in favor of accounts.GasScaler, configured using Wallet.SetGasScaler.
 func ScaleGasLimit(gasLimit *big.Int) *big.Int {
 	// Currently, for some reason the SDK may return slightly smaller L1 gas limit than required for initiating L1->L2
 	// transaction. We use a coefficient to ensure that the transaction will be accepted.
@@ -1,16 +1,63 @@
 package utils
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/zksync-sdk/zksync2-go/contracts/paymasterflow"
 	"github.com/zksync-sdk/zksync2-go/types"
 	"log"
+	"math/big"
 	"strings"
 )
 
+// ExchangeRateScale is the scale of exchange rates returned by PaymasterExchangeRate.
+var ExchangeRateScale = big.NewInt(1e18)
+
+// PaymasterExchangeRate returns the amount of the token, scaled by ExchangeRateScale, which the paymaster
+// charges for 1 wei of the fee paid in the base token.
+type PaymasterExchangeRate func(ctx context.Context, token common.Address) (*big.Int, error)
+
+// FixedExchangeRate returns PaymasterExchangeRate which always returns the given rate,
+// e.g. ExchangeRateScale for the testnet paymaster which charges the fee 1:1.
+func FixedExchangeRate(rate *big.Int) PaymasterExchangeRate {
+	return func(_ context.Context, _ common.Address) (*big.Int, error) {
+		return new(big.Int).Set(rate), nil
+	}
+}
+
+// OnChainExchangeRate returns PaymasterExchangeRate which queries the paymaster using the view method
+// with the given name, accepting the token address and returning the exchange rate as uint256,
+// e.g. "getExchangeRate" for the method `getExchangeRate(address) returns (uint256)`.
+func OnChainExchangeRate(caller bind.ContractCaller, paymaster common.Address, method string) PaymasterExchangeRate {
+	selector := crypto.Keccak256([]byte(method + "(address)"))[:4]
+	return func(ctx context.Context, token common.Address) (*big.Int, error) {
+		data := append(append([]byte{}, selector...), common.LeftPadBytes(token.Bytes(), 32)...)
+		result, err := caller.CallContract(ctx, ethereum.CallMsg{To: &paymaster, Data: data}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query paymaster exchange rate: %w", err)
+		}
+		if len(result) != 32 {
+			return nil, fmt.Errorf("invalid paymaster exchange rate returned by %s: %x", method, result)
+		}
+		return new(big.Int).SetBytes(result), nil
+	}
+}
+
+// MinimalAllowance returns the allowance of the token needed to pay the fee at the exchange rate, scaled by
+// ExchangeRateScale. The allowance is rounded up, so that the fee is always covered.
+func MinimalAllowance(fee, rate *big.Int) *big.Int {
+	allowance := new(big.Int).Mul(fee, rate)
+	allowance.Add(allowance, new(big.Int).Sub(ExchangeRateScale, big.NewInt(1)))
+	return allowance.Div(allowance, ExchangeRateScale)
+}
+
 var paymasterFlowAbi abi.ABI
 
 func init() {
@@ -29,6 +76,19 @@ func GetApprovalBasedPaymasterInput(paymasterInput types.ApprovalBasedPaymasterI
 		paymasterInput.InnerInput)
 }
 
+// GetApprovalBasedPaymasterInputForFee returns encoded input for an approval-based paymaster, whose minimal
+// allowance is computed from the estimated fee and the exchange rate of the paymaster, instead of
+// paymasterInput.MinimalAllowance.
+func GetApprovalBasedPaymasterInputForFee(ctx context.Context, paymasterInput types.ApprovalBasedPaymasterInput,
+	fee *big.Int, rate PaymasterExchangeRate) ([]byte, error) {
+	exchangeRate, err := rate(ctx, paymasterInput.Token)
+	if err != nil {
+		return nil, err
+	}
+	paymasterInput.MinimalAllowance = MinimalAllowance(fee, exchangeRate)
+	return GetApprovalBasedPaymasterInput(paymasterInput)
+}
+
 // GetGeneralPaymasterInput returns encoded input for a general-based paymaster.
 func GetGeneralPaymasterInput(paymasterInput types.GeneralPaymasterInput) ([]byte, error) {
 	return paymasterFlowAbi.Pack("general", paymasterInput)
@@ -66,3 +126,94 @@ func GetPaymasterParams(paymasterAddress common.Address, paymasterInput types.Pa
 		return &types.PaymasterParams{}, fmt.Errorf("cannot recognize given paymaster input type: %s", paymasterInput.GetType())
 	}
 }
+
+// PaymasterFlow is the flow of the paymaster, classified by the selector of the paymaster input.
+type PaymasterFlow string
+
+const (
+	PaymasterFlowGeneral       PaymasterFlow = "General"       // The input is encoded using IPaymasterFlow.general.
+	PaymasterFlowApprovalBased PaymasterFlow = "ApprovalBased" // The input is encoded using IPaymasterFlow.approvalBased.
+	PaymasterFlowUnknown       PaymasterFlow = "Unknown"       // The input is not encoded using IPaymasterFlow.
+)
+
+// DecodedPaymasterParams contains the paymaster parameters of the transaction along with their decoded input.
+type DecodedPaymasterParams struct {
+	Paymaster common.Address
+	Flow      PaymasterFlow
+	// Token used to pay the fee, set for the approval-based flow.
+	Token common.Address
+	// Minimal allowance of the Token towards the paymaster, set for the approval-based flow.
+	MinimalAllowance *big.Int
+	// Additional payload passed to the paymaster, not set for the unknown flow.
+	InnerInput []byte
+	// Raw paymaster input.
+	Input []byte
+}
+
+// DecodePaymasterParams decodes and classifies the input of the paymaster parameters.
+func DecodePaymasterParams(params *types.PaymasterParams) (*DecodedPaymasterParams, error) {
+	decoded := &DecodedPaymasterParams{
+		Paymaster: params.Paymaster,
+		Flow:      PaymasterFlowUnknown,
+		Input:     params.PaymasterInput,
+	}
+	if len(params.PaymasterInput) < 4 {
+		return decoded, nil
+	}
+	method, err := paymasterFlowAbi.MethodById(params.PaymasterInput[:4])
+	if err != nil {
+		return decoded, nil
+	}
+	args, err := method.Inputs.Unpack(params.PaymasterInput[4:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s paymaster input: %w", method.Name, err)
+	}
+	switch method.Name {
+	case "general":
+		decoded.Flow = PaymasterFlowGeneral
+		decoded.InnerInput = args[0].([]byte)
+	case "approvalBased":
+		decoded.Flow = PaymasterFlowApprovalBased
+		decoded.Token = args[0].(common.Address)
+		decoded.MinimalAllowance = args[1].(*big.Int)
+		decoded.InnerInput = args[2].([]byte)
+	}
+	return decoded, nil
+}
+
+// DecodePaymasterParamsFromRawTransaction extracts and decodes the paymaster parameters of the raw EIP-712
+// transaction, e.g. for analytics and debugging. It returns nil if the transaction does not use a paymaster.
+// Since the transactions returned by the node do not contain the paymaster parameters, the raw transaction
+// has to be used, e.g. the one passed to eth_sendRawTransaction.
+func DecodePaymasterParamsFromRawTransaction(rawTx []byte) (*DecodedPaymasterParams, error) {
+	if len(rawTx) == 0 || rawTx[0] != 0x71 {
+		return nil, errors.New("transaction is not EIP-712 transaction")
+	}
+	fields, _, err := rlp.SplitList(rawTx[1:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode EIP-712 transaction: %w", err)
+	}
+	// Paymaster parameters are the 16th field of the transaction.
+	var element, content []byte
+	for i := 0; i < 16; i++ {
+		if len(fields) == 0 {
+			return nil, errors.New("failed to decode EIP-712 transaction: too few fields")
+		}
+		_, c, rest, err := rlp.Split(fields)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode EIP-712 transaction: %w", err)
+		}
+		element, content, fields = fields[:len(fields)-len(rest)], c, rest
+	}
+	if len(content) == 0 {
+		return nil, nil
+	}
+	var params struct {
+		Paymaster      common.Address
+		PaymasterInput []byte
+	}
+	if err = rlp.DecodeBytes(element, &params); err != nil {
+		return nil, fmt.Errorf("failed to decode paymaster params: %w", err)
+	}
+	return DecodePaymasterParams(&types.PaymasterParams{Paymaster: params.Paymaster, PaymasterInput: params.PaymasterInput})
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/zksync-sdk/zksync2-go/types"
+)
+
+// ErrInvalidLogProof is returned when the Merkle proof of the L2 to L1 log does not lead to the expected root.
+var ErrInvalidLogProof = errors.New("invalid L2 to L1 log proof")
+
+// HashL2ToL1Log returns the leaf of the L2 to L1 log in the Merkle tree of the batch, which is the keccak256
+// hash of the packed log: shard ID (1 byte), service flag (1 byte), number of the transaction in the batch
+// (2 bytes), sender (20 bytes), key (32 bytes) and value (32 bytes). The number of the transaction in the batch
+// is the L1BatchTxIndex of the receipt.
+func HashL2ToL1Log(log *types.L2ToL1Log, txNumberInBatch uint16) common.Hash {
+	packed := make([]byte, 0, 88)
+	shardID := byte(0)
+	if log.ShardId != nil {
+		shardID = byte(*log.ShardId)
+	}
+	isService := byte(0)
+	if log.IsService {
+		isService = 1
+	}
+	packed = append(packed, shardID, isService)
+	packed = binary.BigEndian.AppendUint16(packed, txNumberInBatch)
+	packed = append(packed, log.Sender.Bytes()...)
+	packed = append(packed, log.KeyHash().Bytes()...)
+	packed = append(packed, log.ValueHash().Bytes()...)
+	return crypto.Keccak256Hash(packed)
+}
+
+// ComputeLogProofRoot computes the root of the Merkle tree of L2 to L1 logs from the hash of the log,
+// its index in the tree and the proof, as returned by Client.LogProof. The nodes of the tree are hashed
+// as keccak256(left || right), the same as by the Merkle library of zkSync contracts.
+func ComputeLogProofRoot(proof []common.Hash, logHash common.Hash, index int) (common.Hash, error) {
+	if len(proof) == 0 || len(proof) >= 256 {
+		return common.Hash{}, fmt.Errorf("%w: invalid length of proof: %d", ErrInvalidLogProof, len(proof))
+	}
+	if index < 0 || len(proof) < 63 && index >= 1<<len(proof) {
+		return common.Hash{}, fmt.Errorf("%w: index %d out of range", ErrInvalidLogProof, index)
+	}
+	current := logHash
+	for _, sibling := range proof {
+		if index%2 == 0 {
+			current = crypto.Keccak256Hash(current.Bytes(), sibling.Bytes())
+		} else {
+			current = crypto.Keccak256Hash(sibling.Bytes(), current.Bytes())
+		}
+		index /= 2
+	}
+	return current, nil
+}
+
+// VerifyLogProof verifies that the proof of the log with the hash, as returned by HashL2ToL1Log, at the index
+// in the Merkle tree of L2 to L1 logs leads to the root, which allows the proof to be validated locally before
+// it is submitted to L1, e.g. by finalizing the withdrawal.
+func VerifyLogProof(proof []common.Hash, logHash, root common.Hash, index int) error {
+	computed, err := ComputeLogProofRoot(proof, logHash, index)
+	if err != nil {
+		return err
+	}
+	if computed != root {
+		return fmt.Errorf("%w: computed root %s, expected %s", ErrInvalidLogProof, computed, root)
+	}
+	return nil
+}
+
+// VerifyMessageProof verifies the proof of the L2 to L1 log, as returned by Client.LogProof, using VerifyLogProof.
+func VerifyMessageProof(proof *types.MessageProof, log *types.L2ToL1Log, txNumberInBatch uint16) error {
+	return VerifyLogProof(proof.Proof, HashL2ToL1Log(log, txNumberInBatch), proof.Root, proof.Id)
+}
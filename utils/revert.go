@@ -0,0 +1,200 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"math/big"
+	"strings"
+	"sync"
+)
+
+var (
+	// errorSelector is the selector of Error(string), used by require and revert with the reason.
+	errorSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+	// panicSelector is the selector of Panic(uint256), used by failed assertions and arithmetic errors.
+	panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71}
+)
+
+// panicReasons describes the panic codes defined by Solidity.
+var panicReasons = map[uint64]string{
+	0x00: "generic panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid encoding of storage byte array",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to uninitialized internal function",
+}
+
+// ErrUnknownRevert is returned by ErrorDecoder.Decode when the selector of the revert data matches
+// neither Error(string), Panic(uint256), nor any registered custom error.
+var ErrUnknownRevert = errors.New("unknown revert data")
+
+// DecodedRevert is the decoded data of the reverted call.
+type DecodedRevert struct {
+	Name      string        // Name of the error: Error, Panic or the name of the custom error.
+	Signature string        // Signature of the error, e.g. InsufficientBalance(uint256,uint256).
+	Args      []interface{} // Decoded arguments of the error.
+	// Reason is the reason given to require or revert for Error(string), and the description of the code
+	// for Panic(uint256). It is empty for custom errors.
+	Reason string
+}
+
+func (r *DecodedRevert) String() string {
+	switch r.Name {
+	case "Error":
+		return fmt.Sprintf("reverted with reason %q", r.Reason)
+	case "Panic":
+		return fmt.Sprintf("panic: %s (%#x)", r.Reason, r.Args[0])
+	}
+	args := make([]string, len(r.Args))
+	for i, arg := range r.Args {
+		args[i] = fmt.Sprintf("%v", arg)
+	}
+	return fmt.Sprintf("reverted with custom error %s(%s)", r.Name, strings.Join(args, ", "))
+}
+
+// ErrorDecoder decodes the revert data using the custom errors of the registered contract ABIs,
+// in addition to Error(string) and Panic(uint256). It is safe for concurrent use.
+type ErrorDecoder struct {
+	mu     sync.RWMutex
+	errors map[[4]byte]abi.Error
+}
+
+// DefaultErrorDecoder is the error decoder used by DecodeRevert and by clients, unless configured otherwise.
+var DefaultErrorDecoder = NewErrorDecoder()
+
+// NewErrorDecoder creates an instance of ErrorDecoder without registered custom errors.
+func NewErrorDecoder() *ErrorDecoder {
+	return &ErrorDecoder{errors: make(map[[4]byte]abi.Error)}
+}
+
+// Register registers the custom errors of the contract ABI.
+func (d *ErrorDecoder) Register(contractABI abi.ABI) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, abiError := range contractABI.Errors {
+		var selector [4]byte
+		copy(selector[:], abiError.ID[:4])
+		d.errors[selector] = abiError
+	}
+}
+
+// RegisterJSON registers the custom errors of the contract ABI given in JSON format,
+// e.g. the ABI field of the MetaData of abigen-generated bindings.
+func (d *ErrorDecoder) RegisterJSON(abiJSON string) error {
+	contractABI, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return fmt.Errorf("failed to parse ABI: %w", err)
+	}
+	d.Register(contractABI)
+	return nil
+}
+
+// Decode decodes the revert data. The empty data, returned by revert without the reason,
+// is decoded as Error with the empty reason.
+func (d *ErrorDecoder) Decode(data []byte) (*DecodedRevert, error) {
+	if len(data) == 0 {
+		return &DecodedRevert{Name: "Error", Signature: "Error(string)"}, nil
+	}
+	if len(data) < 4 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRevert, hexutil.Encode(data))
+	}
+	switch {
+	case bytes.Equal(data[:4], errorSelector):
+		reason, err := abi.UnpackRevert(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode revert reason: %w", err)
+		}
+		return &DecodedRevert{Name: "Error", Signature: "Error(string)", Args: []interface{}{reason}, Reason: reason}, nil
+	case bytes.Equal(data[:4], panicSelector):
+		if len(data) != 4+32 {
+			return nil, errors.New("failed to decode panic code: invalid length of data")
+		}
+		code := new(big.Int).SetBytes(data[4:])
+		reason, ok := panicReasons[code.Uint64()]
+		if !ok || !code.IsUint64() {
+			reason = "unknown panic code"
+		}
+		return &DecodedRevert{Name: "Panic", Signature: "Panic(uint256)", Args: []interface{}{code}, Reason: reason}, nil
+	}
+
+	var selector [4]byte
+	copy(selector[:], data[:4])
+	d.mu.RLock()
+	abiError, ok := d.errors[selector]
+	d.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRevert, hexutil.Encode(data))
+	}
+	args, err := abiError.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode custom error %s: %w", abiError.Name, err)
+	}
+	return &DecodedRevert{Name: abiError.Name, Signature: abiError.Sig, Args: args}, nil
+}
+
+// RegisterErrorABI registers the custom errors of the contract ABI with DefaultErrorDecoder.
+func RegisterErrorABI(contractABI abi.ABI) {
+	DefaultErrorDecoder.Register(contractABI)
+}
+
+// DecodeRevert decodes the revert data using DefaultErrorDecoder.
+func DecodeRevert(data []byte) (*DecodedRevert, error) {
+	return DefaultErrorDecoder.Decode(data)
+}
+
+// RevertError is the error of the reverted call or gas estimation, carrying the revert data returned
+// by the node along with its decoded form. It wraps the original RPC error.
+type RevertError struct {
+	Data    hexutil.Bytes  // Revert data returned by the node.
+	Decoded *DecodedRevert // Decoded revert data.
+	Err     error          // The original error.
+}
+
+func (e *RevertError) Error() string {
+	return fmt.Sprintf("%v: %s", e.Err, e.Decoded)
+}
+
+func (e *RevertError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeRevertError returns RevertError if the error carries the revert data which is decoded by
+// the decoder, or DefaultErrorDecoder if the decoder is nil. Otherwise, the error is returned as is.
+func DecodeRevertError(decoder *ErrorDecoder, err error) error {
+	if err == nil {
+		return nil
+	}
+	var revertErr *RevertError
+	if errors.As(err, &revertErr) {
+		return err
+	}
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return err
+	}
+	text, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return err
+	}
+	data, decodeErr := hexutil.Decode(text)
+	if decodeErr != nil {
+		return err
+	}
+	if decoder == nil {
+		decoder = DefaultErrorDecoder
+	}
+	decoded, decodeErr := decoder.Decode(data)
+	if decodeErr != nil {
+		return err
+	}
+	return &RevertError{Data: data, Decoded: decoded, Err: err}
+}
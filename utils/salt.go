@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"runtime"
+	"sync"
+)
+
+// Create2Predicate reports whether the address computed by the CREATE2 formula is acceptable.
+type Create2Predicate func(address common.Address) bool
+
+// LeadingZeroBytes returns the predicate accepting addresses starting with at least n zero bytes,
+// whose use in calldata is cheaper.
+func LeadingZeroBytes(n int) Create2Predicate {
+	if n > common.AddressLength {
+		n = common.AddressLength
+	}
+	return func(address common.Address) bool {
+		for _, b := range address[:n] {
+			if b != 0 {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// AddressPrefix returns the predicate accepting addresses starting with the prefix.
+func AddressPrefix(prefix []byte) Create2Predicate {
+	return func(address common.Address) bool {
+		return bytes.HasPrefix(address[:], prefix)
+	}
+}
+
+// MineCreate2Salt searches for the salt for which the zkSync CREATE2 formula, with the deployer, the hash
+// of the bytecode, as returned by HashBytecode, and the keccak256 hash of the constructor input, yields
+// the address accepted by the predicate, e.g. a vanity address or the one with leading zero bytes.
+// The search is performed by a pool of workers, one per CPU, each starting with random salt, and runs
+// until the salt is found or the context is done. The predicate must be safe for concurrent use.
+func MineCreate2Salt(ctx context.Context, deployer common.Address, bytecodeHash, inputHash common.Hash,
+	predicate Create2Predicate) (common.Hash, common.Address, error) {
+	type result struct {
+		salt    common.Hash
+		address common.Address
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	found := make(chan result, 1)
+	errs := make(chan error, 1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < runtime.NumCPU(); i++ {
+		var salt common.Hash
+		if _, err := rand.Read(salt[:]); err != nil {
+			return common.Hash{}, common.Address{}, fmt.Errorf("failed to generate salt: %w", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// The preimage of the address is prefix || deployer || salt || bytecode hash || input hash,
+			// in which only the salt changes between attempts.
+			preimage := make([]byte, 5*32)
+			copy(preimage[0:32], crypto.Keccak256([]byte("zksyncCreate2")))
+			copy(preimage[44:64], deployer[:])
+			copy(preimage[96:128], bytecodeHash[:])
+			copy(preimage[128:160], inputHash[:])
+			hasher := crypto.NewKeccakState()
+			var digest common.Hash
+			for attempt := 0; ; attempt++ {
+				if attempt%4096 == 0 && ctx.Err() != nil {
+					return
+				}
+				copy(preimage[64:96], salt[:])
+				hasher.Reset()
+				hasher.Write(preimage)
+				if _, err := hasher.Read(digest[:]); err != nil {
+					select {
+					case errs <- err:
+					default:
+					}
+					cancel()
+					return
+				}
+				address := common.BytesToAddress(digest[12:])
+				if predicate(address) {
+					select {
+					case found <- result{salt: salt, address: address}:
+					default:
+					}
+					cancel()
+					return
+				}
+				incrementSalt(&salt)
+			}
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case r := <-found:
+		return r.salt, r.address, nil
+	case err := <-errs:
+		return common.Hash{}, common.Address{}, fmt.Errorf("failed to hash CREATE2 preimage: %w", err)
+	default:
+		return common.Hash{}, common.Address{}, ctx.Err()
+	}
+}
+
+// incrementSalt increments the salt as a big-endian integer, wrapping around on overflow.
+func incrementSalt(salt *common.Hash) {
+	for i := len(salt) - 1; i >= 0; i-- {
+		salt[i]++
+		if salt[i] != 0 {
+			return
+		}
+	}
+}
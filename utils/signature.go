@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/zksync-sdk/zksync2-go/contracts/erc1271"
+	"github.com/zksync-sdk/zksync2-go/eip712"
+	"strings"
+)
+
+// EIP1271MagicValue is the value returned by isValidSignature of EIP-1271 contracts for valid signatures.
+var EIP1271MagicValue = [4]byte{0x16, 0x26, 0xba, 0x7e}
+
+// IsMessageSignatureValid checks whether the signature of the message, signed as EIP-191 personal message,
+// is valid for the address, which is either an EOA or a smart account implementing EIP-1271.
+func IsMessageSignatureValid(ctx context.Context, caller bind.ContractCaller, address common.Address, msg, sig []byte) (bool, error) {
+	return IsHashSignatureValid(ctx, caller, address, HashMessage(msg), sig)
+}
+
+// IsTypedDataSignatureValid checks whether the signature of the EIP-712 typed data is valid for the address,
+// which is either an EOA or a smart account implementing EIP-1271.
+func IsTypedDataSignatureValid(ctx context.Context, caller bind.ContractCaller, address common.Address,
+	domain *eip712.Domain, data eip712.TypedData, sig []byte) (bool, error) {
+	hash, err := eip712.HashTypedData(domain, data)
+	if err != nil {
+		return false, fmt.Errorf("failed to get hash of typed data: %w", err)
+	}
+	return IsHashSignatureValid(ctx, caller, address, hash, sig)
+}
+
+// IsHashSignatureValid checks whether the signature of the hash is valid for the address. The signer is recovered
+// from the signature first, and if it is not the address, the signature is validated by the isValidSignature method
+// of the contract deployed at the address, as specified by EIP-1271.
+func IsHashSignatureValid(ctx context.Context, caller bind.ContractCaller, address common.Address, hash, sig []byte) (bool, error) {
+	if len(sig) == crypto.SignatureLength {
+		recoverable := common.CopyBytes(sig)
+		if recoverable[64] >= 27 {
+			recoverable[64] -= 27
+		}
+		if publicKey, err := crypto.SigToPub(hash, recoverable); err == nil && crypto.PubkeyToAddress(*publicKey) == address {
+			return true, nil
+		}
+	}
+
+	code, err := caller.CodeAt(ctx, address, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to get code of %s: %w", address, err)
+	}
+	if len(code) == 0 {
+		return false, nil
+	}
+	account, err := erc1271.NewIERC1271Caller(address, caller)
+	if err != nil {
+		return false, err
+	}
+	magic, err := account.IsValidSignature(&bind.CallOpts{Context: ctx}, common.BytesToHash(hash), sig)
+	if err != nil {
+		// Contracts are allowed to revert on invalid signatures.
+		if isRevert(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to call isValidSignature: %w", err)
+	}
+	return bytes.Equal(magic[:], EIP1271MagicValue[:]), nil
+}
+
+// isRevert reports whether the error is caused by the reverted call.
+func isRevert(err error) bool {
+	var dataErr rpc.DataError
+	return errors.As(err, &dataErr) || strings.Contains(err.Error(), "revert")
+}
+
+// HashMessage returns the hash of the message signed as EIP-191 personal message, i.e. keccak256 of the message
+// prefixed with "\x19Ethereum Signed Message:\n" and its length.
+func HashMessage(msg []byte) []byte {
+	return accounts.TextHash(msg)
+}
+
+// RecoverMessageSigner returns the address of the account which has signed the EIP-191 personal message.
+func RecoverMessageSigner(msg, sig []byte) (common.Address, error) {
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("invalid length of signature: %d", len(sig))
+	}
+	recoverable := common.CopyBytes(sig)
+	if recoverable[64] >= 27 {
+		recoverable[64] -= 27
+	}
+	publicKey, err := crypto.SigToPub(HashMessage(msg), recoverable)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to recover signer: %w", err)
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
+
+// VerifyMessage reports whether the EIP-191 personal message has been signed by the EOA with the address.
+// Use IsMessageSignatureValid to verify signatures of smart accounts.
+func VerifyMessage(address common.Address, msg, sig []byte) bool {
+	signer, err := RecoverMessageSigner(msg, sig)
+	return err == nil && signer == address
+}
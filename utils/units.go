@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// EtherDecimals is the number of decimals of ether, and of the base token of ETH-based chains.
+const EtherDecimals = 18
+
+// ErrInvalidAmount is returned when the decimal string cannot be parsed as the amount.
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// ParseEther parses the decimal string representing the amount of ether, e.g. "1.5", into wei.
+func ParseEther(value string) (*big.Int, error) {
+	return ParseUnits(value, EtherDecimals)
+}
+
+// FormatEther formats the amount of wei as the decimal string representing the amount of ether.
+func FormatEther(wei *big.Int) string {
+	return FormatUnits(wei, EtherDecimals)
+}
+
+// ParseUnits parses the decimal string, e.g. "1.5", representing the amount of the token with
+// the given number of decimals, into the amount in the smallest units of the token. The conversion
+// is exact: the string must not have more fractional digits than decimals, except trailing zeros.
+// The string may have a leading sign, but not the exponent or digit separators.
+func ParseUnits(value string, decimals int) (*big.Int, error) {
+	if decimals < 0 {
+		return nil, fmt.Errorf("invalid number of decimals: %d", decimals)
+	}
+	s := strings.TrimSpace(value)
+	negative := false
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+	whole, fraction, _ := strings.Cut(s, ".")
+	if whole == "" && fraction == "" || !isDigits(whole) || !isDigits(fraction) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > decimals {
+		return nil, fmt.Errorf("%w: %q has more than %d decimals", ErrInvalidAmount, value, decimals)
+	}
+	digits := whole + fraction + strings.Repeat("0", decimals-len(fraction))
+	amount, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+	if negative {
+		amount.Neg(amount)
+	}
+	return amount, nil
+}
+
+// FormatUnits formats the amount in the smallest units of the token with the given number of decimals
+// as the decimal string, e.g. "1.5". Trailing zeros of the fractional part are omitted, as is the decimal
+// point of the whole amounts. Nil amount is formatted as "0".
+func FormatUnits(amount *big.Int, decimals int) string {
+	if amount == nil {
+		return "0"
+	}
+	if decimals <= 0 {
+		return amount.String()
+	}
+	digits := new(big.Int).Abs(amount).String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	whole, fraction := digits[:len(digits)-decimals], strings.TrimRight(digits[len(digits)-decimals:], "0")
+	result := whole
+	if fraction != "" {
+		result += "." + fraction
+	}
+	if amount.Sign() < 0 {
+		result = "-" + result
+	}
+	return result
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+// Package accounts is the v2 API of the accounts package. It provides option-struct based constructors,
+// while the types are shared with the v1 package, so that values can be freely passed between code
+// using either version of the API during migration.
+package accounts
+
+import (
+	"github.com/zksync-sdk/zksync2-go/accounts"
+)
+
+type (
+	// Signer provides support for signing EIP-712 transactions as well as other types of transactions.
+	Signer = accounts.Signer
+	// BaseSigner represents basis implementation of Signer interface.
+	BaseSigner = accounts.BaseSigner
+	// SignerOptions contains options used to create a BaseSigner.
+	SignerOptions = accounts.SignerOptions
+	// Wallet wraps all operations that interact with an associated account.
+	Wallet = accounts.Wallet
+	// WalletOptions contains options used to create a Wallet.
+	WalletOptions = accounts.WalletOptions
+	// Adapter provides common functionalities on both L1 and L2 network for the associated account.
+	Adapter = accounts.Adapter
+	// TransactOpts contains common data required to create a valid transaction on both L1 and L2.
+	TransactOpts = accounts.TransactOpts
+	// CallOpts is the collection of options to fine tune a contract call request.
+	CallOpts = accounts.CallOpts
+	// PaymasterPolicy is enforced on paymasters used by transactions.
+	PaymasterPolicy = accounts.PaymasterPolicy
+	// NonceManager assigns nonces to transactions sent on L2 network.
+	NonceManager = accounts.NonceManager
+	// MinimumAmounts contains per-token minimum amounts of transfers and withdrawals.
+	MinimumAmounts = accounts.MinimumAmounts
+	// RecipientGuard checks recipients of transfers and withdrawals.
+	RecipientGuard = accounts.RecipientGuard
+	// BridgeMetrics receives the latencies of completed bridging flows.
+	BridgeMetrics = accounts.BridgeMetrics
+	// PaymasterGuard limits the fees sponsored by paymasters.
+	PaymasterGuard = accounts.PaymasterGuard
+	// SpendingGuard limits the transactions signed by the wallet.
+	SpendingGuard = accounts.SpendingGuard
+	// Hooks holds the callbacks invoked on the lifecycle of transactions.
+	Hooks = accounts.Hooks
+	// PaymasterParamsProvider provides the paymaster parameters of transactions.
+	PaymasterParamsProvider = accounts.PaymasterParamsProvider
+	// FactoryDepsResolver provides the bytecode of contracts created by deployed contracts.
+	FactoryDepsResolver = accounts.FactoryDepsResolver
+	// ContractBackend sends transactions of abigen-generated contract bindings as EIP-712 transactions.
+	ContractBackend = accounts.ContractBackend
+	// ProxyBytecodes contains the zkEVM bytecode of the OpenZeppelin proxy contracts.
+	ProxyBytecodes = accounts.ProxyBytecodes
+	// GasScaler scales the gas limits estimated by the wallet.
+	GasScaler = accounts.GasScaler
+	// GasOracle provides the gas price of transactions sent by the wallet.
+	GasOracle = accounts.GasOracle
+)
+
+// NewSigner creates an instance of BaseSigner using the provided options. Exactly one source
+// of the account must be provided: PrivateKey, Mnemonic, Keystore or Random.
+func NewSigner(opts SignerOptions) (*BaseSigner, error) {
+	return accounts.NewSignerWithOptions(opts)
+}
+
+// NewWallet creates an instance of Wallet using the provided options. The account is either provided
+// by WalletOptions.Signer or created from the embedded SignerOptions, in which case the chain ID
+// is fetched using WalletOptions.ClientL2 unless SignerOptions.ChainID is provided.
+func NewWallet(opts WalletOptions) (*Wallet, error) {
+	return accounts.NewWalletWithOptions(opts)
+}
@@ -0,0 +1,43 @@
+// Package clients is the v2 API of the clients package. It provides option-struct based constructors,
+// while the types are shared with the v1 package, so that values can be freely passed between code
+// using either version of the API during migration.
+package clients
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/zksync-sdk/zksync2-go/clients"
+)
+
+type (
+	// Client defines both ethereum and zkSync Era RPC methods and common features.
+	Client = clients.Client
+	// BaseClient implements the Client interface.
+	BaseClient = clients.BaseClient
+	// Options contains options used when connecting a client to the node.
+	Options = clients.ClientOptions
+	// Auth is an authentication scheme applied to every request sent to the node.
+	Auth = clients.Auth
+	// PrivacyMode specifies how transactions are submitted to the network.
+	PrivacyMode = clients.PrivacyMode
+	// BridgeRegistry contains the custom bridges consulted when mapping tokens between L1 and L2.
+	BridgeRegistry = clients.BridgeRegistry
+	// CustomBridge is the bridge deployed besides the default bridges, which bridges specific tokens.
+	CustomBridge = clients.CustomBridge
+	// TokenPair is the pair of addresses of the same token on L1 and L2.
+	TokenPair = clients.TokenPair
+	// DevClient extends Client with the methods manipulating the state of the local development node.
+	DevClient = clients.DevClient
+)
+
+// Dial connects a client to the given URL with context using the provided options.
+// The opts parameter is optional; if not provided, default options are used.
+func Dial(ctx context.Context, rawUrl string, opts *Options) (Client, error) {
+	return clients.DialContextWithOptions(ctx, rawUrl, opts)
+}
+
+// NewClient creates a client that uses the given RPC client and options.
+// The opts parameter is optional; if not provided, default options are used.
+func NewClient(c *rpc.Client, opts *Options) Client {
+	return clients.NewClientWithOptions(c, opts)
+}
@@ -0,0 +1,28 @@
+// Command zkmigrate reports usages of zksync2-go v1 APIs which have been replaced by v2 APIs.
+// It is run by go vet:
+//
+//	go install github.com/zksync-sdk/zksync2-go/v2/cmd/zkmigrate@latest
+//	go vet -vettool=$(which zkmigrate) ./...
+//
+// The migration guide is printed with:
+//
+//	zkmigrate guide
+package main
+
+import (
+	"fmt"
+	"github.com/zksync-sdk/zksync2-go/v2/migrate"
+	"golang.org/x/tools/go/analysis/unitchecker"
+	"os"
+)
+
+func main() {
+	if len(os.Args) == 2 && os.Args[1] == "guide" {
+		if err := migrate.WriteGuide(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	unitchecker.Main(migrate.Analyzer)
+}
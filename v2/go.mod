@@ -0,0 +1,33 @@
+module github.com/elementex/zksync2-go/v2
+
+// Go 1.21 is required by log/slog, used for the logging of clients and wallets.
+go 1.21
+
+require (
+	github.com/elementex/zksync2-go v0.4.0
+	github.com/ethereum/go-ethereum v1.12.0
+	golang.org/x/tools v0.7.0
+)
+
+require (
+	github.com/btcsuite/btcd v0.22.3 // indirect
+	github.com/btcsuite/btcd/chaincfg/chainhash v1.0.1 // indirect
+	github.com/btcsuite/btcutil v1.0.3-0.20201208143702-a53e38424cce // indirect
+	github.com/deckarep/golang-set/v2 v2.1.0 // indirect
+	github.com/fsnotify/fsnotify v1.6.0 // indirect
+	github.com/go-stack/stack v1.8.1 // indirect
+	github.com/golang/protobuf v1.5.2 // indirect
+	github.com/google/uuid v1.3.0 // indirect
+	github.com/gorilla/websocket v1.4.2 // indirect
+	github.com/holiman/uint256 v1.2.2-0.20230321075855-87b91420868c // indirect
+	github.com/karalabe/usb v0.0.2 // indirect
+	github.com/miguelmota/go-ethereum-hdwallet v0.1.1 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/shirou/gopsutil v3.21.4-0.20210419000835-c7a38de76ee5+incompatible // indirect
+	github.com/tklauser/go-sysconf v0.3.5 // indirect
+	github.com/tklauser/numcpus v0.2.2 // indirect
+	github.com/tyler-smith/go-bip39 v1.1.0 // indirect
+	golang.org/x/crypto v0.1.0 // indirect
+	golang.org/x/sys v0.7.0 // indirect
+	google.golang.org/protobuf v1.28.1 // indirect
+)
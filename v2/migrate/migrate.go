@@ -0,0 +1,132 @@
+// Package migrate provides an analyzer which flags usages of v1 APIs that have been replaced by v2 APIs,
+// and generates the migration guide describing the replacements.
+package migrate
+
+import (
+	"fmt"
+	"go/types"
+	"golang.org/x/tools/go/analysis"
+	"io"
+	"sort"
+	"strings"
+)
+
+//go:generate sh -c "go run ../cmd/zkmigrate guide > ../MIGRATION.md"
+
+const modulePath = "github.com/zksync-sdk/zksync2-go"
+
+// Replacement describes a v1 function and the v2 API which replaces it.
+type Replacement struct {
+	Package     string // Import path of the v1 package.
+	Name        string // Name of the v1 function.
+	Replacement string // The v2 API replacing the function.
+	Note        string // How to migrate the call, e.g. which options to set.
+}
+
+// Replacements contains all v1 functions which have been replaced by v2 APIs.
+var Replacements = []Replacement{
+	{
+		Package:     modulePath + "/accounts",
+		Name:        "NewWallet",
+		Replacement: "v2/accounts.NewWallet",
+		Note:        "Set WalletOptions.PrivateKey, ClientL2 and ClientL1. Clients are passed by value.",
+	},
+	{
+		Package:     modulePath + "/accounts",
+		Name:        "NewWalletFromRawPrivateKey",
+		Replacement: "v2/accounts.NewWallet",
+		Note:        "Set WalletOptions.PrivateKey and ChainID. Clients are optional and passed by value.",
+	},
+	{
+		Package:     modulePath + "/accounts",
+		Name:        "NewRandomWallet",
+		Replacement: "v2/accounts.NewWallet",
+		Note:        "Set WalletOptions.Random and ChainID. Clients are optional and passed by value.",
+	},
+	{
+		Package:     modulePath + "/accounts",
+		Name:        "NewWalletFromMnemonic",
+		Replacement: "v2/accounts.NewWallet",
+		Note:        "Set WalletOptions.Mnemonic, DerivationPath, ClientL2 and ClientL1. Clients are passed by value.",
+	},
+	{
+		Package:     modulePath + "/accounts",
+		Name:        "NewWalletFromKeystore",
+		Replacement: "v2/accounts.NewWallet",
+		Note:        "Set WalletOptions.Keystore, Password, ClientL2 and ClientL1. Clients are passed by value.",
+	},
+	{
+		Package:     modulePath + "/accounts",
+		Name:        "NewBaseSignerFromMnemonic",
+		Replacement: "v2/accounts.NewSigner",
+		Note:        "Set SignerOptions.Mnemonic and ChainID.",
+	},
+	{
+		Package:     modulePath + "/accounts",
+		Name:        "NewBaseSignerFromMnemonicAndAccountId",
+		Replacement: "v2/accounts.NewSigner",
+		Note:        "Set SignerOptions.Mnemonic, ChainID and DerivationPath to m/44'/60'/0'/0/<accountId>.",
+	},
+}
+
+// Analyzer reports usages of v1 functions which have been replaced by v2 APIs.
+var Analyzer = &analysis.Analyzer{
+	Name: "zkmigrate",
+	Doc:  "reports usages of zksync2-go v1 APIs which have been replaced by v2 APIs",
+	Run:  run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	replacements := make(map[string]Replacement, len(Replacements))
+	for _, r := range Replacements {
+		replacements[r.Package+"."+r.Name] = r
+	}
+	for ident, obj := range pass.TypesInfo.Uses {
+		fn, ok := obj.(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg() == pass.Pkg {
+			continue
+		}
+		if sig, ok := fn.Type().(*types.Signature); !ok || sig.Recv() != nil {
+			continue
+		}
+		r, ok := replacements[fn.Pkg().Path()+"."+fn.Name()]
+		if !ok {
+			continue
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos:     ident.Pos(),
+			End:     ident.End(),
+			Message: fmt.Sprintf("%s.%s is deprecated, use %s instead: %s", fn.Pkg().Name(), fn.Name(), r.Replacement, r.Note),
+		})
+	}
+	return nil, nil
+}
+
+// WriteGuide writes the migration guide in Markdown format, describing all replacements.
+func WriteGuide(w io.Writer) error {
+	replacements := make([]Replacement, len(Replacements))
+	copy(replacements, Replacements)
+	sort.SliceStable(replacements, func(i, j int) bool {
+		return replacements[i].Package < replacements[j].Package
+	})
+
+	var b strings.Builder
+	b.WriteString("# Migration from v1 to v2\n\n")
+	b.WriteString("The v2 packages provide option-struct based APIs, while sharing all types with the v1 packages,\n")
+	b.WriteString("so the code can be migrated gradually. The v1 functions listed below are deprecated and kept as\n")
+	b.WriteString("shims sharing the implementation with their v2 replacements.\n\n")
+	b.WriteString("The v2 packages are the separate module " + modulePath + "/v2, which requires the v1 module,\n")
+	b.WriteString("so the v1 module does not depend on the migration tooling:\n\n")
+	b.WriteString("```shell\ngo get " + modulePath + "/v2@latest\n```\n\n")
+	b.WriteString("To find all usages of the deprecated functions in your code, run:\n\n")
+	b.WriteString("```shell\ngo install " + modulePath + "/v2/cmd/zkmigrate@latest\n")
+	b.WriteString("go vet -vettool=$(which zkmigrate) ./...\n```\n\n")
+	b.WriteString("| v1 function | v2 replacement | Migration |\n")
+	b.WriteString("|---|---|---|\n")
+	for _, r := range replacements {
+		pkg := strings.TrimPrefix(r.Package, modulePath+"/")
+		fmt.Fprintf(&b, "| `%s.%s` | `%s` | %s |\n", pkg, r.Name, r.Replacement, r.Note)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,291 @@
+// Package verify provides the client of the contract verification API of the zkSync block explorer, which
+// compiles the submitted source code using the given zksolc and solc versions and compares the result with
+// the bytecode deployed on the network.
+package verify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	MainnetURL          = "https://zksync2-mainnet-explorer.zksync.io/contract_verification" // Verification API of zkSync Era mainnet.
+	SepoliaURL          = "https://explorer.sepolia.era.zksync.dev/contract_verification"    // Verification API of zkSync Era Sepolia testnet.
+	DefaultPollInterval = 2 * time.Second                                                    // Default interval of polling the status.
+)
+
+var (
+	// ErrVerificationFailed is returned when the explorer fails to verify the contract, e.g. because
+	// the compiled bytecode does not match the deployed one.
+	ErrVerificationFailed = errors.New("contract verification failed")
+	// ErrAlreadyVerified is returned when the request is submitted for the contract which is already verified.
+	ErrAlreadyVerified = errors.New("contract is already verified")
+)
+
+// CodeFormat is the format of the submitted source code.
+type CodeFormat string
+
+const (
+	SolidityStandardJSON CodeFormat = "solidity-standard-json-input" // Standard JSON input of solc.
+	SoliditySingleFile   CodeFormat = "solidity-single-file"         // Single flattened source file.
+)
+
+// StandardJSONInput is the standard JSON input of solc, as used by zksolc --standard-json.
+type StandardJSONInput struct {
+	Language string                    `json:"language"`
+	Sources  map[string]StandardSource `json:"sources"`
+	Settings json.RawMessage           `json:"settings,omitempty"`
+}
+
+// StandardSource is the source file of the standard JSON input.
+type StandardSource struct {
+	Content string `json:"content"`
+}
+
+// Request is the request to verify the deployed contract.
+type Request struct {
+	ContractAddress common.Address `json:"contractAddress"`
+	// ContractName is the fully qualified name of the contract, e.g. contracts/Token.sol:Token,
+	// or the plain name of the contract in the single source file.
+	ContractName string     `json:"contractName"`
+	CodeFormat   CodeFormat `json:"codeFormat"`
+	// SourceCode is the source file for SoliditySingleFile, and StandardJSONInput, or its raw JSON encoding,
+	// for SolidityStandardJSON.
+	SourceCode            interface{} `json:"sourceCode"`
+	CompilerZksolcVersion string      `json:"compilerZksolcVersion"` // Version of zksolc, e.g. v1.3.14.
+	CompilerSolcVersion   string      `json:"compilerSolcVersion"`   // Version of solc, e.g. 0.8.17.
+	OptimizationUsed      bool        `json:"optimizationUsed"`
+	// OptimizerMode is the optimization mode of zksolc, one of 0, 1, 2, 3, s and z. Optional.
+	OptimizerMode        string        `json:"optimizerMode,omitempty"`
+	ConstructorArguments hexutil.Bytes `json:"constructorArguments"` // ABI encoded constructor arguments.
+	IsSystem             bool          `json:"isSystem"`             // Whether the contract is compiled with --system-mode.
+	ForceEvmla           bool          `json:"forceEvmla"`           // Whether the contract is compiled with --force-evmla.
+}
+
+// NewStandardJSONRequest creates the request to verify the contract compiled from the standard JSON input.
+func NewStandardJSONRequest(address common.Address, contractName string, input *StandardJSONInput, zksolcVersion, solcVersion string) *Request {
+	return &Request{
+		ContractAddress:       address,
+		ContractName:          contractName,
+		CodeFormat:            SolidityStandardJSON,
+		SourceCode:            input,
+		CompilerZksolcVersion: zksolcVersion,
+		CompilerSolcVersion:   solcVersion,
+		OptimizationUsed:      true,
+		ConstructorArguments:  hexutil.Bytes{},
+	}
+}
+
+// NewSingleFileRequest creates the request to verify the contract compiled from the single source file.
+func NewSingleFileRequest(address common.Address, contractName, source string, zksolcVersion, solcVersion string) *Request {
+	return &Request{
+		ContractAddress:       address,
+		ContractName:          contractName,
+		CodeFormat:            SoliditySingleFile,
+		SourceCode:            source,
+		CompilerZksolcVersion: zksolcVersion,
+		CompilerSolcVersion:   solcVersion,
+		OptimizationUsed:      true,
+		ConstructorArguments:  hexutil.Bytes{},
+	}
+}
+
+// Validate checks that the request contains the fields required by the verification API.
+func (r *Request) Validate() error {
+	switch {
+	case r.ContractAddress == (common.Address{}):
+		return errors.New("contract address must be provided")
+	case r.ContractName == "":
+		return errors.New("contract name must be provided")
+	case r.SourceCode == nil:
+		return errors.New("source code must be provided")
+	case r.CompilerZksolcVersion == "" || r.CompilerSolcVersion == "":
+		return errors.New("zksolc and solc versions must be provided")
+	}
+	switch r.CodeFormat {
+	case SoliditySingleFile:
+		if _, ok := r.SourceCode.(string); !ok {
+			return fmt.Errorf("source code of %s must be string", r.CodeFormat)
+		}
+	case SolidityStandardJSON:
+	default:
+		return fmt.Errorf("unsupported code format %q", r.CodeFormat)
+	}
+	return nil
+}
+
+// VerificationStatus is the status of the verification request.
+type VerificationStatus string
+
+const (
+	StatusQueued     VerificationStatus = "queued"
+	StatusInProgress VerificationStatus = "in_progress"
+	StatusSuccessful VerificationStatus = "successful"
+	StatusFailed     VerificationStatus = "failed"
+)
+
+// Status is the status of the verification request reported by the explorer.
+type Status struct {
+	Status            VerificationStatus `json:"status"`
+	Error             string             `json:"error,omitempty"`             // Reason of the failure.
+	CompilationErrors []string           `json:"compilationErrors,omitempty"` // Errors of the compilation, if it failed.
+}
+
+// Done reports whether the verification is completed, either successfully or not.
+func (s *Status) Done() bool {
+	return s.Status == StatusSuccessful || s.Status == StatusFailed
+}
+
+// Client is the client of the contract verification API of the block explorer.
+type Client struct {
+	URL          string        // URL of the verification API, e.g. SepoliaURL.
+	Header       http.Header   // Headers added to each request. Optional.
+	Client       *http.Client  // HTTP client used to send requests. Optional, http.DefaultClient is used by default.
+	PollInterval time.Duration // Interval of polling the status. Optional, DefaultPollInterval is used by default.
+}
+
+// NewClient creates an instance of Client for the verification API at the given URL.
+func NewClient(url string) *Client {
+	return &Client{URL: strings.TrimSuffix(url, "/"), Header: make(http.Header)}
+}
+
+// Submit submits the verification request and returns its ID.
+func (c *Client) Submit(ctx context.Context, req *Request) (int64, error) {
+	if err := req.Validate(); err != nil {
+		return 0, err
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := c.do(ctx, http.MethodPost, c.URL, body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to submit verification request: %w", err)
+	}
+	id, err := strconv.ParseInt(strings.Trim(string(bytes.TrimSpace(resp)), `"`), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected response of verification API: %s", resp)
+	}
+	return id, nil
+}
+
+// Status returns the status of the verification request.
+func (c *Client) Status(ctx context.Context, id int64) (*Status, error) {
+	resp, err := c.do(ctx, http.MethodGet, c.URL+"/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get verification status: %w", err)
+	}
+	var status Status
+	if err = json.Unmarshal(resp, &status); err != nil {
+		return nil, fmt.Errorf("failed to decode verification status: %w", err)
+	}
+	return &status, nil
+}
+
+// Wait polls the status of the verification request until it is completed. If the verification fails,
+// the status is returned along with the error wrapping ErrVerificationFailed.
+func (c *Client) Wait(ctx context.Context, id int64) (*Status, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	interval := c.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		status, err := c.Status(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if status.Status == StatusFailed {
+			return status, verificationError(status)
+		}
+		if status.Done() {
+			return status, nil
+		}
+		select {
+		case <-ctx.Done():
+			return status, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Verify submits the verification request and waits until it is completed.
+func (c *Client) Verify(ctx context.Context, req *Request) (*Status, error) {
+	id, err := c.Submit(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return c.Wait(ctx, id)
+}
+
+func (c *Client) do(ctx context.Context, method, url string, body []byte) ([]byte, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range c.Header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		message := bytes.TrimSpace(data)
+		if len(message) > 512 {
+			message = message[:512]
+		}
+		if bytes.Contains(bytes.ToLower(message), []byte("already verified")) {
+			return nil, fmt.Errorf("%w: %s", ErrAlreadyVerified, message)
+		}
+		return nil, fmt.Errorf("verification API responded with %s: %s", resp.Status, message)
+	}
+	return data, nil
+}
+
+func verificationError(status *Status) error {
+	reason := status.Error
+	if len(status.CompilationErrors) > 0 {
+		reason = strings.TrimSpace(reason + ": " + strings.Join(status.CompilationErrors, "; "))
+	}
+	if reason == "" {
+		return ErrVerificationFailed
+	}
+	return fmt.Errorf("%w: %s", ErrVerificationFailed, reason)
+}
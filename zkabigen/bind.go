@@ -0,0 +1,228 @@
+// Package zkabigen generates Go bindings of zkSync contracts. The bindings contain everything generated
+// by the abigen tool of go-ethereum, extended with the ZkTransactor, whose transact methods send EIP-712
+// transactions using the wallet and accept TransactOpts with the paymaster parameters, factory dependencies
+// and gas per pubdata, and with the function deploying the contract through ContractDeployer.
+//
+// The package also contains the runtime used by the generated bindings. The bindings are generated by
+// the zkabigen command, e.g. using go:generate:
+//
+//	//go:generate go run github.com/zksync-sdk/zksync2-go/cmd/zkabigen -abi Storage.abi -bin Storage.zbin -type Storage -pkg storage -out storage.go
+package zkabigen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+	"text/template"
+)
+
+const modulePath = "github.com/zksync-sdk/zksync2-go"
+
+// Bind generates the Go binding of the contract with the ABI and the optional zkSync bytecode, produced
+// by zksolc, named typeName in the package pkg. The function deploying the contract is generated only
+// if the bytecode is provided.
+func Bind(typeName, abiJSON string, bytecode []byte, pkg string) (string, error) {
+	bin := ""
+	if len(bytecode) > 0 {
+		bin = hexutil.Encode(bytecode)
+	}
+	code, err := bind.Bind([]string{typeName}, []string{abiJSON}, []string{bin}, nil, pkg, bind.LangGo, nil, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate binding: %w", err)
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", code, parser.ParseComments)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse generated binding: %w", err)
+	}
+	data := &tmplData{Type: typeName}
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		switch {
+		case fn.Recv == nil && fn.Name.Name == "Deploy"+typeName && len(bytecode) > 0:
+			data.Deploy = &tmplMethod{
+				Params: source(code, fset, fn.Type.Params.List[2:]),
+				Args:   argNames(fn.Type.Params.List[2:]),
+			}
+		case fn.Recv != nil && receiverType(fn) == typeName+"Transactor":
+			method, err := transactMethod(code, fset, fn)
+			if err != nil {
+				return "", err
+			}
+			data.Methods = append(data.Methods, method)
+		}
+	}
+
+	var zk bytes.Buffer
+	if err = zkTemplate.Execute(&zk, data); err != nil {
+		return "", fmt.Errorf("failed to generate zkSync binding: %w", err)
+	}
+	// The packages used by the zkSync binding are added to the last group of the imports generated by abigen.
+	start := strings.Index(code, "import (")
+	if start < 0 {
+		return "", errors.New("generated binding has no import block")
+	}
+	end := start + strings.Index(code[start:], "\n)")
+	code = code[:end] + fmt.Sprintf("\n\t%q\n\t%q", modulePath+"/accounts", modulePath+"/zkabigen") + code[end:]
+	if file, err = parser.ParseFile(fset, "", code+zk.String(), parser.ParseComments); err != nil {
+		return "", fmt.Errorf("failed to parse generated zkSync binding: %w", err)
+	}
+	ast.SortImports(fset, file)
+
+	var out bytes.Buffer
+	if err = format.Node(&out, fset, file); err != nil {
+		return "", fmt.Errorf("failed to format generated binding: %w", err)
+	}
+	return out.String(), nil
+}
+
+// tmplData is the data of zkTemplate.
+type tmplData struct {
+	Type    string        // Name of the contract type.
+	Deploy  *tmplMethod   // The constructor, nil if the bytecode is not provided.
+	Methods []*tmplMethod // The transact methods.
+}
+
+// tmplMethod is the transact method, or the constructor, of the contract.
+type tmplMethod struct {
+	Doc    string // Doc comment of the method generated by abigen.
+	Name   string // Name of the method.
+	Params string // Parameters following the transact options, including the leading comma.
+	Args   string // Arguments passed to the contract, including the leading comma.
+	Call   string // Call of the bound contract, e.g. Transact(opts, "transfer", to, amount).
+}
+
+// transactMethod extracts the transact method from the method of the transactor generated by abigen.
+func transactMethod(code string, fset *token.FileSet, fn *ast.FuncDecl) (*tmplMethod, error) {
+	var call *ast.CallExpr
+	if len(fn.Body.List) == 1 {
+		if ret, ok := fn.Body.List[0].(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+			call, _ = ret.Results[0].(*ast.CallExpr)
+		}
+	}
+	if call == nil {
+		return nil, fmt.Errorf("unexpected body of generated method %s", fn.Name.Name)
+	}
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, fmt.Errorf("unexpected call in generated method %s", fn.Name.Name)
+	}
+	var args []string
+	for _, arg := range call.Args {
+		args = append(args, code[fset.Position(arg.Pos()).Offset:fset.Position(arg.End()).Offset])
+	}
+	doc := ""
+	if fn.Doc != nil {
+		doc = code[fset.Position(fn.Doc.Pos()).Offset:fset.Position(fn.Doc.End()).Offset]
+	}
+	return &tmplMethod{
+		Doc:    doc,
+		Name:   fn.Name.Name,
+		Params: source(code, fset, fn.Type.Params.List[1:]),
+		Call:   selector.Sel.Name + "(" + strings.Join(args, ", ") + ")",
+	}, nil
+}
+
+// receiverType returns the name of the type of the method receiver.
+func receiverType(fn *ast.FuncDecl) string {
+	if star, ok := fn.Recv.List[0].Type.(*ast.StarExpr); ok {
+		if ident, ok := star.X.(*ast.Ident); ok {
+			return ident.Name
+		}
+	}
+	return ""
+}
+
+// source returns the source of the parameters, preceded by a comma if there are any.
+func source(code string, fset *token.FileSet, params []*ast.Field) string {
+	if len(params) == 0 {
+		return ""
+	}
+	start := fset.Position(params[0].Pos()).Offset
+	end := fset.Position(params[len(params)-1].End()).Offset
+	return ", " + code[start:end]
+}
+
+// argNames returns the names of the parameters, preceded by a comma if there are any.
+func argNames(params []*ast.Field) string {
+	var names []string
+	for _, param := range params {
+		for _, name := range param.Names {
+			names = append(names, name.Name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return ", " + strings.Join(names, ", ")
+}
+
+// ErrInvalidBytecode is returned by ParseBytecode when the bytecode is neither hex-encoded nor raw.
+var ErrInvalidBytecode = errors.New("invalid bytecode")
+
+// ParseBytecode parses the bytecode, which is either hex-encoded, with or without the 0x prefix, or raw.
+func ParseBytecode(data []byte) ([]byte, error) {
+	text := strings.TrimPrefix(strings.TrimSpace(string(data)), "0x")
+	if text == "" {
+		return nil, nil
+	}
+	if decoded, err := hexutil.Decode("0x" + text); err == nil {
+		return decoded, nil
+	}
+	// zksolc produces bytecode of whole 32-byte words.
+	if len(data)%32 != 0 {
+		return nil, ErrInvalidBytecode
+	}
+	return data, nil
+}
+
+var zkTemplate = template.Must(template.New("zk").Parse(`
+{{$T := .Type}}
+{{- if .Deploy}}
+// Deploy{{$T}}Zk deploys a new zkSync contract using the wallet, returning the hash of the deployment transaction.
+func Deploy{{$T}}Zk(opts *zkabigen.TransactOpts, wallet accounts.AdapterL2{{.Deploy.Params}}) (common.Hash, error) {
+	parsed, err := {{$T}}MetaData.GetAbi()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if parsed == nil {
+		return common.Hash{}, errors.New("GetABI returned nil")
+	}
+	return zkabigen.DeployContract(opts, wallet, *parsed, common.FromHex({{$T}}Bin){{.Deploy.Args}})
+}
+{{end}}
+// {{$T}}ZkTransactor is an auto generated write-only Go binding around a zkSync contract, sending
+// EIP-712 transactions using the wallet.
+type {{$T}}ZkTransactor struct {
+	contract *zkabigen.BoundContract // Generic contract wrapper for the EIP-712 transactions
+}
+
+// New{{$T}}ZkTransactor creates a new write-only instance of {{$T}}, bound to a specific deployed contract,
+// sending EIP-712 transactions using the wallet.
+func New{{$T}}ZkTransactor(address common.Address, wallet accounts.AdapterL2) (*{{$T}}ZkTransactor, error) {
+	parsed, err := {{$T}}MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	if parsed == nil {
+		return nil, errors.New("GetABI returned nil")
+	}
+	return &{{$T}}ZkTransactor{contract: zkabigen.NewBoundContract(address, *parsed, wallet)}, nil
+}
+{{range .Methods}}
+{{.Doc}}
+func (_{{$T}} *{{$T}}ZkTransactor) {{.Name}}(opts *zkabigen.TransactOpts{{.Params}}) (common.Hash, error) {
+	return _{{$T}}.contract.{{.Call}}
+}
+{{end}}`))
@@ -0,0 +1,133 @@
+package zkabigen
+
+import (
+	"context"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/zksync-sdk/zksync2-go/accounts"
+	zkTypes "github.com/zksync-sdk/zksync2-go/types"
+	"github.com/zksync-sdk/zksync2-go/utils"
+	"math/big"
+)
+
+// TransactOpts is the collection of options of the transactions sent by bindings generated by zkabigen.
+// In addition to accounts.TransactOpts, it contains the zkSync specific fields of EIP-712 transactions.
+type TransactOpts struct {
+	accounts.TransactOpts
+
+	PaymasterParams *zkTypes.PaymasterParams // Parameters of the paymaster paying the fee. Optional.
+	// Bytecode of the contracts which can be deployed by the called contract. For deployments, the bytecode
+	// of the deployed contract is added by DeployContract.
+	FactoryDeps [][]byte
+	// Maximum amount of gas the account is willing to pay for a single byte of pubdata.
+	// Optional, utils.DefaultGasPerPubdataLimit by default.
+	GasPerPubdata *big.Int
+}
+
+// meta returns the EIP-712 metadata of the transaction.
+func (o *TransactOpts) meta() *zkTypes.Eip712Meta {
+	meta := &zkTypes.Eip712Meta{
+		GasPerPubdata:   utils.NewBig(utils.DefaultGasPerPubdataLimit.Int64()),
+		PaymasterParams: o.PaymasterParams,
+	}
+	if o.GasPerPubdata != nil {
+		meta.GasPerPubdata = (*hexutil.Big)(o.GasPerPubdata)
+	}
+	for _, dep := range o.FactoryDeps {
+		meta.FactoryDeps = append(meta.FactoryDeps, dep)
+	}
+	return meta
+}
+
+// gasFeeCap returns the fee cap of the transaction, which is the gas price if only the latter is set.
+func (o *TransactOpts) gasFeeCap() *big.Int {
+	if o.GasFeeCap == nil {
+		return o.GasPrice
+	}
+	return o.GasFeeCap
+}
+
+func ensureTransactOpts(opts *TransactOpts) *TransactOpts {
+	if opts == nil {
+		opts = &TransactOpts{}
+	}
+	if opts.Context == nil {
+		opts.Context = context.Background()
+	}
+	return opts
+}
+
+// BoundContract is the contract wrapper used by the bindings generated by zkabigen, which sends
+// the transactions to the contract as EIP-712 transactions using the wallet.
+type BoundContract struct {
+	address common.Address
+	abi     abi.ABI
+	wallet  accounts.AdapterL2
+}
+
+// NewBoundContract creates an instance of BoundContract for the contract deployed at the address.
+func NewBoundContract(address common.Address, abi abi.ABI, wallet accounts.AdapterL2) *BoundContract {
+	return &BoundContract{
+		address: address,
+		abi:     abi,
+		wallet:  wallet,
+	}
+}
+
+// Address returns the address of the contract.
+func (c *BoundContract) Address() common.Address {
+	return c.address
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (c *BoundContract) Transact(opts *TransactOpts, method string, params ...interface{}) (common.Hash, error) {
+	input, err := c.abi.Pack(method, params...)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to encode %s: %w", method, err)
+	}
+	return c.RawTransact(opts, input)
+}
+
+// RawTransact initiates the transaction with the given raw calldata as input. It is usually used to
+// initiate transactions invoking the fallback or receive function.
+func (c *BoundContract) RawTransact(opts *TransactOpts, calldata []byte) (common.Hash, error) {
+	opts = ensureTransactOpts(opts)
+	return c.wallet.SendTransaction(opts.Context, &accounts.Transaction{
+		To:        &c.address,
+		Data:      calldata,
+		Value:     opts.Value,
+		Nonce:     opts.Nonce,
+		GasTipCap: opts.GasTipCap,
+		GasFeeCap: opts.gasFeeCap(),
+		Gas:       opts.GasLimit,
+		Meta:      opts.meta(),
+	})
+}
+
+// DeployContract deploys the contract with the bytecode through ContractDeployer using CREATE, passing params
+// to the constructor, and returns the hash of the deployment transaction. The address of the deployed
+// contract is the ContractAddress of the transaction receipt.
+func DeployContract(opts *TransactOpts, wallet accounts.AdapterL2, abi abi.ABI, bytecode []byte, params ...interface{}) (common.Hash, error) {
+	opts = ensureTransactOpts(opts)
+	calldata, err := abi.Pack("", params...)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to encode constructor arguments: %w", err)
+	}
+	create := accounts.CreateTransaction{
+		Bytecode:     bytecode,
+		Calldata:     calldata,
+		Dependencies: opts.FactoryDeps,
+	}
+	tx, err := create.ToTransaction(accounts.DeployContract, &opts.TransactOpts)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	meta := opts.meta()
+	meta.FactoryDeps = tx.Meta.FactoryDeps
+	tx.Meta = meta
+	tx.Value = opts.Value
+	tx.GasFeeCap = opts.gasFeeCap()
+	return wallet.SendTransaction(opts.Context, tx)
+}